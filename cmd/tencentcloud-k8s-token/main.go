@@ -0,0 +1,259 @@
+// Command tencentcloud-k8s-token is a `client.authentication.k8s.io` exec plugin printing the credential of TKE
+// clusters, which is the client certificate TKE issues to the CAM identity and returns by `tke:DescribeClusterKubeconfig`,
+// so the kubeconfig keeps no credential and the access follows the CAM permissions of the identity.
+//
+// The certificate is passed through as is, it is long-lived and accepted by the cluster until it expires, even if the
+// CAM permissions of the identity are removed. The credential printed expires in 10 minutes only to make clients run
+// the command again, no short-lived token is issued because TKE does not accept tokens signed with CAM or STS credentials.
+// It resolves credentials the same way as the TencentCloud provider:
+//  1. TENCENTCLOUD_SECRET_ID, TENCENTCLOUD_SECRET_KEY and TENCENTCLOUD_SECURITY_TOKEN;
+//  2. the tccli profile named by TENCENTCLOUD_PROFILE under TENCENTCLOUD_SHARED_CREDENTIALS_DIR (default ~/.tccli);
+//  3. the CAM role of the CVM, named by TENCENTCLOUD_CAM_ROLE_NAME or discovered from the metadata server.
+//
+// The credential assumes `--role-arn` (or TENCENTCLOUD_ASSUME_ROLE_ARN) first if it is set.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	sdkcommon "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common"
+	sdkprofile "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common/profile"
+	sdksts "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/sts/v20180813"
+	sdktke "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/tke/v20180525"
+)
+
+const (
+	envSecretId              = "TENCENTCLOUD_SECRET_ID"
+	envSecretKey             = "TENCENTCLOUD_SECRET_KEY"
+	envSecurityToken         = "TENCENTCLOUD_SECURITY_TOKEN"
+	envRegion                = "TENCENTCLOUD_REGION"
+	envDomain                = "TENCENTCLOUD_DOMAIN"
+	envProfile               = "TENCENTCLOUD_PROFILE"
+	envSharedCredentialsDir  = "TENCENTCLOUD_SHARED_CREDENTIALS_DIR"
+	envCamRoleName           = "TENCENTCLOUD_CAM_ROLE_NAME"
+	envAssumeRoleArn         = "TENCENTCLOUD_ASSUME_ROLE_ARN"
+	envAssumeRoleSessionName = "TENCENTCLOUD_ASSUME_ROLE_SESSION_NAME"
+
+	defaultProfile         = "default"
+	defaultDomain          = "tencentcloudapi.com"
+	defaultRoleSessionName = "tencentcloud-k8s-token"
+)
+
+func main() {
+	var (
+		clusterId       string
+		region          string
+		roleArn         string
+		roleSessionName string
+	)
+
+	flag.StringVar(&clusterId, "cluster-id", "", "ID of the TKE cluster, such as `cls-xxxxxxxx`.")
+	flag.StringVar(&region, "region", os.Getenv(envRegion), "Region of the cluster, default is $"+envRegion+".")
+	flag.StringVar(&roleArn, "role-arn", os.Getenv(envAssumeRoleArn), "CAM role to assume before requesting the credential, default is $"+envAssumeRoleArn+".")
+	flag.StringVar(&roleSessionName, "role-session-name", os.Getenv(envAssumeRoleSessionName), "Session name of the assumed role, default is $"+envAssumeRoleSessionName+".")
+	flag.Parse()
+
+	if err := run(clusterId, region, roleArn, roleSessionName); err != nil {
+		fmt.Fprintf(os.Stderr, "tencentcloud-k8s-token: %s\n", err.Error())
+		os.Exit(1)
+	}
+}
+
+func run(clusterId, region, roleArn, roleSessionName string) error {
+	if clusterId == "" {
+		return fmt.Errorf("`--cluster-id` is required")
+	}
+
+	credential, profileRegion, err := resolveCredential()
+	if err != nil {
+		return err
+	}
+	if region == "" {
+		region = profileRegion
+	}
+
+	domain := os.Getenv(envDomain)
+	if domain == "" {
+		domain = defaultDomain
+	}
+	stsHost := "sts." + domain
+	tkeHost := "tke." + domain
+
+	if roleArn != "" {
+		if roleSessionName == "" {
+			roleSessionName = defaultRoleSessionName
+		}
+		if credential, err = assumeRole(credential, stsHost, region, roleArn, roleSessionName); err != nil {
+			return fmt.Errorf("assume role `%s` failed: %s", roleArn, err.Error())
+		}
+	}
+
+	config, err := describeClusterKubeconfig(credential, tkeHost, region, clusterId)
+	if err != nil {
+		return fmt.Errorf("describe kubeconfig of cluster `%s` failed: %s", clusterId, err.Error())
+	}
+	execCredential, err := NewExecCredential(config, time.Now())
+	if err != nil {
+		return err
+	}
+
+	return json.NewEncoder(os.Stdout).Encode(execCredential)
+}
+
+func resolveCredential() (credential Credential, region string, err error) {
+	if secretId, secretKey := os.Getenv(envSecretId), os.Getenv(envSecretKey); secretId != "" && secretKey != "" {
+		credential = Credential{SecretId: secretId, SecretKey: secretKey, Token: os.Getenv(envSecurityToken)}
+		return
+	}
+
+	config, err := readTccliProfile()
+	if err != nil {
+		return
+	}
+	region = config["region"]
+	if config["secretId"] != "" && config["secretKey"] != "" {
+		credential = Credential{SecretId: config["secretId"], SecretKey: config["secretKey"], Token: config["token"]}
+		return
+	}
+
+	provider := sdkcommon.DefaultCvmRoleProvider()
+	if roleName := os.Getenv(envCamRoleName); roleName != "" {
+		provider = sdkcommon.NewCvmRoleProvider(roleName)
+	}
+	cvmCredential, e := provider.GetCredential()
+	if e != nil {
+		err = fmt.Errorf("no TencentCloud credential found in environment variables, tccli profile or CVM role: %s", e.Error())
+		return
+	}
+	secretId, secretKey, token := cvmCredential.GetCredential()
+	credential = Credential{SecretId: secretId, SecretKey: secretKey, Token: token}
+	return
+}
+
+// readTccliProfile reads the credential and region of the tccli profile, a missing profile is not an error.
+func readTccliProfile() (map[string]string, error) {
+	profile := os.Getenv(envProfile)
+	if profile == "" {
+		profile = defaultProfile
+	}
+
+	dir := os.Getenv(envSharedCredentialsDir)
+	if dir == "" {
+		home := os.Getenv("HOME")
+		if runtime.GOOS == "windows" {
+			home = os.Getenv("USERPROFILE")
+		}
+		dir = filepath.Join(home, ".tccli")
+	} else if strings.HasPrefix(dir, "~") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, err
+		}
+		dir = filepath.Join(home, strings.TrimPrefix(dir, "~"))
+	}
+
+	config := make(map[string]string)
+
+	credential := map[string]interface{}{}
+	if err := readJsonFile(filepath.Join(dir, profile+".credential"), &credential); err != nil {
+		return nil, err
+	}
+	for k, v := range credential {
+		if s, ok := v.(string); ok {
+			config[k] = strings.TrimSpace(s)
+		}
+	}
+
+	configure := struct {
+		SysParam map[string]interface{} `json:"_sys_param"`
+	}{}
+	if err := readJsonFile(filepath.Join(dir, profile+".configure"), &configure); err != nil {
+		return nil, err
+	}
+	if region, ok := configure.SysParam["region"].(string); ok {
+		config["region"] = strings.TrimSpace(region)
+	}
+
+	return config, nil
+}
+
+func readJsonFile(path string, v interface{}) error {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err = json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("parse `%s` failed: %s", path, err.Error())
+	}
+	return nil
+}
+
+func newSdkCredential(credential Credential) sdkcommon.CredentialIface {
+	if credential.Token != "" {
+		return sdkcommon.NewTokenCredential(credential.SecretId, credential.SecretKey, credential.Token)
+	}
+	return sdkcommon.NewCredential(credential.SecretId, credential.SecretKey)
+}
+
+// describeClusterKubeconfig returns the kubeconfig of the cluster with the client certificate of the CAM identity.
+func describeClusterKubeconfig(credential Credential, tkeHost, region, clusterId string) (string, error) {
+	if region == "" {
+		return "", fmt.Errorf("`--region` is required")
+	}
+	clientProfile := sdkprofile.NewClientProfile()
+	clientProfile.HttpProfile.Endpoint = tkeHost
+
+	client, err := sdktke.NewClient(newSdkCredential(credential), region, clientProfile)
+	if err != nil {
+		return "", err
+	}
+
+	request := sdktke.NewDescribeClusterKubeconfigRequest()
+	request.ClusterId = &clusterId
+	response, err := client.DescribeClusterKubeconfig(request)
+	if err != nil {
+		return "", err
+	}
+	if response.Response == nil || response.Response.Kubeconfig == nil {
+		return "", fmt.Errorf("DescribeClusterKubeconfig returned empty kubeconfig")
+	}
+	return *response.Response.Kubeconfig, nil
+}
+
+func assumeRole(credential Credential, stsHost, region, roleArn, roleSessionName string) (Credential, error) {
+	clientProfile := sdkprofile.NewClientProfile()
+	clientProfile.HttpProfile.Endpoint = stsHost
+
+	client, err := sdksts.NewClient(newSdkCredential(credential), region, clientProfile)
+	if err != nil {
+		return credential, err
+	}
+
+	request := sdksts.NewAssumeRoleRequest()
+	request.RoleArn = &roleArn
+	request.RoleSessionName = &roleSessionName
+	response, err := client.AssumeRole(request)
+	if err != nil {
+		return credential, err
+	}
+	if response.Response == nil || response.Response.Credentials == nil ||
+		response.Response.Credentials.TmpSecretId == nil || response.Response.Credentials.TmpSecretKey == nil || response.Response.Credentials.Token == nil {
+		return credential, fmt.Errorf("AssumeRole returned empty credentials")
+	}
+
+	return Credential{
+		SecretId:  *response.Response.Credentials.TmpSecretId,
+		SecretKey: *response.Response.Credentials.TmpSecretKey,
+		Token:     *response.Response.Credentials.Token,
+	}, nil
+}
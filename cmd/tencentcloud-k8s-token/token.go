@@ -0,0 +1,109 @@
+package main
+
+import (
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	execCredentialApiVersion = "client.authentication.k8s.io/v1beta1"
+
+	// credentialRefreshInterval is how long clients cache the credential before running the command again, so that the
+	// CAM permissions of the identity are checked again by `tke:DescribeClusterKubeconfig`. It does not shorten the
+	// validity of the client certificate, which is accepted by the cluster until it expires.
+	credentialRefreshInterval = 10 * time.Minute
+)
+
+// Credential is the TencentCloud credential used to request the cluster credential.
+type Credential struct {
+	SecretId  string
+	SecretKey string
+	Token     string
+}
+
+// ExecCredential is the output of exec plugins of `client.authentication.k8s.io`.
+type ExecCredential struct {
+	ApiVersion string               `json:"apiVersion"`
+	Kind       string               `json:"kind"`
+	Status     ExecCredentialStatus `json:"status"`
+}
+
+type ExecCredentialStatus struct {
+	ExpirationTimestamp   string `json:"expirationTimestamp,omitempty"`
+	Token                 string `json:"token,omitempty"`
+	ClientCertificateData string `json:"clientCertificateData,omitempty"`
+	ClientKeyData         string `json:"clientKeyData,omitempty"`
+}
+
+// kubeconfig is the part of the kubeconfig returned by `tke:DescribeClusterKubeconfig` holding the credential.
+type kubeconfig struct {
+	Users []struct {
+		Name string `yaml:"name"`
+		User struct {
+			ClientCertificateData string `yaml:"client-certificate-data"`
+			ClientKeyData         string `yaml:"client-key-data"`
+			Token                 string `yaml:"token"`
+		} `yaml:"user"`
+	} `yaml:"users"`
+}
+
+// NewExecCredential passes through the credential of the CAM identity from the kubeconfig returned by
+// `tke:DescribeClusterKubeconfig`, which is the long-lived client certificate TKE issues to the identity, TKE does not
+// accept short-lived tokens signed with CAM or STS credentials. The ExecCredential expires at now plus
+// credentialRefreshInterval, or when the certificate expires if earlier, which only makes clients fetch it again.
+func NewExecCredential(config string, now time.Time) (*ExecCredential, error) {
+	parsed := kubeconfig{}
+	if err := yaml.Unmarshal([]byte(config), &parsed); err != nil {
+		return nil, fmt.Errorf("parse kubeconfig failed: %s", err.Error())
+	}
+	if len(parsed.Users) == 0 {
+		return nil, fmt.Errorf("no user found in the kubeconfig")
+	}
+
+	var (
+		user       = parsed.Users[0].User
+		status     = ExecCredentialStatus{Token: user.Token}
+		expiration = now.Add(credentialRefreshInterval)
+	)
+	if user.ClientCertificateData != "" || user.ClientKeyData != "" {
+		certificate, err := base64.StdEncoding.DecodeString(user.ClientCertificateData)
+		if err != nil {
+			return nil, fmt.Errorf("decode client certificate failed: %s", err.Error())
+		}
+		key, err := base64.StdEncoding.DecodeString(user.ClientKeyData)
+		if err != nil {
+			return nil, fmt.Errorf("decode client key failed: %s", err.Error())
+		}
+		block, _ := pem.Decode(certificate)
+		if block == nil {
+			return nil, fmt.Errorf("client certificate is not PEM encoded")
+		}
+		parsedCertificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("parse client certificate failed: %s", err.Error())
+		}
+		if !parsedCertificate.NotAfter.After(now) {
+			return nil, fmt.Errorf("client certificate expired at %s", parsedCertificate.NotAfter.UTC().Format(time.RFC3339))
+		}
+		if parsedCertificate.NotAfter.Before(expiration) {
+			expiration = parsedCertificate.NotAfter
+		}
+		status.ClientCertificateData = string(certificate)
+		status.ClientKeyData = string(key)
+	}
+	if status.Token == "" && status.ClientCertificateData == "" {
+		return nil, fmt.Errorf("no client certificate or token found in the kubeconfig")
+	}
+	status.ExpirationTimestamp = expiration.UTC().Format(time.RFC3339)
+
+	return &ExecCredential{
+		ApiVersion: execCredentialApiVersion,
+		Kind:       "ExecCredential",
+		Status:     status,
+	}, nil
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func testClientCertificate(t *testing.T, notAfter time.Time) (certificate, key string) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "100000000001-1700000000"},
+		NotBefore:    notAfter.Add(-365 * 24 * time.Hour),
+		NotAfter:     notAfter,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &privateKey.PublicKey, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDer, err := x509.MarshalECPrivateKey(privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certificate = string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+	key = string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}))
+	return
+}
+
+func testKubeconfig(certificate, key, token string) string {
+	return fmt.Sprintf(`apiVersion: v1
+clusters:
+- cluster:
+    certificate-authority-data: Y2E=
+    server: https://cls-test.ccs.tencent-cloud.com
+  name: cls-test
+contexts:
+- context:
+    cluster: cls-test
+    user: "100000000001"
+  name: cls-test-100000000001-context-default
+current-context: cls-test-100000000001-context-default
+kind: Config
+users:
+- name: "100000000001"
+  user:
+    client-certificate-data: %s
+    client-key-data: %s
+    token: %s
+`, base64.StdEncoding.EncodeToString([]byte(certificate)), base64.StdEncoding.EncodeToString([]byte(key)), token)
+}
+
+func TestNewExecCredential(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	certificate, key := testClientCertificate(t, now.Add(365*24*time.Hour))
+
+	execCredential, err := NewExecCredential(testKubeconfig(certificate, key, ""), now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if execCredential.ApiVersion != "client.authentication.k8s.io/v1beta1" || execCredential.Kind != "ExecCredential" {
+		t.Fatalf("unexpected exec credential header %s/%s", execCredential.ApiVersion, execCredential.Kind)
+	}
+	if execCredential.Status.ExpirationTimestamp != "2024-01-02T03:14:05Z" {
+		t.Errorf("unexpected expiration %s", execCredential.Status.ExpirationTimestamp)
+	}
+	if execCredential.Status.ClientCertificateData != certificate || execCredential.Status.ClientKeyData != key {
+		t.Errorf("unexpected client certificate and key")
+	}
+	if execCredential.Status.Token != "" {
+		t.Errorf("unexpected token %s", execCredential.Status.Token)
+	}
+
+	// the credential expires with the certificate
+	certificate, key = testClientCertificate(t, now.Add(time.Minute))
+	execCredential, err = NewExecCredential(testKubeconfig(certificate, key, ""), now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if execCredential.Status.ExpirationTimestamp != "2024-01-02T03:05:05Z" {
+		t.Errorf("unexpected expiration %s", execCredential.Status.ExpirationTimestamp)
+	}
+
+	certificate, key = testClientCertificate(t, now.Add(-time.Minute))
+	if _, err = NewExecCredential(testKubeconfig(certificate, key, ""), now); err == nil {
+		t.Errorf("expected error of expired certificate")
+	}
+}
+
+func TestNewExecCredentialWithToken(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	config := `users:
+- name: admin
+  user:
+    token: abcdef
+`
+	execCredential, err := NewExecCredential(config, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if execCredential.Status.Token != "abcdef" || execCredential.Status.ClientCertificateData != "" {
+		t.Errorf("unexpected status %+v", execCredential.Status)
+	}
+}
+
+func TestNewExecCredentialInvalid(t *testing.T) {
+	now := time.Now()
+	cases := map[string]string{
+		"no user":             "users: []\n",
+		"no credential":       "users:\n- name: admin\n  user: {}\n",
+		"invalid yaml":        "users: [",
+		"not PEM":             testKubeconfig("!!", "!!", ""),
+		"invalid certificate": "users:\n- name: admin\n  user:\n    client-certificate-data: " + base64.StdEncoding.EncodeToString([]byte("certificate")) + "\n",
+	}
+	for name, config := range cases {
+		if _, err := NewExecCredential(config, now); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
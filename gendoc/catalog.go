@@ -0,0 +1,327 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	catalogResource   = "resource"
+	catalogDataSource = "data_source"
+)
+
+var versionMatch = regexp.MustCompile(`(?m)^## (\d+\.\d+\.\d+)`)
+
+// Catalog is the machine-readable schema of all the resources and data sources of a provider version
+type Catalog struct {
+	Version     string                  `json:"version" yaml:"version"`
+	Resources   map[string]*CatalogItem `json:"resources" yaml:"resources"`
+	DataSources map[string]*CatalogItem `json:"data_sources" yaml:"data_sources"`
+}
+
+// CatalogItem is the schema of a resource or data source
+type CatalogItem struct {
+	Product    string                      `json:"product" yaml:"product"`
+	Importable bool                        `json:"importable,omitempty" yaml:"importable,omitempty"`
+	Deprecated string                      `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
+	Arguments  map[string]*CatalogArgument `json:"arguments" yaml:"arguments"`
+}
+
+// CatalogArgument is the schema of an argument or attribute, the nested blocks are kept in Arguments
+type CatalogArgument struct {
+	Type        string                      `json:"type" yaml:"type"`
+	ElemType    string                      `json:"elem_type,omitempty" yaml:"elem_type,omitempty"`
+	Required    bool                        `json:"required,omitempty" yaml:"required,omitempty"`
+	Optional    bool                        `json:"optional,omitempty" yaml:"optional,omitempty"`
+	Computed    bool                        `json:"computed,omitempty" yaml:"computed,omitempty"`
+	ForceNew    bool                        `json:"force_new,omitempty" yaml:"force_new,omitempty"`
+	Sensitive   bool                        `json:"sensitive,omitempty" yaml:"sensitive,omitempty"`
+	Deprecated  string                      `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
+	Description string                      `json:"description,omitempty" yaml:"description,omitempty"`
+	Arguments   map[string]*CatalogArgument `json:"arguments,omitempty" yaml:"arguments,omitempty"`
+}
+
+// CatalogDiff is the changes between two catalogs
+type CatalogDiff struct {
+	From    string          `json:"from" yaml:"from"`
+	To      string          `json:"to" yaml:"to"`
+	Changes []CatalogChange `json:"changes" yaml:"changes"`
+}
+
+// CatalogChange is a single change of a resource, data source or argument
+type CatalogChange struct {
+	Kind     string `json:"kind" yaml:"kind"`
+	Name     string `json:"name" yaml:"name"`
+	Argument string `json:"argument,omitempty" yaml:"argument,omitempty"`
+	Breaking bool   `json:"breaking" yaml:"breaking"`
+	Message  string `json:"message" yaml:"message"`
+}
+
+// genCatalog generating catalog of the provider schema
+func genCatalog(filePath, version string, provider *schema.Provider, products []Product) *Catalog {
+	if version == "" {
+		version = providerVersion(filepath.Join(filePath, "..", "CHANGELOG.md"))
+	}
+
+	catalog := &Catalog{
+		Version:     version,
+		Resources:   make(map[string]*CatalogItem),
+		DataSources: make(map[string]*CatalogItem),
+	}
+
+	productOf := make(map[string]string)
+	for _, product := range products {
+		for _, name := range product.DataSources {
+			productOf[catalogDataSource+"."+name] = product.Name
+		}
+		for _, name := range product.Resources {
+			productOf[catalogResource+"."+name] = product.Name
+		}
+	}
+
+	for name, resource := range provider.ResourcesMap {
+		catalog.Resources[name] = &CatalogItem{
+			Product:    productOf[catalogResource+"."+name],
+			Importable: resource.Importer != nil,
+			Deprecated: resource.DeprecationMessage,
+			Arguments:  catalogArguments(resource.Schema),
+		}
+	}
+
+	for name, dataSource := range provider.DataSourcesMap {
+		catalog.DataSources[name] = &CatalogItem{
+			Product:    productOf[catalogDataSource+"."+name],
+			Deprecated: dataSource.DeprecationMessage,
+			Arguments:  catalogArguments(dataSource.Schema),
+		}
+	}
+
+	return catalog
+}
+
+func catalogArguments(s map[string]*schema.Schema) map[string]*CatalogArgument {
+	arguments := make(map[string]*CatalogArgument, len(s))
+	for k, v := range s {
+		argument := &CatalogArgument{
+			Type:        parseType(v),
+			Required:    v.Required,
+			Optional:    v.Optional,
+			Computed:    v.Computed,
+			ForceNew:    v.ForceNew,
+			Sensitive:   v.Sensitive,
+			Deprecated:  v.Deprecated,
+			Description: v.Description,
+		}
+		switch elem := v.Elem.(type) {
+		case *schema.Schema:
+			argument.ElemType = parseType(elem)
+		case *schema.Resource:
+			argument.Arguments = catalogArguments(elem.Schema)
+		}
+		arguments[k] = argument
+	}
+
+	return arguments
+}
+
+// providerVersion get the latest released version from the changelog
+func providerVersion(changelog string) string {
+	raw, err := os.ReadFile(changelog)
+	if err != nil {
+		message("[SKIP!]read version from %s failed: %s", changelog, err)
+		return ""
+	}
+
+	m := versionMatch.FindStringSubmatch(string(raw))
+	if len(m) == 0 {
+		return ""
+	}
+
+	return m[1]
+}
+
+// diffCatalog compares the catalog of two provider versions
+func diffCatalog(from, to *Catalog) *CatalogDiff {
+	diff := &CatalogDiff{
+		From:    from.Version,
+		To:      to.Version,
+		Changes: []CatalogChange{},
+	}
+
+	diff.Changes = append(diff.Changes, diffCatalogItems(catalogResource, from.Resources, to.Resources)...)
+	diff.Changes = append(diff.Changes, diffCatalogItems(catalogDataSource, from.DataSources, to.DataSources)...)
+
+	sort.SliceStable(diff.Changes, func(i, j int) bool {
+		a, b := diff.Changes[i], diff.Changes[j]
+		if a.Kind != b.Kind {
+			return a.Kind > b.Kind
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.Argument < b.Argument
+	})
+
+	return diff
+}
+
+func diffCatalogItems(kind string, from, to map[string]*CatalogItem) (changes []CatalogChange) {
+	for name, item := range from {
+		if _, ok := to[name]; !ok {
+			changes = append(changes, CatalogChange{Kind: kind, Name: name, Breaking: true, Message: "removed"})
+			continue
+		}
+		changes = append(changes, diffCatalogItem(kind, name, item, to[name])...)
+	}
+
+	for name := range to {
+		if _, ok := from[name]; !ok {
+			changes = append(changes, CatalogChange{Kind: kind, Name: name, Message: "added"})
+		}
+	}
+
+	return
+}
+
+func diffCatalogItem(kind, name string, from, to *CatalogItem) (changes []CatalogChange) {
+	change := func(breaking bool, format string, v ...interface{}) {
+		changes = append(changes, CatalogChange{Kind: kind, Name: name, Breaking: breaking, Message: fmt.Sprintf(format, v...)})
+	}
+
+	if from.Product != to.Product {
+		change(false, "product changed from `%s` to `%s`", from.Product, to.Product)
+	}
+	if from.Importable && !to.Importable {
+		change(true, "import is no longer supported")
+	}
+	if !from.Importable && to.Importable {
+		change(false, "import is supported")
+	}
+	if from.Deprecated == "" && to.Deprecated != "" {
+		change(false, "deprecated: %s", to.Deprecated)
+	}
+
+	changes = append(changes, diffCatalogArguments(kind, name, "", from.Arguments, to.Arguments)...)
+	return
+}
+
+func diffCatalogArguments(kind, name, prefix string, from, to map[string]*CatalogArgument) (changes []CatalogChange) {
+	for k, v := range from {
+		path := prefix + k
+		change := func(breaking bool, format string, v ...interface{}) {
+			changes = append(changes, CatalogChange{Kind: kind, Name: name, Argument: path, Breaking: breaking, Message: fmt.Sprintf(format, v...)})
+		}
+
+		n, ok := to[k]
+		if !ok {
+			change(true, "removed")
+			continue
+		}
+
+		if v.Type != n.Type || v.ElemType != n.ElemType {
+			change(true, "type changed from `%s` to `%s`", catalogArgumentType(v), catalogArgumentType(n))
+		}
+		if !v.Required && n.Required {
+			change(true, "became required")
+		}
+		if v.Required && !n.Required && n.Optional {
+			change(false, "became optional")
+		}
+		if (v.Required || v.Optional) && !n.Required && !n.Optional {
+			change(true, "became read-only")
+		}
+		if !v.Required && !v.Optional && (n.Required || n.Optional) {
+			change(false, "became configurable")
+		}
+		if v.Computed && !n.Computed && !n.Required {
+			change(true, "is no longer computed")
+		}
+		if !v.ForceNew && n.ForceNew {
+			change(true, "forces new resource when changed")
+		}
+		if v.ForceNew && !n.ForceNew {
+			change(false, "no longer forces new resource when changed")
+		}
+		if v.Sensitive != n.Sensitive {
+			change(false, "sensitive changed from %t to %t", v.Sensitive, n.Sensitive)
+		}
+		if v.Deprecated == "" && n.Deprecated != "" {
+			change(false, "deprecated: %s", n.Deprecated)
+		}
+
+		changes = append(changes, diffCatalogArguments(kind, name, path+".", v.Arguments, n.Arguments)...)
+	}
+
+	for k, n := range to {
+		if _, ok := from[k]; ok {
+			continue
+		}
+		changes = append(changes, CatalogChange{Kind: kind, Name: name, Argument: prefix + k, Breaking: n.Required, Message: "added"})
+	}
+
+	return
+}
+
+func catalogArgumentType(v *CatalogArgument) string {
+	if v.ElemType == "" {
+		return v.Type
+	}
+	return fmt.Sprintf("%s(%s)", v.Type, v.ElemType)
+}
+
+// readCatalog read the catalog from a json or yaml file
+func readCatalog(filename string) (*Catalog, error) {
+	raw, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	catalog := &Catalog{}
+	if isYamlFile(filename) {
+		err = yaml.Unmarshal(raw, catalog)
+	} else {
+		err = json.Unmarshal(raw, catalog)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse catalog %s failed: %s", filename, err)
+	}
+
+	return catalog, nil
+}
+
+// writeCatalogFile write v to filename in json or yaml format by the extension, or to stdout in json if filename is empty
+func writeCatalogFile(filename string, v interface{}) error {
+	var (
+		raw []byte
+		err error
+	)
+	if filename != "" && isYamlFile(filename) {
+		raw, err = yaml.Marshal(v)
+	} else {
+		raw, err = json.MarshalIndent(v, "", "  ")
+		raw = append(raw, '\n')
+	}
+	if err != nil {
+		return err
+	}
+
+	if filename == "" {
+		_, err = os.Stdout.Write(raw)
+		return err
+	}
+
+	return os.WriteFile(filename, raw, 0644)
+}
+
+func isYamlFile(filename string) bool {
+	ext := strings.ToLower(filepath.Ext(filename))
+	return ext == ".yaml" || ext == ".yml"
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"flag"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestGenCatalog(t *testing.T) {
+	provider := testExampleProvider()
+	provider.ResourcesMap["tencentcloud_example"].Importer = &schema.ResourceImporter{}
+	products := []Product{
+		{Name: "Example", DataSources: []string{"tencentcloud_examples"}, Resources: []string{"tencentcloud_example"}},
+	}
+
+	catalog := genCatalog("", "1.0.0", provider, products)
+	if catalog.Version != "1.0.0" {
+		t.Errorf("unexpected version: %s", catalog.Version)
+	}
+
+	resource := catalog.Resources["tencentcloud_example"]
+	if resource == nil || resource.Product != "Example" || !resource.Importable {
+		t.Fatalf("unexpected resource: %+v", resource)
+	}
+	if v := resource.Arguments["old_name"]; v.Type != "String" || !v.Optional || v.Deprecated == "" {
+		t.Errorf("unexpected argument old_name: %+v", v)
+	}
+	if v := resource.Arguments["rule"]; v.Type != "List" || v.Arguments["port"] == nil || !v.Arguments["port"].Required {
+		t.Errorf("unexpected argument rule: %+v", v)
+	}
+
+	dataSource := catalog.DataSources["tencentcloud_examples"]
+	if dataSource == nil || dataSource.Product != "Example" || dataSource.Importable {
+		t.Errorf("unexpected data source: %+v", dataSource)
+	}
+}
+
+func TestDiffCatalog(t *testing.T) {
+	from := &Catalog{
+		Version: "1.0.0",
+		Resources: map[string]*CatalogItem{
+			"tencentcloud_example": {
+				Product:    "Example",
+				Importable: true,
+				Arguments: map[string]*CatalogArgument{
+					"name":   {Type: "String", Required: true},
+					"zone":   {Type: "String", Optional: true},
+					"status": {Type: "String", Computed: true},
+					"rule": {Type: "List", Optional: true, Arguments: map[string]*CatalogArgument{
+						"port": {Type: "Int", Required: true},
+					}},
+				},
+			},
+			"tencentcloud_removed": {Product: "Example", Arguments: map[string]*CatalogArgument{}},
+		},
+		DataSources: map[string]*CatalogItem{},
+	}
+	to := &Catalog{
+		Version: "1.1.0",
+		Resources: map[string]*CatalogItem{
+			"tencentcloud_example": {
+				Product: "Example",
+				Arguments: map[string]*CatalogArgument{
+					"name":   {Type: "String", Required: true, ForceNew: true},
+					"zone":   {Type: "String", Optional: true, Deprecated: "Use `zones` instead."},
+					"zones":  {Type: "List", ElemType: "String", Optional: true},
+					"vpc_id": {Type: "String", Required: true},
+					"rule": {Type: "List", Optional: true, Arguments: map[string]*CatalogArgument{
+						"port": {Type: "String", Required: true},
+					}},
+				},
+			},
+		},
+		DataSources: map[string]*CatalogItem{
+			"tencentcloud_examples": {Product: "Example", Arguments: map[string]*CatalogArgument{}},
+		},
+	}
+
+	expected := []CatalogChange{
+		{Kind: "resource", Name: "tencentcloud_example", Breaking: true, Message: "import is no longer supported"},
+		{Kind: "resource", Name: "tencentcloud_example", Argument: "name", Breaking: true, Message: "forces new resource when changed"},
+		{Kind: "resource", Name: "tencentcloud_example", Argument: "rule.port", Breaking: true, Message: "type changed from `Int` to `String`"},
+		{Kind: "resource", Name: "tencentcloud_example", Argument: "status", Breaking: true, Message: "removed"},
+		{Kind: "resource", Name: "tencentcloud_example", Argument: "vpc_id", Breaking: true, Message: "added"},
+		{Kind: "resource", Name: "tencentcloud_example", Argument: "zone", Message: "deprecated: Use `zones` instead."},
+		{Kind: "resource", Name: "tencentcloud_example", Argument: "zones", Message: "added"},
+		{Kind: "resource", Name: "tencentcloud_removed", Breaking: true, Message: "removed"},
+		{Kind: "data_source", Name: "tencentcloud_examples", Message: "added"},
+	}
+
+	diff := diffCatalog(from, to)
+	if diff.From != "1.0.0" || diff.To != "1.1.0" {
+		t.Errorf("unexpected versions: %s -> %s", diff.From, diff.To)
+	}
+	if !reflect.DeepEqual(diff.Changes, expected) {
+		t.Errorf("expected %+v, got %+v", expected, diff.Changes)
+	}
+}
+
+func TestParseArgs(t *testing.T) {
+	cases := []struct {
+		args        []string
+		positionals []string
+		output      string
+	}{
+		{[]string{"-o", "diff.json", "old.json", "new.json"}, []string{"old.json", "new.json"}, "diff.json"},
+		{[]string{"old.json", "new.json", "-o", "diff.json"}, []string{"old.json", "new.json"}, "diff.json"},
+		{[]string{"old.json", "-o", "diff.json", "new.json"}, []string{"old.json", "new.json"}, "diff.json"},
+		{[]string{"old.json", "new.json"}, []string{"old.json", "new.json"}, ""},
+	}
+	for _, c := range cases {
+		flags := flag.NewFlagSet("gendoc", flag.ContinueOnError)
+		output := flags.String("o", "", "")
+		if err := flags.Parse(c.args); err != nil {
+			t.Fatalf("parse %v failed: %s", c.args, err)
+		}
+		positionals, err := parseArgs(flags, flags.Args())
+		if err != nil {
+			t.Fatalf("parse %v failed: %s", c.args, err)
+		}
+		if !reflect.DeepEqual(positionals, c.positionals) || *output != c.output {
+			t.Errorf("%v: expected %v -o %q, got %v -o %q", c.args, c.positionals, c.output, positionals, *output)
+		}
+	}
+}
@@ -0,0 +1,282 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var (
+	// resourceMetaArguments are the arguments supported by every resource and data source
+	resourceMetaArguments = map[string]bool{
+		"count":      true,
+		"for_each":   true,
+		"provider":   true,
+		"depends_on": true,
+	}
+
+	// resourceMetaBlocks are the nested blocks supported by every resource
+	resourceMetaBlocks = map[string]bool{
+		"lifecycle":   true,
+		"provisioner": true,
+		"connection":  true,
+	}
+
+	// providerMetaArguments are the arguments supported by every provider block
+	providerMetaArguments = map[string]bool{
+		"alias":   true,
+		"version": true,
+	}
+)
+
+// exampleIssue is a problem found in the example usage of a document
+type exampleIssue struct {
+	Line    int
+	Message string
+}
+
+// checkExamples validates the examples of all the documents against the provider schema,
+// it returns false if any of the examples is invalid
+func checkExamples(filePath string, provider *schema.Provider) bool {
+	var files []string
+	for _, dtype := range []string{"resource", "data_source"} {
+		matches, err := filepath.Glob(filepath.Join(filePath, "services", "*", fmt.Sprintf("%s_%s_*.md", dtype, cloudMarkShort)))
+		if err != nil {
+			message("[FAIL!]find documents failed: %s", err)
+			os.Exit(1)
+		}
+		files = append(files, matches...)
+	}
+	sort.Strings(files)
+
+	failed := 0
+	for _, file := range files {
+		raw, err := os.ReadFile(file)
+		if err != nil {
+			message("[FAIL!]read file %s failed: %s", file, err)
+			os.Exit(1)
+		}
+
+		filename, _ := filepath.Rel(filePath, file)
+		issues := checkDocExamples(string(raw), provider)
+		if len(issues) == 0 {
+			continue
+		}
+
+		failed++
+		var lines []string
+		for _, issue := range issues {
+			lines = append(lines, fmt.Sprintf("  line %d: %s", issue.Line, issue.Message))
+		}
+		message("[FAIL!]invalid example usage: %s\n%s", filename, strings.Join(lines, "\n"))
+	}
+
+	if failed > 0 {
+		message("[FAIL!]%d of %d documents have invalid example usage", failed, len(files))
+		return false
+	}
+
+	message("[SUCC.]example usage of %d documents checked", len(files))
+	return true
+}
+
+// checkDocExamples checks the hcl examples of the "Example Usage" section in a document,
+// the lines of the issues are relative to the document
+func checkDocExamples(doc string, provider *schema.Provider) (issues []exampleIssue) {
+	pos := strings.Index(doc, "\nExample Usage\n")
+	if pos == -1 {
+		return []exampleIssue{{Line: 1, Message: "example usage missing"}}
+	}
+
+	end := len(doc)
+	if importPos := strings.Index(doc[pos:], "\nImport\n"); importPos != -1 {
+		end = pos + importPos
+	}
+
+	for _, m := range hclMatch.FindAllStringSubmatchIndex(doc[pos:end], -1) {
+		start, stop := pos+m[6], pos+m[7]
+		baseLine := strings.Count(doc[:start], "\n") + 1
+		for _, issue := range checkExample([]byte(doc[start:stop]), provider) {
+			issue.Line += baseLine - 1
+			issues = append(issues, issue)
+		}
+	}
+
+	return
+}
+
+// checkExample checks a single hcl example, the lines of the issues are relative to src
+func checkExample(src []byte, provider *schema.Provider) (issues []exampleIssue) {
+	file, diags := hclsyntax.ParseConfig(src, "example.tf", hcl.InitialPos)
+	if diags.HasErrors() {
+		for _, diag := range diags {
+			line := 1
+			if diag.Subject != nil {
+				line = diag.Subject.Start.Line
+			}
+			issues = append(issues, exampleIssue{Line: line, Message: fmt.Sprintf("%s: %s", diag.Summary, diag.Detail)})
+		}
+		return
+	}
+
+	if line := unformattedLine(src); line > 0 {
+		issues = append(issues, exampleIssue{Line: line, Message: "example is not formatted in the style of `terraform fmt`"})
+	}
+
+	for _, block := range file.Body.(*hclsyntax.Body).Blocks {
+		switch block.Type {
+		case "resource", "data":
+			if len(block.Labels) != 2 || !strings.HasPrefix(block.Labels[0], cloudPrefix) {
+				continue
+			}
+
+			name := block.Labels[0]
+			resourcesMap := provider.ResourcesMap
+			if block.Type == "data" {
+				resourcesMap = provider.DataSourcesMap
+			}
+			resource, ok := resourcesMap[name]
+			if !ok {
+				issues = append(issues, newExampleIssue(block.TypeRange, "%s `%s` is not supported by the provider", block.Type, name))
+				continue
+			}
+			if resource.DeprecationMessage != "" {
+				issues = append(issues, newExampleIssue(block.TypeRange, "%s `%s` is deprecated: %s", block.Type, name, resource.DeprecationMessage))
+			}
+
+			metaBlocks := map[string]bool{"lifecycle": true}
+			if block.Type == "resource" {
+				metaBlocks = resourceMetaBlocks
+				if resource.Timeouts != nil {
+					metaBlocks = map[string]bool{"timeouts": true}
+					for k := range resourceMetaBlocks {
+						metaBlocks[k] = true
+					}
+				}
+			}
+			issues = append(issues, checkExampleBody(block.Body, resource.Schema, name, resourceMetaArguments, metaBlocks)...)
+		case "provider":
+			if len(block.Labels) != 1 || block.Labels[0] != cloudMark {
+				continue
+			}
+			issues = append(issues, checkExampleBody(block.Body, provider.Schema, "provider", providerMetaArguments, nil)...)
+		}
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		return issues[i].Line < issues[j].Line
+	})
+
+	return
+}
+
+// checkExampleBody checks the arguments and nested blocks of body against the schema
+func checkExampleBody(body *hclsyntax.Body, s map[string]*schema.Schema, path string, metaArguments, metaBlocks map[string]bool) (issues []exampleIssue) {
+	present := make(map[string]bool)
+
+	for name, attr := range body.Attributes {
+		if metaArguments[name] {
+			continue
+		}
+
+		present[name] = true
+		v, ok := s[name]
+		if !ok {
+			issues = append(issues, newExampleIssue(attr.NameRange, "unsupported argument `%s` in `%s`", name, path))
+			continue
+		}
+		if !v.Required && !v.Optional {
+			issues = append(issues, newExampleIssue(attr.NameRange, "argument `%s` in `%s` is read-only", name, path))
+			continue
+		}
+		if v.Deprecated != "" {
+			issues = append(issues, newExampleIssue(attr.NameRange, "argument `%s` in `%s` is deprecated: %s", name, path, v.Deprecated))
+		}
+		if _, ok := v.Elem.(*schema.Resource); ok && v.ConfigMode != schema.SchemaConfigModeAttr {
+			issues = append(issues, newExampleIssue(attr.NameRange, "`%s` in `%s` must be defined as a block", name, path))
+		}
+	}
+
+	for _, block := range body.Blocks {
+		name, nested := block.Type, block.Body
+		if name == "dynamic" {
+			if len(block.Labels) != 1 {
+				continue
+			}
+			name = block.Labels[0]
+			nested = nil
+			for _, b := range block.Body.Blocks {
+				if b.Type == "content" {
+					nested = b.Body
+				}
+			}
+		} else if metaBlocks[name] {
+			continue
+		}
+
+		present[name] = true
+		v, ok := s[name]
+		if !ok {
+			issues = append(issues, newExampleIssue(block.TypeRange, "unsupported block `%s` in `%s`", name, path))
+			continue
+		}
+		if !v.Required && !v.Optional {
+			issues = append(issues, newExampleIssue(block.TypeRange, "block `%s` in `%s` is read-only", name, path))
+			continue
+		}
+		if v.Deprecated != "" {
+			issues = append(issues, newExampleIssue(block.TypeRange, "block `%s` in `%s` is deprecated: %s", name, path, v.Deprecated))
+		}
+		elem, ok := v.Elem.(*schema.Resource)
+		if !ok {
+			issues = append(issues, newExampleIssue(block.TypeRange, "`%s` in `%s` is an argument, not a block", name, path))
+			continue
+		}
+		if nested != nil {
+			issues = append(issues, checkExampleBody(nested, elem.Schema, path+"."+name, nil, nil)...)
+		}
+	}
+
+	var missing []string
+	for k, v := range s {
+		if v.Required && !present[k] {
+			missing = append(missing, k)
+		}
+	}
+	sort.Strings(missing)
+	for _, k := range missing {
+		issues = append(issues, newExampleIssue(body.SrcRange, "required argument `%s` is missing in `%s`", k, path))
+	}
+
+	return
+}
+
+// unformattedLine returns the first line which differs from the output of `terraform fmt`, or 0 if src is well formatted
+func unformattedLine(src []byte) int {
+	expected := strings.Split(strings.TrimSpace(string(hclwrite.Format(src))), "\n")
+	actual := strings.Split(strings.TrimSpace(string(src)), "\n")
+
+	// src starts after the code fence, the leading blank lines are not part of the example
+	offset := strings.Count(string(src)[:strings.Index(string(src), strings.TrimSpace(string(src)))], "\n")
+	for i := range actual {
+		if i >= len(expected) || actual[i] != expected[i] {
+			return offset + i + 1
+		}
+	}
+	if len(expected) > len(actual) {
+		return offset + len(actual) + 1
+	}
+
+	return 0
+}
+
+func newExampleIssue(rng hcl.Range, format string, v ...interface{}) exampleIssue {
+	return exampleIssue{Line: rng.Start.Line, Message: fmt.Sprintf(format, v...)}
+}
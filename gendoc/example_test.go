@@ -0,0 +1,148 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func testExampleProvider() *schema.Provider {
+	return &schema.Provider{
+		Schema: map[string]*schema.Schema{
+			"region": {Type: schema.TypeString, Optional: true},
+		},
+		ResourcesMap: map[string]*schema.Resource{
+			"tencentcloud_example": {
+				Schema: map[string]*schema.Schema{
+					"name":     {Type: schema.TypeString, Required: true},
+					"old_name": {Type: schema.TypeString, Optional: true, Deprecated: "Use `name` instead."},
+					"status":   {Type: schema.TypeString, Computed: true},
+					"rule": {
+						Type:     schema.TypeList,
+						Optional: true,
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"port": {Type: schema.TypeInt, Required: true},
+							},
+						},
+					},
+				},
+				Timeouts: &schema.ResourceTimeout{},
+			},
+		},
+		DataSourcesMap: map[string]*schema.Resource{
+			"tencentcloud_examples": {
+				Schema: map[string]*schema.Schema{
+					"result_output_file": {Type: schema.TypeString, Optional: true},
+				},
+			},
+		},
+	}
+}
+
+func TestCheckExampleValid(t *testing.T) {
+	src := `
+provider "tencentcloud" {
+  region = "ap-guangzhou"
+  alias  = "gz"
+}
+
+resource "tencentcloud_example" "example" {
+  count = 2
+  name  = "example"
+
+  dynamic "rule" {
+    for_each = [80, 443]
+    content {
+      port = rule.value
+    }
+  }
+
+  timeouts {
+    create = "10m"
+  }
+
+  lifecycle {
+    ignore_changes = [name]
+  }
+}
+
+data "tencentcloud_examples" "examples" {
+  depends_on = [tencentcloud_example.example]
+}
+
+output "status" {
+  value = tencentcloud_example.example[0].status
+}
+`
+	if issues := checkExample([]byte(src), testExampleProvider()); len(issues) > 0 {
+		t.Errorf("expected no issues, got %v", issues)
+	}
+}
+
+func TestCheckExampleInvalid(t *testing.T) {
+	src := `
+resource "tencentcloud_example" "example" {
+  old_name = "example"
+  status   = "running"
+  unknown  = true
+
+  rule {
+  }
+}
+
+data "tencentcloud_removed" "removed" {
+}
+`
+	expected := []exampleIssue{
+		{Line: 2, Message: "required argument `name` is missing in `tencentcloud_example`"},
+		{Line: 3, Message: "argument `old_name` in `tencentcloud_example` is deprecated: Use `name` instead."},
+		{Line: 4, Message: "argument `status` in `tencentcloud_example` is read-only"},
+		{Line: 5, Message: "unsupported argument `unknown` in `tencentcloud_example`"},
+		{Line: 7, Message: "required argument `port` is missing in `tencentcloud_example.rule`"},
+		{Line: 11, Message: "data `tencentcloud_removed` is not supported by the provider"},
+	}
+
+	issues := checkExample([]byte(src), testExampleProvider())
+	if len(issues) != len(expected) {
+		t.Fatalf("expected %d issues, got %v", len(expected), issues)
+	}
+	for i := range expected {
+		if issues[i] != expected[i] {
+			t.Errorf("expected %v, got %v", expected[i], issues[i])
+		}
+	}
+}
+
+func TestCheckDocExamples(t *testing.T) {
+	doc := `Provides a resource to create an example.
+
+Example Usage
+
+'hcl
+resource "tencentcloud_example" "example" {
+	name = "example"
+}
+'
+
+Import
+
+example can be imported using the id, e.g.
+
+'
+$ terraform import tencentcloud_example.example example
+'
+`
+	doc = strings.Replace(doc, "'", "```", -1)
+
+	issues := checkDocExamples(doc, testExampleProvider())
+	if len(issues) != 1 || issues[0].Line != 7 || !strings.Contains(issues[0].Message, "terraform fmt") {
+		t.Errorf("expected the unformatted line 7, got %v", issues)
+	}
+
+	issues = checkDocExamples(strings.Replace(doc, "\tname", "  name", 1), testExampleProvider())
+	if len(issues) > 0 {
+		t.Errorf("expected no issues, got %v", issues)
+	}
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -33,12 +34,85 @@ var (
 	productNameRegexp = regexp.MustCompile(`^.*\((.*)\)$`)
 )
 
+// parseArgs returns the positional arguments and parses the flags between and after them, the flag package stops
+// parsing at the first positional argument, such as: -catalog-diff old.json new.json -o diff.json
+func parseArgs(flags *flag.FlagSet, args []string) ([]string, error) {
+	var positionals []string
+	for len(args) > 0 {
+		positionals = append(positionals, args[0])
+		if err := flags.Parse(args[1:]); err != nil {
+			return nil, err
+		}
+		args = flags.Args()
+	}
+	return positionals, nil
+}
+
 func main() {
+	var (
+		checkOnly      = flag.Bool("check-examples", false, "validate the example usage of the documents against the provider schema without generating doc")
+		catalogOnly    = flag.Bool("catalog", false, "generate the schema catalog of all the resources and data sources to the file set by -o without generating doc")
+		catalogDiff    = flag.Bool("catalog-diff", false, "compare two schema catalogs given as arguments, such as: -catalog-diff old.json new.json")
+		catalogVersion = flag.String("catalog-version", "", "provider version of the schema catalog, default to the latest version of CHANGELOG.md")
+		output         = flag.String("o", "", "output file of -catalog and -catalog-diff, in yaml format if the extension is .yaml or .yml, otherwise json")
+	)
+	flag.Parse()
+	args, err := parseArgs(flag.CommandLine, flag.Args())
+	if err != nil {
+		message("[FAIL!]%s", err)
+		os.Exit(1)
+	}
+
+	if *catalogDiff {
+		if len(args) != 2 {
+			message("[FAIL!]-catalog-diff requires the old and new catalog files")
+			os.Exit(1)
+		}
+		from, err := readCatalog(args[0])
+		if err != nil {
+			message("[FAIL!]%s", err)
+			os.Exit(1)
+		}
+		to, err := readCatalog(args[1])
+		if err != nil {
+			message("[FAIL!]%s", err)
+			os.Exit(1)
+		}
+		if err := writeCatalogFile(*output, diffCatalog(from, to)); err != nil {
+			message("[FAIL!]write catalog diff failed: %s", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	provider := cloud.Provider()
 	vProvider := runtime.FuncForPC(reflect.ValueOf(cloud.Provider).Pointer())
 
 	filename, _ := vProvider.FileLine(0)
 	filePath := filepath.Dir(filename)
+
+	if *checkOnly {
+		message("checking example usage from: %s\n", filePath)
+		if !checkExamples(filePath, provider) {
+			os.Exit(1)
+		}
+		return
+	}
+
+	if *catalogOnly {
+		if *output == "" {
+			message("[FAIL!]-catalog requires the output file set by -o")
+			os.Exit(1)
+		}
+		message("generating schema catalog from: %s\n", filePath)
+		if err := writeCatalogFile(*output, genCatalog(filePath, *catalogVersion, provider, loadIdx(filePath))); err != nil {
+			message("[FAIL!]write catalog to file %s failed: %s", *output, err)
+			os.Exit(1)
+		}
+		message("[SUCC.]write catalog to file success: %s", *output)
+		return
+	}
+
 	message("generating doc from: %s\n", filePath)
 
 	// document for Index
@@ -59,6 +133,40 @@ func main() {
 
 // genIdx generating index for resource
 func genIdx(filePath string) (prods []Product) {
+	prods = loadIdx(filePath)
+	if len(prods) == 0 {
+		return
+	}
+
+	data := map[string]interface{}{
+		"cloud_mark":  cloudMark,
+		"cloud_title": cloudTitle,
+		"cloudPrefix": cloudPrefix,
+		"Products":    prods,
+	}
+
+	filename := filepath.Join(docRoot, "..", fmt.Sprintf("%s.erb", cloudMark))
+	fd, err := os.OpenFile(filename, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		message("[FAIL!]open file %s failed: %s", filename, err)
+		os.Exit(1)
+	}
+
+	defer fd.Close()
+
+	tmpl := template.Must(template.New("t").Funcs(template.FuncMap{"replace": replace}).Parse(idxTPL))
+
+	if err := tmpl.Execute(fd, data); err != nil {
+		message("[FAIL!]write file %s failed: %s", filename, err)
+		os.Exit(1)
+	}
+
+	message("[SUCC.]write doc to file success: %s", filename)
+	return
+}
+
+// loadIdx get the products from the resources list of provider.md
+func loadIdx(filePath string) (prods []Product) {
 	filename := "provider.md"
 
 	message("[START]get description from file: %s\n", filename)
@@ -91,30 +199,6 @@ func genIdx(filePath string) (prods []Product) {
 		os.Exit(1)
 	}
 
-	data := map[string]interface{}{
-		"cloud_mark":  cloudMark,
-		"cloud_title": cloudTitle,
-		"cloudPrefix": cloudPrefix,
-		"Products":    prods,
-	}
-
-	filename = filepath.Join(docRoot, "..", fmt.Sprintf("%s.erb", cloudMark))
-	fd, err := os.OpenFile(filename, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
-	if err != nil {
-		message("[FAIL!]open file %s failed: %s", filename, err)
-		os.Exit(1)
-	}
-
-	defer fd.Close()
-
-	tmpl := template.Must(template.New("t").Funcs(template.FuncMap{"replace": replace}).Parse(idxTPL))
-
-	if err := tmpl.Execute(fd, data); err != nil {
-		message("[FAIL!]write file %s failed: %s", filename, err)
-		os.Exit(1)
-	}
-
-	message("[SUCC.]write doc to file success: %s", filename)
 	return
 }
 
@@ -3,9 +3,10 @@ package main
 import (
 	"context"
 	"flag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
-	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud"
 	"log"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5/tf5server"
+	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud"
 )
 
 func main() {
@@ -14,16 +15,18 @@ func main() {
 	flag.BoolVar(&debugMode, "debuggable", false, "set to true to run the provider with support for debuggers like delve")
 	flag.Parse()
 
+	serverFactory, err := tencentcloud.ProtoV5ProviderServerFactory(context.Background())
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	var serveOpts []tf5server.ServeOpt
 	if debugMode {
-		err := plugin.Debug(context.Background(), "registry.terraform.io/tencentcloudstack/tencentcloud",
-			&plugin.ServeOpts{
-				ProviderFunc: tencentcloud.Provider,
-			})
-		if err != nil {
-			log.Println(err.Error())
-		}
-	} else {
-		plugin.Serve(&plugin.ServeOpts{
-			ProviderFunc: tencentcloud.Provider})
+		serveOpts = append(serveOpts, tf5server.WithManagedDebug())
+	}
+
+	err = tf5server.Serve("registry.terraform.io/tencentcloudstack/tencentcloud", serverFactory, serveOpts...)
+	if err != nil {
+		log.Fatal(err.Error())
 	}
 }
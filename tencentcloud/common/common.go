@@ -11,7 +11,6 @@ import (
 	"net/http"
 	"os"
 	"os/user"
-	"path/filepath"
 	"reflect"
 	"sort"
 	"strconv"
@@ -322,8 +321,10 @@ func IsString(data interface{}) bool {
 	return false
 }
 
-// WriteToFile write data to file
-func WriteToFile(filePath string, data interface{}) error {
+// WriteToFile write data to file, strings are written as they are, other data is encoded as option,
+// which is the `result_output_format` of the data source got by GetResultOutputOption, json by default.
+// The file is replaced atomically and only if its content changes.
+func WriteToFile(filePath string, data interface{}, option ...ResultOutputOption) error {
 	if strings.HasPrefix(filePath, "~") {
 		usr, err := user.Current()
 		if err != nil {
@@ -334,35 +335,20 @@ func WriteToFile(filePath string, data interface{}) error {
 		}
 	}
 
-	fileInfo, err := os.Stat(filePath)
-	if err != nil && !os.IsNotExist(err) {
-		return fmt.Errorf("stat old file error,reason %s", err.Error())
-	}
-
-	if !os.IsNotExist(err) {
-		if fileInfo.IsDir() {
-			return fmt.Errorf("old filepath is a dir,can not delete")
-		}
-		if err := os.Remove(filePath); err != nil {
-			return fmt.Errorf("delete old file error,reason %s", err.Error())
-		}
-	}
-
-	dir := filepath.Dir(filePath)
-	if err := os.MkdirAll(dir, 0755); err != nil {
-		return fmt.Errorf("create directory error, reason: %s", err.Error())
-	}
-
 	if IsString(data) {
-		return ioutil.WriteFile(filePath, []byte(data.(string)), 0422)
+		return writeFileIfChanged(filePath, []byte(data.(string)), 0422)
 	}
 
-	jsonStr, err := json.MarshalIndent(data, "", "\t")
+	outputOption := ResultOutputOption{Format: RESULT_OUTPUT_FORMAT_JSON}
+	if len(option) > 0 {
+		outputOption = option[0]
+	}
+	content, err := MarshalResultOutput(data, outputOption)
 	if err != nil {
-		return fmt.Errorf("json decode error,reason %s", err.Error())
+		return err
 	}
 
-	return ioutil.WriteFile(filePath, jsonStr, 0422)
+	return writeFileIfChanged(filePath, content, 0422)
 }
 
 // ReadFromFile return file content
@@ -405,12 +391,19 @@ func CheckNil(object interface{}, fields map[string]string) (nilFields []string)
 // The format is `qcs:project_id:service_type:region:account:resource`.
 // For more information, go to https://cloud.tencent.com/document/product/598/10606.
 func BuildTagResourceName(serviceType, resourceType, region, id string) string {
+	return BuildQcsResourceName(serviceType, region, "", fmt.Sprintf("%s/%s", resourceType, id))
+}
+
+// BuildQcsResourceName builds the CAM resource description of a resource.
+// The format is `qcs::service_type:region:uin/account:resource`, `cos` uses `uid/` instead of `uin/`.
+// An empty account stands for the account of the caller.
+func BuildQcsResourceName(serviceType, region, account, resource string) string {
 	switch serviceType {
 	case "cos":
-		return fmt.Sprintf("qcs::%s:%s:uid/:%s/%s", serviceType, region, resourceType, id)
+		return fmt.Sprintf("qcs::%s:%s:uid/%s:%s", serviceType, region, account, resource)
 
 	default:
-		return fmt.Sprintf("qcs::%s:%s:uin/:%s/%s", serviceType, region, resourceType, id)
+		return fmt.Sprintf("qcs::%s:%s:uin/%s:%s", serviceType, region, account, resource)
 	}
 }
 
@@ -716,3 +709,20 @@ func EqualArrayIgnoreOrder(sliceA, sliceB []string) bool {
 
 	return true
 }
+
+// SetRouteConflicts shows the route conflicts found at plan time in `route_conflicts` of the resource, they fail the
+// plan instead when `fail_on_route_conflict` is set.
+func SetRouteConflicts(d *schema.ResourceDiff, conflicts []string) error {
+	if len(conflicts) > 0 && d.Get("fail_on_route_conflict").(bool) {
+		return fmt.Errorf("route conflict:\n  %s", strings.Join(conflicts, "\n  "))
+	}
+
+	var old []string
+	for _, item := range d.Get("route_conflicts").([]interface{}) {
+		old = append(old, item.(string))
+	}
+	if d.Id() == "" || strings.Join(old, "\n") != strings.Join(conflicts, "\n") {
+		return d.SetNew("route_conflicts", conflicts)
+	}
+	return nil
+}
@@ -1,9 +1,13 @@
 package common
 
 import (
+	"context"
 	"reflect"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
 	sdkErrors "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common/errors"
 
 	"github.com/stretchr/testify/assert"
@@ -224,3 +228,51 @@ desc: this is a description`
 	assert.Equalf(t, reflect.TypeOf(yaml1).String(), "map[interface {}]interface {}", "")
 	assert.Equalf(t, yaml1["name"], "test-name", "")
 }
+
+func TestSetRouteConflicts(t *testing.T) {
+	cases := []struct {
+		name      string
+		fail      bool
+		conflicts []string
+		expected  string
+		expectErr bool
+	}{
+		{"no conflict", false, nil, "0", false},
+		{"no conflict with fail", true, nil, "0", false},
+		{"conflict", false, []string{"route A conflicts with route B"}, "1", false},
+		{"conflict with fail", true, []string{"route A conflicts with route B"}, "", true},
+	}
+
+	for _, c := range cases {
+		conflicts := c.conflicts
+		r := &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"fail_on_route_conflict": {Type: schema.TypeBool, Optional: true},
+				"route_conflicts":        {Type: schema.TypeList, Computed: true, Elem: &schema.Schema{Type: schema.TypeString}},
+			},
+			CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+				return SetRouteConflicts(d, conflicts)
+			},
+		}
+		config := terraform.NewResourceConfigRaw(map[string]interface{}{"fail_on_route_conflict": c.fail})
+
+		diff, err := r.SimpleDiff(context.TODO(), nil, config, nil)
+		if (err != nil) != c.expectErr {
+			t.Errorf("%s: unexpected error %v", c.name, err)
+			continue
+		}
+		if c.expectErr {
+			continue
+		}
+		// an empty list is not in the diff of a new resource, it is unknown unless it is set at plan time
+		count := "0"
+		if attr := diff.Attributes["route_conflicts.#"]; attr != nil && attr.NewComputed {
+			count = "unknown"
+		} else if attr != nil {
+			count = attr.New
+		}
+		if count != c.expected {
+			t.Errorf("%s: expected %s route conflicts, got %s", c.name, c.expected, count)
+		}
+	}
+}
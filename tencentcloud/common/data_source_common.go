@@ -0,0 +1,103 @@
+package common
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// DataSourceFilter is a filter of the `filter` block, mapped onto an element of the `Filters` parameter of the API.
+type DataSourceFilter struct {
+	Name   string
+	Values []string
+}
+
+// DataSourceFilterSchema returns the schema of the generic `filter` block of list data sources,
+// names are the filter names supported by the `Filters` parameter of the API.
+func DataSourceFilterSchema(api string) *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Description: fmt.Sprintf("Filters mapped onto the `Filters` parameter of the `%s` API, filters of different names are ANDed and "+
+			"values of the same filter are ORed. Conflicts with the argument of the same filter.", api),
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "Name of the filter, such as `zone`.",
+				},
+				"values": {
+					Type:        schema.TypeList,
+					Required:    true,
+					MinItems:    1,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+					Description: "Values of the filter.",
+				},
+			},
+		},
+	}
+}
+
+// GetDataSourceFilters returns the filters built from arguments together with the `filter` blocks, sorted by name.
+// A `filter` block using the name of a filter built from arguments is rejected, so is a name used by several blocks.
+func GetDataSourceFilters(d *schema.ResourceData, filters map[string][]string) ([]DataSourceFilter, error) {
+	result := make([]DataSourceFilter, 0, len(filters))
+	for name, values := range filters {
+		result = append(result, DataSourceFilter{Name: name, Values: values})
+	}
+
+	seen := make(map[string]bool)
+	if v, ok := d.GetOk("filter"); ok {
+		for _, item := range v.([]interface{}) {
+			filter := item.(map[string]interface{})
+			name := filter["name"].(string)
+			if _, ok := filters[name]; ok {
+				return nil, fmt.Errorf("filter `%s` conflicts with the argument of the same filter", name)
+			}
+			if seen[name] {
+				return nil, fmt.Errorf("filter `%s` is set more than once, put all the values into one `filter` block", name)
+			}
+			seen[name] = true
+
+			values := make([]string, 0, len(filter["values"].([]interface{})))
+			for _, value := range filter["values"].([]interface{}) {
+				values = append(values, value.(string))
+			}
+			result = append(result, DataSourceFilter{Name: name, Values: values})
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+
+	return result, nil
+}
+
+// DescribeAllPages calls describe page by page from offset 0 until all the items are returned.
+// describe returns the items of the page and the total count reported by the API, or -1 if the API does not report it.
+func DescribeAllPages[T any](limit int, describe func(offset, limit int) (items []T, total int, err error)) ([]T, error) {
+	result := make([]T, 0, limit)
+	for offset := 0; ; offset += limit {
+		items, total, err := describe(offset, limit)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, items...)
+		if len(items) < limit || (total >= 0 && len(result) >= total) {
+			break
+		}
+	}
+
+	return result, nil
+}
+
+// SortByKey sorts the results of data sources by key, to keep the order stable between reads.
+func SortByKey[T any](items []T, key func(T) string) {
+	sort.SliceStable(items, func(i, j int) bool {
+		return key(items[i]) < key(items[j])
+	})
+}
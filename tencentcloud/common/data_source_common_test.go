@@ -0,0 +1,104 @@
+package common
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestGetDataSourceFilters(t *testing.T) {
+	s := map[string]*schema.Schema{
+		"filter": DataSourceFilterSchema("DescribeInstances"),
+	}
+	d := schema.TestResourceDataRaw(t, s, map[string]interface{}{
+		"filter": []interface{}{
+			map[string]interface{}{"name": "zone", "values": []interface{}{"ap-guangzhou-3", "ap-guangzhou-4"}},
+			map[string]interface{}{"name": "instance-state", "values": []interface{}{"RUNNING"}},
+		},
+	})
+
+	filters, err := GetDataSourceFilters(d, map[string][]string{"vpc-id": {"vpc-xxxxxxxx"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []DataSourceFilter{
+		{Name: "instance-state", Values: []string{"RUNNING"}},
+		{Name: "vpc-id", Values: []string{"vpc-xxxxxxxx"}},
+		{Name: "zone", Values: []string{"ap-guangzhou-3", "ap-guangzhou-4"}},
+	}
+	if !reflect.DeepEqual(filters, expected) {
+		t.Errorf("expected %v, got %v", expected, filters)
+	}
+
+	if _, err := GetDataSourceFilters(d, map[string][]string{"zone": {"ap-guangzhou-3"}}); err == nil {
+		t.Errorf("expected error of filter conflicts with argument")
+	}
+
+	d = schema.TestResourceDataRaw(t, s, map[string]interface{}{
+		"filter": []interface{}{
+			map[string]interface{}{"name": "zone", "values": []interface{}{"ap-guangzhou-3"}},
+			map[string]interface{}{"name": "zone", "values": []interface{}{"ap-guangzhou-4"}},
+		},
+	})
+	if _, err := GetDataSourceFilters(d, nil); err == nil {
+		t.Errorf("expected error of duplicated filter")
+	}
+}
+
+func TestDescribeAllPages(t *testing.T) {
+	items := make([]int, 0, 250)
+	for i := 0; i < 250; i++ {
+		items = append(items, i)
+	}
+	page := func(total int) func(offset, limit int) ([]int, int, error) {
+		return func(offset, limit int) ([]int, int, error) {
+			end := offset + limit
+			if end > len(items) {
+				end = len(items)
+			}
+			if offset > end {
+				offset = end
+			}
+			return items[offset:end], total, nil
+		}
+	}
+
+	for _, total := range []int{len(items), -1} {
+		calls := 0
+		result, err := DescribeAllPages(100, func(offset, limit int) ([]int, int, error) {
+			calls++
+			return page(total)(offset, limit)
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(result, items) {
+			t.Errorf("expected all the %d items, got %d", len(items), len(result))
+		}
+		if calls != 3 {
+			t.Errorf("expected 3 pages, got %d", calls)
+		}
+	}
+
+	// stop once the total count is reached, even if the page is full
+	result, err := DescribeAllPages(125, page(len(items)))
+	if err != nil || len(result) != len(items) {
+		t.Errorf("expected all the %d items, got %d, %v", len(items), len(result), err)
+	}
+
+	if _, err := DescribeAllPages(100, func(offset, limit int) ([]int, int, error) {
+		return nil, 0, errors.New("internal error")
+	}); err == nil {
+		t.Errorf("expected error of describe")
+	}
+}
+
+func TestSortByKey(t *testing.T) {
+	ids := []string{"ins-c", "ins-a", "ins-b"}
+	SortByKey(ids, func(id string) string { return id })
+	if !reflect.DeepEqual(ids, []string{"ins-a", "ins-b", "ins-c"}) {
+		t.Errorf("unexpected order: %v", ids)
+	}
+}
@@ -9,3 +9,22 @@ type ProviderMeta interface {
 	// GetAPIV3Conn 返回访问云 API 的客户端连接对象
 	GetAPIV3Conn() *connectivity.TencentCloudClient
 }
+
+const (
+	SECURITY_GROUP_GUARDRAILS_MODE_WARN = "warn"
+	SECURITY_GROUP_GUARDRAILS_MODE_FAIL = "fail"
+)
+
+// SecurityGroupGuardrails is the provider level policy which security group rule sets are checked against at plan time.
+type SecurityGroupGuardrails struct {
+	Mode                string
+	CheckShadowedRules  bool
+	CheckDuplicateRules bool
+	// ports which must not be open to 0.0.0.0/0 or ::/0 by an ingress rule
+	WorldOpenPorts []int64
+}
+
+// SecurityGroupGuardrailsMeta is implemented by the provider meta configured with `security_group_guardrails`.
+type SecurityGroupGuardrailsMeta interface {
+	GetSecurityGroupGuardrails() *SecurityGroupGuardrails
+}
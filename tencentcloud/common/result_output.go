@@ -0,0 +1,287 @@
+package common
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	RESULT_OUTPUT_FORMAT_JSON   = "json"
+	RESULT_OUTPUT_FORMAT_YAML   = "yaml"
+	RESULT_OUTPUT_FORMAT_CSV    = "csv"
+	RESULT_OUTPUT_FORMAT_NDJSON = "ndjson"
+)
+
+var RESULT_OUTPUT_FORMATS = []string{
+	RESULT_OUTPUT_FORMAT_JSON,
+	RESULT_OUTPUT_FORMAT_YAML,
+	RESULT_OUTPUT_FORMAT_CSV,
+	RESULT_OUTPUT_FORMAT_NDJSON,
+}
+
+// ResultOutputOption is how the results of a data source are exported to its `result_output_file`.
+type ResultOutputOption struct {
+	Format string
+	Fields []string
+}
+
+// AddResultOutputFormat adds the `result_output_format` and `result_output_fields` arguments to a data source
+// which supports `result_output_file`, the data source passes them to WriteToFile by GetResultOutputOption.
+func AddResultOutputFormat(r *schema.Resource) {
+	if _, ok := r.Schema["result_output_file"]; !ok {
+		return
+	}
+
+	r.Schema["result_output_format"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		ValidateFunc: validation.StringInSlice(RESULT_OUTPUT_FORMATS, false),
+		Description: "Format of the results saved to `result_output_file`, valid values are `json`, `yaml`, `csv` and `ndjson`, default is `json`. " +
+			"With `csv` and `ndjson`, each element of the results is exported as a row or a line.",
+	}
+	r.Schema["result_output_fields"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Description: "Fields of the results saved to `result_output_file`, in the order of the `csv` columns. All the fields are saved if not set.",
+	}
+}
+
+// GetResultOutputOption returns the output option of the data source for its `result_output_file`.
+func GetResultOutputOption(d *schema.ResourceData) ResultOutputOption {
+	option := ResultOutputOption{Format: RESULT_OUTPUT_FORMAT_JSON}
+	if v, ok := d.GetOk("result_output_format"); ok {
+		option.Format = v.(string)
+	}
+	if v, ok := d.GetOk("result_output_fields"); ok {
+		for _, field := range v.([]interface{}) {
+			option.Fields = append(option.Fields, field.(string))
+		}
+	}
+	return option
+}
+
+// MarshalResultOutput encodes the results of a data source in the format of option.
+// Results are a list of elements or a single element, fields select the top level keys of each element.
+func MarshalResultOutput(data interface{}, option ResultOutputOption) ([]byte, error) {
+	if option.Format == "" || (option.Format == RESULT_OUTPUT_FORMAT_JSON && len(option.Fields) == 0) {
+		content, err := json.MarshalIndent(data, "", "\t")
+		if err != nil {
+			return nil, fmt.Errorf("json encode error, reason: %s", err.Error())
+		}
+		return content, nil
+	}
+
+	value, err := normalizeResultOutput(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(option.Fields) > 0 {
+		value = selectResultOutputFields(value, option.Fields)
+	}
+
+	switch option.Format {
+	case RESULT_OUTPUT_FORMAT_JSON:
+		return json.MarshalIndent(value, "", "\t")
+	case RESULT_OUTPUT_FORMAT_YAML:
+		return yaml.Marshal(value)
+	case RESULT_OUTPUT_FORMAT_NDJSON:
+		var buf bytes.Buffer
+		for _, item := range resultOutputRows(value) {
+			line, err := json.Marshal(item)
+			if err != nil {
+				return nil, err
+			}
+			buf.Write(line)
+			buf.WriteByte('\n')
+		}
+		return buf.Bytes(), nil
+	case RESULT_OUTPUT_FORMAT_CSV:
+		return marshalResultOutputCsv(resultOutputRows(value), option.Fields)
+	}
+
+	return nil, fmt.Errorf("unsupported result output format `%s`", option.Format)
+}
+
+// normalizeResultOutput converts the results into plain maps, lists and scalars through json,
+// so that pointers of the SDK models are encoded the same way in every format.
+func normalizeResultOutput(data interface{}) (interface{}, error) {
+	content, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("json encode error, reason: %s", err.Error())
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, fmt.Errorf("json decode error, reason: %s", err.Error())
+	}
+
+	return convertJsonNumber(value), nil
+}
+
+func convertJsonNumber(value interface{}) interface{} {
+	switch v := value.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		if f, err := v.Float64(); err == nil {
+			return f
+		}
+		return v.String()
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = convertJsonNumber(item)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = convertJsonNumber(item)
+		}
+	}
+	return value
+}
+
+func selectResultOutputFields(value interface{}, fields []string) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		selected := make(map[string]interface{}, len(fields))
+		for _, field := range fields {
+			if item, ok := v[field]; ok {
+				selected[field] = item
+			}
+		}
+		return selected
+	case []interface{}:
+		for i, item := range v {
+			v[i] = selectResultOutputFields(item, fields)
+		}
+	}
+	return value
+}
+
+func resultOutputRows(value interface{}) []interface{} {
+	if rows, ok := value.([]interface{}); ok {
+		return rows
+	}
+	if value == nil {
+		return nil
+	}
+	return []interface{}{value}
+}
+
+func marshalResultOutputCsv(rows []interface{}, fields []string) ([]byte, error) {
+	header := fields
+	if len(header) == 0 {
+		keys := make(map[string]bool)
+		for _, row := range rows {
+			if item, ok := row.(map[string]interface{}); ok {
+				for key := range item {
+					keys[key] = true
+				}
+			}
+		}
+		for key := range keys {
+			header = append(header, key)
+		}
+		sort.Strings(header)
+	}
+	if len(header) == 0 {
+		header = []string{"value"}
+	}
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	if err := writer.Write(header); err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		item, ok := row.(map[string]interface{})
+		if !ok {
+			item = map[string]interface{}{header[0]: row}
+		}
+
+		record := make([]string, 0, len(header))
+		for _, key := range header {
+			cell, err := resultOutputCsvCell(item[key])
+			if err != nil {
+				return nil, err
+			}
+			record = append(record, cell)
+		}
+		if err := writer.Write(record); err != nil {
+			return nil, err
+		}
+	}
+	writer.Flush()
+
+	return buf.Bytes(), writer.Error()
+}
+
+// resultOutputCsvCell returns scalars as they are, nested lists and maps are encoded as compact json.
+func resultOutputCsvCell(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case map[string]interface{}, []interface{}:
+		content, err := json.Marshal(v)
+		return string(content), err
+	}
+	return fmt.Sprint(value), nil
+}
+
+// writeFileIfChanged writes content to filePath through a temporary file and a rename, so readers never see
+// a partially written file. The file is left untouched if its content is the same.
+func writeFileIfChanged(filePath string, content []byte, perm os.FileMode) error {
+	fileInfo, err := os.Stat(filePath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("stat old file error, reason: %s", err.Error())
+	}
+	if err == nil {
+		if fileInfo.IsDir() {
+			return fmt.Errorf("old filepath is a dir, can not overwrite")
+		}
+		if old, err := os.ReadFile(filePath); err == nil && bytes.Equal(old, content) {
+			return nil
+		}
+	}
+
+	dir := filepath.Dir(filePath)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("create directory error, reason: %s", err.Error())
+	}
+
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(filePath)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("create temporary file error, reason: %s", err.Error())
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(content); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("write temporary file error, reason: %s", err.Error())
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("write temporary file error, reason: %s", err.Error())
+	}
+	if err := os.Chmod(tmp.Name(), perm); err != nil {
+		return fmt.Errorf("chmod temporary file error, reason: %s", err.Error())
+	}
+	if err := os.Rename(tmp.Name(), filePath); err != nil {
+		return fmt.Errorf("rename temporary file error, reason: %s", err.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,142 @@
+package common
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func testResultOutputData() []map[string]interface{} {
+	name, zone := "ins-a", "ap-guangzhou-3"
+	cpu := int64(2)
+	return []map[string]interface{}{
+		{"instance_id": &name, "availability_zone": &zone, "cpu": &cpu, "tags": map[string]interface{}{"env": "test"}},
+		{"instance_id": "ins-b, \"quoted\"", "availability_zone": nil, "cpu": 4},
+	}
+}
+
+func TestMarshalResultOutput(t *testing.T) {
+	cases := []struct {
+		option   ResultOutputOption
+		expected string
+	}{
+		{
+			option: ResultOutputOption{Format: RESULT_OUTPUT_FORMAT_CSV},
+			expected: "availability_zone,cpu,instance_id,tags\n" +
+				"ap-guangzhou-3,2,ins-a,\"{\"\"env\"\":\"\"test\"\"}\"\n" +
+				",4,\"ins-b, \"\"quoted\"\"\",\n",
+		},
+		{
+			option:   ResultOutputOption{Format: RESULT_OUTPUT_FORMAT_CSV, Fields: []string{"instance_id", "cpu"}},
+			expected: "instance_id,cpu\nins-a,2\n\"ins-b, \"\"quoted\"\"\",4\n",
+		},
+		{
+			option:   ResultOutputOption{Format: RESULT_OUTPUT_FORMAT_NDJSON, Fields: []string{"instance_id", "cpu"}},
+			expected: "{\"cpu\":2,\"instance_id\":\"ins-a\"}\n{\"cpu\":4,\"instance_id\":\"ins-b, \\\"quoted\\\"\"}\n",
+		},
+		{
+			option:   ResultOutputOption{Format: RESULT_OUTPUT_FORMAT_YAML, Fields: []string{"instance_id", "cpu"}},
+			expected: "- cpu: 2\n  instance_id: ins-a\n- cpu: 4\n  instance_id: ins-b, \"quoted\"\n",
+		},
+		{
+			option:   ResultOutputOption{Format: RESULT_OUTPUT_FORMAT_JSON, Fields: []string{"cpu"}},
+			expected: "[\n\t{\n\t\t\"cpu\": 2\n\t},\n\t{\n\t\t\"cpu\": 4\n\t}\n]",
+		},
+	}
+
+	for _, c := range cases {
+		content, err := MarshalResultOutput(testResultOutputData(), c.option)
+		if err != nil {
+			t.Fatalf("%v: unexpected error: %v", c.option, err)
+		}
+		if string(content) != c.expected {
+			t.Errorf("%v: expected\n%s\ngot\n%s", c.option, c.expected, content)
+		}
+	}
+
+	if _, err := MarshalResultOutput(testResultOutputData(), ResultOutputOption{Format: "xml"}); err == nil {
+		t.Errorf("expected error of unsupported format")
+	}
+}
+
+func TestWriteToFileWithResultOutputFormat(t *testing.T) {
+	r := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"result_output_file": {Type: schema.TypeString, Optional: true},
+		},
+	}
+	filePath := filepath.Join(t.TempDir(), "output", "instances.csv")
+	r.Read = func(d *schema.ResourceData, meta interface{}) error {
+		return WriteToFile(d.Get("result_output_file").(string), testResultOutputData(), GetResultOutputOption(d))
+	}
+	AddResultOutputFormat(r)
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"result_output_file":   filePath,
+		"result_output_format": "csv",
+		"result_output_fields": []interface{}{"instance_id"},
+	})
+	if err := r.Read(d, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := "instance_id\nins-a\n\"ins-b, \"\"quoted\"\"\"\n"; string(content) != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, content)
+	}
+
+	// the file is not rewritten if the content is unchanged
+	old := time.Now().Add(-time.Hour).Truncate(time.Second)
+	if err := os.Chtimes(filePath, old, old); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := r.Read(d, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if info, err := os.Stat(filePath); err != nil || !info.ModTime().Equal(old) {
+		t.Errorf("expected the unchanged file not to be rewritten, %v", err)
+	}
+
+	// the default format is json
+	if err := WriteToFile(filePath, []string{"ins-a"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if content, _ := os.ReadFile(filePath); string(content) != "[\n\t\"ins-a\"\n]" {
+		t.Errorf("expected json content, got\n%s", content)
+	}
+
+	entries, _ := os.ReadDir(filepath.Dir(filePath))
+	if len(entries) != 1 {
+		t.Errorf("expected no temporary file left, got %d entries", len(entries))
+	}
+}
+
+func TestGetResultOutputOption(t *testing.T) {
+	r := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"result_output_file": {Type: schema.TypeString, Optional: true},
+		},
+	}
+
+	// the option of a data source without the arguments is the default one
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{})
+	if option := GetResultOutputOption(d); option.Format != RESULT_OUTPUT_FORMAT_JSON || len(option.Fields) != 0 {
+		t.Errorf("expected the default option, got %+v", option)
+	}
+
+	AddResultOutputFormat(r)
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"result_output_format": "yaml",
+		"result_output_fields": []interface{}{"instance_id", "instance_name"},
+	})
+	expected := ResultOutputOption{Format: RESULT_OUTPUT_FORMAT_YAML, Fields: []string{"instance_id", "instance_name"}}
+	if option := GetResultOutputOption(d); !reflect.DeepEqual(option, expected) {
+		t.Errorf("expected %+v, got %+v", expected, option)
+	}
+}
@@ -472,6 +472,19 @@ func (me *TencentCloudClient) UseVpcClient(iacExtInfo ...IacExtInfo) *vpc.Client
 	return me.vpcConn
 }
 
+// UseVpcClientRegion returns vpc client of the region, which is not cached since it is only used to describe resources of other regions
+func (me *TencentCloudClient) UseVpcClientRegion(region string) *vpc.Client {
+	if region == "" || region == me.Region {
+		return me.UseVpcClient()
+	}
+
+	cpf := me.NewClientProfile(300)
+	conn, _ := vpc.NewClient(me.Credential, region, cpf)
+	conn.WithHttpTransport(&LogRoundTripper{})
+
+	return conn
+}
+
 func (me *TencentCloudClient) UseOmitNilClient(module string) *common.Client {
 	secretId := me.Credential.SecretId
 	secretKey := me.Credential.SecretKey
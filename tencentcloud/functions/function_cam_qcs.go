@@ -0,0 +1,67 @@
+package functions
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+
+	tccommon "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/common"
+)
+
+var _ function.Function = &CamQcsFunction{}
+
+type CamQcsFunction struct{}
+
+func NewCamQcsFunction() function.Function {
+	return &CamQcsFunction{}
+}
+
+func (f *CamQcsFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "cam_qcs"
+}
+
+func (f *CamQcsFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Build a CAM resource description.",
+		Description: "Returns the six-segment CAM resource description `qcs::service_type:region:uin/account:resource` used by CAM policies and tags. For more information, go to https://cloud.tencent.com/document/product/598/10606.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "service",
+				Description: "Service type, such as `cvm` or `cos`.",
+			},
+			function.StringParameter{
+				Name:        "region",
+				Description: "Region of the resource, empty for global services and all regions.",
+			},
+			function.StringParameter{
+				Name:        "uin",
+				Description: "Owner account of the resource, empty for the current account.",
+			},
+			function.StringParameter{
+				Name:        "resource",
+				Description: "Resource path, such as `instance/ins-xxxxxxxx` or `*`.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *CamQcsFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var service, region, uin, resource string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &service, &region, &uin, &resource))
+	if resp.Error != nil {
+		return
+	}
+
+	if service == "" {
+		resp.Error = function.NewArgumentFuncError(0, "service can not be empty")
+		return
+	}
+	if resource == "" {
+		resp.Error = function.NewArgumentFuncError(3, "resource can not be empty")
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, tccommon.BuildQcsResourceName(service, region, uin, resource)))
+}
@@ -0,0 +1,85 @@
+package functions
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	tccommon "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/common"
+	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/internal/helper"
+)
+
+var _ function.Function = &CidrSubnetsForZoneFunction{}
+
+type CidrSubnetsForZoneFunction struct{}
+
+func NewCidrSubnetsForZoneFunction() function.Function {
+	return &CidrSubnetsForZoneFunction{}
+}
+
+func (f *CidrSubnetsForZoneFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "cidr_subnets_for_zone"
+}
+
+func (f *CidrSubnetsForZoneFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Plan one subnet per availability zone.",
+		Description: "Splits the VPC CIDR into consecutive subnets whose prefix is extended by `newbits`, and assigns them to the availability zones in the given order. The result is keyed by availability zone.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "cidr",
+				Description: "CIDR of the VPC, such as `10.0.0.0/16`.",
+			},
+			function.Int64Parameter{
+				Name:        "newbits",
+				Description: "Number of additional bits of the subnet prefix.",
+			},
+			function.ListParameter{
+				Name:        "zones",
+				ElementType: types.StringType,
+				Description: "Availability zones, such as `[\"ap-guangzhou-3\", \"ap-guangzhou-4\"]`.",
+			},
+		},
+		Return: function.MapReturn{
+			ElementType: types.StringType,
+		},
+	}
+}
+
+func (f *CidrSubnetsForZoneFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var (
+		cidr    string
+		newbits int64
+		zones   []string
+	)
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &cidr, &newbits, &zones))
+	if resp.Error != nil {
+		return
+	}
+
+	if _, errs := tccommon.ValidateCIDRNetworkAddress(cidr, "cidr"); len(errs) > 0 {
+		resp.Error = function.NewArgumentFuncError(0, errs[0].Error())
+		return
+	}
+	_, base, _ := net.ParseCIDR(cidr)
+
+	result := make(map[string]string, len(zones))
+	for i, zone := range zones {
+		if _, ok := result[zone]; ok {
+			resp.Error = function.NewArgumentFuncError(2, fmt.Sprintf("zone `%s` is duplicated", zone))
+			return
+		}
+		subnet, err := helper.CidrSubnet(base, int(newbits), int64(i))
+		if err != nil {
+			resp.Error = function.NewArgumentFuncError(1, err.Error())
+			return
+		}
+		result[zone] = subnet.String()
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, result))
+}
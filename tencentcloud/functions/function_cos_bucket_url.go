@@ -0,0 +1,60 @@
+package functions
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+
+	tccommon "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/common"
+)
+
+var _ function.Function = &CosBucketUrlFunction{}
+
+type CosBucketUrlFunction struct{}
+
+func NewCosBucketUrlFunction() function.Function {
+	return &CosBucketUrlFunction{}
+}
+
+func (f *CosBucketUrlFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "cos_bucket_url"
+}
+
+func (f *CosBucketUrlFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Build the URL of a COS bucket.",
+		Description: "Returns the default domain of a COS bucket, in the same format as the `cos_bucket_url` attribute of `tencentcloud_cos_bucket`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "bucket",
+				Description: "Name of the bucket, format `name-appid`.",
+			},
+			function.StringParameter{
+				Name:        "region",
+				Description: "Region of the bucket, such as `ap-guangzhou`.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *CosBucketUrlFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var bucket, region string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &bucket, &region))
+	if resp.Error != nil {
+		return
+	}
+
+	if _, errs := tccommon.ValidateCosBucketName(bucket, "bucket"); len(errs) > 0 {
+		resp.Error = function.NewArgumentFuncError(0, errs[0].Error())
+		return
+	}
+	if region == "" {
+		resp.Error = function.NewArgumentFuncError(1, "region can not be empty")
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, fmt.Sprintf("%s.cos.%s.myqcloud.com", bucket, region)))
+}
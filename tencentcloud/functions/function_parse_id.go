@@ -0,0 +1,113 @@
+package functions
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/internal/helper"
+)
+
+// compositeIdFields records the parts of the composite ids joined by `tccommon.FILED_SP`,
+// in the order the resources build them.
+var compositeIdFields = map[string][]string{
+	"tencentcloud_api_gateway_custom_domain":             {"service_id", "sub_domain"},
+	"tencentcloud_api_gateway_ip_strategy":               {"service_id", "strategy_id"},
+	"tencentcloud_cam_group_policy_attachment":           {"group_id", "policy_id"},
+	"tencentcloud_cam_role_policy_attachment":            {"role_id", "policy_id"},
+	"tencentcloud_cam_user_policy_attachment":            {"user_id", "policy_id"},
+	"tencentcloud_clb_attachment":                        {"rule_id", "listener_id", "clb_id"},
+	"tencentcloud_clb_listener":                          {"clb_id", "listener_id"},
+	"tencentcloud_clb_listener_rule":                     {"clb_id", "listener_id", "rule_id"},
+	"tencentcloud_clb_target_group_attachment":           {"target_group_id", "listener_id", "clb_id", "rule_id"},
+	"tencentcloud_dnspod_record":                         {"domain", "record_id"},
+	"tencentcloud_identity_center_group":                 {"zone_id", "group_id"},
+	"tencentcloud_identity_center_role_configuration":    {"zone_id", "role_configuration_id"},
+	"tencentcloud_identity_center_user":                  {"zone_id", "user_id"},
+	"tencentcloud_identity_center_user_group_attachment": {"zone_id", "group_id", "user_id"},
+	"tencentcloud_kms_cloud_resource_attachment":         {"key_id", "product_id", "resource_id"},
+	"tencentcloud_kubernetes_addon_attachment":           {"cluster_id", "name"},
+	"tencentcloud_kubernetes_native_node_pool":           {"cluster_id", "node_pool_id"},
+	"tencentcloud_kubernetes_node_pool":                  {"cluster_id", "node_pool_id"},
+	"tencentcloud_mysql_database":                        {"instance_id", "db_name"},
+	"tencentcloud_private_dns_record":                    {"zone_id", "record_id"},
+	"tencentcloud_tcm_cluster_attachment":                {"mesh_id", "cluster_id"},
+	"tencentcloud_tcr_customized_domain":                 {"registry_id", "domain_name"},
+	"tencentcloud_tcr_service_account":                   {"registry_id", "name"},
+	"tencentcloud_tdmq_namespace":                        {"environ_name", "cluster_id"},
+	"tencentcloud_vpn_gateway_route":                     {"vpn_gateway_id", "route_id"},
+}
+
+var _ function.Function = &ParseIdFunction{}
+
+type ParseIdFunction struct{}
+
+func NewParseIdFunction() function.Function {
+	return &ParseIdFunction{}
+}
+
+func (f *ParseIdFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_id"
+}
+
+func (f *ParseIdFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Parse the composite id of a resource.",
+		Description: "Splits the composite id of a resource into a map keyed by the argument names the id is composed of.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "resource_type",
+				Description: "Resource type the id belongs to, such as `tencentcloud_kubernetes_node_pool`.",
+			},
+			function.StringParameter{
+				Name:        "id",
+				Description: "Composite id of the resource.",
+			},
+		},
+		Return: function.MapReturn{
+			ElementType: types.StringType,
+		},
+	}
+}
+
+func (f *ParseIdFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var resourceType, id string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &resourceType, &id))
+	if resp.Error != nil {
+		return
+	}
+
+	fields, ok := compositeIdFields[resourceType]
+	if !ok {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("resource type `%s` has no composite id, supported: %s", resourceType, strings.Join(ParseIdResourceTypes(), ", ")))
+		return
+	}
+
+	items := helper.IdParse(id)
+	if len(items) != len(fields) {
+		resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("id `%s` is broken, expected the format `%s`", id, helper.IdFormat(fields...)))
+		return
+	}
+
+	result := make(map[string]string, len(fields))
+	for i, field := range fields {
+		result[field] = items[i]
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, result))
+}
+
+// ParseIdResourceTypes returns the sorted resource types supported by `parse_id`.
+func ParseIdResourceTypes() []string {
+	resourceTypes := make([]string, 0, len(compositeIdFields))
+	for k := range compositeIdFields {
+		resourceTypes = append(resourceTypes, k)
+	}
+	sort.Strings(resourceTypes)
+	return resourceTypes
+}
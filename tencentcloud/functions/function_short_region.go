@@ -0,0 +1,53 @@
+package functions
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+
+	tccommon "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/common"
+)
+
+var _ function.Function = &ShortRegionFunction{}
+
+type ShortRegionFunction struct{}
+
+func NewShortRegionFunction() function.Function {
+	return &ShortRegionFunction{}
+}
+
+func (f *ShortRegionFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "short_region"
+}
+
+func (f *ShortRegionFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Resolve a short region name.",
+		Description: "Resolves a short region name returned by some APIs, such as `gz`, into the full region name, such as `ap-guangzhou`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "name",
+				Description: "Short region name.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *ShortRegionFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var name string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &name))
+	if resp.Error != nil {
+		return
+	}
+
+	region := tccommon.ShortRegionNameParse(name)
+	if region == "" {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("unknown short region name `%s`", name))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, region))
+}
@@ -0,0 +1,17 @@
+package functions
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Functions returns all the provider-defined functions, they are served through the plugin-framework
+// and require Terraform 1.8 or later.
+func Functions() []func() function.Function {
+	return []func() function.Function{
+		NewParseIdFunction,
+		NewCosBucketUrlFunction,
+		NewCamQcsFunction,
+		NewShortRegionFunction,
+		NewCidrSubnetsForZoneFunction,
+	}
+}
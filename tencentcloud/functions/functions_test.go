@@ -0,0 +1,161 @@
+package functions_test
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/functions"
+)
+
+func runFunction(t *testing.T, f function.Function, args ...attr.Value) (attr.Value, *function.FuncError) {
+	t.Helper()
+
+	ctx := context.Background()
+	definitionResp := &function.DefinitionResponse{}
+	f.Definition(ctx, function.DefinitionRequest{}, definitionResp)
+	if definitionResp.Diagnostics.HasError() {
+		t.Fatalf("invalid definition: %v", definitionResp.Diagnostics)
+	}
+
+	resultData, funcErr := definitionResp.Definition.Return.NewResultData(ctx)
+	if funcErr != nil {
+		t.Fatalf("new result data failed: %v", funcErr)
+	}
+	resp := &function.RunResponse{Result: resultData}
+	f.Run(ctx, function.RunRequest{Arguments: function.NewArgumentsData(args)}, resp)
+
+	return resp.Result.Value(), resp.Error
+}
+
+func stringMap(t *testing.T, v attr.Value) map[string]string {
+	t.Helper()
+
+	result := make(map[string]string)
+	for k, e := range v.(types.Map).Elements() {
+		result[k] = e.(types.String).ValueString()
+	}
+	return result
+}
+
+func TestFunctionsUnique(t *testing.T) {
+	names := make(map[string]bool)
+	for _, newFunc := range functions.Functions() {
+		resp := &function.MetadataResponse{}
+		newFunc().Metadata(context.Background(), function.MetadataRequest{}, resp)
+		if names[resp.Name] {
+			t.Errorf("duplicate function name: %s", resp.Name)
+		}
+		names[resp.Name] = true
+	}
+}
+
+func TestParseIdFunction(t *testing.T) {
+	result, funcErr := runFunction(t, functions.NewParseIdFunction(),
+		types.StringValue("tencentcloud_kubernetes_node_pool"), types.StringValue("cls-xxxxxxxx#np-xxxxxxxx"))
+	if funcErr != nil {
+		t.Fatalf("unexpected error: %v", funcErr)
+	}
+	expected := map[string]string{"cluster_id": "cls-xxxxxxxx", "node_pool_id": "np-xxxxxxxx"}
+	if actual := stringMap(t, result); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected %v, got %v", expected, actual)
+	}
+
+	_, funcErr = runFunction(t, functions.NewParseIdFunction(),
+		types.StringValue("tencentcloud_kubernetes_node_pool"), types.StringValue("cls-xxxxxxxx"))
+	if funcErr == nil || funcErr.FunctionArgument == nil || *funcErr.FunctionArgument != 1 {
+		t.Errorf("expected argument error of id, got %v", funcErr)
+	}
+
+	_, funcErr = runFunction(t, functions.NewParseIdFunction(),
+		types.StringValue("tencentcloud_instance"), types.StringValue("ins-xxxxxxxx"))
+	if funcErr == nil || funcErr.FunctionArgument == nil || *funcErr.FunctionArgument != 0 {
+		t.Errorf("expected argument error of resource_type, got %v", funcErr)
+	}
+}
+
+func TestCosBucketUrlFunction(t *testing.T) {
+	result, funcErr := runFunction(t, functions.NewCosBucketUrlFunction(),
+		types.StringValue("example-1250000000"), types.StringValue("ap-guangzhou"))
+	if funcErr != nil {
+		t.Fatalf("unexpected error: %v", funcErr)
+	}
+	if actual := result.(types.String).ValueString(); actual != "example-1250000000.cos.ap-guangzhou.myqcloud.com" {
+		t.Errorf("unexpected url: %s", actual)
+	}
+
+	_, funcErr = runFunction(t, functions.NewCosBucketUrlFunction(),
+		types.StringValue("example"), types.StringValue("ap-guangzhou"))
+	if funcErr == nil {
+		t.Errorf("expected error of bucket without appid")
+	}
+}
+
+func TestCamQcsFunction(t *testing.T) {
+	cases := []struct {
+		service, region, uin, resource string
+		expected                       string
+	}{
+		{"cvm", "ap-guangzhou", "100000000001", "instance/ins-xxxxxxxx", "qcs::cvm:ap-guangzhou:uin/100000000001:instance/ins-xxxxxxxx"},
+		{"cam", "", "100000000001", "roleName/example", "qcs::cam::uin/100000000001:roleName/example"},
+		{"cos", "ap-guangzhou", "", "prefix//1250000000/example/*", "qcs::cos:ap-guangzhou:uid/:prefix//1250000000/example/*"},
+	}
+
+	for _, c := range cases {
+		result, funcErr := runFunction(t, functions.NewCamQcsFunction(),
+			types.StringValue(c.service), types.StringValue(c.region), types.StringValue(c.uin), types.StringValue(c.resource))
+		if funcErr != nil {
+			t.Fatalf("unexpected error: %v", funcErr)
+		}
+		if actual := result.(types.String).ValueString(); actual != c.expected {
+			t.Errorf("expected %s, got %s", c.expected, actual)
+		}
+	}
+}
+
+func TestShortRegionFunction(t *testing.T) {
+	result, funcErr := runFunction(t, functions.NewShortRegionFunction(), types.StringValue("gz"))
+	if funcErr != nil {
+		t.Fatalf("unexpected error: %v", funcErr)
+	}
+	if actual := result.(types.String).ValueString(); actual != "ap-guangzhou" {
+		t.Errorf("unexpected region: %s", actual)
+	}
+
+	_, funcErr = runFunction(t, functions.NewShortRegionFunction(), types.StringValue("unknown"))
+	if funcErr == nil || !strings.Contains(funcErr.Error(), "unknown") {
+		t.Errorf("expected error of unknown short region, got %v", funcErr)
+	}
+}
+
+func TestCidrSubnetsForZoneFunction(t *testing.T) {
+	zones := types.ListValueMust(types.StringType, []attr.Value{
+		types.StringValue("ap-guangzhou-3"),
+		types.StringValue("ap-guangzhou-4"),
+		types.StringValue("ap-guangzhou-6"),
+	})
+	result, funcErr := runFunction(t, functions.NewCidrSubnetsForZoneFunction(),
+		types.StringValue("10.0.0.0/16"), types.Int64Value(8), zones)
+	if funcErr != nil {
+		t.Fatalf("unexpected error: %v", funcErr)
+	}
+	expected := map[string]string{
+		"ap-guangzhou-3": "10.0.0.0/24",
+		"ap-guangzhou-4": "10.0.1.0/24",
+		"ap-guangzhou-6": "10.0.2.0/24",
+	}
+	if actual := stringMap(t, result); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected %v, got %v", expected, actual)
+	}
+
+	_, funcErr = runFunction(t, functions.NewCidrSubnetsForZoneFunction(),
+		types.StringValue("10.0.0.0/16"), types.Int64Value(1), zones)
+	if funcErr == nil {
+		t.Errorf("expected error of insufficient address space")
+	}
+}
@@ -0,0 +1,95 @@
+package helper
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"net"
+)
+
+// CidrSubnet calculates the num-th subnet of base whose prefix is extended by newbits, like terraform `cidrsubnet`.
+func CidrSubnet(base *net.IPNet, newbits int, num int64) (*net.IPNet, error) {
+	ones, bits := base.Mask.Size()
+	if newbits < 0 || ones+newbits > bits {
+		return nil, fmt.Errorf("insufficient address space to extend prefix of %d by %d", ones, newbits)
+	}
+	if num < 0 || big.NewInt(num).BitLen() > newbits {
+		return nil, fmt.Errorf("prefix extension of %d does not accommodate a subnet numbered %d", newbits, num)
+	}
+
+	ip := base.IP.Mask(base.Mask)
+	if bits == net.IPv4len*8 {
+		ip = ip.To4()
+	}
+	ipInt := new(big.Int).SetBytes(ip)
+	ipInt.Or(ipInt, new(big.Int).Lsh(big.NewInt(num), uint(bits-ones-newbits)))
+
+	subnet := make(net.IP, len(ip))
+	ipInt.FillBytes(subnet)
+
+	return &net.IPNet{IP: subnet, Mask: net.CIDRMask(ones+newbits, bits)}, nil
+}
+
+// CidrContains returns whether the cidr outer contains the cidr inner entirely.
+func CidrContains(outer, inner *net.IPNet) bool {
+	outerOnes, outerBits := outer.Mask.Size()
+	innerOnes, innerBits := inner.Mask.Size()
+	return outerBits == innerBits && outerOnes <= innerOnes && outer.Contains(inner.IP)
+}
+
+// CidrOverlaps returns whether two cidrs share any address.
+func CidrOverlaps(a, b *net.IPNet) bool {
+	return a.Contains(b.IP) || b.Contains(a.IP)
+}
+
+// CidrNextFree returns the lowest IPv4 cidr of prefixLength in supernet which does not overlap any of used,
+// nil is returned if the supernet is exhausted.
+func CidrNextFree(supernet *net.IPNet, prefixLength int, used []*net.IPNet) (*net.IPNet, error) {
+	ones, bits := supernet.Mask.Size()
+	base := supernet.IP.Mask(supernet.Mask).To4()
+	if base == nil || bits != net.IPv4len*8 {
+		return nil, fmt.Errorf("only IPv4 cidrs are supported, got %s", supernet.String())
+	}
+	if prefixLength < ones || prefixLength > bits {
+		return nil, fmt.Errorf("prefix length %d is out of the range [%d, %d] of %s", prefixLength, ones, bits, supernet.String())
+	}
+
+	var (
+		start = uint64(binary.BigEndian.Uint32(base))
+		end   = start + uint64(1)<<uint(bits-ones)
+		size  = uint64(1) << uint(bits-prefixLength)
+	)
+	for candidate := start; candidate+size <= end; {
+		next := candidate + size
+		free := true
+		for _, cidr := range used {
+			usedStart, usedEnd, ok := ipv4CidrRange(cidr)
+			if !ok || usedEnd <= candidate || usedStart >= candidate+size {
+				continue
+			}
+			free = false
+			// skip the whole used cidr, candidates are aligned to their size
+			if aligned := (usedEnd + size - 1) / size * size; aligned > next {
+				next = aligned
+			}
+		}
+		if free {
+			ip := make(net.IP, net.IPv4len)
+			binary.BigEndian.PutUint32(ip, uint32(candidate))
+			return &net.IPNet{IP: ip, Mask: net.CIDRMask(prefixLength, bits)}, nil
+		}
+		candidate = next
+	}
+	return nil, nil
+}
+
+// ipv4CidrRange returns the first address and the address after the last one of the IPv4 cidr.
+func ipv4CidrRange(cidr *net.IPNet) (start, end uint64, ok bool) {
+	ones, bits := cidr.Mask.Size()
+	ip := cidr.IP.Mask(cidr.Mask).To4()
+	if ip == nil || bits != net.IPv4len*8 {
+		return 0, 0, false
+	}
+	start = uint64(binary.BigEndian.Uint32(ip))
+	return start, start + uint64(1)<<uint(bits-ones), true
+}
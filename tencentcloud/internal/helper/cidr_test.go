@@ -0,0 +1,123 @@
+package helper
+
+import (
+	"net"
+	"testing"
+)
+
+func mustParseCidr(t *testing.T, cidr string) *net.IPNet {
+	t.Helper()
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ipNet
+}
+
+func TestCidrSubnet(t *testing.T) {
+	cases := []struct {
+		base    string
+		newbits int
+		num     int64
+		want    string
+		wantErr bool
+	}{
+		{base: "10.0.0.0/16", newbits: 8, num: 0, want: "10.0.0.0/24"},
+		{base: "10.0.0.0/16", newbits: 8, num: 255, want: "10.0.255.0/24"},
+		{base: "10.0.0.0/16", newbits: 4, num: 3, want: "10.0.48.0/20"},
+		{base: "fd00::/56", newbits: 8, num: 1, want: "fd00:0:0:1::/64"},
+		{base: "10.0.0.0/16", newbits: 8, num: 256, wantErr: true},
+		{base: "10.0.0.0/30", newbits: 3, num: 0, wantErr: true},
+		{base: "10.0.0.0/16", newbits: 8, num: -1, wantErr: true},
+	}
+	for _, c := range cases {
+		got, err := CidrSubnet(mustParseCidr(t, c.base), c.newbits, c.num)
+		if c.wantErr {
+			if err == nil {
+				t.Errorf("CidrSubnet(%s, %d, %d): expected error, got %s", c.base, c.newbits, c.num, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("CidrSubnet(%s, %d, %d): %s", c.base, c.newbits, c.num, err)
+			continue
+		}
+		if got.String() != c.want {
+			t.Errorf("CidrSubnet(%s, %d, %d) = %s, want %s", c.base, c.newbits, c.num, got, c.want)
+		}
+	}
+}
+
+func TestCidrContainsAndOverlaps(t *testing.T) {
+	cases := []struct {
+		a, b     string
+		contains bool
+		overlaps bool
+	}{
+		{a: "10.0.0.0/16", b: "10.0.1.0/24", contains: true, overlaps: true},
+		{a: "10.0.0.0/16", b: "10.0.0.0/16", contains: true, overlaps: true},
+		{a: "10.0.1.0/24", b: "10.0.0.0/16", contains: false, overlaps: true},
+		{a: "10.0.0.0/16", b: "10.1.0.0/16", contains: false, overlaps: false},
+		{a: "::/0", b: "10.0.0.0/8", contains: false, overlaps: false},
+	}
+	for _, c := range cases {
+		a, b := mustParseCidr(t, c.a), mustParseCidr(t, c.b)
+		if got := CidrContains(a, b); got != c.contains {
+			t.Errorf("CidrContains(%s, %s) = %t, want %t", c.a, c.b, got, c.contains)
+		}
+		if got := CidrOverlaps(a, b); got != c.overlaps {
+			t.Errorf("CidrOverlaps(%s, %s) = %t, want %t", c.a, c.b, got, c.overlaps)
+		}
+	}
+}
+
+func TestCidrNextFree(t *testing.T) {
+	cases := []struct {
+		name         string
+		supernet     string
+		prefixLength int
+		used         []string
+		want         string
+		wantErr      bool
+	}{
+		{name: "empty", supernet: "10.0.0.0/16", prefixLength: 24, want: "10.0.0.0/24"},
+		{name: "whole supernet", supernet: "10.0.0.0/16", prefixLength: 16, want: "10.0.0.0/16"},
+		{name: "skip used", supernet: "10.0.0.0/16", prefixLength: 24, used: []string{"10.0.0.0/24", "10.0.1.0/24"}, want: "10.0.2.0/24"},
+		{name: "fill hole", supernet: "10.0.0.0/16", prefixLength: 24, used: []string{"10.0.0.0/24", "10.0.2.0/24"}, want: "10.0.1.0/24"},
+		{name: "aligned after smaller used", supernet: "10.0.0.0/16", prefixLength: 24, used: []string{"10.0.0.128/25"}, want: "10.0.1.0/24"},
+		{name: "skip larger used", supernet: "10.0.0.0/16", prefixLength: 24, used: []string{"10.0.0.0/20"}, want: "10.0.16.0/24"},
+		{name: "used outside", supernet: "10.0.0.0/16", prefixLength: 24, used: []string{"192.168.0.0/16", "10.1.0.0/24"}, want: "10.0.0.0/24"},
+		{name: "used supernet", supernet: "10.0.0.0/24", prefixLength: 26, used: []string{"10.0.0.0/8"}},
+		{name: "exhausted", supernet: "10.0.0.0/24", prefixLength: 25, used: []string{"10.0.0.0/25", "10.0.0.128/26"}},
+		{name: "ignore IPv6 used", supernet: "10.0.0.0/24", prefixLength: 24, used: []string{"fd00::/8"}, want: "10.0.0.0/24"},
+		{name: "prefix shorter than supernet", supernet: "10.0.0.0/16", prefixLength: 8, wantErr: true},
+		{name: "prefix too long", supernet: "10.0.0.0/16", prefixLength: 33, wantErr: true},
+		{name: "IPv6 supernet", supernet: "fd00::/56", prefixLength: 64, wantErr: true},
+	}
+	for _, c := range cases {
+		used := make([]*net.IPNet, 0, len(c.used))
+		for _, cidr := range c.used {
+			used = append(used, mustParseCidr(t, cidr))
+		}
+		got, err := CidrNextFree(mustParseCidr(t, c.supernet), c.prefixLength, used)
+		if c.wantErr {
+			if err == nil {
+				t.Errorf("%s: expected error, got %s", c.name, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %s", c.name, err)
+			continue
+		}
+		if c.want == "" {
+			if got != nil {
+				t.Errorf("%s: expected no free cidr, got %s", c.name, got)
+			}
+			continue
+		}
+		if got == nil || got.String() != c.want {
+			t.Errorf("%s: got %s, want %s", c.name, got, c.want)
+		}
+	}
+}
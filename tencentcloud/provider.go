@@ -151,10 +151,12 @@ const (
 )
 
 type TencentCloudClient struct {
-	apiV3Conn *connectivity.TencentCloudClient
+	apiV3Conn               *connectivity.TencentCloudClient
+	securityGroupGuardrails *tccommon.SecurityGroupGuardrails
 }
 
 var _ tccommon.ProviderMeta = &TencentCloudClient{}
+var _ tccommon.SecurityGroupGuardrailsMeta = &TencentCloudClient{}
 
 func init() {
 	commonJson.OmitBehaviour = commonJson.OmitEmpty
@@ -165,8 +167,13 @@ func (meta *TencentCloudClient) GetAPIV3Conn() *connectivity.TencentCloudClient
 	return meta.apiV3Conn
 }
 
+// GetSecurityGroupGuardrails returns the guardrails of security group rules, nil if not configured
+func (meta *TencentCloudClient) GetSecurityGroupGuardrails() *tccommon.SecurityGroupGuardrails {
+	return meta.securityGroupGuardrails
+}
+
 func Provider() *schema.Provider {
-	return &schema.Provider{
+	provider := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"secret_id": {
 				Type:        schema.TypeString,
@@ -385,6 +392,41 @@ func Provider() *schema.Provider {
 				ConflictsWith: []string{"allowed_account_ids", "assume_role_with_saml", "assume_role_with_web_identity"},
 				Description:   "List of forbidden TencentCloud account IDs to prevent you from mistakenly using the wrong one (and potentially end up destroying a live environment). Conflicts with `allowed_account_ids`, If use `assume_role_with_saml` or `assume_role_with_web_identity`, it is not supported.",
 			},
+			"security_group_guardrails": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Guardrails which the rules of `tencentcloud_security_group_rule_set` are checked against at plan time.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"mode": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      tccommon.SECURITY_GROUP_GUARDRAILS_MODE_WARN,
+							ValidateFunc: tccommon.ValidateAllowedStringValue([]string{tccommon.SECURITY_GROUP_GUARDRAILS_MODE_WARN, tccommon.SECURITY_GROUP_GUARDRAILS_MODE_FAIL}),
+							Description:  "Action on violations. Valid values: `warn` (the violations are shown in `guardrail_violations` of the rule set), `fail` (the plan fails). Default is `warn`.",
+						},
+						"check_shadowed_rules": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     true,
+							Description: "Whether a rule covered by an earlier rule is a violation. Default is `true`.",
+						},
+						"check_duplicate_rules": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     true,
+							Description: "Whether a rule same as an earlier rule is a violation. Default is `true`.",
+						},
+						"world_open_ports": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeInt},
+							Description: "Ports which must not be accepted from `0.0.0.0/0` or `::/0` by an ingress rule, e.g. `[22, 3389]`.",
+						},
+					},
+				},
+			},
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
@@ -433,6 +475,7 @@ func Provider() *schema.Provider {
 			"tencentcloud_vpc_limits":                                   vpc.DataSourceTencentCloudVpcLimits(),
 			"tencentcloud_vpc_used_ip_address":                          vpc.DataSourceTencentCloudVpcUsedIpAddress(),
 			"tencentcloud_vpc_net_detect_state_check":                   vpc.DataSourceTencentCloudVpcNetDetectStateCheck(),
+			"tencentcloud_vpc_reachability_analysis":                    vpc.DataSourceTencentCloudVpcReachabilityAnalysis(),
 			"tencentcloud_subnet":                                       vpc.DataSourceTencentCloudSubnet(),
 			"tencentcloud_route_table":                                  vpc.DataSourceTencentCloudRouteTable(),
 			"tencentcloud_domains":                                      domain.DataSourceTencentCloudDomains(),
@@ -483,11 +526,13 @@ func Provider() *schema.Provider {
 			"tencentcloud_dc_gateway_ccn_routes":                        dcg.DataSourceTencentCloudDcGatewayCCNRoutes(),
 			"tencentcloud_security_group":                               vpc.DataSourceTencentCloudSecurityGroup(),
 			"tencentcloud_security_groups":                              vpc.DataSourceTencentCloudSecurityGroups(),
+			"tencentcloud_security_group_effective_rules":               vpc.DataSourceTencentCloudSecurityGroupEffectiveRules(),
 			"tencentcloud_kubernetes_clusters":                          tke.DataSourceTencentCloudKubernetesClusters(),
 			"tencentcloud_kubernetes_charts":                            tke.DataSourceTencentCloudKubernetesCharts(),
 			"tencentcloud_kubernetes_cluster_levels":                    tke.DataSourceTencentCloudKubernetesClusterLevels(),
 			"tencentcloud_kubernetes_cluster_common_names":              tke.DataSourceTencentCloudKubernetesClusterCommonNames(),
 			"tencentcloud_kubernetes_cluster_authentication_options":    tke.DataSourceTencentCloudKubernetesClusterAuthenticationOptions(),
+			"tencentcloud_kubernetes_cluster_exec_kubeconfig":           tke.DataSourceTencentCloudKubernetesClusterExecKubeconfig(),
 			"tencentcloud_kubernetes_available_cluster_versions":        tke.DataSourceTencentCloudKubernetesAvailableClusterVersions(),
 			"tencentcloud_eks_clusters":                                 tke.DataSourceTencentCloudEKSClusters(),
 			"tencentcloud_eks_cluster_credential":                       tke.DataSourceTencentCloudEksClusterCredential(),
@@ -522,6 +567,7 @@ func Provider() *schema.Provider {
 			"tencentcloud_mysql_project_security_group":                 cdb.DataSourceTencentCloudMysqlProjectSecurityGroup(),
 			"tencentcloud_mysql_ro_min_scale":                           cdb.DataSourceTencentCloudMysqlRoMinScale(),
 			"tencentcloud_cos_bucket_object":                            cos.DataSourceTencentCloudCosBucketObject(),
+			"tencentcloud_cos_object_presigned_url":                     cos.DataSourceTencentCloudCosObjectPresignedUrl(),
 			"tencentcloud_cos_buckets":                                  cos.DataSourceTencentCloudCosBuckets(),
 			"tencentcloud_cos_batchs":                                   cos.DataSourceTencentCloudCosBatchs(),
 			"tencentcloud_cos_bucket_inventorys":                        cos.DataSourceTencentCloudCosBucketInventorys(),
@@ -628,6 +674,7 @@ func Provider() *schema.Provider {
 			"tencentcloud_gaap_domain_error_page_infos":                 gaap.DataSourceTencentCloudGaapDomainErrorPageInfos(),
 			"tencentcloud_gaap_check_proxy_create":                      gaap.DataSourceTencentCloudGaapCheckProxyCreate(),
 			"tencentcloud_ssl_certificates":                             ssl.DataSourceTencentCloudSslCertificates(),
+			"tencentcloud_ssl_certificate_expiry":                       ssl.DataSourceTencentCloudSslCertificateExpiry(),
 			"tencentcloud_ssl_describe_certificate":                     ssl.DataSourceTencentCloudSslDescribeCertificate(),
 			"tencentcloud_ssl_describe_companies":                       ssl.DataSourceTencentCloudSslDescribeCompanies(),
 			"tencentcloud_ssl_describe_host_api_gateway_instance_list":  ssl.DataSourceTencentCloudSslDescribeHostApiGatewayInstanceList(),
@@ -662,8 +709,11 @@ func Provider() *schema.Provider {
 			"tencentcloud_user_info":                                    cam.DataSourceTencentCloudUserInfo(),
 			"tencentcloud_cam_sub_accounts":                             cam.DataSourceTencentCloudCamSubAccounts(),
 			"tencentcloud_cam_role_detail":                              cam.DataSourceTencentCloudCamRoleDetail(),
+			"tencentcloud_cam_policy_document":                          cam.DataSourceTencentCloudCamPolicyDocument(),
+			"tencentcloud_cam_policy_simulation":                        cam.DataSourceTencentCloudCamPolicySimulation(),
 			"tencentcloud_cdn_domains":                                  cdn.DataSourceTencentCloudCdnDomains(),
 			"tencentcloud_cdn_domain_verifier":                          cdn.DataSourceTencentCloudCdnDomainVerifyRecord(),
+			"tencentcloud_cdn_signed_url":                               cdn.DataSourceTencentCloudCdnSignedUrl(),
 			"tencentcloud_scf_functions":                                scf.DataSourceTencentCloudScfFunctions(),
 			"tencentcloud_scf_namespaces":                               scf.DataSourceTencentCloudScfNamespaces(),
 			"tencentcloud_scf_account_info":                             scf.DataSourceTencentCloudScfAccountInfo(),
@@ -911,6 +961,7 @@ func Provider() *schema.Provider {
 			"tencentcloud_dnspod_record_analytics":                      dnspod.DataSourceTencentCloudDnspodRecordAnalytics(),
 			"tencentcloud_dnspod_record_line_list":                      dnspod.DataSourceTencentCloudDnspodRecordLineList(),
 			"tencentcloud_dnspod_record_list":                           dnspod.DataSourceTencentCloudDnspodRecordList(),
+			"tencentcloud_dnspod_zone_file":                             dnspod.DataSourceTencentCloudDnspodZoneFile(),
 			"tencentcloud_dnspod_record_type":                           dnspod.DataSourceTencentCloudDnspodRecordType(),
 			"tencentcloud_subdomain_validate_status":                    dnspod.DataSourceTencentCloudSubdomainValidateStatus(),
 			"tencentcloud_tat_command":                                  tat.DataSourceTencentCloudTatCommand(),
@@ -1190,6 +1241,8 @@ func Provider() *schema.Provider {
 			"tencentcloud_vpc_classic_link_attachment":                                              vpc.ResourceTencentCloudVpcClassicLinkAttachment(),
 			"tencentcloud_vpc_dhcp_ip":                                                              vpc.ResourceTencentCloudVpcDhcpIp(),
 			"tencentcloud_vpc_ipv6_cidr_block":                                                      vpc.ResourceTencentCloudVpcIpv6CidrBlock(),
+			"tencentcloud_vpc_ipam_pool":                                                            vpc.ResourceTencentCloudVpcIpamPool(),
+			"tencentcloud_vpc_ipam_allocation":                                                      vpc.ResourceTencentCloudVpcIpamAllocation(),
 			"tencentcloud_vpc_ipv6_subnet_cidr_block":                                               vpc.ResourceTencentCloudVpcIpv6SubnetCidrBlock(),
 			"tencentcloud_vpc_ipv6_eni_address":                                                     vpc.ResourceTencentCloudVpcIpv6EniAddress(),
 			"tencentcloud_vpc_dhcp_associate_address":                                               vpc.ResourceTencentCloudVpcDhcpAssociateAddress(),
@@ -1270,12 +1323,14 @@ func Provider() *schema.Provider {
 			"tencentcloud_clb_instance":                                                             clb.ResourceTencentCloudClbInstance(),
 			"tencentcloud_clb_listener":                                                             clb.ResourceTencentCloudClbListener(),
 			"tencentcloud_clb_listener_rule":                                                        clb.ResourceTencentCloudClbListenerRule(),
+			"tencentcloud_clb_listener_rules":                                                       clb.ResourceTencentCloudClbListenerRules(),
 			"tencentcloud_clb_listener_default_domain":                                              clb.ResourceTencentCloudClbListenerDefaultDomain(),
 			"tencentcloud_clb_attachment":                                                           clb.ResourceTencentCloudClbServerAttachment(),
 			"tencentcloud_clb_redirection":                                                          clb.ResourceTencentCloudClbRedirection(),
 			"tencentcloud_clb_target_group":                                                         clb.ResourceTencentCloudClbTargetGroup(),
 			"tencentcloud_clb_target_group_instance_attachment":                                     clb.ResourceTencentCloudClbTGAttachmentInstance(),
 			"tencentcloud_clb_target_group_attachment":                                              clb.ResourceTencentCloudClbTargetGroupAttachment(),
+			"tencentcloud_clb_traffic_shift":                                                        clb.ResourceTencentCloudClbTrafficShift(),
 			"tencentcloud_clb_log_set":                                                              clb.ResourceTencentCloudClbLogSet(),
 			"tencentcloud_clb_log_topic":                                                            clb.ResourceTencentCloudClbLogTopic(),
 			"tencentcloud_clb_customized_config":                                                    clb.ResourceTencentCloudClbCustomizedConfig(),
@@ -1345,6 +1400,14 @@ func Provider() *schema.Provider {
 			"tencentcloud_cos_bucket_object":                                                        cos.ResourceTencentCloudCosBucketObject(),
 			"tencentcloud_cos_bucket_referer":                                                       cos.ResourceTencentCloudCosBucketReferer(),
 			"tencentcloud_cos_bucket_version":                                                       cos.ResourceTencentCloudCosBucketVersion(),
+			"tencentcloud_cos_bucket_object_lock":                                                   cos.ResourceTencentCloudCosBucketObjectLock(),
+			"tencentcloud_cos_bucket_lifecycle":                                                     cos.ResourceTencentCloudCosBucketLifecycle(),
+			"tencentcloud_cos_bucket_cors":                                                          cos.ResourceTencentCloudCosBucketCors(),
+			"tencentcloud_cos_bucket_website":                                                       cos.ResourceTencentCloudCosBucketWebsite(),
+			"tencentcloud_cos_bucket_replication":                                                   cos.ResourceTencentCloudCosBucketReplication(),
+			"tencentcloud_cos_bucket_logging":                                                       cos.ResourceTencentCloudCosBucketLogging(),
+			"tencentcloud_cos_bucket_encryption":                                                    cos.ResourceTencentCloudCosBucketEncryption(),
+			"tencentcloud_cos_bucket_origin":                                                        cos.ResourceTencentCloudCosBucketOrigin(),
 			"tencentcloud_cfs_file_system":                                                          cfs.ResourceTencentCloudCfsFileSystem(),
 			"tencentcloud_cfs_access_group":                                                         cfs.ResourceTencentCloudCfsAccessGroup(),
 			"tencentcloud_cfs_access_rule":                                                          cfs.ResourceTencentCloudCfsAccessRule(),
@@ -1430,6 +1493,7 @@ func Provider() *schema.Provider {
 			"tencentcloud_ssl_certificate":                                                          ssl.ResourceTencentCloudSslCertificate(),
 			"tencentcloud_ssl_pay_certificate":                                                      ssl.ResourceTencentCloudSSLInstance(),
 			"tencentcloud_ssl_free_certificate":                                                     ssl.ResourceTencentCloudSSLFreeCertificate(),
+			"tencentcloud_acme_certificate":                                                         ssl.ResourceTencentCloudAcmeCertificate(),
 			"tencentcloud_cam_role":                                                                 cam.ResourceTencentCloudCamRole(),
 			"tencentcloud_cam_role_by_name":                                                         cam.ResourceTencentCloudCamRoleByName(),
 			"tencentcloud_cam_user":                                                                 cam.ResourceTencentCloudCamUser(),
@@ -1483,6 +1547,7 @@ func Provider() *schema.Provider {
 			"tencentcloud_monitor_alarm_policy":                                                     monitor.ResourceTencentCloudMonitorAlarmPolicy(),
 			"tencentcloud_monitor_alarm_notice":                                                     monitor.ResourceTencentCloudMonitorAlarmNotice(),
 			"tencentcloud_monitor_alarm_policy_set_default":                                         monitor.ResourceTencentCloudMonitorAlarmPolicySetDefault(),
+			"tencentcloud_monitor_alarm_policy_set":                                                 monitor.ResourceTencentCloudMonitorAlarmPolicySet(),
 			"tencentcloud_monitor_tmp_instance":                                                     tmp.ResourceTencentCloudMonitorTmpInstance(),
 			"tencentcloud_monitor_tmp_cvm_agent":                                                    tmp.ResourceTencentCloudMonitorTmpCvmAgent(),
 			"tencentcloud_monitor_tmp_scrape_job":                                                   tmp.ResourceTencentCloudMonitorTmpScrapeJob(),
@@ -1703,6 +1768,7 @@ func Provider() *schema.Provider {
 			"tencentcloud_dnspod_domain_alias":                                                      dnspod.ResourceTencentCloudDnspodDomainAlias(),
 			"tencentcloud_dnspod_record":                                                            dnspod.ResourceTencentCloudDnspodRecord(),
 			"tencentcloud_dnspod_record_group":                                                      dnspod.ResourceTencentCloudDnspodRecordGroup(),
+			"tencentcloud_dnspod_zone_records":                                                      dnspod.ResourceTencentCloudDnspodZoneRecords(),
 			"tencentcloud_dnspod_modify_domain_owner_operation":                                     dnspod.ResourceTencentCloudDnspodModifyDomainOwnerOperation(),
 			"tencentcloud_dnspod_modify_record_group_operation":                                     dnspod.ResourceTencentCloudDnspodModifyRecordGroupOperation(),
 			"tencentcloud_dnspod_download_snapshot_operation":                                       dnspod.ResourceTencentCloudDnspodDownloadSnapshotOperation(),
@@ -2039,6 +2105,7 @@ func Provider() *schema.Provider {
 			"tencentcloud_cvm_renew_instance":                                                       cvm.ResourceTencentCloudCvmRenewInstance(),
 			"tencentcloud_cvm_export_images":                                                        cvm.ResourceTencentCloudCvmExportImages(),
 			"tencentcloud_cvm_image_share_permission":                                               cvm.ResourceTencentCloudCvmImageSharePermission(),
+			"tencentcloud_cvm_image_builder":                                                        cvm.ResourceTencentCloudCvmImageBuilder(),
 			"tencentcloud_cvm_import_image":                                                         cvm.ResourceTencentCloudCvmImportImage(),
 			"tencentcloud_cvm_renew_host":                                                           cvm.ResourceTencentCloudCvmRenewHost(),
 			"tencentcloud_cvm_program_fpga_image":                                                   cvm.ResourceTencentCloudCvmProgramFpgaImage(),
@@ -2224,6 +2291,12 @@ func Provider() *schema.Provider {
 
 		ConfigureFunc: providerConfigure,
 	}
+
+	for _, dataSource := range provider.DataSourcesMap {
+		tccommon.AddResultOutputFormat(dataSource)
+	}
+
+	return provider
 }
 
 func providerConfigure(d *schema.ResourceData) (interface{}, error) {
@@ -2312,6 +2385,18 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 		CosDomain: cosDomain,
 	}
 
+	if v, ok := helper.InterfacesHeadMap(d, "security_group_guardrails"); ok {
+		guardrails := &tccommon.SecurityGroupGuardrails{
+			Mode:                v["mode"].(string),
+			CheckShadowedRules:  v["check_shadowed_rules"].(bool),
+			CheckDuplicateRules: v["check_duplicate_rules"].(bool),
+		}
+		for _, port := range v["world_open_ports"].([]interface{}) {
+			guardrails.WorldOpenPorts = append(guardrails.WorldOpenPorts, int64(port.(int)))
+		}
+		tcClient.securityGroupGuardrails = guardrails
+	}
+
 	if v, ok := d.GetOk("allowed_account_ids"); ok && v.(*schema.Set).Len() > 0 {
 		for _, v := range v.(*schema.Set).List() {
 			allowedAccountIds = append(allowedAccountIds, v.(string))
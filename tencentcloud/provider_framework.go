@@ -0,0 +1,244 @@
+package tencentcloud
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	fwschema "github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-mux/tf5muxserver"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/functions"
+)
+
+var (
+	_ provider.Provider              = &frameworkProvider{}
+	_ provider.ProviderWithFunctions = &frameworkProvider{}
+)
+
+// frameworkProvider serves the features only available in the plugin-framework, such as provider-defined functions.
+// Resources and data sources stay in the SDK provider, the two providers are combined by the mux server.
+type frameworkProvider struct {
+	providerSchema fwschema.Schema
+}
+
+// NewFrameworkProvider returns a plugin-framework provider sharing the provider schema of sdkProvider,
+// as the mux server requires all the combined providers to declare identical provider schemas.
+func NewFrameworkProvider(sdkProvider *schema.Provider) (provider.Provider, error) {
+	resp, err := schema.NewGRPCProviderServer(sdkProvider).GetProviderSchema(context.Background(), &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		return nil, err
+	}
+	for _, diag := range resp.Diagnostics {
+		if diag.Severity == tfprotov5.DiagnosticSeverityError {
+			return nil, fmt.Errorf("get provider schema failed: %s: %s", diag.Summary, diag.Detail)
+		}
+	}
+
+	attributes, blocks, err := convertProtoSchemaBlock(resp.Provider.Block)
+	if err != nil {
+		return nil, err
+	}
+
+	return &frameworkProvider{
+		providerSchema: fwschema.Schema{
+			Attributes: attributes,
+			Blocks:     blocks,
+		},
+	}, nil
+}
+
+// ProtoV5ProviderServerFactory returns the factory of the mux server combining the SDK provider and the framework provider.
+func ProtoV5ProviderServerFactory(ctx context.Context) (func() tfprotov5.ProviderServer, error) {
+	sdkProvider := Provider()
+	fwProvider, err := NewFrameworkProvider(sdkProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	servers := []func() tfprotov5.ProviderServer{
+		sdkProvider.GRPCProvider,
+		providerserver.NewProtocol5(fwProvider),
+	}
+
+	muxServer, err := tf5muxserver.NewMuxServer(ctx, servers...)
+	if err != nil {
+		return nil, err
+	}
+
+	return muxServer.ProviderServer, nil
+}
+
+func (p *frameworkProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
+	resp.TypeName = "tencentcloud"
+}
+
+func (p *frameworkProvider) Schema(ctx context.Context, req provider.SchemaRequest, resp *provider.SchemaResponse) {
+	resp.Schema = p.providerSchema
+}
+
+// Configure does nothing, the provider-defined functions never call the cloud API.
+func (p *frameworkProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+}
+
+func (p *frameworkProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
+	return nil
+}
+
+func (p *frameworkProvider) Resources(ctx context.Context) []func() resource.Resource {
+	return nil
+}
+
+func (p *frameworkProvider) Functions(ctx context.Context) []func() function.Function {
+	return functions.Functions()
+}
+
+func convertProtoSchemaBlock(block *tfprotov5.SchemaBlock) (map[string]fwschema.Attribute, map[string]fwschema.Block, error) {
+	attributes := make(map[string]fwschema.Attribute, len(block.Attributes))
+	for _, v := range block.Attributes {
+		attribute, err := convertProtoSchemaAttribute(v)
+		if err != nil {
+			return nil, nil, err
+		}
+		attributes[v.Name] = attribute
+	}
+
+	blocks := make(map[string]fwschema.Block, len(block.BlockTypes))
+	for _, v := range block.BlockTypes {
+		nestedAttributes, nestedBlocks, err := convertProtoSchemaBlock(v.Block)
+		if err != nil {
+			return nil, nil, err
+		}
+		nestedObject := fwschema.NestedBlockObject{
+			Attributes: nestedAttributes,
+			Blocks:     nestedBlocks,
+		}
+		deprecationMessage := ""
+		if v.Block.Deprecated {
+			deprecationMessage = fmt.Sprintf("`%s` is deprecated.", v.TypeName)
+		}
+
+		switch v.Nesting {
+		case tfprotov5.SchemaNestedBlockNestingModeList:
+			blocks[v.TypeName] = fwschema.ListNestedBlock{
+				NestedObject:       nestedObject,
+				Description:        v.Block.Description,
+				DeprecationMessage: deprecationMessage,
+			}
+		case tfprotov5.SchemaNestedBlockNestingModeSet:
+			blocks[v.TypeName] = fwschema.SetNestedBlock{
+				NestedObject:       nestedObject,
+				Description:        v.Block.Description,
+				DeprecationMessage: deprecationMessage,
+			}
+		case tfprotov5.SchemaNestedBlockNestingModeSingle:
+			blocks[v.TypeName] = fwschema.SingleNestedBlock{
+				Attributes:         nestedAttributes,
+				Blocks:             nestedBlocks,
+				Description:        v.Block.Description,
+				DeprecationMessage: deprecationMessage,
+			}
+		default:
+			return nil, nil, fmt.Errorf("unsupported nesting mode %s of block `%s`", v.Nesting, v.TypeName)
+		}
+	}
+
+	return attributes, blocks, nil
+}
+
+func convertProtoSchemaAttribute(v *tfprotov5.SchemaAttribute) (fwschema.Attribute, error) {
+	deprecationMessage := ""
+	if v.Deprecated {
+		deprecationMessage = fmt.Sprintf("`%s` is deprecated.", v.Name)
+	}
+
+	switch {
+	case v.Type.Is(tftypes.String):
+		return fwschema.StringAttribute{
+			Required:           v.Required,
+			Optional:           v.Optional,
+			Sensitive:          v.Sensitive,
+			Description:        v.Description,
+			DeprecationMessage: deprecationMessage,
+		}, nil
+	case v.Type.Is(tftypes.Number):
+		return fwschema.NumberAttribute{
+			Required:           v.Required,
+			Optional:           v.Optional,
+			Sensitive:          v.Sensitive,
+			Description:        v.Description,
+			DeprecationMessage: deprecationMessage,
+		}, nil
+	case v.Type.Is(tftypes.Bool):
+		return fwschema.BoolAttribute{
+			Required:           v.Required,
+			Optional:           v.Optional,
+			Sensitive:          v.Sensitive,
+			Description:        v.Description,
+			DeprecationMessage: deprecationMessage,
+		}, nil
+	case v.Type.Is(tftypes.List{}):
+		elemType, err := convertProtoElementType(v.Type.(tftypes.List).ElementType)
+		if err != nil {
+			return nil, fmt.Errorf("attribute `%s`: %s", v.Name, err)
+		}
+		return fwschema.ListAttribute{
+			ElementType:        elemType,
+			Required:           v.Required,
+			Optional:           v.Optional,
+			Sensitive:          v.Sensitive,
+			Description:        v.Description,
+			DeprecationMessage: deprecationMessage,
+		}, nil
+	case v.Type.Is(tftypes.Set{}):
+		elemType, err := convertProtoElementType(v.Type.(tftypes.Set).ElementType)
+		if err != nil {
+			return nil, fmt.Errorf("attribute `%s`: %s", v.Name, err)
+		}
+		return fwschema.SetAttribute{
+			ElementType:        elemType,
+			Required:           v.Required,
+			Optional:           v.Optional,
+			Sensitive:          v.Sensitive,
+			Description:        v.Description,
+			DeprecationMessage: deprecationMessage,
+		}, nil
+	case v.Type.Is(tftypes.Map{}):
+		elemType, err := convertProtoElementType(v.Type.(tftypes.Map).ElementType)
+		if err != nil {
+			return nil, fmt.Errorf("attribute `%s`: %s", v.Name, err)
+		}
+		return fwschema.MapAttribute{
+			ElementType:        elemType,
+			Required:           v.Required,
+			Optional:           v.Optional,
+			Sensitive:          v.Sensitive,
+			Description:        v.Description,
+			DeprecationMessage: deprecationMessage,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported type %s of attribute `%s`", v.Type, v.Name)
+}
+
+func convertProtoElementType(t tftypes.Type) (attr.Type, error) {
+	switch {
+	case t.Is(tftypes.String):
+		return types.StringType, nil
+	case t.Is(tftypes.Number):
+		return types.NumberType, nil
+	case t.Is(tftypes.Bool):
+		return types.BoolType, nil
+	}
+
+	return nil, fmt.Errorf("unsupported element type %s", t)
+}
@@ -1,10 +1,13 @@
 package tencentcloud
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+
 	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/connectivity"
 )
 
@@ -25,3 +28,30 @@ func TestProvider(t *testing.T) {
 func TestProviderImpl(t *testing.T) {
 	var _ = Provider()
 }
+
+func TestProviderMuxServer(t *testing.T) {
+	ctx := context.Background()
+	serverFactory, err := ProtoV5ProviderServerFactory(ctx)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	server := serverFactory()
+	schemaResp, err := server.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	for _, diag := range schemaResp.Diagnostics {
+		if diag.Severity == tfprotov5.DiagnosticSeverityError {
+			t.Fatalf("get provider schema failed: %s: %s", diag.Summary, diag.Detail)
+		}
+	}
+
+	functionsResp, err := server.GetFunctions(ctx, &tfprotov5.GetFunctionsRequest{})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if len(functionsResp.Functions) == 0 {
+		t.Fatalf("provider-defined functions are missing")
+	}
+}
@@ -1104,7 +1104,7 @@ func dataSourceTencentCloudApiGatewayApiAppApiRead(d *schema.ResourceData, meta
 	d.SetId(strings.Join([]string{service_id, api_id, api_region}, tccommon.FILED_SP))
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), d); e != nil {
+		if e := tccommon.WriteToFile(output.(string), d, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
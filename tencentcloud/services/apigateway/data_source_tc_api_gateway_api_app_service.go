@@ -520,7 +520,7 @@ func dataSourceTencentCloudAPIGatewayApiAppServicesRead(d *schema.ResourceData,
 	d.SetId(serviceId)
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), d); e != nil {
+		if e := tccommon.WriteToFile(output.(string), d, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
@@ -152,7 +152,7 @@ func dataSourceTencentCloudAPIGatewayAPIAppsRead(d *schema.ResourceData, meta in
 	d.SetId(helper.DataResourceIdsHash(ids))
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), apiAppList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), apiAppList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
@@ -100,7 +100,7 @@ func dataSourceTencentCloudAPIGatewayAPIDocsRead(d *schema.ResourceData, meta in
 	d.SetId(helper.DataResourceIdsHash(ids))
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), apiDocList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), apiDocList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
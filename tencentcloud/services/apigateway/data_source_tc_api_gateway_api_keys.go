@@ -119,7 +119,7 @@ func dataSourceTencentCloudAPIGatewayAPIKeysRead(d *schema.ResourceData, meta in
 	d.SetId(strings.Join([]string{secretName, accessKeyId}, tccommon.FILED_SP))
 
 	if output, ok := d.GetOk("result_output_file"); ok && output.(string) != "" {
-		return tccommon.WriteToFile(output.(string), list)
+		return tccommon.WriteToFile(output.(string), list, tccommon.GetResultOutputOption(d))
 	}
 	return nil
 }
@@ -166,7 +166,7 @@ func dataSourceTencentCloudApiGatewayApiPluginsRead(d *schema.ResourceData, meta
 	d.SetId(helper.DataResourceIdsHash(ids))
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), d); e != nil {
+		if e := tccommon.WriteToFile(output.(string), d, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
@@ -369,7 +369,7 @@ func dataSourceTencentCloudAPIGatewayAPIsRead(d *schema.ResourceData, meta inter
 	d.SetId(strings.Join([]string{apiName, apiId}, tccommon.FILED_SP))
 
 	if output, ok := d.GetOk("result_output_file"); ok && output.(string) != "" {
-		return tccommon.WriteToFile(output.(string), list)
+		return tccommon.WriteToFile(output.(string), list, tccommon.GetResultOutputOption(d))
 	}
 	return nil
 }
@@ -224,7 +224,7 @@ func dataSourceTencentCloudApiGatewayBindApiAppsStatusRead(d *schema.ResourceDat
 	d.SetId(helper.DataResourceIdsHash(ids))
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), d); e != nil {
+		if e := tccommon.WriteToFile(output.(string), d, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
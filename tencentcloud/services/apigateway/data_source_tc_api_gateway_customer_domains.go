@@ -152,7 +152,7 @@ func dataSourceTencentCloudAPIGatewayCustomerDomainRead(d *schema.ResourceData,
 	d.SetId(serviceId)
 
 	if output, ok := d.GetOk("result_output_file"); ok && output.(string) != "" {
-		return tccommon.WriteToFile(output.(string), list)
+		return tccommon.WriteToFile(output.(string), list, tccommon.GetResultOutputOption(d))
 	}
 	return nil
 }
@@ -121,7 +121,7 @@ func dataSourceTencentCloudApiGatewayServiceEnvironmentListRead(d *schema.Resour
 	d.SetId(serviceId)
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), d); e != nil {
+		if e := tccommon.WriteToFile(output.(string), d, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
@@ -267,7 +267,7 @@ func dataSourceTencentCloudAPIGatewayServicesRead(d *schema.ResourceData, meta i
 	d.SetId(strings.Join([]string{serviceName, serviceId}, tccommon.FILED_SP))
 
 	if output, ok := d.GetOk("result_output_file"); ok && output.(string) != "" {
-		return tccommon.WriteToFile(output.(string), list)
+		return tccommon.WriteToFile(output.(string), list, tccommon.GetResultOutputOption(d))
 	}
 	return nil
 }
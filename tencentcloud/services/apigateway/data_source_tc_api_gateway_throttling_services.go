@@ -157,7 +157,7 @@ func dataSourceTencentCloudAPIGatewayThrottlingServicesRead(d *schema.ResourceDa
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if err := tccommon.WriteToFile(output.(string), resultLists); err != nil {
+		if err := tccommon.WriteToFile(output.(string), resultLists, tccommon.GetResultOutputOption(d)); err != nil {
 			return err
 		}
 	}
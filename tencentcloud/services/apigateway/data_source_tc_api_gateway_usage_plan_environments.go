@@ -139,7 +139,7 @@ func dataSourceTencentCloudUsagePlanEnvironmentRead(d *schema.ResourceData, meta
 	d.SetId(strings.Join([]string{usagePlanId, bindType}, tccommon.FILED_SP))
 
 	if output, ok := d.GetOk("result_output_file"); ok && output.(string) != "" {
-		return tccommon.WriteToFile(output.(string), list)
+		return tccommon.WriteToFile(output.(string), list, tccommon.GetResultOutputOption(d))
 	}
 	return nil
 }
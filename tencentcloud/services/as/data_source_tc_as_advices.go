@@ -156,7 +156,7 @@ func dataSourceTencentCloudAsAdvicesRead(d *schema.ResourceData, meta interface{
 	d.SetId(helper.DataResourceIdsHash(ids))
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), tmpList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), tmpList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
@@ -98,7 +98,7 @@ func dataSourceTencentCloudAsLimitsRead(d *schema.ResourceData, meta interface{}
 	d.SetId(helper.DataResourceIdsHash(ids))
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), asLimitMap); e != nil {
+		if e := tccommon.WriteToFile(output.(string), asLimitMap, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
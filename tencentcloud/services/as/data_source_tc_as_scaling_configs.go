@@ -237,7 +237,7 @@ func dataSourceTencentCloudAsScalingConfigRead(d *schema.ResourceData, meta inte
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if err = tccommon.WriteToFile(output.(string), configurationList); err != nil {
+		if err = tccommon.WriteToFile(output.(string), configurationList, tccommon.GetResultOutputOption(d)); err != nil {
 			return err
 		}
 	}
@@ -164,7 +164,7 @@ func dataSourceTencentCloudAsScalingPolicyRead(d *schema.ResourceData, meta inte
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if err = tccommon.WriteToFile(output.(string), scalingPolicyList); err != nil {
+		if err = tccommon.WriteToFile(output.(string), scalingPolicyList, tccommon.GetResultOutputOption(d)); err != nil {
 			return err
 		}
 	}
@@ -87,7 +87,7 @@ func dataSourceTencentCloudAuditCosRegionsRead(d *schema.ResourceData, meta inte
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), regionList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), regionList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
@@ -93,7 +93,7 @@ func dataSourceTencentCloudAuditKeyAliasRead(d *schema.ResourceData, meta interf
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), keyList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), keyList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
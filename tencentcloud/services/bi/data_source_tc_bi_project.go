@@ -387,7 +387,7 @@ func dataSourceTencentCloudBiProjectRead(d *schema.ResourceData, meta interface{
 	d.SetId(helper.DataResourceIdsHash(ids))
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), listList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), listList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
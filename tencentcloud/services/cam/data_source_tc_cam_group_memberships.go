@@ -103,7 +103,7 @@ func dataSourceTencentCloudCamGroupMembershipsRead(d *schema.ResourceData, meta
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), groupList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), groupList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
@@ -151,7 +151,7 @@ func dataSourceTencentCloudCamGroupPolicyAttachmentsRead(d *schema.ResourceData,
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), policyOfGroupList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), policyOfGroupList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
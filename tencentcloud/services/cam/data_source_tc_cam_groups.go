@@ -133,7 +133,7 @@ func dataSourceTencentCloudCamGroupsRead(d *schema.ResourceData, meta interface{
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), groupList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), groupList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
@@ -132,7 +132,7 @@ func dataSourceTencentCloudCamOidcConfigRead(d *schema.ResourceData, meta interf
 	d.SetId(name)
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), result); e != nil {
+		if e := tccommon.WriteToFile(output.(string), result, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
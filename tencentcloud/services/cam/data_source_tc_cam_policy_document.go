@@ -0,0 +1,477 @@
+package cam
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	tccommon "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/common"
+	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/internal/helper"
+)
+
+const CAM_POLICY_DOCUMENT_VERSION = "2.0"
+
+var CAM_POLICY_EFFECTS = []string{"allow", "deny"}
+
+const (
+	CAM_POLICY_DOCUMENT_STYLE_CAM = "cam"
+	CAM_POLICY_DOCUMENT_STYLE_COS = "cos"
+)
+
+var CAM_POLICY_PRINCIPAL_TYPES = []string{"qcs", "service", "federated"}
+
+// CAM_POLICY_CONDITION_OPERATORS are the condition operators of CAM, each of them can be suffixed with `_if_exist`
+// and prefixed with `for_all_value:` or `for_any_value:` for multi-valued keys.
+var CAM_POLICY_CONDITION_OPERATORS = []string{
+	"string_equal", "string_not_equal", "string_equal_ignore_case", "string_not_equal_ignore_case",
+	"string_like", "string_not_like",
+	"numeric_equal", "numeric_not_equal", "numeric_greater_than", "numeric_greater_than_equal",
+	"numeric_less_than", "numeric_less_than_equal",
+	"date_equal", "date_not_equal", "date_greater_than", "date_greater_than_equal",
+	"date_less_than", "date_less_than_equal",
+	"ip_equal", "ip_not_equal",
+	"bool_equal", "null_equal",
+}
+
+var camPolicyActionRegexp = regexp.MustCompile(`^(name/)?[a-zA-Z0-9_-]+:[a-zA-Z0-9_*]+$`)
+
+func DataSourceTencentCloudCamPolicyDocument() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceTencentCloudCamPolicyDocumentRead,
+
+		Schema: map[string]*schema.Schema{
+			"version": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      CAM_POLICY_DOCUMENT_VERSION,
+				ValidateFunc: validation.StringInSlice([]string{CAM_POLICY_DOCUMENT_VERSION}, false),
+				Description:  "Version of the policy syntax. Only `2.0` is supported now.",
+			},
+			"style": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      CAM_POLICY_DOCUMENT_STYLE_CAM,
+				ValidateFunc: validation.StringInSlice([]string{CAM_POLICY_DOCUMENT_STYLE_CAM, CAM_POLICY_DOCUMENT_STYLE_COS}, false),
+				Description: "Key style of the exported document, which follows the copy returned by the server. Valid values: `cam`, `cos`. Default is `cam`. " +
+					"`cam` exports lower case keys for CAM policies and roles, `cos` exports capitalized keys such as `Statement` for COS bucket policies.",
+			},
+			"source_policy_documents": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Description: "Policy documents in JSON merged into the exported document. Statements of the source documents must have unique `sid`, " +
+					"and are replaced by the `statement` blocks of the same `sid`.",
+			},
+			"override_policy_documents": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Description: "Policy documents in JSON merged into the exported document in order, statements of them replace the statements " +
+					"of the same `sid`, statements without `sid` are appended.",
+			},
+			"statement": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Statements of the policy.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"sid": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Identifier of the statement, used to replace statements when merging documents.",
+						},
+						"effect": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "allow",
+							ValidateFunc: validation.StringInSlice(CAM_POLICY_EFFECTS, false),
+							Description:  "Whether the statement allows or denies the actions. Valid values: `allow`, `deny`. Default is `allow`.",
+						},
+						"action": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Actions of the statement, in the format of `service:Action` or `name/service:Action`, such as `cos:GetObject` and `cvm:*`, or `*` for all the actions.",
+						},
+						"resource": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+							Description: "Resources of the statement, in the six-segment format of `qcs:project_id:service_type:region:account:resource`, " +
+								"such as `qcs::cos:ap-guangzhou:uid/1250000000:examplebucket-1250000000/*`, or `*` for all the resources.",
+						},
+						"principal": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "Principals of the statement, used by the policies of roles and resources such as COS buckets.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"type": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: validation.StringInSlice(CAM_POLICY_PRINCIPAL_TYPES, false),
+										Description:  "Type of the principal. Valid values: `qcs`, `service`, `federated`.",
+									},
+									"identifiers": {
+										Type:     schema.TypeList,
+										Required: true,
+										MinItems: 1,
+										Elem:     &schema.Schema{Type: schema.TypeString},
+										Description: "Identifiers of the principal, such as `qcs::cam::uin/100000000001:root` for `qcs` " +
+											"and `cvm.qcloud.com` for `service`.",
+									},
+								},
+							},
+						},
+						"condition": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "Conditions of the statement, all of them must be met for the statement to take effect.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"test": {
+										Type:     schema.TypeString,
+										Required: true,
+										ValidateFunc: func(v interface{}, k string) (ws []string, errs []error) {
+											if !isCamPolicyConditionOperator(v.(string)) {
+												errs = append(errs, fmt.Errorf("%s: invalid condition operator `%s`", k, v.(string)))
+											}
+											return
+										},
+										Description: "Condition operator, such as `string_equal`, `ip_equal` and `date_less_than`, " +
+											"optionally suffixed with `_if_exist` and prefixed with `for_all_value:` or `for_any_value:`.",
+									},
+									"variable": {
+										Type:        schema.TypeString,
+										Required:    true,
+										Description: "Condition key, such as `qcs:ip` and `qcs:resource_tag`.",
+									},
+									"values": {
+										Type:        schema.TypeList,
+										Required:    true,
+										MinItems:    1,
+										Elem:        &schema.Schema{Type: schema.TypeString},
+										Description: "Values of the condition key.",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"json": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Policy document in canonical JSON, which can be used as `document` of `tencentcloud_cam_policy` and `tencentcloud_cam_role`, or `policy` of `tencentcloud_cos_bucket_policy`.",
+			},
+			"result_output_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Used to save results.",
+			},
+		},
+	}
+}
+
+func dataSourceTencentCloudCamPolicyDocumentRead(d *schema.ResourceData, meta interface{}) error {
+	defer tccommon.LogElapsed("data_source.tencentcloud_cam_policy_document.read")()
+
+	document := &camPolicyDocument{Version: d.Get("version").(string)}
+
+	if v, ok := d.GetOk("source_policy_documents"); ok {
+		sids := make(map[string]bool)
+		for i, item := range v.([]interface{}) {
+			source, err := parseCamPolicyDocument(item.(string))
+			if err != nil {
+				return fmt.Errorf("source_policy_documents.%d: %s", i, err.Error())
+			}
+			for _, statement := range source.Statement {
+				if statement.Sid != "" {
+					if sids[statement.Sid] {
+						return fmt.Errorf("source_policy_documents.%d: duplicated statement sid `%s`", i, statement.Sid)
+					}
+					sids[statement.Sid] = true
+				}
+			}
+			document.Statement = append(document.Statement, source.Statement...)
+		}
+	}
+
+	statements := make([]*camPolicyStatement, 0)
+	if v, ok := d.GetOk("statement"); ok {
+		sids := make(map[string]bool)
+		for i, item := range v.([]interface{}) {
+			statement := expandCamPolicyStatement(item.(map[string]interface{}))
+			if statement.Sid != "" {
+				if sids[statement.Sid] {
+					return fmt.Errorf("statement.%d: duplicated statement sid `%s`", i, statement.Sid)
+				}
+				sids[statement.Sid] = true
+			}
+			statements = append(statements, statement)
+		}
+	}
+	document.merge(statements)
+
+	if v, ok := d.GetOk("override_policy_documents"); ok {
+		for i, item := range v.([]interface{}) {
+			override, err := parseCamPolicyDocument(item.(string))
+			if err != nil {
+				return fmt.Errorf("override_policy_documents.%d: %s", i, err.Error())
+			}
+			document.merge(override.Statement)
+		}
+	}
+
+	if len(document.Statement) == 0 {
+		return fmt.Errorf("policy document has no statement, set `statement` or `source_policy_documents`")
+	}
+
+	for i, statement := range document.Statement {
+		if err := statement.validate(); err != nil {
+			return fmt.Errorf("statement %d of the merged document: %s", i, err.Error())
+		}
+	}
+
+	content, err := document.marshal(d.Get("style").(string))
+	if err != nil {
+		return err
+	}
+
+	d.SetId(helper.DataResourceIdHash(content))
+	_ = d.Set("json", content)
+
+	output, ok := d.GetOk("result_output_file")
+	if ok && output.(string) != "" {
+		if e := tccommon.WriteToFile(output.(string), content, tccommon.GetResultOutputOption(d)); e != nil {
+			return e
+		}
+	}
+
+	return nil
+}
+
+type camPolicyDocument struct {
+	Version   string                `json:"version"`
+	Statement []*camPolicyStatement `json:"statement"`
+}
+
+// camPolicyStatement has the fields in the order of the canonical document, all the values are arrays
+// as returned by CAM, so the document is not changed by the server.
+type camPolicyStatement struct {
+	Sid       string                                `json:"sid,omitempty"`
+	Effect    string                                `json:"effect"`
+	Action    camPolicyValues                       `json:"action,omitempty"`
+	Resource  camPolicyValues                       `json:"resource,omitempty"`
+	Condition map[string]map[string]camPolicyValues `json:"condition,omitempty"`
+	Principal map[string]camPolicyValues            `json:"principal,omitempty"`
+}
+
+// camPolicyValues accepts both a string and an array of strings in the source documents.
+type camPolicyValues []string
+
+func (v *camPolicyValues) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err == nil {
+		*v = camPolicyValues{value}
+		return nil
+	}
+
+	var values []string
+	if err := json.Unmarshal(data, &values); err != nil {
+		return fmt.Errorf("value must be a string or an array of strings: %s", string(data))
+	}
+	*v = values
+	return nil
+}
+
+func parseCamPolicyDocument(content string) (*camPolicyDocument, error) {
+	document := &camPolicyDocument{}
+	if err := json.Unmarshal([]byte(content), document); err != nil {
+		return nil, fmt.Errorf("invalid policy document: %s", err.Error())
+	}
+	if document.Version != "" && document.Version != CAM_POLICY_DOCUMENT_VERSION {
+		return nil, fmt.Errorf("unsupported policy version `%s`", document.Version)
+	}
+	for _, statement := range document.Statement {
+		statement.Effect = strings.ToLower(statement.Effect)
+	}
+	return document, nil
+}
+
+func expandCamPolicyStatement(m map[string]interface{}) *camPolicyStatement {
+	statement := &camPolicyStatement{
+		Sid:      m["sid"].(string),
+		Effect:   m["effect"].(string),
+		Action:   helper.InterfacesStrings(m["action"].([]interface{})),
+		Resource: helper.InterfacesStrings(m["resource"].([]interface{})),
+	}
+
+	for _, item := range m["principal"].([]interface{}) {
+		principal := item.(map[string]interface{})
+		if statement.Principal == nil {
+			statement.Principal = make(map[string]camPolicyValues)
+		}
+		principalType := principal["type"].(string)
+		statement.Principal[principalType] = appendCamPolicyValues(statement.Principal[principalType],
+			helper.InterfacesStrings(principal["identifiers"].([]interface{})))
+	}
+
+	for _, item := range m["condition"].([]interface{}) {
+		condition := item.(map[string]interface{})
+		if statement.Condition == nil {
+			statement.Condition = make(map[string]map[string]camPolicyValues)
+		}
+		test, variable := condition["test"].(string), condition["variable"].(string)
+		if statement.Condition[test] == nil {
+			statement.Condition[test] = make(map[string]camPolicyValues)
+		}
+		statement.Condition[test][variable] = appendCamPolicyValues(statement.Condition[test][variable],
+			helper.InterfacesStrings(condition["values"].([]interface{})))
+	}
+
+	return statement
+}
+
+// appendCamPolicyValues appends values in order and drops the duplicated ones.
+func appendCamPolicyValues(values camPolicyValues, items []string) camPolicyValues {
+	for _, item := range items {
+		exists := false
+		for _, value := range values {
+			if value == item {
+				exists = true
+				break
+			}
+		}
+		if !exists {
+			values = append(values, item)
+		}
+	}
+	return values
+}
+
+// merge replaces the statements of the same sid in place, and appends the others.
+func (document *camPolicyDocument) merge(statements []*camPolicyStatement) {
+	for _, statement := range statements {
+		replaced := false
+		if statement.Sid != "" {
+			for i, old := range document.Statement {
+				if old.Sid == statement.Sid {
+					document.Statement[i] = statement
+					replaced = true
+					break
+				}
+			}
+		}
+		if !replaced {
+			document.Statement = append(document.Statement, statement)
+		}
+	}
+}
+
+// cosPolicyStatement is camPolicyStatement with the capitalized keys returned by COS.
+type cosPolicyStatement struct {
+	Sid       string                                `json:"Sid,omitempty"`
+	Effect    string                                `json:"Effect"`
+	Action    camPolicyValues                       `json:"Action,omitempty"`
+	Resource  camPolicyValues                       `json:"Resource,omitempty"`
+	Condition map[string]map[string]camPolicyValues `json:"Condition,omitempty"`
+	Principal map[string]camPolicyValues            `json:"Principal,omitempty"`
+}
+
+type cosPolicyDocument struct {
+	Version   string                `json:"version"`
+	Statement []*cosPolicyStatement `json:"Statement"`
+}
+
+func (document *camPolicyDocument) marshal(style string) (string, error) {
+	if document.Version == "" {
+		document.Version = CAM_POLICY_DOCUMENT_VERSION
+	}
+
+	var value interface{} = document
+	if style == CAM_POLICY_DOCUMENT_STYLE_COS {
+		cosDocument := &cosPolicyDocument{Version: document.Version}
+		for _, statement := range document.Statement {
+			cosStatement := cosPolicyStatement(*statement)
+			cosDocument.Statement = append(cosDocument.Statement, &cosStatement)
+		}
+		value = cosDocument
+	}
+
+	return marshalCamPolicyJson(value)
+}
+
+// marshalCamPolicyJson encodes value in compact JSON, and keeps `/` and `&` as they are,
+// which are escaped by neither CAM nor COS.
+func marshalCamPolicyJson(value interface{}) (string, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return "", fmt.Errorf("encode policy document error, reason: %s", err.Error())
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+func (statement *camPolicyStatement) validate() error {
+	if !tccommon.IsContains(CAM_POLICY_EFFECTS, statement.Effect) {
+		return fmt.Errorf("invalid effect `%s`, valid values are `allow` and `deny`", statement.Effect)
+	}
+	if len(statement.Action) == 0 {
+		return fmt.Errorf("action can not be empty")
+	}
+	for _, action := range statement.Action {
+		if action != "*" && !camPolicyActionRegexp.MatchString(action) {
+			return fmt.Errorf("invalid action `%s`, it must be in the format of `service:Action`", action)
+		}
+	}
+	for _, resource := range statement.Resource {
+		if err := validateCamPolicyResource(resource); err != nil {
+			return err
+		}
+	}
+	for principalType, identifiers := range statement.Principal {
+		if !tccommon.IsContains(CAM_POLICY_PRINCIPAL_TYPES, principalType) {
+			return fmt.Errorf("invalid principal type `%s`, valid values are `%s`", principalType, strings.Join(CAM_POLICY_PRINCIPAL_TYPES, "`, `"))
+		}
+		if principalType != "qcs" {
+			continue
+		}
+		for _, identifier := range identifiers {
+			if err := validateCamPolicyResource(identifier); err != nil {
+				return fmt.Errorf("invalid principal: %s", err.Error())
+			}
+		}
+	}
+	for test := range statement.Condition {
+		if !isCamPolicyConditionOperator(test) {
+			return fmt.Errorf("invalid condition operator `%s`", test)
+		}
+	}
+	return nil
+}
+
+// validateCamPolicyResource checks the six-segment description `qcs:project_id:service_type:region:account:resource`.
+func validateCamPolicyResource(resource string) error {
+	if resource == "*" {
+		return nil
+	}
+	segments := strings.SplitN(resource, ":", 6)
+	if len(segments) != 6 || segments[0] != "qcs" || segments[5] == "" {
+		return fmt.Errorf("invalid resource `%s`, it must be in the format of `qcs:project_id:service_type:region:account:resource`", resource)
+	}
+	return nil
+}
+
+func isCamPolicyConditionOperator(test string) bool {
+	test = strings.TrimPrefix(test, "for_all_value:")
+	test = strings.TrimPrefix(test, "for_any_value:")
+	test = strings.TrimSuffix(test, "_if_exist")
+	return tccommon.IsContains(CAM_POLICY_CONDITION_OPERATORS, test)
+}
@@ -0,0 +1,198 @@
+package cam
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestCamPolicyDocumentRead(t *testing.T) {
+	allowGet := map[string]interface{}{"sid": "get", "action": []interface{}{"cos:GetObject"}, "resource": []interface{}{"*"}}
+	sources := []interface{}{
+		`{"version":"2.0","statement":[{"sid":"get","effect":"allow","action":"cos:HeadObject","resource":"*"}]}`,
+		`{"statement":[{"sid":"put","effect":"Deny","action":["cos:PutObject"],"resource":["*"]}]}`,
+	}
+
+	cases := []struct {
+		name string
+		raw  map[string]interface{}
+		want string
+		err  string
+	}{
+		{
+			name: "statement",
+			raw: map[string]interface{}{"statement": []interface{}{
+				map[string]interface{}{
+					"action":   []interface{}{"cos:GetObject", "cos:HeadObject"},
+					"resource": []interface{}{"qcs::cos:ap-guangzhou:uid/1250000000:examplebucket-1250000000/*"},
+					"principal": []interface{}{
+						map[string]interface{}{"type": "qcs", "identifiers": []interface{}{"qcs::cam::uin/100000000001:root"}},
+						map[string]interface{}{"type": "qcs", "identifiers": []interface{}{"qcs::cam::uin/100000000001:root", "qcs::cam::uin/100000000002:root"}},
+					},
+					"condition": []interface{}{
+						map[string]interface{}{"test": "ip_equal", "variable": "qcs:ip", "values": []interface{}{"10.0.0.0/8"}},
+						map[string]interface{}{"test": "ip_equal", "variable": "qcs:ip", "values": []interface{}{"10.0.0.0/8", "172.16.0.0/12"}},
+					},
+				},
+			}},
+			want: `{"version":"2.0","statement":[{"effect":"allow","action":["cos:GetObject","cos:HeadObject"],` +
+				`"resource":["qcs::cos:ap-guangzhou:uid/1250000000:examplebucket-1250000000/*"],` +
+				`"condition":{"ip_equal":{"qcs:ip":["10.0.0.0/8","172.16.0.0/12"]}},` +
+				`"principal":{"qcs":["qcs::cam::uin/100000000001:root","qcs::cam::uin/100000000002:root"]}}]}`,
+		},
+		{
+			name: "statement replaces the source statement of the same sid",
+			raw:  map[string]interface{}{"source_policy_documents": sources, "statement": []interface{}{allowGet}},
+			want: `{"version":"2.0","statement":[{"sid":"get","effect":"allow","action":["cos:GetObject"],"resource":["*"]},` +
+				`{"sid":"put","effect":"deny","action":["cos:PutObject"],"resource":["*"]}]}`,
+		},
+		{
+			name: "overrides replace in place and append in order",
+			raw: map[string]interface{}{
+				"source_policy_documents": sources,
+				"override_policy_documents": []interface{}{
+					`{"statement":[{"sid":"put","effect":"allow","action":"cos:PutObject","resource":"*"},{"effect":"deny","action":"cos:DeleteObject","resource":"*"}]}`,
+					`{"statement":[{"sid":"get","effect":"deny","action":"cos:GetObject","resource":"*"}]}`,
+				},
+			},
+			want: `{"version":"2.0","statement":[{"sid":"get","effect":"deny","action":["cos:GetObject"],"resource":["*"]},` +
+				`{"sid":"put","effect":"allow","action":["cos:PutObject"],"resource":["*"]},` +
+				`{"effect":"deny","action":["cos:DeleteObject"],"resource":["*"]}]}`,
+		},
+		{
+			name: "cos style",
+			raw:  map[string]interface{}{"style": "cos", "statement": []interface{}{allowGet}},
+			want: `{"version":"2.0","Statement":[{"Sid":"get","Effect":"allow","Action":["cos:GetObject"],"Resource":["*"]}]}`,
+		},
+		{
+			name: "duplicated sid in the sources",
+			raw:  map[string]interface{}{"source_policy_documents": []interface{}{sources[0], sources[0]}},
+			err:  "source_policy_documents.1: duplicated statement sid `get`",
+		},
+		{
+			name: "duplicated sid in the statements",
+			raw:  map[string]interface{}{"statement": []interface{}{allowGet, allowGet}},
+			err:  "statement.1: duplicated statement sid `get`",
+		},
+		{
+			name: "invalid source",
+			raw:  map[string]interface{}{"source_policy_documents": []interface{}{`{"statement":`}},
+			err:  "source_policy_documents.0: invalid policy document",
+		},
+		{
+			name: "unsupported source version",
+			raw:  map[string]interface{}{"source_policy_documents": []interface{}{`{"version":"1.0","statement":[]}`}},
+			err:  "source_policy_documents.0: unsupported policy version `1.0`",
+		},
+		{
+			name: "invalid override",
+			raw: map[string]interface{}{
+				"statement":                 []interface{}{allowGet},
+				"override_policy_documents": []interface{}{`{"statement":{}}`},
+			},
+			err: "override_policy_documents.0: invalid policy document",
+		},
+		{
+			name: "no statement",
+			raw:  map[string]interface{}{"override_policy_documents": []interface{}{`{"statement":[]}`}},
+			err:  "policy document has no statement",
+		},
+		{
+			name: "invalid statement after merge",
+			raw: map[string]interface{}{
+				"statement":                 []interface{}{allowGet},
+				"override_policy_documents": []interface{}{`{"statement":[{"effect":"allow","action":"GetObject"}]}`},
+			},
+			err: "statement 1 of the merged document: invalid action `GetObject`",
+		},
+	}
+	for _, c := range cases {
+		d := schema.TestResourceDataRaw(t, DataSourceTencentCloudCamPolicyDocument().Schema, c.raw)
+		err := dataSourceTencentCloudCamPolicyDocumentRead(d, nil)
+		if c.err != "" {
+			if err == nil || !strings.Contains(err.Error(), c.err) {
+				t.Errorf("%s: error %v, want %q", c.name, err, c.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", c.name, err)
+			continue
+		}
+		if got := d.Get("json").(string); got != c.want {
+			t.Errorf("%s: json\n%s\nwant\n%s", c.name, got, c.want)
+		}
+	}
+}
+
+func TestParseCamPolicyDocument(t *testing.T) {
+	document, err := parseCamPolicyDocument(`{"statement":[{"effect":"Allow","action":"cos:GetObject","resource":["*","qcs::cos::uid/1250000000:*"],"principal":{"qcs":"qcs::cam::uin/1:root"}}]}`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := &camPolicyStatement{
+		Effect:    "allow",
+		Action:    camPolicyValues{"cos:GetObject"},
+		Resource:  camPolicyValues{"*", "qcs::cos::uid/1250000000:*"},
+		Principal: map[string]camPolicyValues{"qcs": {"qcs::cam::uin/1:root"}},
+	}
+	if len(document.Statement) != 1 || !reflect.DeepEqual(document.Statement[0], want) {
+		t.Errorf("statements %+v, want %+v", document.Statement, want)
+	}
+
+	if _, err := parseCamPolicyDocument(`{"statement":[{"effect":"allow","action":1}]}`); err == nil {
+		t.Errorf("expected error of a value which is neither a string nor an array of strings")
+	}
+}
+
+func TestCamPolicyDocumentMerge(t *testing.T) {
+	statement := func(sid, action string) *camPolicyStatement {
+		return &camPolicyStatement{Sid: sid, Effect: "allow", Action: camPolicyValues{action}}
+	}
+	document := &camPolicyDocument{Statement: []*camPolicyStatement{statement("a", "cos:A"), statement("", "cos:B"), statement("c", "cos:C")}}
+	document.merge([]*camPolicyStatement{statement("c", "cos:C2"), statement("", "cos:B"), statement("d", "cos:D"), statement("a", "cos:A2")})
+
+	var got []string
+	for _, item := range document.Statement {
+		got = append(got, item.Sid+"="+item.Action[0])
+	}
+	want := []string{"a=cos:A2", "=cos:B", "c=cos:C2", "=cos:B", "d=cos:D"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("merged statements %v, want %v", got, want)
+	}
+}
+
+func TestCamPolicyStatementValidate(t *testing.T) {
+	cases := []struct {
+		name      string
+		statement camPolicyStatement
+		err       string
+	}{
+		{name: "valid", statement: camPolicyStatement{Effect: "allow", Action: camPolicyValues{"name/cos:Get*"}, Resource: camPolicyValues{"*"}}},
+		{name: "all actions", statement: camPolicyStatement{Effect: "deny", Action: camPolicyValues{"*"}}},
+		{name: "invalid effect", statement: camPolicyStatement{Effect: "Allow", Action: camPolicyValues{"*"}}, err: "invalid effect `Allow`"},
+		{name: "no action", statement: camPolicyStatement{Effect: "allow"}, err: "action can not be empty"},
+		{name: "invalid action", statement: camPolicyStatement{Effect: "allow", Action: camPolicyValues{"cos:Get:Object"}}, err: "invalid action `cos:Get:Object`"},
+		{name: "six segments resource", statement: camPolicyStatement{Effect: "allow", Action: camPolicyValues{"*"}, Resource: camPolicyValues{"qcs::cos:ap-guangzhou:uid/1250000000:bucket/a:b"}}},
+		{name: "short resource", statement: camPolicyStatement{Effect: "allow", Action: camPolicyValues{"*"}, Resource: camPolicyValues{"qcs::cos:ap-guangzhou:uid/1250000000"}}, err: "invalid resource"},
+		{name: "resource without qcs", statement: camPolicyStatement{Effect: "allow", Action: camPolicyValues{"*"}, Resource: camPolicyValues{"cos::cos:ap-guangzhou:uid/1:a"}}, err: "invalid resource"},
+		{name: "service principal", statement: camPolicyStatement{Effect: "allow", Action: camPolicyValues{"*"}, Principal: map[string]camPolicyValues{"service": {"cvm.qcloud.com"}}}},
+		{name: "invalid principal type", statement: camPolicyStatement{Effect: "allow", Action: camPolicyValues{"*"}, Principal: map[string]camPolicyValues{"user": {"1"}}}, err: "invalid principal type `user`"},
+		{name: "invalid qcs principal", statement: camPolicyStatement{Effect: "allow", Action: camPolicyValues{"*"}, Principal: map[string]camPolicyValues{"qcs": {"100000000001"}}}, err: "invalid principal: invalid resource"},
+		{name: "condition with prefix and suffix", statement: camPolicyStatement{Effect: "allow", Action: camPolicyValues{"*"},
+			Condition: map[string]map[string]camPolicyValues{"for_any_value:string_equal_if_exist": {"qcs:tag": {"a"}}}}},
+		{name: "invalid condition", statement: camPolicyStatement{Effect: "allow", Action: camPolicyValues{"*"},
+			Condition: map[string]map[string]camPolicyValues{"string_match": {"qcs:tag": {"a"}}}}, err: "invalid condition operator `string_match`"},
+	}
+	for _, c := range cases {
+		err := c.statement.validate()
+		if c.err == "" && err != nil {
+			t.Errorf("%s: unexpected error: %v", c.name, err)
+		}
+		if c.err != "" && (err == nil || !strings.Contains(err.Error(), c.err)) {
+			t.Errorf("%s: error %v, want %q", c.name, err, c.err)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package cam_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	tcacctest "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/acctest"
+)
+
+func TestAccTencentCloudCamPolicyDocumentDataSource_basic(t *testing.T) {
+	t.Parallel()
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			tcacctest.AccPreCheck(t)
+		},
+		Providers: tcacctest.AccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCamPolicyDocumentDataSource,
+				Check: resource.ComposeTestCheckFunc(
+					tcacctest.AccCheckTencentCloudDataSourceID("data.tencentcloud_cam_policy_document.example"),
+					resource.TestCheckResourceAttr("data.tencentcloud_cam_policy_document.example", "json",
+						`{"version":"2.0","statement":[{"sid":"Read","effect":"allow","action":["cos:GetObject","cos:HeadObject"],"resource":["qcs::cos:ap-guangzhou:uid/1250000000:examplebucket-1250000000/*"],"condition":{"ip_equal":{"qcs:ip":["10.0.0.0/8","192.168.0.0/16"]}}},{"effect":"deny","action":["cos:DeleteObject"],"resource":["*"]}]}`),
+					resource.TestCheckResourceAttr("data.tencentcloud_cam_policy_document.bucket", "json",
+						`{"version":"2.0","Statement":[{"Sid":"Read","Effect":"allow","Action":["name/cos:GetObject"],"Resource":["*"],"Principal":{"qcs":["qcs::cam::anyone:anyone"]}},{"Effect":"deny","Action":["name/cos:DeleteObject"],"Resource":["*"]}]}`),
+				),
+			},
+			{
+				Config:      testAccCamPolicyDocumentDataSource_invalidResource,
+				ExpectError: regexp.MustCompile("invalid resource `cos:examplebucket`"),
+			},
+		},
+	})
+}
+
+const testAccCamPolicyDocumentDataSource = `
+data "tencentcloud_cam_policy_document" "example" {
+  source_policy_documents = [
+    jsonencode({
+      version = "2.0"
+      statement = [
+        {
+          sid      = "Read"
+          effect   = "allow"
+          action   = "cos:GetObject"
+          resource = "*"
+        },
+      ]
+    }),
+  ]
+
+  statement {
+    sid      = "Read"
+    action   = ["cos:GetObject", "cos:HeadObject"]
+    resource = ["qcs::cos:ap-guangzhou:uid/1250000000:examplebucket-1250000000/*"]
+
+    condition {
+      test     = "ip_equal"
+      variable = "qcs:ip"
+      values   = ["10.0.0.0/8"]
+    }
+
+    condition {
+      test     = "ip_equal"
+      variable = "qcs:ip"
+      values   = ["192.168.0.0/16"]
+    }
+  }
+
+  statement {
+    effect   = "deny"
+    action   = ["cos:DeleteObject"]
+    resource = ["*"]
+  }
+}
+
+data "tencentcloud_cam_policy_document" "bucket" {
+  style = "cos"
+
+  statement {
+    sid      = "Read"
+    action   = ["name/cos:GetObject", "name/cos:HeadObject"]
+    resource = ["*"]
+  }
+
+  override_policy_documents = [
+    jsonencode({
+      Statement = [
+        {
+          Sid       = "Read"
+          Effect    = "Allow"
+          Action    = ["name/cos:GetObject"]
+          Resource  = ["*"]
+          Principal = { qcs = ["qcs::cam::anyone:anyone"] }
+        },
+        {
+          Effect   = "Deny"
+          Action   = ["name/cos:DeleteObject"]
+          Resource = ["*"]
+        },
+      ]
+    }),
+  ]
+}
+`
+
+const testAccCamPolicyDocumentDataSource_invalidResource = `
+data "tencentcloud_cam_policy_document" "example" {
+  statement {
+    action   = ["cos:GetObject"]
+    resource = ["cos:examplebucket"]
+  }
+}
+`
@@ -246,7 +246,7 @@ func dataSourceTencentCloudCamPolicyGrantingServiceAccessRead(d *schema.Resource
 	d.SetId(targetUin + tccommon.FILED_SP + roleId + groupId)
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), tmpList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), tmpList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
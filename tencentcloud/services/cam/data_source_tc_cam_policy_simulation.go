@@ -0,0 +1,598 @@
+package cam
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	tccommon "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/common"
+	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/internal/helper"
+)
+
+const (
+	CAM_POLICY_DECISION_ALLOWED       = "allowed"
+	CAM_POLICY_DECISION_EXPLICIT_DENY = "explicit_deny"
+	CAM_POLICY_DECISION_IMPLICIT_DENY = "implicit_deny"
+)
+
+func DataSourceTencentCloudCamPolicySimulation() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceTencentCloudCamPolicySimulationRead,
+
+		Schema: map[string]*schema.Schema{
+			"role_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"role_name"},
+				Description:   "ID of the role whose attached policies are evaluated.",
+			},
+			"role_name": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"role_id"},
+				Description:   "Name of the role whose attached policies are evaluated.",
+			},
+			"policy_ids": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Description: "IDs of the policies evaluated in addition to the attached ones, such as the policies about to be attached.",
+			},
+			"policy_versions": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Versions of the policies to evaluate instead of their default versions. The policies must be attached to the role or set in `policy_ids`.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"policy_id": {
+							Type:        schema.TypeInt,
+							Required:    true,
+							Description: "ID of the policy.",
+						},
+						"version_id": {
+							Type:        schema.TypeInt,
+							Required:    true,
+							Description: "ID of the policy version.",
+						},
+					},
+				},
+			},
+			"policy_documents": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Policy documents in JSON evaluated in addition to the policies, such as `json` of `tencentcloud_cam_policy_document`.",
+			},
+			"action": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Action to evaluate, such as `cos:GetObject`.",
+			},
+			"resource": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Resource to evaluate, in the six-segment format of `qcs:project_id:service_type:region:account:resource`.",
+			},
+			"context": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Description: "Condition keys of the request to evaluate the conditions of the statements with. " +
+					"Conditions on the keys not set are only met with `_if_exist`, `for_all_value:`, negative operators such as `string_not_equal`, and `null_equal`.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Condition key, such as `qcs:ip`.",
+						},
+						"values": {
+							Type:        schema.TypeList,
+							Required:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Values of the condition key.",
+						},
+					},
+				},
+			},
+			"decision": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Decision of the evaluation. Valid values: `allowed`, `explicit_deny` (denied by a `deny` statement), `implicit_deny` (no `allow` statement matches).",
+			},
+			"allowed": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the action on the resource is allowed.",
+			},
+			"matched_statements": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Statements matching the action, the resource and the context. Each element contains the following attributes:",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"policy_id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "ID of the policy, `0` for the statements of `policy_documents`.",
+						},
+						"policy_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the policy, `policy_documents.<index>` for the statements of `policy_documents`.",
+						},
+						"sid": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Identifier of the statement.",
+						},
+						"effect": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Effect of the statement.",
+						},
+						"statement": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Statement in JSON.",
+						},
+					},
+				},
+			},
+			"result_output_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Used to save results.",
+			},
+		},
+	}
+}
+
+type camSimulationPolicy struct {
+	PolicyId   uint64
+	PolicyName string
+	Document   *camPolicyDocument
+}
+
+func dataSourceTencentCloudCamPolicySimulationRead(d *schema.ResourceData, meta interface{}) error {
+	defer tccommon.LogElapsed("data_source.tencentcloud_cam_policy_simulation.read")()
+
+	var (
+		logId      = tccommon.GetLogId(tccommon.ContextNil)
+		ctx        = context.WithValue(context.TODO(), tccommon.LogIdKey, logId)
+		camService = CamService{client: meta.(tccommon.ProviderMeta).GetAPIV3Conn()}
+		policyIds  = make([]uint64, 0)
+		names      = make(map[uint64]string)
+		versions   = make(map[uint64]uint64)
+		policies   = make([]*camSimulationPolicy, 0)
+	)
+
+	params := make(map[string]interface{})
+	if v, ok := d.GetOk("role_id"); ok {
+		params["role_id"] = v.(string)
+	}
+	if v, ok := d.GetOk("role_name"); ok {
+		params["role_name"] = v.(string)
+	}
+	if len(params) > 0 {
+		err := resource.Retry(tccommon.ReadRetryTimeout, func() *resource.RetryError {
+			results, e := camService.DescribeRolePolicyAttachmentsByFilter(ctx, params)
+			if e != nil {
+				return tccommon.RetryError(e)
+			}
+			policyIds = policyIds[:0]
+			for _, policy := range results {
+				policyIds = append(policyIds, *policy.PolicyId)
+				names[*policy.PolicyId] = *policy.PolicyName
+			}
+			return nil
+		})
+		if err != nil {
+			log.Printf("[CRITAL]%s read CAM role policy attachments failed, reason:%s\n", logId, err.Error())
+			return err
+		}
+	}
+
+	if v, ok := d.GetOk("policy_ids"); ok {
+		for _, item := range v.([]interface{}) {
+			policyId := uint64(item.(int))
+			if _, ok := names[policyId]; !ok {
+				policyIds = append(policyIds, policyId)
+				names[policyId] = ""
+			}
+		}
+	}
+
+	if v, ok := d.GetOk("policy_versions"); ok {
+		var err error
+		if versions, err = camPolicySimulationVersions(v.([]interface{}), policyIds); err != nil {
+			return err
+		}
+	}
+
+	for _, policyId := range policyIds {
+		var (
+			policyName = names[policyId]
+			document   string
+		)
+		err := resource.Retry(tccommon.ReadRetryTimeout, func() *resource.RetryError {
+			result, e := camService.DescribePolicyById(ctx, strconv.FormatUint(policyId, 10))
+			if e != nil {
+				return tccommon.RetryError(e)
+			}
+			if result == nil || result.Response == nil || result.Response.PolicyName == nil {
+				return resource.NonRetryableError(fmt.Errorf("CAM policy %d is not found", policyId))
+			}
+			policyName = *result.Response.PolicyName
+			if result.Response.PolicyDocument != nil {
+				document = *result.Response.PolicyDocument
+			}
+
+			if versionId, ok := versions[policyId]; ok {
+				version, e := camService.DescribeCamPolicyVersionById(ctx, policyId, versionId)
+				if e != nil {
+					return tccommon.RetryError(e)
+				}
+				if version == nil || version.Document == nil {
+					return resource.NonRetryableError(fmt.Errorf("version %d of CAM policy %d is not found", versionId, policyId))
+				}
+				document = *version.Document
+			}
+			return nil
+		})
+		if err != nil {
+			log.Printf("[CRITAL]%s read CAM policy %d failed, reason:%s\n", logId, policyId, err.Error())
+			if versionId, ok := versions[policyId]; ok {
+				return fmt.Errorf("read version %d of CAM policy %d failed, %s", versionId, policyId, err.Error())
+			}
+			return err
+		}
+
+		parsed, err := parseCamPolicyDocument(document)
+		if err != nil {
+			return fmt.Errorf("CAM policy %d: %s", policyId, err.Error())
+		}
+		policies = append(policies, &camSimulationPolicy{PolicyId: policyId, PolicyName: policyName, Document: parsed})
+	}
+
+	if v, ok := d.GetOk("policy_documents"); ok {
+		for i, item := range v.([]interface{}) {
+			parsed, err := parseCamPolicyDocument(item.(string))
+			if err != nil {
+				return fmt.Errorf("policy_documents.%d: %s", i, err.Error())
+			}
+			policies = append(policies, &camSimulationPolicy{PolicyName: fmt.Sprintf("policy_documents.%d", i), Document: parsed})
+		}
+	}
+
+	if len(policies) == 0 && len(params) == 0 {
+		return fmt.Errorf("one of `role_id`, `role_name`, `policy_ids` and `policy_documents` must be set")
+	}
+
+	requestContext := make(map[string][]string)
+	if v, ok := d.GetOk("context"); ok {
+		for _, item := range v.([]interface{}) {
+			key := item.(map[string]interface{})
+			requestContext[key["key"].(string)] = helper.InterfacesStrings(key["values"].([]interface{}))
+		}
+	}
+
+	action := d.Get("action").(string)
+	resourceName := d.Get("resource").(string)
+	decision, matches := evaluateCamPolicySimulation(policies, action, resourceName, requestContext)
+	matchedStatements := make([]map[string]interface{}, 0, len(matches))
+	for _, match := range matches {
+		content, err := marshalCamPolicyJson(match.Statement)
+		if err != nil {
+			return err
+		}
+		matchedStatements = append(matchedStatements, map[string]interface{}{
+			"policy_id":   int(match.Policy.PolicyId),
+			"policy_name": match.Policy.PolicyName,
+			"sid":         match.Statement.Sid,
+			"effect":      match.Statement.Effect,
+			"statement":   content,
+		})
+	}
+
+	d.SetId(helper.DataResourceIdsHash(camPolicySimulationIdParts(d, decision)))
+	_ = d.Set("decision", decision)
+	_ = d.Set("allowed", decision == CAM_POLICY_DECISION_ALLOWED)
+	if err := d.Set("matched_statements", matchedStatements); err != nil {
+		log.Printf("[CRITAL]%s provider set matched statements fail, reason:%s\n", logId, err.Error())
+		return err
+	}
+
+	output, ok := d.GetOk("result_output_file")
+	if ok && output.(string) != "" {
+		if e := tccommon.WriteToFile(output.(string), map[string]interface{}{
+			"decision":           decision,
+			"matched_statements": matchedStatements,
+		}, tccommon.GetResultOutputOption(d)); e != nil {
+			return e
+		}
+	}
+
+	return nil
+}
+
+// camPolicySimulationVersions returns the versions of policy_versions by the policy IDs, the policies must be among the
+// evaluated policies and have only one version.
+func camPolicySimulationVersions(items []interface{}, policyIds []uint64) (map[uint64]uint64, error) {
+	versions := make(map[uint64]uint64)
+	for i, item := range items {
+		version := item.(map[string]interface{})
+		policyId, versionId := uint64(version["policy_id"].(int)), uint64(version["version_id"].(int))
+		evaluated := false
+		for _, id := range policyIds {
+			if id == policyId {
+				evaluated = true
+				break
+			}
+		}
+		if !evaluated {
+			return nil, fmt.Errorf("policy_versions.%d: CAM policy %d is neither attached to the role nor in `policy_ids`", i, policyId)
+		}
+		if other, ok := versions[policyId]; ok {
+			return nil, fmt.Errorf("policy_versions.%d: CAM policy %d already has version %d", i, policyId, other)
+		}
+		versions[policyId] = versionId
+	}
+	return versions, nil
+}
+
+// camPolicySimulationIdParts returns the arguments identifying the simulation, so that different simulations have
+// different IDs.
+func camPolicySimulationIdParts(d *schema.ResourceData, decision string) []string {
+	parts := []string{d.Get("role_id").(string), d.Get("role_name").(string), d.Get("action").(string), d.Get("resource").(string), decision}
+	for _, item := range d.Get("policy_ids").([]interface{}) {
+		parts = append(parts, "policy_id:"+strconv.Itoa(item.(int)))
+	}
+	for _, item := range d.Get("policy_versions").([]interface{}) {
+		version := item.(map[string]interface{})
+		parts = append(parts, fmt.Sprintf("policy_version:%d:%d", version["policy_id"].(int), version["version_id"].(int)))
+	}
+	for _, item := range d.Get("policy_documents").([]interface{}) {
+		document, _ := item.(string)
+		parts = append(parts, "policy_document:"+document)
+	}
+	for _, item := range d.Get("context").([]interface{}) {
+		key := item.(map[string]interface{})
+		parts = append(parts, "context:"+key["key"].(string)+"="+strings.Join(helper.InterfacesStrings(key["values"].([]interface{})), ","))
+	}
+	return parts
+}
+
+type camSimulationMatch struct {
+	Policy    *camSimulationPolicy
+	Statement *camPolicyStatement
+}
+
+// evaluateCamPolicySimulation returns the decision of the policies on the action and the resource with the request
+// context, and the statements matching them. A matching `deny` statement takes precedence over the `allow` ones.
+func evaluateCamPolicySimulation(policies []*camSimulationPolicy, action, resource string, requestContext map[string][]string) (string, []camSimulationMatch) {
+	decision := CAM_POLICY_DECISION_IMPLICIT_DENY
+	matches := make([]camSimulationMatch, 0)
+	for _, policy := range policies {
+		for _, statement := range policy.Document.Statement {
+			if !statement.matches(action, resource, requestContext) {
+				continue
+			}
+
+			if statement.Effect == "deny" {
+				decision = CAM_POLICY_DECISION_EXPLICIT_DENY
+			} else if decision == CAM_POLICY_DECISION_IMPLICIT_DENY {
+				decision = CAM_POLICY_DECISION_ALLOWED
+			}
+			matches = append(matches, camSimulationMatch{Policy: policy, Statement: statement})
+		}
+	}
+	return decision, matches
+}
+
+// matches returns whether the statement applies to the action on the resource with the request context.
+func (statement *camPolicyStatement) matches(action, resource string, requestContext map[string][]string) bool {
+	actionMatched := false
+	for _, pattern := range statement.Action {
+		if matchCamPolicyAction(pattern, action) {
+			actionMatched = true
+			break
+		}
+	}
+	if !actionMatched {
+		return false
+	}
+
+	resourceMatched := false
+	for _, pattern := range statement.Resource {
+		if matchCamPolicyResource(pattern, resource) {
+			resourceMatched = true
+			break
+		}
+	}
+	if !resourceMatched {
+		return false
+	}
+
+	// all the operators and all the keys must be met
+	for test, keys := range statement.Condition {
+		for key, values := range keys {
+			if !matchCamPolicyCondition(test, values, requestContext[key]) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// matchCamPolicyAction matches actions case-insensitively, with or without the `name/` prefix.
+func matchCamPolicyAction(pattern, action string) bool {
+	pattern = strings.ToLower(strings.TrimPrefix(pattern, "name/"))
+	action = strings.ToLower(strings.TrimPrefix(action, "name/"))
+	return matchCamPolicyWildcard(pattern, action)
+}
+
+// matchCamPolicyResource matches the six-segment descriptions segment by segment,
+// the empty project, region and account segments of the pattern match any value.
+func matchCamPolicyResource(pattern, resource string) bool {
+	if pattern == "*" {
+		return true
+	}
+
+	patterns := strings.SplitN(pattern, ":", 6)
+	segments := strings.SplitN(resource, ":", 6)
+	if len(patterns) != 6 || len(segments) != 6 {
+		return matchCamPolicyWildcard(pattern, resource)
+	}
+	for i := range patterns {
+		if patterns[i] == "" && (i == 1 || i == 3 || i == 4) {
+			continue
+		}
+		if !matchCamPolicyWildcard(patterns[i], segments[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchCamPolicyWildcard matches value with pattern, in which `*` matches any sequence of characters.
+func matchCamPolicyWildcard(pattern, value string) bool {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == value
+	}
+	if !strings.HasPrefix(value, parts[0]) {
+		return false
+	}
+	value = value[len(parts[0]):]
+	for _, part := range parts[1 : len(parts)-1] {
+		index := strings.Index(value, part)
+		if index < 0 {
+			return false
+		}
+		value = value[index+len(part):]
+	}
+	return strings.HasSuffix(value, parts[len(parts)-1])
+}
+
+// matchCamPolicyCondition evaluates a condition key of an operator, the values of the policy are ORed.
+// Without the `for_all_value:` or `for_any_value:` prefix, the condition is met if any value of the request matches.
+func matchCamPolicyCondition(test string, policyValues, requestValues []string) bool {
+	forAll := strings.HasPrefix(test, "for_all_value:")
+	test = strings.TrimPrefix(strings.TrimPrefix(test, "for_all_value:"), "for_any_value:")
+	ifExist := strings.HasSuffix(test, "_if_exist")
+	test = strings.TrimSuffix(test, "_if_exist")
+
+	if test == "null_equal" {
+		for _, value := range policyValues {
+			if strings.EqualFold(value, "true") == (len(requestValues) == 0) {
+				return true
+			}
+		}
+		return false
+	}
+
+	// a missing key only meets the `_if_exist`, `for_all_value:` and negative conditions
+	negative := strings.Contains(test, "_not_")
+	if len(requestValues) == 0 {
+		return ifExist || forAll || negative
+	}
+
+	operator := strings.Replace(test, "_not_", "_", 1)
+	matchValue := func(requestValue string) bool {
+		matched := false
+		for _, policyValue := range policyValues {
+			if compareCamPolicyConditionValue(operator, policyValue, requestValue) {
+				matched = true
+				break
+			}
+		}
+		if negative {
+			return !matched
+		}
+		return matched
+	}
+
+	for _, requestValue := range requestValues {
+		matched := matchValue(requestValue)
+		if forAll && !matched {
+			return false
+		}
+		if !forAll && matched {
+			return true
+		}
+	}
+	return forAll
+}
+
+func compareCamPolicyConditionValue(operator, policyValue, requestValue string) bool {
+	switch operator {
+	case "string_equal":
+		return policyValue == requestValue
+	case "string_equal_ignore_case":
+		return strings.EqualFold(policyValue, requestValue)
+	case "string_like":
+		return matchCamPolicyWildcard(policyValue, requestValue)
+	case "bool_equal":
+		return strings.EqualFold(policyValue, requestValue)
+	case "ip_equal":
+		ip := net.ParseIP(requestValue)
+		if ip == nil {
+			return false
+		}
+		if _, cidr, err := net.ParseCIDR(policyValue); err == nil {
+			return cidr.Contains(ip)
+		}
+		return ip.Equal(net.ParseIP(policyValue))
+	}
+
+	if strings.HasPrefix(operator, "numeric_") {
+		policyNumber, err := strconv.ParseFloat(policyValue, 64)
+		if err != nil {
+			return false
+		}
+		requestNumber, err := strconv.ParseFloat(requestValue, 64)
+		if err != nil {
+			return false
+		}
+		return compareCamPolicyConditionOrder(strings.TrimPrefix(operator, "numeric_"), requestNumber-policyNumber)
+	}
+
+	if strings.HasPrefix(operator, "date_") {
+		policyTime, err := time.Parse(time.RFC3339, policyValue)
+		if err != nil {
+			return false
+		}
+		requestTime, err := time.Parse(time.RFC3339, requestValue)
+		if err != nil {
+			return false
+		}
+		return compareCamPolicyConditionOrder(strings.TrimPrefix(operator, "date_"), float64(requestTime.Sub(policyTime)))
+	}
+
+	return false
+}
+
+// compareCamPolicyConditionOrder compares the request value to the policy value by their difference.
+func compareCamPolicyConditionOrder(order string, difference float64) bool {
+	switch order {
+	case "equal":
+		return difference == 0
+	case "greater_than":
+		return difference > 0
+	case "greater_than_equal":
+		return difference >= 0
+	case "less_than":
+		return difference < 0
+	case "less_than_equal":
+		return difference <= 0
+	}
+	return false
+}
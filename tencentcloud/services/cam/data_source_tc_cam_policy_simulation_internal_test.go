@@ -0,0 +1,219 @@
+package cam
+
+import (
+	"reflect"
+	"testing"
+)
+
+func testCamSimulationPolicy(t *testing.T, name, document string) *camSimulationPolicy {
+	parsed, err := parseCamPolicyDocument(document)
+	if err != nil {
+		t.Fatalf("parse policy %s failed: %v", name, err)
+	}
+	return &camSimulationPolicy{PolicyName: name, Document: parsed}
+}
+
+func TestEvaluateCamPolicySimulation(t *testing.T) {
+	allowCos := testCamSimulationPolicy(t, "allow-cos", `{
+		"version": "2.0",
+		"statement": [{"effect": "allow", "action": ["cos:*"], "resource": ["*"]}]
+	}`)
+	denyDelete := testCamSimulationPolicy(t, "deny-delete", `{
+		"version": "2.0",
+		"statement": [{"effect": "Deny", "action": ["cos:Delete*"], "resource": ["qcs::cos::uid/1250000000:prefix//1250000000/bucket/*"]}]
+	}`)
+	allowFromOffice := testCamSimulationPolicy(t, "allow-office", `{
+		"version": "2.0",
+		"statement": [{
+			"effect": "allow",
+			"action": "cvm:RunInstances",
+			"resource": "*",
+			"condition": {"ip_equal": {"qcs:ip": ["10.0.0.0/8"]}}
+		}]
+	}`)
+
+	cases := []struct {
+		name     string
+		policies []*camSimulationPolicy
+		action   string
+		resource string
+		context  map[string][]string
+		decision string
+		matches  int
+	}{
+		{"no policy", nil, "cos:GetObject", "*", nil, CAM_POLICY_DECISION_IMPLICIT_DENY, 0},
+		{"allowed by wildcard", []*camSimulationPolicy{allowCos}, "name/cos:GetObject", "qcs::cos:ap-guangzhou:uid/1250000000:prefix//1250000000/bucket/a", nil, CAM_POLICY_DECISION_ALLOWED, 1},
+		{"action not matched", []*camSimulationPolicy{allowCos}, "cvm:RunInstances", "*", nil, CAM_POLICY_DECISION_IMPLICIT_DENY, 0},
+		{"deny takes precedence", []*camSimulationPolicy{allowCos, denyDelete}, "cos:DeleteObject", "qcs::cos:ap-guangzhou:uid/1250000000:prefix//1250000000/bucket/a", nil, CAM_POLICY_DECISION_EXPLICIT_DENY, 2},
+		{"deny takes precedence in any order", []*camSimulationPolicy{denyDelete, allowCos}, "cos:DeleteObject", "qcs::cos:ap-guangzhou:uid/1250000000:prefix//1250000000/bucket/a", nil, CAM_POLICY_DECISION_EXPLICIT_DENY, 2},
+		{"deny resource not matched", []*camSimulationPolicy{allowCos, denyDelete}, "cos:DeleteObject", "qcs::cos:ap-guangzhou:uid/1250000000:prefix//1250000000/other/a", nil, CAM_POLICY_DECISION_ALLOWED, 1},
+		{"condition met", []*camSimulationPolicy{allowFromOffice}, "cvm:RunInstances", "*", map[string][]string{"qcs:ip": {"10.1.2.3"}}, CAM_POLICY_DECISION_ALLOWED, 1},
+		{"condition not met", []*camSimulationPolicy{allowFromOffice}, "cvm:RunInstances", "*", map[string][]string{"qcs:ip": {"192.168.0.1"}}, CAM_POLICY_DECISION_IMPLICIT_DENY, 0},
+		{"condition key missing", []*camSimulationPolicy{allowFromOffice}, "cvm:RunInstances", "*", nil, CAM_POLICY_DECISION_IMPLICIT_DENY, 0},
+	}
+	for _, c := range cases {
+		decision, matches := evaluateCamPolicySimulation(c.policies, c.action, c.resource, c.context)
+		if decision != c.decision || len(matches) != c.matches {
+			t.Errorf("%s: expected %s with %d matches, got %s with %d matches", c.name, c.decision, c.matches, decision, len(matches))
+		}
+	}
+}
+
+func TestMatchCamPolicyWildcard(t *testing.T) {
+	cases := []struct {
+		pattern string
+		value   string
+		matched bool
+	}{
+		{"*", "", true},
+		{"*", "anything", true},
+		{"cos:GetObject", "cos:GetObject", true},
+		{"cos:GetObject", "cos:GetObjectAcl", false},
+		{"cos:Get*", "cos:GetObjectAcl", true},
+		{"cos:Get*", "cos:PutObject", false},
+		{"*Object", "cos:GetObject", true},
+		{"*Object", "cos:GetObjectAcl", false},
+		{"cos:*Object*", "cos:PutObjectAcl", true},
+		{"a*b*c", "abc", true},
+		{"a*b*c", "aXbYc", true},
+		{"a*b*c", "acb", false},
+		{"ab*ba", "aba", false},
+	}
+	for _, c := range cases {
+		if matched := matchCamPolicyWildcard(c.pattern, c.value); matched != c.matched {
+			t.Errorf("%q on %q: expected %v, got %v", c.pattern, c.value, c.matched, matched)
+		}
+	}
+}
+
+func TestMatchCamPolicyResource(t *testing.T) {
+	resource := "qcs::cvm:ap-guangzhou:uin/100000000001:instance/ins-a"
+	cases := []struct {
+		pattern string
+		matched bool
+	}{
+		{"*", true},
+		{"qcs::cvm:ap-guangzhou:uin/100000000001:instance/ins-a", true},
+		{"qcs::cvm:::instance/ins-a", true},
+		{"qcs::cvm:ap-guangzhou::instance/*", true},
+		{"qcs::cvm:ap-shanghai::instance/*", false},
+		{"qcs::cvm:ap-guangzhou:uin/100000000002:instance/*", false},
+		{"qcs::cos:ap-guangzhou::instance/*", false},
+		{"qcs::cvm:*:*:instance/ins-*", true},
+		{"qcs::cvm:::instance/ins-b", false},
+	}
+	for _, c := range cases {
+		if matched := matchCamPolicyResource(c.pattern, resource); matched != c.matched {
+			t.Errorf("%q: expected %v, got %v", c.pattern, c.matched, matched)
+		}
+	}
+}
+
+func TestMatchCamPolicyCondition(t *testing.T) {
+	cases := []struct {
+		test          string
+		policyValues  []string
+		requestValues []string
+		matched       bool
+	}{
+		{"string_equal", []string{"a", "b"}, []string{"b"}, true},
+		{"string_equal", []string{"a"}, []string{"A"}, false},
+		{"string_equal", []string{"a"}, nil, false},
+		{"string_equal_if_exist", []string{"a"}, nil, true},
+		{"string_equal_if_exist", []string{"a"}, []string{"c"}, false},
+		{"string_not_equal", []string{"a"}, []string{"b"}, true},
+		{"string_not_equal", []string{"a"}, []string{"a"}, false},
+		{"string_not_equal", []string{"a"}, nil, true},
+		{"string_equal_ignore_case", []string{"a"}, []string{"A"}, true},
+		{"string_like", []string{"prod-*"}, []string{"prod-web"}, true},
+		{"string_not_like", []string{"prod-*"}, []string{"prod-web"}, false},
+		{"bool_equal", []string{"true"}, []string{"TRUE"}, true},
+		{"null_equal", []string{"true"}, nil, true},
+		{"null_equal", []string{"true"}, []string{"a"}, false},
+		{"null_equal", []string{"false"}, []string{"a"}, true},
+		{"ip_equal", []string{"10.0.0.0/8"}, []string{"10.1.1.1"}, true},
+		{"ip_equal", []string{"10.0.0.1"}, []string{"10.0.0.1"}, true},
+		{"ip_equal", []string{"10.0.0.0/8"}, []string{"11.0.0.1"}, false},
+		{"ip_not_equal", []string{"10.0.0.0/8"}, []string{"11.0.0.1"}, true},
+		{"ip_equal", []string{"10.0.0.0/8"}, []string{"invalid"}, false},
+		{"numeric_equal", []string{"10"}, []string{"10.0"}, true},
+		{"numeric_greater_than", []string{"10"}, []string{"11"}, true},
+		{"numeric_greater_than", []string{"10"}, []string{"10"}, false},
+		{"numeric_greater_than_equal", []string{"10"}, []string{"10"}, true},
+		{"numeric_less_than", []string{"10"}, []string{"9"}, true},
+		{"numeric_less_than_equal", []string{"10"}, []string{"11"}, false},
+		{"numeric_not_equal", []string{"10"}, []string{"11"}, true},
+		{"numeric_equal", []string{"10"}, []string{"ten"}, false},
+		{"date_less_than", []string{"2026-01-01T00:00:00Z"}, []string{"2025-12-31T23:59:59Z"}, true},
+		{"date_greater_than", []string{"2026-01-01T00:00:00Z"}, []string{"2025-12-31T23:59:59Z"}, false},
+		{"unknown_operator", []string{"a"}, []string{"a"}, false},
+		// without a prefix, any value of the request matching is enough
+		{"string_equal", []string{"a"}, []string{"b", "a"}, true},
+		{"for_any_value:string_equal", []string{"a"}, []string{"b", "a"}, true},
+		{"for_any_value:string_equal", []string{"a"}, []string{"b", "c"}, false},
+		// with for_all_value, all the values of the request must match, and a missing key is met
+		{"for_all_value:string_equal", []string{"a", "b"}, []string{"b", "a"}, true},
+		{"for_all_value:string_equal", []string{"a"}, []string{"b", "a"}, false},
+		{"for_all_value:string_equal", []string{"a"}, nil, true},
+	}
+	for _, c := range cases {
+		if matched := matchCamPolicyCondition(c.test, c.policyValues, c.requestValues); matched != c.matched {
+			t.Errorf("%s %v on %v: expected %v, got %v", c.test, c.policyValues, c.requestValues, c.matched, matched)
+		}
+	}
+}
+
+func TestCompareCamPolicyConditionValue(t *testing.T) {
+	cases := []struct {
+		operator     string
+		policyValue  string
+		requestValue string
+		matched      bool
+	}{
+		{"string_equal", "a", "a", true},
+		{"string_like", "a*c", "abc", true},
+		{"ip_equal", "2001:db8::/32", "2001:db8::1", true},
+		{"ip_equal", "2001:db8::/32", "2001:db9::1", false},
+		{"numeric_less_than", "1.5", "1.25", true},
+		{"numeric_less_than", "invalid", "1", false},
+		{"date_equal", "2026-01-01T08:00:00+08:00", "2026-01-01T00:00:00Z", true},
+		{"date_equal", "2026-01-01", "2026-01-01T00:00:00Z", false},
+	}
+	for _, c := range cases {
+		if matched := compareCamPolicyConditionValue(c.operator, c.policyValue, c.requestValue); matched != c.matched {
+			t.Errorf("%s %q on %q: expected %v, got %v", c.operator, c.policyValue, c.requestValue, c.matched, matched)
+		}
+	}
+}
+
+func TestCamPolicySimulationVersions(t *testing.T) {
+	version := func(policyId, versionId int) interface{} {
+		return map[string]interface{}{"policy_id": policyId, "version_id": versionId}
+	}
+
+	cases := []struct {
+		name     string
+		items    []interface{}
+		versions map[uint64]uint64
+		err      string
+	}{
+		{"no versions", nil, map[uint64]uint64{}, ""},
+		{"evaluated policies", []interface{}{version(1, 2), version(3, 1)}, map[uint64]uint64{1: 2, 3: 1}, ""},
+		{"unknown policy", []interface{}{version(1, 2), version(2, 1)}, nil, "policy_versions.1: CAM policy 2 is neither attached to the role nor in `policy_ids`"},
+		{"duplicated policy", []interface{}{version(1, 2), version(1, 3)}, nil, "policy_versions.1: CAM policy 1 already has version 2"},
+	}
+	for _, c := range cases {
+		versions, err := camPolicySimulationVersions(c.items, []uint64{1, 3})
+		if c.err != "" {
+			if err == nil || err.Error() != c.err {
+				t.Errorf("%s: expected error %q, got %v", c.name, c.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", c.name, err)
+		} else if !reflect.DeepEqual(versions, c.versions) {
+			t.Errorf("%s: expected %v, got %v", c.name, c.versions, versions)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package cam_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	tcacctest "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/acctest"
+)
+
+func TestAccTencentCloudCamPolicySimulationDataSource_basic(t *testing.T) {
+	t.Parallel()
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			tcacctest.AccPreCheck(t)
+		},
+		Providers: tcacctest.AccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCamPolicySimulationDataSource,
+				Check: resource.ComposeTestCheckFunc(
+					tcacctest.AccCheckTencentCloudDataSourceID("data.tencentcloud_cam_policy_simulation.allowed"),
+					resource.TestCheckResourceAttr("data.tencentcloud_cam_policy_simulation.allowed", "decision", "allowed"),
+					resource.TestCheckResourceAttr("data.tencentcloud_cam_policy_simulation.allowed", "allowed", "true"),
+					resource.TestCheckResourceAttr("data.tencentcloud_cam_policy_simulation.allowed", "matched_statements.#", "1"),
+					resource.TestCheckResourceAttr("data.tencentcloud_cam_policy_simulation.denied", "decision", "explicit_deny"),
+					resource.TestCheckResourceAttr("data.tencentcloud_cam_policy_simulation.denied", "matched_statements.#", "2"),
+					resource.TestCheckResourceAttr("data.tencentcloud_cam_policy_simulation.no_context", "decision", "implicit_deny"),
+					resource.TestCheckResourceAttr("data.tencentcloud_cam_policy_simulation.no_context", "allowed", "false"),
+				),
+			},
+		},
+	})
+}
+
+const testAccCamPolicySimulationDataSource = `
+data "tencentcloud_cam_policy_document" "example" {
+  statement {
+    action   = ["cos:Get*"]
+    resource = ["qcs::cos::uid/1250000000:examplebucket-1250000000/*"]
+
+    condition {
+      test     = "ip_equal"
+      variable = "qcs:ip"
+      values   = ["10.0.0.0/8"]
+    }
+  }
+
+  statement {
+    effect   = "deny"
+    action   = ["cos:GetObject"]
+    resource = ["qcs::cos:ap-guangzhou:uid/1250000000:examplebucket-1250000000/secret/*"]
+  }
+}
+
+data "tencentcloud_cam_policy_simulation" "allowed" {
+  policy_documents = [data.tencentcloud_cam_policy_document.example.json]
+  action           = "cos:GetObject"
+  resource         = "qcs::cos:ap-guangzhou:uid/1250000000:examplebucket-1250000000/data/example.txt"
+
+  context {
+    key    = "qcs:ip"
+    values = ["10.0.0.1"]
+  }
+}
+
+data "tencentcloud_cam_policy_simulation" "denied" {
+  policy_documents = [data.tencentcloud_cam_policy_document.example.json]
+  action           = "cos:GetObject"
+  resource         = "qcs::cos:ap-guangzhou:uid/1250000000:examplebucket-1250000000/secret/example.txt"
+
+  context {
+    key    = "qcs:ip"
+    values = ["10.0.0.1"]
+  }
+}
+
+data "tencentcloud_cam_policy_simulation" "no_context" {
+  policy_documents = [data.tencentcloud_cam_policy_document.example.json]
+  action           = "cos:GetObject"
+  resource         = "qcs::cos:ap-guangzhou:uid/1250000000:examplebucket-1250000000/data/example.txt"
+}
+`
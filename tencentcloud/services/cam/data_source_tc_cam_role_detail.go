@@ -217,7 +217,7 @@ func dataSourceTencentCloudCamRoleDetailRead(d *schema.ResourceData, meta interf
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), roleInfoMap); e != nil {
+		if e := tccommon.WriteToFile(output.(string), roleInfoMap, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
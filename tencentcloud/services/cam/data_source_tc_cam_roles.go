@@ -147,7 +147,7 @@ func dataSourceTencentCloudCamRolesRead(d *schema.ResourceData, meta interface{}
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), roleList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), roleList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
@@ -117,7 +117,7 @@ func dataSourceTencentCloudCamSAMLProvidersRead(d *schema.ResourceData, meta int
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), providerList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), providerList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
@@ -168,7 +168,7 @@ func dataSourceTencentCloudCamSubAccountsRead(d *schema.ResourceData, meta inter
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), subAccountsList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), subAccountsList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
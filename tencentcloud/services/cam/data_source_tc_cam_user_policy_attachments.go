@@ -169,7 +169,7 @@ func dataSourceTencentCloudCamUserPolicyAttachmentsRead(d *schema.ResourceData,
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), policyOfUserList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), policyOfUserList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
@@ -204,7 +204,7 @@ func dataSourceTencentCloudCamUsersRead(d *schema.ResourceData, meta interface{}
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), userList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), userList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
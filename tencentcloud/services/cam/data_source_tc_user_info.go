@@ -139,7 +139,7 @@ func datasourceTencentCloudUserInfoRead(d *schema.ResourceData, meta interface{}
 			"uin":      uin,
 			"ownerUin": ownerUin,
 			"name":     name,
-		}); e != nil {
+		}, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
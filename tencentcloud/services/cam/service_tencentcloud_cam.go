@@ -275,15 +275,19 @@ func (me *CamService) DescribeRolePolicyAttachmentById(ctx context.Context, role
 
 func (me *CamService) DescribeRolePolicyAttachmentsByFilter(ctx context.Context, params map[string]interface{}) (policyOfRoles []*cam.AttachedPolicyOfRole, errRet error) {
 	logId := tccommon.GetLogId(ctx)
-	roleId := params["role_id"].(string)
 	request := cam.NewListAttachedRolePoliciesRequest()
+	if v, ok := params["role_id"]; ok {
+		request.RoleId = helper.String(v.(string))
+	}
+	if v, ok := params["role_name"]; ok {
+		request.RoleName = helper.String(v.(string))
+	}
 	pageStart := uint64(1)
 	rp := uint64(PAGE_ITEM)
 	policyOfRoles = make([]*cam.AttachedPolicyOfRole, 0)
 	for {
 		request.Page = &pageStart
 		request.Rp = &rp
-		request.RoleId = &roleId
 		ratelimit.Check(request.GetAction())
 		response, err := me.client.UseCamClient().ListAttachedRolePolicies(request)
 		if err != nil {
@@ -184,7 +184,7 @@ func dataSourceTencentCloudCbsSnapshotsRead(d *schema.ResourceData, meta interfa
 
 		output, ok := d.GetOk("result_output_file")
 		if ok && output.(string) != "" {
-			if e := tccommon.WriteToFile(output.(string), snapshotList); e != nil {
+			if e := tccommon.WriteToFile(output.(string), snapshotList, tccommon.GetResultOutputOption(d)); e != nil {
 				return resource.NonRetryableError(e)
 			}
 		}
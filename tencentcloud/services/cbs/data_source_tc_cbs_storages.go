@@ -9,6 +9,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	cbs "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/cbs/v20170312"
 
 	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/internal/helper"
 )
@@ -95,6 +96,7 @@ func DataSourceTencentCloudCbsStorages() *schema.Resource {
 				Description: "List filter by tag values.",
 				Elem:        &schema.Schema{Type: schema.TypeString},
 			},
+			"filter": tccommon.DataSourceFilterSchema("DescribeDisks"),
 			"result_output_file": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -268,12 +270,21 @@ func dataSourceTencentCloudCbsStoragesRead(d *schema.ResourceData, meta interfac
 		params["tag-value"] = helper.InterfacesStringsPoint(v.([]interface{}))
 	}
 
-	err := resource.Retry(tccommon.ReadRetryTimeout, func() *resource.RetryError {
-		storages, e := cbsService.DescribeDisksByFilter(ctx, params)
+	filters, err := tccommon.GetDataSourceFilters(d, cbsFilterValues(params))
+	if err != nil {
+		return err
+	}
+
+	err = resource.Retry(tccommon.ReadRetryTimeout, func() *resource.RetryError {
+		storages, e := cbsService.DescribeDisksByFilters(ctx, filters)
 		if e != nil {
 			return tccommon.RetryError(e)
 		}
 
+		tccommon.SortByKey(storages, func(storage *cbs.Disk) string {
+			return *storage.DiskId
+		})
+
 		ids := make([]string, 0, len(storages))
 		storageList := make([]map[string]interface{}, 0, len(storages))
 		for _, storage := range storages {
@@ -317,7 +328,7 @@ func dataSourceTencentCloudCbsStoragesRead(d *schema.ResourceData, meta interfac
 
 		output, ok := d.GetOk("result_output_file")
 		if ok && output.(string) != "" {
-			if e := tccommon.WriteToFile(output.(string), storageList); e != nil {
+			if e := tccommon.WriteToFile(output.(string), storageList, tccommon.GetResultOutputOption(d)); e != nil {
 				return resource.NonRetryableError(e)
 			}
 		}
@@ -32,6 +32,8 @@ func TestAccTencentCloudCbsStoragesDataSourceId(t *testing.T) {
 					resource.TestCheckResourceAttrSet("data.tencentcloud_cbs_storages.storages", "storage_list.0.create_time"),
 					resource.TestCheckResourceAttrSet("data.tencentcloud_cbs_storages.storages", "storage_list.0.status"),
 					resource.TestCheckResourceAttrSet("data.tencentcloud_cbs_storages.storages", "storage_list.0.charge_type"),
+					resource.TestCheckResourceAttr("data.tencentcloud_cbs_storages.filter_storages", "storage_list.#", "1"),
+					resource.TestCheckResourceAttr("data.tencentcloud_cbs_storages.filter_storages", "storage_list.0.storage_name", "tf-test-storage"),
 				),
 			},
 		},
@@ -69,6 +71,15 @@ resource "tencentcloud_cbs_storage" "storage" {
 data "tencentcloud_cbs_storages" "storages" {
   storage_id = tencentcloud_cbs_storage.storage.id
 }
+
+data "tencentcloud_cbs_storages" "filter_storages" {
+  availability_zone = tencentcloud_cbs_storage.storage.availability_zone
+
+  filter {
+    name   = "disk-id"
+    values = [tencentcloud_cbs_storage.storage.id]
+  }
+}
 `
 
 const testAccCbsStoragesDataSourceNewParams = `
@@ -108,51 +108,60 @@ func (me *CbsService) DescribeDiskList(ctx context.Context, diskIds []*string) (
 }
 
 func (me *CbsService) DescribeDisksByFilter(ctx context.Context, params map[string]interface{}) (disks []*cbs.Disk, errRet error) {
+	filters := make([]tccommon.DataSourceFilter, 0, len(params))
+	for k, v := range cbsFilterValues(params) {
+		filters = append(filters, tccommon.DataSourceFilter{Name: k, Values: v})
+	}
+	return me.DescribeDisksByFilters(ctx, filters)
+}
+
+func (me *CbsService) DescribeDisksByFilters(ctx context.Context, filters []tccommon.DataSourceFilter) (disks []*cbs.Disk, errRet error) {
 	logId := tccommon.GetLogId(ctx)
 	request := cbs.NewDescribeDisksRequest()
-	request.Filters = make([]*cbs.Filter, 0, len(params))
-	for k, v := range params {
-		filter := &cbs.Filter{
-			Name: helper.String(k),
-		}
-		switch v := v.(type) {
-		case string:
-			filter.Values = []*string{helper.String(v)}
-		case []*string:
-			filter.Values = v
-		}
-		request.Filters = append(request.Filters, filter)
+	request.Filters = make([]*cbs.Filter, 0, len(filters))
+	for _, v := range filters {
+		request.Filters = append(request.Filters, &cbs.Filter{
+			Name:   helper.String(v.Name),
+			Values: helper.Strings(v.Values),
+		})
 	}
 
-	offset := 0
-	pageSize := 100
-	disks = make([]*cbs.Disk, 0)
-	for {
+	return tccommon.DescribeAllPages(100, func(offset, limit int) ([]*cbs.Disk, int, error) {
 		request.Offset = helper.IntUint64(offset)
-		request.Limit = helper.IntUint64(pageSize)
+		request.Limit = helper.IntUint64(limit)
 		ratelimit.Check(request.GetAction())
 		response, err := me.client.UseCbsClient().DescribeDisks(request)
 		if err != nil {
 			log.Printf("[CRITAL]%s api[%s] fail, request body [%s], reason[%s]\n",
 				logId, request.GetAction(), request.ToJsonString(), err.Error())
-			errRet = err
-			return
+			return nil, 0, err
 		}
 		log.Printf("[DEBUG]%s api[%s] success, request body [%s], response body [%s]\n",
 			logId, request.GetAction(), request.ToJsonString(), response.ToJsonString())
 
-		if response == nil || len(response.Response.DiskSet) < 1 {
-			break
+		if response == nil || response.Response == nil {
+			return nil, 0, nil
 		}
+		total := -1
+		if response.Response.TotalCount != nil {
+			total = int(*response.Response.TotalCount)
+		}
+		return response.Response.DiskSet, total, nil
+	})
+}
 
-		disks = append(disks, response.Response.DiskSet...)
-
-		if len(response.Response.DiskSet) < pageSize {
-			break
+// cbsFilterValues converts the filter params of string or []*string values to the values of `Filters`
+func cbsFilterValues(params map[string]interface{}) map[string][]string {
+	values := make(map[string][]string, len(params))
+	for k, v := range params {
+		switch v := v.(type) {
+		case string:
+			values[k] = []string{v}
+		case []*string:
+			values[k] = helper.PStrings(v)
 		}
-		offset += pageSize
 	}
-	return
+	return values
 }
 
 func (me *CbsService) DescribeDisksInParallelByFilter(ctx context.Context, params map[string]interface{}) (disks []*cbs.Disk, errRet error) {
@@ -87,7 +87,7 @@ func dataSourceTencentCloudCcnBandwidthLimitsRead(d *schema.ResourceData, meta i
 	d.SetId(ccnId)
 
 	if output, ok := d.GetOk("result_output_file"); ok && output.(string) != "" {
-		if err := tccommon.WriteToFile(output.(string), infoList); err != nil {
+		if err := tccommon.WriteToFile(output.(string), infoList, tccommon.GetResultOutputOption(d)); err != nil {
 			log.Printf("[CRITAL]%s output file[%s] fail, reason[%s]\n",
 				logId, output.(string), err.Error())
 			return err
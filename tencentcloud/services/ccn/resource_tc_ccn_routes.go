@@ -4,9 +4,11 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net"
 	"strings"
 
 	tccommon "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/common"
+	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/internal/helper"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -22,6 +24,7 @@ func ResourceTencentCloudCcnRoutes() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: ccnRoutesCustomizeDiff,
 		Schema: map[string]*schema.Schema{
 			"ccn_id": {
 				Required:    true,
@@ -40,10 +43,121 @@ func ResourceTencentCloudCcnRoutes() *schema.Resource {
 				Type:        schema.TypeString,
 				Description: "`on`: Enable, `off`: Disable.",
 			},
+			"fail_on_route_conflict": {
+				Optional:    true,
+				Type:        schema.TypeBool,
+				Default:     false,
+				Description: "Whether to fail the plan when the route to be enabled conflicts with the other routes of the CCN, default is `false`.",
+			},
+			"route_conflicts": {
+				Computed:    true,
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Conflicts of the route to be enabled with the other routes of the CCN found at plan time.",
+			},
 		},
 	}
 }
 
+// ccnRoutesCustomizeDiff checks the route to be enabled against the other routes of the CCN at plan time. The
+// conflicts are shown in `route_conflicts` and only fail the plan with `fail_on_route_conflict`, since another route
+// to the same destination may be disabled in the same apply.
+func ccnRoutesCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Get("switch").(string) != "on" {
+		return tccommon.SetRouteConflicts(d, nil)
+	}
+	if d.Id() != "" && !d.HasChange("switch") && !d.HasChange("route_id") {
+		return nil
+	}
+	if !d.NewValueKnown("ccn_id") || !d.NewValueKnown("route_id") {
+		return d.SetNewComputed("route_conflicts")
+	}
+
+	var (
+		logId   = tccommon.GetLogId(tccommon.ContextNil)
+		service = VpcService{client: meta.(tccommon.ProviderMeta).GetAPIV3Conn()}
+		ccnId   = d.Get("ccn_id").(string)
+		routeId = d.Get("route_id").(string)
+	)
+	ctx = context.WithValue(ctx, tccommon.LogIdKey, logId)
+
+	routes, err := describeCcnRoutesOfCcn(ctx, &service, ccnId)
+	if err != nil {
+		return err
+	}
+	var conflicts []string
+	equal, overlaps := checkCcnRouteConflicts(routeId, routes)
+	if equal != nil {
+		conflicts = append(conflicts, ccnRouteConflictMessage(ccnId, routeId, equal))
+	}
+	for _, other := range overlaps {
+		conflicts = append(conflicts, fmt.Sprintf("route %s of %s is overridden by the more specific route %s to %s",
+			routeId, ccnId, helper.PString(other.RouteId), helper.PString(other.DestinationCidrBlock)))
+	}
+	for _, conflict := range conflicts {
+		log.Printf("[WARN]%s route conflict: %s\n", logId, conflict)
+	}
+	return tccommon.SetRouteConflicts(d, conflicts)
+}
+
+func describeCcnRoutesOfCcn(ctx context.Context, service *VpcService, ccnId string) (routes []*vpc.CcnRoute, errRet error) {
+	errRet = resource.Retry(tccommon.ReadRetryTimeout, func() *resource.RetryError {
+		result, e := service.DescribeVpcDescribeCcnRoutesByFilter(ctx, map[string]interface{}{"CcnId": helper.String(ccnId)})
+		if e != nil {
+			return tccommon.RetryError(e)
+		}
+		routes = result
+		return nil
+	})
+	return
+}
+
+// checkCcnRouteConflicts returns the enabled route to the same destination with the same priority as the route, which
+// keeps the CCN from enabling the route, and the enabled more specific routes overriding the route partially.
+func checkCcnRouteConflicts(routeId string, routes []*vpc.CcnRoute) (equal *vpc.CcnRoute, overlaps []*vpc.CcnRoute) {
+	var route *vpc.CcnRoute
+	for _, item := range routes {
+		if helper.PString(item.RouteId) == routeId {
+			route = item
+			break
+		}
+	}
+	if route == nil {
+		return
+	}
+	_, cidr, err := net.ParseCIDR(helper.PString(route.DestinationCidrBlock))
+	if err != nil {
+		return
+	}
+	ones, _ := cidr.Mask.Size()
+
+	for _, other := range routes {
+		if other == route || other.Enabled == nil || !*other.Enabled {
+			continue
+		}
+		_, otherCidr, e := net.ParseCIDR(helper.PString(other.DestinationCidrBlock))
+		if e != nil || len(otherCidr.IP) != len(cidr.IP) {
+			continue
+		}
+		otherOnes, _ := otherCidr.Mask.Size()
+		switch {
+		case cidr.String() == otherCidr.String() && helper.PUint64(route.RoutePriority) == helper.PUint64(other.RoutePriority):
+			if equal == nil {
+				equal = other
+			}
+		case ones > 0 && ones < otherOnes && cidr.Contains(otherCidr.IP):
+			overlaps = append(overlaps, other)
+		}
+	}
+	return
+}
+
+func ccnRouteConflictMessage(ccnId, routeId string, other *vpc.CcnRoute) string {
+	return fmt.Sprintf("route %s of %s cannot be enabled, the enabled route %s to %s %s routes the same destination %s",
+		routeId, ccnId, helper.PString(other.RouteId), helper.PString(other.InstanceType), helper.PString(other.InstanceId),
+		helper.PString(other.DestinationCidrBlock))
+}
+
 func resourceTencentCloudCcnRoutesCreate(d *schema.ResourceData, meta interface{}) error {
 	defer tccommon.LogElapsed("resource.tencentcloud_ccn_routes.create")()
 	defer tccommon.InconsistentCheck(d, meta)()
@@ -103,7 +217,9 @@ func resourceTencentCloudCcnRoutesUpdate(d *schema.ResourceData, meta interface{
 	defer tccommon.InconsistentCheck(d, meta)()
 
 	var (
-		logId = tccommon.GetLogId(tccommon.ContextNil)
+		logId   = tccommon.GetLogId(tccommon.ContextNil)
+		ctx     = context.WithValue(context.TODO(), tccommon.LogIdKey, logId)
+		service = VpcService{client: meta.(tccommon.ProviderMeta).GetAPIV3Conn()}
 	)
 
 	idSplit := strings.Split(d.Id(), tccommon.FILED_SP)
@@ -133,6 +249,19 @@ func resourceTencentCloudCcnRoutesUpdate(d *schema.ResourceData, meta interface{
 			log.Printf("[CRITAL]%s update vpc ccnRoutes failed, reason:%+v", logId, err)
 			return err
 		}
+
+		// the CCN does not enable the route while another enabled route goes to the same destination
+		routes, err := describeCcnRoutesOfCcn(ctx, &service, ccnId)
+		if err != nil {
+			return err
+		}
+		if equal, _ := checkCcnRouteConflicts(routeId, routes); equal != nil {
+			for _, route := range routes {
+				if helper.PString(route.RouteId) == routeId && (route.Enabled == nil || !*route.Enabled) {
+					return fmt.Errorf("route conflict: %s", ccnRouteConflictMessage(ccnId, routeId, equal))
+				}
+			}
+		}
 	} else {
 		request := vpc.NewDisableCcnRoutesRequest()
 		request.CcnId = &ccnId
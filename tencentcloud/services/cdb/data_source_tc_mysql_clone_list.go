@@ -177,7 +177,7 @@ func dataSourceTencentCloudMysqlCloneListRead(d *schema.ResourceData, meta inter
 	d.SetId(helper.DataResourceIdsHash([]string{instanceId}))
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), tmpList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), tmpList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
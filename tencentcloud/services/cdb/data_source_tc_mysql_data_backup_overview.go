@@ -183,7 +183,7 @@ func dataSourceTencentCloudMysqlDataBackupOverviewRead(d *schema.ResourceData, m
 	d.SetId(helper.DataResourceIdsHash([]string{product}))
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), dataBackupOverview); e != nil {
+		if e := tccommon.WriteToFile(output.(string), dataBackupOverview, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
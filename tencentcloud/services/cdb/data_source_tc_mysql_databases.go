@@ -141,7 +141,7 @@ func dataSourceTencentCloudMysqlDatabasesRead(d *schema.ResourceData, meta inter
 		if e := tccommon.WriteToFile(output.(string), map[string]interface{}{
 			"items":         databases.Items,
 			"database_list": tmpList,
-		}); e != nil {
+		}, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
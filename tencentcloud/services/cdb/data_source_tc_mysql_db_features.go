@@ -152,7 +152,7 @@ func dataSourceTencentCloudMysqlDbFeaturesRead(d *schema.ResourceData, meta inte
 	d.SetId(instanceId)
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), d); e != nil {
+		if e := tccommon.WriteToFile(output.(string), d, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
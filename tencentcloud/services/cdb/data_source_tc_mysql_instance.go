@@ -10,6 +10,7 @@ import (
 	cdb "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/cdb/v20170320"
 
 	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/internal/helper"
+	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/ratelimit"
 )
 
 func DataSourceTencentCloudMysqlInstance() *schema.Resource {
@@ -100,6 +101,12 @@ func DataSourceTencentCloudMysqlInstance() *schema.Resource {
 				ValidateFunc: tccommon.ValidateIntegerInRange(1, 2000),
 				Description:  "Number of results returned for a single request. Default is `20`, and maximum is 2000.",
 			},
+			"fetch_all": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to return all the instances page by page, `offset` and `limit` are ignored if true. Default is `false`.",
+			},
 			"result_output_file": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -358,22 +365,42 @@ func dataSourceTencentCloudMysqlInstanceRead(d *schema.ResourceData, meta interf
 		withMasterValue := int64(withMaster.(int))
 		request.WithMaster = &withMasterValue
 	}
-	offset := d.Get("offset")
-	offsetValue := uint64(offset.(int))
-	request.Offset = &offsetValue
-	limit := d.Get("limit")
-	limitValue := uint64(limit.(int))
-	request.Limit = &limitValue
-
 	client := meta.(tccommon.ProviderMeta).GetAPIV3Conn()
-	response, err := client.UseMysqlClient().DescribeDBInstances(request)
+	describe := func(offset, limit int) ([]*cdb.InstanceInfo, int, error) {
+		request.Offset = helper.IntUint64(offset)
+		request.Limit = helper.IntUint64(limit)
+		ratelimit.Check(request.GetAction())
+		response, err := client.UseMysqlClient().DescribeDBInstances(request)
+		if err != nil {
+			log.Printf("[CRITAL]%s api[%s] fail, request body [%s], reason[%s]\n",
+				logId, request.GetAction(), request.ToJsonString(), err.Error())
+			return nil, 0, fmt.Errorf("api[DescribeDBInstances]fail, return %s", err.Error())
+		}
+
+		total := -1
+		if response.Response.TotalCount != nil {
+			total = int(*response.Response.TotalCount)
+		}
+		return response.Response.Items, total, nil
+	}
+
+	var (
+		instanceDetails []*cdb.InstanceInfo
+		err             error
+	)
+	if d.Get("fetch_all").(bool) {
+		instanceDetails, err = tccommon.DescribeAllPages(2000, describe)
+	} else {
+		instanceDetails, _, err = describe(d.Get("offset").(int), d.Get("limit").(int))
+	}
 	if err != nil {
-		log.Printf("[CRITAL]%s api[%s] fail, request body [%s], reason[%s]\n",
-			logId, request.GetAction(), request.ToJsonString(), err.Error())
-		return fmt.Errorf("api[DescribeDBInstances]fail, return %s", err.Error())
+		return err
 	}
 
-	instanceDetails := response.Response.Items
+	tccommon.SortByKey(instanceDetails, func(item *cdb.InstanceInfo) string {
+		return *item.InstanceId
+	})
+
 	instanceList := make([]map[string]interface{}, 0, len(instanceDetails))
 	ids := make([]string, 0, len(instanceDetails))
 	for _, item := range instanceDetails {
@@ -446,7 +473,7 @@ func dataSourceTencentCloudMysqlInstanceRead(d *schema.ResourceData, meta interf
 	}
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		err = tccommon.WriteToFile(output.(string), instanceList)
+		err = tccommon.WriteToFile(output.(string), instanceList, tccommon.GetResultOutputOption(d))
 		if err != nil {
 			return err
 		}
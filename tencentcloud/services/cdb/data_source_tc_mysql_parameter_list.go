@@ -151,7 +151,7 @@ func dataSourceTencentMysqlParameterListRead(d *schema.ResourceData, meta interf
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if err = tccommon.WriteToFile(output.(string), parameterList); err != nil {
+		if err = tccommon.WriteToFile(output.(string), parameterList, tccommon.GetResultOutputOption(d)); err != nil {
 			return err
 		}
 	}
@@ -329,7 +329,7 @@ func dataSourceTencentMysqlZoneConfigRead(d *schema.ResourceData, meta interface
 	d.SetId("zoneconfig" + region)
 
 	if output, ok := d.GetOk("result_output_file"); ok && output.(string) != "" {
-		if err := tccommon.WriteToFile(output.(string), zoneConfigs); err != nil {
+		if err := tccommon.WriteToFile(output.(string), zoneConfigs, tccommon.GetResultOutputOption(d)); err != nil {
 			log.Printf("[CRITAL]%s output file[%s] fail, reason[%s]\n",
 				logId, output.(string), err.Error())
 		}
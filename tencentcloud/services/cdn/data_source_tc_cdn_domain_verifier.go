@@ -153,7 +153,7 @@ func resourceTencentCloudCdnDomainVerifyRecordRead(d *schema.ResourceData, meta
 			"file_verify_domains": response.FileVerifyDomains,
 			"file_verify_name":    response.FileVerifyName,
 		}
-		if err := tccommon.WriteToFile(output.(string), result); err != nil {
+		if err := tccommon.WriteToFile(output.(string), result, tccommon.GetResultOutputOption(d)); err != nil {
 			log.Printf("[CRITAL]%s output file[%s] fail, reason[%v]",
 				logId, output.(string), err)
 			return err
@@ -0,0 +1,137 @@
+package cdn
+
+import (
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	tccommon "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/common"
+	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/internal/helper"
+)
+
+func DataSourceTencentCloudCdnSignedUrl() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceTencentCloudCdnSignedUrlRead,
+
+		Schema: map[string]*schema.Schema{
+			"url": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Url to sign, such as `https://www.example.com/dir/example.mp4`.",
+			},
+			"auth_type": {
+				Type:     schema.TypeString,
+				Required: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					helper.CDN_AUTH_TYPE_A, helper.CDN_AUTH_TYPE_B, helper.CDN_AUTH_TYPE_C, helper.CDN_AUTH_TYPE_D,
+				}, false),
+				Description: "Timestamp hotlink protection mode of the domain, matching the `authentication` block of `tencentcloud_cdn_domain`. Valid values: `TypeA`, `TypeB`, `TypeC`, `TypeD`.",
+			},
+			"secret_key": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Sensitive:   true,
+				Description: "The key for signature calculation, `secret_key` or `backup_secret_key` of the authentication mode.",
+			},
+			"sign_param": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Signature parameter name of `TypeA` and `TypeD`. Default is `sign`.",
+			},
+			"time_param": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Timestamp parameter name of `TypeD`. Default is `t`.",
+			},
+			"time_format": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"dec", "hex"}, false),
+				Description:  "Timestamp format of `TypeC` and `TypeD`, available values: `dec`, `hex`. Default is `hex` for `TypeC` and `dec` for `TypeD`.",
+			},
+			"rand": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Random string of `TypeA`, made of digits and letters. Default is `0`.",
+			},
+			"uid": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "User ID of `TypeA`. Default is `0`.",
+			},
+			"timestamp": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsRFC3339Time,
+				Description:  "Time the url takes effect from, in the format of RFC3339. Default is the time of reading the data source.",
+			},
+			"expire_time": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Signature expiration time in second configured for the domain, only used to export `expiration_time`.",
+			},
+			"signed_url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The signed url.",
+			},
+			"expiration_time": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Expiration time of the signed url in the format of RFC3339, empty if `expire_time` is not set.",
+			},
+			"result_output_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Used to save results.",
+			},
+		},
+	}
+}
+
+func dataSourceTencentCloudCdnSignedUrlRead(d *schema.ResourceData, meta interface{}) error {
+	defer tccommon.LogElapsed("data_source.tencentcloud_cdn_signed_url.read")()
+
+	rawUrl := d.Get("url").(string)
+	timestamp := time.Now()
+	if v, ok := d.GetOk("timestamp"); ok {
+		timestamp, _ = time.Parse(time.RFC3339, v.(string))
+	}
+
+	signedUrl, err := helper.CdnAuthSignUrl(rawUrl, timestamp, helper.CdnAuthOption{
+		Type:       d.Get("auth_type").(string),
+		SecretKey:  d.Get("secret_key").(string),
+		SignParam:  d.Get("sign_param").(string),
+		TimeParam:  d.Get("time_param").(string),
+		TimeFormat: d.Get("time_format").(string),
+		Rand:       d.Get("rand").(string),
+		Uid:        d.Get("uid").(string),
+	})
+	if err != nil {
+		return err
+	}
+
+	var expirationTime string
+	if v, ok := d.GetOk("expire_time"); ok {
+		expirationTime = timestamp.Add(time.Duration(v.(int)) * time.Second).UTC().Format(time.RFC3339)
+	}
+
+	d.SetId(helper.DataResourceIdsHash([]string{rawUrl, d.Get("auth_type").(string)}))
+	_ = d.Set("signed_url", signedUrl)
+	_ = d.Set("expiration_time", expirationTime)
+
+	output, ok := d.GetOk("result_output_file")
+	if ok && output.(string) != "" {
+		if err = tccommon.WriteToFile(output.(string), map[string]interface{}{
+			"url":             rawUrl,
+			"signed_url":      signedUrl,
+			"expiration_time": expirationTime,
+		}, tccommon.GetResultOutputOption(d)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package cdn_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	tcacctest "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/acctest"
+)
+
+func TestAccTencentCloudCdnSignedUrlDataSource_basic(t *testing.T) {
+	t.Parallel()
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			tcacctest.AccPreCheck(t)
+		},
+		Providers: tcacctest.AccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCdnSignedUrlDataSource,
+				Check: resource.ComposeTestCheckFunc(
+					tcacctest.AccCheckTencentCloudDataSourceID("data.tencentcloud_cdn_signed_url.type_a"),
+					resource.TestCheckResourceAttr("data.tencentcloud_cdn_signed_url.type_a", "signed_url", "https://www.example.com/dir/example.mp4?sign=1571587200-0-0-61a22cb36b3c19c6e61518c743f1b314"),
+					resource.TestCheckResourceAttr("data.tencentcloud_cdn_signed_url.type_a", "expiration_time", "2019-10-20T17:00:00Z"),
+					resource.TestCheckResourceAttr("data.tencentcloud_cdn_signed_url.type_b", "signed_url", "https://www.example.com/201910210000/d59002be8f5edf5ae4e79a435b44749f/dir/example.mp4"),
+					resource.TestCheckResourceAttr("data.tencentcloud_cdn_signed_url.type_c", "signed_url", "https://www.example.com/27af5927560c8593d351fd48f34958d8/5dac8480/dir/example.mp4"),
+					resource.TestCheckResourceAttr("data.tencentcloud_cdn_signed_url.type_d", "signed_url", "https://www.example.com/dir/example.mp4?sign=4ee6cd25aed485258d84350664f966ee&t=1571587200"),
+				),
+			},
+		},
+	})
+}
+
+const testAccCdnSignedUrlDataSource = `
+locals {
+  url        = "https://www.example.com/dir/example.mp4"
+  secret_key = "abcdef123"
+  timestamp  = "2019-10-20T16:00:00Z"
+}
+
+data "tencentcloud_cdn_signed_url" "type_a" {
+  url         = local.url
+  auth_type   = "TypeA"
+  secret_key  = local.secret_key
+  timestamp   = local.timestamp
+  expire_time = 3600
+}
+
+data "tencentcloud_cdn_signed_url" "type_b" {
+  url        = local.url
+  auth_type  = "TypeB"
+  secret_key = local.secret_key
+  timestamp  = local.timestamp
+}
+
+data "tencentcloud_cdn_signed_url" "type_c" {
+  url        = local.url
+  auth_type  = "TypeC"
+  secret_key = local.secret_key
+  timestamp  = local.timestamp
+}
+
+data "tencentcloud_cdn_signed_url" "type_d" {
+  url        = local.url
+  auth_type  = "TypeD"
+  secret_key = local.secret_key
+  timestamp  = local.timestamp
+}
+`
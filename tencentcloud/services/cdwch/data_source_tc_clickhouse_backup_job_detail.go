@@ -157,7 +157,7 @@ func dataSourceTencentCloudClickhouseBackupJobDetailRead(d *schema.ResourceData,
 	d.SetId(instanceId + helper.IntToStr(backUpJobId))
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), tmpList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), tmpList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
@@ -942,7 +942,7 @@ func dataSourceTencentCloudCdwdorisInstancesRead(d *schema.ResourceData, meta in
 	d.SetId(helper.DataResourceIdsHash(ids))
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), tmpInstancesList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), tmpInstancesList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
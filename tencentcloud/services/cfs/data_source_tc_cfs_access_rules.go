@@ -121,7 +121,7 @@ func dataSourceTencentCloudCfsAccessRulesRead(d *schema.ResourceData, meta inter
 	}
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if err := tccommon.WriteToFile(output.(string), accessRuleList); err != nil {
+		if err := tccommon.WriteToFile(output.(string), accessRuleList, tccommon.GetResultOutputOption(d)); err != nil {
 			return err
 		}
 	}
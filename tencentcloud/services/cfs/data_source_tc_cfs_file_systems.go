@@ -209,7 +209,7 @@ func dataSourceTencentCloudCfsFileSystemsRead(d *schema.ResourceData, meta inter
 	}
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if err := tccommon.WriteToFile(output.(string), fileSystemList); err != nil {
+		if err := tccommon.WriteToFile(output.(string), fileSystemList, tccommon.GetResultOutputOption(d)); err != nil {
 			return err
 		}
 	}
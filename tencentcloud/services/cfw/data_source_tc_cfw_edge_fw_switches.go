@@ -164,7 +164,7 @@ func dataSourceTencentCloudCfwEdgeFwSwitchesRead(d *schema.ResourceData, meta in
 	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), tmpList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), tmpList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
@@ -127,7 +127,7 @@ func dataSourceTencentCloudCfwVpcFwSwitchesRead(d *schema.ResourceData, meta int
 	d.SetId(vpcInsId)
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), tmpList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), tmpList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
@@ -128,7 +128,7 @@ func dataSourceTencentCloudCkafkaAclsRead(d *schema.ResourceData, meta interface
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), aclList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), aclList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
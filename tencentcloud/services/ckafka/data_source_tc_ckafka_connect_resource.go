@@ -1167,7 +1167,7 @@ func dataSourceTencentCloudCkafkaConnectResourceRead(d *schema.ResourceData, met
 	d.SetId(helper.DataResourceIdsHash(ids))
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), describeConnectResourcesRespMap); e != nil {
+		if e := tccommon.WriteToFile(output.(string), describeConnectResourcesRespMap, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
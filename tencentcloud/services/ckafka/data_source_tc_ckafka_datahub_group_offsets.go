@@ -189,7 +189,7 @@ func dataSourceTencentCloudCkafkaDatahubGroupOffsetsRead(d *schema.ResourceData,
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), topicList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), topicList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
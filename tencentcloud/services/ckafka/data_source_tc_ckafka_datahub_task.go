@@ -5178,7 +5178,7 @@ func dataSourceTencentCloudCkafkaDatahubTaskRead(d *schema.ResourceData, meta in
 	_ = d.Set("task_list", taskList)
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), taskList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), taskList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
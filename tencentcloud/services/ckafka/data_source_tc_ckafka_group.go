@@ -112,7 +112,7 @@ func dataSourceTencentCloudCkafkaGroupRead(d *schema.ResourceData, meta interfac
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), groupMapList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), groupMapList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
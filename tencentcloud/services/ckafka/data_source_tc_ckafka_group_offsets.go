@@ -204,7 +204,7 @@ func dataSourceTencentCloudCkafkaGroupOffsetsRead(d *schema.ResourceData, meta i
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), groupOffsetResponseMap); e != nil {
+		if e := tccommon.WriteToFile(output.(string), groupOffsetResponseMap, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
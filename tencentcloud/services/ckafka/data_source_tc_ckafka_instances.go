@@ -377,7 +377,7 @@ func dataSourceTencentCloudCkafkaInstancesRead(d *schema.ResourceData, meta inte
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), result); e != nil {
+		if e := tccommon.WriteToFile(output.(string), result, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
@@ -297,7 +297,7 @@ func dataSourceTencentCloudCkafkaTopicFlowRankingRead(d *schema.ResourceData, me
 	d.SetId(instanceId)
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), topicFlowRankingResultMapList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), topicFlowRankingResultMapList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
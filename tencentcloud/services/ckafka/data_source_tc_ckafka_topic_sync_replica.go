@@ -176,7 +176,7 @@ func dataSourceTencentCloudCkafkaTopicSyncReplicaRead(d *schema.ResourceData, me
 	d.SetId(helper.DataResourceIdsHash(ids))
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), topicInSyncReplicaList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), topicInSyncReplicaList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
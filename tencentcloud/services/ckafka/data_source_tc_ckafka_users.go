@@ -94,7 +94,7 @@ func dataSourceTencentCloudCkafkaUsersRead(d *schema.ResourceData, meta interfac
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), userList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), userList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
@@ -463,7 +463,7 @@ func dataSourceTencentCloudCkafkaZoneRead(d *schema.ResourceData, meta interface
 	d.SetId(helper.DataResourceIdsHash(ids))
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), zoneResponseMapList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), zoneResponseMapList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
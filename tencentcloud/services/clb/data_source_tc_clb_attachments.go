@@ -176,7 +176,7 @@ func dataSourceTencentCloudClbServerAttachmentsRead(d *schema.ResourceData, meta
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), attachmentList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), attachmentList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
@@ -39,6 +39,7 @@ func DataSourceTencentCloudClbInstances() *schema.Resource {
 				Optional:    true,
 				Description: "Project ID of the CLB.",
 			},
+			"filter": tccommon.DataSourceFilterSchema("DescribeLoadBalancers"),
 			"result_output_file": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -215,7 +216,13 @@ func dataSourceTencentCloudClbInstancesRead(d *schema.ResourceData, meta interfa
 		params["master_zone"] = v.(string)
 	}
 
-	err := resource.Retry(tccommon.ReadRetryTimeout, func() *resource.RetryError {
+	filters, err := tccommon.GetDataSourceFilters(d, nil)
+	if err != nil {
+		return err
+	}
+	params["filters"] = filters
+
+	err = resource.Retry(tccommon.ReadRetryTimeout, func() *resource.RetryError {
 		results, e := clbService.DescribeLoadBalancerByFilter(ctx, params)
 		if e != nil {
 			return tccommon.RetryError(e)
@@ -230,6 +237,10 @@ func dataSourceTencentCloudClbInstancesRead(d *schema.ResourceData, meta interfa
 		return err
 	}
 
+	tccommon.SortByKey(clbs, func(clbInstance *clb.LoadBalancer) string {
+		return *clbInstance.LoadBalancerId
+	})
+
 	clbList := make([]map[string]interface{}, 0, len(clbs))
 	ids := make([]string, 0, len(clbs))
 	for _, clbInstance := range clbs {
@@ -289,7 +300,7 @@ func dataSourceTencentCloudClbInstancesRead(d *schema.ResourceData, meta interfa
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), clbList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), clbList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
@@ -32,6 +32,8 @@ func TestAccTencentCloudClbInstancesDataSource_internal(t *testing.T) {
 					resource.TestCheckResourceAttrSet("data.tencentcloud_clb_instances.clbs", "clb_list.0.status_time"),
 					resource.TestCheckResourceAttrSet("data.tencentcloud_clb_instances.clbs", "clb_list.0.status"),
 					resource.TestCheckResourceAttr("data.tencentcloud_clb_instances.clbs", "clb_list.0.tags.test", "tf"),
+					resource.TestCheckResourceAttr("data.tencentcloud_clb_instances.clbs_filter", "clb_list.#", "1"),
+					resource.TestCheckResourceAttr("data.tencentcloud_clb_instances.clbs_filter", "clb_list.0.clb_name", "tf-clb-data-internal"),
 				),
 			},
 		},
@@ -103,6 +105,15 @@ resource "tencentcloud_clb_instance" "clb" {
 data "tencentcloud_clb_instances" "clbs" {
   clb_id = tencentcloud_clb_instance.clb.id
 }
+
+data "tencentcloud_clb_instances" "clbs_filter" {
+  network_type = "INTERNAL"
+
+  filter {
+    name   = "vpc-id"
+    values = [tencentcloud_clb_instance.clb.vpc_id]
+  }
+}
 `
 
 const testAccClbInstancesDataSource_open = `
@@ -157,7 +157,7 @@ func dataSourceTencentCloudClbRedirectionsRead(d *schema.ResourceData, meta inte
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), redirectionList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), redirectionList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
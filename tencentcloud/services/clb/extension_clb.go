@@ -145,3 +145,30 @@ const (
 	CLB_SESSION_TYPE_NORMAL = "NORMAL"
 	CLB_SESSION_TYPE_QUIC   = "QUIC_CID"
 )
+
+const (
+	CLB_TRAFFIC_SHIFT_STEP_SUCCESS     = "SUCCESS"
+	CLB_TRAFFIC_SHIFT_STEP_FAILED      = "FAILED"
+	CLB_TRAFFIC_SHIFT_STEP_ROLLED_BACK = "ROLLED_BACK"
+)
+
+const (
+	CLB_TRAFFIC_SHIFT_COMPARISON_GT = "GT"
+	CLB_TRAFFIC_SHIFT_COMPARISON_GE = "GE"
+	CLB_TRAFFIC_SHIFT_COMPARISON_LT = "LT"
+	CLB_TRAFFIC_SHIFT_COMPARISON_LE = "LE"
+)
+
+var CLB_TRAFFIC_SHIFT_COMPARISON = []string{
+	CLB_TRAFFIC_SHIFT_COMPARISON_GT,
+	CLB_TRAFFIC_SHIFT_COMPARISON_GE,
+	CLB_TRAFFIC_SHIFT_COMPARISON_LT,
+	CLB_TRAFFIC_SHIFT_COMPARISON_LE,
+}
+
+const (
+	CLB_TRAFFIC_SHIFT_MAX_WEIGHT    = 100
+	CLB_TRAFFIC_SHIFT_HISTORY_LIMIT = 100
+	// CLB_TARGET_GROUP_ASSOCIATION_DEFAULT_WEIGHT is the weight of a target group association if it is not set
+	CLB_TARGET_GROUP_ASSOCIATION_DEFAULT_WEIGHT = 10
+)
@@ -0,0 +1,582 @@
+package clb
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	tccommon "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func ResourceTencentCloudClbListenerRules() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceTencentCloudClbListenerRulesCreate,
+		Read:   resourceTencentCloudClbListenerRulesRead,
+		Update: resourceTencentCloudClbListenerRulesUpdate,
+		Delete: resourceTencentCloudClbListenerRulesDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		CustomizeDiff: resourceTencentCloudClbListenerRulesCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"clb_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of CLB instance.",
+			},
+			"listener_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the HTTP or HTTPS listener.",
+			},
+			"delete_unmanaged": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to delete the rules of the listener which are not declared in `rule`, such as the rules added in the console. Default is `false`, the undeclared rules are left alone and listed in `unmanaged_rules`.",
+			},
+			"rule": {
+				Type:        schema.TypeList,
+				Required:    true,
+				Description: "Rules of the listener. Each rule is identified by `domain` and `url`, the rules of the same domain must have the same certificate and HTTP2 settings.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"domain": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Domain name of the rule.",
+						},
+						"url": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Url of the rule.",
+						},
+						"scheduler": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      CLB_LISTENER_SCHEDULER_WRR,
+							ValidateFunc: tccommon.ValidateAllowedStringValue(CLB_LISTENER_SCHEDULER),
+							Description:  "Scheduling method of the rule. Valid values: `WRR`, `IP_HASH`, `LEAST_CONN`. The default is `WRR`.",
+						},
+						"session_expire_time": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: tccommon.ValidateIntegerInRange(0, 3600),
+							Description:  "Time of session persistence. Valid value ranges: [30~3600] sec, `0` means session persistence is disabled. Default is `0`.",
+						},
+						"forward_type": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: tccommon.ValidateAllowedStringValue([]string{"HTTP", "HTTPS", "GRPC", "GRPCS", "TRPC"}),
+							Description:  "Forwarding protocol between the CLB instance and real server. Valid values: `HTTP`, `HTTPS`, `GRPC`, `GRPCS`, `TRPC`. The default is `HTTP`.",
+						},
+						"target_type": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      CLB_TARGET_TYPE_NODE,
+							ValidateFunc: tccommon.ValidateAllowedStringValue([]string{CLB_TARGET_TYPE_NODE, CLB_TARGET_TYPE_TARGETGROUP}),
+							Description:  "Backend target type. Valid values: `NODE`, `TARGETGROUP`. Changing it deletes the rule and creates it again.",
+						},
+						"certificate_ssl_mode": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: tccommon.ValidateAllowedStringValue(CERT_SSL_MODE),
+							Description:  "Type of certificate of the domain. Valid values: `UNIDIRECTIONAL`, `MUTUAL`. NOTES: Only supports listeners of HTTPS protocol.",
+						},
+						"certificate_id": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Computed:    true,
+							Description: "ID of the server certificate of the domain. NOTES: Only supports listeners of HTTPS protocol.",
+						},
+						"certificate_ca_id": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Computed:    true,
+							Description: "ID of the client certificate of the domain. NOTES: Only supports listeners of HTTPS protocol.",
+						},
+						"http2_switch": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Computed:    true,
+							Description: "Indicate to apply HTTP2.0 protocol to the domain or not.",
+						},
+						"health_check": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Computed:    true,
+							MaxItems:    1,
+							Description: "Health check of the rule. The health check is left alone if it is not specified.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"switch": {
+										Type:        schema.TypeBool,
+										Optional:    true,
+										Default:     true,
+										Description: "Indicates whether health check is enabled. Default is `true`.",
+									},
+									"interval_time": {
+										Type:         schema.TypeInt,
+										Optional:     true,
+										Computed:     true,
+										ValidateFunc: tccommon.ValidateIntegerInRange(2, 300),
+										Description:  "Interval time of health check. Valid value ranges: [2~300] sec.",
+									},
+									"health_num": {
+										Type:         schema.TypeInt,
+										Optional:     true,
+										Computed:     true,
+										ValidateFunc: tccommon.ValidateIntegerInRange(2, 10),
+										Description:  "Health threshold of health check. Valid value ranges: [2~10].",
+									},
+									"unhealth_num": {
+										Type:         schema.TypeInt,
+										Optional:     true,
+										Computed:     true,
+										ValidateFunc: tccommon.ValidateIntegerInRange(2, 10),
+										Description:  "Unhealthy threshold of health check. Valid value ranges: [2~10].",
+									},
+									"time_out": {
+										Type:         schema.TypeInt,
+										Optional:     true,
+										Computed:     true,
+										ValidateFunc: tccommon.ValidateIntegerInRange(2, 60),
+										Description:  "Time out of health check. Valid value ranges: [2~60] sec.",
+									},
+									"http_code": {
+										Type:         schema.TypeInt,
+										Optional:     true,
+										Computed:     true,
+										ValidateFunc: tccommon.ValidateIntegerInRange(1, 31),
+										Description:  "HTTP status codes regarded as healthy, the sum of `1` (1xx), `2` (2xx), `4` (3xx), `8` (4xx) and `16` (5xx).",
+									},
+									"http_check_path": {
+										Type:        schema.TypeString,
+										Optional:    true,
+										Computed:    true,
+										Description: "Path of health check.",
+									},
+									"http_check_domain": {
+										Type:        schema.TypeString,
+										Optional:    true,
+										Computed:    true,
+										Description: "Domain name of health check.",
+									},
+									"http_check_method": {
+										Type:         schema.TypeString,
+										Optional:     true,
+										Computed:     true,
+										ValidateFunc: tccommon.ValidateAllowedStringValue(CLB_HTTP_METHOD),
+										Description:  "Methods of health check. Valid values: `HEAD`, `GET`.",
+									},
+									"type": {
+										Type:         schema.TypeString,
+										Optional:     true,
+										Computed:     true,
+										ValidateFunc: tccommon.ValidateAllowedStringValue(HEALTH_CHECK_TYPE),
+										Description:  "Type of health check. Valid values: `CUSTOM`, `PING`, `TCP`, `HTTP`, `HTTPS`, `GRPC`, `GRPCS`.",
+									},
+								},
+							},
+						},
+						"targets": {
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Description: "Backends of the rule when `target_type` is `NODE`.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"instance_id": {
+										Type:        schema.TypeString,
+										Optional:    true,
+										Description: "ID of the backend CVM instance. Conflicts with `eni_ip`.",
+									},
+									"eni_ip": {
+										Type:        schema.TypeString,
+										Optional:    true,
+										Description: "IP of the backend ENI. Conflicts with `instance_id`.",
+									},
+									"port": {
+										Type:         schema.TypeInt,
+										Required:     true,
+										ValidateFunc: tccommon.ValidateIntegerInRange(0, 65535),
+										Description:  "Port of the backend.",
+									},
+									"weight": {
+										Type:         schema.TypeInt,
+										Optional:     true,
+										Default:      10,
+										ValidateFunc: tccommon.ValidateIntegerInRange(0, 100),
+										Description:  "Forwarding weight of the backend. Valid value ranges: [0~100]. Default is `10`.",
+									},
+								},
+							},
+						},
+						"target_group_id": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "ID of the target group bound to the rule when `target_type` is `TARGETGROUP`.",
+						},
+						"location_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ID of the rule.",
+						},
+					},
+				},
+			},
+
+			// computed
+			"unmanaged_rules": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Rules of the listener which are not declared in `rule`. It is always empty when `delete_unmanaged` is `true`.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"domain": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Domain name of the rule.",
+						},
+						"url": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Url of the rule.",
+						},
+						"location_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ID of the rule.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceTencentCloudClbListenerRulesCreate(d *schema.ResourceData, meta interface{}) error {
+	defer tccommon.LogElapsed("resource.tencentcloud_clb_listener_rules.create")()
+
+	clbActionMu.Lock()
+	defer clbActionMu.Unlock()
+
+	logId := tccommon.GetLogId(tccommon.ContextNil)
+	ctx := context.WithValue(context.TODO(), tccommon.LogIdKey, logId)
+	service := ClbService{client: meta.(tccommon.ProviderMeta).GetAPIV3Conn()}
+
+	var (
+		clbId      = d.Get("clb_id").(string)
+		listenerId = d.Get("listener_id").(string)
+	)
+
+	protocol, current, err := service.DescribeClbListenerRules(ctx, clbId, listenerId)
+	if err != nil {
+		return err
+	}
+	if protocol != CLB_LISTENER_PROTOCOL_HTTP && protocol != CLB_LISTENER_PROTOCOL_HTTPS {
+		return fmt.Errorf("listener %s of CLB %s is not a HTTP or HTTPS listener", listenerId, clbId)
+	}
+
+	desired := expandClbListenerRules(d.Get("rule").([]interface{}))
+	err = service.ApplyClbListenerRules(ctx, clbId, listenerId, current, desired, nil, d.Get("delete_unmanaged").(bool))
+	// the id is set before checking the error, so that the rules which have been created are kept in state
+	d.SetId(strings.Join([]string{clbId, listenerId}, tccommon.FILED_SP))
+	if err != nil {
+		return err
+	}
+
+	return resourceTencentCloudClbListenerRulesRead(d, meta)
+}
+
+func resourceTencentCloudClbListenerRulesRead(d *schema.ResourceData, meta interface{}) error {
+	defer tccommon.LogElapsed("resource.tencentcloud_clb_listener_rules.read")()
+	defer tccommon.InconsistentCheck(d, meta)()
+
+	logId := tccommon.GetLogId(tccommon.ContextNil)
+	ctx := context.WithValue(context.TODO(), tccommon.LogIdKey, logId)
+	service := ClbService{client: meta.(tccommon.ProviderMeta).GetAPIV3Conn()}
+
+	ids := strings.Split(d.Id(), tccommon.FILED_SP)
+	if len(ids) != 2 {
+		return fmt.Errorf("id is broken, id is %s", d.Id())
+	}
+	clbId, listenerId := ids[0], ids[1]
+
+	protocol, current, err := service.DescribeClbListenerRules(ctx, clbId, listenerId)
+	if err != nil {
+		return err
+	}
+	if protocol == "" {
+		d.SetId("")
+		return nil
+	}
+
+	currentByKey := make(map[string]*ClbListenerRule, len(current))
+	for _, rule := range current {
+		currentByKey[rule.Key()] = rule
+	}
+
+	// keep the order of the rules in state, the rules out of state are appended when importing or
+	// deleting unmanaged rules, so that they show up in the plan
+	stateRules := expandClbListenerRules(d.Get("rule").([]interface{}))
+	managed := make(map[string]bool, len(stateRules))
+	rules := make([]interface{}, 0, len(current))
+	for _, item := range stateRules {
+		if rule, ok := currentByKey[item.Key()]; ok && !managed[item.Key()] {
+			managed[item.Key()] = true
+			rules = append(rules, flattenClbListenerRule(rule))
+		}
+	}
+
+	var unmanagedRules []interface{}
+	showUnmanaged := len(stateRules) == 0 || d.Get("delete_unmanaged").(bool)
+	sort.Slice(current, func(i, j int) bool { return current[i].Key() < current[j].Key() })
+	for _, rule := range current {
+		if managed[rule.Key()] {
+			continue
+		}
+		if showUnmanaged {
+			rules = append(rules, flattenClbListenerRule(rule))
+			continue
+		}
+		unmanagedRules = append(unmanagedRules, map[string]interface{}{
+			"domain":      rule.Domain,
+			"url":         rule.Url,
+			"location_id": rule.LocationId,
+		})
+	}
+
+	_ = d.Set("clb_id", clbId)
+	_ = d.Set("listener_id", listenerId)
+	_ = d.Set("rule", rules)
+	_ = d.Set("unmanaged_rules", unmanagedRules)
+
+	return nil
+}
+
+func resourceTencentCloudClbListenerRulesUpdate(d *schema.ResourceData, meta interface{}) error {
+	defer tccommon.LogElapsed("resource.tencentcloud_clb_listener_rules.update")()
+
+	if !d.HasChange("rule") && !d.HasChange("delete_unmanaged") {
+		return resourceTencentCloudClbListenerRulesRead(d, meta)
+	}
+
+	clbActionMu.Lock()
+	defer clbActionMu.Unlock()
+
+	logId := tccommon.GetLogId(tccommon.ContextNil)
+	ctx := context.WithValue(context.TODO(), tccommon.LogIdKey, logId)
+	service := ClbService{client: meta.(tccommon.ProviderMeta).GetAPIV3Conn()}
+
+	var (
+		clbId      = d.Get("clb_id").(string)
+		listenerId = d.Get("listener_id").(string)
+	)
+
+	_, current, err := service.DescribeClbListenerRules(ctx, clbId, listenerId)
+	if err != nil {
+		return err
+	}
+
+	o, n := d.GetChange("rule")
+	managed := make(map[string]bool)
+	for _, rule := range expandClbListenerRules(o.([]interface{})) {
+		managed[rule.Key()] = true
+	}
+	desired := expandClbListenerRules(n.([]interface{}))
+	err = service.ApplyClbListenerRules(ctx, clbId, listenerId, current, desired, managed, d.Get("delete_unmanaged").(bool))
+	if err != nil {
+		return err
+	}
+
+	return resourceTencentCloudClbListenerRulesRead(d, meta)
+}
+
+func resourceTencentCloudClbListenerRulesDelete(d *schema.ResourceData, meta interface{}) error {
+	defer tccommon.LogElapsed("resource.tencentcloud_clb_listener_rules.delete")()
+
+	clbActionMu.Lock()
+	defer clbActionMu.Unlock()
+
+	logId := tccommon.GetLogId(tccommon.ContextNil)
+	ctx := context.WithValue(context.TODO(), tccommon.LogIdKey, logId)
+	service := ClbService{client: meta.(tccommon.ProviderMeta).GetAPIV3Conn()}
+
+	var (
+		clbId      = d.Get("clb_id").(string)
+		listenerId = d.Get("listener_id").(string)
+	)
+
+	protocol, current, err := service.DescribeClbListenerRules(ctx, clbId, listenerId)
+	if err != nil {
+		return err
+	}
+	if protocol == "" {
+		return nil
+	}
+
+	// only the rules in state are deleted
+	managed := make(map[string]bool)
+	for _, rule := range expandClbListenerRules(d.Get("rule").([]interface{})) {
+		managed[rule.Key()] = true
+	}
+	var rules []*ClbListenerRule
+	for _, rule := range current {
+		if managed[rule.Key()] {
+			rules = append(rules, rule)
+		}
+	}
+
+	return service.deleteClbListenerRules(ctx, clbId, listenerId, rules)
+}
+
+func resourceTencentCloudClbListenerRulesCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	rules := expandClbListenerRules(d.Get("rule").([]interface{}))
+
+	keys := make(map[string]bool, len(rules))
+	domains := make(map[string]*ClbListenerRule)
+	for _, rule := range rules {
+		if keys[rule.Key()] {
+			return fmt.Errorf("rule %s is declared more than once", rule)
+		}
+		keys[rule.Key()] = true
+
+		if rule.TargetType == CLB_TARGET_TYPE_TARGETGROUP && len(rule.Targets) > 0 {
+			return fmt.Errorf("rule %s can not have `targets` when `target_type` is `%s`", rule, CLB_TARGET_TYPE_TARGETGROUP)
+		}
+		if rule.TargetType == CLB_TARGET_TYPE_NODE && rule.TargetGroupId != "" {
+			return fmt.Errorf("rule %s can not have `target_group_id` when `target_type` is `%s`", rule, CLB_TARGET_TYPE_NODE)
+		}
+		for _, target := range rule.Targets {
+			if (target.InstanceId == "") == (target.EniIp == "") {
+				return fmt.Errorf("targets of rule %s must have exactly one of `instance_id` and `eni_ip`", rule)
+			}
+		}
+
+		// the certificate and http2 are the attributes of the domain
+		domain := strings.ToLower(rule.Domain)
+		first, ok := domains[domain]
+		if !ok {
+			domains[domain] = rule
+			continue
+		}
+		if first.Http2 != rule.Http2 || (first.Certificate == nil) != (rule.Certificate == nil) ||
+			(first.Certificate != nil && *first.Certificate != *rule.Certificate) {
+			return fmt.Errorf("rules %s and %s of the same domain must have the same certificate and `http2_switch`", first, rule)
+		}
+	}
+	return nil
+}
+
+func expandClbListenerRules(items []interface{}) []*ClbListenerRule {
+	rules := make([]*ClbListenerRule, 0, len(items))
+	for _, item := range items {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		rule := &ClbListenerRule{
+			LocationId:        m["location_id"].(string),
+			Domain:            m["domain"].(string),
+			Url:               m["url"].(string),
+			Scheduler:         m["scheduler"].(string),
+			SessionExpireTime: int64(m["session_expire_time"].(int)),
+			ForwardType:       m["forward_type"].(string),
+			TargetType:        m["target_type"].(string),
+			Http2:             m["http2_switch"].(bool),
+			TargetGroupId:     m["target_group_id"].(string),
+		}
+		if certId := m["certificate_id"].(string); certId != "" {
+			rule.Certificate = &ClbRuleCertificate{
+				SSLMode:  m["certificate_ssl_mode"].(string),
+				CertId:   certId,
+				CertCaId: m["certificate_ca_id"].(string),
+			}
+			if rule.Certificate.SSLMode == "" {
+				rule.Certificate.SSLMode = CERT_SSL_MODE_UNI
+			}
+		}
+		if healthChecks, ok := m["health_check"].([]interface{}); ok && len(healthChecks) > 0 && healthChecks[0] != nil {
+			healthCheck := healthChecks[0].(map[string]interface{})
+			rule.HealthCheck = &ClbRuleHealthCheck{
+				Enabled:         healthCheck["switch"].(bool),
+				IntervalTime:    int64(healthCheck["interval_time"].(int)),
+				HealthNum:       int64(healthCheck["health_num"].(int)),
+				UnHealthNum:     int64(healthCheck["unhealth_num"].(int)),
+				TimeOut:         int64(healthCheck["time_out"].(int)),
+				HttpCode:        int64(healthCheck["http_code"].(int)),
+				HttpCheckPath:   healthCheck["http_check_path"].(string),
+				HttpCheckDomain: healthCheck["http_check_domain"].(string),
+				HttpCheckMethod: healthCheck["http_check_method"].(string),
+				CheckType:       healthCheck["type"].(string),
+			}
+		}
+		if targets, ok := m["targets"].(*schema.Set); ok {
+			for _, target := range targets.List() {
+				t := target.(map[string]interface{})
+				rule.Targets = append(rule.Targets, ClbRuleTarget{
+					InstanceId: t["instance_id"].(string),
+					EniIp:      t["eni_ip"].(string),
+					Port:       int64(t["port"].(int)),
+					Weight:     int64(t["weight"].(int)),
+				})
+			}
+		}
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+func flattenClbListenerRule(rule *ClbListenerRule) map[string]interface{} {
+	m := map[string]interface{}{
+		"location_id":         rule.LocationId,
+		"domain":              rule.Domain,
+		"url":                 rule.Url,
+		"scheduler":           rule.Scheduler,
+		"session_expire_time": rule.SessionExpireTime,
+		"forward_type":        rule.ForwardType,
+		"target_type":         rule.TargetType,
+		"http2_switch":        rule.Http2,
+		"target_group_id":     rule.TargetGroupId,
+	}
+	if rule.Certificate != nil {
+		m["certificate_ssl_mode"] = rule.Certificate.SSLMode
+		m["certificate_id"] = rule.Certificate.CertId
+		m["certificate_ca_id"] = rule.Certificate.CertCaId
+	}
+	if rule.HealthCheck != nil {
+		m["health_check"] = []interface{}{
+			map[string]interface{}{
+				"switch":            rule.HealthCheck.Enabled,
+				"interval_time":     rule.HealthCheck.IntervalTime,
+				"health_num":        rule.HealthCheck.HealthNum,
+				"unhealth_num":      rule.HealthCheck.UnHealthNum,
+				"time_out":          rule.HealthCheck.TimeOut,
+				"http_code":         rule.HealthCheck.HttpCode,
+				"http_check_path":   rule.HealthCheck.HttpCheckPath,
+				"http_check_domain": rule.HealthCheck.HttpCheckDomain,
+				"http_check_method": rule.HealthCheck.HttpCheckMethod,
+				"type":              rule.HealthCheck.CheckType,
+			},
+		}
+	}
+	targets := make([]interface{}, 0, len(rule.Targets))
+	for _, target := range rule.Targets {
+		targets = append(targets, map[string]interface{}{
+			"instance_id": target.InstanceId,
+			"eni_ip":      target.EniIp,
+			"port":        target.Port,
+			"weight":      target.Weight,
+		})
+	}
+	m["targets"] = targets
+	return m
+}
@@ -0,0 +1,112 @@
+package clb_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	tcacctest "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/acctest"
+)
+
+func TestAccTencentCloudClbListenerRulesResource_basic(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			tcacctest.AccPreCheck(t)
+		},
+		Providers: tcacctest.AccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccClbListenerRules,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("tencentcloud_clb_listener_rules.rules", "id"),
+					resource.TestCheckResourceAttr("tencentcloud_clb_listener_rules.rules", "rule.#", "2"),
+					resource.TestCheckResourceAttr("tencentcloud_clb_listener_rules.rules", "rule.0.domain", "abc.com"),
+					resource.TestCheckResourceAttr("tencentcloud_clb_listener_rules.rules", "rule.0.health_check.0.http_check_path", "/health"),
+					resource.TestCheckResourceAttrSet("tencentcloud_clb_listener_rules.rules", "rule.0.location_id"),
+					resource.TestCheckResourceAttr("tencentcloud_clb_listener_rules.rules", "rule.1.url", "/api"),
+				),
+			},
+			{
+				Config: testAccClbListenerRulesUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tencentcloud_clb_listener_rules.rules", "rule.#", "2"),
+					resource.TestCheckResourceAttr("tencentcloud_clb_listener_rules.rules", "rule.0.scheduler", "LEAST_CONN"),
+					resource.TestCheckResourceAttr("tencentcloud_clb_listener_rules.rules", "rule.1.url", "/static"),
+				),
+			},
+			{
+				ResourceName:            "tencentcloud_clb_listener_rules.rules",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"delete_unmanaged"},
+			},
+		},
+	})
+}
+
+const testAccClbListenerRulesBasic = `
+resource "tencentcloud_clb_instance" "clb" {
+  network_type = "OPEN"
+  clb_name     = "tf-clb-rules"
+}
+
+resource "tencentcloud_clb_listener" "listener" {
+  clb_id        = tencentcloud_clb_instance.clb.id
+  port          = 80
+  protocol      = "HTTP"
+  listener_name = "tf-clb-rules"
+}
+`
+
+const testAccClbListenerRules = testAccClbListenerRulesBasic + `
+resource "tencentcloud_clb_listener_rules" "rules" {
+  clb_id      = tencentcloud_clb_instance.clb.id
+  listener_id = tencentcloud_clb_listener.listener.listener_id
+
+  rule {
+    domain = "abc.com"
+    url    = "/"
+
+    health_check {
+      interval_time   = 5
+      health_num      = 3
+      unhealth_num    = 3
+      http_check_path = "/health"
+    }
+  }
+
+  rule {
+    domain              = "abc.com"
+    url                 = "/api"
+    session_expire_time = 30
+  }
+}
+`
+
+const testAccClbListenerRulesUpdate = testAccClbListenerRulesBasic + `
+resource "tencentcloud_clb_listener_rules" "rules" {
+  clb_id           = tencentcloud_clb_instance.clb.id
+  listener_id      = tencentcloud_clb_listener.listener.listener_id
+  delete_unmanaged = true
+
+  rule {
+    domain    = "abc.com"
+    url       = "/"
+    scheduler = "LEAST_CONN"
+
+    health_check {
+      interval_time   = 10
+      health_num      = 3
+      unhealth_num    = 3
+      http_check_path = "/health"
+    }
+  }
+
+  rule {
+    domain = "abc.com"
+    url    = "/static"
+  }
+}
+`
@@ -0,0 +1,359 @@
+package clb
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	tccommon "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/internal/helper"
+)
+
+func ResourceTencentCloudClbTrafficShift() *schema.Resource {
+	targetsElem := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"instance_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "ID of the backend CVM instance. Conflicts with `eni_ip`.",
+			},
+			"eni_ip": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "IP of the backend ENI. Conflicts with `instance_id`.",
+			},
+			"port": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: tccommon.ValidateIntegerInRange(0, 65535),
+				Description:  "Port of the backend.",
+			},
+		},
+	}
+
+	return &schema.Resource{
+		Create: resourceTencentCloudClbTrafficShiftCreate,
+		Read:   resourceTencentCloudClbTrafficShiftRead,
+		Update: resourceTencentCloudClbTrafficShiftUpdate,
+		Delete: resourceTencentCloudClbTrafficShiftDelete,
+
+		Schema: map[string]*schema.Schema{
+			"clb_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of CLB instance.",
+			},
+			"listener_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of CLB listener.",
+			},
+			"location_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "ID of the forwarding rule. It is required for HTTP and HTTPS listeners.",
+			},
+			"from_target_group_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"from_target_group_id", "from_targets"},
+				RequiredWith: []string{"to_target_group_id"},
+				Description:  "ID of the target group which the traffic is shifted from. Both target groups must be v2 target groups bound to the listener rule, the traffic is split between them by the weights of the bindings.",
+			},
+			"to_target_group_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"to_target_group_id", "to_targets"},
+				RequiredWith: []string{"from_target_group_id"},
+				Description:  "ID of the target group which the traffic is shifted to.",
+			},
+			"from_targets": {
+				Type:         schema.TypeSet,
+				Optional:     true,
+				ForceNew:     true,
+				RequiredWith: []string{"to_targets"},
+				Description:  "Backends which the traffic is shifted from. Both backend sets must be registered to the listener rule.",
+				Elem:         targetsElem,
+			},
+			"to_targets": {
+				Type:         schema.TypeSet,
+				Optional:     true,
+				ForceNew:     true,
+				RequiredWith: []string{"from_targets"},
+				Description:  "Backends which the traffic is shifted to.",
+				Elem:         targetsElem,
+			},
+			"target_percent": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: tccommon.ValidateIntegerInRange(0, 100),
+				Description:  "Percent of the traffic going to the to side. Changing it shifts the traffic from the current percent step by step.",
+			},
+			"step_percent": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      10,
+				ValidateFunc: tccommon.ValidateIntegerInRange(1, 100),
+				Description:  "Percent of the traffic shifted in each step. Default is `10`.",
+			},
+			"step_interval": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      60,
+				ValidateFunc: tccommon.ValidateIntegerInRange(0, 3600),
+				Description:  "Seconds to wait after each step before checking. Default is `60`.",
+			},
+			"check_health": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether to check the CLB health status of the backends taking traffic after each step. Default is `true`.",
+			},
+			"metric_check": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Cloud Monitor metric checked after each step, the step fails if the latest value breaches the threshold.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"namespace": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Namespace of the metric, such as `QCE/LB_PUBLIC`.",
+						},
+						"metric_name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Name of the metric, such as `HttpCode5xx`.",
+						},
+						"dimensions": {
+							Type:        schema.TypeMap,
+							Required:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Dimensions of the metric, such as `{ vip = \"1.1.1.1\" }`.",
+						},
+						"comparison": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      CLB_TRAFFIC_SHIFT_COMPARISON_GT,
+							ValidateFunc: tccommon.ValidateAllowedStringValue(CLB_TRAFFIC_SHIFT_COMPARISON),
+							Description:  "Comparison of the value and the threshold which means a breach. Valid values: `GT`, `GE`, `LT`, `LE`. Default is `GT`.",
+						},
+						"threshold": {
+							Type:        schema.TypeFloat,
+							Required:    true,
+							Description: "Threshold of the metric.",
+						},
+						"period": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Default:     60,
+							Description: "Statistical period of the metric in seconds. Default is `60`.",
+						},
+					},
+				},
+			},
+			"rollback_on_failure": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether to restore the weights before the apply when a step fails. Default is `true`.",
+			},
+
+			// computed
+			"current_percent": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Percent of the traffic going to the to side, calculated from the current weights of the backends.",
+			},
+			"step_history": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "History of the steps, the latest 100 steps are kept.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"percent": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Percent of the traffic going to the to side after the step.",
+						},
+						"time": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Time of the step.",
+						},
+						"status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Status of the step. Valid values: `SUCCESS`, `FAILED`, `ROLLED_BACK`.",
+						},
+						"message": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Reason of the failure.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceTencentCloudClbTrafficShiftCreate(d *schema.ResourceData, meta interface{}) error {
+	defer tccommon.LogElapsed("resource.tencentcloud_clb_traffic_shift.create")()
+
+	d.SetId(strings.Join([]string{d.Get("clb_id").(string), d.Get("listener_id").(string), d.Get("location_id").(string)}, tccommon.FILED_SP))
+	if err := resourceTencentCloudClbTrafficShiftApply(d, meta); err != nil {
+		return err
+	}
+
+	return resourceTencentCloudClbTrafficShiftRead(d, meta)
+}
+
+func resourceTencentCloudClbTrafficShiftRead(d *schema.ResourceData, meta interface{}) error {
+	defer tccommon.LogElapsed("resource.tencentcloud_clb_traffic_shift.read")()
+	defer tccommon.InconsistentCheck(d, meta)()
+
+	logId := tccommon.GetLogId(tccommon.ContextNil)
+	ctx := context.WithValue(context.TODO(), tccommon.LogIdKey, logId)
+	service := ClbService{client: meta.(tccommon.ProviderMeta).GetAPIV3Conn()}
+
+	shift, err := expandClbTrafficShift(d)
+	if err != nil {
+		return err
+	}
+
+	listener, err := service.DescribeListenerById(ctx, shift.ListenerId, shift.ClbId)
+	if err != nil {
+		return err
+	}
+	if listener == nil {
+		log.Printf("[WARN]%s listener [%s] of traffic shift is not found, remove it from state", logId, shift.ListenerId)
+		d.SetId("")
+		return nil
+	}
+
+	state, err := service.DescribeClbTrafficShiftState(ctx, shift)
+	if err != nil {
+		return err
+	}
+	// the weights changed outside are planned as a change of target_percent
+	percent := shift.CurrentPercent(state, int64(d.Get("target_percent").(int)))
+	_ = d.Set("current_percent", percent)
+	_ = d.Set("target_percent", percent)
+
+	return nil
+}
+
+func resourceTencentCloudClbTrafficShiftUpdate(d *schema.ResourceData, meta interface{}) error {
+	defer tccommon.LogElapsed("resource.tencentcloud_clb_traffic_shift.update")()
+
+	if d.HasChange("target_percent") {
+		if err := resourceTencentCloudClbTrafficShiftApply(d, meta); err != nil {
+			return err
+		}
+	}
+
+	return resourceTencentCloudClbTrafficShiftRead(d, meta)
+}
+
+func resourceTencentCloudClbTrafficShiftDelete(d *schema.ResourceData, meta interface{}) error {
+	defer tccommon.LogElapsed("resource.tencentcloud_clb_traffic_shift.delete")()
+
+	// the weights of the backends are left as they are
+	return nil
+}
+
+func resourceTencentCloudClbTrafficShiftApply(d *schema.ResourceData, meta interface{}) error {
+	logId := tccommon.GetLogId(tccommon.ContextNil)
+	ctx := context.WithValue(context.TODO(), tccommon.LogIdKey, logId)
+	service := ClbService{client: meta.(tccommon.ProviderMeta).GetAPIV3Conn()}
+
+	shift, err := expandClbTrafficShift(d)
+	if err != nil {
+		return err
+	}
+
+	last, target := d.GetChange("target_percent")
+	clbActionMu.Lock()
+	current, steps, err := service.ShiftClbTraffic(ctx, shift, int64(last.(int)), int64(target.(int)))
+	clbActionMu.Unlock()
+
+	history := d.Get("step_history").([]interface{})
+	for _, step := range steps {
+		history = append(history, map[string]interface{}{
+			"percent": step.Percent,
+			"time":    step.Time,
+			"status":  step.Status,
+			"message": step.Message,
+		})
+	}
+	if len(history) > CLB_TRAFFIC_SHIFT_HISTORY_LIMIT {
+		history = history[len(history)-CLB_TRAFFIC_SHIFT_HISTORY_LIMIT:]
+	}
+	_ = d.Set("step_history", history)
+
+	if err != nil {
+		// keep the percent the traffic stays at in state, so that the shift is planned again
+		if len(steps) > 0 {
+			_ = d.Set("target_percent", current)
+		}
+		return err
+	}
+	return nil
+}
+
+func expandClbTrafficShift(d *schema.ResourceData) (*ClbTrafficShift, error) {
+	shift := &ClbTrafficShift{
+		ClbId:             d.Get("clb_id").(string),
+		ListenerId:        d.Get("listener_id").(string),
+		LocationId:        d.Get("location_id").(string),
+		FromTargetGroupId: d.Get("from_target_group_id").(string),
+		ToTargetGroupId:   d.Get("to_target_group_id").(string),
+		StepPercent:       int64(d.Get("step_percent").(int)),
+		StepInterval:      time.Duration(d.Get("step_interval").(int)) * time.Second,
+		CheckHealth:       d.Get("check_health").(bool),
+		RollbackOnErr:     d.Get("rollback_on_failure").(bool),
+	}
+
+	for key, targets := range map[string]*[]ClbTrafficShiftBackend{"from_targets": &shift.FromTargets, "to_targets": &shift.ToTargets} {
+		for _, item := range d.Get(key).(*schema.Set).List() {
+			target := item.(map[string]interface{})
+			backend := ClbTrafficShiftBackend{
+				InstanceId: target["instance_id"].(string),
+				Ip:         target["eni_ip"].(string),
+				Port:       int64(target["port"].(int)),
+			}
+			if (backend.InstanceId == "") == (backend.Ip == "") {
+				return nil, fmt.Errorf("`%s` must have exactly one of `instance_id` and `eni_ip`", key)
+			}
+			*targets = append(*targets, backend)
+		}
+	}
+
+	if v, ok := helper.InterfacesHeadMap(d, "metric_check"); ok {
+		check := &ClbTrafficShiftMetricCheck{
+			Namespace:  v["namespace"].(string),
+			MetricName: v["metric_name"].(string),
+			Dimensions: make(map[string]string),
+			Comparison: v["comparison"].(string),
+			Threshold:  v["threshold"].(float64),
+			Period:     int64(v["period"].(int)),
+		}
+		for name, value := range v["dimensions"].(map[string]interface{}) {
+			check.Dimensions[name] = value.(string)
+		}
+		shift.MetricCheck = check
+	}
+	return shift, nil
+}
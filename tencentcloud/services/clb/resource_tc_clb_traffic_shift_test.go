@@ -0,0 +1,106 @@
+package clb_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	tcacctest "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/acctest"
+)
+
+func TestAccTencentCloudClbTrafficShiftResource_basic(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			tcacctest.AccPreCheck(t)
+		},
+		Providers: tcacctest.AccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccClbTrafficShift(50),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("tencentcloud_clb_traffic_shift.shift", "id"),
+					resource.TestCheckResourceAttr("tencentcloud_clb_traffic_shift.shift", "current_percent", "50"),
+					resource.TestCheckResourceAttr("tencentcloud_clb_traffic_shift.shift", "step_history.#", "2"),
+					resource.TestCheckResourceAttr("tencentcloud_clb_traffic_shift.shift", "step_history.1.status", "SUCCESS"),
+				),
+			},
+			{
+				Config: testAccClbTrafficShift(100),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tencentcloud_clb_traffic_shift.shift", "current_percent", "100"),
+					resource.TestCheckResourceAttr("tencentcloud_clb_traffic_shift.shift", "step_history.#", "4"),
+				),
+			},
+		},
+	})
+}
+
+func testAccClbTrafficShift(percent int) string {
+	return tcacctest.DefaultVpcSubnets + `
+resource "tencentcloud_clb_instance" "clb" {
+  network_type = "INTERNAL"
+  clb_name     = "tf-clb-traffic-shift"
+  vpc_id       = local.vpc_id
+  subnet_id    = local.subnet_id
+}
+
+resource "tencentcloud_clb_listener" "listener" {
+  clb_id        = tencentcloud_clb_instance.clb.id
+  port          = 80
+  protocol      = "HTTP"
+  listener_name = "tf-clb-traffic-shift"
+}
+
+resource "tencentcloud_clb_listener_rule" "rule" {
+  clb_id      = tencentcloud_clb_instance.clb.id
+  listener_id = tencentcloud_clb_listener.listener.listener_id
+  domain      = "abc.com"
+  url         = "/"
+}
+
+resource "tencentcloud_clb_attachment" "attachment" {
+  clb_id      = tencentcloud_clb_instance.clb.id
+  listener_id = tencentcloud_clb_listener.listener.listener_id
+  rule_id     = tencentcloud_clb_listener_rule.rule.rule_id
+
+  targets {
+    eni_ip = "172.16.0.10"
+    port   = 8080
+  }
+
+  targets {
+    eni_ip = "172.16.0.11"
+    port   = 8080
+  }
+
+  lifecycle {
+    ignore_changes = [targets]
+  }
+}
+
+resource "tencentcloud_clb_traffic_shift" "shift" {
+  clb_id         = tencentcloud_clb_instance.clb.id
+  listener_id    = tencentcloud_clb_listener.listener.listener_id
+  location_id    = tencentcloud_clb_listener_rule.rule.rule_id
+  target_percent = ` + fmt.Sprint(percent) + `
+  step_percent   = 25
+  step_interval  = 10
+  check_health   = false
+
+  from_targets {
+    eni_ip = "172.16.0.10"
+    port   = 8080
+  }
+
+  to_targets {
+    eni_ip = "172.16.0.11"
+    port   = 8080
+  }
+
+  depends_on = [tencentcloud_clb_attachment.attachment]
+}
+`
+}
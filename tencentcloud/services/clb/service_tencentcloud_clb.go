@@ -94,34 +94,36 @@ func (me *ClbService) DescribeLoadBalancerByFilter(ctx context.Context, params m
 		if k == "master_zone" {
 			request.MasterZone = helper.String(v.(string))
 		}
+		if k == "filters" {
+			for _, filter := range v.([]tccommon.DataSourceFilter) {
+				request.Filters = append(request.Filters, &clb.Filter{
+					Name:   helper.String(filter.Name),
+					Values: helper.Strings(filter.Values),
+				})
+			}
+		}
 	}
 
-	offset := int64(0)
-	pageSize := int64(CLB_PAGE_LIMIT)
-	clbs = make([]*clb.LoadBalancer, 0)
-	for {
-		request.Offset = &(offset)
-		request.Limit = &(pageSize)
+	clbs, errRet = tccommon.DescribeAllPages(CLB_PAGE_LIMIT, func(offset, limit int) ([]*clb.LoadBalancer, int, error) {
+		request.Offset = helper.IntInt64(offset)
+		request.Limit = helper.IntInt64(limit)
 		ratelimit.Check(request.GetAction())
 		response, err := me.client.UseClbClient().DescribeLoadBalancers(request)
 		if err != nil {
-			errRet = errors.WithStack(err)
-			return
+			return nil, 0, errors.WithStack(err)
 		}
 		log.Printf("[DEBUG]%s api[%s] success, request body [%s], response body [%s]\n",
 			logId, request.GetAction(), request.ToJsonString(), response.ToJsonString())
 
-		if response == nil || len(response.Response.LoadBalancerSet) < 1 {
-			break
+		if response == nil || response.Response == nil {
+			return nil, 0, nil
 		}
-
-		clbs = append(clbs, response.Response.LoadBalancerSet...)
-
-		if int64(len(response.Response.LoadBalancerSet)) < pageSize {
-			break
+		total := -1
+		if response.Response.TotalCount != nil {
+			total = int(*response.Response.TotalCount)
 		}
-		offset += pageSize
-	}
+		return response.Response.LoadBalancerSet, total, nil
+	})
 	return
 }
 
@@ -0,0 +1,730 @@
+package clb
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/pkg/errors"
+	clb "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/clb/v20180317"
+
+	tccommon "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/common"
+	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/internal/helper"
+	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/ratelimit"
+)
+
+const (
+	// CLB_RULE_BATCH_SIZE is the number of rules or targets of a batch request
+	CLB_RULE_BATCH_SIZE = 20
+)
+
+type ClbRuleHealthCheck struct {
+	Enabled         bool
+	IntervalTime    int64
+	HealthNum       int64
+	UnHealthNum     int64
+	TimeOut         int64
+	HttpCode        int64
+	HttpCheckPath   string
+	HttpCheckDomain string
+	HttpCheckMethod string
+	CheckType       string
+}
+
+type ClbRuleCertificate struct {
+	SSLMode  string
+	CertId   string
+	CertCaId string
+}
+
+// ClbRuleTarget is a backend of a rule, either InstanceId or EniIp is set.
+type ClbRuleTarget struct {
+	InstanceId string
+	EniIp      string
+	Port       int64
+	Weight     int64
+}
+
+func (t ClbRuleTarget) key() string {
+	return fmt.Sprintf("%s%s:%d", t.InstanceId, t.EniIp, t.Port)
+}
+
+// ClbListenerRule is a domain/url rule of a layer-7 listener with its domain attributes and forwarding targets.
+// The health check and the certificate are not compared if they are nil.
+type ClbListenerRule struct {
+	LocationId        string
+	Domain            string
+	Url               string
+	Scheduler         string
+	SessionExpireTime int64
+	ForwardType       string
+	TargetType        string
+	Http2             bool
+	HealthCheck       *ClbRuleHealthCheck
+	Certificate       *ClbRuleCertificate
+	Targets           []ClbRuleTarget
+	TargetGroupId     string
+}
+
+// Key identifies the rule in the listener.
+func (r *ClbListenerRule) Key() string {
+	return strings.ToLower(r.Domain) + r.Url
+}
+
+func (r *ClbListenerRule) String() string {
+	return r.Domain + r.Url
+}
+
+func (r *ClbListenerRule) ruleAttributesEqual(current *ClbListenerRule) bool {
+	if r.Scheduler != current.Scheduler || r.SessionExpireTime != current.SessionExpireTime {
+		return false
+	}
+	if r.ForwardType != "" && r.ForwardType != current.ForwardType {
+		return false
+	}
+	return r.HealthCheck == nil || current.HealthCheck == nil || r.HealthCheck.coveredBy(current.HealthCheck)
+}
+
+func (r *ClbListenerRule) domainAttributesEqual(current *ClbListenerRule) bool {
+	if r.Http2 != current.Http2 {
+		return false
+	}
+	return r.Certificate == nil || current.Certificate == nil || reflect.DeepEqual(*r.Certificate, *current.Certificate)
+}
+
+// coveredBy reports whether the current health check has all the values set in h, the zero values are left to the defaults.
+func (h *ClbRuleHealthCheck) coveredBy(current *ClbRuleHealthCheck) bool {
+	if h.Enabled != current.Enabled {
+		return false
+	}
+	if !h.Enabled {
+		return true
+	}
+	merged := *h
+	for field, value := range map[*int64]int64{
+		&merged.IntervalTime: current.IntervalTime,
+		&merged.HealthNum:    current.HealthNum,
+		&merged.UnHealthNum:  current.UnHealthNum,
+		&merged.TimeOut:      current.TimeOut,
+		&merged.HttpCode:     current.HttpCode,
+	} {
+		if *field == 0 {
+			*field = value
+		}
+	}
+	for field, value := range map[*string]string{
+		&merged.HttpCheckPath:   current.HttpCheckPath,
+		&merged.HttpCheckDomain: current.HttpCheckDomain,
+		&merged.HttpCheckMethod: current.HttpCheckMethod,
+		&merged.CheckType:       current.CheckType,
+	} {
+		if *field == "" {
+			*field = value
+		}
+	}
+	return reflect.DeepEqual(merged, *current)
+}
+
+func (h *ClbRuleHealthCheck) input() *clb.HealthCheck {
+	if h == nil {
+		return nil
+	}
+	healthCheck := &clb.HealthCheck{HealthSwitch: helper.Int64(0)}
+	if !h.Enabled {
+		return healthCheck
+	}
+	healthCheck.HealthSwitch = helper.Int64(1)
+	if h.IntervalTime > 0 {
+		healthCheck.IntervalTime = helper.Int64(h.IntervalTime)
+	}
+	if h.HealthNum > 0 {
+		healthCheck.HealthNum = helper.Int64(h.HealthNum)
+	}
+	if h.UnHealthNum > 0 {
+		healthCheck.UnHealthNum = helper.Int64(h.UnHealthNum)
+	}
+	if h.TimeOut > 0 {
+		healthCheck.TimeOut = helper.Int64(h.TimeOut)
+	}
+	if h.HttpCode > 0 {
+		healthCheck.HttpCode = helper.Int64(h.HttpCode)
+	}
+	if h.HttpCheckPath != "" {
+		healthCheck.HttpCheckPath = helper.String(h.HttpCheckPath)
+	}
+	if h.HttpCheckDomain != "" {
+		healthCheck.HttpCheckDomain = helper.String(h.HttpCheckDomain)
+	}
+	if h.HttpCheckMethod != "" {
+		healthCheck.HttpCheckMethod = helper.String(strings.ToLower(h.HttpCheckMethod))
+	}
+	if h.CheckType != "" {
+		healthCheck.CheckType = helper.String(h.CheckType)
+	}
+	return healthCheck
+}
+
+func (c *ClbRuleCertificate) input() *clb.CertificateInput {
+	if c == nil || c.CertId == "" {
+		return nil
+	}
+	certificate := &clb.CertificateInput{
+		SSLMode: helper.String(c.SSLMode),
+		CertId:  helper.String(c.CertId),
+	}
+	if certificate.SSLMode == nil || *certificate.SSLMode == "" {
+		certificate.SSLMode = helper.String(CERT_SSL_MODE_UNI)
+	}
+	if c.CertCaId != "" {
+		certificate.CertCaId = helper.String(c.CertCaId)
+	}
+	return certificate
+}
+
+// ClbListenerRulesChange is the changes to make the listener match the desired rules. The rules in Replace change
+// their target types, which can not be modified, so they are deleted before creating again.
+type ClbListenerRulesChange struct {
+	Create        []*ClbListenerRule
+	Replace       []*ClbListenerRule
+	ModifyRule    []*ClbListenerRule
+	ModifyDomain  []*ClbListenerRule
+	ModifyTargets []*ClbListenerRule
+	Delete        []*ClbListenerRule
+}
+
+func (c *ClbListenerRulesChange) Empty() bool {
+	return len(c.Create)+len(c.Replace)+len(c.ModifyRule)+len(c.ModifyDomain)+len(c.ModifyTargets)+len(c.Delete) == 0
+}
+
+// DiffClbListenerRules works out the changes from the current rules to the desired ones. The current rules which
+// are not desired are deleted only if they are managed or deleteUnmanaged is true. The desired rules to modify carry the
+// location ids of the current ones.
+func DiffClbListenerRules(current, desired []*ClbListenerRule, managed map[string]bool, deleteUnmanaged bool) *ClbListenerRulesChange {
+	change := &ClbListenerRulesChange{}
+
+	currentByKey := make(map[string]*ClbListenerRule, len(current))
+	for _, rule := range current {
+		currentByKey[rule.Key()] = rule
+	}
+	desiredKeys := make(map[string]bool, len(desired))
+	modifiedDomains := make(map[string]bool)
+
+	for _, rule := range desired {
+		desiredKeys[rule.Key()] = true
+		exist, ok := currentByKey[rule.Key()]
+		if !ok {
+			change.Create = append(change.Create, rule)
+			continue
+		}
+		if rule.TargetType != exist.TargetType {
+			change.Replace = append(change.Replace, exist)
+			change.Create = append(change.Create, rule)
+			continue
+		}
+
+		modify := *rule
+		modify.LocationId = exist.LocationId
+		if !rule.ruleAttributesEqual(exist) {
+			change.ModifyRule = append(change.ModifyRule, &modify)
+		}
+		if !rule.domainAttributesEqual(exist) && !modifiedDomains[strings.ToLower(rule.Domain)] {
+			modifiedDomains[strings.ToLower(rule.Domain)] = true
+			change.ModifyDomain = append(change.ModifyDomain, &modify)
+		}
+		register, deregister, reweight := DiffClbRuleTargets(exist.Targets, rule.Targets)
+		if len(register)+len(deregister)+len(reweight) > 0 || rule.TargetGroupId != exist.TargetGroupId {
+			change.ModifyTargets = append(change.ModifyTargets, &modify)
+		}
+	}
+
+	for _, rule := range current {
+		if desiredKeys[rule.Key()] {
+			continue
+		}
+		if deleteUnmanaged || managed[rule.Key()] {
+			change.Delete = append(change.Delete, rule)
+		}
+	}
+	return change
+}
+
+// DiffClbRuleTargets returns the targets to register, to deregister and to change the weight.
+func DiffClbRuleTargets(current, desired []ClbRuleTarget) (register, deregister, reweight []ClbRuleTarget) {
+	currentByKey := make(map[string]ClbRuleTarget, len(current))
+	for _, target := range current {
+		currentByKey[target.key()] = target
+	}
+	desiredKeys := make(map[string]bool, len(desired))
+	for _, target := range desired {
+		desiredKeys[target.key()] = true
+		exist, ok := currentByKey[target.key()]
+		if !ok {
+			register = append(register, target)
+		} else if exist.Weight != target.Weight {
+			reweight = append(reweight, target)
+		}
+	}
+	for _, target := range current {
+		if !desiredKeys[target.key()] {
+			deregister = append(deregister, target)
+		}
+	}
+	return
+}
+
+// DescribeClbListenerRules returns the rules of the listener with their targets, by one DescribeListeners call and one DescribeTargets call.
+func (me *ClbService) DescribeClbListenerRules(ctx context.Context, clbId, listenerId string) (protocol string, rules []*ClbListenerRule, errRet error) {
+	var listener *clb.Listener
+	errRet = resource.Retry(tccommon.ReadRetryTimeout, func() *resource.RetryError {
+		result, e := me.DescribeListenerById(ctx, listenerId, clbId)
+		if e != nil {
+			return tccommon.RetryError(e)
+		}
+		listener = result
+		return nil
+	})
+	if errRet != nil || listener == nil {
+		return
+	}
+	protocol = helper.PString(listener.Protocol)
+
+	var backend *clb.ListenerBackend
+	errRet = resource.Retry(tccommon.ReadRetryTimeout, func() *resource.RetryError {
+		result, e := me.DescribeTargetsByPara(ctx, clbId, listenerId, "")
+		if e != nil {
+			return tccommon.RetryError(e)
+		}
+		backend = result
+		return nil
+	})
+	if errRet != nil {
+		return
+	}
+	targets := make(map[string][]ClbRuleTarget)
+	if backend != nil {
+		for _, ruleTargets := range backend.Rules {
+			locationId := helper.PString(ruleTargets.LocationId)
+			for _, item := range ruleTargets.Targets {
+				target := ClbRuleTarget{Port: helper.PInt64(item.Port), Weight: helper.PInt64(item.Weight)}
+				if helper.PString(item.Type) == CLB_BACKEND_TYPE_ENI && len(item.PrivateIpAddresses) > 0 {
+					target.EniIp = *item.PrivateIpAddresses[0]
+				} else {
+					target.InstanceId = helper.PString(item.InstanceId)
+				}
+				targets[locationId] = append(targets[locationId], target)
+			}
+		}
+	}
+
+	for _, item := range listener.Rules {
+		rule := &ClbListenerRule{
+			LocationId:        helper.PString(item.LocationId),
+			Domain:            helper.PString(item.Domain),
+			Url:               helper.PString(item.Url),
+			Scheduler:         helper.PString(item.Scheduler),
+			SessionExpireTime: helper.PInt64(item.SessionExpireTime),
+			ForwardType:       helper.PString(item.ForwardType),
+			TargetType:        helper.PString(item.TargetType),
+			Http2:             item.Http2 != nil && *item.Http2,
+			Targets:           targets[helper.PString(item.LocationId)],
+		}
+		if rule.TargetType == "" {
+			rule.TargetType = CLB_TARGET_TYPE_NODE
+		}
+		if item.TargetGroup != nil {
+			rule.TargetGroupId = helper.PString(item.TargetGroup.TargetGroupId)
+		}
+		if item.HealthCheck != nil {
+			rule.HealthCheck = &ClbRuleHealthCheck{
+				Enabled:         helper.PInt64(item.HealthCheck.HealthSwitch) == 1,
+				IntervalTime:    helper.PInt64(item.HealthCheck.IntervalTime),
+				HealthNum:       helper.PInt64(item.HealthCheck.HealthNum),
+				UnHealthNum:     helper.PInt64(item.HealthCheck.UnHealthNum),
+				TimeOut:         helper.PInt64(item.HealthCheck.TimeOut),
+				HttpCode:        helper.PInt64(item.HealthCheck.HttpCode),
+				HttpCheckPath:   helper.PString(item.HealthCheck.HttpCheckPath),
+				HttpCheckDomain: helper.PString(item.HealthCheck.HttpCheckDomain),
+				HttpCheckMethod: strings.ToUpper(helper.PString(item.HealthCheck.HttpCheckMethod)),
+				CheckType:       helper.PString(item.HealthCheck.CheckType),
+			}
+		}
+		if item.Certificate != nil {
+			rule.Certificate = &ClbRuleCertificate{
+				SSLMode:  helper.PString(item.Certificate.SSLMode),
+				CertId:   helper.PString(item.Certificate.CertId),
+				CertCaId: helper.PString(item.Certificate.CertCaId),
+			}
+		}
+		sort.Slice(rule.Targets, func(i, j int) bool { return rule.Targets[i].key() < rule.Targets[j].key() })
+		rules = append(rules, rule)
+	}
+	return
+}
+
+// callClbTask calls the API which starts a CLB task and waits for the task to finish.
+func (me *ClbService) callClbTask(ctx context.Context, action string, call func() (requestId *string, err error)) error {
+	logId := tccommon.GetLogId(ctx)
+	err := resource.Retry(tccommon.WriteRetryTimeout, func() *resource.RetryError {
+		ratelimit.Check(action)
+		requestId, e := call()
+		if e != nil {
+			if err := processRetryErrMsg(e); err != nil {
+				return err
+			}
+			return tccommon.RetryError(e, tccommon.InternalError)
+		}
+		if requestId == nil {
+			return resource.NonRetryableError(fmt.Errorf("TencentCloud SDK return nil response, %s", action))
+		}
+		if err := waitForTaskFinish(*requestId, me.client.UseClbClient()); err != nil {
+			return resource.NonRetryableError(errors.WithStack(err))
+		}
+		return nil
+	})
+	if err != nil {
+		log.Printf("[CRITAL]%s api[%s] fail, reason[%s]\n", logId, action, err.Error())
+	}
+	return err
+}
+
+func clbRuleInput(rule *ClbListenerRule) *clb.RuleInput {
+	input := &clb.RuleInput{
+		Domain:      helper.String(rule.Domain),
+		Url:         helper.String(rule.Url),
+		TargetType:  helper.String(rule.TargetType),
+		HealthCheck: rule.HealthCheck.input(),
+		Certificate: rule.Certificate.input(),
+		Http2:       helper.Bool(rule.Http2),
+	}
+	if rule.Scheduler != "" {
+		input.Scheduler = helper.String(rule.Scheduler)
+	}
+	if rule.SessionExpireTime > 0 {
+		input.SessionExpireTime = helper.Int64(rule.SessionExpireTime)
+	}
+	if rule.ForwardType != "" {
+		input.ForwardType = helper.String(rule.ForwardType)
+	}
+	return input
+}
+
+func (me *ClbService) createClbListenerRules(ctx context.Context, clbId, listenerId string, rules []*ClbListenerRule) error {
+	for start := 0; start < len(rules); start += CLB_RULE_BATCH_SIZE {
+		end := start + CLB_RULE_BATCH_SIZE
+		if end > len(rules) {
+			end = len(rules)
+		}
+		batch := rules[start:end]
+
+		request := clb.NewCreateRuleRequest()
+		request.LoadBalancerId = helper.String(clbId)
+		request.ListenerId = helper.String(listenerId)
+		for _, rule := range batch {
+			request.Rules = append(request.Rules, clbRuleInput(rule))
+		}
+
+		var locationIds []*string
+		err := me.callClbTask(ctx, request.GetAction(), func() (*string, error) {
+			response, e := me.client.UseClbClient().CreateRule(request)
+			if e != nil || response == nil || response.Response == nil {
+				return nil, e
+			}
+			log.Printf("[DEBUG]%s api[%s] success, request body [%s], response body [%s]\n",
+				tccommon.GetLogId(ctx), request.GetAction(), request.ToJsonString(), response.ToJsonString())
+			locationIds = response.Response.LocationIds
+			return response.Response.RequestId, nil
+		})
+		if err != nil {
+			return err
+		}
+		if len(locationIds) != len(batch) {
+			return fmt.Errorf("CreateRule returns %d location ids for %d rules", len(locationIds), len(batch))
+		}
+		for i, rule := range batch {
+			rule.LocationId = *locationIds[i]
+		}
+	}
+	return nil
+}
+
+func (me *ClbService) deleteClbListenerRules(ctx context.Context, clbId, listenerId string, rules []*ClbListenerRule) error {
+	for start := 0; start < len(rules); start += CLB_RULE_BATCH_SIZE {
+		end := start + CLB_RULE_BATCH_SIZE
+		if end > len(rules) {
+			end = len(rules)
+		}
+
+		request := clb.NewDeleteRuleRequest()
+		request.LoadBalancerId = helper.String(clbId)
+		request.ListenerId = helper.String(listenerId)
+		for _, rule := range rules[start:end] {
+			request.LocationIds = append(request.LocationIds, helper.String(rule.LocationId))
+		}
+
+		err := me.callClbTask(ctx, request.GetAction(), func() (*string, error) {
+			response, e := me.client.UseClbClient().DeleteRule(request)
+			if e != nil || response == nil || response.Response == nil {
+				return nil, e
+			}
+			log.Printf("[DEBUG]%s api[%s] success, request body [%s], response body [%s]\n",
+				tccommon.GetLogId(ctx), request.GetAction(), request.ToJsonString(), response.ToJsonString())
+			return response.Response.RequestId, nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (me *ClbService) modifyClbListenerRule(ctx context.Context, clbId, listenerId string, rule *ClbListenerRule) error {
+	request := clb.NewModifyRuleRequest()
+	request.LoadBalancerId = helper.String(clbId)
+	request.ListenerId = helper.String(listenerId)
+	request.LocationId = helper.String(rule.LocationId)
+	request.HealthCheck = rule.HealthCheck.input()
+	request.SessionExpireTime = helper.Int64(rule.SessionExpireTime)
+	if rule.Scheduler != "" {
+		request.Scheduler = helper.String(rule.Scheduler)
+	}
+	if rule.ForwardType != "" {
+		request.ForwardType = helper.String(rule.ForwardType)
+	}
+
+	return me.callClbTask(ctx, request.GetAction(), func() (*string, error) {
+		response, e := me.client.UseClbClient().ModifyRule(request)
+		if e != nil || response == nil || response.Response == nil {
+			return nil, e
+		}
+		log.Printf("[DEBUG]%s api[%s] success, request body [%s], response body [%s]\n",
+			tccommon.GetLogId(ctx), request.GetAction(), request.ToJsonString(), response.ToJsonString())
+		return response.Response.RequestId, nil
+	})
+}
+
+func (me *ClbService) modifyClbListenerRuleDomain(ctx context.Context, clbId, listenerId string, rule *ClbListenerRule) error {
+	request := clb.NewModifyDomainAttributesRequest()
+	request.LoadBalancerId = helper.String(clbId)
+	request.ListenerId = helper.String(listenerId)
+	request.Domain = helper.String(rule.Domain)
+	request.Http2 = helper.Bool(rule.Http2)
+	request.Certificate = rule.Certificate.input()
+
+	return me.callClbTask(ctx, request.GetAction(), func() (*string, error) {
+		response, e := me.client.UseClbClient().ModifyDomainAttributes(request)
+		if e != nil || response == nil || response.Response == nil {
+			return nil, e
+		}
+		log.Printf("[DEBUG]%s api[%s] success, request body [%s], response body [%s]\n",
+			tccommon.GetLogId(ctx), request.GetAction(), request.ToJsonString(), response.ToJsonString())
+		return response.Response.RequestId, nil
+	})
+}
+
+func clbBatchTarget(listenerId, locationId string, target ClbRuleTarget) *clb.BatchTarget {
+	batchTarget := &clb.BatchTarget{
+		ListenerId: helper.String(listenerId),
+		LocationId: helper.String(locationId),
+		Port:       helper.Int64(target.Port),
+		Weight:     helper.Int64(target.Weight),
+	}
+	if target.EniIp != "" {
+		batchTarget.EniIp = helper.String(target.EniIp)
+	} else {
+		batchTarget.InstanceId = helper.String(target.InstanceId)
+	}
+	return batchTarget
+}
+
+// modifyClbListenerRulesTargets registers, deregisters and reweights the targets of the rules and switches the target groups,
+// the targets of all the rules are changed by batch requests.
+func (me *ClbService) modifyClbListenerRulesTargets(ctx context.Context, clbId, listenerId string, current map[string]*ClbListenerRule, rules []*ClbListenerRule) error {
+	var (
+		registers    []*clb.BatchTarget
+		deregisters  []*clb.BatchTarget
+		reweights    []*clb.RsWeightRule
+		associate    []*clb.TargetGroupAssociation
+		disassociate []*clb.TargetGroupAssociation
+	)
+	for _, rule := range rules {
+		var exist ClbListenerRule
+		if current[rule.LocationId] != nil {
+			exist = *current[rule.LocationId]
+		}
+
+		register, deregister, reweight := DiffClbRuleTargets(exist.Targets, rule.Targets)
+		for _, target := range register {
+			registers = append(registers, clbBatchTarget(listenerId, rule.LocationId, target))
+		}
+		for _, target := range deregister {
+			deregisters = append(deregisters, clbBatchTarget(listenerId, rule.LocationId, target))
+		}
+		for _, target := range reweight {
+			batchTarget := clbBatchTarget(listenerId, rule.LocationId, target)
+			reweights = append(reweights, &clb.RsWeightRule{
+				ListenerId: batchTarget.ListenerId,
+				LocationId: batchTarget.LocationId,
+				Weight:     batchTarget.Weight,
+				Targets:    []*clb.Target{{InstanceId: batchTarget.InstanceId, EniIp: batchTarget.EniIp, Port: batchTarget.Port, Weight: batchTarget.Weight}},
+			})
+		}
+
+		if rule.TargetGroupId != exist.TargetGroupId {
+			if exist.TargetGroupId != "" {
+				disassociate = append(disassociate, &clb.TargetGroupAssociation{
+					LoadBalancerId: helper.String(clbId), ListenerId: helper.String(listenerId),
+					LocationId: helper.String(rule.LocationId), TargetGroupId: helper.String(exist.TargetGroupId),
+				})
+			}
+			if rule.TargetGroupId != "" {
+				associate = append(associate, &clb.TargetGroupAssociation{
+					LoadBalancerId: helper.String(clbId), ListenerId: helper.String(listenerId),
+					LocationId: helper.String(rule.LocationId), TargetGroupId: helper.String(rule.TargetGroupId),
+				})
+			}
+		}
+	}
+
+	for start := 0; start < len(deregisters); start += CLB_RULE_BATCH_SIZE {
+		request := clb.NewBatchDeregisterTargetsRequest()
+		request.LoadBalancerId = helper.String(clbId)
+		request.Targets = deregisters[start:clbBatchEnd(start, len(deregisters))]
+		err := me.callClbTask(ctx, request.GetAction(), func() (*string, error) {
+			response, e := me.client.UseClbClient().BatchDeregisterTargets(request)
+			if e != nil || response == nil || response.Response == nil {
+				return nil, e
+			}
+			if len(response.Response.FailListenerIdSet) > 0 {
+				return nil, fmt.Errorf("deregister targets of listeners %v failed", helper.PStrings(response.Response.FailListenerIdSet))
+			}
+			return response.Response.RequestId, nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	for start := 0; start < len(registers); start += CLB_RULE_BATCH_SIZE {
+		request := clb.NewBatchRegisterTargetsRequest()
+		request.LoadBalancerId = helper.String(clbId)
+		request.Targets = registers[start:clbBatchEnd(start, len(registers))]
+		err := me.callClbTask(ctx, request.GetAction(), func() (*string, error) {
+			response, e := me.client.UseClbClient().BatchRegisterTargets(request)
+			if e != nil || response == nil || response.Response == nil {
+				return nil, e
+			}
+			if len(response.Response.FailListenerIdSet) > 0 {
+				return nil, fmt.Errorf("register targets of listeners %v failed, %s", helper.PStrings(response.Response.FailListenerIdSet), helper.PString(response.Response.Message))
+			}
+			return response.Response.RequestId, nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	for start := 0; start < len(reweights); start += CLB_RULE_BATCH_SIZE {
+		request := clb.NewBatchModifyTargetWeightRequest()
+		request.LoadBalancerId = helper.String(clbId)
+		request.ModifyList = reweights[start:clbBatchEnd(start, len(reweights))]
+		err := me.callClbTask(ctx, request.GetAction(), func() (*string, error) {
+			response, e := me.client.UseClbClient().BatchModifyTargetWeight(request)
+			if e != nil || response == nil || response.Response == nil {
+				return nil, e
+			}
+			return response.Response.RequestId, nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(disassociate) > 0 {
+		request := clb.NewDisassociateTargetGroupsRequest()
+		request.Associations = disassociate
+		err := me.callClbTask(ctx, request.GetAction(), func() (*string, error) {
+			response, e := me.client.UseClbClient().DisassociateTargetGroups(request)
+			if e != nil || response == nil || response.Response == nil {
+				return nil, e
+			}
+			return response.Response.RequestId, nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if len(associate) > 0 {
+		request := clb.NewAssociateTargetGroupsRequest()
+		request.Associations = associate
+		err := me.callClbTask(ctx, request.GetAction(), func() (*string, error) {
+			response, e := me.client.UseClbClient().AssociateTargetGroups(request)
+			if e != nil || response == nil || response.Response == nil {
+				return nil, e
+			}
+			return response.Response.RequestId, nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func clbBatchEnd(start, length int) int {
+	if start+CLB_RULE_BATCH_SIZE > length {
+		return length
+	}
+	return start + CLB_RULE_BATCH_SIZE
+}
+
+// ApplyClbListenerRules changes the rules of the listener from current to desired, the location ids of the desired rules are filled.
+func (me *ClbService) ApplyClbListenerRules(ctx context.Context, clbId, listenerId string, current, desired []*ClbListenerRule,
+	managed map[string]bool, deleteUnmanaged bool) error {
+	logId := tccommon.GetLogId(ctx)
+
+	currentById := make(map[string]*ClbListenerRule, len(current))
+	currentByKey := make(map[string]*ClbListenerRule, len(current))
+	for _, rule := range current {
+		currentById[rule.LocationId] = rule
+		currentByKey[rule.Key()] = rule
+	}
+	for _, rule := range desired {
+		rule.LocationId = ""
+		if exist, ok := currentByKey[rule.Key()]; ok && exist.TargetType == rule.TargetType {
+			rule.LocationId = exist.LocationId
+		}
+	}
+
+	change := DiffClbListenerRules(current, desired, managed, deleteUnmanaged)
+	log.Printf("[DEBUG]%s listener [%s] rules change: %d to create, %d to replace, %d to modify, %d domains to modify, %d to change targets, %d to delete",
+		logId, listenerId, len(change.Create), len(change.Replace), len(change.ModifyRule), len(change.ModifyDomain), len(change.ModifyTargets), len(change.Delete))
+
+	if err := me.deleteClbListenerRules(ctx, clbId, listenerId, change.Replace); err != nil {
+		return err
+	}
+	if err := me.createClbListenerRules(ctx, clbId, listenerId, change.Create); err != nil {
+		return err
+	}
+	for _, rule := range change.ModifyRule {
+		if err := me.modifyClbListenerRule(ctx, clbId, listenerId, rule); err != nil {
+			return fmt.Errorf("modify rule %s failed, %v", rule, err)
+		}
+	}
+	for _, rule := range change.ModifyDomain {
+		if err := me.modifyClbListenerRuleDomain(ctx, clbId, listenerId, rule); err != nil {
+			return fmt.Errorf("modify domain %s failed, %v", rule.Domain, err)
+		}
+	}
+
+	// the targets of the created rules are all registered
+	targetRules := append(append([]*ClbListenerRule{}, change.ModifyTargets...), change.Create...)
+	if err := me.modifyClbListenerRulesTargets(ctx, clbId, listenerId, currentById, targetRules); err != nil {
+		return err
+	}
+
+	return me.deleteClbListenerRules(ctx, clbId, listenerId, change.Delete)
+}
@@ -0,0 +1,250 @@
+package clb
+
+import (
+	"reflect"
+	"testing"
+)
+
+func testClbRuleStrings(rules []*ClbListenerRule) []string {
+	result := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		result = append(result, rule.LocationId+" "+rule.String())
+	}
+	return result
+}
+
+func testClbRuleTargetKeys(targets []ClbRuleTarget) []string {
+	result := make([]string, 0, len(targets))
+	for _, target := range targets {
+		result = append(result, target.key())
+	}
+	return result
+}
+
+func TestDiffClbListenerRules(t *testing.T) {
+	rule := func(locationId, domain, url string) *ClbListenerRule {
+		return &ClbListenerRule{
+			LocationId: locationId, Domain: domain, Url: url, Scheduler: CLB_LISTENER_SCHEDULER_WRR, TargetType: CLB_TARGET_TYPE_NODE,
+			Targets: []ClbRuleTarget{{InstanceId: "ins-1", Port: 80, Weight: 10}},
+		}
+	}
+	with := func(rule *ClbListenerRule, change func(rule *ClbListenerRule)) *ClbListenerRule {
+		change(rule)
+		return rule
+	}
+	certificate := func(certId string) func(rule *ClbListenerRule) {
+		return func(rule *ClbListenerRule) {
+			rule.Certificate = &ClbRuleCertificate{SSLMode: CERT_SSL_MODE_UNI, CertId: certId}
+		}
+	}
+
+	type expected struct {
+		create, replace, modifyRule, modifyDomain, modifyTargets, delete []string
+	}
+	cases := []struct {
+		name            string
+		current         []*ClbListenerRule
+		desired         []*ClbListenerRule
+		managed         []string
+		deleteUnmanaged bool
+		want            expected
+	}{
+		{
+			name:    "unchanged with other case of domain",
+			current: []*ClbListenerRule{rule("loc-1", "www.example.com", "/")},
+			desired: []*ClbListenerRule{rule("", "WWW.example.com", "/")},
+		},
+		{
+			name:    "created",
+			current: []*ClbListenerRule{rule("loc-1", "www.example.com", "/")},
+			desired: []*ClbListenerRule{rule("", "www.example.com", "/"), rule("", "www.example.com", "/api")},
+			want:    expected{create: []string{" www.example.com/api"}},
+		},
+		{
+			name:    "unmanaged rules kept",
+			current: []*ClbListenerRule{rule("loc-1", "www.example.com", "/"), rule("loc-2", "www.example.com", "/old"), rule("loc-3", "api.example.com", "/")},
+			desired: []*ClbListenerRule{rule("", "www.example.com", "/")},
+			managed: []string{"www.example.com/", "www.example.com/old"},
+			want:    expected{delete: []string{"loc-2 www.example.com/old"}},
+		},
+		{
+			name:    "nothing managed",
+			current: []*ClbListenerRule{rule("loc-1", "www.example.com", "/"), rule("loc-2", "api.example.com", "/")},
+			desired: []*ClbListenerRule{rule("", "www.example.com", "/")},
+		},
+		{
+			name:            "unmanaged rules deleted",
+			current:         []*ClbListenerRule{rule("loc-1", "www.example.com", "/"), rule("loc-2", "www.example.com", "/old"), rule("loc-3", "api.example.com", "/")},
+			desired:         []*ClbListenerRule{rule("", "www.example.com", "/")},
+			managed:         []string{"www.example.com/old"},
+			deleteUnmanaged: true,
+			want:            expected{delete: []string{"loc-2 www.example.com/old", "loc-3 api.example.com/"}},
+		},
+		{
+			name:    "target type replaced",
+			current: []*ClbListenerRule{rule("loc-1", "www.example.com", "/"), rule("loc-2", "www.example.com", "/api")},
+			desired: []*ClbListenerRule{
+				with(rule("", "www.example.com", "/"), func(rule *ClbListenerRule) {
+					rule.TargetType, rule.Targets, rule.TargetGroupId = CLB_TARGET_TYPE_TARGETGROUP, nil, "lbtg-1"
+				}),
+				rule("", "www.example.com", "/api"),
+			},
+			managed: []string{"www.example.com/", "www.example.com/api"},
+			want:    expected{create: []string{" www.example.com/"}, replace: []string{"loc-1 www.example.com/"}},
+		},
+		{
+			name:    "rule attributes modified",
+			current: []*ClbListenerRule{rule("loc-1", "www.example.com", "/"), rule("loc-2", "www.example.com", "/api")},
+			desired: []*ClbListenerRule{
+				with(rule("", "www.example.com", "/"), func(rule *ClbListenerRule) { rule.Scheduler = CLB_LISTENER_SCHEDULER_LEASTCONN }),
+				with(rule("", "www.example.com", "/api"), func(rule *ClbListenerRule) { rule.SessionExpireTime = 30 }),
+			},
+			want: expected{modifyRule: []string{"loc-1 www.example.com/", "loc-2 www.example.com/api"}},
+		},
+		{
+			name: "health check defaults not compared",
+			current: []*ClbListenerRule{with(rule("loc-1", "www.example.com", "/"), func(rule *ClbListenerRule) {
+				rule.HealthCheck = &ClbRuleHealthCheck{Enabled: true, IntervalTime: 5, HealthNum: 3, UnHealthNum: 3, TimeOut: 2, HttpCode: 31, HttpCheckPath: "/", HttpCheckMethod: "HEAD"}
+			})},
+			desired: []*ClbListenerRule{with(rule("", "www.example.com", "/"), func(rule *ClbListenerRule) {
+				rule.HealthCheck = &ClbRuleHealthCheck{Enabled: true, HttpCheckPath: "/"}
+			})},
+		},
+		{
+			name: "health check changed",
+			current: []*ClbListenerRule{with(rule("loc-1", "www.example.com", "/"), func(rule *ClbListenerRule) {
+				rule.HealthCheck = &ClbRuleHealthCheck{Enabled: true, IntervalTime: 5, HttpCheckPath: "/"}
+			})},
+			desired: []*ClbListenerRule{with(rule("", "www.example.com", "/"), func(rule *ClbListenerRule) {
+				rule.HealthCheck = &ClbRuleHealthCheck{Enabled: true, HttpCheckPath: "/health"}
+			})},
+			want: expected{modifyRule: []string{"loc-1 www.example.com/"}},
+		},
+		{
+			name: "certificate modified once per domain",
+			current: []*ClbListenerRule{
+				with(rule("loc-1", "www.example.com", "/"), certificate("cert-old")),
+				with(rule("loc-2", "www.example.com", "/api"), certificate("cert-old")),
+				with(rule("loc-3", "api.example.com", "/"), certificate("cert-old")),
+			},
+			desired: []*ClbListenerRule{
+				with(rule("", "www.example.com", "/"), certificate("cert-new")),
+				with(rule("", "www.example.com", "/api"), certificate("cert-new")),
+				with(rule("", "api.example.com", "/"), certificate("cert-new")),
+			},
+			want: expected{modifyDomain: []string{"loc-1 www.example.com/", "loc-3 api.example.com/"}},
+		},
+		{
+			name:    "certificate not set is not compared",
+			current: []*ClbListenerRule{with(rule("loc-1", "www.example.com", "/"), certificate("cert-old"))},
+			desired: []*ClbListenerRule{rule("", "www.example.com", "/")},
+		},
+		{
+			name:    "http2 modified",
+			current: []*ClbListenerRule{rule("loc-1", "www.example.com", "/")},
+			desired: []*ClbListenerRule{with(rule("", "www.example.com", "/"), func(rule *ClbListenerRule) { rule.Http2 = true })},
+			want:    expected{modifyDomain: []string{"loc-1 www.example.com/"}},
+		},
+		{
+			name:    "targets modified",
+			current: []*ClbListenerRule{rule("loc-1", "www.example.com", "/"), rule("loc-2", "www.example.com", "/api")},
+			desired: []*ClbListenerRule{
+				with(rule("", "www.example.com", "/"), func(rule *ClbListenerRule) { rule.Targets[0].Weight = 20 }),
+				rule("", "www.example.com", "/api"),
+			},
+			want: expected{modifyTargets: []string{"loc-1 www.example.com/"}},
+		},
+		{
+			name: "target group switched",
+			current: []*ClbListenerRule{with(rule("loc-1", "www.example.com", "/"), func(rule *ClbListenerRule) {
+				rule.TargetType, rule.Targets, rule.TargetGroupId = CLB_TARGET_TYPE_TARGETGROUP, nil, "lbtg-1"
+			})},
+			desired: []*ClbListenerRule{with(rule("", "www.example.com", "/"), func(rule *ClbListenerRule) {
+				rule.TargetType, rule.Targets, rule.TargetGroupId = CLB_TARGET_TYPE_TARGETGROUP, nil, "lbtg-2"
+			})},
+			want: expected{modifyTargets: []string{"loc-1 www.example.com/"}},
+		},
+	}
+	for _, c := range cases {
+		managed := make(map[string]bool, len(c.managed))
+		for _, key := range c.managed {
+			managed[key] = true
+		}
+		change := DiffClbListenerRules(c.current, c.desired, managed, c.deleteUnmanaged)
+		for _, item := range []struct {
+			kind      string
+			got, want []string
+		}{
+			{"create", testClbRuleStrings(change.Create), c.want.create},
+			{"replace", testClbRuleStrings(change.Replace), c.want.replace},
+			{"modify rule", testClbRuleStrings(change.ModifyRule), c.want.modifyRule},
+			{"modify domain", testClbRuleStrings(change.ModifyDomain), c.want.modifyDomain},
+			{"modify targets", testClbRuleStrings(change.ModifyTargets), c.want.modifyTargets},
+			{"delete", testClbRuleStrings(change.Delete), c.want.delete},
+		} {
+			if len(item.got) == 0 && len(item.want) == 0 {
+				continue
+			}
+			if !reflect.DeepEqual(item.got, item.want) {
+				t.Errorf("%s: %s got %v, want %v", c.name, item.kind, item.got, item.want)
+			}
+		}
+	}
+}
+
+func TestDiffClbRuleTargets(t *testing.T) {
+	cases := []struct {
+		name                           string
+		current, desired               []ClbRuleTarget
+		register, deregister, reweight []string
+	}{
+		{
+			name:    "unchanged",
+			current: []ClbRuleTarget{{InstanceId: "ins-1", Port: 80, Weight: 10}, {EniIp: "10.0.0.1", Port: 80, Weight: 10}},
+			desired: []ClbRuleTarget{{EniIp: "10.0.0.1", Port: 80, Weight: 10}, {InstanceId: "ins-1", Port: 80, Weight: 10}},
+		},
+		{
+			name:       "port changed",
+			current:    []ClbRuleTarget{{InstanceId: "ins-1", Port: 80, Weight: 10}},
+			desired:    []ClbRuleTarget{{InstanceId: "ins-1", Port: 8080, Weight: 10}},
+			register:   []string{"ins-1:8080"},
+			deregister: []string{"ins-1:80"},
+		},
+		{
+			name:     "weight changed",
+			current:  []ClbRuleTarget{{InstanceId: "ins-1", Port: 80, Weight: 10}, {InstanceId: "ins-2", Port: 80, Weight: 10}},
+			desired:  []ClbRuleTarget{{InstanceId: "ins-1", Port: 80, Weight: 0}, {InstanceId: "ins-2", Port: 80, Weight: 10}},
+			reweight: []string{"ins-1:80"},
+		},
+		{
+			name:       "instance replaced by ENI",
+			current:    []ClbRuleTarget{{InstanceId: "ins-1", Port: 80, Weight: 10}},
+			desired:    []ClbRuleTarget{{EniIp: "10.0.0.1", Port: 80, Weight: 10}, {InstanceId: "ins-2", Port: 80, Weight: 10}},
+			register:   []string{"10.0.0.1:80", "ins-2:80"},
+			deregister: []string{"ins-1:80"},
+		},
+		{
+			name:       "all deregistered",
+			current:    []ClbRuleTarget{{InstanceId: "ins-1", Port: 80, Weight: 10}, {InstanceId: "ins-1", Port: 81, Weight: 10}},
+			deregister: []string{"ins-1:80", "ins-1:81"},
+		},
+	}
+	for _, c := range cases {
+		register, deregister, reweight := DiffClbRuleTargets(c.current, c.desired)
+		for _, item := range []struct {
+			kind      string
+			got, want []string
+		}{
+			{"register", testClbRuleTargetKeys(register), c.register},
+			{"deregister", testClbRuleTargetKeys(deregister), c.deregister},
+			{"reweight", testClbRuleTargetKeys(reweight), c.reweight},
+		} {
+			if len(item.got) == 0 && len(item.want) == 0 {
+				continue
+			}
+			if !reflect.DeepEqual(item.got, item.want) {
+				t.Errorf("%s: %s got %v, want %v", c.name, item.kind, item.got, item.want)
+			}
+		}
+	}
+}
@@ -0,0 +1,593 @@
+package clb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	clb "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/clb/v20180317"
+	tchttp "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common/http"
+	monitor "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/monitor/v20180724"
+
+	tccommon "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/common"
+	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/internal/helper"
+	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/ratelimit"
+)
+
+// ClbTrafficShiftBackend is a backend of a side of the traffic shift. Ip is the bind ip of a target group instance or the ENI ip.
+type ClbTrafficShiftBackend struct {
+	InstanceId string
+	Ip         string
+	Port       int64
+	Weight     int64
+}
+
+func (b ClbTrafficShiftBackend) String() string {
+	if b.InstanceId != "" {
+		return fmt.Sprintf("%s:%d", b.InstanceId, b.Port)
+	}
+	return fmt.Sprintf("%s:%d", b.Ip, b.Port)
+}
+
+type ClbTrafficShiftMetricCheck struct {
+	Namespace  string
+	MetricName string
+	Dimensions map[string]string
+	Comparison string
+	Threshold  float64
+	Period     int64
+}
+
+// ClbTrafficShift moves the traffic of a listener rule from a side to the other, a side is either a target group bound to the rule
+// or a set of backends registered to the rule.
+type ClbTrafficShift struct {
+	ClbId             string
+	ListenerId        string
+	LocationId        string
+	FromTargetGroupId string
+	ToTargetGroupId   string
+	FromTargets       []ClbTrafficShiftBackend
+	ToTargets         []ClbTrafficShiftBackend
+
+	StepPercent   int64
+	StepInterval  time.Duration
+	CheckHealth   bool
+	MetricCheck   *ClbTrafficShiftMetricCheck
+	RollbackOnErr bool
+}
+
+func (s *ClbTrafficShift) byTargetGroup() bool {
+	return s.FromTargetGroupId != ""
+}
+
+type ClbTrafficShiftStep struct {
+	Percent int64
+	Time    string
+	Status  string
+	Message string
+}
+
+// ClbTrafficShiftSteps returns the percents of the steps from start to target, the start is excluded and the target is included.
+func ClbTrafficShiftSteps(start, target, step int64) (steps []int64) {
+	if step <= 0 {
+		step = 100
+	}
+	for percent := start; percent != target; {
+		if percent < target {
+			percent = int64(math.Min(float64(percent+step), float64(target)))
+		} else {
+			percent = int64(math.Max(float64(percent-step), float64(target)))
+		}
+		steps = append(steps, percent)
+	}
+	return
+}
+
+// ClbTrafficShiftWeights returns the weight of each backend of the two sides, so that percent of the traffic goes to the
+// to side. The larger weight is CLB_TRAFFIC_SHIFT_MAX_WEIGHT.
+func ClbTrafficShiftWeights(percent int64, fromCount, toCount int) (fromWeight, toWeight int64) {
+	if percent <= 0 || toCount == 0 {
+		return CLB_TRAFFIC_SHIFT_MAX_WEIGHT, 0
+	}
+	if percent >= 100 || fromCount == 0 {
+		return 0, CLB_TRAFFIC_SHIFT_MAX_WEIGHT
+	}
+	from := float64(100-percent) / float64(fromCount)
+	to := float64(percent) / float64(toCount)
+	scale := CLB_TRAFFIC_SHIFT_MAX_WEIGHT / math.Max(from, to)
+	fromWeight = int64(math.Max(math.Round(from*scale), 1))
+	toWeight = int64(math.Max(math.Round(to*scale), 1))
+	return
+}
+
+// ClbTrafficShiftPercent returns the percent of the traffic going to the to side.
+func ClbTrafficShiftPercent(from, to []ClbTrafficShiftBackend) int64 {
+	var fromWeight, toWeight int64
+	for _, backend := range from {
+		fromWeight += backend.Weight
+	}
+	for _, backend := range to {
+		toWeight += backend.Weight
+	}
+	return clbTrafficShiftWeightPercent(fromWeight, toWeight)
+}
+
+func clbTrafficShiftWeightPercent(fromWeight, toWeight int64) int64 {
+	if fromWeight+toWeight == 0 {
+		return 0
+	}
+	return int64(math.Round(float64(toWeight) * 100 / float64(fromWeight+toWeight)))
+}
+
+// ClbTrafficShiftState is the weights of the two sides. Between two target groups, the traffic is split by FromWeight and
+// ToWeight, the weights of the target group associations with the rule, and the backends in the groups keep their weights.
+// Between two backend sets, the traffic is split by the weights of the backends.
+type ClbTrafficShiftState struct {
+	From       []ClbTrafficShiftBackend
+	To         []ClbTrafficShiftBackend
+	FromWeight int64
+	ToWeight   int64
+}
+
+func (s *ClbTrafficShiftState) weightsEqual(other *ClbTrafficShiftState) bool {
+	if s.FromWeight != other.FromWeight || s.ToWeight != other.ToWeight || len(s.From) != len(other.From) || len(s.To) != len(other.To) {
+		return false
+	}
+	for i := range s.From {
+		if s.From[i] != other.From[i] {
+			return false
+		}
+	}
+	for i := range s.To {
+		if s.To[i] != other.To[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Percent returns the percent of the traffic going to the to side.
+func (s *ClbTrafficShift) Percent(state *ClbTrafficShiftState) int64 {
+	if s.byTargetGroup() {
+		return clbTrafficShiftWeightPercent(state.FromWeight, state.ToWeight)
+	}
+	return ClbTrafficShiftPercent(state.From, state.To)
+}
+
+// Weighted returns a copy of the state with the weights which send percent of the traffic to the to side.
+func (s *ClbTrafficShift) Weighted(state *ClbTrafficShiftState, percent int64) *ClbTrafficShiftState {
+	weighted := &ClbTrafficShiftState{
+		From:       append([]ClbTrafficShiftBackend{}, state.From...),
+		To:         append([]ClbTrafficShiftBackend{}, state.To...),
+		FromWeight: state.FromWeight,
+		ToWeight:   state.ToWeight,
+	}
+	if s.byTargetGroup() {
+		weighted.FromWeight, weighted.ToWeight = ClbTrafficShiftWeights(percent, 1, 1)
+		return weighted
+	}
+	fromWeight, toWeight := ClbTrafficShiftWeights(percent, len(state.From), len(state.To))
+	for i := range weighted.From {
+		weighted.From[i].Weight = fromWeight
+	}
+	for i := range weighted.To {
+		weighted.To[i].Weight = toWeight
+	}
+	return weighted
+}
+
+// CurrentPercent returns the percent of the traffic going to the to side. If the weights are the ones set for the last
+// percent, the last percent is returned, since the rounded weights may not give it back exactly.
+func (s *ClbTrafficShift) CurrentPercent(state *ClbTrafficShiftState, last int64) int64 {
+	if s.Weighted(state, last).weightsEqual(state) {
+		return last
+	}
+	return s.Percent(state)
+}
+
+// ClbTrafficShiftMetricBreached reports whether the value breaches the threshold.
+func ClbTrafficShiftMetricBreached(comparison string, value, threshold float64) bool {
+	switch comparison {
+	case CLB_TRAFFIC_SHIFT_COMPARISON_GE:
+		return value >= threshold
+	case CLB_TRAFFIC_SHIFT_COMPARISON_LT:
+		return value < threshold
+	case CLB_TRAFFIC_SHIFT_COMPARISON_LE:
+		return value <= threshold
+	default:
+		return value > threshold
+	}
+}
+
+// DescribeClbTrafficShiftState returns the backends of the two sides with their current weights, and the weights of the
+// target group associations if the traffic is shifted between target groups.
+func (me *ClbService) DescribeClbTrafficShiftState(ctx context.Context, shift *ClbTrafficShift) (state *ClbTrafficShiftState, errRet error) {
+	state = &ClbTrafficShiftState{}
+	if shift.byTargetGroup() {
+		for _, side := range []struct {
+			targetGroupId string
+			backends      *[]ClbTrafficShiftBackend
+			weight        *int64
+		}{
+			{shift.FromTargetGroupId, &state.From, &state.FromWeight},
+			{shift.ToTargetGroupId, &state.To, &state.ToWeight},
+		} {
+			if *side.backends, errRet = me.describeClbTrafficShiftTargetGroup(ctx, side.targetGroupId); errRet != nil {
+				return
+			}
+			if *side.weight, errRet = me.describeClbTrafficShiftAssociationWeight(ctx, shift, side.targetGroupId); errRet != nil {
+				return
+			}
+		}
+		return
+	}
+
+	var listenerBackend *clb.ListenerBackend
+	errRet = resource.Retry(tccommon.ReadRetryTimeout, func() *resource.RetryError {
+		result, e := me.DescribeTargetsByPara(ctx, shift.ClbId, shift.ListenerId, shift.LocationId)
+		if e != nil {
+			return tccommon.RetryError(e)
+		}
+		listenerBackend = result
+		return nil
+	})
+	if errRet != nil {
+		return
+	}
+
+	var registered []*clb.Backend
+	if listenerBackend != nil {
+		registered = listenerBackend.Targets
+		for _, rule := range listenerBackend.Rules {
+			if helper.PString(rule.LocationId) == shift.LocationId {
+				registered = rule.Targets
+			}
+		}
+	}
+	weights := make(map[string]int64, len(registered))
+	for _, backend := range registered {
+		port := helper.PInt64(backend.Port)
+		weights[fmt.Sprintf("%s:%d", helper.PString(backend.InstanceId), port)] = helper.PInt64(backend.Weight)
+		for _, ip := range backend.PrivateIpAddresses {
+			weights[fmt.Sprintf("%s:%d", *ip, port)] = helper.PInt64(backend.Weight)
+		}
+	}
+
+	find := func(backends []ClbTrafficShiftBackend) ([]ClbTrafficShiftBackend, error) {
+		result := make([]ClbTrafficShiftBackend, 0, len(backends))
+		for _, backend := range backends {
+			weight, ok := weights[backend.String()]
+			if !ok {
+				return nil, fmt.Errorf("backend %s is not registered to listener %s rule %s", backend, shift.ListenerId, shift.LocationId)
+			}
+			backend.Weight = weight
+			result = append(result, backend)
+		}
+		return result, nil
+	}
+	if state.From, errRet = find(shift.FromTargets); errRet != nil {
+		return
+	}
+	state.To, errRet = find(shift.ToTargets)
+	return
+}
+
+// clbTargetGroupAssociationsResponse is the response of DescribeTargetGroups with the weights of the associations,
+// which the SDK does not have yet.
+type clbTargetGroupAssociationsResponse struct {
+	Response struct {
+		TargetGroupSet []struct {
+			TargetGroupId  string
+			AssociatedRule []struct {
+				ListenerId string
+				LocationId string
+				Weight     *int64
+			}
+		}
+	}
+}
+
+func (me *ClbService) describeClbTrafficShiftAssociationWeight(ctx context.Context, shift *ClbTrafficShift, targetGroupId string) (weight int64, errRet error) {
+	logId := tccommon.GetLogId(ctx)
+	request := tchttp.NewCommonRequest("clb", "2018-03-17", "DescribeTargetGroups")
+	if errRet = request.SetActionParameters(map[string]interface{}{"TargetGroupIds": []string{targetGroupId}}); errRet != nil {
+		return
+	}
+
+	var result clbTargetGroupAssociationsResponse
+	errRet = resource.Retry(tccommon.ReadRetryTimeout, func() *resource.RetryError {
+		ratelimit.Check(request.GetAction())
+		response := tchttp.NewCommonResponse()
+		if e := me.client.UseOmitNilClient("clb").Send(request, response); e != nil {
+			return tccommon.RetryError(e)
+		}
+		if e := json.Unmarshal(response.GetBody(), &result); e != nil {
+			return resource.NonRetryableError(e)
+		}
+		return nil
+	})
+	if errRet != nil {
+		log.Printf("[CRITAL]%s api[%s] fail, request body [%s], reason[%s]\n",
+			logId, request.GetAction(), string(request.GetBody()), errRet.Error())
+		return
+	}
+
+	for _, targetGroup := range result.Response.TargetGroupSet {
+		for _, rule := range targetGroup.AssociatedRule {
+			if rule.ListenerId != shift.ListenerId || rule.LocationId != shift.LocationId {
+				continue
+			}
+			if rule.Weight == nil {
+				return CLB_TARGET_GROUP_ASSOCIATION_DEFAULT_WEIGHT, nil
+			}
+			return *rule.Weight, nil
+		}
+	}
+	return 0, fmt.Errorf("target group %s is not associated with listener %s rule %s", targetGroupId, shift.ListenerId, shift.LocationId)
+}
+
+func (me *ClbService) describeClbTrafficShiftTargetGroup(ctx context.Context, targetGroupId string) (backends []ClbTrafficShiftBackend, errRet error) {
+	var instances []*clb.TargetGroupBackend
+	errRet = resource.Retry(tccommon.ReadRetryTimeout, func() *resource.RetryError {
+		result, e := me.DescribeTargetGroupInstances(ctx, map[string]string{"TargetGroupId": targetGroupId})
+		if e != nil {
+			return tccommon.RetryError(e)
+		}
+		instances = result
+		return nil
+	})
+	if errRet != nil {
+		return
+	}
+	for _, instance := range instances {
+		backend := ClbTrafficShiftBackend{
+			InstanceId: helper.PString(instance.InstanceId),
+			Port:       int64(helper.PUint64(instance.Port)),
+			Weight:     int64(helper.PUint64(instance.Weight)),
+		}
+		if len(instance.PrivateIpAddresses) > 0 {
+			backend.Ip = *instance.PrivateIpAddresses[0]
+		}
+		backends = append(backends, backend)
+	}
+	return
+}
+
+// SetClbTrafficShiftState changes the weights of the target group associations or the backends to the ones of the state.
+func (me *ClbService) SetClbTrafficShiftState(ctx context.Context, shift *ClbTrafficShift, state *ClbTrafficShiftState) error {
+	logId := tccommon.GetLogId(ctx)
+
+	if shift.byTargetGroup() {
+		// associating a bound target group again updates the weight of the association
+		request := clb.NewAssociateTargetGroupsRequest()
+		for _, side := range []struct {
+			targetGroupId string
+			weight        int64
+		}{{shift.FromTargetGroupId, state.FromWeight}, {shift.ToTargetGroupId, state.ToWeight}} {
+			association := &clb.TargetGroupAssociation{
+				LoadBalancerId: helper.String(shift.ClbId),
+				ListenerId:     helper.String(shift.ListenerId),
+				TargetGroupId:  helper.String(side.targetGroupId),
+				Weight:         helper.Int64(side.weight),
+			}
+			if shift.LocationId != "" {
+				association.LocationId = helper.String(shift.LocationId)
+			}
+			request.Associations = append(request.Associations, association)
+		}
+		return me.callClbTask(ctx, request.GetAction(), func() (*string, error) {
+			response, e := me.client.UseClbClient().AssociateTargetGroups(request)
+			if e != nil || response == nil || response.Response == nil {
+				return nil, e
+			}
+			log.Printf("[DEBUG]%s api[%s] success, request body [%s], response body [%s]\n",
+				logId, request.GetAction(), request.ToJsonString(), response.ToJsonString())
+			return response.Response.RequestId, nil
+		})
+	}
+
+	rule := &clb.RsWeightRule{ListenerId: helper.String(shift.ListenerId)}
+	if shift.LocationId != "" {
+		rule.LocationId = helper.String(shift.LocationId)
+	}
+	for _, backend := range append(append([]ClbTrafficShiftBackend{}, state.From...), state.To...) {
+		target := &clb.Target{Port: helper.Int64(backend.Port), Weight: helper.Int64(backend.Weight)}
+		if backend.InstanceId != "" {
+			target.InstanceId = helper.String(backend.InstanceId)
+		} else {
+			target.EniIp = helper.String(backend.Ip)
+		}
+		rule.Targets = append(rule.Targets, target)
+	}
+	request := clb.NewBatchModifyTargetWeightRequest()
+	request.LoadBalancerId = helper.String(shift.ClbId)
+	request.ModifyList = []*clb.RsWeightRule{rule}
+
+	return me.callClbTask(ctx, request.GetAction(), func() (*string, error) {
+		response, e := me.client.UseClbClient().BatchModifyTargetWeight(request)
+		if e != nil || response == nil || response.Response == nil {
+			return nil, e
+		}
+		log.Printf("[DEBUG]%s api[%s] success, request body [%s], response body [%s]\n",
+			logId, request.GetAction(), request.ToJsonString(), response.ToJsonString())
+		return response.Response.RequestId, nil
+	})
+}
+
+// CheckClbTrafficShiftHealth returns an error if any backend taking traffic is unhealthy.
+func (me *ClbService) CheckClbTrafficShiftHealth(ctx context.Context, shift *ClbTrafficShift, from, to []ClbTrafficShiftBackend, percent int64) error {
+	var backends []ClbTrafficShiftBackend
+	if percent < 100 {
+		backends = append(backends, from...)
+	}
+	if percent > 0 {
+		backends = append(backends, to...)
+	}
+
+	var loadBalancers []*clb.LoadBalancerHealth
+	err := resource.Retry(tccommon.ReadRetryTimeout, func() *resource.RetryError {
+		result, e := me.DescribeClbTargetHealthByFilter(ctx, map[string]interface{}{
+			"LoadBalancerIds": []*string{helper.String(shift.ClbId)},
+		})
+		if e != nil {
+			return tccommon.RetryError(e)
+		}
+		loadBalancers = result
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	healthy := make(map[string]bool)
+	for _, loadBalancer := range loadBalancers {
+		for _, listener := range loadBalancer.Listeners {
+			if helper.PString(listener.ListenerId) != shift.ListenerId {
+				continue
+			}
+			for _, rule := range listener.Rules {
+				if shift.LocationId != "" && helper.PString(rule.LocationId) != shift.LocationId {
+					continue
+				}
+				for _, target := range rule.Targets {
+					port := helper.PInt64(target.Port)
+					status := target.HealthStatus != nil && *target.HealthStatus
+					healthy[fmt.Sprintf("%s:%d", helper.PString(target.TargetId), port)] = status
+					healthy[fmt.Sprintf("%s:%d", helper.PString(target.IP), port)] = status
+				}
+			}
+		}
+	}
+
+	var unhealthy []string
+	for _, backend := range backends {
+		if !healthy[fmt.Sprintf("%s:%d", backend.InstanceId, backend.Port)] && !healthy[fmt.Sprintf("%s:%d", backend.Ip, backend.Port)] {
+			unhealthy = append(unhealthy, backend.String())
+		}
+	}
+	if len(unhealthy) > 0 {
+		return fmt.Errorf("backends %s are unhealthy", strings.Join(unhealthy, ", "))
+	}
+	return nil
+}
+
+// CheckClbTrafficShiftMetric returns an error if the latest value of the metric breaches the threshold.
+func (me *ClbService) CheckClbTrafficShiftMetric(ctx context.Context, check *ClbTrafficShiftMetricCheck) error {
+	logId := tccommon.GetLogId(ctx)
+
+	request := monitor.NewGetMonitorDataRequest()
+	request.Namespace = helper.String(check.Namespace)
+	request.MetricName = helper.String(check.MetricName)
+	request.Period = helper.Uint64(uint64(check.Period))
+	now := time.Now()
+	request.StartTime = helper.String(now.Add(-5 * time.Duration(check.Period) * time.Second).Format(time.RFC3339))
+	request.EndTime = helper.String(now.Format(time.RFC3339))
+	instance := &monitor.Instance{}
+	for name, value := range check.Dimensions {
+		instance.Dimensions = append(instance.Dimensions, &monitor.Dimension{
+			Name:  helper.String(name),
+			Value: helper.String(value),
+		})
+	}
+	request.Instances = []*monitor.Instance{instance}
+
+	var response *monitor.GetMonitorDataResponse
+	err := resource.Retry(tccommon.ReadRetryTimeout, func() *resource.RetryError {
+		ratelimit.Check(request.GetAction())
+		result, e := me.client.UseMonitorClient().GetMonitorData(request)
+		if e != nil {
+			return tccommon.RetryError(e, tccommon.InternalError)
+		}
+		response = result
+		return nil
+	})
+	if err != nil {
+		log.Printf("[CRITAL]%s api[%s] fail, request body [%s], reason[%s]\n",
+			logId, request.GetAction(), request.ToJsonString(), err.Error())
+		return err
+	}
+	log.Printf("[DEBUG]%s api[%s] success, request body [%s], response body [%s]\n",
+		logId, request.GetAction(), request.ToJsonString(), response.ToJsonString())
+
+	if response.Response == nil || len(response.Response.DataPoints) == 0 || len(response.Response.DataPoints[0].Values) == 0 {
+		log.Printf("[WARN]%s metric %s/%s has no data, the check is skipped", logId, check.Namespace, check.MetricName)
+		return nil
+	}
+	values := response.Response.DataPoints[0].Values
+	if values[len(values)-1] == nil {
+		return nil
+	}
+	value := *values[len(values)-1]
+	if ClbTrafficShiftMetricBreached(check.Comparison, value, check.Threshold) {
+		return fmt.Errorf("metric %s/%s is %v, which breaches the threshold %s %v",
+			check.Namespace, check.MetricName, value, check.Comparison, check.Threshold)
+	}
+	return nil
+}
+
+// ShiftClbTraffic moves the traffic from the current percent to target percent step by step, last is the percent set by the
+// previous shift. After each step it waits for the step interval and checks the health of the backends and the metric, and on
+// failure it restores the weights before the shift if RollbackOnErr is set. The percent the traffic stays at and the steps are
+// returned with the error.
+func (me *ClbService) ShiftClbTraffic(ctx context.Context, shift *ClbTrafficShift, last, target int64) (current int64, steps []ClbTrafficShiftStep, errRet error) {
+	logId := tccommon.GetLogId(ctx)
+
+	state, err := me.DescribeClbTrafficShiftState(ctx, shift)
+	if err != nil {
+		errRet = err
+		return
+	}
+	if len(state.From) == 0 || len(state.To) == 0 {
+		errRet = fmt.Errorf("both sides of the traffic shift must have backends, got %d and %d", len(state.From), len(state.To))
+		return
+	}
+	start := shift.CurrentPercent(state, last)
+	current = start
+
+	record := func(percent int64, status, message string) {
+		steps = append(steps, ClbTrafficShiftStep{
+			Percent: percent,
+			Time:    time.Now().Format(time.RFC3339),
+			Status:  status,
+			Message: message,
+		})
+		log.Printf("[DEBUG]%s traffic shift of listener [%s] to %d%%: %s %s", logId, shift.ListenerId, percent, status, message)
+	}
+
+	for _, percent := range ClbTrafficShiftSteps(start, target, shift.StepPercent) {
+		err := me.SetClbTrafficShiftState(ctx, shift, shift.Weighted(state, percent))
+		if err == nil {
+			current = percent
+			time.Sleep(shift.StepInterval)
+			if shift.CheckHealth {
+				err = me.CheckClbTrafficShiftHealth(ctx, shift, state.From, state.To, percent)
+			}
+			if err == nil && shift.MetricCheck != nil {
+				err = me.CheckClbTrafficShiftMetric(ctx, shift.MetricCheck)
+			}
+		}
+		if err == nil {
+			record(percent, CLB_TRAFFIC_SHIFT_STEP_SUCCESS, "")
+			continue
+		}
+
+		record(percent, CLB_TRAFFIC_SHIFT_STEP_FAILED, err.Error())
+		errRet = fmt.Errorf("traffic shift to %d%% failed, %v", percent, err)
+		if !shift.RollbackOnErr {
+			return
+		}
+		if e := me.SetClbTrafficShiftState(ctx, shift, state); e != nil {
+			errRet = fmt.Errorf("%v, and rollback to %d%% failed, %v", errRet, start, e)
+			return
+		}
+		current = start
+		record(start, CLB_TRAFFIC_SHIFT_STEP_ROLLED_BACK, "")
+		return
+	}
+	return
+}
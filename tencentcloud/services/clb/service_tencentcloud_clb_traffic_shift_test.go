@@ -0,0 +1,176 @@
+package clb
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestClbTrafficShiftSteps(t *testing.T) {
+	cases := []struct {
+		start, target, step int64
+		want                []int64
+	}{
+		{start: 0, target: 50, step: 10, want: []int64{10, 20, 30, 40, 50}},
+		{start: 0, target: 35, step: 10, want: []int64{10, 20, 30, 35}},
+		{start: 50, target: 0, step: 25, want: []int64{25, 0}},
+		{start: 43, target: 100, step: 30, want: []int64{73, 100}},
+		{start: 30, target: 30, step: 10},
+		{start: 0, target: 100, step: 0, want: []int64{100}},
+	}
+	for _, c := range cases {
+		if got := ClbTrafficShiftSteps(c.start, c.target, c.step); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("ClbTrafficShiftSteps(%d, %d, %d) = %v, want %v", c.start, c.target, c.step, got, c.want)
+		}
+	}
+}
+
+func TestClbTrafficShiftWeights(t *testing.T) {
+	cases := []struct {
+		percent            int64
+		fromCount, toCount int
+		fromWeight         int64
+		toWeight           int64
+		wantPercent        int64
+	}{
+		{percent: 0, fromCount: 2, toCount: 2, fromWeight: 100, toWeight: 0, wantPercent: 0},
+		{percent: 100, fromCount: 2, toCount: 2, fromWeight: 0, toWeight: 100, wantPercent: 100},
+		{percent: 50, fromCount: 1, toCount: 1, fromWeight: 100, toWeight: 100, wantPercent: 50},
+		{percent: 50, fromCount: 2, toCount: 1, fromWeight: 50, toWeight: 100, wantPercent: 50},
+		{percent: 25, fromCount: 1, toCount: 3, fromWeight: 100, toWeight: 11, wantPercent: 25},
+		{percent: 70, fromCount: 3, toCount: 2, fromWeight: 29, toWeight: 100, wantPercent: 70},
+		// the weight of a side taking traffic is at least 1, so small percents are rounded up
+		{percent: 1, fromCount: 1, toCount: 10, fromWeight: 100, toWeight: 1, wantPercent: 9},
+		// a side without backends takes no traffic
+		{percent: 50, fromCount: 2, toCount: 0, fromWeight: 100, toWeight: 0, wantPercent: 0},
+		{percent: 50, fromCount: 0, toCount: 2, fromWeight: 0, toWeight: 100, wantPercent: 100},
+	}
+	for _, c := range cases {
+		fromWeight, toWeight := ClbTrafficShiftWeights(c.percent, c.fromCount, c.toCount)
+		if fromWeight != c.fromWeight || toWeight != c.toWeight {
+			t.Errorf("ClbTrafficShiftWeights(%d, %d, %d) = %d, %d, want %d, %d",
+				c.percent, c.fromCount, c.toCount, fromWeight, toWeight, c.fromWeight, c.toWeight)
+			continue
+		}
+		from := make([]ClbTrafficShiftBackend, c.fromCount)
+		for i := range from {
+			from[i].Weight = fromWeight
+		}
+		to := make([]ClbTrafficShiftBackend, c.toCount)
+		for i := range to {
+			to[i].Weight = toWeight
+		}
+		if got := ClbTrafficShiftPercent(from, to); got != c.wantPercent {
+			t.Errorf("percent of ClbTrafficShiftWeights(%d, %d, %d) = %d, want %d", c.percent, c.fromCount, c.toCount, got, c.wantPercent)
+		}
+	}
+
+	if got := ClbTrafficShiftPercent([]ClbTrafficShiftBackend{{Weight: 0}}, []ClbTrafficShiftBackend{{Weight: 0}}); got != 0 {
+		t.Errorf("percent of zero weights = %d, want 0", got)
+	}
+}
+
+func TestClbTrafficShiftStateBackends(t *testing.T) {
+	shift := &ClbTrafficShift{}
+	state := &ClbTrafficShiftState{
+		From: []ClbTrafficShiftBackend{{InstanceId: "ins-1", Port: 80, Weight: 10}, {InstanceId: "ins-2", Port: 80, Weight: 30}},
+		To:   []ClbTrafficShiftBackend{{Ip: "10.0.0.1", Port: 80, Weight: 0}},
+	}
+	if got := shift.Percent(state); got != 0 {
+		t.Errorf("Percent = %d, want 0", got)
+	}
+
+	weighted := shift.Weighted(state, 50)
+	wantWeighted := &ClbTrafficShiftState{
+		From: []ClbTrafficShiftBackend{{InstanceId: "ins-1", Port: 80, Weight: 50}, {InstanceId: "ins-2", Port: 80, Weight: 50}},
+		To:   []ClbTrafficShiftBackend{{Ip: "10.0.0.1", Port: 80, Weight: 100}},
+	}
+	if !reflect.DeepEqual(weighted, wantWeighted) {
+		t.Errorf("Weighted = %+v, want %+v", weighted, wantWeighted)
+	}
+	// the original weights are kept for the rollback
+	if state.From[0].Weight != 10 || state.From[1].Weight != 30 || state.To[0].Weight != 0 {
+		t.Errorf("Weighted changes the original state %+v", state)
+	}
+
+	cases := []struct {
+		name  string
+		state *ClbTrafficShiftState
+		last  int64
+		want  int64
+	}{
+		{name: "weights of the last percent", state: weighted, last: 50, want: 50},
+		{name: "original weights", state: state, last: 0, want: 0},
+		{name: "changed outside", state: &ClbTrafficShiftState{
+			From: []ClbTrafficShiftBackend{{InstanceId: "ins-1", Port: 80, Weight: 10}, {InstanceId: "ins-2", Port: 80, Weight: 10}},
+			To:   []ClbTrafficShiftBackend{{Ip: "10.0.0.1", Port: 80, Weight: 60}},
+		}, last: 50, want: 75},
+	}
+	for _, c := range cases {
+		if got := shift.CurrentPercent(c.state, c.last); got != c.want {
+			t.Errorf("%s: CurrentPercent = %d, want %d", c.name, got, c.want)
+		}
+	}
+
+	// the rounded weights of 1% give 9%, which is taken as 1% only if it was set by the last shift
+	small := &ClbTrafficShiftState{From: make([]ClbTrafficShiftBackend, 1), To: make([]ClbTrafficShiftBackend, 10)}
+	small = shift.Weighted(small, 1)
+	if got := shift.CurrentPercent(small, 1); got != 1 {
+		t.Errorf("CurrentPercent of the last percent = %d, want 1", got)
+	}
+	if got := shift.CurrentPercent(small, 20); got != 9 {
+		t.Errorf("CurrentPercent of other percent = %d, want 9", got)
+	}
+}
+
+func TestClbTrafficShiftStateTargetGroups(t *testing.T) {
+	shift := &ClbTrafficShift{FromTargetGroupId: "lbtg-1", ToTargetGroupId: "lbtg-2"}
+	state := &ClbTrafficShiftState{
+		From:       []ClbTrafficShiftBackend{{Ip: "10.0.0.1", Port: 80, Weight: 10}, {Ip: "10.0.0.2", Port: 80, Weight: 20}},
+		To:         []ClbTrafficShiftBackend{{Ip: "10.0.0.3", Port: 80, Weight: 10}},
+		FromWeight: 10,
+		ToWeight:   10,
+	}
+	// the traffic is split by the weights of the associations, not the backends
+	if got := shift.Percent(state); got != 50 {
+		t.Errorf("Percent = %d, want 50", got)
+	}
+
+	weighted := shift.Weighted(state, 30)
+	if weighted.FromWeight != 100 || weighted.ToWeight != 43 {
+		t.Errorf("Weighted association weights = %d, %d, want 100, 43", weighted.FromWeight, weighted.ToWeight)
+	}
+	if !reflect.DeepEqual(weighted.From, state.From) || !reflect.DeepEqual(weighted.To, state.To) {
+		t.Errorf("Weighted changes the weights of the backends in the target groups: %+v", weighted)
+	}
+	if state.FromWeight != 10 || state.ToWeight != 10 {
+		t.Errorf("Weighted changes the original state %+v", state)
+	}
+
+	if got := shift.CurrentPercent(weighted, 30); got != 30 {
+		t.Errorf("CurrentPercent of the last percent = %d, want 30", got)
+	}
+	if got := shift.CurrentPercent(state, 30); got != 50 {
+		t.Errorf("CurrentPercent of weights changed outside = %d, want 50", got)
+	}
+}
+
+func TestClbTrafficShiftMetricBreached(t *testing.T) {
+	cases := []struct {
+		comparison       string
+		value, threshold float64
+		want             bool
+	}{
+		{comparison: CLB_TRAFFIC_SHIFT_COMPARISON_GT, value: 10, threshold: 10, want: false},
+		{comparison: CLB_TRAFFIC_SHIFT_COMPARISON_GT, value: 11, threshold: 10, want: true},
+		{comparison: CLB_TRAFFIC_SHIFT_COMPARISON_GE, value: 10, threshold: 10, want: true},
+		{comparison: CLB_TRAFFIC_SHIFT_COMPARISON_LT, value: 9.5, threshold: 10, want: true},
+		{comparison: CLB_TRAFFIC_SHIFT_COMPARISON_LT, value: 10, threshold: 10, want: false},
+		{comparison: CLB_TRAFFIC_SHIFT_COMPARISON_LE, value: 10, threshold: 10, want: true},
+		{comparison: "", value: 11, threshold: 10, want: true},
+	}
+	for _, c := range cases {
+		if got := ClbTrafficShiftMetricBreached(c.comparison, c.value, c.threshold); got != c.want {
+			t.Errorf("ClbTrafficShiftMetricBreached(%q, %v, %v) = %t, want %t", c.comparison, c.value, c.threshold, got, c.want)
+		}
+	}
+}
@@ -128,7 +128,7 @@ func dataSourceTencentCloudAvailabilityZonesRead(d *schema.ResourceData, meta in
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if err := tccommon.WriteToFile(output.(string), zoneList); err != nil {
+		if err := tccommon.WriteToFile(output.(string), zoneList, tccommon.GetResultOutputOption(d)); err != nil {
 			return err
 		}
 	}
@@ -137,7 +137,7 @@ func dataSourceTencentCloudAvailabilityZonesByProductRead(d *schema.ResourceData
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if err := tccommon.WriteToFile(output.(string), zoneList); err != nil {
+		if err := tccommon.WriteToFile(output.(string), zoneList, tccommon.GetResultOutputOption(d)); err != nil {
 			return err
 		}
 	}
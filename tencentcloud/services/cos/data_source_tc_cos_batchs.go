@@ -164,7 +164,7 @@ func dataSourceTencentCloudCosBatchsRead(d *schema.ResourceData, meta interface{
 	_ = d.Set("jobs", jobs)
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if err := tccommon.WriteToFile(output.(string), jobs); err != nil {
+		if err := tccommon.WriteToFile(output.(string), jobs, tccommon.GetResultOutputOption(d)); err != nil {
 			return err
 		}
 	}
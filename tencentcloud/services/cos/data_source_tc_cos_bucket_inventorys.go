@@ -246,7 +246,7 @@ func dataSourceTencentCloudCosBucketInventorysRead(d *schema.ResourceData, meta
 	_ = d.Set("inventorys", inventoryConfigurations)
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if err := tccommon.WriteToFile(output.(string), inventoryConfigurations); err != nil {
+		if err := tccommon.WriteToFile(output.(string), inventoryConfigurations, tccommon.GetResultOutputOption(d)); err != nil {
 			return err
 		}
 	}
@@ -174,7 +174,7 @@ func dataSourceTencentCloudCosBucketMultipartUploadsRead(d *schema.ResourceData,
 	_ = d.Set("uploads", multipartUploads)
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if err := tccommon.WriteToFile(output.(string), multipartUploads); err != nil {
+		if err := tccommon.WriteToFile(output.(string), multipartUploads, tccommon.GetResultOutputOption(d)); err != nil {
 			return err
 		}
 	}
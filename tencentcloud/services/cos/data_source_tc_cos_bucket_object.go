@@ -115,7 +115,7 @@ func dataSourceTencentCloudCosBucketObjectsRead(d *schema.ResourceData, meta int
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if err = tccommon.WriteToFile(output.(string), outputMap); err != nil {
+		if err = tccommon.WriteToFile(output.(string), outputMap, tccommon.GetResultOutputOption(d)); err != nil {
 			return err
 		}
 	}
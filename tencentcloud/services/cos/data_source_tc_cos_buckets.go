@@ -402,13 +402,13 @@ func dataSourceTencentCloudCosBucketsRead(d *schema.ResourceData, meta interface
 
 		cosDomain := meta.(tccommon.ProviderMeta).GetAPIV3Conn().CosDomain
 		if cosDomain == "" {
-			originRules, err := cosService.GetBucketPullOrigin(ctx, *v.Name)
+			originRules, err := cosService.GetBucketPullOrigin(ctx, *v.Name, "")
 			if err != nil {
 				return err
 			}
 			bucket["origin_pull_rules"] = originRules
 
-			domainRules, err := cosService.GetBucketOriginDomain(ctx, *v.Name)
+			domainRules, err := cosService.GetBucketOriginDomain(ctx, *v.Name, "")
 			if err == nil {
 				bucket["origin_domain_rules"] = domainRules
 			}
@@ -444,7 +444,7 @@ func dataSourceTencentCloudCosBucketsRead(d *schema.ResourceData, meta interface
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if err = tccommon.WriteToFile(output.(string), bucketList); err != nil {
+		if err = tccommon.WriteToFile(output.(string), bucketList, tccommon.GetResultOutputOption(d)); err != nil {
 			return err
 		}
 	}
@@ -0,0 +1,135 @@
+package cos
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	tccommon "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/common"
+	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/internal/helper"
+)
+
+func DataSourceTencentCloudCosObjectPresignedUrl() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceTencentCloudCosObjectPresignedUrlRead,
+
+		Schema: map[string]*schema.Schema{
+			"bucket": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the bucket, in the format of `bucketname-appid`.",
+			},
+			"key": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The full path to the object inside the bucket.",
+			},
+			"cdc_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "CDC cluster ID of the bucket.",
+			},
+			"method": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      http.MethodGet,
+				ValidateFunc: validation.StringInSlice([]string{http.MethodGet, http.MethodPut, http.MethodHead}, false),
+				Description:  "HTTP method of the url. Valid values: `GET`, `PUT`, `HEAD`. Default is `GET`.",
+			},
+			"expire_seconds": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      3600,
+				ValidateFunc: validation.IntBetween(1, 7*24*3600),
+				Description:  "Validity period of the url in seconds, at most 7 days. Default is `3600`.",
+			},
+			"headers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Headers signed into the url, such as `Content-Type`, requests of the url must carry the same headers.",
+			},
+			"query": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Query params signed into the url, such as `response-content-disposition`.",
+			},
+			"sign_host": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether to sign the `Host` header. Default is `true`, set it to `false` if the url is accessed through another domain.",
+			},
+			"url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The presigned url.",
+			},
+			"expiration_time": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Expiration time of the url, in the format of RFC3339.",
+			},
+			"result_output_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Used to save results.",
+			},
+		},
+	}
+}
+
+func dataSourceTencentCloudCosObjectPresignedUrlRead(d *schema.ResourceData, meta interface{}) error {
+	defer tccommon.LogElapsed("data_source.tencentcloud_cos_object_presigned_url.read")()
+
+	var (
+		logId      = tccommon.GetLogId(tccommon.ContextNil)
+		ctx        = context.WithValue(context.TODO(), tccommon.LogIdKey, logId)
+		cosService = CosService{client: meta.(tccommon.ProviderMeta).GetAPIV3Conn()}
+		bucket     = d.Get("bucket").(string)
+		key        = d.Get("key").(string)
+		method     = d.Get("method").(string)
+		expired    = time.Duration(d.Get("expire_seconds").(int)) * time.Second
+		header     = http.Header{}
+		query      = url.Values{}
+	)
+
+	for k, v := range d.Get("headers").(map[string]interface{}) {
+		header.Set(k, v.(string))
+	}
+	for k, v := range d.Get("query").(map[string]interface{}) {
+		query.Set(k, v.(string))
+	}
+
+	expirationTime := time.Now().Add(expired)
+	presignedUrl, err := cosService.GetObjectPresignedUrl(ctx, bucket, key, method, expired, header, query,
+		d.Get("sign_host").(bool), d.Get("cdc_id").(string))
+	if err != nil {
+		return err
+	}
+
+	d.SetId(helper.DataResourceIdsHash([]string{bucket, key, method}))
+	_ = d.Set("url", presignedUrl)
+	_ = d.Set("expiration_time", expirationTime.UTC().Format(time.RFC3339))
+
+	output, ok := d.GetOk("result_output_file")
+	if ok && output.(string) != "" {
+		if err = tccommon.WriteToFile(output.(string), map[string]interface{}{
+			"bucket":          bucket,
+			"key":             key,
+			"method":          method,
+			"url":             presignedUrl,
+			"expiration_time": expirationTime.UTC().Format(time.RFC3339),
+		}, tccommon.GetResultOutputOption(d)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
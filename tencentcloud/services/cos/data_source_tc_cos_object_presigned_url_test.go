@@ -0,0 +1,59 @@
+package cos_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	tcacctest "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/acctest"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccTencentCloudCosObjectPresignedUrlDataSource(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { tcacctest.AccPreCheck(t) },
+		Providers: tcacctest.AccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCosObjectPresignedUrlDataSource(tcacctest.Appid),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					tcacctest.AccCheckTencentCloudDataSourceID("data.tencentcloud_cos_object_presigned_url.get"),
+					resource.TestMatchResourceAttr("data.tencentcloud_cos_object_presigned_url.get", "url",
+						regexp.MustCompile(`^https://tf-presigned-url-\d+\.cos\.ap-guangzhou\.myqcloud\.com/dir/example\.txt\?.*q-sign-algorithm=sha1`)),
+					resource.TestMatchResourceAttr("data.tencentcloud_cos_object_presigned_url.get", "url",
+						regexp.MustCompile(`response-content-type=text%2Fplain`)),
+					resource.TestCheckResourceAttrSet("data.tencentcloud_cos_object_presigned_url.get", "expiration_time"),
+					resource.TestMatchResourceAttr("data.tencentcloud_cos_object_presigned_url.put", "url",
+						regexp.MustCompile(`q-header-list=content-type`)),
+				),
+			},
+		},
+	})
+}
+
+func testAccCosObjectPresignedUrlDataSource(appid string) string {
+	return fmt.Sprintf(`
+data "tencentcloud_cos_object_presigned_url" "get" {
+  bucket         = "tf-presigned-url-%[1]s"
+  key            = "dir/example.txt"
+  expire_seconds = 600
+
+  query = {
+    "response-content-type" = "text/plain"
+  }
+}
+
+data "tencentcloud_cos_object_presigned_url" "put" {
+  bucket = "tf-presigned-url-%[1]s"
+  key    = "dir/example.txt"
+  method = "PUT"
+
+  headers = {
+    "Content-Type" = "text/plain"
+  }
+}
+`, appid)
+}
@@ -17,3 +17,46 @@ var COSACLPermissionSeq = []string{
 	"WRITE_ACP",
 	"READ_ACP",
 }
+
+const (
+	COS_BUCKET_SUB_CONFIG_LIFECYCLE   = "lifecycle"
+	COS_BUCKET_SUB_CONFIG_CORS        = "cors"
+	COS_BUCKET_SUB_CONFIG_WEBSITE     = "website"
+	COS_BUCKET_SUB_CONFIG_REPLICATION = "replication"
+	COS_BUCKET_SUB_CONFIG_LOGGING     = "logging"
+	COS_BUCKET_SUB_CONFIG_ENCRYPTION  = "encryption"
+	COS_BUCKET_SUB_CONFIG_ORIGIN      = "origin"
+)
+
+var COSBucketSubConfigs = []string{
+	COS_BUCKET_SUB_CONFIG_LIFECYCLE,
+	COS_BUCKET_SUB_CONFIG_CORS,
+	COS_BUCKET_SUB_CONFIG_WEBSITE,
+	COS_BUCKET_SUB_CONFIG_REPLICATION,
+	COS_BUCKET_SUB_CONFIG_LOGGING,
+	COS_BUCKET_SUB_CONFIG_ENCRYPTION,
+	COS_BUCKET_SUB_CONFIG_ORIGIN,
+}
+
+// COSBucketSubConfigFields are the arguments of `tencentcloud_cos_bucket` for each sub configuration,
+// the standalone resource `tencentcloud_cos_bucket_<sub config>` uses the same arguments.
+var COSBucketSubConfigFields = map[string][]string{
+	COS_BUCKET_SUB_CONFIG_LIFECYCLE:   {"lifecycle_rules"},
+	COS_BUCKET_SUB_CONFIG_CORS:        {"cors_rules"},
+	COS_BUCKET_SUB_CONFIG_WEBSITE:     {"website"},
+	COS_BUCKET_SUB_CONFIG_REPLICATION: {"replica_role", "replica_rules"},
+	COS_BUCKET_SUB_CONFIG_LOGGING:     {"log_enable", "log_target_bucket", "log_prefix"},
+	COS_BUCKET_SUB_CONFIG_ENCRYPTION:  {"encryption_algorithm", "kms_id"},
+	COS_BUCKET_SUB_CONFIG_ORIGIN:      {"origin_pull_rules", "origin_domain_rules"},
+}
+
+// COS_OBJECT_LOCK_UNAVAILABLE_ERRORS are the error codes of reading the object lock configuration of a bucket which
+// has no configuration, is not accessible, or is in a region or CDC cluster not supporting object lock.
+var COS_OBJECT_LOCK_UNAVAILABLE_ERRORS = []string{
+	"NoSuchObjectLockConfiguration",
+	"ObjectLockConfigurationNotFoundError",
+	"AccessDenied",
+	"MethodNotAllowed",
+	"NotImplemented",
+	"NotSupported",
+}
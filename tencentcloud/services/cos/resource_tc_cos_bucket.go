@@ -21,8 +21,10 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/s3"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common/errors"
 
 	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/internal/helper"
@@ -105,6 +107,261 @@ func originPullRules() *schema.Resource {
 	}
 }
 
+func replicaRules() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Name of a specific rule.",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Status identifier, available values: `Enabled`, `Disabled`.",
+			},
+			"prefix": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Prefix matching policy. Policies cannot overlap; otherwise, an error will be returned. To match the root directory, leave this parameter empty.",
+			},
+			"destination_bucket": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Destination bucket identifier, format: `qcs::cos:<region>::<bucketname-appid>`. NOTE: destination bucket must enable versioning.",
+			},
+			"destination_storage_class": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Storage class of destination, available values: `STANDARD`, `INTELLIGENT_TIERING`, `STANDARD_IA`. default is following current class of destination.",
+			},
+		},
+	}
+}
+
+func corsRules() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"allowed_origins": {
+				Type:        schema.TypeList,
+				Required:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Specifies which origins are allowed.",
+			},
+			"allowed_methods": {
+				Type:        schema.TypeList,
+				Required:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Specifies which methods are allowed. Can be `GET`, `PUT`, `POST`, `DELETE` or `HEAD`.",
+			},
+			"allowed_headers": {
+				Type:        schema.TypeList,
+				Required:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Specifies which headers are allowed.",
+			},
+			"max_age_seconds": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Specifies time in seconds that browser can cache the response for a preflight request.",
+			},
+			"expose_headers": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Specifies expose header in the response.",
+			},
+		},
+	}
+}
+
+func originDomainRules() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"domain": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Specify domain host.",
+			},
+			"type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "REST",
+				Description: "Specify origin domain type, available values: `REST`, `WEBSITE`, `ACCELERATE`, default: `REST`.",
+			},
+			"status": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "ENABLED",
+				Description:  "Domain status, default: `ENABLED`.",
+				ValidateFunc: tccommon.ValidateAllowedStringValue([]string{"ENABLED", "DISABLED"}),
+			},
+			//"force_replacement": {
+			//	Type:		 schema.TypeString,
+			//	Optional: 	 true,
+			//	Description: "Specify type to replace exist domain resolve record.",
+			//},
+		},
+	}
+}
+
+func lifecycleRules() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Optional:    true,
+				Description: "A unique identifier for the rule. It can be up to 255 characters.",
+			},
+			"filter_prefix": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Object key prefix identifying one or more objects to which the rule applies.",
+			},
+			"transition": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Set:         transitionHash,
+				Description: "Specifies a period in the object's transitions (documented below).",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"date": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: tccommon.ValidateCosBucketLifecycleTimestamp,
+							Description:  "Specifies the date after which you want the corresponding action to take effect.",
+						},
+						"days": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: tccommon.ValidateIntegerMin(0),
+							Description:  "Specifies the number of days after object creation when the specific rule action takes effect.",
+						},
+						"storage_class": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Specifies the storage class to which you want the object to transition. Available values include `STANDARD_IA`, `MAZ_STANDARD_IA`, `INTELLIGENT_TIERING`, `MAZ_INTELLIGENT_TIERING`, `ARCHIVE`, `DEEP_ARCHIVE`. For more information, please refer to: https://cloud.tencent.com/document/product/436/33417.",
+						},
+					},
+				},
+			},
+			"expiration": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Set:         expirationHash,
+				MaxItems:    1,
+				Description: "Specifies a period in the object's expire (documented below).",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"date": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: tccommon.ValidateCosBucketLifecycleTimestamp,
+							Description:  "Specifies the date after which you want the corresponding action to take effect.",
+						},
+						"days": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: tccommon.ValidateIntegerMin(0),
+							Description:  "Specifies the number of days after object creation when the specific rule action takes effect.",
+						},
+						"delete_marker": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Description: "Indicates whether the delete marker of an expired object will be removed.",
+						},
+					},
+				},
+			},
+			"non_current_transition": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Set:         nonCurrentTransitionHash,
+				Description: "Specifies a period in the non current object's transitions.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"non_current_days": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: tccommon.ValidateIntegerMin(0),
+							Description:  "Number of days after non current object creation when the specific rule action takes effect.",
+						},
+						"storage_class": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Specifies the storage class to which you want the non current object to transition. Available values include `STANDARD_IA`, `MAZ_STANDARD_IA`, `INTELLIGENT_TIERING`, `MAZ_INTELLIGENT_TIERING`, `ARCHIVE`, `DEEP_ARCHIVE`. For more information, please refer to: https://cloud.tencent.com/document/product/436/33417.",
+						},
+					},
+				},
+			},
+			"non_current_expiration": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Set:         nonCurrentExpirationHash,
+				MaxItems:    1,
+				Description: "Specifies when non current object versions shall expire.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"non_current_days": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: tccommon.ValidateIntegerMin(0),
+							Description:  "Number of days after non current object creation when the specific rule action takes effect. The maximum value is 3650.",
+						},
+					},
+				},
+			},
+			"abort_incomplete_multipart_upload": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Set:         abortIncompleteMultipartUploadHash,
+				MaxItems:    1,
+				Description: "Set the maximum time a multipart upload is allowed to remain running.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"days_after_initiation": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: tccommon.ValidateIntegerMin(1),
+							Description:  "Specifies the number of days after the multipart upload starts that the upload must be completed. The maximum value is 3650.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func websiteConfiguration() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"index_document": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "COS returns this index document when requests are made to the root domain or any of the subfolders.",
+			},
+			"error_document": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "An absolute path to the document to return in case of a 4XX error.",
+			},
+			"redirect_all_requests_to": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: tccommon.ValidateAllowedStringValue([]string{"http", "https"}),
+				Description:  "Redirects all request configurations. Valid values: http, https. Default is `http`.",
+			},
+			"endpoint": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "`Endpoint` of the static website.",
+			},
+		},
+	}
+}
+
 // x-cos-grant-* headers may conflict with xml acl body, we don't open up for now.
 //func aclGrantHeaders() *schema.Schema {
 //	return &schema.Schema{
@@ -154,6 +411,7 @@ func ResourceTencentCloudCosBucket() *schema.Resource {
 				"force_clean": false,
 			}),
 		},
+		CustomizeDiff: resourceTencentCloudCosBucketCustomizeDiff,
 
 		Schema: map[string]*schema.Schema{
 			"bucket": {
@@ -211,7 +469,7 @@ func ResourceTencentCloudCosBucket() *schema.Resource {
 				Type:        schema.TypeBool,
 				Optional:    true,
 				Default:     false,
-				Description: "Force cleanup all objects before delete bucket.",
+				Description: "Force cleanup all objects before delete bucket. It is refused if the bucket has object lock enabled, the bucket is cleaned as usual if its object lock configuration can not be read, such as the access is denied or object lock is not supported in the region.",
 			},
 			"replica_role": {
 				Type:         schema.TypeString,
@@ -224,73 +482,13 @@ func ResourceTencentCloudCosBucket() *schema.Resource {
 				Optional:     true,
 				Description:  "List of replica rule. NOTE: only `versioning_enable` is true and `replica_role` set can configure this argument.",
 				RequiredWith: []string{"replica_role", "versioning_enable"},
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"id": {
-							Type:        schema.TypeString,
-							Optional:    true,
-							Description: "Name of a specific rule.",
-						},
-						"status": {
-							Type:        schema.TypeString,
-							Required:    true,
-							Description: "Status identifier, available values: `Enabled`, `Disabled`.",
-						},
-						"prefix": {
-							Type:        schema.TypeString,
-							Optional:    true,
-							Description: "Prefix matching policy. Policies cannot overlap; otherwise, an error will be returned. To match the root directory, leave this parameter empty.",
-						},
-						"destination_bucket": {
-							Type:        schema.TypeString,
-							Required:    true,
-							Description: "Destination bucket identifier, format: `qcs::cos:<region>::<bucketname-appid>`. NOTE: destination bucket must enable versioning.",
-						},
-						"destination_storage_class": {
-							Type:        schema.TypeString,
-							Optional:    true,
-							Description: "Storage class of destination, available values: `STANDARD`, `INTELLIGENT_TIERING`, `STANDARD_IA`. default is following current class of destination.",
-						},
-					},
-				},
+				Elem:         replicaRules(),
 			},
 			"cors_rules": {
 				Type:        schema.TypeList,
 				Optional:    true,
 				Description: "A rule of Cross-Origin Resource Sharing (documented below).",
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"allowed_origins": {
-							Type:        schema.TypeList,
-							Required:    true,
-							Elem:        &schema.Schema{Type: schema.TypeString},
-							Description: "Specifies which origins are allowed.",
-						},
-						"allowed_methods": {
-							Type:        schema.TypeList,
-							Required:    true,
-							Elem:        &schema.Schema{Type: schema.TypeString},
-							Description: "Specifies which methods are allowed. Can be `GET`, `PUT`, `POST`, `DELETE` or `HEAD`.",
-						},
-						"allowed_headers": {
-							Type:        schema.TypeList,
-							Required:    true,
-							Elem:        &schema.Schema{Type: schema.TypeString},
-							Description: "Specifies which headers are allowed.",
-						},
-						"max_age_seconds": {
-							Type:        schema.TypeInt,
-							Optional:    true,
-							Description: "Specifies time in seconds that browser can cache the response for a preflight request.",
-						},
-						"expose_headers": {
-							Type:        schema.TypeList,
-							Optional:    true,
-							Elem:        &schema.Schema{Type: schema.TypeString},
-							Description: "Specifies expose header in the response.",
-						},
-					},
-				},
+				Elem:        corsRules(),
 			},
 			"origin_pull_rules": {
 				Type:        schema.TypeList,
@@ -302,195 +500,20 @@ func ResourceTencentCloudCosBucket() *schema.Resource {
 				Type:        schema.TypeList,
 				Optional:    true,
 				Description: "Bucket Origin Domain settings.",
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"domain": {
-							Type:        schema.TypeString,
-							Required:    true,
-							Description: "Specify domain host.",
-						},
-						"type": {
-							Type:        schema.TypeString,
-							Optional:    true,
-							Default:     "REST",
-							Description: "Specify origin domain type, available values: `REST`, `WEBSITE`, `ACCELERATE`, default: `REST`.",
-						},
-						"status": {
-							Type:         schema.TypeString,
-							Optional:     true,
-							Default:      "ENABLED",
-							Description:  "Domain status, default: `ENABLED`.",
-							ValidateFunc: tccommon.ValidateAllowedStringValue([]string{"ENABLED", "DISABLED"}),
-						},
-						//"force_replacement": {
-						//	Type:		 schema.TypeString,
-						//	Optional: 	 true,
-						//	Description: "Specify type to replace exist domain resolve record.",
-						//},
-					},
-				},
+				Elem:        originDomainRules(),
 			},
 			"lifecycle_rules": {
 				Type:        schema.TypeList,
 				Optional:    true,
 				Description: "A configuration of object lifecycle management (documented below).",
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"id": {
-							Type:        schema.TypeString,
-							Computed:    true,
-							Optional:    true,
-							Description: "A unique identifier for the rule. It can be up to 255 characters.",
-						},
-						"filter_prefix": {
-							Type:        schema.TypeString,
-							Required:    true,
-							Description: "Object key prefix identifying one or more objects to which the rule applies.",
-						},
-						"transition": {
-							Type:        schema.TypeSet,
-							Optional:    true,
-							Set:         transitionHash,
-							Description: "Specifies a period in the object's transitions (documented below).",
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"date": {
-										Type:         schema.TypeString,
-										Optional:     true,
-										ValidateFunc: tccommon.ValidateCosBucketLifecycleTimestamp,
-										Description:  "Specifies the date after which you want the corresponding action to take effect.",
-									},
-									"days": {
-										Type:         schema.TypeInt,
-										Optional:     true,
-										ValidateFunc: tccommon.ValidateIntegerMin(0),
-										Description:  "Specifies the number of days after object creation when the specific rule action takes effect.",
-									},
-									"storage_class": {
-										Type:        schema.TypeString,
-										Required:    true,
-										Description: "Specifies the storage class to which you want the object to transition. Available values include `STANDARD_IA`, `MAZ_STANDARD_IA`, `INTELLIGENT_TIERING`, `MAZ_INTELLIGENT_TIERING`, `ARCHIVE`, `DEEP_ARCHIVE`. For more information, please refer to: https://cloud.tencent.com/document/product/436/33417.",
-									},
-								},
-							},
-						},
-						"expiration": {
-							Type:        schema.TypeSet,
-							Optional:    true,
-							Set:         expirationHash,
-							MaxItems:    1,
-							Description: "Specifies a period in the object's expire (documented below).",
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"date": {
-										Type:         schema.TypeString,
-										Optional:     true,
-										ValidateFunc: tccommon.ValidateCosBucketLifecycleTimestamp,
-										Description:  "Specifies the date after which you want the corresponding action to take effect.",
-									},
-									"days": {
-										Type:         schema.TypeInt,
-										Optional:     true,
-										ValidateFunc: tccommon.ValidateIntegerMin(0),
-										Description:  "Specifies the number of days after object creation when the specific rule action takes effect.",
-									},
-									"delete_marker": {
-										Type:        schema.TypeBool,
-										Optional:    true,
-										Description: "Indicates whether the delete marker of an expired object will be removed.",
-									},
-								},
-							},
-						},
-						"non_current_transition": {
-							Type:        schema.TypeSet,
-							Optional:    true,
-							Set:         nonCurrentTransitionHash,
-							Description: "Specifies a period in the non current object's transitions.",
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"non_current_days": {
-										Type:         schema.TypeInt,
-										Optional:     true,
-										ValidateFunc: tccommon.ValidateIntegerMin(0),
-										Description:  "Number of days after non current object creation when the specific rule action takes effect.",
-									},
-									"storage_class": {
-										Type:        schema.TypeString,
-										Required:    true,
-										Description: "Specifies the storage class to which you want the non current object to transition. Available values include `STANDARD_IA`, `MAZ_STANDARD_IA`, `INTELLIGENT_TIERING`, `MAZ_INTELLIGENT_TIERING`, `ARCHIVE`, `DEEP_ARCHIVE`. For more information, please refer to: https://cloud.tencent.com/document/product/436/33417.",
-									},
-								},
-							},
-						},
-						"non_current_expiration": {
-							Type:        schema.TypeSet,
-							Optional:    true,
-							Set:         nonCurrentExpirationHash,
-							MaxItems:    1,
-							Description: "Specifies when non current object versions shall expire.",
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"non_current_days": {
-										Type:         schema.TypeInt,
-										Optional:     true,
-										ValidateFunc: tccommon.ValidateIntegerMin(0),
-										Description:  "Number of days after non current object creation when the specific rule action takes effect. The maximum value is 3650.",
-									},
-								},
-							},
-						},
-						"abort_incomplete_multipart_upload": {
-							Type:        schema.TypeSet,
-							Optional:    true,
-							Set:         abortIncompleteMultipartUploadHash,
-							MaxItems:    1,
-							Description: "Set the maximum time a multipart upload is allowed to remain running.",
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"days_after_initiation": {
-										Type:         schema.TypeInt,
-										Required:     true,
-										ValidateFunc: tccommon.ValidateIntegerMin(1),
-										Description:  "Specifies the number of days after the multipart upload starts that the upload must be completed. The maximum value is 3650.",
-									},
-								},
-							},
-						},
-					},
-				},
+				Elem:        lifecycleRules(),
 			},
 			"website": {
 				Type:        schema.TypeList,
 				Optional:    true,
 				MaxItems:    1,
 				Description: "A website object(documented below).",
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"index_document": {
-							Type:        schema.TypeString,
-							Optional:    true,
-							Description: "COS returns this index document when requests are made to the root domain or any of the subfolders.",
-						},
-						"error_document": {
-							Type:        schema.TypeString,
-							Optional:    true,
-							Description: "An absolute path to the document to return in case of a 4XX error.",
-						},
-						"redirect_all_requests_to": {
-							Type:         schema.TypeString,
-							Optional:     true,
-							Computed:     true,
-							ValidateFunc: tccommon.ValidateAllowedStringValue([]string{"http", "https"}),
-							Description:  "Redirects all request configurations. Valid values: http, https. Default is `http`.",
-						},
-						"endpoint": {
-							Type:        schema.TypeString,
-							Computed:    true,
-							Description: "`Endpoint` of the static website.",
-						},
-					},
-				},
+				Elem:        websiteConfiguration(),
 			},
 			"tags": {
 				Type:        schema.TypeMap,
@@ -545,6 +568,15 @@ func ResourceTencentCloudCosBucket() *schema.Resource {
 				ForceNew:    true,
 				Description: "CDC cluster ID.",
 			},
+			"ignore_sub_configs": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(COSBucketSubConfigs, false),
+				},
+				Description: "Sub configurations managed by standalone resources, such as `tencentcloud_cos_bucket_lifecycle`. The bucket neither reads nor updates them, and their arguments can not be set in the bucket. Valid values: `lifecycle`, `cors`, `website`, `replication`, `logging`, `encryption`, `origin`.",
+			},
 			//computed
 			"cos_bucket_url": {
 				Type:        schema.TypeString,
@@ -665,80 +697,100 @@ func resourceTencentCloudCosBucketRead(d *schema.ResourceData, meta interface{})
 
 	_ = d.Set("acl", acl)
 
-	// read the cors
-	corsRules, err := cosService.GetBucketCors(ctx, bucket, cdcId)
-	if err != nil {
-		return err
-	}
-	if err = d.Set("cors_rules", corsRules); err != nil {
-		return fmt.Errorf("setting cors_rules error: %v", err)
+	// sub configurations managed by standalone resources are cleared from the state
+	ignored := cosBucketIgnoredSubConfigs(d)
+	for subConfig := range ignored {
+		for _, field := range COSBucketSubConfigFields[subConfig] {
+			_ = d.Set(field, nil)
+		}
 	}
 
-	if cdcId == "" && cosDomain == "" {
-		originPullRules, err := cosService.GetBucketPullOrigin(ctx, bucket)
+	// read the cors
+	if !ignored[COS_BUCKET_SUB_CONFIG_CORS] {
+		corsRules, err := cosService.GetBucketCors(ctx, bucket, cdcId)
 		if err != nil {
 			return err
 		}
-
-		if err = d.Set("origin_pull_rules", originPullRules); err != nil {
-			return fmt.Errorf("setting origin_pull_rules error: %v", err)
+		if err = d.Set("cors_rules", corsRules); err != nil {
+			return fmt.Errorf("setting cors_rules error: %v", err)
 		}
+	}
 
-		originDomainRules, err := cosService.GetBucketOriginDomain(ctx, bucket)
-		if err != nil {
-			return err
-		}
+	if cdcId == "" && cosDomain == "" {
+		if !ignored[COS_BUCKET_SUB_CONFIG_ORIGIN] {
+			originPullRules, err := cosService.GetBucketPullOrigin(ctx, bucket, cdcId)
+			if err != nil {
+				return err
+			}
 
-		if err = d.Set("origin_domain_rules", originDomainRules); err != nil {
-			return fmt.Errorf("setting origin_domain_rules error: %v", err)
-		}
+			if err = d.Set("origin_pull_rules", originPullRules); err != nil {
+				return fmt.Errorf("setting origin_pull_rules error: %v", err)
+			}
 
-		replicaResult, err := cosService.GetBucketReplication(ctx, bucket, cdcId)
-		if err != nil {
-			return err
+			originDomainRules, err := cosService.GetBucketOriginDomain(ctx, bucket, cdcId)
+			if err != nil {
+				return err
+			}
+
+			if err = d.Set("origin_domain_rules", originDomainRules); err != nil {
+				return fmt.Errorf("setting origin_domain_rules error: %v", err)
+			}
 		}
 
-		if replicaResult != nil {
-			err := setBucketReplication(d, *replicaResult)
+		if !ignored[COS_BUCKET_SUB_CONFIG_REPLICATION] {
+			replicaResult, err := cosService.GetBucketReplication(ctx, bucket, cdcId)
 			if err != nil {
 				return err
 			}
+
+			if replicaResult != nil {
+				err := setBucketReplication(d, *replicaResult)
+				if err != nil {
+					return err
+				}
+			}
 		}
 	}
 
 	// read the lifecycle
-	lifecycleRules, err := cosService.GetBucketLifecycle(ctx, bucket, cdcId)
-	if err != nil {
-		return err
-	}
-	if err = d.Set("lifecycle_rules", lifecycleRules); err != nil {
-		return fmt.Errorf("setting lifecycle_rules error: %v", err)
+	if !ignored[COS_BUCKET_SUB_CONFIG_LIFECYCLE] {
+		lifecycleRules, err := cosService.GetBucketLifecycle(ctx, bucket, cdcId)
+		if err != nil {
+			return err
+		}
+		if err = d.Set("lifecycle_rules", lifecycleRules); err != nil {
+			return fmt.Errorf("setting lifecycle_rules error: %v", err)
+		}
 	}
 
 	// read the website
-	website, err := cosService.GetBucketWebsite(ctx, bucket, cdcId)
-	if err != nil {
-		return err
-	}
-	if len(website) > 0 && cosDomain == "" {
-		// {bucket}.cos-website.{region}.myqcloud.com
-		endPointUrl := fmt.Sprintf("%s.cos-website.%s.myqcloud.com", d.Id(), meta.(tccommon.ProviderMeta).GetAPIV3Conn().Region)
-		website[0]["endpoint"] = endPointUrl
-	}
-	if err = d.Set("website", website); err != nil {
-		return fmt.Errorf("setting website error: %v", err)
+	if !ignored[COS_BUCKET_SUB_CONFIG_WEBSITE] {
+		website, err := cosService.GetBucketWebsite(ctx, bucket, cdcId)
+		if err != nil {
+			return err
+		}
+		if len(website) > 0 && cosDomain == "" {
+			// {bucket}.cos-website.{region}.myqcloud.com
+			endPointUrl := fmt.Sprintf("%s.cos-website.%s.myqcloud.com", d.Id(), meta.(tccommon.ProviderMeta).GetAPIV3Conn().Region)
+			website[0]["endpoint"] = endPointUrl
+		}
+		if err = d.Set("website", website); err != nil {
+			return fmt.Errorf("setting website error: %v", err)
+		}
 	}
 
 	// read the encryption algorithm
-	encryption, kmsId, err := cosService.GetBucketEncryption(ctx, bucket, cdcId)
-	if err != nil {
-		return err
-	}
-	if err = d.Set("encryption_algorithm", encryption); err != nil {
-		return fmt.Errorf("setting encryption error: %v", err)
-	}
-	if err = d.Set("kms_id", kmsId); err != nil {
-		return fmt.Errorf("setting kms_id error: %v", err)
+	if !ignored[COS_BUCKET_SUB_CONFIG_ENCRYPTION] {
+		encryption, kmsId, err := cosService.GetBucketEncryption(ctx, bucket, cdcId)
+		if err != nil {
+			return err
+		}
+		if err = d.Set("encryption_algorithm", encryption); err != nil {
+			return fmt.Errorf("setting encryption error: %v", err)
+		}
+		if err = d.Set("kms_id", kmsId); err != nil {
+			return fmt.Errorf("setting kms_id error: %v", err)
+		}
 	}
 
 	// read the versioning
@@ -760,17 +812,19 @@ func resourceTencentCloudCosBucketRead(d *schema.ResourceData, meta interface{})
 	}
 
 	//read the log
-	logEnable, logTargetBucket, logPrefix, err := cosService.GetBucketLogStatus(ctx, bucket, cdcId)
-	if err != nil {
-		if e, ok := err.(*errors.TencentCloudSDKError); ok {
-			if e.GetCode() != "UnSupportedLoggingRegion" {
-				return err
+	if !ignored[COS_BUCKET_SUB_CONFIG_LOGGING] {
+		logEnable, logTargetBucket, logPrefix, err := cosService.GetBucketLogStatus(ctx, bucket, cdcId)
+		if err != nil {
+			if e, ok := err.(*errors.TencentCloudSDKError); ok {
+				if e.GetCode() != "UnSupportedLoggingRegion" {
+					return err
+				}
 			}
+		} else {
+			_ = d.Set("log_enable", logEnable)
+			_ = d.Set("log_target_bucket", logTargetBucket)
+			_ = d.Set("log_prefix", logPrefix)
 		}
-	} else {
-		_ = d.Set("log_enable", logEnable)
-		_ = d.Set("log_target_bucket", logTargetBucket)
-		_ = d.Set("log_prefix", logPrefix)
 	}
 
 	// read the tags
@@ -812,6 +866,7 @@ func resourceTencentCloudCosBucketUpdate(d *schema.ResourceData, meta interface{
 	d.Partial(true)
 
 	cdcId := d.Get("cdc_id").(string)
+	ignored := cosBucketIgnoredSubConfigs(d)
 	if d.HasChange("enable_intelligent_tiering") || d.HasChange("intelligent_tiering_days") || d.HasChange("intelligent_tiering_request_frequent") {
 		old, new := d.GetChange("enable_intelligent_tiering")
 		if old.(bool) && !new.(bool) {
@@ -868,15 +923,15 @@ func resourceTencentCloudCosBucketUpdate(d *schema.ResourceData, meta interface{
 		_ = d.Set("acl_body", body)
 	}
 
-	if d.HasChange("cors_rules") {
-		err := resourceTencentCloudCosBucketCorsUpdate(ctx, meta, d)
+	if !ignored[COS_BUCKET_SUB_CONFIG_CORS] && d.HasChange("cors_rules") {
+		err := resourceTencentCloudCosBucketCorsRulesUpdate(ctx, meta, d)
 		if err != nil {
 			return err
 		}
 
 	}
 
-	if d.HasChange("origin_pull_rules") {
+	if !ignored[COS_BUCKET_SUB_CONFIG_ORIGIN] && d.HasChange("origin_pull_rules") {
 		rules := d.Get("origin_pull_rules")
 		err := resourceTencentCloudCosBucketOriginPullUpdate(ctx, cosService, d)
 		if err != nil {
@@ -885,7 +940,7 @@ func resourceTencentCloudCosBucketUpdate(d *schema.ResourceData, meta interface{
 		_ = d.Set("origin_pull_rules", rules)
 	}
 
-	if d.HasChange("origin_domain_rules") {
+	if !ignored[COS_BUCKET_SUB_CONFIG_ORIGIN] && d.HasChange("origin_domain_rules") {
 		rules := d.Get("origin_domain_rules")
 		if err := resourceTencentCloudCosBucketOriginDomainUpdate(ctx, cosService, d); err != nil {
 			return err
@@ -893,24 +948,24 @@ func resourceTencentCloudCosBucketUpdate(d *schema.ResourceData, meta interface{
 		_ = d.Set("origin_domain_rules", rules)
 	}
 
-	if d.HasChange("lifecycle_rules") {
-		err := resourceTencentCloudCosBucketLifecycleUpdate(ctx, meta, d)
+	if !ignored[COS_BUCKET_SUB_CONFIG_LIFECYCLE] && d.HasChange("lifecycle_rules") {
+		err := resourceTencentCloudCosBucketLifecycleRulesUpdate(ctx, meta, d)
 		if err != nil {
 			return err
 		}
 
 	}
 
-	if d.HasChange("website") {
-		err := resourceTencentCloudCosBucketWebsiteUpdate(ctx, meta, d)
+	if !ignored[COS_BUCKET_SUB_CONFIG_WEBSITE] && d.HasChange("website") {
+		err := resourceTencentCloudCosBucketWebsiteConfigUpdate(ctx, meta, d)
 		if err != nil {
 			return err
 		}
 
 	}
 
-	if d.HasChange("encryption_algorithm") || d.HasChange("kms_id") {
-		err := resourceTencentCloudCosBucketEncryptionUpdate(ctx, meta, d)
+	if !ignored[COS_BUCKET_SUB_CONFIG_ENCRYPTION] && (d.HasChange("encryption_algorithm") || d.HasChange("kms_id")) {
+		err := resourceTencentCloudCosBucketEncryptionConfigUpdate(ctx, meta, d)
 		if err != nil {
 			return err
 		}
@@ -932,7 +987,7 @@ func resourceTencentCloudCosBucketUpdate(d *schema.ResourceData, meta interface{
 
 	}
 
-	if d.HasChange("replica_role") || d.HasChange("replica_rules") {
+	if !ignored[COS_BUCKET_SUB_CONFIG_REPLICATION] && (d.HasChange("replica_role") || d.HasChange("replica_rules")) {
 		err := resourceTencentCloudCosBucketReplicaUpdate(ctx, cosService, d)
 
 		if err != nil {
@@ -950,7 +1005,7 @@ func resourceTencentCloudCosBucketUpdate(d *schema.ResourceData, meta interface{
 
 	}
 
-	if d.HasChange("log_enable") || d.HasChange("log_target_bucket") || d.HasChange("log_prefix") {
+	if !ignored[COS_BUCKET_SUB_CONFIG_LOGGING] && (d.HasChange("log_enable") || d.HasChange("log_target_bucket") || d.HasChange("log_prefix")) {
 		err := resourceTencentCloudCosBucketLogStatusUpdate(ctx, meta, d)
 		if err != nil {
 			return err
@@ -1014,7 +1069,79 @@ func resourceTencentCloudCosBucketDelete(d *schema.ResourceData, meta interface{
 	return nil
 }
 
-func resourceTencentCloudCosBucketEncryptionUpdate(ctx context.Context, meta interface{}, d *schema.ResourceData) error {
+func resourceTencentCloudCosBucketCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	rawConfig := d.GetRawConfig()
+	if rawConfig.IsNull() || !rawConfig.IsKnown() {
+		return nil
+	}
+
+	for subConfig := range cosBucketIgnoredSubConfigs(d) {
+		for _, field := range COSBucketSubConfigFields[subConfig] {
+			if cosBucketConfigValueSet(rawConfig.GetAttr(field)) {
+				return fmt.Errorf("`%s` can not be set when `%s` is in `ignore_sub_configs`, it is managed by `tencentcloud_cos_bucket_%s`",
+					field, subConfig, subConfig)
+			}
+		}
+	}
+
+	return nil
+}
+
+// cosBucketIgnoredSubConfigs returns the sub configurations in `ignore_sub_configs`, d is *schema.ResourceData or *schema.ResourceDiff.
+func cosBucketIgnoredSubConfigs(d interface{ Get(string) interface{} }) map[string]bool {
+	ignored := make(map[string]bool)
+	if v, ok := d.Get("ignore_sub_configs").(*schema.Set); ok {
+		for _, item := range v.List() {
+			ignored[item.(string)] = true
+		}
+	}
+	return ignored
+}
+
+func cosBucketConfigValueSet(v cty.Value) bool {
+	if v.IsNull() {
+		return false
+	}
+	// set with an expression which is known after apply
+	if !v.IsKnown() {
+		return true
+	}
+	if v.Type().IsListType() || v.Type().IsSetType() || v.Type().IsMapType() {
+		return v.LengthInt() > 0
+	}
+	return true
+}
+
+// cosBucketSubConfigCustomizeDiff refuses to create a standalone sub configuration resource at plan time if the
+// sub configuration already exists, which is usually managed by `tencentcloud_cos_bucket` or another resource.
+func cosBucketSubConfigCustomizeDiff(subConfig string) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		if d.Id() != "" || !d.NewValueKnown("bucket") {
+			return nil
+		}
+
+		logId := tccommon.GetLogId(tccommon.ContextNil)
+		ctx = context.WithValue(ctx, tccommon.LogIdKey, logId)
+
+		bucket := d.Get("bucket").(string)
+		cdcId := d.Get("cdc_id").(string)
+		cosService := CosService{client: meta.(tccommon.ProviderMeta).GetAPIV3Conn()}
+		exists, err := cosService.DescribeBucketSubConfigExists(ctx, bucket, subConfig, cdcId)
+		if err != nil {
+			// the bucket may be created in the same apply
+			log.Printf("[WARN]%s check %s of cos bucket [%s] fail, skip checking conflict, reason[%s]\n", logId, subConfig, bucket, err.Error())
+			return nil
+		}
+		if exists {
+			return fmt.Errorf("%s of cos bucket [%s] already exists and may be managed by `tencentcloud_cos_bucket`. "+
+				"Please add `%s` to `ignore_sub_configs` of the bucket and import it by `terraform import tencentcloud_cos_bucket_%s.<name> %s`",
+				subConfig, bucket, subConfig, subConfig, bucket)
+		}
+		return nil
+	}
+}
+
+func resourceTencentCloudCosBucketEncryptionConfigUpdate(ctx context.Context, meta interface{}, d *schema.ResourceData) error {
 	logId := tccommon.GetLogId(ctx)
 
 	bucket := d.Get("bucket").(string)
@@ -1177,7 +1304,7 @@ func resourceTencentCloudCosBucketAclUpdate(ctx context.Context, meta interface{
 	return nil
 }
 
-func resourceTencentCloudCosBucketCorsUpdate(ctx context.Context, meta interface{}, d *schema.ResourceData) error {
+func resourceTencentCloudCosBucketCorsRulesUpdate(ctx context.Context, meta interface{}, d *schema.ResourceData) error {
 	logId := tccommon.GetLogId(ctx)
 
 	bucket := d.Get("bucket").(string)
@@ -1245,7 +1372,7 @@ func resourceTencentCloudCosBucketCorsUpdate(ctx context.Context, meta interface
 	return nil
 }
 
-func resourceTencentCloudCosBucketLifecycleUpdate(ctx context.Context, meta interface{}, d *schema.ResourceData) error {
+func resourceTencentCloudCosBucketLifecycleRulesUpdate(ctx context.Context, meta interface{}, d *schema.ResourceData) error {
 	logId := tccommon.GetLogId(ctx)
 
 	bucket := d.Get("bucket").(string)
@@ -1392,7 +1519,7 @@ func resourceTencentCloudCosBucketLifecycleUpdate(ctx context.Context, meta inte
 	return nil
 }
 
-func resourceTencentCloudCosBucketWebsiteUpdate(ctx context.Context, meta interface{}, d *schema.ResourceData) error {
+func resourceTencentCloudCosBucketWebsiteConfigUpdate(ctx context.Context, meta interface{}, d *schema.ResourceData) error {
 	logId := tccommon.GetLogId(ctx)
 
 	bucket := d.Get("bucket").(string)
@@ -0,0 +1,105 @@
+package cos
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	tccommon "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func ResourceTencentCloudCosBucketCors() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceTencentCloudCosBucketCorsCreate,
+		Read:   resourceTencentCloudCosBucketCorsRead,
+		Update: resourceTencentCloudCosBucketCorsUpdate,
+		Delete: resourceTencentCloudCosBucketCorsDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		CustomizeDiff: cosBucketSubConfigCustomizeDiff(COS_BUCKET_SUB_CONFIG_CORS),
+		Schema: map[string]*schema.Schema{
+			"bucket": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Bucket format should be [custom name]-[appid], for example `mycos-1258798060`.",
+			},
+			"cdc_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "CDC cluster ID.",
+			},
+			"cors_rules": {
+				Type:        schema.TypeList,
+				Required:    true,
+				Description: "Rules of Cross-Origin Resource Sharing, the same as `cors_rules` of `tencentcloud_cos_bucket`.",
+				Elem:        corsRules(),
+			},
+		},
+	}
+}
+
+func resourceTencentCloudCosBucketCorsCreate(d *schema.ResourceData, meta interface{}) error {
+	defer tccommon.LogElapsed("resource.tencentcloud_cos_bucket_cors.create")()
+
+	d.SetId(d.Get("bucket").(string))
+
+	return resourceTencentCloudCosBucketCorsUpdate(d, meta)
+}
+
+func resourceTencentCloudCosBucketCorsRead(d *schema.ResourceData, meta interface{}) error {
+	defer tccommon.LogElapsed("resource.tencentcloud_cos_bucket_cors.read")()
+	defer tccommon.InconsistentCheck(d, meta)()
+
+	logId := tccommon.GetLogId(tccommon.ContextNil)
+	ctx := context.WithValue(context.TODO(), tccommon.LogIdKey, logId)
+
+	cosService := CosService{client: meta.(tccommon.ProviderMeta).GetAPIV3Conn()}
+
+	bucket := d.Id()
+	corsRules, err := cosService.GetBucketCors(ctx, bucket, d.Get("cdc_id").(string))
+	if err != nil {
+		return err
+	}
+
+	if len(corsRules) == 0 {
+		d.SetId("")
+		log.Printf("[WARN]%s resource `CosBucketCors` [%s] not found, please check if it has been deleted.\n", logId, bucket)
+		return nil
+	}
+
+	_ = d.Set("bucket", bucket)
+	if err = d.Set("cors_rules", corsRules); err != nil {
+		return fmt.Errorf("setting cors_rules error: %v", err)
+	}
+
+	return nil
+}
+
+func resourceTencentCloudCosBucketCorsUpdate(d *schema.ResourceData, meta interface{}) error {
+	defer tccommon.LogElapsed("resource.tencentcloud_cos_bucket_cors.update")()
+
+	logId := tccommon.GetLogId(tccommon.ContextNil)
+	ctx := context.WithValue(context.TODO(), tccommon.LogIdKey, logId)
+
+	if err := resourceTencentCloudCosBucketCorsRulesUpdate(ctx, meta, d); err != nil {
+		return err
+	}
+
+	return resourceTencentCloudCosBucketCorsRead(d, meta)
+}
+
+func resourceTencentCloudCosBucketCorsDelete(d *schema.ResourceData, meta interface{}) error {
+	defer tccommon.LogElapsed("resource.tencentcloud_cos_bucket_cors.delete")()
+
+	logId := tccommon.GetLogId(tccommon.ContextNil)
+	ctx := context.WithValue(context.TODO(), tccommon.LogIdKey, logId)
+
+	cosService := CosService{client: meta.(tccommon.ProviderMeta).GetAPIV3Conn()}
+
+	return cosService.DeleteBucketCors(ctx, d.Id(), d.Get("cdc_id").(string))
+}
@@ -0,0 +1,81 @@
+package cos_test
+
+import (
+	"testing"
+
+	tcacctest "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/acctest"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// go test -i; go test -test.run TestAccTencentCloudCosBucketCorsResource_basic -v
+func TestAccTencentCloudCosBucketCorsResource_basic(t *testing.T) {
+	t.Parallel()
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			tcacctest.AccPreCheck(t)
+		},
+		Providers: tcacctest.AccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCosBucketCors,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("tencentcloud_cos_bucket_cors.cors", "id"),
+					resource.TestCheckResourceAttr("tencentcloud_cos_bucket_cors.cors", "cors_rules.#", "1"),
+					resource.TestCheckResourceAttr("tencentcloud_cos_bucket_cors.cors", "cors_rules.0.max_age_seconds", "300"),
+				),
+			},
+			{
+				ResourceName:      "tencentcloud_cos_bucket_cors.cors",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccCosBucketCorsUp,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("tencentcloud_cos_bucket_cors.cors", "id"),
+					resource.TestCheckResourceAttr("tencentcloud_cos_bucket_cors.cors", "cors_rules.0.max_age_seconds", "600"),
+				),
+			},
+		},
+	})
+}
+
+const testAccCosBucketCorsVar = `
+data "tencentcloud_user_info" "info" {}
+
+resource "tencentcloud_cos_bucket" "bucket" {
+	bucket             = "tf-bucket-cors-${data.tencentcloud_user_info.info.app_id}"
+	acl                = "private"
+	ignore_sub_configs = ["cors"]
+	force_clean        = true
+}
+`
+
+const testAccCosBucketCors = testAccCosBucketCorsVar + `
+
+resource "tencentcloud_cos_bucket_cors" "cors" {
+	bucket = tencentcloud_cos_bucket.bucket.bucket
+
+	cors_rules {
+		allowed_origins = ["http://*.abc.com"]
+		allowed_methods = ["PUT", "POST"]
+		allowed_headers = ["*"]
+		max_age_seconds = 300
+	}
+}
+`
+
+const testAccCosBucketCorsUp = testAccCosBucketCorsVar + `
+
+resource "tencentcloud_cos_bucket_cors" "cors" {
+	bucket = tencentcloud_cos_bucket.bucket.bucket
+
+	cors_rules {
+		allowed_origins = ["http://*.abc.com"]
+		allowed_methods = ["PUT", "POST"]
+		allowed_headers = ["*"]
+		max_age_seconds = 600
+	}
+}
+`
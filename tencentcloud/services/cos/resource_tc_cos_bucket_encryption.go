@@ -0,0 +1,108 @@
+package cos
+
+import (
+	"context"
+	"log"
+
+	tccommon "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func ResourceTencentCloudCosBucketEncryption() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceTencentCloudCosBucketEncryptionCreate,
+		Read:   resourceTencentCloudCosBucketEncryptionRead,
+		Update: resourceTencentCloudCosBucketEncryptionUpdate,
+		Delete: resourceTencentCloudCosBucketEncryptionDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		CustomizeDiff: cosBucketSubConfigCustomizeDiff(COS_BUCKET_SUB_CONFIG_ENCRYPTION),
+		Schema: map[string]*schema.Schema{
+			"bucket": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Bucket format should be [custom name]-[appid], for example `mycos-1258798060`.",
+			},
+			"cdc_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "CDC cluster ID.",
+			},
+			"encryption_algorithm": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The server-side encryption algorithm to use. Valid values are `AES256`, `KMS` and `cos/kms`, `cos/kms` is for cdc cos scenario.",
+			},
+			"kms_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The KMS Master Key ID. This value is valid only when `encryption_algorithm` is set to KMS or cos/kms. If not specified, the default kms id is used.",
+			},
+		},
+	}
+}
+
+func resourceTencentCloudCosBucketEncryptionCreate(d *schema.ResourceData, meta interface{}) error {
+	defer tccommon.LogElapsed("resource.tencentcloud_cos_bucket_encryption.create")()
+
+	d.SetId(d.Get("bucket").(string))
+
+	return resourceTencentCloudCosBucketEncryptionUpdate(d, meta)
+}
+
+func resourceTencentCloudCosBucketEncryptionRead(d *schema.ResourceData, meta interface{}) error {
+	defer tccommon.LogElapsed("resource.tencentcloud_cos_bucket_encryption.read")()
+	defer tccommon.InconsistentCheck(d, meta)()
+
+	logId := tccommon.GetLogId(tccommon.ContextNil)
+	ctx := context.WithValue(context.TODO(), tccommon.LogIdKey, logId)
+
+	cosService := CosService{client: meta.(tccommon.ProviderMeta).GetAPIV3Conn()}
+
+	bucket := d.Id()
+	encryption, kmsId, err := cosService.GetBucketEncryption(ctx, bucket, d.Get("cdc_id").(string))
+	if err != nil {
+		return err
+	}
+
+	if encryption == "" {
+		d.SetId("")
+		log.Printf("[WARN]%s resource `CosBucketEncryption` [%s] not found, please check if it has been deleted.\n", logId, bucket)
+		return nil
+	}
+
+	_ = d.Set("bucket", bucket)
+	_ = d.Set("encryption_algorithm", encryption)
+	_ = d.Set("kms_id", kmsId)
+
+	return nil
+}
+
+func resourceTencentCloudCosBucketEncryptionUpdate(d *schema.ResourceData, meta interface{}) error {
+	defer tccommon.LogElapsed("resource.tencentcloud_cos_bucket_encryption.update")()
+
+	logId := tccommon.GetLogId(tccommon.ContextNil)
+	ctx := context.WithValue(context.TODO(), tccommon.LogIdKey, logId)
+
+	if err := resourceTencentCloudCosBucketEncryptionConfigUpdate(ctx, meta, d); err != nil {
+		return err
+	}
+
+	return resourceTencentCloudCosBucketEncryptionRead(d, meta)
+}
+
+func resourceTencentCloudCosBucketEncryptionDelete(d *schema.ResourceData, meta interface{}) error {
+	defer tccommon.LogElapsed("resource.tencentcloud_cos_bucket_encryption.delete")()
+
+	logId := tccommon.GetLogId(tccommon.ContextNil)
+	ctx := context.WithValue(context.TODO(), tccommon.LogIdKey, logId)
+
+	cosService := CosService{client: meta.(tccommon.ProviderMeta).GetAPIV3Conn()}
+
+	return cosService.DeleteBucketEncryption(ctx, d.Id(), d.Get("cdc_id").(string))
+}
@@ -0,0 +1,68 @@
+package cos_test
+
+import (
+	"testing"
+
+	tcacctest "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/acctest"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// go test -i; go test -test.run TestAccTencentCloudCosBucketEncryptionResource_basic -v
+func TestAccTencentCloudCosBucketEncryptionResource_basic(t *testing.T) {
+	t.Parallel()
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			tcacctest.AccPreCheck(t)
+		},
+		Providers: tcacctest.AccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCosBucketEncryption,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("tencentcloud_cos_bucket_encryption.encryption", "id"),
+					resource.TestCheckResourceAttr("tencentcloud_cos_bucket_encryption.encryption", "encryption_algorithm", "AES256"),
+				),
+			},
+			{
+				ResourceName:      "tencentcloud_cos_bucket_encryption.encryption",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccCosBucketEncryptionUp,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("tencentcloud_cos_bucket_encryption.encryption", "id"),
+					resource.TestCheckResourceAttr("tencentcloud_cos_bucket_encryption.encryption", "encryption_algorithm", "AES256"),
+				),
+			},
+		},
+	})
+}
+
+const testAccCosBucketEncryptionVar = `
+data "tencentcloud_user_info" "info" {}
+
+resource "tencentcloud_cos_bucket" "bucket" {
+	bucket             = "tf-bucket-encryption-${data.tencentcloud_user_info.info.app_id}"
+	acl                = "private"
+	ignore_sub_configs = ["encryption"]
+	force_clean        = true
+}
+`
+
+const testAccCosBucketEncryption = testAccCosBucketEncryptionVar + `
+
+resource "tencentcloud_cos_bucket_encryption" "encryption" {
+	bucket               = tencentcloud_cos_bucket.bucket.bucket
+	encryption_algorithm = "AES256"
+}
+`
+
+const testAccCosBucketEncryptionUp = testAccCosBucketEncryptionVar + `
+
+resource "tencentcloud_cos_bucket_encryption" "encryption" {
+	bucket               = tencentcloud_cos_bucket.bucket.bucket
+	encryption_algorithm = "AES256"
+}
+`
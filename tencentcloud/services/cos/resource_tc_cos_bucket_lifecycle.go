@@ -0,0 +1,105 @@
+package cos
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	tccommon "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func ResourceTencentCloudCosBucketLifecycle() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceTencentCloudCosBucketLifecycleCreate,
+		Read:   resourceTencentCloudCosBucketLifecycleRead,
+		Update: resourceTencentCloudCosBucketLifecycleUpdate,
+		Delete: resourceTencentCloudCosBucketLifecycleDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		CustomizeDiff: cosBucketSubConfigCustomizeDiff(COS_BUCKET_SUB_CONFIG_LIFECYCLE),
+		Schema: map[string]*schema.Schema{
+			"bucket": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Bucket format should be [custom name]-[appid], for example `mycos-1258798060`.",
+			},
+			"cdc_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "CDC cluster ID.",
+			},
+			"lifecycle_rules": {
+				Type:        schema.TypeList,
+				Required:    true,
+				Description: "A configuration of object lifecycle management, the same as `lifecycle_rules` of `tencentcloud_cos_bucket`.",
+				Elem:        lifecycleRules(),
+			},
+		},
+	}
+}
+
+func resourceTencentCloudCosBucketLifecycleCreate(d *schema.ResourceData, meta interface{}) error {
+	defer tccommon.LogElapsed("resource.tencentcloud_cos_bucket_lifecycle.create")()
+
+	d.SetId(d.Get("bucket").(string))
+
+	return resourceTencentCloudCosBucketLifecycleUpdate(d, meta)
+}
+
+func resourceTencentCloudCosBucketLifecycleRead(d *schema.ResourceData, meta interface{}) error {
+	defer tccommon.LogElapsed("resource.tencentcloud_cos_bucket_lifecycle.read")()
+	defer tccommon.InconsistentCheck(d, meta)()
+
+	logId := tccommon.GetLogId(tccommon.ContextNil)
+	ctx := context.WithValue(context.TODO(), tccommon.LogIdKey, logId)
+
+	cosService := CosService{client: meta.(tccommon.ProviderMeta).GetAPIV3Conn()}
+
+	bucket := d.Id()
+	lifecycleRules, err := cosService.GetBucketLifecycle(ctx, bucket, d.Get("cdc_id").(string))
+	if err != nil {
+		return err
+	}
+
+	if len(lifecycleRules) == 0 {
+		d.SetId("")
+		log.Printf("[WARN]%s resource `CosBucketLifecycle` [%s] not found, please check if it has been deleted.\n", logId, bucket)
+		return nil
+	}
+
+	_ = d.Set("bucket", bucket)
+	if err = d.Set("lifecycle_rules", lifecycleRules); err != nil {
+		return fmt.Errorf("setting lifecycle_rules error: %v", err)
+	}
+
+	return nil
+}
+
+func resourceTencentCloudCosBucketLifecycleUpdate(d *schema.ResourceData, meta interface{}) error {
+	defer tccommon.LogElapsed("resource.tencentcloud_cos_bucket_lifecycle.update")()
+
+	logId := tccommon.GetLogId(tccommon.ContextNil)
+	ctx := context.WithValue(context.TODO(), tccommon.LogIdKey, logId)
+
+	if err := resourceTencentCloudCosBucketLifecycleRulesUpdate(ctx, meta, d); err != nil {
+		return err
+	}
+
+	return resourceTencentCloudCosBucketLifecycleRead(d, meta)
+}
+
+func resourceTencentCloudCosBucketLifecycleDelete(d *schema.ResourceData, meta interface{}) error {
+	defer tccommon.LogElapsed("resource.tencentcloud_cos_bucket_lifecycle.delete")()
+
+	logId := tccommon.GetLogId(tccommon.ContextNil)
+	ctx := context.WithValue(context.TODO(), tccommon.LogIdKey, logId)
+
+	cosService := CosService{client: meta.(tccommon.ProviderMeta).GetAPIV3Conn()}
+
+	return cosService.DeleteBucketLifecycle(ctx, d.Id(), d.Get("cdc_id").(string))
+}
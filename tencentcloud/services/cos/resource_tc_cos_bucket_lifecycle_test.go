@@ -0,0 +1,83 @@
+package cos_test
+
+import (
+	"testing"
+
+	tcacctest "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/acctest"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// go test -i; go test -test.run TestAccTencentCloudCosBucketLifecycleResource_basic -v
+func TestAccTencentCloudCosBucketLifecycleResource_basic(t *testing.T) {
+	t.Parallel()
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			tcacctest.AccPreCheck(t)
+		},
+		Providers: tcacctest.AccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCosBucketLifecycle,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("tencentcloud_cos_bucket_lifecycle.lifecycle", "id"),
+					resource.TestCheckResourceAttr("tencentcloud_cos_bucket_lifecycle.lifecycle", "lifecycle_rules.#", "1"),
+					resource.TestCheckResourceAttr("tencentcloud_cos_bucket_lifecycle.lifecycle", "lifecycle_rules.0.filter_prefix", "path1/"),
+				),
+			},
+			{
+				ResourceName:      "tencentcloud_cos_bucket_lifecycle.lifecycle",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccCosBucketLifecycleUp,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("tencentcloud_cos_bucket_lifecycle.lifecycle", "id"),
+					resource.TestCheckResourceAttr("tencentcloud_cos_bucket_lifecycle.lifecycle", "lifecycle_rules.0.filter_prefix", "path2/"),
+				),
+			},
+		},
+	})
+}
+
+const testAccCosBucketLifecycleVar = `
+data "tencentcloud_user_info" "info" {}
+
+resource "tencentcloud_cos_bucket" "bucket" {
+	bucket             = "tf-bucket-lifecycle-${data.tencentcloud_user_info.info.app_id}"
+	acl                = "private"
+	ignore_sub_configs = ["lifecycle"]
+	force_clean        = true
+}
+`
+
+const testAccCosBucketLifecycle = testAccCosBucketLifecycleVar + `
+
+resource "tencentcloud_cos_bucket_lifecycle" "lifecycle" {
+	bucket = tencentcloud_cos_bucket.bucket.bucket
+
+	lifecycle_rules {
+		filter_prefix = "path1/"
+
+		expiration {
+			days = 90
+		}
+	}
+}
+`
+
+const testAccCosBucketLifecycleUp = testAccCosBucketLifecycleVar + `
+
+resource "tencentcloud_cos_bucket_lifecycle" "lifecycle" {
+	bucket = tencentcloud_cos_bucket.bucket.bucket
+
+	lifecycle_rules {
+		filter_prefix = "path2/"
+
+		expiration {
+			days = 90
+		}
+	}
+}
+`
@@ -0,0 +1,112 @@
+package cos
+
+import (
+	"context"
+	"log"
+
+	tccommon "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func ResourceTencentCloudCosBucketLogging() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceTencentCloudCosBucketLoggingCreate,
+		Read:   resourceTencentCloudCosBucketLoggingRead,
+		Update: resourceTencentCloudCosBucketLoggingUpdate,
+		Delete: resourceTencentCloudCosBucketLoggingDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		CustomizeDiff: cosBucketSubConfigCustomizeDiff(COS_BUCKET_SUB_CONFIG_LOGGING),
+		Schema: map[string]*schema.Schema{
+			"bucket": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Bucket format should be [custom name]-[appid], for example `mycos-1258798060`.",
+			},
+			"cdc_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "CDC cluster ID.",
+			},
+			"log_target_bucket": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The target bucket name which saves the access log of this bucket per 5 minutes. The log access file format is `log_target_bucket`/`log_prefix`{YYYY}/{MM}/{DD}/{time}_{random}_{index}.gz. User must have full access on this bucket.",
+			},
+			"log_prefix": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The prefix log name which saves the access log of this bucket per 5 minutes. Eg. `MyLogPrefix/`.",
+			},
+		},
+	}
+}
+
+func resourceTencentCloudCosBucketLoggingCreate(d *schema.ResourceData, meta interface{}) error {
+	defer tccommon.LogElapsed("resource.tencentcloud_cos_bucket_logging.create")()
+
+	d.SetId(d.Get("bucket").(string))
+
+	return resourceTencentCloudCosBucketLoggingUpdate(d, meta)
+}
+
+func resourceTencentCloudCosBucketLoggingRead(d *schema.ResourceData, meta interface{}) error {
+	defer tccommon.LogElapsed("resource.tencentcloud_cos_bucket_logging.read")()
+	defer tccommon.InconsistentCheck(d, meta)()
+
+	logId := tccommon.GetLogId(tccommon.ContextNil)
+	ctx := context.WithValue(context.TODO(), tccommon.LogIdKey, logId)
+
+	cosService := CosService{client: meta.(tccommon.ProviderMeta).GetAPIV3Conn()}
+
+	bucket := d.Id()
+	logEnable, logTargetBucket, logPrefix, err := cosService.GetBucketLogStatus(ctx, bucket, d.Get("cdc_id").(string))
+	if err != nil {
+		return err
+	}
+
+	if !logEnable {
+		d.SetId("")
+		log.Printf("[WARN]%s resource `CosBucketLogging` [%s] not found, please check if it has been deleted.\n", logId, bucket)
+		return nil
+	}
+
+	_ = d.Set("bucket", bucket)
+	_ = d.Set("log_target_bucket", logTargetBucket)
+	_ = d.Set("log_prefix", logPrefix)
+
+	return nil
+}
+
+func resourceTencentCloudCosBucketLoggingUpdate(d *schema.ResourceData, meta interface{}) error {
+	defer tccommon.LogElapsed("resource.tencentcloud_cos_bucket_logging.update")()
+
+	logId := tccommon.GetLogId(tccommon.ContextNil)
+	ctx := context.WithValue(context.TODO(), tccommon.LogIdKey, logId)
+
+	cosService := CosService{client: meta.(tccommon.ProviderMeta).GetAPIV3Conn()}
+
+	//grant are solved by the tencentcloud_cam_role_attachment resource
+	err := cosService.PutBucketLogging(ctx, d.Id(), d.Get("log_target_bucket").(string), d.Get("log_prefix").(string), d.Get("cdc_id").(string))
+	if err != nil {
+		return err
+	}
+
+	return resourceTencentCloudCosBucketLoggingRead(d, meta)
+}
+
+func resourceTencentCloudCosBucketLoggingDelete(d *schema.ResourceData, meta interface{}) error {
+	defer tccommon.LogElapsed("resource.tencentcloud_cos_bucket_logging.delete")()
+
+	logId := tccommon.GetLogId(tccommon.ContextNil)
+	ctx := context.WithValue(context.TODO(), tccommon.LogIdKey, logId)
+
+	cosService := CosService{client: meta.(tccommon.ProviderMeta).GetAPIV3Conn()}
+
+	// put empty logging status to disable the log
+	return cosService.PutBucketLogging(ctx, d.Id(), "", "", d.Get("cdc_id").(string))
+}
@@ -0,0 +1,70 @@
+package cos_test
+
+import (
+	"testing"
+
+	tcacctest "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/acctest"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// go test -i; go test -test.run TestAccTencentCloudCosBucketLoggingResource_basic -v
+func TestAccTencentCloudCosBucketLoggingResource_basic(t *testing.T) {
+	t.Parallel()
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			tcacctest.AccPreCheck(t)
+		},
+		Providers: tcacctest.AccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCosBucketLogging,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("tencentcloud_cos_bucket_logging.logging", "id"),
+					resource.TestCheckResourceAttr("tencentcloud_cos_bucket_logging.logging", "log_prefix", "log/"),
+				),
+			},
+			{
+				ResourceName:      "tencentcloud_cos_bucket_logging.logging",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccCosBucketLoggingUp,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("tencentcloud_cos_bucket_logging.logging", "id"),
+					resource.TestCheckResourceAttr("tencentcloud_cos_bucket_logging.logging", "log_prefix", "access/"),
+				),
+			},
+		},
+	})
+}
+
+const testAccCosBucketLoggingVar = `
+data "tencentcloud_user_info" "info" {}
+
+resource "tencentcloud_cos_bucket" "bucket" {
+	bucket             = "tf-bucket-logging-${data.tencentcloud_user_info.info.app_id}"
+	acl                = "private"
+	ignore_sub_configs = ["logging"]
+	force_clean        = true
+}
+`
+
+const testAccCosBucketLogging = testAccCosBucketLoggingVar + `
+
+resource "tencentcloud_cos_bucket_logging" "logging" {
+	bucket            = tencentcloud_cos_bucket.bucket.bucket
+	log_target_bucket = tencentcloud_cos_bucket.bucket.bucket
+	log_prefix        = "log/"
+}
+`
+
+const testAccCosBucketLoggingUp = testAccCosBucketLoggingVar + `
+
+resource "tencentcloud_cos_bucket_logging" "logging" {
+	bucket            = tencentcloud_cos_bucket.bucket.bucket
+	log_target_bucket = tencentcloud_cos_bucket.bucket.bucket
+	log_prefix        = "access/"
+}
+`
@@ -8,6 +8,7 @@ import (
 	"log"
 	"os"
 	"strings"
+	"time"
 
 	tccommon "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/common"
 
@@ -100,6 +101,11 @@ func ResourceTencentCloudCosBucketObject() *schema.Resource {
 				Computed:    true,
 				Description: "The ETag generated for the object (an MD5 sum of the object content).",
 			},
+			"retain_until_date": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The date until which the object is protected by the object lock of the bucket, in the format of RFC3339. Empty if the object is not locked. The object can not be overwritten or deleted before the date.",
+			},
 		},
 	}
 }
@@ -231,6 +237,15 @@ func resourceTencentCloudCosBucketObjectRead(d *schema.ResourceData, meta interf
 
 	_ = d.Set("acl", aclResponse.Header.Get("x-cos-acl"))
 
+	retainUntil, err := cosService.DescribeCosObjectRetainUntilDate(ctx, bucket, key, "")
+	if err != nil {
+		log.Printf("[WARN]%s get retention of object (%s) in bucket (%s) error, skip setting: %s", logId, key, bucket, err.Error())
+	} else if retainUntil.IsZero() {
+		_ = d.Set("retain_until_date", "")
+	} else {
+		_ = d.Set("retain_until_date", retainUntil.UTC().Format(time.RFC3339))
+	}
+
 	var tags map[string]string
 	tags, err = cosService.GetObjectTags(ctx, bucket, key)
 	if err != nil {
@@ -262,18 +277,22 @@ func resourceTencentCloudCosBucketObjectUpdate(d *schema.ResourceData, meta inte
 		"storage_class",
 		"etag",
 	}
-	for _, key := range fields {
-		if d.HasChange(key) {
-			return resourceTencentCloudCosBucketObjectCreate(d, meta)
-		}
-	}
-
 	bucket := d.Get("bucket").(string)
 	key := d.Get("key").(string)
 	cosService := CosService{
 		client: meta.(tccommon.ProviderMeta).GetAPIV3Conn(),
 	}
 
+	for _, field := range fields {
+		if d.HasChange(field) {
+			// objects under retention can not be overwritten
+			if err := cosService.CheckCosObjectUnlocked(ctx, bucket, key, ""); err != nil {
+				return err
+			}
+			return resourceTencentCloudCosBucketObjectCreate(d, meta)
+		}
+	}
+
 	if d.HasChange("acl") {
 		acl := d.Get("acl").(string)
 		err := cosService.PutObjectAcl(ctx, bucket, key, acl)
@@ -308,6 +327,10 @@ func resourceTencentCloudCosBucketObjectDelete(d *schema.ResourceData, meta inte
 	cosService := CosService{
 		client: meta.(tccommon.ProviderMeta).GetAPIV3Conn(),
 	}
+	if err := cosService.CheckCosObjectUnlocked(ctx, bucket, key, ""); err != nil {
+		return err
+	}
+
 	err := cosService.DeleteObject(ctx, bucket, key)
 	if err != nil {
 		return err
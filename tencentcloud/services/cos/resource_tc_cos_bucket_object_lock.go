@@ -0,0 +1,156 @@
+package cos
+
+import (
+	"context"
+	"log"
+
+	tccommon "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	cos "github.com/tencentyun/cos-go-sdk-v5"
+)
+
+const (
+	COS_OBJECT_LOCK_ENABLED         = "Enabled"
+	COS_OBJECT_LOCK_MODE_COMPLIANCE = "COMPLIANCE"
+)
+
+func ResourceTencentCloudCosBucketObjectLock() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceTencentCloudCosBucketObjectLockCreate,
+		Read:   resourceTencentCloudCosBucketObjectLockRead,
+		Update: resourceTencentCloudCosBucketObjectLockUpdate,
+		Delete: resourceTencentCloudCosBucketObjectLockDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"bucket": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Bucket format should be [custom name]-[appid], for example `mycos-1258798060`.",
+			},
+			"cdc_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "CDC cluster ID.",
+			},
+			"retention_mode": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      COS_OBJECT_LOCK_MODE_COMPLIANCE,
+				ValidateFunc: validation.StringInSlice([]string{COS_OBJECT_LOCK_MODE_COMPLIANCE}, false),
+				Description:  "Default retention mode of the objects. COS only supports `COMPLIANCE`, objects can not be overwritten or deleted by any user before the retention expires. Default is `COMPLIANCE`.",
+			},
+			"retention_days": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Default retention days of the objects uploaded to the bucket.",
+			},
+			"object_lock_enabled": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Object lock status of the bucket.",
+			},
+		},
+	}
+}
+
+func resourceTencentCloudCosBucketObjectLockCreate(d *schema.ResourceData, meta interface{}) error {
+	defer tccommon.LogElapsed("resource.tencentcloud_cos_bucket_object_lock.create")()
+	defer tccommon.InconsistentCheck(d, meta)()
+
+	var bucket string
+	if v, ok := d.GetOk("bucket"); ok {
+		bucket = v.(string)
+	}
+
+	d.SetId(bucket)
+
+	return resourceTencentCloudCosBucketObjectLockUpdate(d, meta)
+}
+
+func resourceTencentCloudCosBucketObjectLockRead(d *schema.ResourceData, meta interface{}) error {
+	defer tccommon.LogElapsed("resource.tencentcloud_cos_bucket_object_lock.read")()
+	defer tccommon.InconsistentCheck(d, meta)()
+
+	logId := tccommon.GetLogId(tccommon.ContextNil)
+	ctx := context.WithValue(context.TODO(), tccommon.LogIdKey, logId)
+
+	service := CosService{client: meta.(tccommon.ProviderMeta).GetAPIV3Conn()}
+
+	bucket := d.Id()
+	cdcId := d.Get("cdc_id").(string)
+
+	objectLock, err := service.DescribeCosBucketObjectLockById(ctx, bucket, cdcId)
+	if err != nil {
+		return err
+	}
+
+	if objectLock == nil || objectLock.ObjectLockEnabled != COS_OBJECT_LOCK_ENABLED {
+		d.SetId("")
+		log.Printf("[WARN]%s resource `CosBucketObjectLock` [%s] not found, please check if it has been deleted.\n", logId, bucket)
+		return nil
+	}
+
+	_ = d.Set("bucket", bucket)
+	_ = d.Set("retention_mode", COS_OBJECT_LOCK_MODE_COMPLIANCE)
+	_ = d.Set("object_lock_enabled", objectLock.ObjectLockEnabled)
+
+	if objectLock.Rule != nil {
+		_ = d.Set("retention_days", objectLock.Rule.Days)
+	}
+
+	return nil
+}
+
+func resourceTencentCloudCosBucketObjectLockUpdate(d *schema.ResourceData, meta interface{}) error {
+	defer tccommon.LogElapsed("resource.tencentcloud_cos_bucket_object_lock.update")()
+	defer tccommon.InconsistentCheck(d, meta)()
+
+	logId := tccommon.GetLogId(tccommon.ContextNil)
+	ctx := context.WithValue(context.TODO(), tccommon.LogIdKey, logId)
+
+	service := CosService{client: meta.(tccommon.ProviderMeta).GetAPIV3Conn()}
+
+	bucket := d.Id()
+	cdcId := d.Get("cdc_id").(string)
+
+	request := cos.BucketPutObjectLockOptions{
+		ObjectLockEnabled: COS_OBJECT_LOCK_ENABLED,
+		Rule: &cos.ObjectLockRule{
+			Days: d.Get("retention_days").(int),
+		},
+	}
+
+	err := resource.Retry(tccommon.WriteRetryTimeout, func() *resource.RetryError {
+		if e := service.PutCosBucketObjectLock(ctx, bucket, &request, cdcId); e != nil {
+			return tccommon.RetryError(e)
+		}
+		return nil
+	})
+	if err != nil {
+		log.Printf("[CRITAL]%s cos object lock failed, reason:%+v", logId, err)
+		return err
+	}
+
+	return resourceTencentCloudCosBucketObjectLockRead(d, meta)
+}
+
+func resourceTencentCloudCosBucketObjectLockDelete(d *schema.ResourceData, meta interface{}) error {
+	defer tccommon.LogElapsed("resource.tencentcloud_cos_bucket_object_lock.delete")()
+	defer tccommon.InconsistentCheck(d, meta)()
+
+	logId := tccommon.GetLogId(tccommon.ContextNil)
+
+	// object lock can not be disabled once enabled, only remove it from the state
+	log.Printf("[WARN]%s object lock of cos bucket [%s] can not be disabled, it is only removed from the state, "+
+		"objects under retention are still protected.\n", logId, d.Id())
+
+	return nil
+}
@@ -0,0 +1,68 @@
+package cos_test
+
+import (
+	"testing"
+
+	tcacctest "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/acctest"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// go test -i; go test -test.run TestAccTencentCloudCosBucketObjectLockResource_basic -v
+func TestAccTencentCloudCosBucketObjectLockResource_basic(t *testing.T) {
+	t.Parallel()
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			tcacctest.AccPreCheck(t)
+		},
+		Providers: tcacctest.AccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCosBucketObjectLock,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("tencentcloud_cos_bucket_object_lock.object_lock", "id"),
+					resource.TestCheckResourceAttr("tencentcloud_cos_bucket_object_lock.object_lock", "retention_mode", "COMPLIANCE"),
+					resource.TestCheckResourceAttr("tencentcloud_cos_bucket_object_lock.object_lock", "retention_days", "1"),
+					resource.TestCheckResourceAttr("tencentcloud_cos_bucket_object_lock.object_lock", "object_lock_enabled", "Enabled"),
+				),
+			},
+			{
+				ResourceName:      "tencentcloud_cos_bucket_object_lock.object_lock",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccCosBucketObjectLockUp,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("tencentcloud_cos_bucket_object_lock.object_lock", "id"),
+					resource.TestCheckResourceAttr("tencentcloud_cos_bucket_object_lock.object_lock", "retention_days", "2"),
+				),
+			},
+		},
+	})
+}
+
+const testAccCosBucketObjectLockVar = `
+variable "bucket" {
+	default = "` + tcacctest.DefaultCiBucket + `"
+}
+
+`
+
+const testAccCosBucketObjectLock = testAccCosBucketObjectLockVar + `
+
+resource "tencentcloud_cos_bucket_object_lock" "object_lock" {
+	bucket         = var.bucket
+	retention_days = 1
+}
+
+`
+
+const testAccCosBucketObjectLockUp = testAccCosBucketObjectLockVar + `
+
+resource "tencentcloud_cos_bucket_object_lock" "object_lock" {
+	bucket         = var.bucket
+	retention_days = 2
+}
+
+`
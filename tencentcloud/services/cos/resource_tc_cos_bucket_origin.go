@@ -0,0 +1,145 @@
+package cos
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	tccommon "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func ResourceTencentCloudCosBucketOrigin() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceTencentCloudCosBucketOriginCreate,
+		Read:   resourceTencentCloudCosBucketOriginRead,
+		Update: resourceTencentCloudCosBucketOriginUpdate,
+		Delete: resourceTencentCloudCosBucketOriginDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		CustomizeDiff: cosBucketSubConfigCustomizeDiff(COS_BUCKET_SUB_CONFIG_ORIGIN),
+		Schema: map[string]*schema.Schema{
+			"bucket": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Bucket format should be [custom name]-[appid], for example `mycos-1258798060`.",
+			},
+			"cdc_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "CDC cluster ID.",
+			},
+			"origin_pull_rules": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				AtLeastOneOf: []string{"origin_pull_rules", "origin_domain_rules"},
+				Description:  "Bucket Origin-Pull settings, the same as `origin_pull_rules` of `tencentcloud_cos_bucket`.",
+				Elem:         originPullRules(),
+			},
+			"origin_domain_rules": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				AtLeastOneOf: []string{"origin_pull_rules", "origin_domain_rules"},
+				Description:  "Bucket Origin Domain settings, the same as `origin_domain_rules` of `tencentcloud_cos_bucket`.",
+				Elem:         originDomainRules(),
+			},
+		},
+	}
+}
+
+func resourceTencentCloudCosBucketOriginCreate(d *schema.ResourceData, meta interface{}) error {
+	defer tccommon.LogElapsed("resource.tencentcloud_cos_bucket_origin.create")()
+
+	d.SetId(d.Get("bucket").(string))
+
+	return resourceTencentCloudCosBucketOriginUpdate(d, meta)
+}
+
+func resourceTencentCloudCosBucketOriginRead(d *schema.ResourceData, meta interface{}) error {
+	defer tccommon.LogElapsed("resource.tencentcloud_cos_bucket_origin.read")()
+	defer tccommon.InconsistentCheck(d, meta)()
+
+	logId := tccommon.GetLogId(tccommon.ContextNil)
+	ctx := context.WithValue(context.TODO(), tccommon.LogIdKey, logId)
+
+	cosService := CosService{client: meta.(tccommon.ProviderMeta).GetAPIV3Conn()}
+
+	bucket := d.Id()
+	cdcId := d.Get("cdc_id").(string)
+	originPullRules, err := cosService.GetBucketPullOrigin(ctx, bucket, cdcId)
+	if err != nil {
+		return err
+	}
+
+	originDomainRules, err := cosService.GetBucketOriginDomain(ctx, bucket, cdcId)
+	if err != nil {
+		return err
+	}
+
+	if len(originPullRules) == 0 && len(originDomainRules) == 0 {
+		d.SetId("")
+		log.Printf("[WARN]%s resource `CosBucketOrigin` [%s] not found, please check if it has been deleted.\n", logId, bucket)
+		return nil
+	}
+
+	_ = d.Set("bucket", bucket)
+	if err = d.Set("origin_pull_rules", originPullRules); err != nil {
+		return fmt.Errorf("setting origin_pull_rules error: %v", err)
+	}
+	if err = d.Set("origin_domain_rules", originDomainRules); err != nil {
+		return fmt.Errorf("setting origin_domain_rules error: %v", err)
+	}
+
+	return nil
+}
+
+func resourceTencentCloudCosBucketOriginUpdate(d *schema.ResourceData, meta interface{}) error {
+	defer tccommon.LogElapsed("resource.tencentcloud_cos_bucket_origin.update")()
+
+	logId := tccommon.GetLogId(tccommon.ContextNil)
+	ctx := context.WithValue(context.TODO(), tccommon.LogIdKey, logId)
+
+	cosService := CosService{client: meta.(tccommon.ProviderMeta).GetAPIV3Conn()}
+
+	if d.HasChange("origin_pull_rules") {
+		if err := resourceTencentCloudCosBucketOriginPullUpdate(ctx, cosService, d); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("origin_domain_rules") {
+		if err := resourceTencentCloudCosBucketOriginDomainUpdate(ctx, cosService, d); err != nil {
+			return err
+		}
+	}
+
+	return resourceTencentCloudCosBucketOriginRead(d, meta)
+}
+
+func resourceTencentCloudCosBucketOriginDelete(d *schema.ResourceData, meta interface{}) error {
+	defer tccommon.LogElapsed("resource.tencentcloud_cos_bucket_origin.delete")()
+
+	logId := tccommon.GetLogId(tccommon.ContextNil)
+	ctx := context.WithValue(context.TODO(), tccommon.LogIdKey, logId)
+
+	cosService := CosService{client: meta.(tccommon.ProviderMeta).GetAPIV3Conn()}
+
+	bucket := d.Id()
+	cdcId := d.Get("cdc_id").(string)
+	if len(d.Get("origin_pull_rules").([]interface{})) > 0 {
+		if err := cosService.DeleteBucketPullOrigin(ctx, bucket, cdcId); err != nil {
+			return err
+		}
+	}
+	if len(d.Get("origin_domain_rules").([]interface{})) > 0 {
+		if err := cosService.DeleteBucketOriginDomain(ctx, bucket, cdcId); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package cos_test
+
+import (
+	"testing"
+
+	tcacctest "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/acctest"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// go test -i; go test -test.run TestAccTencentCloudCosBucketOriginResource_basic -v
+func TestAccTencentCloudCosBucketOriginResource_basic(t *testing.T) {
+	t.Parallel()
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			tcacctest.AccPreCheck(t)
+		},
+		Providers: tcacctest.AccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCosBucketOrigin,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("tencentcloud_cos_bucket_origin.origin", "id"),
+					resource.TestCheckResourceAttr("tencentcloud_cos_bucket_origin.origin", "origin_domain_rules.#", "1"),
+					resource.TestCheckResourceAttr("tencentcloud_cos_bucket_origin.origin", "origin_domain_rules.0.status", "ENABLED"),
+				),
+			},
+			{
+				ResourceName:      "tencentcloud_cos_bucket_origin.origin",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccCosBucketOriginUp,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("tencentcloud_cos_bucket_origin.origin", "id"),
+					resource.TestCheckResourceAttr("tencentcloud_cos_bucket_origin.origin", "origin_domain_rules.0.status", "DISABLED"),
+				),
+			},
+		},
+	})
+}
+
+const testAccCosBucketOriginVar = `
+data "tencentcloud_user_info" "info" {}
+
+resource "tencentcloud_cos_bucket" "bucket" {
+	bucket             = "tf-bucket-origin-${data.tencentcloud_user_info.info.app_id}"
+	acl                = "private"
+	ignore_sub_configs = ["origin"]
+	force_clean        = true
+}
+`
+
+const testAccCosBucketOrigin = testAccCosBucketOriginVar + `
+
+resource "tencentcloud_cos_bucket_origin" "origin" {
+	bucket = tencentcloud_cos_bucket.bucket.bucket
+
+	origin_domain_rules {
+		domain = "tf-origin.example.com"
+		type   = "REST"
+		status = "ENABLED"
+	}
+}
+`
+
+const testAccCosBucketOriginUp = testAccCosBucketOriginVar + `
+
+resource "tencentcloud_cos_bucket_origin" "origin" {
+	bucket = tencentcloud_cos_bucket.bucket.bucket
+
+	origin_domain_rules {
+		domain = "tf-origin.example.com"
+		type   = "REST"
+		status = "DISABLED"
+	}
+}
+`
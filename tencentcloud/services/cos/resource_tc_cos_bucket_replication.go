@@ -0,0 +1,109 @@
+package cos
+
+import (
+	"context"
+	"log"
+
+	tccommon "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func ResourceTencentCloudCosBucketReplication() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceTencentCloudCosBucketReplicationCreate,
+		Read:   resourceTencentCloudCosBucketReplicationRead,
+		Update: resourceTencentCloudCosBucketReplicationUpdate,
+		Delete: resourceTencentCloudCosBucketReplicationDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		CustomizeDiff: cosBucketSubConfigCustomizeDiff(COS_BUCKET_SUB_CONFIG_REPLICATION),
+		Schema: map[string]*schema.Schema{
+			"bucket": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Bucket format should be [custom name]-[appid], for example `mycos-1258798060`. NOTE: versioning of the bucket must be enabled.",
+			},
+			"cdc_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "CDC cluster ID.",
+			},
+			"replica_role": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Request initiator identifier, format: `qcs::cam::uin/<owneruin>:uin/<subuin>`.",
+			},
+			"replica_rules": {
+				Type:        schema.TypeList,
+				Required:    true,
+				Description: "List of replica rule, the same as `replica_rules` of `tencentcloud_cos_bucket`.",
+				Elem:        replicaRules(),
+			},
+		},
+	}
+}
+
+func resourceTencentCloudCosBucketReplicationCreate(d *schema.ResourceData, meta interface{}) error {
+	defer tccommon.LogElapsed("resource.tencentcloud_cos_bucket_replication.create")()
+
+	d.SetId(d.Get("bucket").(string))
+
+	return resourceTencentCloudCosBucketReplicationUpdate(d, meta)
+}
+
+func resourceTencentCloudCosBucketReplicationRead(d *schema.ResourceData, meta interface{}) error {
+	defer tccommon.LogElapsed("resource.tencentcloud_cos_bucket_replication.read")()
+	defer tccommon.InconsistentCheck(d, meta)()
+
+	logId := tccommon.GetLogId(tccommon.ContextNil)
+	ctx := context.WithValue(context.TODO(), tccommon.LogIdKey, logId)
+
+	cosService := CosService{client: meta.(tccommon.ProviderMeta).GetAPIV3Conn()}
+
+	bucket := d.Id()
+	result, err := cosService.GetBucketReplication(ctx, bucket, d.Get("cdc_id").(string))
+	if err != nil {
+		return err
+	}
+
+	if result == nil || len(result.Rule) == 0 {
+		d.SetId("")
+		log.Printf("[WARN]%s resource `CosBucketReplication` [%s] not found, please check if it has been deleted.\n", logId, bucket)
+		return nil
+	}
+
+	_ = d.Set("bucket", bucket)
+
+	return setBucketReplication(d, *result)
+}
+
+func resourceTencentCloudCosBucketReplicationUpdate(d *schema.ResourceData, meta interface{}) error {
+	defer tccommon.LogElapsed("resource.tencentcloud_cos_bucket_replication.update")()
+
+	logId := tccommon.GetLogId(tccommon.ContextNil)
+	ctx := context.WithValue(context.TODO(), tccommon.LogIdKey, logId)
+
+	cosService := CosService{client: meta.(tccommon.ProviderMeta).GetAPIV3Conn()}
+
+	role, rules, _ := getBucketReplications(d)
+	if err := cosService.PutBucketReplication(ctx, d.Id(), role, rules, d.Get("cdc_id").(string)); err != nil {
+		return err
+	}
+
+	return resourceTencentCloudCosBucketReplicationRead(d, meta)
+}
+
+func resourceTencentCloudCosBucketReplicationDelete(d *schema.ResourceData, meta interface{}) error {
+	defer tccommon.LogElapsed("resource.tencentcloud_cos_bucket_replication.delete")()
+
+	logId := tccommon.GetLogId(tccommon.ContextNil)
+	ctx := context.WithValue(context.TODO(), tccommon.LogIdKey, logId)
+
+	cosService := CosService{client: meta.(tccommon.ProviderMeta).GetAPIV3Conn()}
+
+	return cosService.DeleteBucketReplication(ctx, d.Id(), d.Get("cdc_id").(string))
+}
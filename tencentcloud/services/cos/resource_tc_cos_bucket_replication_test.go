@@ -0,0 +1,98 @@
+package cos_test
+
+import (
+	"testing"
+
+	tcacctest "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/acctest"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// go test -i; go test -test.run TestAccTencentCloudCosBucketReplicationResource_basic -v
+func TestAccTencentCloudCosBucketReplicationResource_basic(t *testing.T) {
+	t.Parallel()
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			tcacctest.AccPreCheck(t)
+		},
+		Providers: tcacctest.AccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCosBucketReplication,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("tencentcloud_cos_bucket_replication.replication", "id"),
+					resource.TestCheckResourceAttr("tencentcloud_cos_bucket_replication.replication", "replica_rules.#", "1"),
+					resource.TestCheckResourceAttr("tencentcloud_cos_bucket_replication.replication", "replica_rules.0.status", "Enabled"),
+				),
+			},
+			{
+				ResourceName:      "tencentcloud_cos_bucket_replication.replication",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccCosBucketReplicationUp,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("tencentcloud_cos_bucket_replication.replication", "id"),
+					resource.TestCheckResourceAttr("tencentcloud_cos_bucket_replication.replication", "replica_rules.0.status", "Disabled"),
+				),
+			},
+		},
+	})
+}
+
+const testAccCosBucketReplicationVar = `
+data "tencentcloud_user_info" "info" {}
+
+resource "tencentcloud_cos_bucket" "bucket" {
+	bucket             = "tf-bucket-replication-${data.tencentcloud_user_info.info.app_id}"
+	acl                = "private"
+	versioning_enable  = true
+	ignore_sub_configs = ["replication"]
+	force_clean        = true
+}
+`
+
+const testAccCosBucketReplication = testAccCosBucketReplicationVar + `
+
+resource "tencentcloud_cos_bucket" "replicate" {
+	bucket            = "tf-bucket-replicate-${data.tencentcloud_user_info.info.app_id}"
+	acl               = "private"
+	versioning_enable = true
+	force_clean       = true
+}
+
+resource "tencentcloud_cos_bucket_replication" "replication" {
+	bucket       = tencentcloud_cos_bucket.bucket.bucket
+	replica_role = "qcs::cam::uin/${data.tencentcloud_user_info.info.owner_uin}:uin/${data.tencentcloud_user_info.info.uin}"
+
+	replica_rules {
+		id                 = "tf-replication"
+		status             = "Enabled"
+		prefix             = "dist"
+		destination_bucket = "qcs::cos:ap-guangzhou::${tencentcloud_cos_bucket.replicate.bucket}"
+	}
+}
+`
+
+const testAccCosBucketReplicationUp = testAccCosBucketReplicationVar + `
+
+resource "tencentcloud_cos_bucket" "replicate" {
+	bucket            = "tf-bucket-replicate-${data.tencentcloud_user_info.info.app_id}"
+	acl               = "private"
+	versioning_enable = true
+	force_clean       = true
+}
+
+resource "tencentcloud_cos_bucket_replication" "replication" {
+	bucket       = tencentcloud_cos_bucket.bucket.bucket
+	replica_role = "qcs::cam::uin/${data.tencentcloud_user_info.info.owner_uin}:uin/${data.tencentcloud_user_info.info.uin}"
+
+	replica_rules {
+		id                 = "tf-replication"
+		status             = "Disabled"
+		prefix             = "dist"
+		destination_bucket = "qcs::cos:ap-guangzhou::${tencentcloud_cos_bucket.replicate.bucket}"
+	}
+}
+`
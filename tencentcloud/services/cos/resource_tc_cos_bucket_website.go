@@ -0,0 +1,111 @@
+package cos
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	tccommon "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func ResourceTencentCloudCosBucketWebsite() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceTencentCloudCosBucketWebsiteCreate,
+		Read:   resourceTencentCloudCosBucketWebsiteRead,
+		Update: resourceTencentCloudCosBucketWebsiteUpdate,
+		Delete: resourceTencentCloudCosBucketWebsiteDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		CustomizeDiff: cosBucketSubConfigCustomizeDiff(COS_BUCKET_SUB_CONFIG_WEBSITE),
+		Schema: map[string]*schema.Schema{
+			"bucket": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Bucket format should be [custom name]-[appid], for example `mycos-1258798060`.",
+			},
+			"cdc_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "CDC cluster ID.",
+			},
+			"website": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MaxItems:    1,
+				Description: "A website configuration, the same as `website` of `tencentcloud_cos_bucket`.",
+				Elem:        websiteConfiguration(),
+			},
+		},
+	}
+}
+
+func resourceTencentCloudCosBucketWebsiteCreate(d *schema.ResourceData, meta interface{}) error {
+	defer tccommon.LogElapsed("resource.tencentcloud_cos_bucket_website.create")()
+
+	d.SetId(d.Get("bucket").(string))
+
+	return resourceTencentCloudCosBucketWebsiteUpdate(d, meta)
+}
+
+func resourceTencentCloudCosBucketWebsiteRead(d *schema.ResourceData, meta interface{}) error {
+	defer tccommon.LogElapsed("resource.tencentcloud_cos_bucket_website.read")()
+	defer tccommon.InconsistentCheck(d, meta)()
+
+	logId := tccommon.GetLogId(tccommon.ContextNil)
+	ctx := context.WithValue(context.TODO(), tccommon.LogIdKey, logId)
+
+	cosService := CosService{client: meta.(tccommon.ProviderMeta).GetAPIV3Conn()}
+
+	bucket := d.Id()
+	website, err := cosService.GetBucketWebsite(ctx, bucket, d.Get("cdc_id").(string))
+	if err != nil {
+		return err
+	}
+
+	if len(website) == 0 {
+		d.SetId("")
+		log.Printf("[WARN]%s resource `CosBucketWebsite` [%s] not found, please check if it has been deleted.\n", logId, bucket)
+		return nil
+	}
+
+	if meta.(tccommon.ProviderMeta).GetAPIV3Conn().CosDomain == "" {
+		// {bucket}.cos-website.{region}.myqcloud.com
+		website[0]["endpoint"] = fmt.Sprintf("%s.cos-website.%s.myqcloud.com", bucket, meta.(tccommon.ProviderMeta).GetAPIV3Conn().Region)
+	}
+
+	_ = d.Set("bucket", bucket)
+	if err = d.Set("website", website); err != nil {
+		return fmt.Errorf("setting website error: %v", err)
+	}
+
+	return nil
+}
+
+func resourceTencentCloudCosBucketWebsiteUpdate(d *schema.ResourceData, meta interface{}) error {
+	defer tccommon.LogElapsed("resource.tencentcloud_cos_bucket_website.update")()
+
+	logId := tccommon.GetLogId(tccommon.ContextNil)
+	ctx := context.WithValue(context.TODO(), tccommon.LogIdKey, logId)
+
+	if err := resourceTencentCloudCosBucketWebsiteConfigUpdate(ctx, meta, d); err != nil {
+		return err
+	}
+
+	return resourceTencentCloudCosBucketWebsiteRead(d, meta)
+}
+
+func resourceTencentCloudCosBucketWebsiteDelete(d *schema.ResourceData, meta interface{}) error {
+	defer tccommon.LogElapsed("resource.tencentcloud_cos_bucket_website.delete")()
+
+	logId := tccommon.GetLogId(tccommon.ContextNil)
+	ctx := context.WithValue(context.TODO(), tccommon.LogIdKey, logId)
+
+	cosService := CosService{client: meta.(tccommon.ProviderMeta).GetAPIV3Conn()}
+
+	return cosService.DeleteBucketWebsite(ctx, d.Id(), d.Get("cdc_id").(string))
+}
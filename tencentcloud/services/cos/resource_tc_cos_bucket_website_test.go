@@ -0,0 +1,77 @@
+package cos_test
+
+import (
+	"testing"
+
+	tcacctest "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/acctest"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// go test -i; go test -test.run TestAccTencentCloudCosBucketWebsiteResource_basic -v
+func TestAccTencentCloudCosBucketWebsiteResource_basic(t *testing.T) {
+	t.Parallel()
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			tcacctest.AccPreCheck(t)
+		},
+		Providers: tcacctest.AccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCosBucketWebsite,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("tencentcloud_cos_bucket_website.website", "id"),
+					resource.TestCheckResourceAttr("tencentcloud_cos_bucket_website.website", "website.#", "1"),
+					resource.TestCheckResourceAttr("tencentcloud_cos_bucket_website.website", "website.0.index_document", "index.html"),
+				),
+			},
+			{
+				ResourceName:      "tencentcloud_cos_bucket_website.website",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccCosBucketWebsiteUp,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("tencentcloud_cos_bucket_website.website", "id"),
+					resource.TestCheckResourceAttr("tencentcloud_cos_bucket_website.website", "website.0.index_document", "home.html"),
+				),
+			},
+		},
+	})
+}
+
+const testAccCosBucketWebsiteVar = `
+data "tencentcloud_user_info" "info" {}
+
+resource "tencentcloud_cos_bucket" "bucket" {
+	bucket             = "tf-bucket-website-${data.tencentcloud_user_info.info.app_id}"
+	acl                = "private"
+	ignore_sub_configs = ["website"]
+	force_clean        = true
+}
+`
+
+const testAccCosBucketWebsite = testAccCosBucketWebsiteVar + `
+
+resource "tencentcloud_cos_bucket_website" "website" {
+	bucket = tencentcloud_cos_bucket.bucket.bucket
+
+	website {
+		index_document = "index.html"
+		error_document = "error.html"
+	}
+}
+`
+
+const testAccCosBucketWebsiteUp = testAccCosBucketWebsiteVar + `
+
+resource "tencentcloud_cos_bucket_website" "website" {
+	bucket = tencentcloud_cos_bucket.bucket.bucket
+
+	website {
+		index_document = "home.html"
+		error_document = "error.html"
+	}
+}
+`
@@ -5,11 +5,14 @@ import (
 	"context"
 	"encoding/json"
 	"encoding/xml"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
+	"net/url"
 	"regexp"
 	"strings"
+	"time"
 
 	tccommon "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/common"
 
@@ -384,6 +387,20 @@ func (me *CosService) DeleteBucket(ctx context.Context, bucket string, forced bo
 func (me *CosService) ForceCleanObject(ctx context.Context, bucket string, versioned bool, cdcId string) error {
 	logId := tccommon.GetLogId(ctx)
 
+	// objects under retention of object lock can not be deleted, refuse to clean instead of leaving a half cleaned bucket
+	objectLock, err := me.DescribeCosBucketObjectLockById(ctx, bucket, cdcId)
+	if err != nil {
+		if !IsCosObjectLockUnavailableError(err) {
+			return fmt.Errorf("cos force clean object error: %s, bucket: %s", err.Error(), bucket)
+		}
+		log.Printf("[WARN]%s object lock of bucket[%s] can not be read, cleaning it as a bucket without object lock, reason[%s]\n",
+			logId, bucket, err.Error())
+	}
+	if objectLock != nil && objectLock.ObjectLockEnabled == "Enabled" {
+		return fmt.Errorf("cos force clean object error: bucket[%s] has object lock enabled, objects under retention can not be deleted, "+
+			"`force_clean` is refused. Please wait for the retention to expire and clean the bucket manually!!!", bucket)
+	}
+
 	// Get the object list of bucket with all versions
 	verOpt := cos.BucketGetObjectVersionsOptions{}
 	objList, resp, err := me.client.UseTencentCosClientNew(bucket, cdcId).Bucket.GetObjectVersions(ctx, &verOpt)
@@ -1065,6 +1082,35 @@ func (me *CosService) GetObjectTags(ctx context.Context, bucket string, key stri
 	return tags, nil
 }
 
+// GetObjectPresignedUrl signs the url of the object locally with the credential of the provider, no request is sent.
+// The headers and the query params are signed, so requests of the url must carry the same headers.
+func (me *CosService) GetObjectPresignedUrl(ctx context.Context, bucket, key, method string, expired time.Duration,
+	header http.Header, query url.Values, signHost bool, cdcId string) (string, error) {
+	logId := tccommon.GetLogId(ctx)
+
+	credential := me.client.Credential
+	if query == nil {
+		query = url.Values{}
+	}
+	if credential.Token != "" {
+		query.Set("x-cos-security-token", credential.Token)
+	}
+	opt := &cos.PresignedURLOptions{
+		Query:  &query,
+		Header: &header,
+	}
+
+	presignedUrl, err := me.client.UseTencentCosClientNew(bucket, cdcId).Object.GetPresignedURL(ctx, method, key,
+		credential.SecretId, credential.SecretKey, expired, opt, signHost)
+	if err != nil {
+		log.Printf("[CRITAL]%s api[%s] fail, bucket [%s], key [%s], reason[%s]\n",
+			logId, "get presigned url", bucket, key, err.Error())
+		return "", err
+	}
+
+	return presignedUrl.String(), nil
+}
+
 // SetObjectTags same as delete Bucket Tags
 func (me *CosService) SetObjectTags(ctx context.Context, bucket string, key string, tags map[string]string) error {
 	logId := tccommon.GetLogId(ctx)
@@ -1240,7 +1286,7 @@ func GetBucketPublicACL(acl *cos.BucketGetACLResult) string {
 	return s3.ObjectCannedACLPrivate
 }
 
-func (me *CosService) GetBucketPullOrigin(ctx context.Context, bucket string) (result []map[string]interface{}, errRet error) {
+func (me *CosService) GetBucketPullOrigin(ctx context.Context, bucket string, cdcId string) (result []map[string]interface{}, errRet error) {
 	logId := tccommon.GetLogId(ctx)
 
 	defer func() {
@@ -1251,7 +1297,7 @@ func (me *CosService) GetBucketPullOrigin(ctx context.Context, bucket string) (r
 	}()
 
 	ratelimit.Check("TencentcloudCosGetBucketPullOrigin")
-	originConfig, response, err := me.client.UseTencentCosClient(bucket).Bucket.GetOrigin(ctx)
+	originConfig, response, err := me.client.UseTencentCosClientNew(bucket, cdcId).Bucket.GetOrigin(ctx)
 
 	if response.StatusCode == 404 {
 		return make([]map[string]interface{}, 0), nil
@@ -1378,7 +1424,7 @@ func (me *CosService) DeleteBucketPullOrigin(ctx context.Context, bucket string,
 	return nil
 }
 
-func (me *CosService) GetBucketOriginDomain(ctx context.Context, bucket string) (result []map[string]interface{}, errRet error) {
+func (me *CosService) GetBucketOriginDomain(ctx context.Context, bucket string, cdcId string) (result []map[string]interface{}, errRet error) {
 	logId := tccommon.GetLogId(ctx)
 
 	defer func() {
@@ -1389,7 +1435,7 @@ func (me *CosService) GetBucketOriginDomain(ctx context.Context, bucket string)
 	}()
 
 	ratelimit.Check("TencentcloudCosGetBucketOriginDomain")
-	domain, response, err := me.client.UseTencentCosClient(bucket).Bucket.GetDomain(ctx)
+	domain, response, err := me.client.UseTencentCosClientNew(bucket, cdcId).Bucket.GetDomain(ctx)
 
 	if response != nil && response.StatusCode == 404 {
 		log.Printf("[WARN] [GetBucketOriginDomain] returns %d, %s", 404, err)
@@ -1563,6 +1609,141 @@ func (me *CosService) DeleteBucketReplication(ctx context.Context, bucket string
 	return
 }
 
+func (me *CosService) DeleteBucketLifecycle(ctx context.Context, bucket string, cdcId string) (errRet error) {
+	logId := tccommon.GetLogId(ctx)
+
+	request := s3.DeleteBucketLifecycleInput{
+		Bucket: aws.String(bucket),
+	}
+	ratelimit.Check("DeleteBucketLifecycle")
+	response, err := me.client.UseCosClientNew(cdcId).DeleteBucketLifecycle(&request)
+	if err != nil {
+		log.Printf("[CRITAL]%s api[%s] fail, request body [%s], reason[%s]\n",
+			logId, "delete bucket lifecycle", request.String(), err.Error())
+		return fmt.Errorf("cos delete bucket lifecycle error: %s, bucket: %s", err.Error(), bucket)
+	}
+	log.Printf("[DEBUG]%s api[%s] success, request body [%s], response body [%s]\n",
+		logId, "delete bucket lifecycle", request.String(), response.String())
+
+	return nil
+}
+
+func (me *CosService) DeleteBucketCors(ctx context.Context, bucket string, cdcId string) (errRet error) {
+	logId := tccommon.GetLogId(ctx)
+
+	request := s3.DeleteBucketCorsInput{
+		Bucket: aws.String(bucket),
+	}
+	ratelimit.Check("DeleteBucketCors")
+	response, err := me.client.UseCosClientNew(cdcId).DeleteBucketCors(&request)
+	if err != nil {
+		log.Printf("[CRITAL]%s api[%s] fail, request body [%s], reason[%s]\n",
+			logId, "delete bucket cors", request.String(), err.Error())
+		return fmt.Errorf("cos delete bucket cors error: %s, bucket: %s", err.Error(), bucket)
+	}
+	log.Printf("[DEBUG]%s api[%s] success, request body [%s], response body [%s]\n",
+		logId, "delete bucket cors", request.String(), response.String())
+
+	return nil
+}
+
+func (me *CosService) DeleteBucketWebsite(ctx context.Context, bucket string, cdcId string) (errRet error) {
+	logId := tccommon.GetLogId(ctx)
+
+	request := s3.DeleteBucketWebsiteInput{
+		Bucket: aws.String(bucket),
+	}
+	ratelimit.Check("DeleteBucketWebsite")
+	response, err := me.client.UseCosClientNew(cdcId).DeleteBucketWebsite(&request)
+	if err != nil {
+		log.Printf("[CRITAL]%s api[%s] fail, request body [%s], reason[%s]\n",
+			logId, "delete bucket website", request.String(), err.Error())
+		return fmt.Errorf("cos delete bucket website error: %s, bucket: %s", err.Error(), bucket)
+	}
+	log.Printf("[DEBUG]%s api[%s] success, request body [%s], response body [%s]\n",
+		logId, "delete bucket website", request.String(), response.String())
+
+	return nil
+}
+
+func (me *CosService) DeleteBucketEncryption(ctx context.Context, bucket string, cdcId string) (errRet error) {
+	logId := tccommon.GetLogId(ctx)
+
+	request := s3.DeleteBucketEncryptionInput{
+		Bucket: aws.String(bucket),
+	}
+	ratelimit.Check("DeleteBucketEncryption")
+	response, err := me.client.UseCosClientNew(cdcId).DeleteBucketEncryption(&request)
+	if err != nil {
+		log.Printf("[CRITAL]%s api[%s] fail, request body [%s], reason[%s]\n",
+			logId, "delete bucket encryption", request.String(), err.Error())
+		return fmt.Errorf("cos delete bucket encryption error: %s, bucket: %s", err.Error(), bucket)
+	}
+	log.Printf("[DEBUG]%s api[%s] success, request body [%s], response body [%s]\n",
+		logId, "delete bucket encryption", request.String(), response.String())
+
+	return nil
+}
+
+// PutBucketLogging saves the access log of bucket to targetBucket, the log is disabled if targetBucket is empty.
+func (me *CosService) PutBucketLogging(ctx context.Context, bucket, targetBucket, logPrefix string, cdcId string) (errRet error) {
+	logId := tccommon.GetLogId(ctx)
+
+	request := s3.PutBucketLoggingInput{
+		Bucket:              aws.String(bucket),
+		BucketLoggingStatus: &s3.BucketLoggingStatus{},
+	}
+	if targetBucket != "" {
+		request.BucketLoggingStatus.LoggingEnabled = &s3.LoggingEnabled{
+			TargetBucket: aws.String(targetBucket),
+			TargetPrefix: aws.String(logPrefix),
+		}
+	}
+	ratelimit.Check("PutBucketLogging")
+	response, err := me.client.UseCosClientNew(cdcId).PutBucketLogging(&request)
+	if err != nil {
+		log.Printf("[CRITAL]%s api[%s] fail, request body [%s], reason[%s]\n",
+			logId, "put bucket logging", request.String(), err.Error())
+		return fmt.Errorf("cos put bucket logging error: %s, bucket: %s", err.Error(), bucket)
+	}
+	log.Printf("[DEBUG]%s api[%s] success, request body [%s], response body [%s]\n",
+		logId, "put bucket logging", request.String(), response.String())
+
+	return nil
+}
+
+// DescribeBucketSubConfigExists returns whether the sub configuration, such as `lifecycle`, is configured for the bucket.
+func (me *CosService) DescribeBucketSubConfigExists(ctx context.Context, bucket, subConfig string, cdcId string) (exists bool, errRet error) {
+	switch subConfig {
+	case COS_BUCKET_SUB_CONFIG_LIFECYCLE:
+		rules, err := me.GetBucketLifecycle(ctx, bucket, cdcId)
+		return len(rules) > 0, err
+	case COS_BUCKET_SUB_CONFIG_CORS:
+		rules, err := me.GetBucketCors(ctx, bucket, cdcId)
+		return len(rules) > 0, err
+	case COS_BUCKET_SUB_CONFIG_WEBSITE:
+		website, err := me.GetBucketWebsite(ctx, bucket, cdcId)
+		return len(website) > 0, err
+	case COS_BUCKET_SUB_CONFIG_REPLICATION:
+		result, err := me.GetBucketReplication(ctx, bucket, cdcId)
+		return result != nil && len(result.Rule) > 0, err
+	case COS_BUCKET_SUB_CONFIG_LOGGING:
+		logEnable, _, _, err := me.GetBucketLogStatus(ctx, bucket, cdcId)
+		return logEnable, err
+	case COS_BUCKET_SUB_CONFIG_ENCRYPTION:
+		encryption, _, err := me.GetBucketEncryption(ctx, bucket, cdcId)
+		return encryption != "", err
+	case COS_BUCKET_SUB_CONFIG_ORIGIN:
+		pullRules, err := me.GetBucketPullOrigin(ctx, bucket, cdcId)
+		if err != nil || len(pullRules) > 0 {
+			return len(pullRules) > 0, err
+		}
+		domainRules, err := me.GetBucketOriginDomain(ctx, bucket, cdcId)
+		return len(domainRules) > 0, err
+	}
+	return false, fmt.Errorf("unknown sub configuration `%s` of cos bucket", subConfig)
+}
+
 func (me *CosService) DescribeCosBucketDomainCertificate(ctx context.Context, certId string) (result *cos.BucketGetDomainCertificateResult, bucket string, errRet error) {
 	logId := tccommon.GetLogId(ctx)
 
@@ -1706,6 +1887,113 @@ func (me *CosService) DescribeCosBucketVersionById(ctx context.Context, bucket s
 	return resRaw.(*cos.BucketGetVersionResult), nil
 }
 
+func (me *CosService) DescribeCosBucketObjectLockById(ctx context.Context, bucket string, cdcId string) (*cos.BucketGetObjectLockResult, error) {
+	var errRet error
+	logId := tccommon.GetLogId(ctx)
+
+	defer func() {
+		if errRet != nil {
+			log.Printf("[CRITAL]%s api[%s] fail, request body [%s], reason[%s]\n", logId, "GetObjectLockConfiguration", bucket, errRet.Error())
+		}
+	}()
+
+	resRaw, err := tccommon.RetryWithContext(ctx, tccommon.ReadRetryTimeout, func(ctx context.Context) (interface{}, error) {
+		res, _, err := me.client.UseTencentCosClientNew(bucket, cdcId).Bucket.GetObjectLockConfiguration(ctx)
+		return res, err
+	})
+
+	if err != nil {
+		// the bucket has never enabled object lock
+		if cos.IsNotFoundError(err) {
+			return nil, nil
+		}
+		errRet = err
+		return nil, errRet
+	}
+	log.Printf("[DEBUG]%s api[%s] success, request body [%s]\n", logId, "GetObjectLockConfiguration", bucket)
+
+	return resRaw.(*cos.BucketGetObjectLockResult), nil
+}
+
+// IsCosObjectLockUnavailableError returns whether the object lock configuration of a bucket can not be read because the
+// bucket has no configuration, the access is denied, or the region or CDC cluster does not support object lock.
+func IsCosObjectLockUnavailableError(err error) bool {
+	var e *cos.ErrorResponse
+	if !errors.As(err, &e) {
+		return false
+	}
+	if e.Response != nil {
+		switch e.Response.StatusCode {
+		case http.StatusForbidden, http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+			return true
+		}
+	}
+	return tccommon.IsContains(COS_OBJECT_LOCK_UNAVAILABLE_ERRORS, e.Code)
+}
+
+func (me *CosService) PutCosBucketObjectLock(ctx context.Context, bucket string, opt *cos.BucketPutObjectLockOptions, cdcId string) (errRet error) {
+	logId := tccommon.GetLogId(ctx)
+
+	defer func() {
+		if errRet != nil {
+			log.Printf("[CRITAL]%s api[%s] fail, request body [%+v], reason[%s]\n", logId, "PutObjectLockConfiguration", opt, errRet.Error())
+		}
+	}()
+
+	ratelimit.Check("PutObjectLockConfiguration")
+	_, err := me.client.UseTencentCosClientNew(bucket, cdcId).Bucket.PutObjectLockConfiguration(ctx, opt)
+	if err != nil {
+		errRet = err
+		return
+	}
+	log.Printf("[DEBUG]%s api[%s] success, request body [%+v]\n", logId, "PutObjectLockConfiguration", opt)
+
+	return nil
+}
+
+// DescribeCosObjectRetainUntilDate returns the retain until date of the object, zero time if the object is not locked.
+func (me *CosService) DescribeCosObjectRetainUntilDate(ctx context.Context, bucket, key string, cdcId string) (retainUntil time.Time, errRet error) {
+	logId := tccommon.GetLogId(ctx)
+
+	ratelimit.Check("GetRetention")
+	result, _, err := me.client.UseTencentCosClientNew(bucket, cdcId).Object.GetRetention(ctx, key, nil)
+	if err != nil {
+		// buckets without object lock and objects without retention return 404
+		if cos.IsNotFoundError(err) {
+			return
+		}
+		log.Printf("[CRITAL]%s api[%s] fail, bucket [%s], key [%s], reason[%s]\n", logId, "GetRetention", bucket, key, err.Error())
+		errRet = fmt.Errorf("cos get object retention error: %s, bucket: %s, object: %s", err.Error(), bucket, key)
+		return
+	}
+	if result.RetainUntilDate == "" {
+		return
+	}
+
+	for _, layout := range []string{time.RFC3339, time.RFC1123, time.RFC1123Z} {
+		if retainUntil, err = time.Parse(layout, result.RetainUntilDate); err == nil {
+			return
+		}
+	}
+	errRet = fmt.Errorf("cos get object retention error: unknown retain until date `%s`, bucket: %s, object: %s", result.RetainUntilDate, bucket, key)
+	return
+}
+
+// CheckCosObjectUnlocked returns an error if the object is still under retention of the object lock.
+func (me *CosService) CheckCosObjectUnlocked(ctx context.Context, bucket, key string, cdcId string) error {
+	retainUntil, err := me.DescribeCosObjectRetainUntilDate(ctx, bucket, key, cdcId)
+	if err != nil {
+		// the retention is only used to give a clear diagnostic, let the request itself report the error
+		log.Printf("[WARN]%s check object lock of object (%s) in bucket (%s) error, skip checking: %s\n", tccommon.GetLogId(ctx), key, bucket, err.Error())
+		return nil
+	}
+	if retainUntil.After(time.Now()) {
+		return fmt.Errorf("object (%s) in cos bucket (%s) is locked by object lock until %s and can not be deleted or overwritten before then",
+			key, bucket, retainUntil.UTC().Format(time.RFC3339))
+	}
+	return nil
+}
+
 func (me *CosService) BucketPutIntelligentTiering(ctx context.Context, bucket string, opt *cos.BucketPutIntelligentTieringOptions, cdcId string) (errRet error) {
 	logId := tccommon.GetLogId(ctx)
 
@@ -0,0 +1,37 @@
+package cos
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/tencentyun/cos-go-sdk-v5"
+)
+
+func TestIsCosObjectLockUnavailableError(t *testing.T) {
+	response := func(statusCode int, code string) error {
+		return &cos.ErrorResponse{Response: &http.Response{StatusCode: statusCode}, Code: code}
+	}
+
+	cases := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"not found", response(http.StatusNotFound, "NoSuchObjectLockConfiguration"), true},
+		{"access denied", response(http.StatusForbidden, "AccessDenied"), true},
+		{"method not allowed", response(http.StatusMethodNotAllowed, "MethodNotAllowed"), true},
+		{"not implemented", response(http.StatusNotImplemented, "NotImplemented"), true},
+		{"unsupported code", response(http.StatusBadRequest, "NotSupported"), true},
+		{"wrapped", fmt.Errorf("read object lock: %w", response(http.StatusForbidden, "AccessDenied")), true},
+		{"internal error", response(http.StatusInternalServerError, "InternalError"), false},
+		{"invalid bucket", response(http.StatusBadRequest, "InvalidBucketName"), false},
+		{"not a cos error", errors.New("connection reset by peer"), false},
+	}
+	for _, c := range cases {
+		if got := IsCosObjectLockUnavailableError(c.err); got != c.want {
+			t.Errorf("%s: IsCosObjectLockUnavailableError = %v, want %v", c.name, got, c.want)
+		}
+	}
+}
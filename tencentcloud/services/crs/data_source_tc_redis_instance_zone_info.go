@@ -193,7 +193,7 @@ func dataSourceTencentCloudRedisInstanceZoneInfoRead(d *schema.ResourceData, met
 	d.SetId(helper.DataResourceIdsHash([]string{instanceId}))
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), tmpList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), tmpList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
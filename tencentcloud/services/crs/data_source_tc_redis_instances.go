@@ -263,7 +263,7 @@ instanceLoop:
 	d.SetId("redis_instances_list" + region)
 
 	if output, ok := d.GetOk("result_output_file"); ok && output.(string) != "" {
-		if err := tccommon.WriteToFile(output.(string), instanceList); err != nil {
+		if err := tccommon.WriteToFile(output.(string), instanceList, tccommon.GetResultOutputOption(d)); err != nil {
 			log.Printf("[CRITAL]%s output file[%s] fail, reason[%s]\n",
 				logId, output.(string), err.Error())
 		}
@@ -131,7 +131,7 @@ func dataSourceTencentCloudRedisParamRecordsRead(d *schema.ResourceData, meta in
 	d.SetId(helper.DataResourceIdsHash([]string{instanceId}))
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), tmpList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), tmpList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
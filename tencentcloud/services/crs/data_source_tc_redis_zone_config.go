@@ -208,7 +208,7 @@ func dataSourceTencentRedisZoneConfigRead(d *schema.ResourceData, meta interface
 
 	if output, ok := d.GetOk("result_output_file"); ok && output.(string) != "" {
 
-		if err := tccommon.WriteToFile(output.(string), allZonesConfigs); err != nil {
+		if err := tccommon.WriteToFile(output.(string), allZonesConfigs, tccommon.GetResultOutputOption(d)); err != nil {
 			log.Printf("[CRITAL]%s output file[%s] fail, reason[%s]\n",
 				logId, output.(string), err.Error())
 			return err
@@ -156,7 +156,7 @@ func dataSourceTencentCloudCssMonitorReportRead(d *schema.ResourceData, meta int
 	d.SetId(helper.DataResourceIdsHash([]string{monitorId}))
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), d); e != nil {
+		if e := tccommon.WriteToFile(output.(string), d, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
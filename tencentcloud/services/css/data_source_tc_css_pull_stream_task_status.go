@@ -142,7 +142,7 @@ func dataSourceTencentCloudCssPullStreamTaskStatusRead(d *schema.ResourceData, m
 	d.SetId(helper.DataResourceIdsHash([]string{taskId}))
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), taskStatusInfoMap); e != nil {
+		if e := tccommon.WriteToFile(output.(string), taskStatusInfoMap, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
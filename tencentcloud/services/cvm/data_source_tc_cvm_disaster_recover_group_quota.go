@@ -94,7 +94,7 @@ func dataSourceTencentCloudCvmDisasterRecoverGroupQuotaRead(d *schema.ResourceDa
 			"cvm_in_host_group_quota": response.Response.CvmInHostGroupQuota,
 			"cvm_in_sw_group_quota":   response.Response.CvmInSwGroupQuota,
 			"cvm_in_rack_group_quota": response.Response.CvmInRackGroupQuota,
-		}); e != nil {
+		}, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
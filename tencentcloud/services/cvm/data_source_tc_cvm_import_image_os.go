@@ -157,7 +157,7 @@ func dataSourceTencentCloudCvmImportImageOsRead(d *schema.ResourceData, meta int
 	d.SetId(helper.BuildToken())
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), result); e != nil {
+		if e := tccommon.WriteToFile(output.(string), result, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
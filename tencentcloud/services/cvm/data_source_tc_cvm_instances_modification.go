@@ -192,7 +192,7 @@ func dataSourceTencentCloudCvmInstancesModificationRead(d *schema.ResourceData,
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if err := tccommon.WriteToFile(output.(string), instanceTypeConfigStatusList); err != nil {
+		if err := tccommon.WriteToFile(output.(string), instanceTypeConfigStatusList, tccommon.GetResultOutputOption(d)); err != nil {
 			return err
 		}
 	}
@@ -59,7 +59,7 @@ func dataSourceTencentCloudEipNetworkAccountTypeRead(d *schema.ResourceData, met
 	d.SetId(*networkAccountType)
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), *networkAccountType); e != nil {
+		if e := tccommon.WriteToFile(output.(string), *networkAccountType, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
@@ -182,7 +182,7 @@ EIP_LOOP:
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if err := tccommon.WriteToFile(output.(string), eipList); err != nil {
+		if err := tccommon.WriteToFile(output.(string), eipList, tccommon.GetResultOutputOption(d)); err != nil {
 			return err
 		}
 	}
@@ -164,7 +164,7 @@ func dataSourceTencentCloudImageRead(d *schema.ResourceData, meta interface{}) e
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if err = tccommon.WriteToFile(output.(string), resultImageId); err != nil {
+		if err = tccommon.WriteToFile(output.(string), resultImageId, tccommon.GetResultOutputOption(d)); err != nil {
 			return err
 		}
 	}
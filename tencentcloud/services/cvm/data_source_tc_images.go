@@ -303,7 +303,7 @@ func dataSourceTencentCloudImagesRead(d *schema.ResourceData, meta interface{})
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if err := tccommon.WriteToFile(output.(string), imageList); err != nil {
+		if err := tccommon.WriteToFile(output.(string), imageList, tccommon.GetResultOutputOption(d)); err != nil {
 			return err
 		}
 	}
@@ -216,7 +216,7 @@ func dataSourceTencentCloudInstanceTypesRead(d *schema.ResourceData, meta interf
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if err := tccommon.WriteToFile(output.(string), typeList); err != nil {
+		if err := tccommon.WriteToFile(output.(string), typeList, tccommon.GetResultOutputOption(d)); err != nil {
 			return err
 		}
 	}
@@ -59,7 +59,7 @@ func DataSourceTencentCloudInstances() *schema.Resource {
 				Type:          schema.TypeList,
 				Optional:      true,
 				MaxItems:      100,
-				ConflictsWith: []string{"instance_id", "instance_name", "availability_zone", "project_id", "vpc_id", "subnet_id", "tags"},
+				ConflictsWith: []string{"instance_id", "instance_name", "availability_zone", "project_id", "vpc_id", "subnet_id", "tags", "filter"},
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
@@ -70,6 +70,7 @@ func DataSourceTencentCloudInstances() *schema.Resource {
 				Optional:    true,
 				Description: "Tags of the instance.",
 			},
+			"filter": tccommon.DataSourceFilterSchema("DescribeInstances"),
 			"result_output_file": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -314,10 +315,19 @@ func dataSourceTencentCloudInstancesRead(d *schema.ResourceData, meta interface{
 		}
 	}
 
+	filters := make(map[string][]string, len(filter))
+	for k, v := range filter {
+		filters[k] = []string{v}
+	}
+	dataSourceFilters, err := tccommon.GetDataSourceFilters(d, filters)
+	if err != nil {
+		return err
+	}
+
 	var instances []*cvm.Instance
 	var errRet error
-	err := resource.Retry(tccommon.ReadRetryTimeout, func() *resource.RetryError {
-		instances, errRet = cvmService.DescribeInstanceByFilter(ctx, instanceSetIds, filter)
+	err = resource.Retry(tccommon.ReadRetryTimeout, func() *resource.RetryError {
+		instances, errRet = cvmService.DescribeInstancesByFilters(ctx, instanceSetIds, dataSourceFilters)
 		if errRet != nil {
 			return tccommon.RetryError(errRet, tccommon.InternalError)
 		}
@@ -329,6 +339,10 @@ func dataSourceTencentCloudInstancesRead(d *schema.ResourceData, meta interface{
 		return err
 	}
 
+	tccommon.SortByKey(instances, func(instance *cvm.Instance) string {
+		return *instance.InstanceId
+	})
+
 	instanceList := make([]map[string]interface{}, 0, len(instances))
 	ids := make([]string, 0, len(instances))
 	for _, instance := range instances {
@@ -396,7 +410,7 @@ func dataSourceTencentCloudInstancesRead(d *schema.ResourceData, meta interface{
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if err := tccommon.WriteToFile(output.(string), instanceList); err != nil {
+		if err := tccommon.WriteToFile(output.(string), instanceList, tccommon.GetResultOutputOption(d)); err != nil {
 			return err
 		}
 	}
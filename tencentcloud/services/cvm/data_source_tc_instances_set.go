@@ -336,7 +336,7 @@ func dataSourceTencentCloudInstancesSetRead(d *schema.ResourceData, meta interfa
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if err := tccommon.WriteToFile(output.(string), instanceList); err != nil {
+		if err := tccommon.WriteToFile(output.(string), instanceList, tccommon.GetResultOutputOption(d)); err != nil {
 			return err
 		}
 	}
@@ -19,11 +19,32 @@ func TestAccTencentCloudCvmInstancesDataSource_Basic(t *testing.T) {
 				Config: testAccCvmInstancesDataSource_BasicCreate,
 				Check:  resource.ComposeTestCheckFunc(acctest.AccCheckTencentCloudDataSourceID("data.tencentcloud_instances.example"), resource.TestCheckResourceAttr("data.tencentcloud_instances.example", "instance_list.0.project_id", "0"), resource.TestCheckResourceAttr("data.tencentcloud_instances.example", "instance_list.0.instance_name", "tf_example")),
 			},
+			{
+				Config: testAccCvmInstancesDataSource_Filter,
+				Check:  resource.ComposeTestCheckFunc(acctest.AccCheckTencentCloudDataSourceID("data.tencentcloud_instances.example"), resource.TestCheckResourceAttr("data.tencentcloud_instances.example", "instance_list.#", "1"), resource.TestCheckResourceAttr("data.tencentcloud_instances.example", "instance_list.0.instance_name", "tf_example")),
+			},
 		},
 	})
 }
 
-const testAccCvmInstancesDataSource_BasicCreate = `
+const testAccCvmInstancesDataSource_Filter = testAccCvmInstancesDataSource_Instance + `
+
+data "tencentcloud_instances" "example" {
+    vpc_id = tencentcloud_vpc.vpc.id
+
+    filter {
+        name = "instance-name"
+        values = [tencentcloud_instance.example.instance_name]
+    }
+
+    filter {
+        name = "instance-charge-type"
+        values = ["POSTPAID_BY_HOUR", "SPOTPAID"]
+    }
+}
+`
+
+const testAccCvmInstancesDataSource_BasicCreate = testAccCvmInstancesDataSource_Instance + `
 
 data "tencentcloud_instances" "example" {
     project_id = tencentcloud_instance.example.project_id
@@ -37,6 +58,9 @@ data "tencentcloud_instances" "example" {
     instance_name = tencentcloud_instance.example.instance_name
     availability_zone = tencentcloud_instance.example.availability_zone
 }
+`
+
+const testAccCvmInstancesDataSource_Instance = `
 resource "tencentcloud_vpc" "vpc" {
     name = "vpc"
     cidr_block = "10.0.0.0/16"
@@ -177,7 +177,7 @@ func dataSourceTencentCloudReservedInstanceConfigsRead(d *schema.ResourceData, m
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if err := tccommon.WriteToFile(output.(string), configList); err != nil {
+		if err := tccommon.WriteToFile(output.(string), configList, tccommon.GetResultOutputOption(d)); err != nil {
 			return err
 		}
 	}
@@ -23,6 +23,10 @@ const (
 	CVM_LATEST_OPERATION_STATE_SUCCESS   = "SUCCESS"
 	CVM_LATEST_OPERATION_STATE_FAILED    = "FAILED"
 
+	// blocks until cloud-init finishes, exits with a non-zero code if cloud-init reports errors. cloud-init exits
+	// with code 2 if it finishes with recoverable errors, whose status is `degraded done`, the boot succeeded then.
+	CVM_CLOUD_INIT_WAIT_COMMAND = "cloud-init status --wait --long; code=$?; if [ $code -eq 2 ]; then exit 0; fi; exit $code"
+
 	CVM_PREPAID_RENEW_FLAG_NOTIFY_NOTIFY_AND_AUTO_RENEW    = "NOTIFY_AND_AUTO_RENEW"
 	CVM_PREPAID_RENEW_FLAG_NOTIFY_AND_MANUAL_RENEW         = "NOTIFY_AND_MANUAL_RENEW"
 	CVM_PREPAID_RENEW_FLAG_DISABLE_NOTIFY_AND_MANUAL_RENEW = "DISABLE_NOTIFY_AND_MANUAL_RENEW"
@@ -0,0 +1,642 @@
+package cvm
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	tccommon "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/common"
+	svctag "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/services/tag"
+	svctat "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/services/tat"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	cvm "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/cvm/v20170312"
+	tat "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/tat/v20201028"
+
+	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/internal/helper"
+	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/ratelimit"
+)
+
+func ResourceTencentCloudCvmImageBuilder() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceTencentCloudCvmImageBuilderCreate,
+		Read:   resourceTencentCloudCvmImageBuilderRead,
+		Update: resourceTencentCloudCvmImageBuilderUpdate,
+		Delete: resourceTencentCloudCvmImageBuilderDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"image_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the base image the builder instance is launched from. The TAT agent must be installed in the image.",
+			},
+			"instance_type": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Instance type of the builder instance.",
+			},
+			"availability_zone": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Availability zone of the builder instance.",
+			},
+			"vpc_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				RequiredWith: []string{"subnet_id"},
+				Description:  "VPC ID of the builder instance. Default is the default VPC of the availability zone.",
+			},
+			"subnet_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				RequiredWith: []string{"vpc_id"},
+				Description:  "Subnet ID of the builder instance.",
+			},
+			"security_groups": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Security groups of the builder instance.",
+			},
+			"system_disk_type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     CVM_DISK_TYPE_CLOUD_PREMIUM,
+				Description: "System disk type of the builder instance, which is also the system disk type of the image. Default is `CLOUD_PREMIUM`.",
+			},
+			"system_disk_size": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     50,
+				Description: "System disk size of the builder instance in GB, which is also the system disk size of the image. Default is `50`.",
+			},
+			"internet_max_bandwidth_out": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     0,
+				Description: "Maximum outgoing bandwidth of the public IP of the builder instance in Mbps. Default is `0`, which means no public IP is assigned and the scripts can only access the VPC.",
+			},
+			"cam_role_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "CAM role of the builder instance, the scripts can use it to access other cloud services such as COS.",
+			},
+			"script": {
+				Type:        schema.TypeList,
+				Required:    true,
+				ForceNew:    true,
+				MinItems:    1,
+				Description: "Scripts run in order on the builder instance through TAT, the image is not created if any of them fails. Changing them creates a new image.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"content": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Content of the script, it is not base64 encoded.",
+						},
+						"command_type": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      svctat.TAT_COMMAND_TYPE_SHELL,
+							ValidateFunc: tccommon.ValidateAllowedStringValue(svctat.TAT_COMMAND_TYPES),
+							Description:  "Type of the script. Valid values: `SHELL`, `POWERSHELL`, `BAT`. Default is `SHELL`.",
+						},
+						"working_directory": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Working directory of the script. Default is `/root` for Linux and `C:\\Program Files\\qcloud\\tat_agent\\workdir` for Windows.",
+						},
+						"timeout": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      3600,
+							ValidateFunc: tccommon.ValidateIntegerInRange(1, 86400),
+							Description:  "Timeout of the script in seconds. Default is `3600`.",
+						},
+						"username": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "User running the script. Default is `root` for Linux and `System` for Windows.",
+						},
+					},
+				},
+			},
+			"image_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the created image.",
+			},
+			"image_description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Description of the created image.",
+			},
+			"image_family": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Image family of the created image, the latest image of a family can be queried by `tencentcloud_image_from_family`.",
+			},
+			"sysprep": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Whether to run Sysprep when creating a Windows image.",
+			},
+			"tags": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "Tags of the created image, the builder instance is not tagged.",
+			},
+			"share_account_ids": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Accounts the created image is shared with.",
+			},
+			"sync_regions": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Regions the created image is synchronized to. NOTE: the synchronized images are not deleted when the resource is destroyed, the same as `tencentcloud_cvm_sync_image`.",
+			},
+			"synced_images": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Images synchronized to `sync_regions`.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"region": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Region of the image.",
+						},
+						"image_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ID of the image.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceTencentCloudCvmImageBuilderCreate(d *schema.ResourceData, meta interface{}) error {
+	defer tccommon.LogElapsed("resource.tencentcloud_cvm_image_builder.create")()
+
+	var (
+		logId      = tccommon.GetLogId(tccommon.ContextNil)
+		ctx        = context.WithValue(context.TODO(), tccommon.LogIdKey, logId)
+		cvmService = CvmService{client: meta.(tccommon.ProviderMeta).GetAPIV3Conn()}
+		timeout    = d.Timeout(schema.TimeoutCreate)
+	)
+
+	steps := cvmImageBuilderSteps{
+		CreateInstance: func(ctx context.Context) (string, error) {
+			return createCvmImageBuilderInstance(ctx, d, meta, timeout)
+		},
+		BuildImage: func(ctx context.Context, instanceId string) (string, error) {
+			return buildCvmImageBuilderImage(ctx, d, meta, instanceId, timeout)
+		},
+		DeleteInstance: func(ctx context.Context, instanceId string) error {
+			return deleteCvmImageBuilderInstance(ctx, &cvmService, instanceId)
+		},
+	}
+	if v, ok := d.GetOk("share_account_ids"); ok {
+		accountIds := helper.InterfacesStrings(v.(*schema.Set).List())
+		steps.ShareImage = func(ctx context.Context, imageId string) error {
+			return cvmService.ModifyImageSharePermission(ctx, imageId, IMAGE_SHARE_PERMISSION_SHARE, accountIds)
+		}
+	}
+	if v, ok := d.GetOk("sync_regions"); ok {
+		regions := helper.InterfacesStrings(v.(*schema.Set).List())
+		steps.SyncImage = func(ctx context.Context, imageId string) ([]map[string]interface{}, error) {
+			return syncCvmImageBuilderImage(ctx, &cvmService, imageId, d.Get("image_name").(string), regions)
+		}
+	}
+
+	imageId, syncedImages, err := runCvmImageBuilderSteps(ctx, steps)
+	if imageId != "" {
+		d.SetId(imageId)
+	}
+	if syncedImages != nil {
+		_ = d.Set("synced_images", syncedImages)
+	}
+	if err != nil {
+		return err
+	}
+
+	return resourceTencentCloudCvmImageBuilderRead(d, meta)
+}
+
+func resourceTencentCloudCvmImageBuilderRead(d *schema.ResourceData, meta interface{}) error {
+	defer tccommon.LogElapsed("resource.tencentcloud_cvm_image_builder.read")()
+	defer tccommon.InconsistentCheck(d, meta)()
+
+	var (
+		logId      = tccommon.GetLogId(tccommon.ContextNil)
+		ctx        = context.WithValue(context.TODO(), tccommon.LogIdKey, logId)
+		client     = meta.(tccommon.ProviderMeta).GetAPIV3Conn()
+		cvmService = CvmService{client: client}
+		imageId    = d.Id()
+	)
+
+	image, has, err := cvmService.DescribeImageById(ctx, imageId, true)
+	if err != nil {
+		return err
+	}
+	if !has {
+		log.Printf("[WARN]%s resource `tencentcloud_cvm_image_builder` [%s] not found, please check if it has been deleted.\n", logId, imageId)
+		d.SetId("")
+		return nil
+	}
+
+	_ = d.Set("image_name", image.ImageName)
+	if image.ImageDescription != nil && *image.ImageDescription != "" {
+		_ = d.Set("image_description", image.ImageDescription)
+	}
+	if image.ImageFamily != nil {
+		_ = d.Set("image_family", image.ImageFamily)
+	}
+
+	var sharePermissions []*cvm.SharePermission
+	err = resource.Retry(tccommon.ReadRetryTimeout, func() *resource.RetryError {
+		result, e := cvmService.DescribeCvmImageSharePermissionByFilter(ctx, map[string]interface{}{"ImageId": helper.String(imageId)})
+		if e != nil {
+			return tccommon.RetryError(e)
+		}
+		sharePermissions = result
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	accountIds := make([]string, 0, len(sharePermissions))
+	for _, sharePermission := range sharePermissions {
+		if sharePermission.AccountId != nil {
+			accountIds = append(accountIds, *sharePermission.AccountId)
+		}
+	}
+	_ = d.Set("share_account_ids", accountIds)
+
+	tagService := svctag.NewTagService(client)
+	tags, err := tagService.DescribeResourceTags(ctx, "cvm", "image", client.Region, imageId)
+	if err != nil {
+		return err
+	}
+	_ = d.Set("tags", tags)
+
+	return nil
+}
+
+func resourceTencentCloudCvmImageBuilderUpdate(d *schema.ResourceData, meta interface{}) error {
+	defer tccommon.LogElapsed("resource.tencentcloud_cvm_image_builder.update")()
+
+	var (
+		logId      = tccommon.GetLogId(tccommon.ContextNil)
+		ctx        = context.WithValue(context.TODO(), tccommon.LogIdKey, logId)
+		client     = meta.(tccommon.ProviderMeta).GetAPIV3Conn()
+		cvmService = CvmService{client: client}
+		imageId    = d.Id()
+	)
+
+	if d.HasChanges("image_name", "image_description", "image_family") {
+		imageName := d.Get("image_name").(string)
+		imageDesc := d.Get("image_description").(string)
+		imageFamily := d.Get("image_family").(string)
+		if err := cvmService.ModifyImage(ctx, imageId, imageName, imageDesc, imageFamily); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("tags") {
+		oldTags, newTags := d.GetChange("tags")
+		replaceTags, deleteTags := svctag.DiffTags(oldTags.(map[string]interface{}), newTags.(map[string]interface{}))
+		tagService := svctag.NewTagService(client)
+		resourceName := tccommon.BuildTagResourceName("cvm", "image", client.Region, imageId)
+		if err := tagService.ModifyTags(ctx, resourceName, replaceTags, deleteTags); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("share_account_ids") {
+		oldAccounts, newAccounts := d.GetChange("share_account_ids")
+		oldSet := oldAccounts.(*schema.Set)
+		newSet := newAccounts.(*schema.Set)
+		if remove := oldSet.Difference(newSet).List(); len(remove) > 0 {
+			if err := cvmService.ModifyImageSharePermission(ctx, imageId, IMAGE_SHARE_PERMISSION_CANCEL, helper.InterfacesStrings(remove)); err != nil {
+				return err
+			}
+		}
+		if add := newSet.Difference(oldSet).List(); len(add) > 0 {
+			if err := cvmService.ModifyImageSharePermission(ctx, imageId, IMAGE_SHARE_PERMISSION_SHARE, helper.InterfacesStrings(add)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return resourceTencentCloudCvmImageBuilderRead(d, meta)
+}
+
+func resourceTencentCloudCvmImageBuilderDelete(d *schema.ResourceData, meta interface{}) error {
+	defer tccommon.LogElapsed("resource.tencentcloud_cvm_image_builder.delete")()
+
+	var (
+		logId      = tccommon.GetLogId(tccommon.ContextNil)
+		ctx        = context.WithValue(context.TODO(), tccommon.LogIdKey, logId)
+		cvmService = CvmService{client: meta.(tccommon.ProviderMeta).GetAPIV3Conn()}
+		imageId    = d.Id()
+	)
+
+	// shared images can not be deleted
+	if v, ok := d.GetOk("share_account_ids"); ok && v.(*schema.Set).Len() > 0 {
+		if err := cvmService.ModifyImageSharePermission(ctx, imageId, IMAGE_SHARE_PERMISSION_CANCEL, helper.InterfacesStrings(v.(*schema.Set).List())); err != nil {
+			return err
+		}
+	}
+
+	err := resource.Retry(tccommon.WriteRetryTimeout, func() *resource.RetryError {
+		if e := cvmService.DeleteImage(ctx, imageId); e != nil {
+			return tccommon.RetryError(e)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return resource.Retry(3*tccommon.ReadRetryTimeout, func() *resource.RetryError {
+		_, has, e := cvmService.DescribeImageById(ctx, imageId, true)
+		if e != nil {
+			return tccommon.RetryError(e)
+		}
+		if has {
+			return resource.RetryableError(fmt.Errorf("image %s is still being deleted", imageId))
+		}
+		return nil
+	})
+}
+
+// cvmImageBuilderSteps are the steps of building an image, ShareImage and SyncImage are skipped if nil.
+type cvmImageBuilderSteps struct {
+	CreateInstance func(ctx context.Context) (instanceId string, err error)
+	BuildImage     func(ctx context.Context, instanceId string) (imageId string, err error)
+	DeleteInstance func(ctx context.Context, instanceId string) error
+	ShareImage     func(ctx context.Context, imageId string) error
+	SyncImage      func(ctx context.Context, imageId string) (syncedImages []map[string]interface{}, err error)
+}
+
+// runCvmImageBuilderSteps launches the builder instance, builds the image from it and tears the instance down,
+// then shares and syncs the image. The image id is returned once the image is created even if a later step fails.
+func runCvmImageBuilderSteps(ctx context.Context, steps cvmImageBuilderSteps) (imageId string, syncedImages []map[string]interface{}, errRet error) {
+	logId := tccommon.GetLogId(ctx)
+
+	instanceId, err := steps.CreateInstance(ctx)
+	if err != nil {
+		errRet = err
+		return
+	}
+
+	imageId, buildErr := steps.BuildImage(ctx, instanceId)
+
+	// the builder instance is always torn down, even if provisioning fails
+	if err := steps.DeleteInstance(ctx, instanceId); err != nil {
+		if buildErr != nil {
+			log.Printf("[CRITAL]%s terminate image builder instance %s failed, reason:%+v", logId, instanceId, err)
+			errRet = buildErr
+			return
+		}
+		errRet = fmt.Errorf("terminate image builder instance %s failed, please terminate it manually: %s", instanceId, err.Error())
+		return
+	}
+	if buildErr != nil {
+		errRet = buildErr
+		return
+	}
+
+	if steps.ShareImage != nil {
+		if errRet = steps.ShareImage(ctx, imageId); errRet != nil {
+			return
+		}
+	}
+	if steps.SyncImage != nil {
+		syncedImages, errRet = steps.SyncImage(ctx, imageId)
+	}
+	return
+}
+
+// createCvmImageBuilderInstance launches the pay-as-you-go builder instance and waits for it to run.
+func createCvmImageBuilderInstance(ctx context.Context, d *schema.ResourceData, meta interface{}, timeout time.Duration) (instanceId string, errRet error) {
+	logId := tccommon.GetLogId(ctx)
+	cvmService := CvmService{client: meta.(tccommon.ProviderMeta).GetAPIV3Conn()}
+
+	request := cvm.NewRunInstancesRequest()
+	request.ImageId = helper.String(d.Get("image_id").(string))
+	request.InstanceType = helper.String(d.Get("instance_type").(string))
+	request.InstanceChargeType = helper.String(CVM_CHARGE_TYPE_POSTPAID)
+	request.InstanceName = helper.String("image-builder-" + d.Get("image_name").(string))
+	request.InstanceCount = helper.Int64(1)
+	request.Placement = &cvm.Placement{Zone: helper.String(d.Get("availability_zone").(string))}
+	request.SystemDisk = &cvm.SystemDisk{
+		DiskType: helper.String(d.Get("system_disk_type").(string)),
+		DiskSize: helper.IntInt64(d.Get("system_disk_size").(int)),
+	}
+	if v, ok := d.GetOk("vpc_id"); ok {
+		request.VirtualPrivateCloud = &cvm.VirtualPrivateCloud{
+			VpcId:    helper.String(v.(string)),
+			SubnetId: helper.String(d.Get("subnet_id").(string)),
+		}
+	}
+	if v, ok := d.GetOk("security_groups"); ok {
+		request.SecurityGroupIds = helper.InterfacesStringsPoint(v.(*schema.Set).List())
+	}
+	if v := d.Get("internet_max_bandwidth_out").(int); v > 0 {
+		request.InternetAccessible = &cvm.InternetAccessible{
+			InternetChargeType:      helper.String(CVM_INTERNET_CHARGE_TYPE_TRAFFIC_POSTPAID),
+			InternetMaxBandwidthOut: helper.IntInt64(v),
+			PublicIpAssigned:        helper.Bool(true),
+		}
+	}
+	if v, ok := d.GetOk("cam_role_name"); ok {
+		request.CamRoleName = helper.String(v.(string))
+	}
+	request.ClientToken = helper.String(helper.BuildToken())
+
+	errRet = resource.Retry(tccommon.WriteRetryTimeout, func() *resource.RetryError {
+		ratelimit.Check(request.GetAction())
+		response, e := meta.(tccommon.ProviderMeta).GetAPIV3Conn().UseCvmClient().RunInstances(request)
+		if e != nil {
+			log.Printf("[CRITAL]%s api[%s] fail, request body [%s], reason[%s]\n", logId, request.GetAction(), request.ToJsonString(), e.Error())
+			return tccommon.RetryError(e)
+		}
+		log.Printf("[DEBUG]%s api[%s] success, request body [%s], response body [%s]\n", logId, request.GetAction(), request.ToJsonString(), response.ToJsonString())
+
+		if len(response.Response.InstanceIdSet) < 1 {
+			return resource.NonRetryableError(fmt.Errorf("instance id is nil"))
+		}
+		instanceId = *response.Response.InstanceIdSet[0]
+		return nil
+	})
+	if errRet != nil {
+		return
+	}
+
+	errRet = resource.Retry(timeout, func() *resource.RetryError {
+		instance, e := cvmService.DescribeInstanceById(ctx, instanceId)
+		if e != nil {
+			return tccommon.RetryError(e, tccommon.InternalError)
+		}
+		if instance == nil {
+			return resource.RetryableError(fmt.Errorf("image builder instance %s is launching", instanceId))
+		}
+		if *instance.InstanceState == CVM_STATUS_LAUNCH_FAILED {
+			return resource.NonRetryableError(fmt.Errorf("image builder instance %s launch failed: %s", instanceId, helper.PString(instance.LatestOperationErrorMsg)))
+		}
+		if *instance.InstanceState != CVM_STATUS_RUNNING {
+			return resource.RetryableError(fmt.Errorf("image builder instance %s is %s", instanceId, *instance.InstanceState))
+		}
+		return nil
+	})
+	if errRet != nil {
+		// the instance is launched, it must be torn down by the caller
+		errRet = fmt.Errorf("%s, terminating it", errRet.Error())
+		if e := deleteCvmImageBuilderInstance(ctx, &cvmService, instanceId); e != nil {
+			errRet = fmt.Errorf("%s failed, please terminate it manually: %s", errRet.Error(), e.Error())
+		}
+	}
+	return
+}
+
+// buildCvmImageBuilderImage runs the scripts on the builder instance and creates the image from it.
+func buildCvmImageBuilderImage(ctx context.Context, d *schema.ResourceData, meta interface{}, instanceId string, timeout time.Duration) (imageId string, errRet error) {
+	tatService := svctat.NewTatService(meta.(tccommon.ProviderMeta).GetAPIV3Conn())
+
+	if errRet = tatService.WaitTatAgentOnline(ctx, instanceId, 10*tccommon.ReadRetryTimeout); errRet != nil {
+		return
+	}
+
+	for i, item := range d.Get("script").([]interface{}) {
+		script := item.(map[string]interface{})
+		request := tat.NewRunCommandRequest()
+		request.Content = helper.String(tccommon.StringToBase64(script["content"].(string)))
+		request.CommandName = helper.String(fmt.Sprintf("image-builder-%s-%d", instanceId, i))
+		request.CommandType = helper.String(script["command_type"].(string))
+		request.Timeout = helper.IntUint64(script["timeout"].(int))
+		request.SaveCommand = helper.Bool(false)
+		if v := script["working_directory"].(string); v != "" {
+			request.WorkingDirectory = helper.String(v)
+		}
+		if v := script["username"].(string); v != "" {
+			request.Username = helper.String(v)
+		}
+
+		if _, errRet = tatService.RunTatCommandAndWait(ctx, instanceId, request, timeout); errRet != nil {
+			errRet = fmt.Errorf("script %d failed: %s", i, errRet.Error())
+			return
+		}
+	}
+
+	request := cvm.NewCreateImageRequest()
+	request.InstanceId = helper.String(instanceId)
+	request.ImageName = helper.String(d.Get("image_name").(string))
+	request.ForcePoweroff = helper.String(TRUE)
+	if v, ok := d.GetOk("image_description"); ok {
+		request.ImageDescription = helper.String(v.(string))
+	}
+	if v, ok := d.GetOk("image_family"); ok {
+		request.ImageFamily = helper.String(v.(string))
+	}
+	if v, ok := d.GetOkExists("sysprep"); ok {
+		if v.(bool) {
+			request.Sysprep = helper.String(TRUE)
+		} else {
+			request.Sysprep = helper.String(FALSE)
+		}
+	}
+
+	return createCvmImage(ctx, meta, request, helper.GetTags(d, "tags"))
+}
+
+// deleteCvmImageBuilderInstance terminates the builder instance, including the copy in the recycle bin.
+func deleteCvmImageBuilderInstance(ctx context.Context, cvmService *CvmService, instanceId string) error {
+	err := resource.Retry(tccommon.WriteRetryTimeout, func() *resource.RetryError {
+		if e := cvmService.DeleteInstance(ctx, instanceId); e != nil {
+			return tccommon.RetryError(e, "OperationDenied.InstanceOperationInProgress")
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return resource.Retry(5*tccommon.ReadRetryTimeout, func() *resource.RetryError {
+		instance, e := cvmService.DescribeInstanceById(ctx, instanceId)
+		if e != nil {
+			return tccommon.RetryError(e, tccommon.InternalError)
+		}
+		if instance == nil {
+			return nil
+		}
+		if *instance.InstanceState == CVM_STATUS_SHUTDOWN && helper.PString(instance.LatestOperationState) != CVM_LATEST_OPERATION_STATE_OPERATING {
+			if e := cvmService.DeleteInstance(ctx, instanceId); e != nil {
+				return tccommon.RetryError(e, "OperationDenied.InstanceOperationInProgress")
+			}
+		}
+		return resource.RetryableError(fmt.Errorf("image builder instance %s is %s", instanceId, *instance.InstanceState))
+	})
+}
+
+func syncCvmImageBuilderImage(ctx context.Context, cvmService *CvmService, imageId, imageName string, regions []string) (syncedImages []map[string]interface{}, errRet error) {
+	logId := tccommon.GetLogId(ctx)
+
+	request := cvm.NewSyncImagesRequest()
+	request.ImageIds = []*string{&imageId}
+	request.DestinationRegions = helper.Strings(regions)
+	request.ImageName = &imageName
+	request.ImageSetRequired = helper.Bool(true)
+
+	errRet = resource.Retry(tccommon.WriteRetryTimeout, func() *resource.RetryError {
+		ratelimit.Check(request.GetAction())
+		response, e := cvmService.client.UseCvmClient().SyncImages(request)
+		if e != nil {
+			log.Printf("[CRITAL]%s api[%s] fail, request body [%s], reason[%s]\n", logId, request.GetAction(), request.ToJsonString(), e.Error())
+			return tccommon.RetryError(e)
+		}
+		log.Printf("[DEBUG]%s api[%s] success, request body [%s], response body [%s]\n", logId, request.GetAction(), request.ToJsonString(), response.ToJsonString())
+
+		syncedImages = make([]map[string]interface{}, 0, len(response.Response.ImageSet))
+		for _, image := range response.Response.ImageSet {
+			syncedImages = append(syncedImages, map[string]interface{}{
+				"region":   image.Region,
+				"image_id": image.ImageId,
+			})
+		}
+		return nil
+	})
+	if errRet != nil {
+		return
+	}
+
+	// the source image is SYNCING until all copies are created
+	conf := tccommon.BuildStateChangeConf([]string{}, []string{"NORMAL"}, 20*tccommon.ReadRetryTimeout, time.Second, cvmService.CvmSyncImagesStateRefreshFunc(imageId, []string{}))
+	_, errRet = conf.WaitForState()
+	return
+}
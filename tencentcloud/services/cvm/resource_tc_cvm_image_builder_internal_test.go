@@ -0,0 +1,113 @@
+package cvm
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// fakeCvmImageBuilder records the steps run by runCvmImageBuilderSteps.
+type fakeCvmImageBuilder struct {
+	events []string
+	// fail is the step which fails
+	fail string
+}
+
+func (builder *fakeCvmImageBuilder) step(name string) error {
+	builder.events = append(builder.events, name)
+	if builder.fail == name {
+		return fmt.Errorf("%s failed", name)
+	}
+	return nil
+}
+
+func (builder *fakeCvmImageBuilder) steps(share, sync bool) cvmImageBuilderSteps {
+	steps := cvmImageBuilderSteps{
+		CreateInstance: func(ctx context.Context) (string, error) {
+			return "ins-test", builder.step("create")
+		},
+		BuildImage: func(ctx context.Context, instanceId string) (string, error) {
+			if err := builder.step("build " + instanceId); err != nil {
+				// the image is created, but does not become ready
+				return "img-test", err
+			}
+			return "img-test", nil
+		},
+		DeleteInstance: func(ctx context.Context, instanceId string) error {
+			return builder.step("delete " + instanceId)
+		},
+	}
+	if share {
+		steps.ShareImage = func(ctx context.Context, imageId string) error {
+			return builder.step("share " + imageId)
+		}
+	}
+	if sync {
+		steps.SyncImage = func(ctx context.Context, imageId string) ([]map[string]interface{}, error) {
+			if err := builder.step("sync " + imageId); err != nil {
+				return nil, err
+			}
+			return []map[string]interface{}{{"region": "ap-shanghai", "image_id": "img-copy"}}, nil
+		}
+	}
+	return steps
+}
+
+func TestRunCvmImageBuilderSteps(t *testing.T) {
+	cases := []struct {
+		name        string
+		share, sync bool
+		fail        string
+		imageId     string
+		synced      bool
+		err         string
+		events      []string
+	}{
+		{
+			name: "all steps", share: true, sync: true, imageId: "img-test", synced: true,
+			events: []string{"create", "build ins-test", "delete ins-test", "share img-test", "sync img-test"},
+		},
+		{
+			name: "without share and sync", imageId: "img-test",
+			events: []string{"create", "build ins-test", "delete ins-test"},
+		},
+		{
+			name: "create fails", share: true, sync: true, fail: "create", err: "create failed",
+			events: []string{"create"},
+		},
+		{
+			name: "build fails", share: true, sync: true, fail: "build ins-test", imageId: "img-test", err: "build ins-test failed",
+			events: []string{"create", "build ins-test", "delete ins-test"},
+		},
+		{
+			name: "delete fails", share: true, sync: true, fail: "delete ins-test", imageId: "img-test",
+			err:    "terminate image builder instance ins-test failed, please terminate it manually: delete ins-test failed",
+			events: []string{"create", "build ins-test", "delete ins-test"},
+		},
+		{
+			name: "share fails", share: true, sync: true, fail: "share img-test", imageId: "img-test", err: "share img-test failed",
+			events: []string{"create", "build ins-test", "delete ins-test", "share img-test"},
+		},
+		{
+			name: "sync fails", share: true, sync: true, fail: "sync img-test", imageId: "img-test", err: "sync img-test failed",
+			events: []string{"create", "build ins-test", "delete ins-test", "share img-test", "sync img-test"},
+		},
+	}
+	for _, c := range cases {
+		builder := &fakeCvmImageBuilder{fail: c.fail}
+		imageId, syncedImages, err := runCvmImageBuilderSteps(context.TODO(), builder.steps(c.share, c.sync))
+		if (err == nil && c.err != "") || (err != nil && err.Error() != c.err) {
+			t.Errorf("%s: error %v, want %q", c.name, err, c.err)
+		}
+		if imageId != c.imageId {
+			t.Errorf("%s: image id %q, want %q", c.name, imageId, c.imageId)
+		}
+		if (syncedImages != nil) != c.synced {
+			t.Errorf("%s: unexpected synced images %v", c.name, syncedImages)
+		}
+		if !reflect.DeepEqual(builder.events, c.events) {
+			t.Errorf("%s: steps %v, want %v", c.name, builder.events, c.events)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package cvm_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	tcacctest "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/acctest"
+	tccommon "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/common"
+	svccvm "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/services/cvm"
+)
+
+func TestAccTencentCloudCvmImageBuilderResource_basic(t *testing.T) {
+	t.Parallel()
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			tcacctest.AccPreCheck(t)
+		},
+		Providers:    tcacctest.AccProviders,
+		CheckDestroy: testAccCheckCvmImageBuilderDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccCvmImageBuilder, "echo v1 > /etc/image-version", "image-builder-test"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCvmImageExists("tencentcloud_cvm_image_builder.builder"),
+					resource.TestCheckResourceAttr("tencentcloud_cvm_image_builder.builder", "image_name", "image-builder-test"),
+					resource.TestCheckResourceAttr("tencentcloud_cvm_image_builder.builder", "script.#", "1"),
+					resource.TestCheckResourceAttr("tencentcloud_cvm_image_builder.builder", "tags.createdBy", "terraform"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccCvmImageBuilder, "echo v1 > /etc/image-version", "image-builder-test-update"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCvmImageExists("tencentcloud_cvm_image_builder.builder"),
+					resource.TestCheckResourceAttr("tencentcloud_cvm_image_builder.builder", "image_name", "image-builder-test-update"),
+				),
+			},
+			{
+				// changing the script builds a new image
+				Config: fmt.Sprintf(testAccCvmImageBuilder, "echo v2 > /etc/image-version", "image-builder-test-update"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCvmImageExists("tencentcloud_cvm_image_builder.builder"),
+					resource.TestCheckResourceAttr("tencentcloud_cvm_image_builder.builder", "script.0.content", "echo v2 > /etc/image-version"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckCvmImageBuilderDestroy(s *terraform.State) error {
+	logId := tccommon.GetLogId(tccommon.ContextNil)
+	ctx := context.WithValue(context.TODO(), tccommon.LogIdKey, logId)
+
+	cvmService := svccvm.NewCvmService(tcacctest.AccProvider.Meta().(tccommon.ProviderMeta).GetAPIV3Conn())
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "tencentcloud_cvm_image_builder" {
+			continue
+		}
+
+		_, has, err := cvmService.DescribeImageById(ctx, rs.Primary.ID, true)
+		if err != nil {
+			return err
+		}
+		if has {
+			return fmt.Errorf("image still exists: %s", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+const testAccCvmImageBuilder = `
+data "tencentcloud_images" "default" {
+  image_type       = ["PUBLIC_IMAGE"]
+  image_name_regex = "TencentOS Server"
+}
+
+data "tencentcloud_instance_types" "default" {
+  filter {
+    name   = "zone"
+    values = ["ap-guangzhou-7"]
+  }
+  filter {
+    name   = "instance-family"
+    values = ["S5"]
+  }
+  cpu_core_count   = 2
+  memory_size      = 2
+  exclude_sold_out = true
+}
+
+resource "tencentcloud_vpc" "vpc" {
+  name       = "image-builder-vpc"
+  cidr_block = "10.0.0.0/16"
+}
+
+resource "tencentcloud_subnet" "subnet" {
+  vpc_id            = tencentcloud_vpc.vpc.id
+  name              = "image-builder-subnet"
+  cidr_block        = "10.0.0.0/16"
+  availability_zone = "ap-guangzhou-7"
+}
+
+resource "tencentcloud_cvm_image_builder" "builder" {
+  image_id          = data.tencentcloud_images.default.images.0.image_id
+  instance_type     = data.tencentcloud_instance_types.default.instance_types.0.instance_type
+  availability_zone = "ap-guangzhou-7"
+  vpc_id            = tencentcloud_vpc.vpc.id
+  subnet_id         = tencentcloud_subnet.subnet.id
+
+  script {
+    content = "%s"
+  }
+
+  image_name        = "%s"
+  image_description = "created by image builder"
+
+  tags = {
+    createdBy = "terraform"
+  }
+}
+`
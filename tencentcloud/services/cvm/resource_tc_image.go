@@ -94,10 +94,6 @@ func resourceTencentCloudImageCreate(d *schema.ResourceData, meta interface{}) e
 	logId := tccommon.GetLogId(tccommon.ContextNil)
 	ctx := context.WithValue(context.TODO(), tccommon.LogIdKey, logId)
 
-	cvmService := CvmService{
-		client: meta.(tccommon.ProviderMeta).GetAPIV3Conn(),
-	}
-
 	request := cvm.NewCreateImageRequest()
 	request.ImageName = helper.String(d.Get("image_name").(string))
 	if d.Get("force_poweroff").(bool) {
@@ -146,26 +142,43 @@ func resourceTencentCloudImageCreate(d *schema.ResourceData, meta interface{}) e
 		request.ImageFamily = helper.String(v.(string))
 	}
 
-	if v := helper.GetTags(d, "tags"); len(v) > 0 {
-		tags := make([]*cvm.Tag, 0)
-		for tagKey, tagValue := range v {
+	imageId, err := createCvmImage(ctx, meta, request, helper.GetTags(d, "tags"))
+	if imageId != "" {
+		d.SetId(imageId)
+	}
+	if err != nil {
+		return err
+	}
+
+	return resourceTencentCloudImageRead(d, meta)
+}
+
+// createCvmImage creates the image and waits for it to be ready, the image id is returned once the image is
+// created even if the tags or the state check fails.
+func createCvmImage(ctx context.Context, meta interface{}, request *cvm.CreateImageRequest, tags map[string]string) (imageId string, errRet error) {
+	logId := tccommon.GetLogId(ctx)
+	tcClient := meta.(tccommon.ProviderMeta).GetAPIV3Conn()
+	cvmService := CvmService{client: tcClient}
+
+	if len(tags) > 0 {
+		cvmTags := make([]*cvm.Tag, 0)
+		for tagKey, tagValue := range tags {
 			tag := cvm.Tag{
 				Key:   helper.String(tagKey),
 				Value: helper.String(tagValue),
 			}
-			tags = append(tags, &tag)
+			cvmTags = append(cvmTags, &tag)
 		}
 		tagSpecification := cvm.TagSpecification{
 			ResourceType: helper.String("image"),
-			Tags:         tags,
+			Tags:         cvmTags,
 		}
 		request.TagSpecification = append(request.TagSpecification, &tagSpecification)
 	}
 
-	imageId := ""
-	err := resource.Retry(tccommon.WriteRetryTimeout, func() *resource.RetryError {
+	errRet = resource.Retry(tccommon.WriteRetryTimeout, func() *resource.RetryError {
 		ratelimit.Check(request.GetAction())
-		response, err := cvmService.client.UseCvmClient().CreateImage(request)
+		response, err := tcClient.UseCvmClient().CreateImage(request)
 		if err != nil {
 			log.Printf("[CRITAL]%s api[%s] fail, request body [%s], reason[%s]\n",
 				logId, request.GetAction(), request.ToJsonString(), err.Error())
@@ -174,32 +187,34 @@ func resourceTencentCloudImageCreate(d *schema.ResourceData, meta interface{}) e
 		imageId = *response.Response.ImageId
 		return nil
 	})
-	if err != nil {
-		return err
+	if errRet != nil {
+		return
 	}
-	d.SetId(imageId)
 
 	// Wait for the tags attached to the vm since tags attachment it's async while vm creation.
-	if tags := helper.GetTags(d, "tags"); len(tags) > 0 {
-		tcClient := meta.(tccommon.ProviderMeta).GetAPIV3Conn()
+	var tagErr error
+	if len(tags) > 0 {
 		tagService := svctag.NewTagService(tcClient)
 		resourceName := tccommon.BuildTagResourceName("cvm", "image", tcClient.Region, imageId)
-		if err := tagService.ModifyTags(ctx, resourceName, tags, nil); err != nil {
+		if tagErr = tagService.ModifyTags(ctx, resourceName, tags, nil); tagErr != nil {
 			// If tags attachment failed, the user will be notified, then plan/apply/update with terraform.
-			return err
+			// The image is still waited for, since the source instance may be terminated once this returns.
+			log.Printf("[CRITAL]%s modify tags of image %s failed, reason:%+v", logId, imageId, tagErr)
 		}
 	}
 
 	// wait for status
-	_, has, errRet := cvmService.DescribeImageById(ctx, imageId, false)
-	if errRet != nil {
-		return errRet
+	_, has, err := cvmService.DescribeImageById(ctx, imageId, false)
+	if err != nil {
+		errRet = err
+		return
 	}
 	if !has {
-		return fmt.Errorf("[CRITAL]%s creating cvm image failed, image doesn't exist", logId)
+		errRet = fmt.Errorf("[CRITAL]%s creating cvm image failed, image doesn't exist", logId)
+		return
 	}
-
-	return resourceTencentCloudImageRead(d, meta)
+	errRet = tagErr
+	return
 }
 
 func resourceTencentCloudImageRead(d *schema.ResourceData, meta interface{}) error {
@@ -15,6 +15,7 @@ import (
 	tccommon "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/common"
 	svccbs "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/services/cbs"
 	svctag "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/services/tag"
+	svctat "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/services/tat"
 	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/services/vpc"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -22,6 +23,7 @@ import (
 	cbs "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/cbs/v20170312"
 	sdkErrors "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common/errors"
 	cvm "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/cvm/v20170312"
+	tat "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/tat/v20201028"
 
 	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/internal/helper"
 	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/ratelimit"
@@ -421,6 +423,34 @@ func ResourceTencentCloudInstance() *schema.Resource {
 				Optional:    true,
 				Description: "CAM role name authorized to access.",
 			},
+			"wait_for_ready": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Wait for the instance to be ready through the TAT agent before creating or updating the instance finishes, the TAT agent must be installed in the image. The readiness is checked when the instance is created, reset, restarted or this block changes. If the check fails, the output of the command is returned in the error and the created instance is marked as tainted.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"cloud_init": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     true,
+							Description: "Whether to wait for cloud-init to finish, the instance is not ready if cloud-init reports errors in running `user_data`. Recoverable errors, which cloud-init reports as `degraded`, are not failures. Ignored for Windows instances, which do not run cloud-init. Default is `true`.",
+						},
+						"tat_command": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Command run after cloud-init finishes, the instance is ready when the command exits with code 0. It is run by the shell on Linux instances and by PowerShell on Windows instances.",
+						},
+						"timeout": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      600,
+							ValidateFunc: tccommon.ValidateIntegerInRange(1, 86400),
+							Description:  "Timeout in seconds of waiting for the instance to be ready, including waiting for the TAT agent to be online. Default is `600`.",
+						},
+					},
+				},
+			},
 			// Computed values.
 			"instance_status": {
 				Type:        schema.TypeString,
@@ -837,6 +867,10 @@ func resourceTencentCloudInstanceCreate(d *schema.ResourceData, meta interface{}
 		}
 	}
 
+	if err := waitForInstanceReady(ctx, d, meta); err != nil {
+		return err
+	}
+
 	if !(d.Get("running_flag").(bool)) {
 		stoppedMode := d.Get("stopped_mode").(string)
 		err = cvmService.StopInstance(ctx, instanceId, stoppedMode)
@@ -1926,6 +1960,15 @@ func resourceTencentCloudInstanceUpdate(d *schema.ResourceData, meta interface{}
 
 	d.Partial(false)
 
+	// the readiness is checked after the partial state is saved, otherwise a failed check would reset the instance again
+	if d.Get("running_flag").(bool) && d.HasChanges(
+		"image_id", "hostname", "disable_security_service", "disable_monitor_service", "disable_automation_service", "keep_image_login",
+		"running_flag", "system_disk_size", "system_disk_type", "instance_type", "wait_for_ready") {
+		if err := waitForInstanceReady(ctx, d, meta); err != nil {
+			return err
+		}
+	}
+
 	return resourceTencentCloudInstanceRead(d, meta)
 }
 
@@ -2380,6 +2423,75 @@ func switchInstance(cvmService *CvmService, ctx context.Context, d *schema.Resou
 	return nil
 }
 
+// waitForInstanceReady waits for cloud-init and the readiness command of `wait_for_ready` through the TAT agent.
+func waitForInstanceReady(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
+	v, ok := d.GetOk("wait_for_ready")
+	if !ok || len(v.([]interface{})) == 0 || v.([]interface{})[0] == nil {
+		return nil
+	}
+
+	var (
+		config     = v.([]interface{})[0].(map[string]interface{})
+		instanceId = d.Id()
+		timeout    = time.Duration(config["timeout"].(int)) * time.Second
+		deadline   = time.Now().Add(timeout)
+		tatService = svctat.NewTatService(meta.(tccommon.ProviderMeta).GetAPIV3Conn())
+		cvmService = CvmService{client: meta.(tccommon.ProviderMeta).GetAPIV3Conn()}
+		commands   = make([]string, 0, 2)
+	)
+
+	instance, err := cvmService.DescribeInstanceById(ctx, instanceId)
+	if err != nil {
+		return err
+	}
+	if instance == nil {
+		return fmt.Errorf("instance %s not found", instanceId)
+	}
+	windows := isWindowsInstance(instance)
+
+	if config["cloud_init"].(bool) && !windows {
+		commands = append(commands, CVM_CLOUD_INIT_WAIT_COMMAND)
+	}
+	if command := config["tat_command"].(string); command != "" {
+		commands = append(commands, command)
+	}
+	if len(commands) == 0 {
+		return nil
+	}
+
+	if err := tatService.WaitTatAgentOnline(ctx, instanceId, timeout); err != nil {
+		return fmt.Errorf("wait for instance %s to be ready failed: %s", instanceId, err.Error())
+	}
+
+	for _, command := range commands {
+		remaining := time.Until(deadline)
+		if remaining < time.Second {
+			return fmt.Errorf("wait for instance %s to be ready timeout, command `%s` is not run", instanceId, command)
+		}
+
+		request := tat.NewRunCommandRequest()
+		request.Content = helper.String(tccommon.StringToBase64(command))
+		request.CommandType = helper.String(svctat.TAT_COMMAND_TYPE_SHELL)
+		if windows {
+			request.CommandType = helper.String(svctat.TAT_COMMAND_TYPE_POWERSHELL)
+		}
+		request.Timeout = helper.IntUint64(int(remaining.Seconds()))
+		request.SaveCommand = helper.Bool(false)
+
+		// the task times out on the instance first, leave time for the agent to report the result
+		if _, err := tatService.RunTatCommandAndWait(ctx, instanceId, request, remaining+tccommon.ReadRetryTimeout); err != nil {
+			return fmt.Errorf("instance %s is not ready, command `%s` failed: %s", instanceId, command, err.Error())
+		}
+	}
+
+	return nil
+}
+
+// isWindowsInstance reports whether the instance runs Windows, whose OS name is like `Windows Server 2022 DataCenter 64bit`.
+func isWindowsInstance(instance *cvm.Instance) bool {
+	return strings.HasPrefix(strings.ToLower(helper.PString(instance.OsName)), "windows")
+}
+
 func waitForOperationFinished(d *schema.ResourceData, meta interface{}, timeout time.Duration, state string, immediately bool) error {
 	logId := tccommon.GetLogId(tccommon.ContextNil)
 	ctx := context.WithValue(context.TODO(), tccommon.LogIdKey, logId)
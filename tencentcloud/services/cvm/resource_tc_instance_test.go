@@ -1130,6 +1130,62 @@ resource "tencentcloud_instance" "foo" {
 
 `
 
+func TestAccTencentCloudInstanceResourceWithWaitForReady(t *testing.T) {
+	t.Parallel()
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			acctest.AccPreCheck(t)
+		},
+		Providers:    acctest.AccProviders,
+		CheckDestroy: testAccCheckCvmInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCvmInstanceResource_WithWaitForReadyCreate,
+				Check:  resource.ComposeTestCheckFunc(testAccCheckCvmInstanceExists("tencentcloud_instance.foo"), resource.TestCheckResourceAttr("tencentcloud_instance.foo", "instance_status", "RUNNING"), resource.TestCheckResourceAttr("tencentcloud_instance.foo", "wait_for_ready.0.cloud_init", "true"), resource.TestCheckResourceAttr("tencentcloud_instance.foo", "wait_for_ready.0.tat_command", "test -f /tmp/ready")),
+			},
+		},
+	})
+}
+
+const testAccCvmInstanceResource_WithWaitForReadyCreate = `
+
+data "tencentcloud_instance_types" "default" {
+    
+    filter {
+        name = "instance-family"
+        values = ["S1","S2","S3","S4","S5"]
+    }
+    filter {
+        name = "zone"
+        values = ["ap-guangzhou-7"]
+    }
+    cpu_core_count = 2
+    memory_size = 2
+    exclude_sold_out = true
+}
+data "tencentcloud_images" "default" {
+    image_type = ["PUBLIC_IMAGE"]
+    image_name_regex = "Final"
+}
+resource "tencentcloud_instance" "foo" {
+    availability_zone = "ap-guangzhou-7"
+    image_id = data.tencentcloud_images.default.images.0.image_id
+    instance_type = data.tencentcloud_instance_types.default.instance_types.0.instance_type
+    system_disk_type = "CLOUD_PREMIUM"
+    instance_name = "tf-ci-test-ready"
+    user_data_raw = <<-EOF
+      #!/bin/bash
+      sleep 30 && touch /tmp/ready
+    EOF
+
+    wait_for_ready {
+        tat_command = "test -f /tmp/ready"
+        timeout = 600
+    }
+}
+
+`
+
 func TestAccTencentCloudInstanceResourceWithSecurityGroup(t *testing.T) {
 	t.Parallel()
 	resource.Test(t, resource.TestCase{
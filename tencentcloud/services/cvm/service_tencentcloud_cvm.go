@@ -90,48 +90,50 @@ func (me *CvmService) DescribeInstanceById(ctx context.Context, instanceId strin
 }
 
 func (me *CvmService) DescribeInstanceByFilter(ctx context.Context, instancesId []*string, filters map[string]string) (instances []*cvm.Instance, errRet error) {
+	dataSourceFilters := make([]tccommon.DataSourceFilter, 0, len(filters))
+	for k, v := range filters {
+		dataSourceFilters = append(dataSourceFilters, tccommon.DataSourceFilter{Name: k, Values: []string{v}})
+	}
+	return me.DescribeInstancesByFilters(ctx, instancesId, dataSourceFilters)
+}
+
+func (me *CvmService) DescribeInstancesByFilters(ctx context.Context, instancesId []*string, filters []tccommon.DataSourceFilter) (instances []*cvm.Instance, errRet error) {
 	logId := tccommon.GetLogId(ctx)
 	request := cvm.NewDescribeInstancesRequest()
 	if instancesId != nil {
 		request.InstanceIds = instancesId
 	} else {
 		request.Filters = make([]*cvm.Filter, 0, len(filters))
-		for k, v := range filters {
-			filter := cvm.Filter{
-				Name:   helper.String(k),
-				Values: []*string{helper.String(v)},
-			}
-			request.Filters = append(request.Filters, &filter)
+		for _, v := range filters {
+			request.Filters = append(request.Filters, &cvm.Filter{
+				Name:   helper.String(v.Name),
+				Values: helper.Strings(v.Values),
+			})
 		}
 	}
 
-	var offset int64 = 0
-	var pageSize int64 = 100
-	instances = make([]*cvm.Instance, 0)
-	for {
-		request.Offset = &offset
-		request.Limit = &pageSize
+	return tccommon.DescribeAllPages(100, func(offset, limit int) ([]*cvm.Instance, int, error) {
+		request.Offset = helper.IntInt64(offset)
+		request.Limit = helper.IntInt64(limit)
 		ratelimit.Check(request.GetAction())
 		response, err := me.client.UseCvmClient().DescribeInstances(request)
 		if err != nil {
 			log.Printf("[CRITAL]%s api[%s] fail, request body [%s], reason[%s]\n",
 				logId, request.GetAction(), request.ToJsonString(), err.Error())
-			errRet = err
-			return
+			return nil, 0, err
 		}
 		log.Printf("[DEBUG]%s api[%s] success, request body [%s], response body [%s]\n",
 			logId, request.GetAction(), request.ToJsonString(), response.ToJsonString())
 
-		if response == nil || len(response.Response.InstanceSet) < 1 {
-			break
+		if response == nil || response.Response == nil {
+			return nil, 0, nil
 		}
-		instances = append(instances, response.Response.InstanceSet...)
-		if len(response.Response.InstanceSet) < int(pageSize) {
-			break
+		total := -1
+		if response.Response.TotalCount != nil {
+			total = int(*response.Response.TotalCount)
 		}
-		offset += pageSize
-	}
-	return
+		return response.Response.InstanceSet, total, nil
+	})
 }
 
 func (me *CvmService) DescribeInstanceInParallelByFilter(ctx context.Context, filters map[string]string) (instances []*cvm.Instance, errRet error) {
@@ -205,7 +205,7 @@ func dataSourceTencentCloudCynosdbAccountAllGrantPrivilegesRead(d *schema.Resour
 	d.SetId(clusterId)
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), d); e != nil {
+		if e := tccommon.WriteToFile(output.(string), d, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
@@ -82,7 +82,7 @@ func dataSourceTencentCloudCynosdbBackupDownloadUrlRead(d *schema.ResourceData,
 	d.SetId(clusterId)
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), d); e != nil {
+		if e := tccommon.WriteToFile(output.(string), d, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
@@ -132,7 +132,7 @@ func dataSourceTencentCloudCynosdbClusterRead(d *schema.ResourceData, meta inter
 	d.SetId(clusterId)
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), tmpList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), tmpList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
@@ -167,7 +167,7 @@ func dataSourceTencentCloudCynosdbInstancesRead(d *schema.ResourceData, meta int
 
 		output, ok := d.GetOk("result_output_file")
 		if ok && output.(string) != "" {
-			if e := tccommon.WriteToFile(output.(string), instanceList); e != nil {
+			if e := tccommon.WriteToFile(output.(string), instanceList, tccommon.GetResultOutputOption(d)); e != nil {
 				return resource.NonRetryableError(e)
 			}
 		}
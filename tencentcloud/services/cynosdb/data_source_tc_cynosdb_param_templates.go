@@ -328,7 +328,7 @@ func dataSourceTencentCloudCynosdbParamTemplatesRead(d *schema.ResourceData, met
 	_ = d.Set("items", tmpList)
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), tmpList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), tmpList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
@@ -186,7 +186,7 @@ func dataSourceTencentCloudDayuCCHttpsPoliciesRead(d *schema.ResourceData, meta
 	}
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		return tccommon.WriteToFile(output.(string), list)
+		return tccommon.WriteToFile(output.(string), list, tccommon.GetResultOutputOption(d))
 	}
 	return nil
 
@@ -422,7 +422,7 @@ func dataSourceTencentCloudDayuDdosPoliciesRead(d *schema.ResourceData, meta int
 	}
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		return tccommon.WriteToFile(output.(string), list)
+		return tccommon.WriteToFile(output.(string), list, tccommon.GetResultOutputOption(d))
 	}
 	return nil
 
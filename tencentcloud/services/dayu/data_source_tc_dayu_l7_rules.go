@@ -238,7 +238,7 @@ func dataSourceTencentCloudDayuL7RulesRead(d *schema.ResourceData, meta interfac
 	}
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		return tccommon.WriteToFile(output.(string), list)
+		return tccommon.WriteToFile(output.(string), list, tccommon.GetResultOutputOption(d))
 	}
 	return nil
 
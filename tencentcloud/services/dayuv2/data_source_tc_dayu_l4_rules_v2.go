@@ -209,7 +209,7 @@ func dataSourceTencentCloudDayuL4RulesReadV2(d *schema.ResourceData, meta interf
 	}
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		return tccommon.WriteToFile(output.(string), list)
+		return tccommon.WriteToFile(output.(string), list, tccommon.GetResultOutputOption(d))
 	}
 	return nil
 
@@ -138,7 +138,7 @@ func dataSourceTencentCloudDbbrainDbSpaceStatusRead(d *schema.ResourceData, meta
 	d.SetId(helper.DataResourceIdHash(instanceId))
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), tmpList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), tmpList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
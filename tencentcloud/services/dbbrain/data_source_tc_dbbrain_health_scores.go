@@ -265,7 +265,7 @@ func dataSourceTencentCloudDbbrainHealthScoresRead(d *schema.ResourceData, meta
 	d.SetId(instanceId)
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), healthScoreInfoMap); e != nil {
+		if e := tccommon.WriteToFile(output.(string), healthScoreInfoMap, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
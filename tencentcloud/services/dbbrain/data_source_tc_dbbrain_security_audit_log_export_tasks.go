@@ -200,7 +200,7 @@ func dataSourceTencentCloudDbbrainSecurityAuditLogExportTasksRead(d *schema.Reso
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), taskList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), taskList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
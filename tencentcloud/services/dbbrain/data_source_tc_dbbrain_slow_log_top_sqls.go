@@ -393,7 +393,7 @@ func dataSourceTencentCloudDbbrainSlowLogTopSqlsRead(d *schema.ResourceData, met
 	d.SetId(id)
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), tmpList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), tmpList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
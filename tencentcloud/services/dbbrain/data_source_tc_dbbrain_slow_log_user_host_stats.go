@@ -154,7 +154,7 @@ func dataSourceTencentCloudDbbrainSlowLogUserHostStatsRead(d *schema.ResourceDat
 	d.SetId(id)
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), tmpList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), tmpList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
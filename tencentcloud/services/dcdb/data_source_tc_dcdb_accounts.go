@@ -147,7 +147,7 @@ func dataSourceTencentCloudDcdbAccountsRead(d *schema.ResourceData, meta interfa
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), retList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), retList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
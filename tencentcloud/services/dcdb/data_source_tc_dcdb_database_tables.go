@@ -128,7 +128,7 @@ func dataSourceTencentCloudDcdbDatabaseTablesRead(d *schema.ResourceData, meta i
 	d.SetId(helper.DataResourceIdsHash(ids))
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), data); e != nil {
+		if e := tccommon.WriteToFile(output.(string), data, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
@@ -92,7 +92,7 @@ func dataSourceTencentCloudDcdbDatabasesRead(d *schema.ResourceData, meta interf
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), list); e != nil {
+		if e := tccommon.WriteToFile(output.(string), list, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
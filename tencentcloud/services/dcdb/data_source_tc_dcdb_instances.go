@@ -553,7 +553,7 @@ func dataSourceTencentCloudDcdbInstancesRead(d *schema.ResourceData, meta interf
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), instanceList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), instanceList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
@@ -192,7 +192,7 @@ func dataSourceTencentCloudDcdbParametersRead(d *schema.ResourceData, meta inter
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), paramList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), paramList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
@@ -295,7 +295,7 @@ func dataSourceTencentCloudDcdbShardsRead(d *schema.ResourceData, meta interface
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), shardList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), shardList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
@@ -149,7 +149,7 @@ func dataSourceTencentCloudDcGatewayInstancesRead(d *schema.ResourceData, meta i
 	d.SetId(fmt.Sprintf("%x", m.Sum(nil)))
 
 	if output, ok := d.GetOk("result_output_file"); ok && output.(string) != "" {
-		if err := tccommon.WriteToFile(output.(string), infoList); err != nil {
+		if err := tccommon.WriteToFile(output.(string), infoList, tccommon.GetResultOutputOption(d)); err != nil {
 			log.Printf("[CRITAL]%s output file[%s] fail, reason[%s]\n",
 				logId,
 				output.(string),
@@ -136,7 +136,7 @@ func dataSourceTencentCloudDlcCheckDataEngineConfigPairsValidityRead(d *schema.R
 	d.SetId(childImageVersionId)
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), result); e != nil {
+		if e := tccommon.WriteToFile(output.(string), result, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
@@ -761,7 +761,7 @@ func dataSourceTencentCloudDlcDescribeDataEngineRead(d *schema.ResourceData, met
 	d.SetId(dataEngineName)
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), dataEngineInfoMap); e != nil {
+		if e := tccommon.WriteToFile(output.(string), dataEngineInfoMap, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
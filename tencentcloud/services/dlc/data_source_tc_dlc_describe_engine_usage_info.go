@@ -86,7 +86,7 @@ func dataSourceTencentCloudDlcDescribeEngineUsageInfoRead(d *schema.ResourceData
 	d.SetId(dataEngineId)
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), tmp); e != nil {
+		if e := tccommon.WriteToFile(output.(string), tmp, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
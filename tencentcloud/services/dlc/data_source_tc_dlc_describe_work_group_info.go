@@ -871,7 +871,7 @@ func dataSourceTencentCloudDlcDescribeWorkGroupInfoRead(d *schema.ResourceData,
 	d.SetId(helper.DataResourceIdsHash(ids))
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), workGroupDetailInfoMap); e != nil {
+		if e := tccommon.WriteToFile(output.(string), workGroupDetailInfoMap, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
@@ -357,7 +357,7 @@ func dataSourceTencentCloudDnspodDomainAnalyticsRead(d *schema.ResourceData, met
 			"info":       info,
 			"data":       data,
 			"alias_data": aliasData,
-		})
+		}, tccommon.GetResultOutputOption(d))
 		if e != nil {
 			return e
 		}
@@ -89,7 +89,7 @@ func dataSourceTencentCloudDnspodDomainLogListRead(d *schema.ResourceData, meta
 	d.SetId(helper.DataResourceIdHash(domain))
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), logList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), logList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
@@ -389,7 +389,7 @@ func dataSourceTencentCloudDnspodRecordAnalyticsRead(d *schema.ResourceData, met
 			"info":       info,
 			"data":       data,
 			"alias_data": aliasData,
-		})
+		}, tccommon.GetResultOutputOption(d))
 		if e != nil {
 			return e
 		}
@@ -195,7 +195,7 @@ func dataSourceTencentCloudDnspodRecordLineListRead(d *schema.ResourceData, meta
 		e = tccommon.WriteToFile(output.(string), map[string]interface{}{
 			"line_list":       lineList,
 			"line_group_list": lineGroupList,
-		})
+		}, tccommon.GetResultOutputOption(d))
 		if e != nil {
 			return e
 		}
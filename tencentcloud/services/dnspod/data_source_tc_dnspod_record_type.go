@@ -79,7 +79,7 @@ func dataSourceTencentCloudDnspodRecordTypeRead(d *schema.ResourceData, meta int
 	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), typeList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), typeList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
@@ -271,7 +271,7 @@ func dataSourceTencentCloudDnspodRead(d *schema.ResourceData, meta interface{})
 		err = tccommon.WriteToFile(output.(string), map[string]interface{}{
 			"record_count_info": info,
 			"result":            result,
-		})
+		}, tccommon.GetResultOutputOption(d))
 		if err != nil {
 			return err
 		}
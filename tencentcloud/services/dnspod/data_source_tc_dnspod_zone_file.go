@@ -0,0 +1,85 @@
+package dnspod
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	tccommon "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceTencentCloudDnspodZoneFile() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceTencentCloudDnspodZoneFileRead,
+		Schema: map[string]*schema.Schema{
+			"domain": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The domain to export.",
+			},
+			"sub_domain": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only export the records of the sub domain.",
+			},
+			"record_type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only export the records of the type.",
+			},
+			"result_output_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Used to save the zone file.",
+			},
+
+			// computed
+			"zone_file": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Records in BIND zone file format, which can be used as `zone_file` of `tencentcloud_dnspod_zone_records`. The default NS records are not exported, and the records not supported by BIND, such as URL forwarding records, are exported as comments.",
+			},
+			"record_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of the exported records.",
+			},
+		},
+	}
+}
+
+func dataSourceTencentCloudDnspodZoneFileRead(d *schema.ResourceData, meta interface{}) error {
+	defer tccommon.LogElapsed("data_source.tencentcloud_dnspod_zone_file.read")()
+
+	logId := tccommon.GetLogId(tccommon.ContextNil)
+	ctx := context.WithValue(context.TODO(), tccommon.LogIdKey, logId)
+	service := DnspodService{client: meta.(tccommon.ProviderMeta).GetAPIV3Conn()}
+
+	var (
+		domain     = d.Get("domain").(string)
+		subDomain  = d.Get("sub_domain").(string)
+		recordType = d.Get("record_type").(string)
+	)
+
+	records, err := service.DescribeDnspodZoneRecords(ctx, domain, subDomain, recordType)
+	if err != nil {
+		return err
+	}
+
+	zoneFile := FormatDnspodZoneFile(domain, records)
+	_ = d.Set("zone_file", zoneFile)
+	_ = d.Set("record_count", len(records))
+	d.SetId(strings.Join([]string{domain, subDomain, recordType}, tccommon.FILED_SP))
+
+	if output, ok := d.GetOk("result_output_file"); ok && output.(string) != "" {
+		if err := tccommon.WriteToFile(output.(string), zoneFile, tccommon.GetResultOutputOption(d)); err != nil {
+			log.Printf("[CRITAL]%s output file[%s] fail, reason[%s]",
+				logId, output.(string), err.Error())
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,35 @@
+package dnspod_test
+
+import (
+	"regexp"
+	"testing"
+
+	tcacctest "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/acctest"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccTencentCloudDnspodZoneFileDataSource_basic(t *testing.T) {
+	t.Parallel()
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { tcacctest.AccPreCheckCommon(t, tcacctest.ACCOUNT_TYPE_PREPAY) },
+		Providers: tcacctest.AccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDnspodZoneFileDataSource,
+				Check: resource.ComposeTestCheckFunc(
+					tcacctest.AccCheckTencentCloudDataSourceID("data.tencentcloud_dnspod_zone_file.zone_file"),
+					resource.TestMatchResourceAttr("data.tencentcloud_dnspod_zone_file.zone_file", "zone_file", regexp.MustCompile(`^\$ORIGIN iac-tf\.cloud\.`)),
+				),
+			},
+		},
+	})
+}
+
+const testAccDnspodZoneFileDataSource = `
+
+data "tencentcloud_dnspod_zone_file" "zone_file" {
+  domain = "iac-tf.cloud"
+}
+
+`
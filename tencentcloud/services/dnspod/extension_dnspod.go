@@ -19,3 +19,25 @@ var DNSPOD_DOMAIN_STATUS_TYPE = []string{
 	DNSPOD_DOMAIN_STATUS_ENABLE,
 	DNSPOD_DOMAIN_STATUS_DISABLE,
 }
+
+const (
+	DNSPOD_RECORD_STATUS_ENABLE  = "ENABLE"
+	DNSPOD_RECORD_STATUS_DISABLE = "DISABLE"
+)
+
+var DNSPOD_RECORD_STATUS = []string{
+	DNSPOD_RECORD_STATUS_ENABLE,
+	DNSPOD_RECORD_STATUS_DISABLE,
+}
+
+const (
+	DNSPOD_DEFAULT_RECORD_LINE = "默认"
+	DNSPOD_DEFAULT_RECORD_TTL  = 600
+)
+
+const (
+	// DNSPOD_RECORD_BATCH_SIZE is the number of records of a batch task
+	DNSPOD_RECORD_BATCH_SIZE = 100
+	// DNSPOD_RECORD_LIST_LIMIT is the max page size of DescribeRecordList
+	DNSPOD_RECORD_LIST_LIMIT = 3000
+)
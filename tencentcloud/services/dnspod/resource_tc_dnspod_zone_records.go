@@ -0,0 +1,362 @@
+package dnspod
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	tccommon "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func ResourceTencentCloudDnspodZoneRecords() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceTencentCloudDnspodZoneRecordsCreate,
+		Read:   resourceTencentCloudDnspodZoneRecordsRead,
+		Update: resourceTencentCloudDnspodZoneRecordsUpdate,
+		Delete: resourceTencentCloudDnspodZoneRecordsDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		CustomizeDiff: dnspodZoneRecordsCustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			"domain": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The domain whose records are managed.",
+			},
+			"sub_domain": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Only manage the records of the sub domain, such as `www` or `@`. All the sub domains are managed if not set.",
+			},
+			"record_type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Only manage the records of the type, such as `A` or `TXT`. All the types are managed if not set.",
+			},
+			"zone_file": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"zone_file", "record"},
+				Description:  "Records in BIND zone file format. SOA records are ignored. Line, weight, status and remark of a record can be set in its comment, such as `; line=xxx weight=10 status=DISABLE remark=\"web\"`. Conflicts with `record`.",
+			},
+			"record": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Computed:    true,
+				Description: "Records of the domain. The records in the scope of `sub_domain` and `record_type` but not listed here are deleted. It is computed from `zone_file` if `zone_file` is set.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"sub_domain": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "@",
+							Description: "The host record. Default is `@`.",
+						},
+						"record_type": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The record type.",
+						},
+						"value": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The record value.",
+						},
+						"record_line": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     DNSPOD_DEFAULT_RECORD_LINE,
+							Description: "The record line. Default is the default line of DNSPod.",
+						},
+						"mx": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Description: "MX priority, required when the record type is MX, range 1-20.",
+						},
+						"ttl": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Default:     DNSPOD_DEFAULT_RECORD_TTL,
+							Description: "TTL, the range is 1-604800, and the minimum value of different levels of domain names is different. Default is 600.",
+						},
+						"weight": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Description: "Weight of the record, range 1-100. Only enterprise VIP domain names are available, 0 means the weight is not set.",
+						},
+						"status": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      DNSPOD_RECORD_STATUS_ENABLE,
+							ValidateFunc: tccommon.ValidateAllowedStringValue(DNSPOD_RECORD_STATUS),
+							Description:  "Status of the record, valid values are `ENABLE` and `DISABLE`. Default is `ENABLE`.",
+						},
+						"remark": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The remark of the record.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func expandDnspodZoneRecords(list []interface{}) []*DnspodZoneRecord {
+	records := make([]*DnspodZoneRecord, 0, len(list))
+	for _, item := range list {
+		m := item.(map[string]interface{})
+		records = append(records, &DnspodZoneRecord{
+			SubDomain:  m["sub_domain"].(string),
+			RecordType: m["record_type"].(string),
+			RecordLine: m["record_line"].(string),
+			Value:      m["value"].(string),
+			MX:         uint64(m["mx"].(int)),
+			TTL:        uint64(m["ttl"].(int)),
+			Weight:     uint64(m["weight"].(int)),
+			Enabled:    m["status"].(string) != DNSPOD_RECORD_STATUS_DISABLE,
+			Remark:     m["remark"].(string),
+		})
+	}
+	return records
+}
+
+func flattenDnspodZoneRecords(records []*DnspodZoneRecord) []interface{} {
+	list := make([]interface{}, 0, len(records))
+	for _, record := range records {
+		status := DNSPOD_RECORD_STATUS_ENABLE
+		if !record.Enabled {
+			status = DNSPOD_RECORD_STATUS_DISABLE
+		}
+		list = append(list, map[string]interface{}{
+			"sub_domain":  record.SubDomain,
+			"record_type": record.RecordType,
+			"record_line": record.RecordLine,
+			"value":       record.Value,
+			"mx":          int(record.MX),
+			"ttl":         int(record.TTL),
+			"weight":      int(record.Weight),
+			"status":      status,
+			"remark":      record.Remark,
+		})
+	}
+	return list
+}
+
+// checkDnspodZoneRecords checks the records are in the scope and are not duplicated.
+func checkDnspodZoneRecords(records []*DnspodZoneRecord, subDomain, recordType string) error {
+	identities := make(map[string]bool, len(records))
+	for _, record := range records {
+		if subDomain != "" && !strings.EqualFold(record.SubDomain, subDomain) {
+			return fmt.Errorf("record `%s` is out of the sub domain `%s`", record, subDomain)
+		}
+		if recordType != "" && !strings.EqualFold(record.RecordType, recordType) {
+			return fmt.Errorf("record `%s` is out of the record type `%s`", record, recordType)
+		}
+		if identities[record.Identity()] {
+			return fmt.Errorf("record `%s` is duplicated", record)
+		}
+		identities[record.Identity()] = true
+	}
+	return nil
+}
+
+// dnspodZoneRecordsDesired returns the desired records, which are parsed from zone_file if it is set.
+func dnspodZoneRecordsDesired(d *schema.ResourceData) ([]*DnspodZoneRecord, error) {
+	if v, ok := d.GetOk("zone_file"); ok {
+		records, err := ParseDnspodZoneFile(d.Get("domain").(string), v.(string))
+		if err != nil {
+			return nil, fmt.Errorf("parse zone_file failed, %v", err)
+		}
+		return records, nil
+	}
+	return expandDnspodZoneRecords(d.Get("record").(*schema.Set).List()), nil
+}
+
+// dnspodZoneRecordsCustomizeDiff computes `record` from `zone_file` so that the changes of the records are shown in the plan,
+// and checks the records at plan time.
+func dnspodZoneRecordsCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	var records []*DnspodZoneRecord
+	if !d.NewValueKnown("zone_file") || !d.NewValueKnown("domain") {
+		return d.SetNewComputed("record")
+	}
+
+	if v, ok := d.GetOk("zone_file"); ok {
+		parsed, err := ParseDnspodZoneFile(d.Get("domain").(string), v.(string))
+		if err != nil {
+			return fmt.Errorf("parse zone_file failed, %v", err)
+		}
+		records = parsed
+	} else {
+		if !d.NewValueKnown("record") {
+			return nil
+		}
+		records = expandDnspodZoneRecords(d.Get("record").(*schema.Set).List())
+	}
+
+	if err := checkDnspodZoneRecords(records, d.Get("sub_domain").(string), d.Get("record_type").(string)); err != nil {
+		return err
+	}
+
+	if _, ok := d.GetOk("zone_file"); ok {
+		// keep the values of the same records in the form of the state, DNSPod may return the names with the trailing dot
+		old, _ := d.GetChange("record")
+		values := make(map[string]string)
+		for _, record := range expandDnspodZoneRecords(old.(*schema.Set).List()) {
+			values[record.Identity()] = record.Value
+		}
+		for _, record := range records {
+			if value, ok := values[record.Identity()]; ok {
+				record.Value = value
+			}
+		}
+		return d.SetNew("record", flattenDnspodZoneRecords(records))
+	}
+	return nil
+}
+
+func resourceTencentCloudDnspodZoneRecordsCreate(d *schema.ResourceData, meta interface{}) error {
+	defer tccommon.LogElapsed("resource.tencentcloud_dnspod_zone_records.create")()
+
+	logId := tccommon.GetLogId(tccommon.ContextNil)
+	ctx := context.WithValue(context.TODO(), tccommon.LogIdKey, logId)
+	service := DnspodService{client: meta.(tccommon.ProviderMeta).GetAPIV3Conn()}
+
+	var (
+		domain     = d.Get("domain").(string)
+		subDomain  = d.Get("sub_domain").(string)
+		recordType = d.Get("record_type").(string)
+	)
+
+	desired, err := dnspodZoneRecordsDesired(d)
+	if err != nil {
+		return err
+	}
+	current, err := service.DescribeDnspodZoneRecords(ctx, domain, subDomain, recordType)
+	if err != nil {
+		return err
+	}
+	if err := service.ApplyDnspodZoneRecords(ctx, domain, current, desired); err != nil {
+		log.Printf("[CRITAL]%s apply records of domain [%s] failed, reason:%+v", logId, domain, err)
+		return err
+	}
+
+	d.SetId(strings.Join([]string{domain, subDomain, recordType}, tccommon.FILED_SP))
+	return resourceTencentCloudDnspodZoneRecordsRead(d, meta)
+}
+
+func resourceTencentCloudDnspodZoneRecordsRead(d *schema.ResourceData, meta interface{}) error {
+	defer tccommon.LogElapsed("resource.tencentcloud_dnspod_zone_records.read")()
+	defer tccommon.InconsistentCheck(d, meta)()
+
+	logId := tccommon.GetLogId(tccommon.ContextNil)
+	ctx := context.WithValue(context.TODO(), tccommon.LogIdKey, logId)
+	service := DnspodService{client: meta.(tccommon.ProviderMeta).GetAPIV3Conn()}
+
+	idSplit := strings.Split(d.Id(), tccommon.FILED_SP)
+	if len(idSplit) != 3 {
+		return fmt.Errorf("id is broken,%s", d.Id())
+	}
+	domain, subDomain, recordType := idSplit[0], idSplit[1], idSplit[2]
+
+	records, err := service.DescribeDnspodZoneRecords(ctx, domain, subDomain, recordType)
+	if err != nil {
+		return err
+	}
+
+	// keep the values in the form of the configuration, such as the CNAME values without the trailing dot
+	values := make(map[string]string)
+	if v, ok := d.GetOk("record"); ok {
+		for _, record := range expandDnspodZoneRecords(v.(*schema.Set).List()) {
+			values[record.Identity()] = record.Value
+		}
+	}
+	for _, record := range records {
+		if value, ok := values[record.Identity()]; ok {
+			record.Value = value
+		}
+	}
+
+	_ = d.Set("domain", domain)
+	_ = d.Set("sub_domain", subDomain)
+	_ = d.Set("record_type", recordType)
+	_ = d.Set("record", flattenDnspodZoneRecords(records))
+
+	return nil
+}
+
+func resourceTencentCloudDnspodZoneRecordsUpdate(d *schema.ResourceData, meta interface{}) error {
+	defer tccommon.LogElapsed("resource.tencentcloud_dnspod_zone_records.update")()
+
+	logId := tccommon.GetLogId(tccommon.ContextNil)
+	ctx := context.WithValue(context.TODO(), tccommon.LogIdKey, logId)
+	service := DnspodService{client: meta.(tccommon.ProviderMeta).GetAPIV3Conn()}
+
+	if d.HasChanges("record", "zone_file") {
+		var (
+			domain     = d.Get("domain").(string)
+			subDomain  = d.Get("sub_domain").(string)
+			recordType = d.Get("record_type").(string)
+		)
+
+		desired, err := dnspodZoneRecordsDesired(d)
+		if err != nil {
+			return err
+		}
+		current, err := service.DescribeDnspodZoneRecords(ctx, domain, subDomain, recordType)
+		if err != nil {
+			return err
+		}
+		if err := service.ApplyDnspodZoneRecords(ctx, domain, current, desired); err != nil {
+			log.Printf("[CRITAL]%s apply records of domain [%s] failed, reason:%+v", logId, domain, err)
+			return err
+		}
+	}
+
+	return resourceTencentCloudDnspodZoneRecordsRead(d, meta)
+}
+
+func resourceTencentCloudDnspodZoneRecordsDelete(d *schema.ResourceData, meta interface{}) error {
+	defer tccommon.LogElapsed("resource.tencentcloud_dnspod_zone_records.delete")()
+
+	logId := tccommon.GetLogId(tccommon.ContextNil)
+	ctx := context.WithValue(context.TODO(), tccommon.LogIdKey, logId)
+	service := DnspodService{client: meta.(tccommon.ProviderMeta).GetAPIV3Conn()}
+
+	var (
+		domain     = d.Get("domain").(string)
+		subDomain  = d.Get("sub_domain").(string)
+		recordType = d.Get("record_type").(string)
+	)
+
+	current, err := service.DescribeDnspodZoneRecords(ctx, domain, subDomain, recordType)
+	if err != nil {
+		return err
+	}
+
+	// only the records in the state are deleted, the ones created after the last apply are kept
+	managed := make(map[string]bool)
+	for _, record := range expandDnspodZoneRecords(d.Get("record").(*schema.Set).List()) {
+		managed[record.Identity()] = true
+	}
+	records := make([]*DnspodZoneRecord, 0, len(current))
+	for _, record := range current {
+		if managed[record.Identity()] {
+			records = append(records, record)
+		}
+	}
+
+	if err := service.DeleteDnspodRecordBatch(ctx, records); err != nil {
+		log.Printf("[CRITAL]%s delete records of domain [%s] failed, reason:%+v", logId, domain, err)
+		return err
+	}
+	return nil
+}
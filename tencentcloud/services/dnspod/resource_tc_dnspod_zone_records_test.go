@@ -0,0 +1,76 @@
+package dnspod_test
+
+import (
+	"testing"
+
+	tcacctest "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/acctest"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccTencentCloudDnspodZoneRecordsResource_basic(t *testing.T) {
+	t.Parallel()
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { tcacctest.AccPreCheckCommon(t, tcacctest.ACCOUNT_TYPE_PREPAY) },
+		Providers: tcacctest.AccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDnspodZoneRecords,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tencentcloud_dnspod_zone_records.zone_records", "domain", "iac-tf.cloud"),
+					resource.TestCheckResourceAttr("tencentcloud_dnspod_zone_records.zone_records", "sub_domain", "tf-zone-records"),
+					resource.TestCheckResourceAttr("tencentcloud_dnspod_zone_records.zone_records", "record.#", "2"),
+				),
+			},
+			{
+				Config: testAccDnspodZoneRecordsZoneFile,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tencentcloud_dnspod_zone_records.zone_records", "record.#", "3"),
+				),
+			},
+			{
+				ResourceName:            "tencentcloud_dnspod_zone_records.zone_records",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"zone_file"},
+			},
+		},
+	})
+}
+
+const testAccDnspodZoneRecords = `
+
+resource "tencentcloud_dnspod_zone_records" "zone_records" {
+  domain     = "iac-tf.cloud"
+  sub_domain = "tf-zone-records"
+
+  record {
+    sub_domain  = "tf-zone-records"
+    record_type = "A"
+    value       = "1.1.1.1"
+  }
+
+  record {
+    sub_domain  = "tf-zone-records"
+    record_type = "TXT"
+    value       = "terraform"
+    remark      = "test"
+  }
+}
+
+`
+
+const testAccDnspodZoneRecordsZoneFile = `
+
+resource "tencentcloud_dnspod_zone_records" "zone_records" {
+  domain     = "iac-tf.cloud"
+  sub_domain = "tf-zone-records"
+  zone_file  = <<-EOT
+    $ORIGIN iac-tf.cloud.
+    tf-zone-records 600 IN A   1.1.1.2
+                    600 IN A   1.1.1.3 ; status=DISABLE
+                    600 IN TXT "terraform" ; remark="test"
+  EOT
+}
+
+`
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"strings"
 
 	tccommon "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/common"
 
@@ -830,3 +831,86 @@ func (me *DnspodService) DescribeSubdomainValidateStatusByFilter(ctx context.Con
 	status = 1
 	return
 }
+
+// DescribeDnspodZoneByName returns the longest domain in the account which the name belongs to, it is empty if not found.
+func (me *DnspodService) DescribeDnspodZoneByName(ctx context.Context, name string) (zone string, errRet error) {
+	labels := strings.Split(strings.TrimSuffix(name, "."), ".")
+	for i := 0; i < len(labels)-1; i++ {
+		candidate := strings.Join(labels[i:], ".")
+		var response *dnspod.DescribeDomainResponse
+		err := resource.Retry(tccommon.ReadRetryTimeout, func() *resource.RetryError {
+			result, e := me.DescribeDomain(ctx, candidate)
+			if e != nil {
+				if sdkErr, ok := e.(*sdkErrors.TencentCloudSDKError); ok && sdkErr.Code == "InvalidParameterValue.DomainNotExists" {
+					return nil
+				}
+				return tccommon.RetryError(e, tccommon.InternalError)
+			}
+			response = result
+			return nil
+		})
+		if err != nil {
+			errRet = err
+			return
+		}
+		if response != nil && response.Response.DomainInfo != nil {
+			zone = candidate
+			return
+		}
+	}
+	return
+}
+
+func (me *DnspodService) CreateDnspodTxtRecord(ctx context.Context, domain, subDomain, value, recordLine string, ttl int) (recordId uint64, errRet error) {
+	logId := tccommon.GetLogId(ctx)
+	request := dnspod.NewCreateTXTRecordRequest()
+	request.Domain = &domain
+	request.SubDomain = &subDomain
+	request.Value = &value
+	request.RecordLine = &recordLine
+	request.TTL = helper.IntUint64(ttl)
+
+	defer func() {
+		if errRet != nil {
+			log.Printf("[CRITAL]%s api[%s] fail, request body [%s], reason[%s]\n", logId, request.GetAction(), request.ToJsonString(), errRet.Error())
+		}
+	}()
+
+	errRet = resource.Retry(tccommon.WriteRetryTimeout, func() *resource.RetryError {
+		ratelimit.Check(request.GetAction())
+		response, e := me.client.UseDnsPodClient().CreateTXTRecord(request)
+		if e != nil {
+			return tccommon.RetryError(e)
+		}
+		if response == nil || response.Response == nil || response.Response.RecordId == nil {
+			return resource.NonRetryableError(fmt.Errorf("TencentCloud SDK return nil response, %s", request.GetAction()))
+		}
+		log.Printf("[DEBUG]%s api[%s] success, request body [%s], response body [%s]\n", logId, request.GetAction(), request.ToJsonString(), response.ToJsonString())
+		recordId = *response.Response.RecordId
+		return nil
+	})
+	return
+}
+
+func (me *DnspodService) DeleteDnspodRecordById(ctx context.Context, domain string, recordId uint64) (errRet error) {
+	logId := tccommon.GetLogId(ctx)
+	request := dnspod.NewDeleteRecordRequest()
+	request.Domain = &domain
+	request.RecordId = &recordId
+
+	defer func() {
+		if errRet != nil {
+			log.Printf("[CRITAL]%s api[%s] fail, request body [%s], reason[%s]\n", logId, request.GetAction(), request.ToJsonString(), errRet.Error())
+		}
+	}()
+
+	errRet = resource.Retry(tccommon.WriteRetryTimeout, func() *resource.RetryError {
+		ratelimit.Check(request.GetAction())
+		_, e := me.client.UseDnsPodClient().DeleteRecord(request)
+		if e != nil {
+			return tccommon.RetryError(e)
+		}
+		return nil
+	})
+	return
+}
@@ -0,0 +1,459 @@
+package dnspod
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	sdkErrors "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common/errors"
+	dnspod "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/dnspod/v20210323"
+
+	tccommon "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/common"
+	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/internal/helper"
+	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/ratelimit"
+)
+
+// DnspodZoneRecord is a record of the zone, the weight is not set if it is 0.
+type DnspodZoneRecord struct {
+	RecordId   uint64
+	SubDomain  string
+	RecordType string
+	RecordLine string
+	Value      string
+	MX         uint64
+	TTL        uint64
+	Weight     uint64
+	Enabled    bool
+	Remark     string
+}
+
+func dnspodAbsoluteValue(value string) string {
+	if strings.HasSuffix(value, ".") {
+		return value
+	}
+	return value + "."
+}
+
+// normalizeDnspodRecordValue returns the value in the form DNSPod compares records, the names are case insensitive
+// and may be written with or without the trailing dot.
+func normalizeDnspodRecordValue(recordType, value string) string {
+	value = strings.TrimSpace(value)
+	switch strings.ToUpper(recordType) {
+	case "CNAME", "MX", "NS", "PTR":
+		return strings.ToLower(strings.TrimSuffix(value, "."))
+	case "TXT", "SPF":
+		if len(value) >= 2 && strings.HasPrefix(value, `"`) && strings.HasSuffix(value, `"`) {
+			return value[1 : len(value)-1]
+		}
+	}
+	return value
+}
+
+// group identifies the records which can be modified into each other.
+func (r *DnspodZoneRecord) group() string {
+	return strings.Join([]string{strings.ToLower(r.SubDomain), strings.ToUpper(r.RecordType), r.RecordLine}, "\n")
+}
+
+// Identity identifies the record in the zone, DNSPod does not allow two records of the same identity.
+func (r *DnspodZoneRecord) Identity() string {
+	return r.group() + "\n" + normalizeDnspodRecordValue(r.RecordType, r.Value)
+}
+
+func (r *DnspodZoneRecord) sameAttributes(other *DnspodZoneRecord) bool {
+	return r.MX == other.MX && r.TTL == other.TTL && r.Weight == other.Weight && r.Enabled == other.Enabled && r.Remark == other.Remark
+}
+
+func (r *DnspodZoneRecord) String() string {
+	return fmt.Sprintf("%s %s(%s) %s", r.SubDomain, r.RecordType, r.RecordLine, r.Value)
+}
+
+// DnspodZoneRecordsChange is the changes to make the zone match the desired records. The records in DeleteFirst conflict
+// with the ones to create, such as a CNAME record and an A record of the same sub domain, so they are deleted before creating.
+type DnspodZoneRecordsChange struct {
+	Create      []*DnspodZoneRecord
+	Modify      []*DnspodZoneRecord
+	DeleteFirst []*DnspodZoneRecord
+	Delete      []*DnspodZoneRecord
+}
+
+func (c *DnspodZoneRecordsChange) Empty() bool {
+	return len(c.Create)+len(c.Modify)+len(c.DeleteFirst)+len(c.Delete) == 0
+}
+
+// DiffDnspodZoneRecords works out the changes from the current records to the desired ones. The records of the same
+// identity are modified in place if the attributes differ, and the records of the same sub domain, type and line
+// are modified into each other so that the names keep resolving during the change.
+func DiffDnspodZoneRecords(current, desired []*DnspodZoneRecord) *DnspodZoneRecordsChange {
+	change := &DnspodZoneRecordsChange{}
+
+	currentByIdentity := make(map[string]*DnspodZoneRecord, len(current))
+	for _, record := range current {
+		currentByIdentity[record.Identity()] = record
+	}
+
+	var (
+		matched     = make(map[*DnspodZoneRecord]bool, len(current))
+		staleGroups = make(map[string][]*DnspodZoneRecord)
+		newGroups   = make(map[string][]*DnspodZoneRecord)
+		groupOrder  []string
+	)
+	for _, record := range desired {
+		if exist, ok := currentByIdentity[record.Identity()]; ok && !matched[exist] {
+			matched[exist] = true
+			if !exist.sameAttributes(record) {
+				modify := *record
+				modify.RecordId = exist.RecordId
+				change.Modify = append(change.Modify, &modify)
+			}
+			continue
+		}
+		if _, ok := newGroups[record.group()]; !ok {
+			groupOrder = append(groupOrder, record.group())
+		}
+		newGroups[record.group()] = append(newGroups[record.group()], record)
+	}
+	for _, record := range current {
+		if !matched[record] {
+			staleGroups[record.group()] = append(staleGroups[record.group()], record)
+		}
+	}
+
+	byValue := func(records []*DnspodZoneRecord) {
+		sort.SliceStable(records, func(i, j int) bool { return records[i].Value < records[j].Value })
+	}
+	for _, group := range groupOrder {
+		news, stales := newGroups[group], staleGroups[group]
+		byValue(news)
+		byValue(stales)
+		for len(news) > 0 && len(stales) > 0 {
+			modify := *news[0]
+			modify.RecordId = stales[0].RecordId
+			change.Modify = append(change.Modify, &modify)
+			news, stales = news[1:], stales[1:]
+		}
+		change.Create = append(change.Create, news...)
+		staleGroups[group] = stales
+	}
+
+	var deletes []*DnspodZoneRecord
+	for _, records := range staleGroups {
+		deletes = append(deletes, records...)
+	}
+	SortDnspodZoneRecords(deletes)
+	for _, record := range deletes {
+		conflict := false
+		for _, create := range change.Create {
+			if strings.EqualFold(create.SubDomain, record.SubDomain) && create.RecordLine == record.RecordLine &&
+				(strings.EqualFold(create.RecordType, "CNAME") || strings.EqualFold(record.RecordType, "CNAME")) {
+				conflict = true
+				break
+			}
+		}
+		if conflict {
+			change.DeleteFirst = append(change.DeleteFirst, record)
+		} else {
+			change.Delete = append(change.Delete, record)
+		}
+	}
+	return change
+}
+
+// DescribeDnspodZoneRecords returns the records of the domain, which are optionally filtered by the sub domain and the type.
+// The default NS records are not returned since they can not be changed.
+func (me *DnspodService) DescribeDnspodZoneRecords(ctx context.Context, domain, subDomain, recordType string) (records []*DnspodZoneRecord, errRet error) {
+	logId := tccommon.GetLogId(ctx)
+	request := dnspod.NewDescribeRecordListRequest()
+	request.Domain = &domain
+	if subDomain != "" {
+		request.Subdomain = &subDomain
+	}
+	if recordType != "" {
+		request.RecordType = &recordType
+	}
+
+	var (
+		offset uint64 = 0
+		limit  uint64 = DNSPOD_RECORD_LIST_LIMIT
+	)
+	for {
+		request.Offset = &offset
+		request.Limit = &limit
+
+		var (
+			list   []*dnspod.RecordListItem
+			noData bool
+		)
+		err := resource.Retry(tccommon.ReadRetryTimeout, func() *resource.RetryError {
+			result, _, e := me.DescribeRecordList(ctx, request)
+			if e != nil {
+				if sdkErr, ok := e.(*sdkErrors.TencentCloudSDKError); ok && sdkErr.Code == "ResourceNotFound.NoDataOfRecord" {
+					noData = true
+					return nil
+				}
+				return tccommon.RetryError(e, tccommon.InternalError)
+			}
+			list = result
+			return nil
+		})
+		if err != nil {
+			errRet = err
+			return
+		}
+		if noData {
+			break
+		}
+
+		for _, item := range list {
+			if item.RecordId == nil || (item.DefaultNS != nil && *item.DefaultNS) {
+				continue
+			}
+			records = append(records, &DnspodZoneRecord{
+				RecordId:   *item.RecordId,
+				SubDomain:  helper.PString(item.Name),
+				RecordType: helper.PString(item.Type),
+				RecordLine: helper.PString(item.Line),
+				Value:      helper.PString(item.Value),
+				MX:         helper.PUint64(item.MX),
+				TTL:        helper.PUint64(item.TTL),
+				Weight:     helper.PUint64(item.Weight),
+				Enabled:    helper.PString(item.Status) != DNSPOD_RECORD_STATUS_DISABLE,
+				Remark:     helper.PString(item.Remark),
+			})
+		}
+		if len(list) < int(limit) {
+			break
+		}
+		offset += limit
+	}
+
+	log.Printf("[DEBUG]%s domain [%s] has %d records of sub domain [%s] and type [%s]", logId, domain, len(records), subDomain, recordType)
+	return
+}
+
+// waitDnspodBatchTask waits for the batch task to finish and returns the errors of the failed records.
+func (me *DnspodService) waitDnspodBatchTask(ctx context.Context, jobId uint64) (errRet error) {
+	logId := tccommon.GetLogId(ctx)
+	request := dnspod.NewDescribeBatchTaskRequest()
+	request.JobId = &jobId
+
+	var response *dnspod.DescribeBatchTaskResponseParams
+	errRet = resource.Retry(6*tccommon.ReadRetryTimeout, func() *resource.RetryError {
+		ratelimit.Check(request.GetAction())
+		result, e := me.client.UseDnsPodClient().DescribeBatchTask(request)
+		if e != nil {
+			return tccommon.RetryError(e, tccommon.InternalError)
+		}
+		if result == nil || result.Response == nil {
+			return resource.NonRetryableError(fmt.Errorf("TencentCloud SDK return nil response, %s", request.GetAction()))
+		}
+		log.Printf("[DEBUG]%s api[%s] success, request body [%s], response body [%s]\n", logId, request.GetAction(), request.ToJsonString(), result.ToJsonString())
+
+		total := helper.PUint64(result.Response.TotalCount)
+		if helper.PUint64(result.Response.SuccessCount)+helper.PUint64(result.Response.FailCount) < total {
+			return resource.RetryableError(fmt.Errorf("batch task %d is running", jobId))
+		}
+		response = result.Response
+		return nil
+	})
+	if errRet != nil || helper.PUint64(response.FailCount) == 0 {
+		return
+	}
+
+	var messages []string
+	for _, detail := range response.DetailList {
+		if msg := helper.PString(detail.ErrMsg); msg != "" {
+			messages = append(messages, msg)
+		}
+		for _, record := range detail.RecordList {
+			if msg := helper.PString(record.ErrMsg); msg != "" {
+				messages = append(messages, fmt.Sprintf("%s %s %s: %s", helper.PString(record.SubDomain), helper.PString(record.RecordType), helper.PString(record.Value), msg))
+			}
+		}
+	}
+	return fmt.Errorf("batch task %d failed for %d records: %s", jobId, *response.FailCount, strings.Join(messages, "; "))
+}
+
+// CreateDnspodRecordBatch creates the records by batch tasks of DNSPOD_RECORD_BATCH_SIZE records.
+func (me *DnspodService) CreateDnspodRecordBatch(ctx context.Context, domainId uint64, records []*DnspodZoneRecord) (errRet error) {
+	logId := tccommon.GetLogId(ctx)
+	for start := 0; start < len(records); start += DNSPOD_RECORD_BATCH_SIZE {
+		end := start + DNSPOD_RECORD_BATCH_SIZE
+		if end > len(records) {
+			end = len(records)
+		}
+
+		request := dnspod.NewCreateRecordBatchRequest()
+		request.DomainIdList = []*string{helper.String(strconv.FormatUint(domainId, 10))}
+		for _, record := range records[start:end] {
+			item := &dnspod.AddRecordBatch{
+				SubDomain:  helper.String(record.SubDomain),
+				RecordType: helper.String(record.RecordType),
+				RecordLine: helper.String(record.RecordLine),
+				Value:      helper.String(record.Value),
+				TTL:        helper.Uint64(record.TTL),
+				Enabled:    helper.Uint64(0),
+			}
+			if record.Enabled {
+				item.Enabled = helper.Uint64(1)
+			}
+			if record.RecordType == "MX" {
+				item.MX = helper.Uint64(record.MX)
+			}
+			if record.Weight > 0 {
+				item.Weight = helper.Uint64(record.Weight)
+			}
+			if record.Remark != "" {
+				item.Remark = helper.String(record.Remark)
+			}
+			request.RecordList = append(request.RecordList, item)
+		}
+
+		var jobId uint64
+		err := resource.Retry(tccommon.WriteRetryTimeout, func() *resource.RetryError {
+			ratelimit.Check(request.GetAction())
+			response, e := me.client.UseDnsPodClient().CreateRecordBatch(request)
+			if e != nil {
+				return tccommon.RetryError(e)
+			}
+			if response == nil || response.Response == nil || response.Response.JobId == nil {
+				return resource.NonRetryableError(fmt.Errorf("TencentCloud SDK return nil response, %s", request.GetAction()))
+			}
+			log.Printf("[DEBUG]%s api[%s] success, request body [%s], response body [%s]\n", logId, request.GetAction(), request.ToJsonString(), response.ToJsonString())
+			jobId = *response.Response.JobId
+			return nil
+		})
+		if err != nil {
+			log.Printf("[CRITAL]%s api[%s] fail, request body [%s], reason[%s]\n", logId, request.GetAction(), request.ToJsonString(), err.Error())
+			return err
+		}
+		if err := me.waitDnspodBatchTask(ctx, jobId); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteDnspodRecordBatch deletes the records by batch tasks of DNSPOD_RECORD_BATCH_SIZE records.
+func (me *DnspodService) DeleteDnspodRecordBatch(ctx context.Context, records []*DnspodZoneRecord) (errRet error) {
+	logId := tccommon.GetLogId(ctx)
+	for start := 0; start < len(records); start += DNSPOD_RECORD_BATCH_SIZE {
+		end := start + DNSPOD_RECORD_BATCH_SIZE
+		if end > len(records) {
+			end = len(records)
+		}
+
+		request := dnspod.NewDeleteRecordBatchRequest()
+		for _, record := range records[start:end] {
+			request.RecordIdList = append(request.RecordIdList, helper.Uint64(record.RecordId))
+		}
+
+		var jobId uint64
+		err := resource.Retry(tccommon.WriteRetryTimeout, func() *resource.RetryError {
+			ratelimit.Check(request.GetAction())
+			response, e := me.client.UseDnsPodClient().DeleteRecordBatch(request)
+			if e != nil {
+				return tccommon.RetryError(e)
+			}
+			if response == nil || response.Response == nil || response.Response.JobId == nil {
+				return resource.NonRetryableError(fmt.Errorf("TencentCloud SDK return nil response, %s", request.GetAction()))
+			}
+			log.Printf("[DEBUG]%s api[%s] success, request body [%s], response body [%s]\n", logId, request.GetAction(), request.ToJsonString(), response.ToJsonString())
+			jobId = *response.Response.JobId
+			return nil
+		})
+		if err != nil {
+			log.Printf("[CRITAL]%s api[%s] fail, request body [%s], reason[%s]\n", logId, request.GetAction(), request.ToJsonString(), err.Error())
+			return err
+		}
+		if err := me.waitDnspodBatchTask(ctx, jobId); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ModifyDnspodZoneRecord modifies the record of RecordId into the record, there is no batch API to modify records of different values.
+func (me *DnspodService) ModifyDnspodZoneRecord(ctx context.Context, domain string, record *DnspodZoneRecord, clearWeight bool) (errRet error) {
+	logId := tccommon.GetLogId(ctx)
+	request := dnspod.NewModifyRecordRequest()
+	request.Domain = &domain
+	request.RecordId = helper.Uint64(record.RecordId)
+	request.SubDomain = helper.String(record.SubDomain)
+	request.RecordType = helper.String(record.RecordType)
+	request.RecordLine = helper.String(record.RecordLine)
+	request.Value = helper.String(record.Value)
+	request.TTL = helper.Uint64(record.TTL)
+	request.Remark = helper.String(record.Remark)
+	request.Status = helper.String(DNSPOD_RECORD_STATUS_DISABLE)
+	if record.Enabled {
+		request.Status = helper.String(DNSPOD_RECORD_STATUS_ENABLE)
+	}
+	if record.RecordType == "MX" {
+		request.MX = helper.Uint64(record.MX)
+	}
+	if record.Weight > 0 || clearWeight {
+		request.Weight = helper.Uint64(record.Weight)
+	}
+
+	defer func() {
+		if errRet != nil {
+			log.Printf("[CRITAL]%s api[%s] fail, request body [%s], reason[%s]\n", logId, request.GetAction(), request.ToJsonString(), errRet.Error())
+		}
+	}()
+
+	errRet = resource.Retry(tccommon.WriteRetryTimeout, func() *resource.RetryError {
+		ratelimit.Check(request.GetAction())
+		response, e := me.client.UseDnsPodClient().ModifyRecord(request)
+		if e != nil {
+			return tccommon.RetryError(e)
+		}
+		log.Printf("[DEBUG]%s api[%s] success, request body [%s], response body [%s]\n", logId, request.GetAction(), request.ToJsonString(), response.ToJsonString())
+		return nil
+	})
+	return
+}
+
+// ApplyDnspodZoneRecords changes the records of the domain from current to desired.
+func (me *DnspodService) ApplyDnspodZoneRecords(ctx context.Context, domain string, current, desired []*DnspodZoneRecord) error {
+	logId := tccommon.GetLogId(ctx)
+	change := DiffDnspodZoneRecords(current, desired)
+	if change.Empty() {
+		return nil
+	}
+	log.Printf("[DEBUG]%s domain [%s] records change: %d to create, %d to modify, %d to delete",
+		logId, domain, len(change.Create), len(change.Modify), len(change.DeleteFirst)+len(change.Delete))
+
+	if err := me.DeleteDnspodRecordBatch(ctx, change.DeleteFirst); err != nil {
+		return err
+	}
+
+	currentById := make(map[uint64]*DnspodZoneRecord, len(current))
+	for _, record := range current {
+		currentById[record.RecordId] = record
+	}
+	for _, record := range change.Modify {
+		clearWeight := currentById[record.RecordId] != nil && currentById[record.RecordId].Weight > 0
+		if err := me.ModifyDnspodZoneRecord(ctx, domain, record, clearWeight); err != nil {
+			return fmt.Errorf("modify record %s failed, %v", record, err)
+		}
+	}
+
+	if len(change.Create) > 0 {
+		response, err := me.DescribeDomain(ctx, domain)
+		if err != nil {
+			return err
+		}
+		if response.Response.DomainInfo == nil || response.Response.DomainInfo.DomainId == nil {
+			return fmt.Errorf("domain `%s` not found", domain)
+		}
+		if err := me.CreateDnspodRecordBatch(ctx, *response.Response.DomainInfo.DomainId, change.Create); err != nil {
+			return err
+		}
+	}
+
+	return me.DeleteDnspodRecordBatch(ctx, change.Delete)
+}
@@ -0,0 +1,445 @@
+package dnspod
+
+import (
+	"bufio"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type zoneFileToken struct {
+	text   string
+	quoted bool
+}
+
+// tokenizeZoneFileLine splits the line into tokens and the comment, the parentheses are returned as separated tokens.
+func tokenizeZoneFileLine(line string) (tokens []zoneFileToken, comment string, err error) {
+	var (
+		current  strings.Builder
+		quoted   bool
+		inToken  bool
+		runes    = []rune(line)
+		endToken = func() {
+			if inToken {
+				tokens = append(tokens, zoneFileToken{text: current.String(), quoted: quoted})
+			}
+			current.Reset()
+			quoted = false
+			inToken = false
+		}
+	)
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case r == '"':
+			inToken = true
+			quoted = true
+			closed := false
+			for i++; i < len(runes); i++ {
+				if runes[i] == '\\' && i+1 < len(runes) {
+					i++
+					current.WriteRune(runes[i])
+					continue
+				}
+				if runes[i] == '"' {
+					closed = true
+					break
+				}
+				current.WriteRune(runes[i])
+			}
+			if !closed {
+				return nil, "", fmt.Errorf("unterminated quoted string in `%s`", line)
+			}
+		case r == ';':
+			endToken()
+			return tokens, strings.TrimSpace(string(runes[i+1:])), nil
+		case r == '(' || r == ')':
+			endToken()
+			tokens = append(tokens, zoneFileToken{text: string(r)})
+		case unicode.IsSpace(r):
+			endToken()
+		case r == '\\' && i+1 < len(runes):
+			inToken = true
+			i++
+			current.WriteRune(runes[i])
+		default:
+			inToken = true
+			current.WriteRune(r)
+		}
+	}
+	endToken()
+	return
+}
+
+// parseZoneFileTTL parses the TTL in seconds or with the units of BIND, such as `1h30m`.
+func parseZoneFileTTL(text string) (uint64, bool) {
+	if text == "" {
+		return 0, false
+	}
+	if ttl, err := strconv.ParseUint(text, 10, 32); err == nil {
+		return ttl, true
+	}
+
+	var ttl, number uint64
+	hasNumber := false
+	for _, r := range strings.ToLower(text) {
+		if r >= '0' && r <= '9' {
+			number = number*10 + uint64(r-'0')
+			hasNumber = true
+			continue
+		}
+		unit, ok := map[rune]uint64{'s': 1, 'm': 60, 'h': 3600, 'd': 86400, 'w': 604800}[r]
+		if !ok || !hasNumber {
+			return 0, false
+		}
+		ttl += number * unit
+		number = 0
+		hasNumber = false
+	}
+	if hasNumber {
+		return 0, false
+	}
+	return ttl, true
+}
+
+func zoneFileAbsoluteName(name, origin string) string {
+	if name == "@" {
+		return origin
+	}
+	if strings.HasSuffix(name, ".") {
+		return name
+	}
+	return name + "." + origin
+}
+
+func zoneFileSubDomain(owner, domain string) (string, error) {
+	owner = strings.ToLower(owner)
+	domainFqdn := strings.ToLower(strings.TrimSuffix(domain, ".")) + "."
+	if owner == domainFqdn {
+		return "@", nil
+	}
+	if strings.HasSuffix(owner, "."+domainFqdn) {
+		return strings.TrimSuffix(owner, "."+domainFqdn), nil
+	}
+	return "", fmt.Errorf("owner `%s` is out of the domain `%s`", owner, domain)
+}
+
+func zoneFileQuote(text string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(text) + `"`
+}
+
+// zoneFileRecordAttributes reads the DNSPod attributes from the comment of the record, the other contents of the comment are ignored.
+func zoneFileRecordAttributes(record *DnspodZoneRecord, comment string) error {
+	tokens, _, err := tokenizeZoneFileLine(comment)
+	if err != nil {
+		return err
+	}
+	for _, token := range tokens {
+		kv := strings.SplitN(token.text, "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		switch kv[0] {
+		case "line":
+			record.RecordLine = kv[1]
+		case "remark":
+			record.Remark = kv[1]
+		case "weight":
+			weight, err := strconv.ParseUint(kv[1], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid weight `%s`", kv[1])
+			}
+			record.Weight = weight
+		case "status":
+			switch strings.ToUpper(kv[1]) {
+			case DNSPOD_RECORD_STATUS_ENABLE:
+				record.Enabled = true
+			case DNSPOD_RECORD_STATUS_DISABLE:
+				record.Enabled = false
+			default:
+				return fmt.Errorf("invalid status `%s`, valid values are %v", kv[1], DNSPOD_RECORD_STATUS)
+			}
+		}
+	}
+	return nil
+}
+
+// ParseDnspodZoneFile parses the records of the BIND zone file of the domain. The SOA records are skipped since they are managed by DNSPod.
+// The attributes which BIND does not support are read from the comment of the record, such as `; line=默认 weight=10 status=DISABLE remark="web"`.
+func ParseDnspodZoneFile(domain, content string) ([]*DnspodZoneRecord, error) {
+	var (
+		origin     = strings.ToLower(strings.TrimSuffix(domain, ".")) + "."
+		defaultTTL = uint64(DNSPOD_DEFAULT_RECORD_TTL)
+		lastOwner  string
+		records    []*DnspodZoneRecord
+
+		tokens   []zoneFileToken
+		comments []string
+		depth    int
+		indented bool
+		lineNo   int
+		startNo  int
+	)
+
+	parseEntry := func() error {
+		if len(tokens) == 0 {
+			return nil
+		}
+
+		if !indented && strings.HasPrefix(tokens[0].text, "$") {
+			directive := strings.ToUpper(tokens[0].text)
+			switch {
+			case directive == "$ORIGIN" && len(tokens) == 2:
+				origin = strings.ToLower(zoneFileAbsoluteName(tokens[1].text, origin))
+			case directive == "$TTL" && len(tokens) == 2:
+				ttl, ok := parseZoneFileTTL(tokens[1].text)
+				if !ok {
+					return fmt.Errorf("invalid TTL `%s`", tokens[1].text)
+				}
+				defaultTTL = ttl
+			case directive == "$INCLUDE" || directive == "$GENERATE":
+				return fmt.Errorf("directive `%s` is not supported", tokens[0].text)
+			default:
+				return fmt.Errorf("malformed directive `%s`", tokens[0].text)
+			}
+			return nil
+		}
+
+		rest := tokens
+		if !indented {
+			lastOwner = strings.ToLower(zoneFileAbsoluteName(rest[0].text, origin))
+			rest = rest[1:]
+		}
+		if lastOwner == "" {
+			return fmt.Errorf("the record has no owner")
+		}
+
+		var ttl uint64
+		hasTTL := false
+		for len(rest) > 0 {
+			if value, ok := parseZoneFileTTL(rest[0].text); ok && !hasTTL {
+				ttl, hasTTL = value, true
+			} else if strings.EqualFold(rest[0].text, "IN") {
+				// the class is optional
+			} else {
+				break
+			}
+			rest = rest[1:]
+		}
+		if len(rest) == 0 {
+			return fmt.Errorf("the record has no type")
+		}
+		if !hasTTL {
+			ttl = defaultTTL
+		}
+
+		recordType := strings.ToUpper(rest[0].text)
+		rdata := rest[1:]
+		if recordType == "SOA" {
+			return nil
+		}
+		if len(rdata) == 0 {
+			return fmt.Errorf("the %s record has no data", recordType)
+		}
+
+		subDomain, err := zoneFileSubDomain(lastOwner, domain)
+		if err != nil {
+			return err
+		}
+		record := &DnspodZoneRecord{
+			SubDomain:  subDomain,
+			RecordType: recordType,
+			RecordLine: DNSPOD_DEFAULT_RECORD_LINE,
+			TTL:        ttl,
+			Enabled:    true,
+		}
+
+		switch recordType {
+		case "MX":
+			if len(rdata) != 2 {
+				return fmt.Errorf("the MX record should have a preference and an exchange")
+			}
+			mx, err := strconv.ParseUint(rdata[0].text, 10, 16)
+			if err != nil {
+				return fmt.Errorf("invalid MX preference `%s`", rdata[0].text)
+			}
+			record.MX = mx
+			record.Value = zoneFileAbsoluteName(rdata[1].text, origin)
+		case "CNAME", "NS", "PTR":
+			if len(rdata) != 1 {
+				return fmt.Errorf("the %s record should have exactly one name", recordType)
+			}
+			record.Value = zoneFileAbsoluteName(rdata[0].text, origin)
+		case "TXT", "SPF":
+			var value strings.Builder
+			for _, item := range rdata {
+				value.WriteString(item.text)
+			}
+			record.Value = value.String()
+		default:
+			values := make([]string, 0, len(rdata))
+			for _, item := range rdata {
+				if item.quoted {
+					values = append(values, zoneFileQuote(item.text))
+				} else {
+					values = append(values, item.text)
+				}
+			}
+			record.Value = strings.Join(values, " ")
+		}
+
+		if err := zoneFileRecordAttributes(record, strings.Join(comments, " ")); err != nil {
+			return err
+		}
+		records = append(records, record)
+		return nil
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		lineNo++
+		line := scanner.Text()
+		lineTokens, comment, err := tokenizeZoneFileLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", lineNo, err)
+		}
+
+		if depth == 0 {
+			if len(lineTokens) == 0 {
+				continue
+			}
+			tokens, comments = nil, nil
+			indented = line != "" && (line[0] == ' ' || line[0] == '\t')
+			startNo = lineNo
+		}
+		if comment != "" {
+			comments = append(comments, comment)
+		}
+		for _, token := range lineTokens {
+			switch {
+			case token.text == "(" && !token.quoted:
+				depth++
+			case token.text == ")" && !token.quoted:
+				if depth == 0 {
+					return nil, fmt.Errorf("line %d: unexpected `)`", lineNo)
+				}
+				depth--
+			default:
+				tokens = append(tokens, token)
+			}
+		}
+		if depth > 0 {
+			continue
+		}
+		if err := parseEntry(); err != nil {
+			return nil, fmt.Errorf("line %d: %v", startNo, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if depth > 0 {
+		return nil, fmt.Errorf("line %d: unclosed `(`", startNo)
+	}
+	return records, nil
+}
+
+func zoneFileIsStandardType(recordType string) bool {
+	for _, r := range recordType {
+		if !(r >= 'A' && r <= 'Z') && !(r >= '0' && r <= '9') {
+			return false
+		}
+	}
+	return recordType != ""
+}
+
+// zoneFileSplitText splits the text into quoted character strings of at most 255 bytes, without cutting a UTF-8 character.
+func zoneFileSplitText(text string) []string {
+	chunks := make([]string, 0, len(text)/255+1)
+	for len(text) > 255 {
+		end := 255
+		for end > 0 && !utf8.RuneStart(text[end]) {
+			end--
+		}
+		chunks = append(chunks, zoneFileQuote(text[:end]))
+		text = text[end:]
+	}
+	return append(chunks, zoneFileQuote(text))
+}
+
+// FormatDnspodZoneFile formats the records as a BIND zone file of the domain, which can be parsed by ParseDnspodZoneFile.
+// The records which are specific to DNSPod, such as the URL forwarding records, are written as comments.
+func FormatDnspodZoneFile(domain string, records []*DnspodZoneRecord) string {
+	sorted := make([]*DnspodZoneRecord, len(records))
+	copy(sorted, records)
+	SortDnspodZoneRecords(sorted)
+
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "$ORIGIN %s.\n", strings.TrimSuffix(domain, "."))
+	fmt.Fprintf(&buf, "$TTL %d\n", DNSPOD_DEFAULT_RECORD_TTL)
+
+	for _, record := range sorted {
+		var rdata string
+		switch record.RecordType {
+		case "MX":
+			rdata = fmt.Sprintf("%d %s", record.MX, dnspodAbsoluteValue(record.Value))
+		case "CNAME", "NS", "PTR":
+			rdata = dnspodAbsoluteValue(record.Value)
+		case "TXT", "SPF":
+			rdata = strings.Join(zoneFileSplitText(record.Value), " ")
+		default:
+			rdata = record.Value
+		}
+
+		line := fmt.Sprintf("%s\t%d\tIN\t%s\t%s", record.SubDomain, record.TTL, record.RecordType, rdata)
+		if !zoneFileIsStandardType(record.RecordType) {
+			buf.WriteString("; not supported by BIND: " + line + "\n")
+			continue
+		}
+
+		var attributes []string
+		if record.RecordLine != "" && record.RecordLine != DNSPOD_DEFAULT_RECORD_LINE {
+			attributes = append(attributes, "line="+record.RecordLine)
+		}
+		if record.Weight > 0 {
+			attributes = append(attributes, fmt.Sprintf("weight=%d", record.Weight))
+		}
+		if !record.Enabled {
+			attributes = append(attributes, "status="+DNSPOD_RECORD_STATUS_DISABLE)
+		}
+		if record.Remark != "" {
+			attributes = append(attributes, "remark="+zoneFileQuote(record.Remark))
+		}
+		if len(attributes) > 0 {
+			line += "\t; " + strings.Join(attributes, " ")
+		}
+		buf.WriteString(line + "\n")
+	}
+	return buf.String()
+}
+
+// SortDnspodZoneRecords sorts the records by the sub domain, type, line and value.
+func SortDnspodZoneRecords(records []*DnspodZoneRecord) {
+	sort.SliceStable(records, func(i, j int) bool {
+		a, b := records[i], records[j]
+		if a.SubDomain != b.SubDomain {
+			// the apex goes first
+			if a.SubDomain == "@" || b.SubDomain == "@" {
+				return a.SubDomain == "@"
+			}
+			return a.SubDomain < b.SubDomain
+		}
+		if a.RecordType != b.RecordType {
+			return a.RecordType < b.RecordType
+		}
+		if a.RecordLine != b.RecordLine {
+			return a.RecordLine < b.RecordLine
+		}
+		return a.Value < b.Value
+	})
+}
@@ -0,0 +1,318 @@
+package dnspod
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func testDnspodZoneRecordsString(records []*DnspodZoneRecord) string {
+	lines := make([]string, 0, len(records))
+	for _, record := range records {
+		lines = append(lines, testDnspodZoneRecordString(record))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func testDnspodZoneRecordString(record *DnspodZoneRecord) string {
+	status := DNSPOD_RECORD_STATUS_ENABLE
+	if !record.Enabled {
+		status = DNSPOD_RECORD_STATUS_DISABLE
+	}
+	return fmt.Sprintf("%s %s %s %s mx=%d ttl=%d weight=%d %s remark=%s",
+		record.SubDomain, record.RecordType, record.RecordLine, record.Value, record.MX, record.TTL, record.Weight, status, record.Remark)
+}
+
+func TestParseDnspodZoneFile(t *testing.T) {
+	cases := []struct {
+		name    string
+		content string
+		want    []string
+	}{
+		{
+			name: "relative and absolute names",
+			content: `
+@		IN	A	1.1.1.1
+www		300	IN	A	2.2.2.2
+api.example.com.	IN	CNAME	www
+WWW2	CNAME	cdn.example.net.
+@	MX	10 mail
+@	MX	20 mx.example.net.
+`,
+			want: []string{
+				"@ A 默认 1.1.1.1 mx=0 ttl=600 weight=0 ENABLE remark=",
+				"www A 默认 2.2.2.2 mx=0 ttl=300 weight=0 ENABLE remark=",
+				"api CNAME 默认 www.example.com. mx=0 ttl=600 weight=0 ENABLE remark=",
+				"www2 CNAME 默认 cdn.example.net. mx=0 ttl=600 weight=0 ENABLE remark=",
+				"@ MX 默认 mail.example.com. mx=10 ttl=600 weight=0 ENABLE remark=",
+				"@ MX 默认 mx.example.net. mx=20 ttl=600 weight=0 ENABLE remark=",
+			},
+		},
+		{
+			name: "origin and ttl directives",
+			content: `
+$TTL 1h30m
+www	A	1.1.1.1
+$ORIGIN dev.example.com.
+api	A	2.2.2.2
+@	60	CNAME	api
+$ORIGIN test
+$TTL 120
+db	A	3.3.3.3
+`,
+			want: []string{
+				"www A 默认 1.1.1.1 mx=0 ttl=5400 weight=0 ENABLE remark=",
+				"api.dev A 默认 2.2.2.2 mx=0 ttl=5400 weight=0 ENABLE remark=",
+				"dev CNAME 默认 api.dev.example.com. mx=0 ttl=60 weight=0 ENABLE remark=",
+				"db.test.dev A 默认 3.3.3.3 mx=0 ttl=120 weight=0 ENABLE remark=",
+			},
+		},
+		{
+			name: "parentheses and indented owner",
+			content: `
+@	IN	SOA	ns1.dnspod.net. admin.example.com. (
+		2024010101	; serial
+		3600 )
+www	IN	A	1.1.1.1
+	IN	A	2.2.2.2	; line=电信
+@	CAA	( 0 issue
+		"letsencrypt.org" )
+`,
+			want: []string{
+				"www A 默认 1.1.1.1 mx=0 ttl=600 weight=0 ENABLE remark=",
+				"www A 电信 2.2.2.2 mx=0 ttl=600 weight=0 ENABLE remark=",
+				`@ CAA 默认 0 issue "letsencrypt.org" mx=0 ttl=600 weight=0 ENABLE remark=`,
+			},
+		},
+		{
+			name: "TXT joining",
+			content: `
+@	TXT	"v=spf1 include:spf.example.net" " ~all"
+_dmarc	TXT	(
+		"v=DMARC1; p=none;"
+		" rua=mailto:dmarc@example.com" )
+quote	TXT	"say \"hi\" \\ bye"
+`,
+			want: []string{
+				"@ TXT 默认 v=spf1 include:spf.example.net ~all mx=0 ttl=600 weight=0 ENABLE remark=",
+				"_dmarc TXT 默认 v=DMARC1; p=none; rua=mailto:dmarc@example.com mx=0 ttl=600 weight=0 ENABLE remark=",
+				`quote TXT 默认 say "hi" \ bye mx=0 ttl=600 weight=0 ENABLE remark=`,
+			},
+		},
+		{
+			name: "comment attributes",
+			content: `
+www	A	1.1.1.1	; line=联通 weight=10 status=DISABLE remark="web server"
+www	A	2.2.2.2	; generated by hand, weight=5
+api	A	3.3.3.3	; status=enable
+`,
+			want: []string{
+				"www A 联通 1.1.1.1 mx=0 ttl=600 weight=10 DISABLE remark=web server",
+				"www A 默认 2.2.2.2 mx=0 ttl=600 weight=5 ENABLE remark=",
+				"api A 默认 3.3.3.3 mx=0 ttl=600 weight=0 ENABLE remark=",
+			},
+		},
+	}
+	for _, c := range cases {
+		records, err := ParseDnspodZoneFile("Example.com", c.content)
+		if err != nil {
+			t.Errorf("%s: %s", c.name, err)
+			continue
+		}
+		if got, want := testDnspodZoneRecordsString(records), strings.Join(c.want, "\n"); got != want {
+			t.Errorf("%s: got\n%s\nwant\n%s", c.name, got, want)
+		}
+	}
+}
+
+func TestParseDnspodZoneFileErrors(t *testing.T) {
+	cases := []struct {
+		content string
+		wantErr string
+	}{
+		{content: "www A (1.1.1.1", wantErr: "line 1: unclosed `(`"},
+		{content: "www A 1.1.1.1 )", wantErr: "line 1: unexpected `)`"},
+		{content: `www TXT "unterminated`, wantErr: "unterminated quoted string"},
+		{content: "$INCLUDE other.zone", wantErr: "directive `$INCLUDE` is not supported"},
+		{content: "$TTL forever", wantErr: "invalid TTL `forever`"},
+		{content: "\tA 1.1.1.1", wantErr: "the record has no owner"},
+		{content: "www 600 IN", wantErr: "the record has no type"},
+		{content: "www.example.net. A 1.1.1.1", wantErr: "is out of the domain"},
+		{content: "@ MX mail", wantErr: "should have a preference and an exchange"},
+		{content: "www CNAME a b", wantErr: "should have exactly one name"},
+		{content: "www A 1.1.1.1 ; weight=heavy", wantErr: "invalid weight `heavy`"},
+		{content: "www A 1.1.1.1 ; status=PAUSED", wantErr: "invalid status `PAUSED`"},
+	}
+	for _, c := range cases {
+		_, err := ParseDnspodZoneFile("example.com", c.content)
+		if err == nil || !strings.Contains(err.Error(), c.wantErr) {
+			t.Errorf("ParseDnspodZoneFile(%q) = %v, want error containing %q", c.content, err, c.wantErr)
+		}
+	}
+}
+
+func TestFormatDnspodZoneFileRoundTrip(t *testing.T) {
+	content := `
+$ORIGIN example.com.
+$TTL 300
+@	A	1.1.1.1	; weight=20
+@	MX	10 mail
+@	TXT	"v=spf1 -all"
+www	IN	A	2.2.2.2	; line=电信 status=DISABLE remark="say \"hi\""
+api	3600	CNAME	www
+_sip._tcp	SRV	10 60 5060 sip.example.com.
+`
+	records, err := ParseDnspodZoneFile("example.com", content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the records of DNSPod only, which are kept as comments
+	formatted := FormatDnspodZoneFile("example.com", append(records, &DnspodZoneRecord{
+		SubDomain: "go", RecordType: "显性URL", RecordLine: DNSPOD_DEFAULT_RECORD_LINE, Value: "https://example.net", TTL: 600, Enabled: true,
+	}))
+	if !strings.HasPrefix(formatted, "$ORIGIN example.com.\n$TTL 600\n") {
+		t.Errorf("unexpected header of\n%s", formatted)
+	}
+	if !strings.Contains(formatted, "; not supported by BIND: go\t600\tIN\t显性URL\thttps://example.net\n") {
+		t.Errorf("the URL record is not commented out in\n%s", formatted)
+	}
+
+	parsed, err := ParseDnspodZoneFile("example.com", formatted)
+	if err != nil {
+		t.Fatalf("%s in\n%s", err, formatted)
+	}
+	SortDnspodZoneRecords(records)
+	if got, want := testDnspodZoneRecordsString(parsed), testDnspodZoneRecordsString(records); got != want {
+		t.Errorf("round trip got\n%s\nwant\n%s\nformatted\n%s", got, want, formatted)
+	}
+	bindOnly := strings.Replace(formatted, "; not supported by BIND: go\t600\tIN\t显性URL\thttps://example.net\n", "", 1)
+	if again := FormatDnspodZoneFile("example.com", parsed); again != bindOnly {
+		t.Errorf("formatting is not stable:\n%s\n%s", formatted, again)
+	}
+}
+
+func TestFormatDnspodZoneFileLongTXT(t *testing.T) {
+	cases := []struct {
+		name  string
+		value string
+	}{
+		{name: "ascii", value: strings.Repeat("a", 600)},
+		// 3-byte runes, 255 is a rune boundary
+		{name: "three bytes", value: strings.Repeat("域", 200)},
+		// 2-byte runes, the byte 255 is the second half of a rune
+		{name: "two bytes", value: strings.Repeat("é", 300)},
+		{name: "two bytes after ascii", value: "a" + strings.Repeat("é", 300)},
+		{name: "four bytes", value: strings.Repeat("😀", 100)},
+	}
+	for _, c := range cases {
+		record := &DnspodZoneRecord{SubDomain: "@", RecordType: "TXT", RecordLine: DNSPOD_DEFAULT_RECORD_LINE, Value: c.value, TTL: 600, Enabled: true}
+		formatted := FormatDnspodZoneFile("example.com", []*DnspodZoneRecord{record})
+
+		line := strings.Split(formatted, "\n")[2]
+		tokens, _, err := tokenizeZoneFileLine(line)
+		if err != nil {
+			t.Fatalf("%s: %s", c.name, err)
+		}
+		for _, token := range tokens[4:] {
+			if !token.quoted || len(token.text) > 255 || !utf8.ValidString(token.text) {
+				t.Errorf("%s: invalid character string of %d bytes: %q", c.name, len(token.text), token.text)
+			}
+		}
+
+		parsed, err := ParseDnspodZoneFile("example.com", formatted)
+		if err != nil {
+			t.Fatalf("%s: %s", c.name, err)
+		}
+		if len(parsed) != 1 || parsed[0].Value != c.value {
+			t.Errorf("%s: the value is changed by the round trip", c.name)
+		}
+	}
+}
+
+func TestDiffDnspodZoneRecords(t *testing.T) {
+	record := func(id uint64, subDomain, recordType, value string, ttl uint64) *DnspodZoneRecord {
+		return &DnspodZoneRecord{RecordId: id, SubDomain: subDomain, RecordType: recordType, RecordLine: DNSPOD_DEFAULT_RECORD_LINE, Value: value, TTL: ttl, Enabled: true}
+	}
+	describe := func(records []*DnspodZoneRecord) []string {
+		result := make([]string, 0, len(records))
+		for _, item := range records {
+			result = append(result, fmt.Sprintf("%d %s ttl=%d", item.RecordId, item, item.TTL))
+		}
+		return result
+	}
+
+	cases := []struct {
+		name        string
+		current     []*DnspodZoneRecord
+		desired     []*DnspodZoneRecord
+		create      []string
+		modify      []string
+		deleteFirst []string
+		delete      []string
+	}{
+		{
+			name:    "unchanged with other spelling of names",
+			current: []*DnspodZoneRecord{record(1, "www", "CNAME", "CDN.example.net.", 600), record(2, "@", "TXT", "v=spf1 -all", 600)},
+			desired: []*DnspodZoneRecord{record(0, "www", "CNAME", "cdn.example.net", 600), record(0, "@", "TXT", `"v=spf1 -all"`, 600)},
+		},
+		{
+			name:    "attributes modified in place",
+			current: []*DnspodZoneRecord{record(1, "www", "A", "1.1.1.1", 600), record(2, "www", "A", "2.2.2.2", 600)},
+			desired: []*DnspodZoneRecord{record(0, "www", "A", "2.2.2.2", 300), record(0, "www", "A", "1.1.1.1", 600)},
+			modify:  []string{"2 www A(默认) 2.2.2.2 ttl=300"},
+		},
+		{
+			name:    "values of the same group modified into each other",
+			current: []*DnspodZoneRecord{record(1, "www", "A", "1.1.1.1", 600), record(2, "www", "A", "2.2.2.2", 600), record(3, "www", "A", "3.3.3.3", 600)},
+			desired: []*DnspodZoneRecord{record(0, "www", "A", "2.2.2.2", 600), record(0, "www", "A", "5.5.5.5", 600), record(0, "www", "A", "4.4.4.4", 600), record(0, "www", "A", "6.6.6.6", 600)},
+			create:  []string{"0 www A(默认) 6.6.6.6 ttl=600"},
+			modify:  []string{"1 www A(默认) 4.4.4.4 ttl=600", "3 www A(默认) 5.5.5.5 ttl=600"},
+		},
+		{
+			name:    "stale records deleted",
+			current: []*DnspodZoneRecord{record(1, "www", "A", "1.1.1.1", 600), record(2, "old", "A", "2.2.2.2", 600), record(3, "www", "AAAA", "::1", 600)},
+			desired: []*DnspodZoneRecord{record(0, "www", "A", "1.1.1.1", 600)},
+			delete:  []string{"2 old A(默认) 2.2.2.2 ttl=600", "3 www AAAA(默认) ::1 ttl=600"},
+		},
+		{
+			name:        "CNAME replacing A deleted first",
+			current:     []*DnspodZoneRecord{record(1, "www", "A", "1.1.1.1", 600), record(2, "api", "A", "2.2.2.2", 600)},
+			desired:     []*DnspodZoneRecord{record(0, "www", "CNAME", "cdn.example.net.", 600)},
+			create:      []string{"0 www CNAME(默认) cdn.example.net. ttl=600"},
+			deleteFirst: []string{"1 www A(默认) 1.1.1.1 ttl=600"},
+			delete:      []string{"2 api A(默认) 2.2.2.2 ttl=600"},
+		},
+		{
+			name:        "A replacing CNAME deleted first",
+			current:     []*DnspodZoneRecord{record(1, "www", "CNAME", "cdn.example.net.", 600)},
+			desired:     []*DnspodZoneRecord{record(0, "www", "A", "1.1.1.1", 600)},
+			create:      []string{"0 www A(默认) 1.1.1.1 ttl=600"},
+			deleteFirst: []string{"1 www CNAME(默认) cdn.example.net. ttl=600"},
+		},
+	}
+	for _, c := range cases {
+		change := DiffDnspodZoneRecords(c.current, c.desired)
+		if c.create == nil && c.modify == nil && c.deleteFirst == nil && c.delete == nil && !change.Empty() {
+			t.Errorf("%s: expected no change, got %+v", c.name, change)
+			continue
+		}
+		for _, item := range []struct {
+			kind      string
+			got, want []string
+		}{
+			{"create", describe(change.Create), c.create},
+			{"modify", describe(change.Modify), c.modify},
+			{"delete first", describe(change.DeleteFirst), c.deleteFirst},
+			{"delete", describe(change.Delete), c.delete},
+		} {
+			if len(item.got) == 0 && len(item.want) == 0 {
+				continue
+			}
+			if !reflect.DeepEqual(item.got, item.want) {
+				t.Errorf("%s: %s got %v, want %v", c.name, item.kind, item.got, item.want)
+			}
+		}
+	}
+}
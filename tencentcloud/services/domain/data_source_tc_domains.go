@@ -144,7 +144,7 @@ func datasourceTencentCloudDomainsRead(d *schema.ResourceData, meta interface{})
 	}
 
 	if output, ok := d.GetOk("result_output_file"); ok {
-		return tccommon.WriteToFile(output.(string), result)
+		return tccommon.WriteToFile(output.(string), result, tccommon.GetResultOutputOption(d))
 	}
 
 	return nil
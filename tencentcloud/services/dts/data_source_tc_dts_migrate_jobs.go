@@ -1246,7 +1246,7 @@ func dataSourceTencentCloudDtsMigrateJobsRead(d *schema.ResourceData, meta inter
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), jobList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), jobList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
@@ -1362,7 +1362,7 @@ func dataSourceTencentCloudDtsSyncJobsRead(d *schema.ResourceData, meta interfac
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), jobList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), jobList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
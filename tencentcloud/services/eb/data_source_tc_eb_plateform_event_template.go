@@ -73,7 +73,7 @@ func dataSourceTencentCloudEbPlateformEventTemplateRead(d *schema.ResourceData,
 	d.SetId(helper.DataResourceIdsHash([]string{eventType, *eventTemplate}))
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), eventTemplate); e != nil {
+		if e := tccommon.WriteToFile(output.(string), eventTemplate, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
@@ -106,7 +106,7 @@ func dataSourceTencentCloudEbPlatformEventPatternsRead(d *schema.ResourceData, m
 	d.SetId(helper.DataResourceIdsHash(append(ids, productType)))
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), tmpList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), tmpList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
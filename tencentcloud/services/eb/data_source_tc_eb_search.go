@@ -342,7 +342,7 @@ func dataSourceTencentCloudEbSearchRead(d *schema.ResourceData, meta interface{}
 	d.SetId(helper.DataResourceIdsHash([]string{startTime, endTime, eventBusId}))
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), d); e != nil {
+		if e := tccommon.WriteToFile(output.(string), d, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
@@ -171,7 +171,7 @@ func dataSourceTencentCloudEmrRead(d *schema.ResourceData, meta interface{}) err
 	}
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if err := tccommon.WriteToFile(output.(string), emr_instances); err != nil {
+		if err := tccommon.WriteToFile(output.(string), emr_instances, tccommon.GetResultOutputOption(d)); err != nil {
 			return err
 		}
 	}
@@ -505,7 +505,7 @@ func dataSourceTencentCloudEmrNodesRead(d *schema.ResourceData, meta interface{}
 	_ = d.Set("nodes", emrNodes)
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if err := tccommon.WriteToFile(output.(string), emrNodes); err != nil {
+		if err := tccommon.WriteToFile(output.(string), emrNodes, tccommon.GetResultOutputOption(d)); err != nil {
 			return err
 		}
 	}
@@ -578,7 +578,7 @@ func dataSourceTencentCloudElasticsearchViewsRead(d *schema.ResourceData, meta i
 	result["kibanas_view"] = kibanasViewMapList
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), result); e != nil {
+		if e := tccommon.WriteToFile(output.(string), result, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
@@ -65,7 +65,7 @@ func dataSourceTencentCloudGaapBlackHeaderRead(d *schema.ResourceData, meta inte
 	d.SetId(helper.DataResourceIdsHash(ids))
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), blackHeaders); e != nil {
+		if e := tccommon.WriteToFile(output.(string), blackHeaders, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
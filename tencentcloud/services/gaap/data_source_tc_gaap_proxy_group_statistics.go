@@ -178,7 +178,7 @@ func dataSourceTencentCloudGaapProxyGroupStatisticsRead(d *schema.ResourceData,
 	d.SetId(groupId)
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), tmpList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), tmpList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
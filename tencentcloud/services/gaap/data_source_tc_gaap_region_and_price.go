@@ -251,7 +251,7 @@ func dataSourceTencentCloudGaapRegionAndPriceRead(d *schema.ResourceData, meta i
 	}
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), result); e != nil {
+		if e := tccommon.WriteToFile(output.(string), result, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
@@ -77,7 +77,7 @@ func dataSourceTencentCloudGaapSecurityPoliciesRead(d *schema.ResourceData, m in
 	}
 
 	if output, ok := d.GetOk("result_output_file"); ok && output.(string) != "" {
-		if err := tccommon.WriteToFile(output.(string), m); err != nil {
+		if err := tccommon.WriteToFile(output.(string), m, tccommon.GetResultOutputOption(d)); err != nil {
 			log.Printf("[CRITAL]%s output file[%s] fail, reason[%s]\n",
 				logId, output.(string), err.Error())
 			return err
@@ -87,7 +87,7 @@ func dataSourceTencentCloudKmsPublicKeyRead(d *schema.ResourceData, meta interfa
 	d.SetId(keyId)
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), d); e != nil {
+		if e := tccommon.WriteToFile(output.(string), d, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
@@ -130,7 +130,7 @@ func dataSourceTencentCloudLighthouseAllSceneRead(d *schema.ResourceData, meta i
 	_ = d.Set("scene_set", tmpList)
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), tmpList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), tmpList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
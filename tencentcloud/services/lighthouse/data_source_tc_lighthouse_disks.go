@@ -293,7 +293,7 @@ func dataSourceTencentCloudLighthouseInstanceDisksRead(d *schema.ResourceData, m
 	_ = d.Set("disk_list", diskList)
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), diskList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), diskList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
@@ -72,7 +72,7 @@ func dataSourceTencentCloudLighthouseInstanceVncUrlRead(d *schema.ResourceData,
 	if ok && output.(string) != "" {
 		if e := tccommon.WriteToFile(output.(string), map[string]interface{}{
 			"instance_vnc_url": *response.Response.InstanceVncUrl,
-		}); e != nil {
+		}, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
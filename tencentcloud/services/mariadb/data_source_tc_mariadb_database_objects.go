@@ -192,7 +192,7 @@ func dataSourceTencentCloudMariadbDatabaseObjectsRead(d *schema.ResourceData, me
 	d.SetId(instanceId + tccommon.FILED_SP + dbName)
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), d); e != nil {
+		if e := tccommon.WriteToFile(output.(string), d, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
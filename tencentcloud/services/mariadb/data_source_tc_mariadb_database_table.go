@@ -127,7 +127,7 @@ func dataSourceTencentCloudMariadbDatabaseTableRead(d *schema.ResourceData, meta
 	d.SetId(instanceId + tccommon.FILED_SP + dbName + tccommon.FILED_SP + table)
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), d); e != nil {
+		if e := tccommon.WriteToFile(output.(string), d, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
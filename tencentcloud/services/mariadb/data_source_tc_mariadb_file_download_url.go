@@ -84,7 +84,7 @@ func dataSourceTencentCloudMariadbFileDownloadUrlRead(d *schema.ResourceData, me
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), d); e != nil {
+		if e := tccommon.WriteToFile(output.(string), d, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
@@ -139,7 +139,7 @@ func dataSourceTencentCloudMariadbOrdersRead(d *schema.ResourceData, meta interf
 	d.SetId(dealName)
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), tmpList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), tmpList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
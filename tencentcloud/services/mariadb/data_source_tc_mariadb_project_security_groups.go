@@ -251,7 +251,7 @@ func dataSourceTencentCloudMariadbProjectSecurityGroupsRead(d *schema.ResourceDa
 	d.SetId(Product)
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), tmpList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), tmpList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
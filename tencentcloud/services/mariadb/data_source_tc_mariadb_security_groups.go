@@ -242,7 +242,7 @@ func dataSourceTencentCloudMariadbSecurityGroupsRead(d *schema.ResourceData, met
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), groupList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), groupList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
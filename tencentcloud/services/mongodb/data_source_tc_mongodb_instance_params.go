@@ -474,7 +474,7 @@ func dataSourceTencentCloudMongodbInstanceParamsRead(d *schema.ResourceData, met
 	d.SetId(helper.DataResourceIdsHash(ids))
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), paramList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), paramList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
@@ -146,7 +146,7 @@ func dataSourceTencentCloudMongodbZoneConfigRead(d *schema.ResourceData, meta in
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if err := tccommon.WriteToFile(output.(string), configList); err != nil {
+		if err := tccommon.WriteToFile(output.(string), configList, tccommon.GetResultOutputOption(d)); err != nil {
 			return err
 		}
 	}
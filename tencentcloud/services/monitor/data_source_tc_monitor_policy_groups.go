@@ -469,7 +469,7 @@ func dataSourceTencentMonitorPolicyGroupsRead(d *schema.ResourceData, meta inter
 	d.SetId(id)
 
 	if output, ok := d.GetOk("result_output_file"); ok {
-		return tccommon.WriteToFile(output.(string), list)
+		return tccommon.WriteToFile(output.(string), list, tccommon.GetResultOutputOption(d))
 	}
 	return nil
 }
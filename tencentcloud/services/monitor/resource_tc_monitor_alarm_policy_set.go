@@ -0,0 +1,313 @@
+package monitor
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	tccommon "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	monitor "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/monitor/v20180724"
+
+	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/internal/helper"
+)
+
+func ResourceTencentCloudMonitorAlarmPolicySet() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceTencentCloudMonitorAlarmPolicySetCreate,
+		Read:   resourceTencentCloudMonitorAlarmPolicySetRead,
+		Update: resourceTencentCloudMonitorAlarmPolicySetUpdate,
+		Delete: resourceTencentCloudMonitorAlarmPolicySetDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceTencentCloudMonitorAlarmPolicySetImport,
+		},
+		CustomizeDiff: monitorAlarmPolicySetCustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the policy set, which is the ID of the resource.",
+			},
+			"rules": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Content of the rules file in YAML, such as `file(\"alerts.yaml\")`. Each group of the file is an alarm policy, see the example for the schema. The metrics, operators, periods and durations are validated against the metrics of the namespaces at plan time.",
+			},
+			"notice_ids": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "IDs of the notification rules of the policies whose groups do not set `notice_ids`.",
+			},
+			"project_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     -1,
+				Description: "Project ID of the policies. For products with different projects, a value other than -1 must be passed in.",
+			},
+			// computed
+			"policies": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Policies managed by the set.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the policy, which is the name of the group.",
+						},
+						"namespace": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Namespace of the policy.",
+						},
+						"monitor_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Monitor type of the policy.",
+						},
+						"policy_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ID of the policy.",
+						},
+						"content": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Status, remark, conditions, notices and bindings of the policy in a canonical text, which is read back to detect the changes made outside of Terraform.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func expandMonitorAlarmPolicySetItems(list []interface{}) []*MonitorAlarmPolicySetItem {
+	items := make([]*MonitorAlarmPolicySetItem, 0, len(list))
+	for _, item := range list {
+		m := item.(map[string]interface{})
+		items = append(items, &MonitorAlarmPolicySetItem{
+			Name:        m["name"].(string),
+			Namespace:   m["namespace"].(string),
+			MonitorType: m["monitor_type"].(string),
+			PolicyId:    m["policy_id"].(string),
+			Content:     m["content"].(string),
+		})
+	}
+	return items
+}
+
+func flattenMonitorAlarmPolicySetItems(items []*MonitorAlarmPolicySetItem) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(items))
+	for _, item := range items {
+		result = append(result, map[string]interface{}{
+			"name":         item.Name,
+			"namespace":    item.Namespace,
+			"monitor_type": item.MonitorType,
+			"policy_id":    item.PolicyId,
+			"content":      item.Content,
+		})
+	}
+	return result
+}
+
+func monitorAlarmPolicySetSpecs(d interface{ Get(string) interface{} }) ([]*MonitorAlarmPolicySpec, error) {
+	noticeIds := helper.InterfacesStrings(d.Get("notice_ids").([]interface{}))
+	specs, err := ParseMonitorAlarmRules(d.Get("rules").(string), noticeIds)
+	if err != nil {
+		return nil, fmt.Errorf("parse rules failed, %v", err)
+	}
+	return specs, nil
+}
+
+// monitorAlarmPolicySetCustomizeDiff parses and validates the rules at plan time, and plans an update if a policy of
+// the set is deleted, renamed or changed outside of Terraform.
+func monitorAlarmPolicySetCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("rules") || !d.NewValueKnown("notice_ids") {
+		return d.SetNewComputed("policies")
+	}
+
+	specs, err := monitorAlarmPolicySetSpecs(d)
+	if err != nil {
+		return err
+	}
+
+	if d.Id() == "" || d.HasChange("rules") {
+		logId := tccommon.GetLogId(tccommon.ContextNil)
+		ctx = context.WithValue(ctx, tccommon.LogIdKey, logId)
+		service := MonitorService{client: meta.(tccommon.ProviderMeta).GetAPIV3Conn()}
+		if err := service.CheckMonitorAlarmPolicySpecs(ctx, specs); err != nil {
+			return fmt.Errorf("check rules failed, %v", err)
+		}
+	}
+
+	if d.Id() == "" {
+		return nil
+	}
+	items := expandMonitorAlarmPolicySetItems(d.Get("policies").([]interface{}))
+	if len(items) != len(specs) {
+		return d.SetNewComputed("policies")
+	}
+	changed := false
+	for i, spec := range specs {
+		if items[i].Name != spec.Name || items[i].Namespace != spec.Namespace || items[i].MonitorType != spec.MonitorType {
+			return d.SetNewComputed("policies")
+		}
+		if content := spec.Content(); items[i].Content != content {
+			items[i].Content = content
+			changed = true
+		}
+	}
+	if changed {
+		return d.SetNew("policies", flattenMonitorAlarmPolicySetItems(items))
+	}
+	return nil
+}
+
+func resourceTencentCloudMonitorAlarmPolicySetCreate(d *schema.ResourceData, meta interface{}) error {
+	defer tccommon.LogElapsed("resource.tencentcloud_monitor_alarm_policy_set.create")()
+
+	logId := tccommon.GetLogId(tccommon.ContextNil)
+	ctx := context.WithValue(context.TODO(), tccommon.LogIdKey, logId)
+	service := MonitorService{client: meta.(tccommon.ProviderMeta).GetAPIV3Conn()}
+
+	specs, err := monitorAlarmPolicySetSpecs(d)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(d.Get("name").(string))
+	items, err := service.ApplyMonitorAlarmPolicySpecs(ctx, nil, specs, int64(d.Get("project_id").(int)))
+	_ = d.Set("policies", flattenMonitorAlarmPolicySetItems(items))
+	if err != nil {
+		log.Printf("[CRITAL]%s create monitor alarm policy set [%s] failed, reason:%+v", logId, d.Id(), err)
+		return err
+	}
+
+	return resourceTencentCloudMonitorAlarmPolicySetRead(d, meta)
+}
+
+func resourceTencentCloudMonitorAlarmPolicySetRead(d *schema.ResourceData, meta interface{}) error {
+	defer tccommon.LogElapsed("resource.tencentcloud_monitor_alarm_policy_set.read")()
+	defer tccommon.InconsistentCheck(d, meta)()
+
+	logId := tccommon.GetLogId(tccommon.ContextNil)
+	ctx := context.WithValue(context.TODO(), tccommon.LogIdKey, logId)
+	service := MonitorService{client: meta.(tccommon.ProviderMeta).GetAPIV3Conn()}
+
+	items := expandMonitorAlarmPolicySetItems(d.Get("policies").([]interface{}))
+	exists := make([]*MonitorAlarmPolicySetItem, 0, len(items))
+	for _, item := range items {
+		spec, err := service.DescribeMonitorAlarmPolicySpec(ctx, item.PolicyId)
+		if err != nil {
+			return err
+		}
+		if spec == nil {
+			log.Printf("[WARN]%s policy [%s] of monitor alarm policy set [%s] not found, please check if it has been deleted.\n", logId, item.PolicyId, d.Id())
+			continue
+		}
+		exists = append(exists, &MonitorAlarmPolicySetItem{
+			Name:        spec.Name,
+			Namespace:   spec.Namespace,
+			MonitorType: spec.MonitorType,
+			PolicyId:    item.PolicyId,
+			Content:     spec.Content(),
+		})
+	}
+
+	if len(items) > 0 && len(exists) == 0 {
+		log.Printf("[WARN]%s resource `MonitorAlarmPolicySet` [%s] not found, please check if it has been deleted.\n", logId, d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	_ = d.Set("name", d.Id())
+	_ = d.Set("policies", flattenMonitorAlarmPolicySetItems(exists))
+	return nil
+}
+
+// resourceTencentCloudMonitorAlarmPolicySetImport imports the set from its name and the IDs of its policies, the
+// project of the set is the project of the first policy.
+func resourceTencentCloudMonitorAlarmPolicySetImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	logId := tccommon.GetLogId(tccommon.ContextNil)
+	ctx := context.WithValue(context.TODO(), tccommon.LogIdKey, logId)
+	service := MonitorService{client: meta.(tccommon.ProviderMeta).GetAPIV3Conn()}
+
+	idSplit := strings.Split(d.Id(), tccommon.FILED_SP)
+	if len(idSplit) < 2 || idSplit[0] == "" {
+		return nil, fmt.Errorf("the id format must be '{name}#{policy_id}[#{policy_id}...]', got %s", d.Id())
+	}
+
+	var policy *monitor.AlarmPolicy
+	err := resource.Retry(tccommon.ReadRetryTimeout, func() *resource.RetryError {
+		result, e := service.DescribeAlarmPolicyById(ctx, idSplit[1])
+		if e != nil {
+			return tccommon.RetryError(e, tccommon.InternalError)
+		}
+		policy = result
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if policy == nil {
+		return nil, fmt.Errorf("policy %s of monitor alarm policy set %s is not found", idSplit[1], idSplit[0])
+	}
+
+	items := make([]*MonitorAlarmPolicySetItem, 0, len(idSplit)-1)
+	for _, policyId := range idSplit[1:] {
+		items = append(items, &MonitorAlarmPolicySetItem{PolicyId: policyId})
+	}
+	d.SetId(idSplit[0])
+	_ = d.Set("project_id", helper.PInt64(policy.ProjectId))
+	_ = d.Set("policies", flattenMonitorAlarmPolicySetItems(items))
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceTencentCloudMonitorAlarmPolicySetUpdate(d *schema.ResourceData, meta interface{}) error {
+	defer tccommon.LogElapsed("resource.tencentcloud_monitor_alarm_policy_set.update")()
+
+	logId := tccommon.GetLogId(tccommon.ContextNil)
+	ctx := context.WithValue(context.TODO(), tccommon.LogIdKey, logId)
+	service := MonitorService{client: meta.(tccommon.ProviderMeta).GetAPIV3Conn()}
+
+	specs, err := monitorAlarmPolicySetSpecs(d)
+	if err != nil {
+		return err
+	}
+
+	old, _ := d.GetChange("policies")
+	current := expandMonitorAlarmPolicySetItems(old.([]interface{}))
+	items, err := service.ApplyMonitorAlarmPolicySpecs(ctx, current, specs, int64(d.Get("project_id").(int)))
+	_ = d.Set("policies", flattenMonitorAlarmPolicySetItems(items))
+	if err != nil {
+		log.Printf("[CRITAL]%s update monitor alarm policy set [%s] failed, reason:%+v", logId, d.Id(), err)
+		return err
+	}
+
+	return resourceTencentCloudMonitorAlarmPolicySetRead(d, meta)
+}
+
+func resourceTencentCloudMonitorAlarmPolicySetDelete(d *schema.ResourceData, meta interface{}) error {
+	defer tccommon.LogElapsed("resource.tencentcloud_monitor_alarm_policy_set.delete")()
+
+	logId := tccommon.GetLogId(tccommon.ContextNil)
+	ctx := context.WithValue(context.TODO(), tccommon.LogIdKey, logId)
+	service := MonitorService{client: meta.(tccommon.ProviderMeta).GetAPIV3Conn()}
+
+	var policyIds []string
+	for _, item := range expandMonitorAlarmPolicySetItems(d.Get("policies").([]interface{})) {
+		policyIds = append(policyIds, item.PolicyId)
+	}
+	if len(policyIds) == 0 {
+		return nil
+	}
+	return service.DeleteMonitorAlarmPolicies(ctx, policyIds)
+}
@@ -0,0 +1,119 @@
+package monitor_test
+
+import (
+	"fmt"
+	"testing"
+
+	tcacctest "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/acctest"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccTencentCloudMonitorAlarmPolicySetResource_basic(t *testing.T) {
+	t.Parallel()
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { tcacctest.AccPreCheck(t) },
+		Providers: tcacctest.AccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMonitorAlarmPolicySet,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tencentcloud_monitor_alarm_policy_set.example", "policies.#", "2"),
+					resource.TestCheckResourceAttr("tencentcloud_monitor_alarm_policy_set.example", "policies.0.name", "tf-cvm-basic"),
+					resource.TestCheckResourceAttrSet("tencentcloud_monitor_alarm_policy_set.example", "policies.0.policy_id"),
+					resource.TestCheckResourceAttr("tencentcloud_monitor_alarm_policy_set.example", "policies.1.namespace", "cvm_device"),
+					resource.TestCheckResourceAttr("tencentcloud_monitor_alarm_policy_set.example", "policies.1.content",
+						"enabled: true\nremark: \nmatch: all\nnotice_ids: notice-f2svbu3w\nrule: MemUsage ge 90 period=60s for=3 repeat=0s"),
+				),
+			},
+			{
+				Config: testAccMonitorAlarmPolicySetUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tencentcloud_monitor_alarm_policy_set.example", "policies.#", "1"),
+					resource.TestCheckResourceAttr("tencentcloud_monitor_alarm_policy_set.example", "policies.0.name", "tf-cvm-basic"),
+					resource.TestCheckResourceAttr("tencentcloud_monitor_alarm_policy_set.example", "policies.0.content",
+						"enabled: false\nremark: \nmatch: any\nnotice_ids: notice-f2svbu3w\nrule: CpuUsage gt 85 period=60s for=5 repeat=0s"),
+				),
+			},
+			{
+				ResourceName:      "tencentcloud_monitor_alarm_policy_set.example",
+				ImportState:       true,
+				ImportStateIdFunc: testAccMonitorAlarmPolicySetImportId("tencentcloud_monitor_alarm_policy_set.example"),
+				ImportStateVerify: true,
+				// the rules file is not read back
+				ImportStateVerifyIgnore: []string{"rules", "notice_ids"},
+			},
+		},
+	})
+}
+
+func testAccMonitorAlarmPolicySetImportId(name string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return "", fmt.Errorf("resource %s is not found", name)
+		}
+		id := rs.Primary.ID
+		for i := 0; ; i++ {
+			policyId, ok := rs.Primary.Attributes[fmt.Sprintf("policies.%d.policy_id", i)]
+			if !ok {
+				break
+			}
+			id += "#" + policyId
+		}
+		return id, nil
+	}
+}
+
+const testAccMonitorAlarmPolicySet = `
+resource "tencentcloud_monitor_alarm_policy_set" "example" {
+  name       = "tf-example"
+  notice_ids = ["notice-f2svbu3w"]
+  project_id = 0
+  rules      = <<-EOT
+    groups:
+      - name: tf-cvm-basic
+        namespace: cvm_device
+        rules:
+          - alert: HighCpu
+            expr: CpuUsage > 80
+            for: 5m
+            repeat: 1h
+            labels:
+              severity: warning
+          - alert: CriticalCpu
+            expr: CpuUsage > 95
+            for: 5m
+            repeat: 1h
+            labels:
+              severity: critical
+        events:
+          - ping_unreachable
+      - name: tf-cvm-memory
+        namespace: cvm_device
+        match: all
+        rules:
+          - expr: MemUsage >= 90
+            for: 3m
+  EOT
+}
+`
+
+const testAccMonitorAlarmPolicySetUpdate = `
+resource "tencentcloud_monitor_alarm_policy_set" "example" {
+  name       = "tf-example"
+  notice_ids = ["notice-f2svbu3w"]
+  project_id = 0
+  rules      = <<-EOT
+    groups:
+      - name: tf-cvm-basic
+        namespace: cvm_device
+        enabled: false
+        rules:
+          - alert: HighCpu
+            expr: CpuUsage > 85
+            for: 5m
+  EOT
+}
+`
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"log"
 	"time"
 
 	tccommon "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/common"
@@ -18,9 +19,11 @@ import (
 
 func ResourceTencentCloudMonitorPolicyBindingObject() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceTencentMonitorPolicyBindingObjectCreate,
-		Read:   resourceTencentMonitorPolicyBindingObjectRead,
-		Delete: resourceTencentMonitorPolicyBindingObjectDelete,
+		Create:        resourceTencentMonitorPolicyBindingObjectCreate,
+		Read:          resourceTencentMonitorPolicyBindingObjectRead,
+		Update:        resourceTencentMonitorPolicyBindingObjectUpdate,
+		Delete:        resourceTencentMonitorPolicyBindingObjectDelete,
+		CustomizeDiff: monitorPolicyBindingObjectCustomizeDiff,
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
@@ -32,10 +35,11 @@ func ResourceTencentCloudMonitorPolicyBindingObject() *schema.Resource {
 				Description: "Alarm policy ID for binding objects.",
 			},
 			"dimensions": {
-				Type:        schema.TypeSet,
-				Required:    true,
-				ForceNew:    true,
-				Description: "A list objects. Each element contains the following attributes:",
+				Type:         schema.TypeSet,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"dimensions", "tag_selector"},
+				Description:  "A list objects. Changing the objects recreates the binding unless `tag_selector` is used, in which case the objects are computed. Each element contains the following attributes:",
 				Set: func(v interface{}) int {
 					vmap := v.(map[string]interface{})
 					hashMap := map[string]interface{}{}
@@ -50,7 +54,6 @@ func ResourceTencentCloudMonitorPolicyBindingObject() *schema.Resource {
 						"dimensions_json": {
 							Type:        schema.TypeString,
 							Required:    true,
-							ForceNew:    true,
 							Description: `Represents a collection of dimensions of an object instance, json format.eg:'{"unInstanceId":"ins-ot3cq4bi"}'.`,
 						},
 						"unique_id": {
@@ -61,10 +64,145 @@ func ResourceTencentCloudMonitorPolicyBindingObject() *schema.Resource {
 					},
 				},
 			},
+			"tag_selector": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: []string{"dimensions", "tag_selector"},
+				Description:  "Selects the objects by the tags of the resources in the region of the provider. The selected objects are queried from the tag service on every refresh, the objects created or tagged since then are bound and the others are unbound.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"service_type": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Service type of the resources, such as `cvm` or `clb`.",
+						},
+						"resource_prefix": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Resource prefix of the resources, such as `instance` for `cvm` or `clb` for `clb`.",
+						},
+						"dimension_key": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Dimension key of the objects whose value is the resource ID, such as `unInstanceId` for CVM instances. Only the objects identified by the resource ID can be selected.",
+						},
+						"project_id": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Default:     -1,
+							Description: "Project ID of the resources, -1 means any project. Only supported for `cvm/instance` and `clb/clb`.",
+						},
+						"tags": {
+							Type:        schema.TypeList,
+							Required:    true,
+							Description: "Tags of the resources, a resource is selected if it has all the tags.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"key": {
+										Type:        schema.TypeString,
+										Required:    true,
+										Description: "Tag key.",
+									},
+									"values": {
+										Type:        schema.TypeList,
+										Optional:    true,
+										Elem:        &schema.Schema{Type: schema.TypeString},
+										Description: "Tag values, any of which matches. Any value matches if empty.",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
 		},
 	}
 }
 
+func monitorPolicyBindingObjectTagSelector(d interface{ Get(string) interface{} }) *MonitorBindingTagSelector {
+	list := d.Get("tag_selector").([]interface{})
+	if len(list) == 0 || list[0] == nil {
+		return nil
+	}
+	m := list[0].(map[string]interface{})
+	selector := &MonitorBindingTagSelector{
+		ServiceType:    m["service_type"].(string),
+		ResourcePrefix: m["resource_prefix"].(string),
+		DimensionKey:   m["dimension_key"].(string),
+		ProjectId:      int64(m["project_id"].(int)),
+		Tags:           make(map[string][]string),
+	}
+	for _, item := range m["tags"].([]interface{}) {
+		if tag, ok := item.(map[string]interface{}); ok {
+			selector.Tags[tag["key"].(string)] = helper.InterfacesStrings(tag["values"].([]interface{}))
+		}
+	}
+	return selector
+}
+
+// monitorPolicyBindingObjectCustomizeDiff recreates the binding if the explicit objects change, and plans the bindings
+// and unbindings of the objects selected by the tags.
+func monitorPolicyBindingObjectCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if _, ok := d.GetOk("tag_selector"); !ok {
+		if d.Id() != "" && d.HasChange("dimensions") {
+			return d.ForceNew("dimensions")
+		}
+		return nil
+	}
+	if !d.NewValueKnown("tag_selector") {
+		return d.SetNewComputed("dimensions")
+	}
+
+	logId := tccommon.GetLogId(tccommon.ContextNil)
+	ctx = context.WithValue(ctx, tccommon.LogIdKey, logId)
+	service := MonitorService{client: meta.(tccommon.ProviderMeta).GetAPIV3Conn()}
+	desired, err := service.DescribeMonitorBindingObjectsByTags(ctx, monitorPolicyBindingObjectTagSelector(d))
+	if err != nil {
+		return fmt.Errorf("describe objects by tags failed, %v", err)
+	}
+
+	var (
+		current    = d.Get("dimensions").(*schema.Set).List()
+		dimensions = make([]interface{}, 0, len(desired))
+		retained   []string
+	)
+	for _, v := range current {
+		m := v.(map[string]interface{})
+		normalized, err := MonitorBindingDimensions(m["dimensions_json"].(string))
+		if err == nil && helper.StringsContain(desired, normalized) && !helper.StringsContain(retained, normalized) {
+			retained = append(retained, normalized)
+			dimensions = append(dimensions, m)
+		}
+	}
+	if len(retained) == len(current) && len(retained) == len(desired) {
+		return nil
+	}
+	for _, item := range desired {
+		if !helper.StringsContain(retained, item) {
+			dimensions = append(dimensions, map[string]interface{}{
+				"dimensions_json": item,
+			})
+		}
+	}
+	return d.SetNew("dimensions", dimensions)
+}
+
+// monitorPolicyBindingObjectBindings returns the objects to bind, which are queried from the tag service if the
+// tag selector is used.
+func monitorPolicyBindingObjectBindings(ctx context.Context, d *schema.ResourceData, service *MonitorService) ([]string, error) {
+	if selector := monitorPolicyBindingObjectTagSelector(d); selector != nil {
+		return service.DescribeMonitorBindingObjectsByTags(ctx, selector)
+	}
+	dimensions := d.Get("dimensions").(*schema.Set).List()
+	bindings := make([]string, 0, len(dimensions))
+	for _, v := range dimensions {
+		m := v.(map[string]interface{})
+		bindings = append(bindings, m["dimensions_json"].(string))
+	}
+	return bindings, nil
+}
+
 func resourceTencentMonitorPolicyBindingObjectCreate(d *schema.ResourceData, meta interface{}) error {
 	defer tccommon.LogElapsed("resource.tencentcloud_monitor_binding_object.create")()
 
@@ -85,14 +223,16 @@ func resourceTencentMonitorPolicyBindingObjectCreate(d *schema.ResourceData, met
 	}
 	request.GroupId = helper.Int64(0)
 	request.PolicyId = &policyId
-	dimensions := d.Get("dimensions").(*schema.Set).List()
+	dimensions, err := monitorPolicyBindingObjectBindings(ctx, d, &monitorService)
+	if err != nil {
+		return err
+	}
 
 	request.Dimensions = make([]*monitor.BindingPolicyObjectDimension, 0, len(dimensions))
 
 	for _, v := range dimensions {
-		m := v.(map[string]interface{})
 		var dimension monitor.BindingPolicyObjectDimension
-		var dimensionsJson = m["dimensions_json"].(string)
+		var dimensionsJson = v
 		var region = MonitorRegionMap[monitorService.client.Region]
 
 		if region == "" {
@@ -103,6 +243,11 @@ func resourceTencentMonitorPolicyBindingObjectCreate(d *schema.ResourceData, met
 		request.Dimensions = append(request.Dimensions, &dimension)
 	}
 
+	d.SetId(policyId)
+	if len(request.Dimensions) == 0 {
+		return resourceTencentMonitorPolicyBindingObjectRead(d, meta)
+	}
+
 	request.Module = helper.String("monitor")
 	if err = resource.Retry(tccommon.WriteRetryTimeout, func() *resource.RetryError {
 		ratelimit.Check(request.GetAction())
@@ -111,10 +256,10 @@ func resourceTencentMonitorPolicyBindingObjectCreate(d *schema.ResourceData, met
 		}
 		return nil
 	}); err != nil {
+		d.SetId("")
 		return err
 	}
 
-	d.SetId(policyId)
 	time.Sleep(3 * time.Second)
 
 	return resourceTencentMonitorPolicyBindingObjectRead(d, meta)
@@ -160,6 +305,38 @@ func resourceTencentMonitorPolicyBindingObjectRead(d *schema.ResourceData, meta
 	return d.Set("dimensions", newDimensions)
 }
 
+func resourceTencentMonitorPolicyBindingObjectUpdate(d *schema.ResourceData, meta interface{}) error {
+	defer tccommon.LogElapsed("resource.tencentcloud_monitor_policy_binding_object.update")()
+
+	var (
+		logId          = tccommon.GetLogId(tccommon.ContextNil)
+		ctx            = context.WithValue(context.TODO(), tccommon.LogIdKey, logId)
+		monitorService = MonitorService{client: meta.(tccommon.ProviderMeta).GetAPIV3Conn()}
+		policyId       = d.Id()
+	)
+
+	dimensions, err := monitorPolicyBindingObjectBindings(ctx, d, &monitorService)
+	if err != nil {
+		return err
+	}
+	bindings := make([]string, 0, len(dimensions))
+	for _, item := range dimensions {
+		normalized, err := MonitorBindingDimensions(item)
+		if err != nil {
+			return fmt.Errorf("invalid dimensions_json `%s`, %v", item, err)
+		}
+		bindings = append(bindings, normalized)
+	}
+
+	if err = monitorService.BindMonitorAlarmPolicyObjects(ctx, policyId, bindings); err != nil {
+		log.Printf("[CRITAL]%s update monitor policy binding object [%s] failed, reason:%+v", logId, policyId, err)
+		return err
+	}
+	time.Sleep(3 * time.Second)
+
+	return resourceTencentMonitorPolicyBindingObjectRead(d, meta)
+}
+
 func resourceTencentMonitorPolicyBindingObjectDelete(d *schema.ResourceData, meta interface{}) error {
 	defer tccommon.LogElapsed("resource.tencentcloud_monitor_binding_object.delete")()
 
@@ -204,6 +381,9 @@ func resourceTencentMonitorPolicyBindingObjectDelete(d *schema.ResourceData, met
 			uniqueIds = append(uniqueIds, &uniqueId)
 		}
 	}
+	if len(uniqueIds) == 0 {
+		return nil
+	}
 
 	var (
 		request = monitor.NewUnBindingPolicyObjectRequest()
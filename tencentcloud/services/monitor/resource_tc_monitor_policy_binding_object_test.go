@@ -0,0 +1,148 @@
+package monitor_test
+
+import (
+	"fmt"
+	"testing"
+
+	tcacctest "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/acctest"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccTencentCloudMonitorPolicyBindingObjectResource_tagSelector(t *testing.T) {
+	t.Parallel()
+	var id string
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { tcacctest.AccPreCheck(t) },
+		Providers: tcacctest.AccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMonitorPolicyBindingObjectDimensions,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMonitorPolicyBindingObjectId("tencentcloud_monitor_policy_binding_object.binding", &id, false),
+					resource.TestCheckResourceAttr("tencentcloud_monitor_policy_binding_object.binding", "dimensions.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs("tencentcloud_monitor_policy_binding_object.binding", "dimensions.*", map[string]string{
+						"dimensions_json": fmt.Sprintf(`{"unInstanceId":"%s"}`, tcacctest.DefaultCommonCvmId),
+					}),
+				),
+			},
+			{
+				// switching to the tag selector which selects the same instance does not replace the binding
+				Config: testAccMonitorPolicyBindingObjectTagSelector,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMonitorPolicyBindingObjectId("tencentcloud_monitor_policy_binding_object.binding", &id, true),
+					resource.TestCheckResourceAttr("tencentcloud_monitor_policy_binding_object.binding", "tag_selector.#", "1"),
+					resource.TestCheckResourceAttr("tencentcloud_monitor_policy_binding_object.binding", "tag_selector.0.project_id", "-1"),
+					resource.TestCheckResourceAttr("tencentcloud_monitor_policy_binding_object.binding", "dimensions.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs("tencentcloud_monitor_policy_binding_object.binding", "dimensions.*", map[string]string{
+						"dimensions_json": fmt.Sprintf(`{"unInstanceId":"%s"}`, tcacctest.DefaultCommonCvmId),
+					}),
+				),
+			},
+			{
+				// the instance does not have the second tag key, so it is unbound
+				Config: testAccMonitorPolicyBindingObjectTagSelectorUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMonitorPolicyBindingObjectId("tencentcloud_monitor_policy_binding_object.binding", &id, true),
+					resource.TestCheckResourceAttr("tencentcloud_monitor_policy_binding_object.binding", "tag_selector.0.tags.#", "2"),
+					resource.TestCheckResourceAttr("tencentcloud_monitor_policy_binding_object.binding", "dimensions.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckMonitorPolicyBindingObjectId records the id of the binding, or checks it is unchanged if same is set.
+func testAccCheckMonitorPolicyBindingObjectId(name string, id *string, same bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("resource %s is not found", name)
+		}
+		if same && rs.Primary.ID != *id {
+			return fmt.Errorf("binding %s is replaced by %s", *id, rs.Primary.ID)
+		}
+		*id = rs.Primary.ID
+		return nil
+	}
+}
+
+const testAccMonitorPolicyBindingObjectBasic = tcacctest.DefaultCvmModificationVariable + `
+data "tencentcloud_user_info" "info" {}
+
+resource "tencentcloud_tag_attachment" "tag_attachment" {
+  tag_key   = "tf_monitor_binding"
+  tag_value = "web"
+  resource  = "qcs::cvm:ap-guangzhou:uin/${data.tencentcloud_user_info.info.uin}:instance/${var.cvm_id}"
+}
+
+resource "tencentcloud_monitor_alarm_policy" "policy" {
+  policy_name  = "tf-binding-tag-selector"
+  monitor_type = "MT_QCE"
+  enable       = 1
+  project_id   = 0
+  namespace    = "cvm_device"
+
+  conditions {
+    is_union_rule = 0
+    rules {
+      metric_name      = "CpuUsage"
+      period           = 60
+      operator         = "ge"
+      value            = "89.9"
+      continue_period  = 1
+      notice_frequency = 3600
+      is_power_notice  = 0
+    }
+  }
+}
+`
+
+const testAccMonitorPolicyBindingObjectDimensions = testAccMonitorPolicyBindingObjectBasic + `
+resource "tencentcloud_monitor_policy_binding_object" "binding" {
+  policy_id = tencentcloud_monitor_alarm_policy.policy.id
+
+  dimensions {
+    dimensions_json = "{\"unInstanceId\":\"${var.cvm_id}\"}"
+  }
+}
+`
+
+const testAccMonitorPolicyBindingObjectTagSelector = testAccMonitorPolicyBindingObjectBasic + `
+resource "tencentcloud_monitor_policy_binding_object" "binding" {
+  policy_id = tencentcloud_monitor_alarm_policy.policy.id
+
+  tag_selector {
+    service_type    = "cvm"
+    resource_prefix = "instance"
+    dimension_key   = "unInstanceId"
+
+    tags {
+      key    = tencentcloud_tag_attachment.tag_attachment.tag_key
+      values = ["web", "api"]
+    }
+  }
+}
+`
+
+const testAccMonitorPolicyBindingObjectTagSelectorUpdate = testAccMonitorPolicyBindingObjectBasic + `
+resource "tencentcloud_monitor_policy_binding_object" "binding" {
+  policy_id = tencentcloud_monitor_alarm_policy.policy.id
+
+  tag_selector {
+    service_type    = "cvm"
+    resource_prefix = "instance"
+    dimension_key   = "unInstanceId"
+
+    tags {
+      key    = tencentcloud_tag_attachment.tag_attachment.tag_key
+      values = ["web", "api"]
+    }
+
+    tags {
+      key = "tf_monitor_binding_absent"
+    }
+  }
+}
+`
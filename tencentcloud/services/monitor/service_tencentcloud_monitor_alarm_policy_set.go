@@ -0,0 +1,842 @@
+package monitor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	monitor "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/monitor/v20180724"
+	"gopkg.in/yaml.v2"
+
+	tccommon "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/common"
+	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/internal/helper"
+	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/ratelimit"
+)
+
+const (
+	MONITOR_ALARM_SEVERITY_INFO     = "info"
+	MONITOR_ALARM_SEVERITY_WARNING  = "warning"
+	MONITOR_ALARM_SEVERITY_CRITICAL = "critical"
+
+	MONITOR_ALARM_MATCH_ANY = "any"
+	MONITOR_ALARM_MATCH_ALL = "all"
+
+	MONITOR_ALARM_DEFAULT_MONITOR_TYPE = "MT_QCE"
+	MONITOR_ALARM_DEFAULT_PERIOD       = 60
+)
+
+var monitorAlarmRuleOperators = map[string]string{
+	">":  "gt",
+	">=": "ge",
+	"<":  "lt",
+	"<=": "le",
+	"==": "eq",
+	"!=": "ne",
+}
+
+var monitorAlarmRuleExprRegexp = regexp.MustCompile(`^\s*([A-Za-z_][\w.]*)\s*(>=|<=|==|!=|>|<)\s*(\S+)\s*$`)
+
+// MonitorAlarmRuleFile is the rules file of tencentcloud_monitor_alarm_policy_set, each group is a policy.
+type MonitorAlarmRuleFile struct {
+	Groups []MonitorAlarmRuleGroup `yaml:"groups"`
+}
+
+type MonitorAlarmRuleGroup struct {
+	Name        string              `yaml:"name"`
+	Namespace   string              `yaml:"namespace"`
+	MonitorType string              `yaml:"monitor_type"`
+	Match       string              `yaml:"match"`
+	Enabled     *bool               `yaml:"enabled"`
+	Remark      string              `yaml:"remark"`
+	NoticeIds   []string            `yaml:"notice_ids"`
+	Bindings    []map[string]string `yaml:"bindings"`
+	Rules       []MonitorAlarmRule  `yaml:"rules"`
+	Events      []string            `yaml:"events"`
+}
+
+type MonitorAlarmRule struct {
+	Alert  string            `yaml:"alert"`
+	Expr   string            `yaml:"expr"`
+	Period string            `yaml:"period"`
+	For    string            `yaml:"for"`
+	Repeat string            `yaml:"repeat"`
+	Labels map[string]string `yaml:"labels"`
+}
+
+// MonitorAlarmPolicySpec is a policy translated from a group of the rules file.
+type MonitorAlarmPolicySpec struct {
+	Name        string
+	Namespace   string
+	MonitorType string
+	Remark      string
+	Enable      int64
+	IsUnionRule int64
+	NoticeIds   []string
+	// Bindings are the dimensions of the objects in json, with the keys sorted.
+	Bindings   []string
+	Rules      []*monitor.AlarmPolicyRule
+	EventRules []*monitor.AlarmPolicyRule
+}
+
+// MonitorAlarmPolicySetItem is a policy managed by tencentcloud_monitor_alarm_policy_set.
+type MonitorAlarmPolicySetItem struct {
+	Name        string
+	Namespace   string
+	MonitorType string
+	PolicyId    string
+	// Content is the content of the policy read back, see MonitorAlarmPolicySpec.Content.
+	Content string
+}
+
+// MonitorAlarmPolicySpecOf returns the spec of an existing policy with the objects bound to it.
+func MonitorAlarmPolicySpecOf(policy *monitor.AlarmPolicy, bindings []string) *MonitorAlarmPolicySpec {
+	spec := &MonitorAlarmPolicySpec{
+		Name:        helper.PString(policy.PolicyName),
+		Namespace:   helper.PString(policy.Namespace),
+		MonitorType: helper.PString(policy.MonitorType),
+		Remark:      helper.PString(policy.Remark),
+		Enable:      helper.PInt64(policy.Enable),
+		NoticeIds:   helper.PStrings(policy.NoticeIds),
+		Bindings:    bindings,
+	}
+	if policy.Condition != nil {
+		spec.IsUnionRule = helper.PInt64(policy.Condition.IsUnionRule)
+		spec.Rules = policy.Condition.Rules
+	}
+	if policy.EventCondition != nil {
+		spec.EventRules = policy.EventCondition.Rules
+	}
+	return spec
+}
+
+// Content returns the status, remark, conditions, notices and bindings of the policy in a canonical text, one item
+// per line. The content of a spec parsed from the rules file equals the content of the policy created from it.
+func (s *MonitorAlarmPolicySpec) Content() string {
+	lines := []string{
+		fmt.Sprintf("enabled: %t", s.Enable == 1),
+		fmt.Sprintf("remark: %s", s.Remark),
+	}
+	if len(s.Rules) > 0 {
+		match := MONITOR_ALARM_MATCH_ANY
+		if s.IsUnionRule == 1 {
+			match = MONITOR_ALARM_MATCH_ALL
+		}
+		lines = append(lines, fmt.Sprintf("match: %s", match))
+	}
+	noticeIds := append([]string{}, s.NoticeIds...)
+	sort.Strings(noticeIds)
+	lines = append(lines, fmt.Sprintf("notice_ids: %s", strings.Join(noticeIds, ",")))
+
+	var items []string
+	for _, binding := range s.Bindings {
+		items = append(items, "binding: "+binding)
+	}
+	sort.Strings(items)
+	lines = append(lines, items...)
+
+	items = items[:0]
+	for _, rule := range s.Rules {
+		items = append(items, "rule: "+monitorAlarmPolicyRuleContent(rule))
+	}
+	sort.Strings(items)
+	lines = append(lines, items...)
+
+	items = items[:0]
+	for _, rule := range s.EventRules {
+		items = append(items, "event: "+helper.PString(rule.MetricName))
+	}
+	sort.Strings(items)
+	lines = append(lines, items...)
+	return strings.Join(lines, "\n")
+}
+
+func monitorAlarmPolicyRuleContent(rule *monitor.AlarmPolicyRule) string {
+	var thresholds []string
+	if value := rule.HierarchicalValue; value != nil {
+		for _, level := range []struct {
+			severity  string
+			threshold *string
+		}{
+			{MONITOR_ALARM_SEVERITY_INFO, value.Remind},
+			{MONITOR_ALARM_SEVERITY_WARNING, value.Warn},
+			{MONITOR_ALARM_SEVERITY_CRITICAL, value.Serious},
+		} {
+			if threshold := monitorAlarmThreshold(level.threshold); threshold != "" {
+				thresholds = append(thresholds, level.severity+"="+threshold)
+			}
+		}
+	}
+	if len(thresholds) == 0 {
+		thresholds = append(thresholds, monitorAlarmThreshold(rule.Value))
+	}
+	return fmt.Sprintf("%s %s %s period=%ds for=%d repeat=%ds", helper.PString(rule.MetricName), helper.PString(rule.Operator),
+		strings.Join(thresholds, " "), helper.PInt64(rule.Period), helper.PInt64(rule.ContinuePeriod), helper.PInt64(rule.NoticeFrequency))
+}
+
+// monitorAlarmThreshold formats a threshold as a number, the policies return `80` as `80.0` or `80.00` sometimes.
+func monitorAlarmThreshold(threshold *string) string {
+	value := strings.TrimSpace(helper.PString(threshold))
+	if number, err := strconv.ParseFloat(value, 64); err == nil {
+		return strconv.FormatFloat(number, 'f', -1, 64)
+	}
+	return value
+}
+
+// MonitorBindingDimensions returns the dimensions of the object in json with the keys sorted, the form in which
+// the bindings are compared.
+func MonitorBindingDimensions(dimensions string) (string, error) {
+	var values map[string]interface{}
+	if err := json.Unmarshal([]byte(dimensions), &values); err != nil {
+		return "", err
+	}
+	normalized := make(map[string]string, len(values))
+	for key, value := range values {
+		normalized[key] = fmt.Sprint(value)
+	}
+	result, _ := json.Marshal(normalized)
+	return string(result), nil
+}
+
+// ParseMonitorAlarmRules translates the rules file into policies. The rules of a group with the same metric, operator,
+// period, duration and repeat interval but different severities are merged into one rule with hierarchical thresholds.
+func ParseMonitorAlarmRules(content string, defaultNoticeIds []string) (specs []*MonitorAlarmPolicySpec, errRet error) {
+	var file MonitorAlarmRuleFile
+	if err := yaml.UnmarshalStrict([]byte(content), &file); err != nil {
+		return nil, err
+	}
+	if len(file.Groups) == 0 {
+		return nil, fmt.Errorf("no group is defined")
+	}
+
+	names := make(map[string]bool)
+	for i, group := range file.Groups {
+		if group.Name == "" {
+			return nil, fmt.Errorf("name of group #%d is empty", i)
+		}
+		if names[group.Name] {
+			return nil, fmt.Errorf("group %s is defined more than once", group.Name)
+		}
+		names[group.Name] = true
+
+		spec, err := parseMonitorAlarmRuleGroup(group, defaultNoticeIds)
+		if err != nil {
+			return nil, fmt.Errorf("group %s: %v", group.Name, err)
+		}
+		specs = append(specs, spec)
+	}
+	return
+}
+
+func parseMonitorAlarmRuleGroup(group MonitorAlarmRuleGroup, defaultNoticeIds []string) (*MonitorAlarmPolicySpec, error) {
+	if group.Namespace == "" {
+		return nil, fmt.Errorf("namespace is required")
+	}
+	if len(group.Rules) == 0 && len(group.Events) == 0 {
+		return nil, fmt.Errorf("at least one of rules and events is required")
+	}
+
+	spec := &MonitorAlarmPolicySpec{
+		Name:        group.Name,
+		Namespace:   group.Namespace,
+		MonitorType: group.MonitorType,
+		Remark:      group.Remark,
+		Enable:      1,
+		NoticeIds:   group.NoticeIds,
+	}
+	if spec.MonitorType == "" {
+		spec.MonitorType = MONITOR_ALARM_DEFAULT_MONITOR_TYPE
+	}
+	if group.Enabled != nil && !*group.Enabled {
+		spec.Enable = 0
+	}
+	if group.NoticeIds == nil {
+		spec.NoticeIds = defaultNoticeIds
+	}
+	switch group.Match {
+	case "", MONITOR_ALARM_MATCH_ANY:
+		spec.IsUnionRule = 0
+	case MONITOR_ALARM_MATCH_ALL:
+		spec.IsUnionRule = 1
+	default:
+		return nil, fmt.Errorf("match must be `%s` or `%s`, got `%s`", MONITOR_ALARM_MATCH_ANY, MONITOR_ALARM_MATCH_ALL, group.Match)
+	}
+
+	for _, binding := range group.Bindings {
+		if len(binding) == 0 {
+			return nil, fmt.Errorf("binding must have at least one dimension")
+		}
+		// json.Marshal sorts the keys of a map, which makes the dimensions comparable
+		dimensions, _ := json.Marshal(binding)
+		if !helper.StringsContain(spec.Bindings, string(dimensions)) {
+			spec.Bindings = append(spec.Bindings, string(dimensions))
+		}
+	}
+
+	merged := make(map[string]*monitor.AlarmPolicyRule)
+	for i, rule := range group.Rules {
+		name := rule.Alert
+		if name == "" {
+			name = fmt.Sprintf("#%d", i)
+		}
+		policyRule, severity, err := parseMonitorAlarmRule(rule)
+		if err != nil {
+			return nil, fmt.Errorf("rule %s: %v", name, err)
+		}
+
+		key := strings.Join([]string{
+			*policyRule.MetricName,
+			*policyRule.Operator,
+			strconv.FormatInt(*policyRule.Period, 10),
+			strconv.FormatInt(*policyRule.ContinuePeriod, 10),
+			strconv.FormatInt(*policyRule.NoticeFrequency, 10),
+		}, "|")
+		exist, ok := merged[key]
+		if !ok {
+			merged[key] = policyRule
+			spec.Rules = append(spec.Rules, policyRule)
+			continue
+		}
+		if severity == "" || exist.HierarchicalValue == nil {
+			return nil, fmt.Errorf("rule %s: duplicates another rule of %s, set different severities to alarm on different thresholds", name, *policyRule.MetricName)
+		}
+		if err := mergeMonitorAlarmHierarchicalValue(exist.HierarchicalValue, policyRule.HierarchicalValue); err != nil {
+			return nil, fmt.Errorf("rule %s: %v", name, err)
+		}
+	}
+
+	for _, event := range group.Events {
+		if event == "" {
+			return nil, fmt.Errorf("event name is empty")
+		}
+		spec.EventRules = append(spec.EventRules, &monitor.AlarmPolicyRule{
+			MetricName: helper.String(event),
+			RuleType:   helper.String("STATIC"),
+		})
+	}
+	return spec, nil
+}
+
+// parseMonitorAlarmRule translates a rule, the threshold goes to the level of the severity if the severity is set.
+func parseMonitorAlarmRule(rule MonitorAlarmRule) (policyRule *monitor.AlarmPolicyRule, severity string, errRet error) {
+	match := monitorAlarmRuleExprRegexp.FindStringSubmatch(rule.Expr)
+	if match == nil {
+		return nil, "", fmt.Errorf("expr `%s` must be in the form of `<metric> <operator> <threshold>`, the operator is one of `>`, `>=`, `<`, `<=`, `==` and `!=`", rule.Expr)
+	}
+	metricName, operator, threshold := match[1], monitorAlarmRuleOperators[match[2]], match[3]
+	if _, err := strconv.ParseFloat(threshold, 64); err != nil {
+		return nil, "", fmt.Errorf("threshold `%s` is not a number", threshold)
+	}
+
+	period := int64(MONITOR_ALARM_DEFAULT_PERIOD)
+	if rule.Period != "" {
+		seconds, err := parseMonitorAlarmDuration(rule.Period)
+		if err != nil || seconds <= 0 {
+			return nil, "", fmt.Errorf("period `%s` is not a valid duration", rule.Period)
+		}
+		period = seconds
+	}
+	continuePeriod := int64(1)
+	if rule.For != "" {
+		seconds, err := parseMonitorAlarmDuration(rule.For)
+		if err != nil || seconds < 0 {
+			return nil, "", fmt.Errorf("for `%s` is not a valid duration", rule.For)
+		}
+		if seconds%period != 0 {
+			return nil, "", fmt.Errorf("for `%s` must be a multiple of the period %ds", rule.For, period)
+		}
+		if seconds > 0 {
+			continuePeriod = seconds / period
+		}
+	}
+	var noticeFrequency int64
+	if rule.Repeat != "" {
+		seconds, err := parseMonitorAlarmDuration(rule.Repeat)
+		if err != nil || seconds < 0 {
+			return nil, "", fmt.Errorf("repeat `%s` is not a valid duration", rule.Repeat)
+		}
+		noticeFrequency = seconds
+	}
+
+	policyRule = &monitor.AlarmPolicyRule{
+		MetricName:      helper.String(metricName),
+		Operator:        helper.String(operator),
+		Period:          helper.Int64(period),
+		ContinuePeriod:  helper.Int64(continuePeriod),
+		NoticeFrequency: helper.Int64(noticeFrequency),
+		IsPowerNotice:   helper.Int64(0),
+		RuleType:        helper.String("STATIC"),
+	}
+
+	severity = rule.Labels["severity"]
+	switch severity {
+	case "":
+		policyRule.Value = helper.String(threshold)
+	case MONITOR_ALARM_SEVERITY_INFO:
+		policyRule.HierarchicalValue = &monitor.AlarmHierarchicalValue{Remind: helper.String(threshold)}
+	case MONITOR_ALARM_SEVERITY_WARNING:
+		policyRule.HierarchicalValue = &monitor.AlarmHierarchicalValue{Warn: helper.String(threshold)}
+	case MONITOR_ALARM_SEVERITY_CRITICAL:
+		policyRule.HierarchicalValue = &monitor.AlarmHierarchicalValue{Serious: helper.String(threshold)}
+	default:
+		return nil, "", fmt.Errorf("severity must be one of `%s`, `%s` and `%s`, got `%s`",
+			MONITOR_ALARM_SEVERITY_INFO, MONITOR_ALARM_SEVERITY_WARNING, MONITOR_ALARM_SEVERITY_CRITICAL, severity)
+	}
+	return
+}
+
+func mergeMonitorAlarmHierarchicalValue(value, other *monitor.AlarmHierarchicalValue) error {
+	merge := func(target **string, source *string, severity string) error {
+		if source == nil {
+			return nil
+		}
+		if *target != nil {
+			return fmt.Errorf("severity %s is defined more than once", severity)
+		}
+		*target = source
+		return nil
+	}
+	if err := merge(&value.Remind, other.Remind, MONITOR_ALARM_SEVERITY_INFO); err != nil {
+		return err
+	}
+	if err := merge(&value.Warn, other.Warn, MONITOR_ALARM_SEVERITY_WARNING); err != nil {
+		return err
+	}
+	return merge(&value.Serious, other.Serious, MONITOR_ALARM_SEVERITY_CRITICAL)
+}
+
+// parseMonitorAlarmDuration returns the seconds of a duration such as `300`, `5m`, `1h30m` or `1d`.
+func parseMonitorAlarmDuration(s string) (int64, error) {
+	s = strings.TrimSpace(s)
+	if seconds, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return seconds, nil
+	}
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.ParseInt(days, 10, 64)
+		if err != nil {
+			return 0, err
+		}
+		return n * 86400, nil
+	}
+	duration, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, err
+	}
+	if duration%time.Second != 0 {
+		return 0, fmt.Errorf("duration `%s` is not in whole seconds", s)
+	}
+	return int64(duration / time.Second), nil
+}
+
+// DescribeMonitorAlarmPolicySpec returns the spec of the policy with the objects bound to it, nil if the policy is not found.
+func (me *MonitorService) DescribeMonitorAlarmPolicySpec(ctx context.Context, policyId string) (spec *MonitorAlarmPolicySpec, errRet error) {
+	var policy *monitor.AlarmPolicy
+	errRet = resource.Retry(tccommon.ReadRetryTimeout, func() *resource.RetryError {
+		result, e := me.DescribeAlarmPolicyById(ctx, policyId)
+		if e != nil {
+			return tccommon.RetryError(e, tccommon.InternalError)
+		}
+		policy = result
+		return nil
+	})
+	if errRet != nil || policy == nil {
+		return
+	}
+
+	objects, errRet := me.DescribeBindingAlarmPolicyObjectList(ctx, policyId)
+	if errRet != nil {
+		return
+	}
+	var bindings []string
+	for _, object := range objects {
+		dimensions, err := MonitorBindingDimensions(helper.PString(object.Dimensions))
+		if err == nil && !helper.StringsContain(bindings, dimensions) {
+			bindings = append(bindings, dimensions)
+		}
+	}
+	return MonitorAlarmPolicySpecOf(policy, bindings), nil
+}
+
+// CheckMonitorAlarmPolicySpecs checks the metrics, operators, periods and durations of the rules against the metrics
+// of the namespaces, all the problems are returned together.
+func (me *MonitorService) CheckMonitorAlarmPolicySpecs(ctx context.Context, specs []*MonitorAlarmPolicySpec) error {
+	var (
+		errs    *multierror.Error
+		metrics = make(map[string][]*monitor.Metric)
+	)
+	for _, spec := range specs {
+		key := spec.MonitorType + tccommon.FILED_SP + spec.Namespace
+		namespaceMetrics, ok := metrics[key]
+		if !ok {
+			err := resource.Retry(tccommon.ReadRetryTimeout, func() *resource.RetryError {
+				result, e := me.DescribeMonitorAlarmMetricByFilter(ctx, map[string]interface{}{
+					"Module":      helper.String("monitor"),
+					"MonitorType": helper.String(spec.MonitorType),
+					"Namespace":   helper.String(spec.Namespace),
+				})
+				if e != nil {
+					return tccommon.RetryError(e)
+				}
+				namespaceMetrics = result
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			metrics[key] = namespaceMetrics
+		}
+		if len(spec.Rules) > 0 && len(namespaceMetrics) == 0 {
+			errs = multierror.Append(errs, fmt.Errorf("group %s: namespace %s of monitor type %s has no metrics", spec.Name, spec.Namespace, spec.MonitorType))
+			continue
+		}
+		for _, rule := range spec.Rules {
+			if err := checkMonitorAlarmPolicyRule(rule, spec.Namespace, namespaceMetrics); err != nil {
+				errs = multierror.Append(errs, fmt.Errorf("group %s: %v", spec.Name, err))
+			}
+		}
+	}
+	return errs.ErrorOrNil()
+}
+
+func checkMonitorAlarmPolicyRule(rule *monitor.AlarmPolicyRule, namespace string, metrics []*monitor.Metric) error {
+	var metric *monitor.Metric
+	for _, item := range metrics {
+		if helper.PString(item.MetricName) == *rule.MetricName {
+			metric = item
+			break
+		}
+	}
+	if metric == nil {
+		return fmt.Errorf("metric %s is not found in namespace %s", *rule.MetricName, namespace)
+	}
+
+	var (
+		operators       []string
+		periods         []int64
+		continuePeriods []int64
+	)
+	for _, operator := range metric.Operators {
+		operators = append(operators, helper.PString(operator.Id))
+	}
+	for _, period := range metric.Periods {
+		periods = append(periods, helper.PInt64(period))
+	}
+	if config := metric.MetricConfig; config != nil {
+		if len(operators) == 0 {
+			operators = helper.PStrings(config.Operator)
+		}
+		if len(periods) == 0 {
+			for _, period := range config.Period {
+				periods = append(periods, helper.PInt64(period))
+			}
+		}
+		for _, continuePeriod := range config.ContinuePeriod {
+			continuePeriods = append(continuePeriods, helper.PInt64(continuePeriod))
+		}
+	}
+
+	if len(operators) > 0 && !helper.StringsContain(operators, *rule.Operator) {
+		return fmt.Errorf("metric %s does not support operator %s, valid operators are %s", *rule.MetricName, *rule.Operator, strings.Join(operators, ","))
+	}
+	if len(periods) > 0 && !containsMonitorInt64(periods, *rule.Period) {
+		return fmt.Errorf("metric %s does not support period %ds, valid periods are %s", *rule.MetricName, *rule.Period, joinMonitorInt64(periods))
+	}
+	if len(continuePeriods) > 0 && !containsMonitorInt64(continuePeriods, *rule.ContinuePeriod) {
+		return fmt.Errorf("metric %s does not support %d periods of duration, valid numbers of periods are %s", *rule.MetricName, *rule.ContinuePeriod, joinMonitorInt64(continuePeriods))
+	}
+	return nil
+}
+
+func containsMonitorInt64(values []int64, value int64) bool {
+	for _, item := range values {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
+
+func joinMonitorInt64(values []int64) string {
+	items := make([]string, 0, len(values))
+	for _, value := range values {
+		items = append(items, strconv.FormatInt(value, 10))
+	}
+	return strings.Join(items, ",")
+}
+
+// ApplyMonitorAlarmPolicySpecs creates, modifies and deletes the policies to match the specs, a policy is matched by
+// its name and recreated if the namespace or the monitor type changes. The policies existing after the call are
+// returned even if it fails, so that none of them is lost from the state.
+func (me *MonitorService) ApplyMonitorAlarmPolicySpecs(ctx context.Context, current []*MonitorAlarmPolicySetItem, specs []*MonitorAlarmPolicySpec, projectId int64) (
+	items []*MonitorAlarmPolicySetItem, errRet error) {
+	remains := make(map[string]*MonitorAlarmPolicySetItem)
+	for _, item := range current {
+		remains[item.Name] = item
+	}
+	defer func() {
+		if errRet == nil {
+			return
+		}
+		for _, item := range current {
+			if _, ok := remains[item.Name]; ok {
+				items = append(items, item)
+			}
+		}
+	}()
+
+	for _, spec := range specs {
+		item, ok := remains[spec.Name]
+		if ok && item.Namespace == spec.Namespace && item.MonitorType == spec.MonitorType {
+			if errRet = me.ModifyMonitorAlarmPolicySpec(ctx, item.PolicyId, spec); errRet != nil {
+				return
+			}
+			delete(remains, spec.Name)
+			items = append(items, item)
+			continue
+		}
+		if ok {
+			if errRet = me.DeleteMonitorAlarmPolicies(ctx, []string{item.PolicyId}); errRet != nil {
+				return
+			}
+			delete(remains, spec.Name)
+		}
+
+		var policyId string
+		policyId, errRet = me.CreateMonitorAlarmPolicySpec(ctx, spec, projectId)
+		if policyId != "" {
+			items = append(items, &MonitorAlarmPolicySetItem{Name: spec.Name, Namespace: spec.Namespace, MonitorType: spec.MonitorType, PolicyId: policyId})
+		}
+		if errRet != nil {
+			return
+		}
+	}
+
+	var (
+		names     []string
+		policyIds []string
+	)
+	for name := range remains {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		policyIds = append(policyIds, remains[name].PolicyId)
+	}
+	if len(policyIds) > 0 {
+		errRet = me.DeleteMonitorAlarmPolicies(ctx, policyIds)
+	}
+	return
+}
+
+// CreateMonitorAlarmPolicySpec creates the policy and binds the objects, the policy id is returned if the policy is
+// created even if the binding fails.
+func (me *MonitorService) CreateMonitorAlarmPolicySpec(ctx context.Context, spec *MonitorAlarmPolicySpec, projectId int64) (policyId string, errRet error) {
+	logId := tccommon.GetLogId(ctx)
+	request := monitor.NewCreateAlarmPolicyRequest()
+	request.Module = helper.String("monitor")
+	request.PolicyName = helper.String(spec.Name)
+	request.MonitorType = helper.String(spec.MonitorType)
+	request.Namespace = helper.String(spec.Namespace)
+	request.Enable = helper.Int64(spec.Enable)
+	if spec.Remark != "" {
+		request.Remark = helper.String(spec.Remark)
+	}
+	if projectId != -1 {
+		request.ProjectId = helper.Int64(projectId)
+	}
+	if len(spec.Rules) > 0 {
+		request.Condition = &monitor.AlarmPolicyCondition{
+			IsUnionRule: helper.Int64(spec.IsUnionRule),
+			Rules:       spec.Rules,
+		}
+	}
+	if len(spec.EventRules) > 0 {
+		request.EventCondition = &monitor.AlarmPolicyEventCondition{Rules: spec.EventRules}
+	}
+	request.NoticeIds = helper.Strings(spec.NoticeIds)
+
+	errRet = resource.Retry(tccommon.WriteRetryTimeout, func() *resource.RetryError {
+		ratelimit.Check(request.GetAction())
+		response, e := me.client.UseMonitorClient().CreateAlarmPolicy(request)
+		if e != nil {
+			log.Printf("[CRITAL]%s api[%s] fail, request body [%s], reason[%s]\n",
+				logId, request.GetAction(), request.ToJsonString(), e.Error())
+			return tccommon.RetryError(e, tccommon.InternalError)
+		}
+		log.Printf("[DEBUG]%s api[%s] success, request body [%s], response body [%s]\n",
+			logId, request.GetAction(), request.ToJsonString(), response.ToJsonString())
+		policyId = helper.PString(response.Response.PolicyId)
+		return nil
+	})
+	if errRet != nil {
+		return
+	}
+
+	errRet = me.BindMonitorAlarmPolicyObjects(ctx, policyId, spec.Bindings)
+	return
+}
+
+// ModifyMonitorAlarmPolicySpec modifies the remark, status, conditions, notices and bindings of the policy.
+func (me *MonitorService) ModifyMonitorAlarmPolicySpec(ctx context.Context, policyId string, spec *MonitorAlarmPolicySpec) (errRet error) {
+	logId := tccommon.GetLogId(ctx)
+	call := func(action string, fn func() (interface{ ToJsonString() string }, error)) error {
+		return resource.Retry(tccommon.WriteRetryTimeout, func() *resource.RetryError {
+			ratelimit.Check(action)
+			response, e := fn()
+			if e != nil {
+				log.Printf("[CRITAL]%s api[%s] fail, policy [%s], reason[%s]\n", logId, action, policyId, e.Error())
+				return tccommon.RetryError(e, tccommon.InternalError)
+			}
+			log.Printf("[DEBUG]%s api[%s] success, policy [%s], response body [%s]\n", logId, action, policyId, response.ToJsonString())
+			return nil
+		})
+	}
+
+	infoRequest := monitor.NewModifyAlarmPolicyInfoRequest()
+	infoRequest.Module = helper.String("monitor")
+	infoRequest.PolicyId = helper.String(policyId)
+	infoRequest.Key = helper.String("REMARK")
+	infoRequest.Value = helper.String(spec.Remark)
+	if errRet = call(infoRequest.GetAction(), func() (interface{ ToJsonString() string }, error) {
+		return me.client.UseMonitorClient().ModifyAlarmPolicyInfo(infoRequest)
+	}); errRet != nil {
+		return
+	}
+
+	statusRequest := monitor.NewModifyAlarmPolicyStatusRequest()
+	statusRequest.Module = helper.String("monitor")
+	statusRequest.PolicyId = helper.String(policyId)
+	statusRequest.Enable = helper.Int64(spec.Enable)
+	if errRet = call(statusRequest.GetAction(), func() (interface{ ToJsonString() string }, error) {
+		return me.client.UseMonitorClient().ModifyAlarmPolicyStatus(statusRequest)
+	}); errRet != nil {
+		return
+	}
+
+	conditionRequest := monitor.NewModifyAlarmPolicyConditionRequest()
+	conditionRequest.Module = helper.String("monitor")
+	conditionRequest.PolicyId = helper.String(policyId)
+	if len(spec.Rules) > 0 {
+		conditionRequest.Condition = &monitor.AlarmPolicyCondition{
+			IsUnionRule: helper.Int64(spec.IsUnionRule),
+			Rules:       spec.Rules,
+		}
+	}
+	conditionRequest.EventCondition = &monitor.AlarmPolicyEventCondition{Rules: spec.EventRules}
+	if errRet = call(conditionRequest.GetAction(), func() (interface{ ToJsonString() string }, error) {
+		return me.client.UseMonitorClient().ModifyAlarmPolicyCondition(conditionRequest)
+	}); errRet != nil {
+		return
+	}
+
+	noticeRequest := monitor.NewModifyAlarmPolicyNoticeRequest()
+	noticeRequest.Module = helper.String("monitor")
+	noticeRequest.PolicyId = helper.String(policyId)
+	noticeRequest.NoticeIds = helper.Strings(spec.NoticeIds)
+	if errRet = call(noticeRequest.GetAction(), func() (interface{ ToJsonString() string }, error) {
+		return me.client.UseMonitorClient().ModifyAlarmPolicyNotice(noticeRequest)
+	}); errRet != nil {
+		return
+	}
+
+	return me.BindMonitorAlarmPolicyObjects(ctx, policyId, spec.Bindings)
+}
+
+// BindMonitorAlarmPolicyObjects binds the objects to the policy and unbinds the others.
+func (me *MonitorService) BindMonitorAlarmPolicyObjects(ctx context.Context, policyId string, bindings []string) (errRet error) {
+	logId := tccommon.GetLogId(ctx)
+	objects, errRet := me.DescribeBindingAlarmPolicyObjectList(ctx, policyId)
+	if errRet != nil {
+		return
+	}
+
+	var (
+		bound     []string
+		uniqueIds []*string
+	)
+	for _, object := range objects {
+		normalized, err := MonitorBindingDimensions(helper.PString(object.Dimensions))
+		if err != nil {
+			continue
+		}
+		if helper.StringsContain(bindings, normalized) {
+			bound = append(bound, normalized)
+		} else {
+			uniqueIds = append(uniqueIds, object.UniqueId)
+		}
+	}
+
+	if len(uniqueIds) > 0 {
+		request := monitor.NewUnBindingPolicyObjectRequest()
+		request.Module = helper.String("monitor")
+		request.GroupId = helper.Int64(0)
+		request.PolicyId = helper.String(policyId)
+		request.UniqueId = uniqueIds
+		errRet = resource.Retry(tccommon.WriteRetryTimeout, func() *resource.RetryError {
+			ratelimit.Check(request.GetAction())
+			if _, e := me.client.UseMonitorClient().UnBindingPolicyObject(request); e != nil {
+				log.Printf("[CRITAL]%s api[%s] fail, request body [%s], reason[%s]\n",
+					logId, request.GetAction(), request.ToJsonString(), e.Error())
+				return tccommon.RetryError(e, tccommon.InternalError)
+			}
+			return nil
+		})
+		if errRet != nil {
+			return
+		}
+	}
+
+	request := monitor.NewBindingPolicyObjectRequest()
+	request.Module = helper.String("monitor")
+	request.GroupId = helper.Int64(0)
+	request.PolicyId = helper.String(policyId)
+	for _, binding := range bindings {
+		if helper.StringsContain(bound, binding) {
+			continue
+		}
+		region := MonitorRegionMap[me.client.Region]
+		if region == "" {
+			return fmt.Errorf("monitor not support region `%s` bind", me.client.Region)
+		}
+		request.Dimensions = append(request.Dimensions, &monitor.BindingPolicyObjectDimension{
+			Region:     helper.String(region),
+			Dimensions: helper.String(binding),
+		})
+	}
+	if len(request.Dimensions) == 0 {
+		return
+	}
+	return resource.Retry(tccommon.WriteRetryTimeout, func() *resource.RetryError {
+		ratelimit.Check(request.GetAction())
+		if _, e := me.client.UseMonitorClient().BindingPolicyObject(request); e != nil {
+			log.Printf("[CRITAL]%s api[%s] fail, request body [%s], reason[%s]\n",
+				logId, request.GetAction(), request.ToJsonString(), e.Error())
+			return tccommon.RetryError(e, tccommon.InternalError)
+		}
+		return nil
+	})
+}
+
+func (me *MonitorService) DeleteMonitorAlarmPolicies(ctx context.Context, policyIds []string) (errRet error) {
+	logId := tccommon.GetLogId(ctx)
+	request := monitor.NewDeleteAlarmPolicyRequest()
+	request.Module = helper.String("monitor")
+	request.PolicyIds = helper.Strings(policyIds)
+
+	return resource.Retry(tccommon.WriteRetryTimeout, func() *resource.RetryError {
+		ratelimit.Check(request.GetAction())
+		if _, e := me.client.UseMonitorClient().DeleteAlarmPolicy(request); e != nil {
+			log.Printf("[CRITAL]%s api[%s] fail, request body [%s], reason[%s]\n",
+				logId, request.GetAction(), request.ToJsonString(), e.Error())
+			return tccommon.RetryError(e, tccommon.InternalError)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,360 @@
+package monitor
+
+import (
+	"strings"
+	"testing"
+
+	monitor "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/monitor/v20180724"
+
+	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/internal/helper"
+)
+
+func monitorAlarmRulesGroup(rules string) string {
+	return "groups:\n  - name: cvm\n    namespace: cvm_device\n    rules:\n" + rules
+}
+
+func TestParseMonitorAlarmRules(t *testing.T) {
+	cases := []struct {
+		name    string
+		content string
+		// the content of each spec
+		want []string
+	}{
+		{
+			name: "defaults",
+			content: monitorAlarmRulesGroup(`
+      - expr: CpuUsage > 80
+`),
+			want: []string{"enabled: true\nremark: \nmatch: any\nnotice_ids: notice-default\nrule: CpuUsage gt 80 period=60s for=1 repeat=0s"},
+		},
+		{
+			name: "all options",
+			content: `
+groups:
+  - name: cvm
+    namespace: cvm_device
+    monitor_type: MT_QCE
+    match: all
+    enabled: false
+    remark: basic alarms
+    notice_ids: ["notice-b", "notice-a"]
+    bindings:
+      - unInstanceId: ins-2
+      - unInstanceId: ins-1
+      - unInstanceId: ins-2
+    rules:
+      - alert: HighMemory
+        expr: MemUsage>=90.5
+        period: 5m
+        for: 15m
+        repeat: 1d
+    events:
+      - ping_unreachable
+      - guest_reboot
+`,
+			want: []string{"enabled: false\nremark: basic alarms\nmatch: all\nnotice_ids: notice-a,notice-b\n" +
+				"binding: {\"unInstanceId\":\"ins-1\"}\nbinding: {\"unInstanceId\":\"ins-2\"}\n" +
+				"rule: MemUsage ge 90.5 period=300s for=3 repeat=86400s\n" +
+				"event: guest_reboot\nevent: ping_unreachable"},
+		},
+		{
+			name: "notice ids of the group override the default",
+			content: `
+groups:
+  - name: cvm
+    namespace: cvm_device
+    notice_ids: []
+    events: [ping_unreachable]
+  - name: lb
+    namespace: qce/lb_public
+    events: [lb_unreachable]
+`,
+			want: []string{"enabled: true\nremark: \nnotice_ids: \nevent: ping_unreachable",
+				"enabled: true\nremark: \nnotice_ids: notice-default\nevent: lb_unreachable"},
+		},
+		{
+			name: "severities merged",
+			content: monitorAlarmRulesGroup(`
+      - alert: HighCpu
+        expr: CpuUsage > 80
+        for: 5m
+        repeat: 1h
+        labels:
+          severity: warning
+      - alert: CriticalCpu
+        expr: CpuUsage > 95
+        for: 300s
+        repeat: 3600
+        labels:
+          severity: critical
+      - alert: NoticeCpu
+        expr: CpuUsage > 60
+        for: 5m
+        repeat: 1h
+        labels:
+          severity: info
+`),
+			want: []string{"enabled: true\nremark: \nmatch: any\nnotice_ids: notice-default\n" +
+				"rule: CpuUsage gt info=60 warning=80 critical=95 period=60s for=5 repeat=3600s"},
+		},
+		{
+			name: "severities with other durations are not merged",
+			content: monitorAlarmRulesGroup(`
+      - expr: CpuUsage > 80
+        for: 5m
+        labels:
+          severity: warning
+      - expr: CpuUsage > 95
+        for: 1m
+        labels:
+          severity: critical
+      - expr: CpuUsage < 1
+        labels:
+          severity: critical
+`),
+			want: []string{"enabled: true\nremark: \nmatch: any\nnotice_ids: notice-default\n" +
+				"rule: CpuUsage gt critical=95 period=60s for=1 repeat=0s\n" +
+				"rule: CpuUsage gt warning=80 period=60s for=5 repeat=0s\n" +
+				"rule: CpuUsage lt critical=1 period=60s for=1 repeat=0s"},
+		},
+		{
+			name: "for in multiples of the period",
+			content: monitorAlarmRulesGroup(`
+      - expr: CpuUsage > 80
+        period: 300
+        for: 1h30m
+      - expr: MemUsage > 80
+        period: 10s
+        for: 0
+      - expr: LanOuttraffic != 0
+        period: 1m
+        for: 60
+`),
+			want: []string{"enabled: true\nremark: \nmatch: any\nnotice_ids: notice-default\n" +
+				"rule: CpuUsage gt 80 period=300s for=18 repeat=0s\n" +
+				"rule: LanOuttraffic ne 0 period=60s for=1 repeat=0s\n" +
+				"rule: MemUsage gt 80 period=10s for=1 repeat=0s"},
+		},
+	}
+	for _, c := range cases {
+		specs, err := ParseMonitorAlarmRules(c.content, []string{"notice-default"})
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", c.name, err)
+			continue
+		}
+		var got []string
+		for _, spec := range specs {
+			got = append(got, spec.Content())
+		}
+		if strings.Join(got, "\n---\n") != strings.Join(c.want, "\n---\n") {
+			t.Errorf("%s: content\n%s\nwant\n%s", c.name, strings.Join(got, "\n---\n"), strings.Join(c.want, "\n---\n"))
+		}
+	}
+
+	specs, err := ParseMonitorAlarmRules(monitorAlarmRulesGroup("      - expr: CpuUsage > 80\n"), nil)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if spec := specs[0]; spec.Name != "cvm" || spec.Namespace != "cvm_device" || spec.MonitorType != MONITOR_ALARM_DEFAULT_MONITOR_TYPE {
+		t.Errorf("unexpected spec %+v", spec)
+	}
+}
+
+func TestParseMonitorAlarmRulesErrors(t *testing.T) {
+	cases := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{name: "not yaml", content: "groups: [", want: "yaml"},
+		{name: "unknown field", content: "groups:\n  - name: cvm\n    namespace: cvm_device\n    threshold: 80\n", want: "field threshold not found"},
+		{name: "no group", content: "groups: []\n", want: "no group is defined"},
+		{name: "group without name", content: "groups:\n  - namespace: cvm_device\n    events: [ping_unreachable]\n", want: "name of group #0 is empty"},
+		{
+			name:    "duplicate groups",
+			content: "groups:\n  - name: cvm\n    namespace: cvm_device\n    events: [a]\n  - name: cvm\n    namespace: cvm_device\n    events: [b]\n",
+			want:    "group cvm is defined more than once",
+		},
+		{name: "no namespace", content: "groups:\n  - name: cvm\n    events: [ping_unreachable]\n", want: "group cvm: namespace is required"},
+		{name: "no rules", content: "groups:\n  - name: cvm\n    namespace: cvm_device\n", want: "at least one of rules and events is required"},
+		{name: "bad match", content: "groups:\n  - name: cvm\n    namespace: cvm_device\n    match: some\n    events: [a]\n", want: "match must be `any` or `all`, got `some`"},
+		{name: "empty binding", content: "groups:\n  - name: cvm\n    namespace: cvm_device\n    bindings: [{}]\n    events: [a]\n", want: "binding must have at least one dimension"},
+		{name: "empty event", content: "groups:\n  - name: cvm\n    namespace: cvm_device\n    events: ['']\n", want: "event name is empty"},
+		{name: "bad operator", content: monitorAlarmRulesGroup("      - alert: HighCpu\n        expr: CpuUsage => 80\n"), want: "rule HighCpu: expr `CpuUsage => 80` must be in the form"},
+		{name: "unknown operator", content: monitorAlarmRulesGroup("      - expr: CpuUsage ~ 80\n"), want: "rule #0: expr `CpuUsage ~ 80`"},
+		{name: "no threshold", content: monitorAlarmRulesGroup("      - expr: CpuUsage >\n"), want: "must be in the form"},
+		{name: "threshold not a number", content: monitorAlarmRulesGroup("      - expr: CpuUsage > high\n"), want: "threshold `high` is not a number"},
+		{name: "bad period", content: monitorAlarmRulesGroup("      - expr: CpuUsage > 80\n        period: 0s\n"), want: "period `0s` is not a valid duration"},
+		{name: "period in fractions", content: monitorAlarmRulesGroup("      - expr: CpuUsage > 80\n        period: 1.5s\n"), want: "period `1.5s` is not a valid duration"},
+		{name: "bad for", content: monitorAlarmRulesGroup("      - expr: CpuUsage > 80\n        for: -1m\n"), want: "for `-1m` is not a valid duration"},
+		{name: "for not a multiple", content: monitorAlarmRulesGroup("      - expr: CpuUsage > 80\n        period: 1m\n        for: 90s\n"), want: "for `90s` must be a multiple of the period 60s"},
+		{name: "bad repeat", content: monitorAlarmRulesGroup("      - expr: CpuUsage > 80\n        repeat: often\n"), want: "repeat `often` is not a valid duration"},
+		{
+			name:    "bad severity",
+			content: monitorAlarmRulesGroup("      - expr: CpuUsage > 80\n        labels:\n          severity: fatal\n"),
+			want:    "severity must be one of `info`, `warning` and `critical`, got `fatal`",
+		},
+		{
+			name:    "duplicate rules",
+			content: monitorAlarmRulesGroup("      - expr: CpuUsage > 80\n      - alert: Again\n        expr: CpuUsage > 90\n"),
+			want:    "rule Again: duplicates another rule of CpuUsage, set different severities",
+		},
+		{
+			name:    "duplicate rule with severity",
+			content: monitorAlarmRulesGroup("      - expr: CpuUsage > 80\n      - expr: CpuUsage > 90\n        labels:\n          severity: warning\n"),
+			want:    "rule #1: duplicates another rule of CpuUsage",
+		},
+		{
+			name: "duplicate severity",
+			content: monitorAlarmRulesGroup("      - expr: CpuUsage > 80\n        labels:\n          severity: warning\n" +
+				"      - expr: CpuUsage > 90\n        labels:\n          severity: warning\n"),
+			want: "rule #1: severity warning is defined more than once",
+		},
+	}
+	for _, c := range cases {
+		_, err := ParseMonitorAlarmRules(c.content, nil)
+		if err == nil {
+			t.Errorf("%s: no error, want %q", c.name, c.want)
+			continue
+		}
+		if !strings.Contains(err.Error(), c.want) {
+			t.Errorf("%s: error %q, want containing %q", c.name, err, c.want)
+		}
+	}
+}
+
+func TestParseMonitorAlarmDuration(t *testing.T) {
+	cases := []struct {
+		duration string
+		want     int64
+		ok       bool
+	}{
+		{duration: "300", want: 300, ok: true},
+		{duration: " 5m ", want: 300, ok: true},
+		{duration: "1h30m", want: 5400, ok: true},
+		{duration: "2d", want: 172800, ok: true},
+		{duration: "0", want: 0, ok: true},
+		{duration: "1.5s"},
+		{duration: "xd"},
+		{duration: "5 minutes"},
+	}
+	for _, c := range cases {
+		got, err := parseMonitorAlarmDuration(c.duration)
+		if (err == nil) != c.ok || got != c.want {
+			t.Errorf("parseMonitorAlarmDuration(%q) = %d, %v, want %d, ok %t", c.duration, got, err, c.want, c.ok)
+		}
+	}
+}
+
+func TestMonitorAlarmPolicySpecContent(t *testing.T) {
+	specs, err := ParseMonitorAlarmRules(`
+groups:
+  - name: cvm
+    namespace: cvm_device
+    match: all
+    remark: basic alarms
+    bindings:
+      - unInstanceId: ins-1
+    rules:
+      - expr: CpuUsage > 80
+        for: 5m
+        labels:
+          severity: warning
+      - expr: CpuUsage > 95
+        for: 5m
+        labels:
+          severity: critical
+      - expr: MemUsage >= 90
+    events:
+      - ping_unreachable
+`, []string{"notice-1"})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	want := specs[0].Content()
+
+	// the policy created from the spec, as it is described
+	newPolicy := func() *monitor.AlarmPolicy {
+		return &monitor.AlarmPolicy{
+			PolicyName:  helper.String("cvm"),
+			Namespace:   helper.String("cvm_device"),
+			MonitorType: helper.String("MT_QCE"),
+			Remark:      helper.String("basic alarms"),
+			Enable:      helper.Int64(1),
+			ProjectId:   helper.Int64(0),
+			NoticeIds:   helper.Strings([]string{"notice-1"}),
+			Condition: &monitor.AlarmPolicyCondition{
+				IsUnionRule: helper.Int64(1),
+				Rules: []*monitor.AlarmPolicyRule{
+					{MetricName: helper.String("MemUsage"), Operator: helper.String("ge"), Value: helper.String("90.00"), Period: helper.Int64(60),
+						ContinuePeriod: helper.Int64(1), NoticeFrequency: helper.Int64(0), Unit: helper.String("%"), Description: helper.String("Memory usage"),
+						HierarchicalValue: &monitor.AlarmHierarchicalValue{Remind: helper.String(""), Warn: helper.String(""), Serious: helper.String("")}},
+					{MetricName: helper.String("CpuUsage"), Operator: helper.String("gt"), Value: helper.String(""), Period: helper.Int64(60),
+						ContinuePeriod: helper.Int64(5), NoticeFrequency: helper.Int64(0), Unit: helper.String("%"),
+						HierarchicalValue: &monitor.AlarmHierarchicalValue{Warn: helper.String("80"), Serious: helper.String("95.0")}},
+				},
+			},
+			EventCondition: &monitor.AlarmPolicyEventCondition{
+				Rules: []*monitor.AlarmPolicyRule{{MetricName: helper.String("ping_unreachable"), RuleType: helper.String("STATIC")}},
+			},
+		}
+	}
+	bindings := []string{`{"unInstanceId":"ins-1"}`}
+	if got := MonitorAlarmPolicySpecOf(newPolicy(), bindings).Content(); got != want {
+		t.Fatalf("content of the policy\n%s\nwant\n%s", got, want)
+	}
+
+	cases := []struct {
+		name     string
+		change   func(policy *monitor.AlarmPolicy)
+		bindings []string
+	}{
+		{name: "threshold", change: func(policy *monitor.AlarmPolicy) {
+			policy.Condition.Rules[1].HierarchicalValue.Warn = helper.String("85")
+		}},
+		{name: "operator", change: func(policy *monitor.AlarmPolicy) { policy.Condition.Rules[0].Operator = helper.String("gt") }},
+		{name: "duration", change: func(policy *monitor.AlarmPolicy) { policy.Condition.Rules[0].ContinuePeriod = helper.Int64(3) }},
+		{name: "repeat", change: func(policy *monitor.AlarmPolicy) { policy.Condition.Rules[0].NoticeFrequency = helper.Int64(3600) }},
+		{name: "rule removed", change: func(policy *monitor.AlarmPolicy) { policy.Condition.Rules = policy.Condition.Rules[1:] }},
+		{name: "match", change: func(policy *monitor.AlarmPolicy) { policy.Condition.IsUnionRule = helper.Int64(0) }},
+		{name: "event removed", change: func(policy *monitor.AlarmPolicy) { policy.EventCondition = nil }},
+		{name: "notice", change: func(policy *monitor.AlarmPolicy) { policy.NoticeIds = helper.Strings([]string{"notice-1", "notice-2"}) }},
+		{name: "disabled", change: func(policy *monitor.AlarmPolicy) { policy.Enable = helper.Int64(0) }},
+		{name: "remark", change: func(policy *monitor.AlarmPolicy) { policy.Remark = nil }},
+		{name: "binding added", bindings: []string{`{"unInstanceId":"ins-1"}`, `{"unInstanceId":"ins-2"}`}},
+		{name: "binding removed", bindings: []string{}},
+	}
+	for _, c := range cases {
+		policy := newPolicy()
+		if c.change != nil {
+			c.change(policy)
+		}
+		policyBindings := bindings
+		if c.bindings != nil {
+			policyBindings = c.bindings
+		}
+		if got := MonitorAlarmPolicySpecOf(policy, policyBindings).Content(); got == want {
+			t.Errorf("%s: the change is not detected in the content\n%s", c.name, got)
+		}
+	}
+}
+
+func TestMonitorBindingDimensions(t *testing.T) {
+	cases := []struct {
+		dimensions string
+		want       string
+		ok         bool
+	}{
+		{dimensions: `{"unInstanceId":"ins-1"}`, want: `{"unInstanceId":"ins-1"}`, ok: true},
+		{dimensions: `{"vip":"10.0.0.1","port":80,"protocol":"TCP"}`, want: `{"port":"80","protocol":"TCP","vip":"10.0.0.1"}`, ok: true},
+		{dimensions: `{}`, want: `{}`, ok: true},
+		{dimensions: `not json`},
+		{dimensions: `["ins-1"]`},
+	}
+	for _, c := range cases {
+		got, err := MonitorBindingDimensions(c.dimensions)
+		if (err == nil) != c.ok || got != c.want {
+			t.Errorf("MonitorBindingDimensions(%s) = %s, %v, want %s", c.dimensions, got, err, c.want)
+		}
+	}
+}
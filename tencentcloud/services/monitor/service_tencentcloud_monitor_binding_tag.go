@@ -0,0 +1,213 @@
+package monitor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	clb "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/clb/v20180317"
+	cvm "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/cvm/v20170312"
+	tag "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/tag/v20180813"
+
+	tccommon "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/common"
+	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/internal/helper"
+	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/ratelimit"
+	svctag "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/services/tag"
+)
+
+const (
+	MONITOR_BINDING_RESOURCE_CVM_INSTANCE = "cvm/instance"
+	MONITOR_BINDING_RESOURCE_CLB          = "clb/clb"
+)
+
+// MonitorBindingTagSelector selects the objects to bind to a policy by the tags of the resources.
+type MonitorBindingTagSelector struct {
+	ServiceType    string
+	ResourcePrefix string
+	DimensionKey   string
+	// Tags are the tag keys the resources must have, with the values allowed, any value is allowed if empty.
+	Tags map[string][]string
+	// ProjectId is the project the resources must belong to, -1 means any project.
+	ProjectId int64
+}
+
+// Match reports whether the tags of a resource satisfy the selector.
+func (s *MonitorBindingTagSelector) Match(tags map[string]string) bool {
+	for key, values := range s.Tags {
+		value, ok := tags[key]
+		if !ok {
+			return false
+		}
+		if len(values) > 0 && !helper.StringsContain(values, value) {
+			return false
+		}
+	}
+	return true
+}
+
+// FilterKey returns the tag key the tag service filters the resources by, the other keys are matched locally.
+func (s *MonitorBindingTagSelector) FilterKey() string {
+	keys := make([]string, 0, len(s.Tags))
+	for key := range s.Tags {
+		keys = append(keys, key)
+	}
+	if len(keys) == 0 {
+		return ""
+	}
+	sort.Strings(keys)
+	return keys[0]
+}
+
+// DescribeMonitorBindingObjectsByTags returns the dimensions of the resources selected in the region of the client,
+// sorted and in the form of MonitorBindingDimensions.
+func (me *MonitorService) DescribeMonitorBindingObjectsByTags(ctx context.Context, selector *MonitorBindingTagSelector) (dimensions []string, errRet error) {
+	logId := tccommon.GetLogId(ctx)
+	request := tag.NewDescribeResourcesByTagsRequest()
+	request.ServiceType = helper.String(selector.ServiceType)
+	request.ResourcePrefix = helper.String(selector.ResourcePrefix)
+	request.ResourceRegion = helper.String(me.client.Region)
+	request.Limit = helper.IntUint64(svctag.DESCRIBE_TAGS_LIMIT)
+	if key := selector.FilterKey(); key != "" {
+		request.TagFilters = []*tag.TagFilter{{TagKey: helper.String(key), TagValue: helper.Strings(selector.Tags[key])}}
+	}
+
+	var (
+		offset    uint64
+		resources = make(map[string]map[string]string)
+	)
+	for {
+		request.Offset = helper.Uint64(offset)
+		var rows []*tag.ResourceTag
+		errRet = resource.Retry(tccommon.ReadRetryTimeout, func() *resource.RetryError {
+			ratelimit.Check(request.GetAction())
+			response, e := me.client.UseTagClient().DescribeResourcesByTags(request)
+			if e != nil {
+				log.Printf("[CRITAL]%s api[%s] fail, request body [%s], reason[%s]\n",
+					logId, request.GetAction(), request.ToJsonString(), e.Error())
+				return tccommon.RetryError(e)
+			}
+			rows = response.Response.Rows
+			return nil
+		})
+		if errRet != nil {
+			return
+		}
+		for _, row := range rows {
+			resourceId := helper.PString(row.ResourceId)
+			if resourceId == "" {
+				continue
+			}
+			if resources[resourceId] == nil {
+				resources[resourceId] = make(map[string]string)
+			}
+			for _, item := range row.Tags {
+				if item.TagKey != nil {
+					resources[resourceId][*item.TagKey] = helper.PString(item.TagValue)
+				}
+			}
+		}
+		if len(rows) < svctag.DESCRIBE_TAGS_LIMIT {
+			break
+		}
+		offset += uint64(svctag.DESCRIBE_TAGS_LIMIT)
+	}
+
+	var resourceIds []string
+	for resourceId, tags := range resources {
+		if selector.Match(tags) {
+			resourceIds = append(resourceIds, resourceId)
+		}
+	}
+	sort.Strings(resourceIds)
+
+	if selector.ProjectId != -1 && len(resourceIds) > 0 {
+		projects, err := me.describeMonitorBindingResourceProjects(ctx, selector.ServiceType+"/"+selector.ResourcePrefix, resourceIds)
+		if err != nil {
+			return nil, err
+		}
+		selected := resourceIds[:0]
+		for _, resourceId := range resourceIds {
+			if projectId, ok := projects[resourceId]; ok && projectId == selector.ProjectId {
+				selected = append(selected, resourceId)
+			}
+		}
+		resourceIds = selected
+	}
+
+	for _, resourceId := range resourceIds {
+		result, _ := json.Marshal(map[string]string{selector.DimensionKey: resourceId})
+		dimensions = append(dimensions, string(result))
+	}
+	return
+}
+
+// describeMonitorBindingResourceProjects returns the projects of the resources, the tag service does not know the
+// project of a resource, so only the CVM instances and the CLB instances are supported.
+func (me *MonitorService) describeMonitorBindingResourceProjects(ctx context.Context, resourceType string, resourceIds []string) (projects map[string]int64, errRet error) {
+	logId := tccommon.GetLogId(ctx)
+	projects = make(map[string]int64)
+
+	switch resourceType {
+	case MONITOR_BINDING_RESOURCE_CVM_INSTANCE:
+		for start := 0; start < len(resourceIds); start += 100 {
+			end := start + 100
+			if end > len(resourceIds) {
+				end = len(resourceIds)
+			}
+			request := cvm.NewDescribeInstancesRequest()
+			request.InstanceIds = helper.Strings(resourceIds[start:end])
+			request.Limit = helper.Int64(100)
+			errRet = resource.Retry(tccommon.ReadRetryTimeout, func() *resource.RetryError {
+				ratelimit.Check(request.GetAction())
+				response, e := me.client.UseCvmClient().DescribeInstances(request)
+				if e != nil {
+					log.Printf("[CRITAL]%s api[%s] fail, request body [%s], reason[%s]\n",
+						logId, request.GetAction(), request.ToJsonString(), e.Error())
+					return tccommon.RetryError(e)
+				}
+				for _, instance := range response.Response.InstanceSet {
+					if instance.Placement != nil {
+						projects[helper.PString(instance.InstanceId)] = helper.PInt64(instance.Placement.ProjectId)
+					}
+				}
+				return nil
+			})
+			if errRet != nil {
+				return
+			}
+		}
+	case MONITOR_BINDING_RESOURCE_CLB:
+		for start := 0; start < len(resourceIds); start += 20 {
+			end := start + 20
+			if end > len(resourceIds) {
+				end = len(resourceIds)
+			}
+			request := clb.NewDescribeLoadBalancersRequest()
+			request.LoadBalancerIds = helper.Strings(resourceIds[start:end])
+			request.Limit = helper.Int64(20)
+			errRet = resource.Retry(tccommon.ReadRetryTimeout, func() *resource.RetryError {
+				ratelimit.Check(request.GetAction())
+				response, e := me.client.UseClbClient().DescribeLoadBalancers(request)
+				if e != nil {
+					log.Printf("[CRITAL]%s api[%s] fail, request body [%s], reason[%s]\n",
+						logId, request.GetAction(), request.ToJsonString(), e.Error())
+					return tccommon.RetryError(e)
+				}
+				for _, loadBalancer := range response.Response.LoadBalancerSet {
+					projects[helper.PString(loadBalancer.LoadBalancerId)] = int64(helper.PUint64(loadBalancer.ProjectId))
+				}
+				return nil
+			})
+			if errRet != nil {
+				return
+			}
+		}
+	default:
+		errRet = fmt.Errorf("selecting by project is only supported for %s and %s, not %s",
+			MONITOR_BINDING_RESOURCE_CVM_INSTANCE, MONITOR_BINDING_RESOURCE_CLB, resourceType)
+	}
+	return
+}
@@ -0,0 +1,52 @@
+package monitor
+
+import "testing"
+
+func TestMonitorBindingTagSelectorMatch(t *testing.T) {
+	selector := &MonitorBindingTagSelector{Tags: map[string][]string{
+		"app":       {"web", "api"},
+		"monitored": nil,
+	}}
+
+	cases := []struct {
+		name string
+		tags map[string]string
+		want bool
+	}{
+		{name: "all keys with an allowed value", tags: map[string]string{"app": "web", "monitored": "yes"}, want: true},
+		{name: "another allowed value", tags: map[string]string{"app": "api", "monitored": ""}, want: true},
+		{name: "extra keys", tags: map[string]string{"app": "web", "monitored": "yes", "owner": "ops"}, want: true},
+		{name: "value not allowed", tags: map[string]string{"app": "db", "monitored": "yes"}},
+		{name: "value case differs", tags: map[string]string{"app": "Web", "monitored": "yes"}},
+		{name: "key missing", tags: map[string]string{"app": "web"}},
+		{name: "any value key missing", tags: map[string]string{"monitored": "yes"}},
+		{name: "no tags"},
+	}
+	for _, c := range cases {
+		if got := selector.Match(c.tags); got != c.want {
+			t.Errorf("%s: Match(%v) = %v, want %v", c.name, c.tags, got, c.want)
+		}
+	}
+
+	if !(&MonitorBindingTagSelector{}).Match(nil) {
+		t.Errorf("Match of a selector without tags = false, want true")
+	}
+}
+
+func TestMonitorBindingTagSelectorFilterKey(t *testing.T) {
+	cases := []struct {
+		name string
+		tags map[string][]string
+		want string
+	}{
+		{name: "first key in order", tags: map[string][]string{"monitored": nil, "app": {"web"}, "owner": nil}, want: "app"},
+		{name: "single key", tags: map[string][]string{"owner": nil}, want: "owner"},
+		{name: "no keys"},
+	}
+	for _, c := range cases {
+		selector := &MonitorBindingTagSelector{Tags: c.tags}
+		if got := selector.FilterKey(); got != c.want {
+			t.Errorf("%s: FilterKey = %q, want %q", c.name, got, c.want)
+		}
+	}
+}
@@ -446,7 +446,7 @@ func dataSourceTencentCloudMpsMediaMetaDataRead(d *schema.ResourceData, meta int
 	d.SetId(helper.DataResourceIdHash(string(id)))
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), mediaMetaDataMap); e != nil {
+		if e := tccommon.WriteToFile(output.(string), mediaMetaDataMap, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
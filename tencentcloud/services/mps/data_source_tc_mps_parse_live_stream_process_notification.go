@@ -67,7 +67,7 @@ func dataSourceTencentCloudMpsParseLiveStreamProcessNotificationRead(d *schema.R
 	d.SetId(taskId)
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), result); e != nil {
+		if e := tccommon.WriteToFile(output.(string), result, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
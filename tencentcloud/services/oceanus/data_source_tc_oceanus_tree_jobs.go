@@ -229,7 +229,7 @@ func dataSourceTencentCloudOceanusTreeJobsRead(d *schema.ResourceData, meta inte
 	d.SetId(workSpaceId)
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), d); e != nil {
+		if e := tccommon.WriteToFile(output.(string), d, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
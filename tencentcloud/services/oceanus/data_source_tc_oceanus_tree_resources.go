@@ -230,7 +230,7 @@ func dataSourceTencentCloudOceanusTreeResourcesRead(d *schema.ResourceData, meta
 	d.SetId(workSpaceId)
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), d); e != nil {
+		if e := tccommon.WriteToFile(output.(string), d, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
@@ -231,7 +231,7 @@ func dataSourceTencentCloudPostgresqlAccountPrivilegesRead(d *schema.ResourceDat
 	d.SetId(strings.Join([]string{dBInstanceId, userName}, tccommon.FILED_SP))
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), tmpList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), tmpList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
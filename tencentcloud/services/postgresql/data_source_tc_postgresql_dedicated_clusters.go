@@ -223,7 +223,7 @@ func dataSourceTencentCloudPostgresqlDedicatedClustersRead(d *schema.ResourceDat
 	d.SetId(helper.DataResourceIdsHash(ids))
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), dedicatedClusterSetList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), dedicatedClusterSetList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
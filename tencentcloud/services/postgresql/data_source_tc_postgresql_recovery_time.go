@@ -87,7 +87,7 @@ func dataSourceTencentCloudPostgresqlRecoveryTimeRead(d *schema.ResourceData, me
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), result); e != nil {
+		if e := tccommon.WriteToFile(output.(string), result, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
@@ -203,7 +203,7 @@ func dataSourceTencentCloudPrivateDnsEndPointsRead(d *schema.ResourceData, meta
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), endPointSetList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), endPointSetList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
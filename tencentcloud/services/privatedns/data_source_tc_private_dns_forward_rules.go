@@ -287,7 +287,7 @@ func dataSourceTencentCloudPrivateDnsForwardRulesRead(d *schema.ResourceData, me
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), forwardRuleSetList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), forwardRuleSetList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
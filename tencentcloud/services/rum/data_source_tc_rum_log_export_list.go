@@ -73,7 +73,7 @@ func dataSourceTencentCloudRumLogExportListRead(d *schema.ResourceData, meta int
 	d.SetId(helper.DataResourceIdsHash([]string{ids}))
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), result); e != nil {
+		if e := tccommon.WriteToFile(output.(string), result, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
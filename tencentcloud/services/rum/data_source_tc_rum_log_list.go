@@ -120,7 +120,7 @@ func dataSourceTencentCloudRumLogListRead(d *schema.ResourceData, meta interface
 	d.SetId(helper.DataResourceIdsHash([]string{startTime, endTime, ids}))
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), result); e != nil {
+		if e := tccommon.WriteToFile(output.(string), result, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
@@ -102,7 +102,7 @@ func dataSourceTencentCloudRumOfflineLogConfigRead(d *schema.ResourceData, meta
 			"project_key":   projectKey,
 			"unique_id_set": uniqueID,
 			"msg":           *logConfigs.Msg,
-		}); e != nil {
+		}, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
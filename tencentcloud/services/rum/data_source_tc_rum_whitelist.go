@@ -143,7 +143,7 @@ func dataSourceTencentCloudRumWhitelistRead(d *schema.ResourceData, meta interfa
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), whitelistSetList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), whitelistSetList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
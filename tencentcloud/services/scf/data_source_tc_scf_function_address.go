@@ -108,7 +108,7 @@ func dataSourceTencentCloudScfFunctionAddressRead(d *schema.ResourceData, meta i
 	output, ok := d.GetOk("result_output_file")
 
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), resMap); e != nil {
+		if e := tccommon.WriteToFile(output.(string), resMap, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
@@ -244,7 +244,7 @@ func dataSourceTencentCloudScfLogsRead(d *schema.ResourceData, m interface{}) er
 	d.SetId(helper.DataResourceIdsHash(ids))
 
 	if output, ok := d.GetOk("result_output_file"); ok && output.(string) != "" {
-		if err := tccommon.WriteToFile(output.(string), logs); err != nil {
+		if err := tccommon.WriteToFile(output.(string), logs, tccommon.GetResultOutputOption(d)); err != nil {
 			err = errors.WithStack(err)
 			log.Printf("[CRITAL]%s output file[%s] fail, reason: %+v", logId, output.(string), err)
 			return err
@@ -123,7 +123,7 @@ func dataSourceTencentCloudScfNamespacesRead(d *schema.ResourceData, m interface
 	d.SetId(helper.DataResourceIdsHash(ids))
 
 	if output, ok := d.GetOk("result_output_file"); ok && output.(string) != "" {
-		if err := tccommon.WriteToFile(output.(string), namespaces); err != nil {
+		if err := tccommon.WriteToFile(output.(string), namespaces, tccommon.GetResultOutputOption(d)); err != nil {
 			err = errors.WithStack(err)
 			log.Printf("[CRITAL]%s output file[%s] fail, reason: %+v", logId, output.(string), err)
 			return err
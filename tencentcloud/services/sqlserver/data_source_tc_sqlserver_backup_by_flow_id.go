@@ -172,7 +172,7 @@ func dataSourceTencentCloudSqlserverBackupByFlowIdRead(d *schema.ResourceData, m
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), d); e != nil {
+		if e := tccommon.WriteToFile(output.(string), d, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
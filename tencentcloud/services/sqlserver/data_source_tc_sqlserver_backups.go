@@ -164,7 +164,7 @@ func dataSourceTencentSqlserverBackupsRead(d *schema.ResourceData, meta interfac
 	}
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		return tccommon.WriteToFile(output.(string), list)
+		return tccommon.WriteToFile(output.(string), list, tccommon.GetResultOutputOption(d))
 	}
 
 	return nil
@@ -104,7 +104,7 @@ func dataSourceTencentSqlserverDBRead(d *schema.ResourceData, meta interface{})
 	d.SetId(instanceId)
 
 	if output, ok := d.GetOk("result_output_file"); ok && output.(string) != "" {
-		if err := tccommon.WriteToFile(output.(string), dbList); err != nil {
+		if err := tccommon.WriteToFile(output.(string), dbList, tccommon.GetResultOutputOption(d)); err != nil {
 			log.Printf("[CRITAL]%s output file[%s] fail, reason[%s]\n",
 				logId, output.(string), err.Error())
 		}
@@ -226,7 +226,7 @@ func dataSourceTencentCloudSqlserverInstanceRead(d *schema.ResourceData, meta in
 	}
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		return tccommon.WriteToFile(output.(string), list)
+		return tccommon.WriteToFile(output.(string), list, tccommon.GetResultOutputOption(d))
 	}
 
 	return nil
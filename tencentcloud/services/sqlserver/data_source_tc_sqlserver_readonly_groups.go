@@ -138,7 +138,7 @@ func dataSourceTencentSqlserverReadonlyGroups(d *schema.ResourceData, meta inter
 	}
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		return tccommon.WriteToFile(output.(string), list)
+		return tccommon.WriteToFile(output.(string), list, tccommon.GetResultOutputOption(d))
 	}
 
 	return nil
@@ -160,7 +160,7 @@ func dataSourceTencentSqlserverZoneConfigRead(d *schema.ResourceData, meta inter
 	d.SetId("zone_config")
 
 	if output, ok := d.GetOk("result_output_file"); ok && output.(string) != "" {
-		if err := tccommon.WriteToFile(output.(string), zoneList); err != nil {
+		if err := tccommon.WriteToFile(output.(string), zoneList, tccommon.GetResultOutputOption(d)); err != nil {
 			log.Printf("[CRITAL]%s output file[%s] fail, reason[%s]\n",
 				logId, output.(string), err.Error())
 		}
@@ -0,0 +1,191 @@
+package ssl
+
+import (
+	"context"
+	"log"
+	"math"
+	"sort"
+	"time"
+
+	tccommon "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	ssl "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/ssl/v20191205"
+
+	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/internal/helper"
+)
+
+func DataSourceTencentCloudSslCertificateExpiry() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceTencentCloudSslCertificateExpiryRead,
+		Schema: map[string]*schema.Schema{
+			"days": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: tccommon.ValidateIntegerMin(0),
+				Description:  "Certificates which expire within the days are returned.",
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: tccommon.ValidateAllowedStringValue(SSL_CERT_TYPE),
+				Description:  "Type of the certificates to be queried. Valid values: `CA` and `SVR`.",
+			},
+			"include_expired": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to return the certificates which have expired. Default is false.",
+			},
+			"result_output_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Used to save results.",
+			},
+
+			// computed
+			"certificates": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Certificates expiring within the days, sorted by the expiration time. Each element contains the following attributes:",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ID of the SSL certificate.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the SSL certificate.",
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Type of the SSL certificate.",
+						},
+						"domain": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Primary domain of the SSL certificate.",
+						},
+						"subject_names": {
+							Type:        schema.TypeList,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Computed:    true,
+							Description: "ALL domains included in the SSL certificate. Including the primary domain name.",
+						},
+						"status": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Status of the SSL certificate.",
+						},
+						"end_time": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Ending time of the SSL certificate.",
+						},
+						"days_remaining": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Days before the certificate expires, it is negative if the certificate has expired.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceTencentCloudSslCertificateExpiryRead(d *schema.ResourceData, m interface{}) error {
+	defer tccommon.LogElapsed("data_source.tencentcloud_ssl_certificate_expiry.read")()
+
+	logId := tccommon.GetLogId(tccommon.ContextNil)
+	ctx := context.WithValue(context.TODO(), tccommon.LogIdKey, logId)
+	sslService := SSLService{client: m.(tccommon.ProviderMeta).GetAPIV3Conn()}
+
+	request := ssl.NewDescribeCertificatesRequest()
+	if v, ok := d.GetOk("type"); ok {
+		request.CertificateType = helper.String(v.(string))
+	}
+
+	var certificateList []*ssl.Certificates
+	err := resource.Retry(tccommon.ReadRetryTimeout, func() *resource.RetryError {
+		result, e := sslService.DescribeCertificates(ctx, request)
+		if e != nil {
+			if sdkErr := helper.UnwarpSDKError(e); sdkErr != nil && tccommon.IsContains("LimitExceeded", sdkErr.Code) {
+				return resource.RetryableError(e)
+			}
+			return tccommon.RetryError(e)
+		}
+		certificateList = result
+		return nil
+	})
+	if err != nil {
+		log.Printf("[CRITAL]%s read certificates failed, reason: %v", logId, err)
+		return err
+	}
+
+	var (
+		now            = time.Now()
+		deadline       = now.Add(time.Duration(d.Get("days").(int)) * 24 * time.Hour)
+		includeExpired = d.Get("include_expired").(bool)
+		expiring       = make([]*ssl.Certificates, 0)
+		endTimes       = make(map[string]time.Time)
+	)
+	for _, certificate := range certificateList {
+		if certificate.CertificateId == nil || certificate.CertEndTime == nil {
+			continue
+		}
+		endTime, err := time.ParseInLocation("2006-01-02 15:04:05", *certificate.CertEndTime, time.FixedZone("UTC+8", 8*3600))
+		if err != nil {
+			log.Printf("[WARN]%s parse end time [%s] of certificate [%s] failed, reason:%+v", logId, *certificate.CertEndTime, *certificate.CertificateId, err)
+			continue
+		}
+		if endTime.After(deadline) || (!includeExpired && endTime.Before(now)) {
+			continue
+		}
+		expiring = append(expiring, certificate)
+		endTimes[*certificate.CertificateId] = endTime
+	}
+	sort.SliceStable(expiring, func(i, j int) bool {
+		return endTimes[*expiring[i].CertificateId].Before(endTimes[*expiring[j].CertificateId])
+	})
+
+	certificates := make([]map[string]interface{}, 0, len(expiring))
+	ids := make([]string, 0, len(expiring))
+	for _, certificate := range expiring {
+		endTime := endTimes[*certificate.CertificateId]
+		subjectAltNames := make([]string, 0, len(certificate.SubjectAltName))
+		for _, name := range certificate.SubjectAltName {
+			subjectAltNames = append(subjectAltNames, *name)
+		}
+
+		ids = append(ids, *certificate.CertificateId)
+		certificates = append(certificates, map[string]interface{}{
+			"id":             *certificate.CertificateId,
+			"name":           helper.PString(certificate.Alias),
+			"type":           helper.PString(certificate.CertificateType),
+			"domain":         helper.PString(certificate.Domain),
+			"subject_names":  subjectAltNames,
+			"status":         int(helper.PUint64(certificate.Status)),
+			"end_time":       *certificate.CertEndTime,
+			"days_remaining": int(math.Floor(endTime.Sub(now).Hours() / 24)),
+		})
+	}
+
+	_ = d.Set("certificates", certificates)
+	d.SetId(helper.DataResourceIdsHash(ids))
+
+	if output, ok := d.GetOk("result_output_file"); ok && output.(string) != "" {
+		if err := tccommon.WriteToFile(output.(string), certificates, tccommon.GetResultOutputOption(d)); err != nil {
+			log.Printf("[CRITAL]%s output file[%s] fail, reason[%s]",
+				logId, output.(string), err.Error())
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,33 @@
+package ssl_test
+
+import (
+	"testing"
+
+	tcacctest "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/acctest"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccTencentCloudSslCertificateExpiryDataSource_basic(t *testing.T) {
+	t.Parallel()
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { tcacctest.AccPreCheck(t) },
+		Providers: tcacctest.AccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSslCertificateExpiryDataSource,
+				Check: resource.ComposeTestCheckFunc(
+					tcacctest.AccCheckTencentCloudDataSourceID("data.tencentcloud_ssl_certificate_expiry.example"),
+					resource.TestCheckResourceAttrSet("data.tencentcloud_ssl_certificate_expiry.example", "certificates.#"),
+				),
+			},
+		},
+	})
+}
+
+const testAccSslCertificateExpiryDataSource = `
+data "tencentcloud_ssl_certificate_expiry" "example" {
+  days            = 3650
+  include_expired = true
+}
+`
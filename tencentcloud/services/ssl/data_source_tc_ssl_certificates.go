@@ -282,7 +282,7 @@ func dataSourceTencentCloudSslCertificatesRead(d *schema.ResourceData, m interfa
 	d.SetId(helper.DataResourceIdsHash(ids))
 
 	if output, ok := d.GetOk("result_output_file"); ok && output.(string) != "" {
-		if err := tccommon.WriteToFile(output.(string), certificates); err != nil {
+		if err := tccommon.WriteToFile(output.(string), certificates, tccommon.GetResultOutputOption(d)); err != nil {
 			log.Printf("[CRITAL]%s output file[%s] fail, reason[%s]",
 				logId, output.(string), err.Error())
 			return err
@@ -911,7 +911,7 @@ func dataSourceTencentCloudSslDescribeCertificateRead(d *schema.ResourceData, me
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), sslResponseMap); e != nil {
+		if e := tccommon.WriteToFile(output.(string), sslResponseMap, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
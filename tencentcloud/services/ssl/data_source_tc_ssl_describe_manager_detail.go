@@ -321,7 +321,7 @@ func dataSourceTencentCloudSslDescribeManagerDetailRead(d *schema.ResourceData,
 	d.SetId(helper.IntToStr(managerId.(int)))
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), tmpList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), tmpList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
@@ -86,3 +86,22 @@ func IsContainProductId(productId int64, lists ...[]int64) bool {
 	}
 	return false
 }
+
+const (
+	ACME_DIRECTORY_LETS_ENCRYPT         = "https://acme-v02.api.letsencrypt.org/directory"
+	ACME_DIRECTORY_LETS_ENCRYPT_STAGING = "https://acme-staging-v02.api.letsencrypt.org/directory"
+
+	ACME_KEY_TYPE_P256    = "P256"
+	ACME_KEY_TYPE_P384    = "P384"
+	ACME_KEY_TYPE_RSA2048 = "RSA2048"
+	ACME_KEY_TYPE_RSA4096 = "RSA4096"
+
+	ACME_DNS_CHALLENGE_PREFIX = "_acme-challenge"
+)
+
+var ACME_KEY_TYPES = []string{
+	ACME_KEY_TYPE_P256,
+	ACME_KEY_TYPE_P384,
+	ACME_KEY_TYPE_RSA2048,
+	ACME_KEY_TYPE_RSA4096,
+}
@@ -0,0 +1,625 @@
+package ssl
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	tccommon "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/common"
+	svcdnspod "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/services/dnspod"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	sdkError "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common/errors"
+	ssl "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/ssl/v20191205"
+
+	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/internal/helper"
+)
+
+func ResourceTencentCloudAcmeCertificate() *schema.Resource {
+	return &schema.Resource{
+		Create:        resourceTencentCloudAcmeCertificateCreate,
+		Read:          resourceTencentCloudAcmeCertificateRead,
+		Update:        resourceTencentCloudAcmeCertificateUpdate,
+		Delete:        resourceTencentCloudAcmeCertificateDelete,
+		CustomizeDiff: acmeCertificateCustomizeDiff,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"directory_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     ACME_DIRECTORY_LETS_ENCRYPT,
+				Description: "Directory URL of the ACME v2 server. Default is the production directory of Let's Encrypt. Use `" + ACME_DIRECTORY_LETS_ENCRYPT_STAGING + "` for testing, `https://acme.zerossl.com/v2/DV90` for ZeroSSL.",
+			},
+			"directory_ca_certificate": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "PEM encoded CA certificates to trust besides the system ones when connecting to the ACME server, such as the one of a local Pebble server.",
+			},
+			"account_key_pem": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Sensitive:   true,
+				Description: "PEM encoded private key of the ACME account. A P256 key is generated if not set.",
+			},
+			"email": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Contact email of the ACME account.",
+			},
+			"external_account_binding": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				ForceNew:    true,
+				MaxItems:    1,
+				Description: "External account binding required by some ACME servers such as ZeroSSL.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key_id": {
+							Type:        schema.TypeString,
+							Required:    true,
+							ForceNew:    true,
+							Description: "Key ID of the external account binding.",
+						},
+						"hmac_key": {
+							Type:        schema.TypeString,
+							Required:    true,
+							ForceNew:    true,
+							Sensitive:   true,
+							Description: "Base64 URL encoded HMAC key of the external account binding.",
+						},
+					},
+				},
+			},
+			"common_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Common name of the certificate, such as `www.example.com` or `*.example.com`.",
+			},
+			"subject_alternative_names": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Other domain names of the certificate.",
+			},
+			"key_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      ACME_KEY_TYPE_P256,
+				ValidateFunc: tccommon.ValidateAllowedStringValue(ACME_KEY_TYPES),
+				Description:  "Type of the private key of the certificate, valid values: `P256`, `P384`, `RSA2048` and `RSA4096`. Default is `P256`.",
+			},
+			"min_days_remaining": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      30,
+				ValidateFunc: tccommon.ValidateIntegerMin(0),
+				Description:  "The certificate is renewed at the next apply once it expires within the days. Default is 30.",
+			},
+			"dns_challenge": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Settings of the TXT records of DNS-01 challenges, which are created in DNSPod and removed once the challenges finish.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"zone": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "DNSPod domain to create the TXT records in. It is detected from the domain names by default.",
+						},
+						"record_line": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "默认",
+							Description: "Record line of the TXT records. Default is the default line of DNSPod.",
+						},
+						"ttl": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Default:     600,
+							Description: "TTL of the TXT records. Default is 600.",
+						},
+						"propagation_wait": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Default:     30,
+							Description: "Seconds to wait after the TXT records are created before the challenges are validated. Default is 30.",
+						},
+					},
+				},
+			},
+			"ssl_upload": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Upload the certificate to SSL certificate management, so that it can be bound to CLB, CDN, TEO and so on by `ssl_certificate_id`. The certificate is uploaded again once it is renewed, and the previous one is kept in `previous_ssl_certificate_ids`.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"alias": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Alias of the uploaded certificate. Default is the common name.",
+						},
+						"project_id": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Default:     0,
+							Description: "Project ID of the uploaded certificate. Default is 0.",
+						},
+					},
+				},
+			},
+			"revoke_certificate_on_destroy": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to revoke the certificate when the resource is destroyed. Default is false.",
+			},
+			"account_url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "URL of the ACME account.",
+			},
+			"certificate_url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "URL of the certificate in the ACME server, it changes when the certificate is renewed while the ID of the resource is kept.",
+			},
+			"certificate_pem": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "PEM encoded certificate.",
+			},
+			"issuer_pem": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "PEM encoded intermediate certificates of the issuer.",
+			},
+			"private_key_pem": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "PEM encoded private key of the certificate.",
+			},
+			"not_after": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Expiration time of the certificate in RFC3339 format.",
+			},
+			"ssl_certificate_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the certificate uploaded to SSL certificate management.",
+			},
+			"previous_ssl_certificate_ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "IDs of the certificates uploaded before the renewals. They are kept in SSL certificate management since resources may still be bound to them, delete them once they are unbound.",
+			},
+		},
+	}
+}
+
+// acmeCertificateCustomizeDiff plans the renewal once the certificate expires within `min_days_remaining`,
+// and the upload once the uploaded certificate is deleted out of terraform.
+func acmeCertificateCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+
+	upload := len(d.Get("ssl_upload").([]interface{})) > 0
+	sslCertificateId := d.Get("ssl_certificate_id").(string)
+
+	renew, err := AcmeCertificateExpiresWithin(d.Get("certificate_pem").(string), d.Get("min_days_remaining").(int))
+	if err != nil {
+		return err
+	}
+	if renew {
+		for _, key := range []string{"certificate_url", "certificate_pem", "issuer_pem", "private_key_pem", "not_after"} {
+			if err := d.SetNewComputed(key); err != nil {
+				return err
+			}
+		}
+		if upload {
+			if sslCertificateId != "" {
+				if err := d.SetNewComputed("previous_ssl_certificate_ids"); err != nil {
+					return err
+				}
+			}
+			return d.SetNewComputed("ssl_certificate_id")
+		}
+	}
+
+	if upload && sslCertificateId == "" {
+		return d.SetNewComputed("ssl_certificate_id")
+	}
+	if !upload && sslCertificateId != "" {
+		return d.SetNew("ssl_certificate_id", "")
+	}
+	return nil
+}
+
+func resourceTencentCloudAcmeCertificateCreate(d *schema.ResourceData, meta interface{}) error {
+	defer tccommon.LogElapsed("resource.tencentcloud_acme_certificate.create")()
+
+	logId := tccommon.GetLogId(tccommon.ContextNil)
+	ctx, cancel := context.WithTimeout(context.WithValue(context.TODO(), tccommon.LogIdKey, logId), d.Timeout(schema.TimeoutCreate))
+	defer cancel()
+
+	if _, ok := d.GetOk("account_key_pem"); !ok {
+		key, err := GenerateAcmePrivateKey(ACME_KEY_TYPE_P256)
+		if err != nil {
+			return err
+		}
+		keyPem, err := EncodeAcmePrivateKey(key)
+		if err != nil {
+			return err
+		}
+		_ = d.Set("account_key_pem", keyPem)
+	}
+
+	if err := acmeCertificateIssue(ctx, d, meta); err != nil {
+		log.Printf("[CRITAL]%s issue ACME certificate failed, reason:%+v", logId, err)
+		return err
+	}
+
+	if len(d.Get("ssl_upload").([]interface{})) > 0 {
+		if err := acmeCertificateUpload(ctx, d, meta); err != nil {
+			return err
+		}
+	}
+
+	return resourceTencentCloudAcmeCertificateRead(d, meta)
+}
+
+func resourceTencentCloudAcmeCertificateRead(d *schema.ResourceData, meta interface{}) error {
+	defer tccommon.LogElapsed("resource.tencentcloud_acme_certificate.read")()
+	defer tccommon.InconsistentCheck(d, meta)()
+
+	logId := tccommon.GetLogId(tccommon.ContextNil)
+	ctx := context.WithValue(context.TODO(), tccommon.LogIdKey, logId)
+	sslService := SSLService{client: meta.(tccommon.ProviderMeta).GetAPIV3Conn()}
+
+	// the certificate only lives in the state, only the uploaded one is checked
+	sslCertificateId := d.Get("ssl_certificate_id").(string)
+	if sslCertificateId == "" {
+		return nil
+	}
+
+	var response *ssl.DescribeCertificateDetailResponse
+	request := ssl.NewDescribeCertificateDetailRequest()
+	request.CertificateId = &sslCertificateId
+	err := resource.Retry(tccommon.ReadRetryTimeout, func() *resource.RetryError {
+		result, e := sslService.DescribeCertificateDetail(ctx, request)
+		if e != nil {
+			if sdkErr, ok := e.(*sdkError.TencentCloudSDKError); ok && sdkErr.Code == CertificateNotFound {
+				return nil
+			}
+			return tccommon.RetryError(e)
+		}
+		response = result
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if response == nil || response.Response == nil || response.Response.CertificateId == nil {
+		log.Printf("[WARN]%s uploaded certificate [%s] of `tencentcloud_acme_certificate` [%s] not found, it will be uploaded again.", logId, sslCertificateId, d.Id())
+		_ = d.Set("ssl_certificate_id", "")
+	}
+
+	return nil
+}
+
+func resourceTencentCloudAcmeCertificateUpdate(d *schema.ResourceData, meta interface{}) error {
+	defer tccommon.LogElapsed("resource.tencentcloud_acme_certificate.update")()
+
+	logId := tccommon.GetLogId(tccommon.ContextNil)
+	ctx, cancel := context.WithTimeout(context.WithValue(context.TODO(), tccommon.LogIdKey, logId), d.Timeout(schema.TimeoutUpdate))
+	defer cancel()
+
+	// the computed attributes planned to change are unknown here, so the values in the state are used
+	oldCertificatePem, _ := d.GetChange("certificate_pem")
+	oldSslCertificateId, _ := d.GetChange("ssl_certificate_id")
+	oldPreviousSslCertificateIds, _ := d.GetChange("previous_ssl_certificate_ids")
+	sslCertificateId := oldSslCertificateId.(string)
+	upload := len(d.Get("ssl_upload").([]interface{})) > 0
+
+	renew, err := AcmeCertificateExpiresWithin(oldCertificatePem.(string), d.Get("min_days_remaining").(int))
+	if err != nil {
+		return err
+	}
+
+	if renew {
+		if err := acmeCertificateIssue(ctx, d, meta); err != nil {
+			log.Printf("[CRITAL]%s renew ACME certificate failed, reason:%+v", logId, err)
+			return err
+		}
+		if upload {
+			// the previous certificate may still be bound to resources which are updated later, so it is kept
+			previousSslCertificateIds := helper.InterfacesStrings(oldPreviousSslCertificateIds.([]interface{}))
+			if sslCertificateId != "" {
+				previousSslCertificateIds = append(previousSslCertificateIds, sslCertificateId)
+			}
+			_ = d.Set("previous_ssl_certificate_ids", previousSslCertificateIds)
+
+			// the renewed certificate is uploaded again at the next apply if the upload fails
+			_ = d.Set("ssl_certificate_id", "")
+			if err := acmeCertificateUpload(ctx, d, meta); err != nil {
+				return err
+			}
+			sslCertificateId = d.Get("ssl_certificate_id").(string)
+		}
+	}
+
+	switch {
+	case !upload && sslCertificateId != "":
+		if err := acmeCertificateDeleteUploaded(ctx, meta, sslCertificateId); err != nil {
+			return err
+		}
+		_ = d.Set("ssl_certificate_id", "")
+	case upload && sslCertificateId == "":
+		if err := acmeCertificateUpload(ctx, d, meta); err != nil {
+			return err
+		}
+	case upload && !renew && d.HasChange("ssl_upload"):
+		if err := acmeCertificateModifyUploaded(ctx, d, meta, sslCertificateId); err != nil {
+			return err
+		}
+	}
+
+	return resourceTencentCloudAcmeCertificateRead(d, meta)
+}
+
+func resourceTencentCloudAcmeCertificateDelete(d *schema.ResourceData, meta interface{}) error {
+	defer tccommon.LogElapsed("resource.tencentcloud_acme_certificate.delete")()
+
+	logId := tccommon.GetLogId(tccommon.ContextNil)
+	ctx := context.WithValue(context.TODO(), tccommon.LogIdKey, logId)
+
+	if sslCertificateId := d.Get("ssl_certificate_id").(string); sslCertificateId != "" {
+		if err := acmeCertificateDeleteUploaded(ctx, meta, sslCertificateId); err != nil {
+			return err
+		}
+	}
+
+	if d.Get("revoke_certificate_on_destroy").(bool) {
+		account, err := acmeCertificateAccount(d)
+		if err != nil {
+			return err
+		}
+		if err := RevokeAcmeCertificate(ctx, account, d.Get("certificate_pem").(string)); err != nil {
+			log.Printf("[CRITAL]%s revoke ACME certificate failed, reason:%+v", logId, err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func acmeCertificateAccount(d *schema.ResourceData) (*AcmeAccount, error) {
+	key, err := ParseSslPrivateKey(d.Get("account_key_pem").(string))
+	if err != nil {
+		return nil, fmt.Errorf("invalid account_key_pem, %v", err)
+	}
+
+	account := &AcmeAccount{
+		DirectoryURL: d.Get("directory_url").(string),
+		DirectoryCA:  d.Get("directory_ca_certificate").(string),
+		Key:          key,
+		Email:        d.Get("email").(string),
+	}
+
+	if v, ok := d.GetOk("external_account_binding"); ok {
+		binding := v.([]interface{})[0].(map[string]interface{})
+		hmacKey, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(binding["hmac_key"].(string), "="))
+		if err != nil {
+			return nil, fmt.Errorf("invalid external_account_binding.hmac_key, %v", err)
+		}
+		account.EabKeyId = binding["key_id"].(string)
+		account.EabHmacKey = hmacKey
+	}
+	return account, nil
+}
+
+func acmeCertificateIssue(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
+	account, err := acmeCertificateAccount(d)
+	if err != nil {
+		return err
+	}
+
+	commonName := d.Get("common_name").(string)
+	names := []string{commonName}
+	alternativeNames := helper.InterfacesStrings(d.Get("subject_alternative_names").(*schema.Set).List())
+	sort.Strings(alternativeNames)
+	for _, name := range alternativeNames {
+		if name != commonName {
+			names = append(names, name)
+		}
+	}
+
+	solver := &dnspodAcmeDnsChallengeSolver{
+		service:    svcdnspod.NewDnspodService(meta.(tccommon.ProviderMeta).GetAPIV3Conn()),
+		recordLine: "默认",
+		ttl:        600,
+	}
+	propagationWait := 30 * time.Second
+	if v, ok := d.GetOk("dns_challenge"); ok && v.([]interface{})[0] != nil {
+		challenge := v.([]interface{})[0].(map[string]interface{})
+		solver.zone = challenge["zone"].(string)
+		solver.recordLine = challenge["record_line"].(string)
+		solver.ttl = challenge["ttl"].(int)
+		propagationWait = time.Duration(challenge["propagation_wait"].(int)) * time.Second
+	}
+
+	certificate, err := IssueAcmeCertificate(ctx, account, names, d.Get("key_type").(string), propagationWait, solver)
+	if err != nil {
+		return err
+	}
+
+	// the ID is the URL of the first certificate, it is kept when the certificate is renewed
+	if d.Id() == "" {
+		d.SetId(certificate.CertificateURL)
+	}
+	_ = d.Set("account_url", certificate.AccountURL)
+	_ = d.Set("certificate_url", certificate.CertificateURL)
+	_ = d.Set("certificate_pem", certificate.CertificatePem)
+	_ = d.Set("issuer_pem", certificate.IssuerPem)
+	_ = d.Set("private_key_pem", certificate.PrivateKeyPem)
+	_ = d.Set("not_after", certificate.NotAfter.UTC().Format(time.RFC3339))
+	return nil
+}
+
+func acmeCertificateUpload(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
+	logId := tccommon.GetLogId(ctx)
+	sslService := SSLService{client: meta.(tccommon.ProviderMeta).GetAPIV3Conn()}
+	upload := d.Get("ssl_upload").([]interface{})[0]
+
+	alias := d.Get("common_name").(string)
+	projectId := 0
+	if upload != nil {
+		if v := upload.(map[string]interface{})["alias"].(string); v != "" {
+			alias = v
+		}
+		projectId = upload.(map[string]interface{})["project_id"].(int)
+	}
+
+	request := ssl.NewUploadCertificateRequest()
+	request.CertificatePublicKey = helper.String(d.Get("certificate_pem").(string) + d.Get("issuer_pem").(string))
+	request.CertificatePrivateKey = helper.String(d.Get("private_key_pem").(string))
+	request.CertificateType = helper.String(SSL_CERT_TYPE_SERVER)
+	request.Alias = &alias
+	request.ProjectId = helper.IntUint64(projectId)
+
+	var id string
+	err := resource.Retry(tccommon.WriteRetryTimeout, func() *resource.RetryError {
+		result, e := sslService.UploadCertificate(ctx, request)
+		if e != nil {
+			return tccommon.RetryError(e)
+		}
+		id = result
+		return nil
+	})
+	if err != nil {
+		log.Printf("[CRITAL]%s upload ACME certificate failed, reason:%+v", logId, err)
+		return err
+	}
+
+	_ = d.Set("ssl_certificate_id", id)
+	return nil
+}
+
+func acmeCertificateModifyUploaded(ctx context.Context, d *schema.ResourceData, meta interface{}, sslCertificateId string) error {
+	sslService := SSLService{client: meta.(tccommon.ProviderMeta).GetAPIV3Conn()}
+
+	alias := d.Get("common_name").(string)
+	if v, ok := d.GetOk("ssl_upload.0.alias"); ok {
+		alias = v.(string)
+	}
+
+	if d.HasChange("ssl_upload.0.alias") {
+		request := ssl.NewModifyCertificateAliasRequest()
+		request.CertificateId = &sslCertificateId
+		request.Alias = &alias
+		err := resource.Retry(tccommon.WriteRetryTimeout, func() *resource.RetryError {
+			if e := sslService.ModifyCertificateAlias(ctx, request); e != nil {
+				return tccommon.RetryError(e)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("ssl_upload.0.project_id") {
+		request := ssl.NewModifyCertificateProjectRequest()
+		request.CertificateIdList = []*string{&sslCertificateId}
+		request.ProjectId = helper.IntUint64(d.Get("ssl_upload.0.project_id").(int))
+		err := resource.Retry(tccommon.WriteRetryTimeout, func() *resource.RetryError {
+			if e := sslService.ModifyCertificateProject(ctx, request); e != nil {
+				return tccommon.RetryError(e)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func acmeCertificateDeleteUploaded(ctx context.Context, meta interface{}, sslCertificateId string) error {
+	sslService := SSLService{client: meta.(tccommon.ProviderMeta).GetAPIV3Conn()}
+	request := ssl.NewDeleteCertificateRequest()
+	request.CertificateId = &sslCertificateId
+
+	return resource.Retry(tccommon.WriteRetryTimeout, func() *resource.RetryError {
+		deleteResult, e := sslService.DeleteCertificate(ctx, request)
+		if e != nil {
+			if sdkErr, ok := e.(*sdkError.TencentCloudSDKError); ok && sdkErr.Code == CertificateNotFound {
+				return nil
+			}
+			return tccommon.RetryError(e)
+		}
+		if !deleteResult {
+			return resource.NonRetryableError(errors.New("failed to delete certificate"))
+		}
+		return nil
+	})
+}
+
+// dnspodAcmeDnsChallengeSolver creates the TXT records of DNS-01 challenges in DNSPod.
+type dnspodAcmeDnsChallengeSolver struct {
+	service    svcdnspod.DnspodService
+	zone       string
+	recordLine string
+	ttl        int
+}
+
+func (me *dnspodAcmeDnsChallengeSolver) Present(ctx context.Context, fqdn, value string) (func(ctx context.Context) error, error) {
+	zone := me.zone
+	if zone == "" {
+		detected, err := me.service.DescribeDnspodZoneByName(ctx, fqdn)
+		if err != nil {
+			return nil, err
+		}
+		if detected == "" {
+			return nil, fmt.Errorf("no DNSPod domain found for %s, please specify `dns_challenge.zone`", fqdn)
+		}
+		zone = detected
+	}
+	if !strings.HasSuffix(fqdn, "."+zone) {
+		return nil, fmt.Errorf("%s does not belong to DNSPod domain %s", fqdn, zone)
+	}
+	subDomain := strings.TrimSuffix(fqdn, "."+zone)
+
+	recordId, err := me.service.CreateDnspodTxtRecord(ctx, zone, subDomain, value, me.recordLine, me.ttl)
+	if err != nil {
+		return nil, err
+	}
+	return func(ctx context.Context) error {
+		return me.service.DeleteDnspodRecordById(ctx, zone, recordId)
+	}, nil
+}
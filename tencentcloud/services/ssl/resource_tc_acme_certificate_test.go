@@ -0,0 +1,70 @@
+package ssl_test
+
+import (
+	"testing"
+
+	tcacctest "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/acctest"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// go test -i; go test -test.run TestAccTencentCloudAcmeCertificateResource_basic -v
+func TestAccTencentCloudAcmeCertificateResource_basic(t *testing.T) {
+	t.Parallel()
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			tcacctest.AccPreCheck(t)
+		},
+		Providers: tcacctest.AccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAcmeCertificate,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("tencentcloud_acme_certificate.example", "id"),
+					resource.TestCheckResourceAttrSet("tencentcloud_acme_certificate.example", "account_url"),
+					resource.TestCheckResourceAttrSet("tencentcloud_acme_certificate.example", "certificate_pem"),
+					resource.TestCheckResourceAttrSet("tencentcloud_acme_certificate.example", "issuer_pem"),
+					resource.TestCheckResourceAttrSet("tencentcloud_acme_certificate.example", "private_key_pem"),
+					resource.TestCheckResourceAttrSet("tencentcloud_acme_certificate.example", "not_after"),
+					resource.TestCheckResourceAttrSet("tencentcloud_acme_certificate.example", "ssl_certificate_id"),
+				),
+			},
+			{
+				Config: testAccAcmeCertificateUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tencentcloud_acme_certificate.example", "ssl_certificate_id", ""),
+				),
+			},
+		},
+	})
+}
+
+const testAccAcmeCertificate = `
+resource "tencentcloud_acme_certificate" "example" {
+  directory_url             = "https://acme-staging-v02.api.letsencrypt.org/directory"
+  email                     = "tf-example@iac-tf.cloud"
+  common_name               = "acme.iac-tf.cloud"
+  subject_alternative_names = ["*.acme.iac-tf.cloud"]
+
+  dns_challenge {
+    zone = "iac-tf.cloud"
+  }
+
+  ssl_upload {
+    alias = "tf-example-acme"
+  }
+}
+`
+
+const testAccAcmeCertificateUpdate = `
+resource "tencentcloud_acme_certificate" "example" {
+  directory_url             = "https://acme-staging-v02.api.letsencrypt.org/directory"
+  email                     = "tf-example@iac-tf.cloud"
+  common_name               = "acme.iac-tf.cloud"
+  subject_alternative_names = ["*.acme.iac-tf.cloud"]
+
+  dns_challenge {
+    zone = "iac-tf.cloud"
+  }
+}
+`
@@ -2,11 +2,13 @@ package ssl
 
 import (
 	"context"
+	"crypto/x509"
 	"errors"
 	"fmt"
 	"log"
 	"strconv"
 	"strings"
+	"time"
 
 	tccommon "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/common"
 	svctag "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/services/tag"
@@ -28,6 +30,7 @@ func ResourceTencentCloudSslCertificate() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: sslCertificateCustomizeDiff,
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -53,7 +56,10 @@ func ResourceTencentCloudSslCertificate() *schema.Resource {
 				Type:        schema.TypeString,
 				Required:    true,
 				ForceNew:    true,
-				Description: "Content of the SSL certificate. Not allowed newline at the start and end.",
+				Description: "Content of the SSL certificate. Not allowed newline at the start and end. The certificates of `SVR` type are checked at plan time and uploaded in the order from the leaf to the root.",
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return SslCertificatePemEqual(old, new)
+				},
 				ValidateFunc: func(v interface{}, k string) (wss []string, errs []error) {
 					value := v.(string)
 					if strings.HasPrefix(value, "\n") {
@@ -129,10 +135,72 @@ func ResourceTencentCloudSslCertificate() *schema.Resource {
 				Computed:    true,
 				Description: "ALL domains included in the SSL certificate. Including the primary domain name.",
 			},
+			"not_before": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Time from which the certificate is valid in RFC3339 format, parsed from `cert`.",
+			},
+			"not_after": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Expiration time of the certificate in RFC3339 format, parsed from `cert`.",
+			},
+			"subject_alternative_names": {
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Computed:    true,
+				Description: "Subject alternative names of the certificate, parsed from `cert`.",
+			},
+			"fingerprint_sha256": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Lower case hex SHA-256 fingerprint of the certificate, parsed from `cert`.",
+			},
+			"issuer": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Distinguished name of the issuer of the certificate, parsed from `cert`.",
+			},
 		},
 	}
 }
 
+// sslCertificateCustomizeDiff checks the certificate chain, the expiration and the private key locally,
+// so that a bad certificate fails at plan time instead of being rejected by the API or breaking the bindings.
+func sslCertificateCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() != "" && !d.HasChange("cert") && !d.HasChange("key") {
+		return nil
+	}
+	if !d.NewValueKnown("type") || !d.NewValueKnown("cert") || !d.NewValueKnown("key") {
+		return nil
+	}
+
+	_, certs, err := CheckSslCertificate(d.Get("type").(string), d.Get("cert").(string), d.Get("key").(string))
+	if err != nil {
+		return err
+	}
+	if len(certs) == 0 || d.Id() != "" {
+		return nil
+	}
+
+	for key, value := range sslCertificateParsedAttributes(certs[0]) {
+		if err := d.SetNew(key, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func sslCertificateParsedAttributes(cert *x509.Certificate) map[string]interface{} {
+	return map[string]interface{}{
+		"not_before":                cert.NotBefore.UTC().Format(time.RFC3339),
+		"not_after":                 cert.NotAfter.UTC().Format(time.RFC3339),
+		"subject_alternative_names": SslCertificateAlternativeNames(cert),
+		"fingerprint_sha256":        SslCertificateFingerprintSha256(cert),
+		"issuer":                    cert.Issuer.String(),
+	}
+}
+
 func resourceTencentCloudSslCertificateCreate(d *schema.ResourceData, m interface{}) error {
 	defer tccommon.LogElapsed("resource.tencentcloud_ssl_certificate.create")()
 
@@ -145,8 +213,13 @@ func resourceTencentCloudSslCertificateCreate(d *schema.ResourceData, m interfac
 		describeResponse *ssl.DescribeCertificateDetailResponse
 	)
 
+	certPem, _, err := CheckSslCertificate(d.Get("type").(string), d.Get("cert").(string), d.Get("key").(string))
+	if err != nil {
+		return err
+	}
+
 	request := ssl.NewUploadCertificateRequest()
-	request.CertificatePublicKey = helper.String(d.Get("cert").(string))
+	request.CertificatePublicKey = helper.String(certPem)
 	request.CertificateType = helper.String(d.Get("type").(string))
 	request.ProjectId = helper.Uint64(uint64(d.Get("project_id").(int)))
 	request.Alias = helper.String(d.Get("name").(string))
@@ -283,6 +356,14 @@ func resourceTencentCloudSslCertificateRead(d *schema.ResourceData, m interface{
 	}
 	_ = d.Set("subject_names", subjectAltNames)
 
+	if certs, err := ParseSslCertificates(*certificate.CertificatePublicKey); err == nil {
+		for key, value := range sslCertificateParsedAttributes(certs[0]) {
+			_ = d.Set(key, value)
+		}
+	} else {
+		log.Printf("[WARN]%s parse certificate [%s] failed, reason:%+v", logId, d.Id(), err)
+	}
+
 	tagClient := m.(tccommon.ProviderMeta).GetAPIV3Conn()
 	tagService := svctag.NewTagService(tagClient)
 
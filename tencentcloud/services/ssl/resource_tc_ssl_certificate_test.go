@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"regexp"
 	"strings"
 	"testing"
 	"time"
@@ -160,12 +161,36 @@ func TestAccTencentCloudSslCertificate_svr(t *testing.T) {
 					resource.TestCheckResourceAttrSet("tencentcloud_ssl_certificate.foo", "end_time"),
 					resource.TestCheckResourceAttrSet("tencentcloud_ssl_certificate.foo", "create_time"),
 					resource.TestCheckResourceAttr("tencentcloud_ssl_certificate.foo", "subject_names.#", "0"),
+					resource.TestCheckResourceAttr("tencentcloud_ssl_certificate.foo", "not_after", "2033-08-26T08:04:53Z"),
+					resource.TestCheckResourceAttrSet("tencentcloud_ssl_certificate.foo", "not_before"),
+					resource.TestCheckResourceAttrSet("tencentcloud_ssl_certificate.foo", "fingerprint_sha256"),
+					resource.TestCheckResourceAttrSet("tencentcloud_ssl_certificate.foo", "issuer"),
 				),
 			},
 		},
 	})
 }
 
+func TestAccTencentCloudSslCertificate_invalid(t *testing.T) {
+	t.Parallel()
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { tcacctest.AccPreCheck(t) },
+		Providers: tcacctest.AccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccSslCertificate("SVR", "invalid certificate", "server", testAccSslCertificateKey),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("invalid PEM data"),
+			},
+			{
+				Config:      testAccSslCertificate("SVR", testAccSslCertificateCA, "server", testAccSslCertificateCA),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("unexpected PEM block `CERTIFICATE` in the private key"),
+			},
+		},
+	})
+}
+
 func testAccCheckSslCertificateDestroy(s *terraform.State) error {
 	logId := tccommon.GetLogId(tccommon.ContextNil)
 	ctx := context.WithValue(context.TODO(), tccommon.LogIdKey, logId)
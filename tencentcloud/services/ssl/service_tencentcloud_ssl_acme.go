@@ -0,0 +1,284 @@
+package ssl
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"golang.org/x/crypto/acme"
+
+	tccommon "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/common"
+)
+
+// AcmeDnsChallengeSolver publishes the TXT records of the DNS-01 challenges.
+type AcmeDnsChallengeSolver interface {
+	// Present creates the TXT record of fqdn and returns the function to remove it.
+	Present(ctx context.Context, fqdn, value string) (cleanup func(ctx context.Context) error, err error)
+}
+
+type AcmeAccount struct {
+	DirectoryURL string
+	// PEM encoded CA certificates trusted besides the system ones when connecting to the directory, such as the one of Pebble.
+	DirectoryCA string
+	Key         crypto.Signer
+	Email       string
+	EabKeyId    string
+	EabHmacKey  []byte
+}
+
+type AcmeCertificate struct {
+	AccountURL     string
+	CertificateURL string
+	CertificatePem string
+	IssuerPem      string
+	PrivateKeyPem  string
+	NotAfter       time.Time
+}
+
+func newAcmeClient(account *AcmeAccount) (*acme.Client, error) {
+	httpClient := http.DefaultClient
+	if account.DirectoryCA != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM([]byte(account.DirectoryCA)) {
+			return nil, errors.New("no certificate found in the CA of the ACME directory")
+		}
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+		httpClient = &http.Client{Transport: transport}
+	}
+
+	return &acme.Client{
+		Key:          account.Key,
+		DirectoryURL: account.DirectoryURL,
+		HTTPClient:   httpClient,
+		UserAgent:    "terraform-provider-tencentcloud",
+	}, nil
+}
+
+// registerAcmeAccount registers the account key, it is fine if the key has been registered.
+func registerAcmeAccount(ctx context.Context, client *acme.Client, account *AcmeAccount) (accountURL string, err error) {
+	acct := &acme.Account{}
+	if account.Email != "" {
+		acct.Contact = []string{"mailto:" + account.Email}
+	}
+	if account.EabKeyId != "" {
+		acct.ExternalAccountBinding = &acme.ExternalAccountBinding{KID: account.EabKeyId, Key: account.EabHmacKey}
+	}
+
+	if _, err = client.Register(ctx, acct, acme.AcceptTOS); err != nil && !errors.Is(err, acme.ErrAccountAlreadyExists) {
+		return "", fmt.Errorf("register ACME account failed, %v", err)
+	}
+	return string(client.KID), nil
+}
+
+// IssueAcmeCertificate orders a certificate of the names, which are the common name followed by the alternative names,
+// and solves the DNS-01 challenges of them by solver.
+func IssueAcmeCertificate(ctx context.Context, account *AcmeAccount, names []string, keyType string,
+	propagationWait time.Duration, solver AcmeDnsChallengeSolver) (certificate *AcmeCertificate, errRet error) {
+	logId := tccommon.GetLogId(ctx)
+
+	client, err := newAcmeClient(account)
+	if err != nil {
+		return nil, err
+	}
+	accountURL, err := registerAcmeAccount(ctx, client, account)
+	if err != nil {
+		return nil, err
+	}
+
+	order, err := client.AuthorizeOrder(ctx, acme.DomainIDs(names...))
+	if err != nil {
+		return nil, fmt.Errorf("create ACME order of %v failed, %v", names, err)
+	}
+
+	var (
+		cleanups   []func(ctx context.Context) error
+		challenges []*acme.Challenge
+		authzURLs  []string
+	)
+	// the TXT records are removed whether the order succeeds or not
+	defer func() {
+		for _, cleanup := range cleanups {
+			if err := cleanup(ctx); err != nil {
+				log.Printf("[WARN]%s remove TXT record of ACME challenge failed, reason:%+v", logId, err)
+			}
+		}
+	}()
+
+	for _, authzURL := range order.AuthzURLs {
+		authz, err := client.GetAuthorization(ctx, authzURL)
+		if err != nil {
+			return nil, fmt.Errorf("get ACME authorization failed, %v", err)
+		}
+		if authz.Status == acme.StatusValid {
+			continue
+		}
+
+		var challenge *acme.Challenge
+		for _, item := range authz.Challenges {
+			if item.Type == "dns-01" {
+				challenge = item
+				break
+			}
+		}
+		if challenge == nil {
+			return nil, fmt.Errorf("ACME authorization of %s has no dns-01 challenge", authz.Identifier.Value)
+		}
+
+		value, err := client.DNS01ChallengeRecord(challenge.Token)
+		if err != nil {
+			return nil, err
+		}
+		cleanup, err := solver.Present(ctx, ACME_DNS_CHALLENGE_PREFIX+"."+authz.Identifier.Value, value)
+		if err != nil {
+			return nil, err
+		}
+		cleanups = append(cleanups, cleanup)
+		challenges = append(challenges, challenge)
+		authzURLs = append(authzURLs, authz.URI)
+	}
+
+	if len(challenges) > 0 && propagationWait > 0 {
+		log.Printf("[DEBUG]%s wait %s for the TXT records of ACME challenges to propagate", logId, propagationWait)
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(propagationWait):
+		}
+	}
+
+	for i, challenge := range challenges {
+		if _, err := client.Accept(ctx, challenge); err != nil {
+			return nil, fmt.Errorf("accept ACME challenge failed, %v", err)
+		}
+		if _, err := client.WaitAuthorization(ctx, authzURLs[i]); err != nil {
+			return nil, fmt.Errorf("ACME authorization failed, %v", err)
+		}
+	}
+
+	order, err = client.WaitOrder(ctx, order.URI)
+	if err != nil {
+		return nil, fmt.Errorf("ACME order failed, %v", err)
+	}
+
+	key, err := GenerateAcmePrivateKey(keyType)
+	if err != nil {
+		return nil, err
+	}
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject:  pkix.Name{CommonName: names[0]},
+		DNSNames: names,
+	}, key)
+	if err != nil {
+		return nil, err
+	}
+
+	der, certURL, err := client.CreateOrderCert(ctx, order.FinalizeURL, csr, true)
+	if err != nil {
+		return nil, fmt.Errorf("finalize ACME order failed, %v", err)
+	}
+	if len(der) == 0 {
+		return nil, errors.New("ACME server returns no certificate")
+	}
+
+	leaf, err := x509.ParseCertificate(der[0])
+	if err != nil {
+		return nil, err
+	}
+	keyPem, err := EncodeAcmePrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	certificate = &AcmeCertificate{
+		AccountURL:     accountURL,
+		CertificateURL: certURL,
+		CertificatePem: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der[0]})),
+		PrivateKeyPem:  keyPem,
+		NotAfter:       leaf.NotAfter,
+	}
+	for _, item := range der[1:] {
+		certificate.IssuerPem += string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: item}))
+	}
+	return
+}
+
+func RevokeAcmeCertificate(ctx context.Context, account *AcmeAccount, certificatePem string) error {
+	block, _ := pem.Decode([]byte(certificatePem))
+	if block == nil {
+		return errors.New("invalid certificate PEM")
+	}
+
+	client, err := newAcmeClient(account)
+	if err != nil {
+		return err
+	}
+	if _, err := registerAcmeAccount(ctx, client, account); err != nil {
+		return err
+	}
+
+	if err := client.RevokeCert(ctx, nil, block.Bytes, acme.CRLReasonCessationOfOperation); err != nil {
+		return fmt.Errorf("revoke ACME certificate failed, %v", err)
+	}
+	return nil
+}
+
+func GenerateAcmePrivateKey(keyType string) (crypto.Signer, error) {
+	switch keyType {
+	case ACME_KEY_TYPE_P256:
+		return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case ACME_KEY_TYPE_P384:
+		return ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	case ACME_KEY_TYPE_RSA2048:
+		return rsa.GenerateKey(rand.Reader, 2048)
+	case ACME_KEY_TYPE_RSA4096:
+		return rsa.GenerateKey(rand.Reader, 4096)
+	}
+	return nil, fmt.Errorf("unsupported key type %s", keyType)
+}
+
+// EncodeAcmePrivateKey encodes the key as PKCS#1 for RSA keys and SEC 1 for EC keys, which are accepted by SSL certificate management.
+func EncodeAcmePrivateKey(key crypto.Signer) (string, error) {
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		return string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(k)})), nil
+	case *ecdsa.PrivateKey:
+		der, err := x509.MarshalECPrivateKey(k)
+		if err != nil {
+			return "", err
+		}
+		return string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})), nil
+	}
+	return "", fmt.Errorf("unsupported private key type %T", key)
+}
+
+// AcmeCertificateExpiresWithin returns whether the certificate expires within days, a missing certificate is regarded as expired.
+func AcmeCertificateExpiresWithin(certificatePem string, days int) (bool, error) {
+	if certificatePem == "" {
+		return true, nil
+	}
+	block, _ := pem.Decode([]byte(certificatePem))
+	if block == nil {
+		return false, errors.New("invalid certificate PEM")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return false, err
+	}
+	return time.Until(cert.NotAfter) < time.Duration(days)*24*time.Hour, nil
+}
@@ -0,0 +1,206 @@
+package ssl
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// The tests run against a local Pebble (https://github.com/letsencrypt/pebble) and are skipped unless it is configured:
+//
+//	pebble-challtestsrv -http01 "" -https01 "" -tlsalpn01 "" &
+//	pebble -config test/config/pebble-config.json -dnsserver 127.0.0.1:8053 &
+//	ACME_PEBBLE_DIRECTORY_URL=https://127.0.0.1:14000/dir \
+//	ACME_PEBBLE_CA_FILE=test/certs/pebble.minica.pem \
+//	ACME_PEBBLE_CHALLTESTSRV_URL=http://127.0.0.1:8055 \
+//	go test -test.run TestIssueAcmeCertificatePebble -v
+//
+// Without ACME_PEBBLE_CHALLTESTSRV_URL, Pebble must be started with PEBBLE_VA_ALWAYS_VALID=1.
+const (
+	acmePebbleDirectoryURL    = "ACME_PEBBLE_DIRECTORY_URL"
+	acmePebbleCAFile          = "ACME_PEBBLE_CA_FILE"
+	acmePebbleChalltestsrvURL = "ACME_PEBBLE_CHALLTESTSRV_URL"
+)
+
+// fakeAcmeDnsChallengeSolver records the TXT records of the challenges, and publishes them to pebble-challtestsrv if
+// its management URL is set.
+type fakeAcmeDnsChallengeSolver struct {
+	challtestsrvURL string
+	// failAfter fails presenting after the number of records, no failure if 0
+	failAfter int
+
+	mu       sync.Mutex
+	records  map[string][]string
+	presents int
+}
+
+func (me *fakeAcmeDnsChallengeSolver) Present(ctx context.Context, fqdn, value string) (func(ctx context.Context) error, error) {
+	me.mu.Lock()
+	defer me.mu.Unlock()
+
+	if me.failAfter > 0 && me.presents >= me.failAfter {
+		return nil, errors.New("present TXT record failed")
+	}
+	me.presents++
+	if err := me.post("/set-txt", map[string]string{"host": fqdn + ".", "value": value}); err != nil {
+		return nil, err
+	}
+	if me.records == nil {
+		me.records = make(map[string][]string)
+	}
+	me.records[fqdn] = append(me.records[fqdn], value)
+
+	return func(ctx context.Context) error {
+		me.mu.Lock()
+		defer me.mu.Unlock()
+		if err := me.post("/clear-txt", map[string]string{"host": fqdn + "."}); err != nil {
+			return err
+		}
+		values := me.records[fqdn]
+		for i, item := range values {
+			if item == value {
+				me.records[fqdn] = append(values[:i], values[i+1:]...)
+				break
+			}
+		}
+		if len(me.records[fqdn]) == 0 {
+			delete(me.records, fqdn)
+		}
+		return nil
+	}, nil
+}
+
+func (me *fakeAcmeDnsChallengeSolver) post(path string, body interface{}) error {
+	if me.challtestsrvURL == "" {
+		return nil
+	}
+	data, _ := json.Marshal(body)
+	response, err := http.Post(me.challtestsrvURL+path, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("pebble-challtestsrv %s returned status %d", path, response.StatusCode)
+	}
+	return nil
+}
+
+func testAcmePebbleAccount(t *testing.T) *AcmeAccount {
+	directoryURL := os.Getenv(acmePebbleDirectoryURL)
+	if directoryURL == "" {
+		t.Skipf("%s is not set, skip the tests against Pebble", acmePebbleDirectoryURL)
+	}
+
+	var ca string
+	if file := os.Getenv(acmePebbleCAFile); file != "" {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ca = string(data)
+	}
+
+	key, err := GenerateAcmePrivateKey(ACME_KEY_TYPE_P256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &AcmeAccount{DirectoryURL: directoryURL, DirectoryCA: ca, Key: key, Email: "tf-acme@example.com"}
+}
+
+func TestIssueAcmeCertificatePebble(t *testing.T) {
+	account := testAcmePebbleAccount(t)
+	ctx, cancel := context.WithTimeout(context.TODO(), 5*time.Minute)
+	defer cancel()
+
+	var accountURL string
+	for _, keyType := range []string{ACME_KEY_TYPE_P256, ACME_KEY_TYPE_RSA2048} {
+		t.Run(keyType, func(t *testing.T) {
+			// Pebble reuses valid authorizations of the account, so each order has its own names
+			domain := strings.ToLower(keyType) + ".tf-acme.example.com"
+			names := []string{domain, "*." + domain, "www." + domain}
+			solver := &fakeAcmeDnsChallengeSolver{challtestsrvURL: os.Getenv(acmePebbleChalltestsrvURL)}
+			certificate, err := IssueAcmeCertificate(ctx, account, names, keyType, 0, solver)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// the wildcard shares the TXT record name with its base domain
+			if solver.presents != len(names) {
+				t.Errorf("expected %d TXT records presented, got %d", len(names), solver.presents)
+			}
+			if len(solver.records) != 0 {
+				t.Errorf("TXT records are not removed: %v", solver.records)
+			}
+
+			if accountURL == "" {
+				accountURL = certificate.AccountURL
+			} else if certificate.AccountURL != accountURL {
+				t.Errorf("the registered account is not reused, %s != %s", certificate.AccountURL, accountURL)
+			}
+			if certificate.CertificateURL == "" || certificate.IssuerPem == "" {
+				t.Errorf("unexpected certificate %+v", certificate)
+			}
+
+			block, _ := pem.Decode([]byte(certificate.CertificatePem))
+			if block == nil {
+				t.Fatal("invalid certificate PEM")
+			}
+			leaf, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				t.Fatal(err)
+			}
+			dnsNames := append([]string(nil), leaf.DNSNames...)
+			expectedNames := append([]string(nil), names...)
+			sort.Strings(dnsNames)
+			sort.Strings(expectedNames)
+			if !reflect.DeepEqual(dnsNames, expectedNames) {
+				t.Errorf("unexpected DNS names %v", leaf.DNSNames)
+			}
+			if !leaf.NotAfter.Equal(certificate.NotAfter) {
+				t.Errorf("unexpected not after %s", certificate.NotAfter)
+			}
+			if _, err := tls.X509KeyPair([]byte(certificate.CertificatePem+certificate.IssuerPem), []byte(certificate.PrivateKeyPem)); err != nil {
+				t.Errorf("private key does not match the certificate: %s", err)
+			}
+
+			renew, err := AcmeCertificateExpiresWithin(certificate.CertificatePem, 1)
+			if err != nil || renew {
+				t.Errorf("new certificate is regarded as expiring: %t, %v", renew, err)
+			}
+
+			if err := RevokeAcmeCertificate(ctx, account, certificate.CertificatePem); err != nil {
+				t.Errorf("revoke certificate failed: %s", err)
+			}
+		})
+	}
+}
+
+func TestIssueAcmeCertificatePebbleSolverFailed(t *testing.T) {
+	account := testAcmePebbleAccount(t)
+	ctx, cancel := context.WithTimeout(context.TODO(), 5*time.Minute)
+	defer cancel()
+
+	solver := &fakeAcmeDnsChallengeSolver{challtestsrvURL: os.Getenv(acmePebbleChalltestsrvURL), failAfter: 1}
+	_, err := IssueAcmeCertificate(ctx, account, []string{"tf-acme-fail.example.com", "www.tf-acme-fail.example.com"}, ACME_KEY_TYPE_P256, 0, solver)
+	if err == nil {
+		t.Fatal("expected error of the failed solver")
+	}
+	// the records presented before the failure are removed
+	if solver.presents != 1 || len(solver.records) != 0 {
+		t.Errorf("unexpected presents %d and records %v", solver.presents, solver.records)
+	}
+}
@@ -0,0 +1,283 @@
+package ssl
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrSslUnsupportedAlgorithm means the PEM data is well formed but uses algorithms which can not be checked locally, such as SM2.
+var ErrSslUnsupportedAlgorithm = errors.New("unsupported algorithm")
+
+func wrapSslUnsupportedAlgorithm(err error) error {
+	msg := err.Error()
+	if strings.Contains(msg, "unsupported") || strings.Contains(msg, "unknown elliptic curve") {
+		return fmt.Errorf("%w: %v", ErrSslUnsupportedAlgorithm, err)
+	}
+	return err
+}
+
+// ParseSslCertificates decodes all the certificates of the PEM data in order, duplicated certificates are dropped.
+func ParseSslCertificates(certPem string) ([]*x509.Certificate, error) {
+	var (
+		certs []*x509.Certificate
+		rest  = []byte(strings.TrimSpace(certPem))
+	)
+	for len(rest) > 0 {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			return nil, errors.New("invalid PEM data, it should only contain blocks of `-----BEGIN CERTIFICATE-----`")
+		}
+		if block.Type != "CERTIFICATE" {
+			return nil, fmt.Errorf("unexpected PEM block `%s`, only certificates are allowed", block.Type)
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, wrapSslUnsupportedAlgorithm(fmt.Errorf("parse certificate %d failed, %v", len(certs)+1, err))
+		}
+
+		duplicated := false
+		for _, item := range certs {
+			if bytes.Equal(item.Raw, cert.Raw) {
+				duplicated = true
+				break
+			}
+		}
+		if !duplicated {
+			certs = append(certs, cert)
+		}
+		rest = bytes.TrimSpace(rest)
+	}
+
+	if len(certs) == 0 {
+		return nil, errors.New("no certificate found in the PEM data")
+	}
+	return certs, nil
+}
+
+func sslCertificateIssues(parent, child *x509.Certificate) bool {
+	return parent != child && bytes.Equal(child.RawIssuer, parent.RawSubject) && child.CheckSignatureFrom(parent) == nil
+}
+
+// OrderSslCertificateChain orders the certificates from the leaf to the root,
+// it fails if there is not exactly one leaf or a certificate is not in the chain of the leaf.
+func OrderSslCertificateChain(certs []*x509.Certificate) ([]*x509.Certificate, error) {
+	leaves := make([]*x509.Certificate, 0, 1)
+	for _, cert := range certs {
+		isIssuer := false
+		for _, other := range certs {
+			if sslCertificateIssues(cert, other) {
+				isIssuer = true
+				break
+			}
+		}
+		if !isIssuer {
+			leaves = append(leaves, cert)
+		}
+	}
+	if len(leaves) != 1 {
+		names := make([]string, 0, len(leaves))
+		for _, leaf := range leaves {
+			names = append(names, leaf.Subject.String())
+		}
+		return nil, fmt.Errorf("malformed certificate chain, it should have exactly one leaf certificate, got %d: %v", len(leaves), names)
+	}
+
+	chain := []*x509.Certificate{leaves[0]}
+	remaining := make([]*x509.Certificate, 0, len(certs)-1)
+	for _, cert := range certs {
+		if cert != leaves[0] {
+			remaining = append(remaining, cert)
+		}
+	}
+	for len(remaining) > 0 {
+		current := chain[len(chain)-1]
+		next := -1
+		for i, cert := range remaining {
+			if sslCertificateIssues(cert, current) {
+				next = i
+				break
+			}
+		}
+		if next < 0 {
+			break
+		}
+		chain = append(chain, remaining[next])
+		remaining = append(remaining[:next], remaining[next+1:]...)
+	}
+	if len(remaining) > 0 {
+		return nil, fmt.Errorf("malformed certificate chain, certificate `%s` does not issue any certificate in the chain of `%s`",
+			remaining[0].Subject.String(), leaves[0].Subject.String())
+	}
+	return chain, nil
+}
+
+// EncodeSslCertificates encodes the certificates without the trailing newline, which is not allowed by `tencentcloud_ssl_certificate`.
+func EncodeSslCertificates(certs []*x509.Certificate) string {
+	var buf bytes.Buffer
+	for _, cert := range certs {
+		_ = pem.Encode(&buf, &pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
+	}
+	return strings.TrimRight(buf.String(), "\n")
+}
+
+// NormalizeSslCertificateChain returns the chain ordered from the leaf to the root.
+func NormalizeSslCertificateChain(certPem string) (string, []*x509.Certificate, error) {
+	certs, err := ParseSslCertificates(certPem)
+	if err != nil {
+		return "", nil, err
+	}
+	chain, err := OrderSslCertificateChain(certs)
+	if err != nil {
+		return "", nil, err
+	}
+	return EncodeSslCertificates(chain), chain, nil
+}
+
+func ParseSslPrivateKey(keyPem string) (crypto.Signer, error) {
+	block, _ := pem.Decode([]byte(strings.TrimSpace(keyPem)))
+	if block == nil {
+		return nil, errors.New("invalid private key PEM")
+	}
+
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, wrapSslUnsupportedAlgorithm(err)
+		}
+		return key, nil
+	case "EC PRIVATE KEY":
+		key, err := x509.ParseECPrivateKey(block.Bytes)
+		if err != nil {
+			return nil, wrapSslUnsupportedAlgorithm(err)
+		}
+		return key, nil
+	}
+	if block.Type != "PRIVATE KEY" {
+		return nil, fmt.Errorf("unexpected PEM block `%s` in the private key", block.Type)
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, wrapSslUnsupportedAlgorithm(err)
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported private key type %T", key)
+	}
+	return signer, nil
+}
+
+// CheckSslCertificateKey checks the private key matches the public key of the certificate.
+func CheckSslCertificateKey(cert *x509.Certificate, keyPem string) error {
+	key, err := ParseSslPrivateKey(keyPem)
+	if err != nil {
+		return err
+	}
+	pub, ok := cert.PublicKey.(interface{ Equal(crypto.PublicKey) bool })
+	if !ok {
+		return fmt.Errorf("%w: public key type %T", ErrSslUnsupportedAlgorithm, cert.PublicKey)
+	}
+	if !pub.Equal(key.Public()) {
+		return fmt.Errorf("the private key does not match the certificate `%s`", cert.Subject.String())
+	}
+	return nil
+}
+
+// CheckSslCertificate checks the certificate and the private key of the type, and returns the normalized certificate PEM.
+// The checks are skipped for the algorithms which can not be parsed locally, the PEM is returned as it is then.
+func CheckSslCertificate(certType, certPem, keyPem string) (string, []*x509.Certificate, error) {
+	var (
+		certs []*x509.Certificate
+		err   error
+	)
+	if certType == SSL_CERT_TYPE_CA {
+		certs, err = ParseSslCertificates(certPem)
+	} else {
+		var normalized string
+		normalized, certs, err = NormalizeSslCertificateChain(certPem)
+		if err == nil {
+			certPem = normalized
+		}
+	}
+	if errors.Is(err, ErrSslUnsupportedAlgorithm) {
+		return certPem, nil, nil
+	}
+	if err != nil {
+		return "", nil, err
+	}
+
+	now := time.Now()
+	if certType == SSL_CERT_TYPE_CA {
+		for _, cert := range certs {
+			if now.After(cert.NotAfter) {
+				return "", nil, fmt.Errorf("CA certificate `%s` has expired at %s", cert.Subject.String(), cert.NotAfter.UTC().Format(time.RFC3339))
+			}
+		}
+		return certPem, certs, nil
+	}
+
+	leaf := certs[0]
+	if now.After(leaf.NotAfter) {
+		return "", nil, fmt.Errorf("certificate `%s` has expired at %s", leaf.Subject.String(), leaf.NotAfter.UTC().Format(time.RFC3339))
+	}
+	if keyPem != "" {
+		if err := CheckSslCertificateKey(leaf, keyPem); err != nil && !errors.Is(err, ErrSslUnsupportedAlgorithm) {
+			return "", nil, err
+		}
+	}
+	return certPem, certs, nil
+}
+
+// SslCertificateFingerprintSha256 returns the lower case hex SHA-256 digest of the DER encoded certificate.
+func SslCertificateFingerprintSha256(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.Raw)
+	return hex.EncodeToString(sum[:])
+}
+
+// SslCertificateAlternativeNames returns the DNS names, IP addresses, emails and URIs of the certificate.
+func SslCertificateAlternativeNames(cert *x509.Certificate) []string {
+	names := make([]string, 0, len(cert.DNSNames)+len(cert.IPAddresses))
+	names = append(names, cert.DNSNames...)
+	for _, ip := range cert.IPAddresses {
+		names = append(names, ip.String())
+	}
+	names = append(names, cert.EmailAddresses...)
+	for _, uri := range cert.URIs {
+		names = append(names, uri.String())
+	}
+	return names
+}
+
+// SslCertificatePemEqual returns whether the PEM data contains the same chain regardless of the order and the blanks.
+func SslCertificatePemEqual(a, b string) bool {
+	if strings.TrimSpace(a) == strings.TrimSpace(b) {
+		return true
+	}
+	certsA, errA := ParseSslCertificates(a)
+	certsB, errB := ParseSslCertificates(b)
+	if errA != nil || errB != nil || len(certsA) != len(certsB) {
+		return false
+	}
+	for _, certA := range certsA {
+		found := false
+		for _, certB := range certsB {
+			if bytes.Equal(certA.Raw, certB.Raw) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,256 @@
+package ssl
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testSslCertificate struct {
+	cert *x509.Certificate
+	key  crypto.Signer
+}
+
+// newTestSslCertificate issues a certificate of key by the parent, or a self-signed one if parent is nil.
+func newTestSslCertificate(t *testing.T, name string, key crypto.Signer, parent *testSslCertificate, isCA bool, notAfter time.Time) *testSslCertificate {
+	t.Helper()
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             notAfter.Add(-365 * 24 * time.Hour),
+		NotAfter:              notAfter,
+		IsCA:                  isCA,
+		BasicConstraintsValid: true,
+	}
+	if isCA {
+		template.KeyUsage = x509.KeyUsageCertSign
+	} else {
+		template.DNSNames = []string{name}
+	}
+
+	issuer, issuerKey := template, key
+	if parent != nil {
+		issuer, issuerKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, issuer, key.Public(), issuerKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testSslCertificate{cert: cert, key: key}
+}
+
+func newTestEcdsaKey(t *testing.T) crypto.Signer {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func newTestRsaKey(t *testing.T) crypto.Signer {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func encodeTestSslPrivateKey(t *testing.T, key crypto.Signer, pkcs8 bool) string {
+	t.Helper()
+	if pkcs8 {
+		der, err := x509.MarshalPKCS8PrivateKey(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+	}
+	keyPem, err := EncodeAcmePrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return keyPem
+}
+
+// newTestSslChain returns the leaf, the intermediate and the root certificates.
+func newTestSslChain(t *testing.T, leafKey crypto.Signer) (leaf, intermediate, root *testSslCertificate) {
+	t.Helper()
+	notAfter := time.Now().Add(90 * 24 * time.Hour)
+	root = newTestSslCertificate(t, "Test Root CA", newTestEcdsaKey(t), nil, true, notAfter)
+	intermediate = newTestSslCertificate(t, "Test Intermediate CA", newTestEcdsaKey(t), root, true, notAfter)
+	leaf = newTestSslCertificate(t, "www.example.com", leafKey, intermediate, false, notAfter)
+	return
+}
+
+func testSslChainNames(chain []*x509.Certificate) string {
+	names := make([]string, 0, len(chain))
+	for _, cert := range chain {
+		names = append(names, cert.Subject.CommonName)
+	}
+	return strings.Join(names, " > ")
+}
+
+func TestOrderSslCertificateChain(t *testing.T) {
+	leaf, intermediate, root := newTestSslChain(t, newTestEcdsaKey(t))
+	cases := []struct {
+		name  string
+		certs []*x509.Certificate
+		want  string
+	}{
+		{name: "ordered", certs: []*x509.Certificate{leaf.cert, intermediate.cert, root.cert}, want: "www.example.com > Test Intermediate CA > Test Root CA"},
+		{name: "reversed", certs: []*x509.Certificate{root.cert, intermediate.cert, leaf.cert}, want: "www.example.com > Test Intermediate CA > Test Root CA"},
+		{name: "leaf in the middle", certs: []*x509.Certificate{intermediate.cert, leaf.cert, root.cert}, want: "www.example.com > Test Intermediate CA > Test Root CA"},
+		{name: "without root", certs: []*x509.Certificate{intermediate.cert, leaf.cert}, want: "www.example.com > Test Intermediate CA"},
+		{name: "leaf only", certs: []*x509.Certificate{leaf.cert}, want: "www.example.com"},
+	}
+	for _, c := range cases {
+		chain, err := OrderSslCertificateChain(c.certs)
+		if err != nil {
+			t.Errorf("%s: %s", c.name, err)
+			continue
+		}
+		if got := testSslChainNames(chain); got != c.want {
+			t.Errorf("%s: got %s, want %s", c.name, got, c.want)
+		}
+	}
+}
+
+func TestOrderSslCertificateChainBroken(t *testing.T) {
+	leaf, intermediate, root := newTestSslChain(t, newTestEcdsaKey(t))
+	otherLeaf, _, _ := newTestSslChain(t, newTestEcdsaKey(t))
+	notAfter := time.Now().Add(24 * time.Hour)
+	unrelated := newTestSslCertificate(t, "Unrelated CA", newTestEcdsaKey(t), nil, true, notAfter)
+	// same subject as the intermediate but signed by another key, so it does not issue the leaf
+	forged := newTestSslCertificate(t, "Test Intermediate CA", newTestEcdsaKey(t), root, true, notAfter)
+
+	cases := []struct {
+		name  string
+		certs []*x509.Certificate
+	}{
+		{name: "missing intermediate", certs: []*x509.Certificate{leaf.cert, root.cert}},
+		{name: "unrelated certificate", certs: []*x509.Certificate{leaf.cert, intermediate.cert, unrelated.cert}},
+		{name: "two leaves", certs: []*x509.Certificate{leaf.cert, otherLeaf.cert, intermediate.cert}},
+		{name: "forged intermediate", certs: []*x509.Certificate{leaf.cert, forged.cert, root.cert}},
+	}
+	for _, c := range cases {
+		chain, err := OrderSslCertificateChain(c.certs)
+		if err == nil {
+			t.Errorf("%s: expected error, got %s", c.name, testSslChainNames(chain))
+			continue
+		}
+		if !strings.Contains(err.Error(), "malformed certificate chain") {
+			t.Errorf("%s: unexpected error %s", c.name, err)
+		}
+	}
+}
+
+func TestNormalizeSslCertificateChain(t *testing.T) {
+	leaf, intermediate, root := newTestSslChain(t, newTestEcdsaKey(t))
+	// duplicated certificates and blanks between the blocks are dropped
+	certPem := "\n" + EncodeSslCertificates([]*x509.Certificate{root.cert, intermediate.cert}) + "\n\n" +
+		EncodeSslCertificates([]*x509.Certificate{leaf.cert, intermediate.cert}) + "\n"
+
+	normalized, chain, err := NormalizeSslCertificateChain(certPem)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := testSslChainNames(chain); got != "www.example.com > Test Intermediate CA > Test Root CA" {
+		t.Errorf("unexpected chain %s", got)
+	}
+	if normalized != EncodeSslCertificates([]*x509.Certificate{leaf.cert, intermediate.cert, root.cert}) {
+		t.Errorf("unexpected normalized PEM:\n%s", normalized)
+	}
+	if strings.HasSuffix(normalized, "\n") {
+		t.Error("normalized PEM ends with a newline")
+	}
+	if !SslCertificatePemEqual(certPem, normalized) {
+		t.Error("normalized PEM is not equal to the original one")
+	}
+
+	for _, invalid := range []string{"", "not a certificate", encodeTestSslPrivateKey(t, leaf.key, false)} {
+		if _, _, err := NormalizeSslCertificateChain(invalid); err == nil {
+			t.Errorf("expected error of %q", invalid)
+		}
+	}
+}
+
+func TestCheckSslCertificateKey(t *testing.T) {
+	ecdsaKey, rsaKey := newTestEcdsaKey(t), newTestRsaKey(t)
+	ecdsaLeaf, _, _ := newTestSslChain(t, ecdsaKey)
+	rsaLeaf, _, _ := newTestSslChain(t, rsaKey)
+
+	cases := []struct {
+		name    string
+		cert    *x509.Certificate
+		keyPem  string
+		wantErr string
+	}{
+		{name: "ECDSA SEC 1", cert: ecdsaLeaf.cert, keyPem: encodeTestSslPrivateKey(t, ecdsaKey, false)},
+		{name: "ECDSA PKCS#8", cert: ecdsaLeaf.cert, keyPem: encodeTestSslPrivateKey(t, ecdsaKey, true)},
+		{name: "RSA PKCS#1", cert: rsaLeaf.cert, keyPem: encodeTestSslPrivateKey(t, rsaKey, false)},
+		{name: "RSA PKCS#8", cert: rsaLeaf.cert, keyPem: encodeTestSslPrivateKey(t, rsaKey, true)},
+		{name: "another ECDSA key", cert: ecdsaLeaf.cert, keyPem: encodeTestSslPrivateKey(t, newTestEcdsaKey(t), false), wantErr: "does not match"},
+		{name: "another RSA key", cert: rsaLeaf.cert, keyPem: encodeTestSslPrivateKey(t, newTestRsaKey(t), false), wantErr: "does not match"},
+		{name: "RSA key of ECDSA certificate", cert: ecdsaLeaf.cert, keyPem: encodeTestSslPrivateKey(t, rsaKey, false), wantErr: "does not match"},
+		{name: "ECDSA key of RSA certificate", cert: rsaLeaf.cert, keyPem: encodeTestSslPrivateKey(t, ecdsaKey, true), wantErr: "does not match"},
+		{name: "invalid PEM", cert: rsaLeaf.cert, keyPem: "not a key", wantErr: "invalid private key PEM"},
+		{name: "certificate as key", cert: rsaLeaf.cert, keyPem: EncodeSslCertificates([]*x509.Certificate{rsaLeaf.cert}), wantErr: "unexpected PEM block `CERTIFICATE`"},
+	}
+	for _, c := range cases {
+		err := CheckSslCertificateKey(c.cert, c.keyPem)
+		if c.wantErr == "" {
+			if err != nil {
+				t.Errorf("%s: %s", c.name, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), c.wantErr) {
+			t.Errorf("%s: got %v, want error containing %q", c.name, err, c.wantErr)
+		}
+	}
+}
+
+func TestCheckSslCertificate(t *testing.T) {
+	key := newTestEcdsaKey(t)
+	leaf, intermediate, root := newTestSslChain(t, key)
+	keyPem := encodeTestSslPrivateKey(t, key, false)
+	certPem := EncodeSslCertificates([]*x509.Certificate{root.cert, leaf.cert, intermediate.cert})
+
+	normalized, certs, err := CheckSslCertificate(SSL_CERT_TYPE_SERVER, certPem, keyPem)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if normalized != EncodeSslCertificates([]*x509.Certificate{leaf.cert, intermediate.cert, root.cert}) || len(certs) != 3 {
+		t.Errorf("chain is not ordered: %s", testSslChainNames(certs))
+	}
+
+	if _, _, err = CheckSslCertificate(SSL_CERT_TYPE_SERVER, certPem, encodeTestSslPrivateKey(t, newTestRsaKey(t), false)); err == nil {
+		t.Error("expected error of mismatched key")
+	}
+
+	expired := newTestSslCertificate(t, "expired.example.com", key, intermediate, false, time.Now().Add(-time.Hour))
+	if _, _, err = CheckSslCertificate(SSL_CERT_TYPE_SERVER, EncodeSslCertificates([]*x509.Certificate{expired.cert, intermediate.cert}), keyPem); err == nil ||
+		!strings.Contains(err.Error(), "has expired") {
+		t.Errorf("expected error of expired certificate, got %v", err)
+	}
+
+	// CA certificates are kept as they are
+	caPem := EncodeSslCertificates([]*x509.Certificate{root.cert, intermediate.cert})
+	if normalized, _, err = CheckSslCertificate(SSL_CERT_TYPE_CA, caPem, ""); err != nil || normalized != caPem {
+		t.Errorf("unexpected CA certificate %v:\n%s", err, normalized)
+	}
+}
@@ -101,7 +101,7 @@ func dataSourceTencentCloudSsmRotationDetailRead(d *schema.ResourceData, meta in
 	d.SetId(secretName)
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), d); e != nil {
+		if e := tccommon.WriteToFile(output.(string), d, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
@@ -74,7 +74,7 @@ func dataSourceTencentCloudSsmRotationHistoryRead(d *schema.ResourceData, meta i
 	d.SetId(secretName)
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), d); e != nil {
+		if e := tccommon.WriteToFile(output.(string), d, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
@@ -152,7 +152,7 @@ func dataSourceTencentCloudSsmSecretVersionsRead(d *schema.ResourceData, meta in
 	}
 
 	if output, ok := d.GetOk("result_output_file"); ok && output.(string) != "" {
-		return tccommon.WriteToFile(output.(string), secretVersionList)
+		return tccommon.WriteToFile(output.(string), secretVersionList, tccommon.GetResultOutputOption(d))
 	}
 
 	return nil
@@ -250,7 +250,7 @@ func dataSourceTencentCloudSsmSecretsRead(d *schema.ResourceData, meta interface
 	}
 
 	if output, ok := d.GetOk("result_output_file"); ok && output.(string) != "" {
-		return tccommon.WriteToFile(output.(string), secretList)
+		return tccommon.WriteToFile(output.(string), secretList, tccommon.GetResultOutputOption(d))
 	}
 
 	return nil
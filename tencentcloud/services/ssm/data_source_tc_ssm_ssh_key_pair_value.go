@@ -125,7 +125,7 @@ func dataSourceTencentCloudSsmSshKeyPairValueRead(d *schema.ResourceData, meta i
 	d.SetId(sshKeyID)
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), d); e != nil {
+		if e := tccommon.WriteToFile(output.(string), d, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
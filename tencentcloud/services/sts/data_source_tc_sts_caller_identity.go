@@ -107,7 +107,7 @@ func dataSourceTencentCloudStsCallerIdentityRead(d *schema.ResourceData, meta in
 			"user_id":      callerIdentity.UserId,
 			"principal_id": callerIdentity.PrincipalId,
 			"type":         callerIdentity.Type,
-		}); e != nil {
+		}, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
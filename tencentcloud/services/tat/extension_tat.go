@@ -0,0 +1,26 @@
+package tat
+
+const (
+	TAT_AGENT_STATUS_ONLINE = "Online"
+
+	TAT_COMMAND_TYPE_SHELL      = "SHELL"
+	TAT_COMMAND_TYPE_POWERSHELL = "POWERSHELL"
+	TAT_COMMAND_TYPE_BAT        = "BAT"
+
+	TAT_TASK_STATUS_SUCCESS = "SUCCESS"
+)
+
+var TAT_COMMAND_TYPES = []string{
+	TAT_COMMAND_TYPE_SHELL,
+	TAT_COMMAND_TYPE_POWERSHELL,
+	TAT_COMMAND_TYPE_BAT,
+}
+
+// task status which means the command is still delivering or running
+var TAT_TASK_PENDING_STATUS = []string{
+	"PENDING",
+	"DELIVERING",
+	"DELIVER_DELAYED",
+	"RUNNING",
+	"CANCELLING",
+}
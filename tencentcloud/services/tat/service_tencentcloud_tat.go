@@ -2,10 +2,14 @@ package tat
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"strings"
+	"time"
 
 	tccommon "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/common"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	tat "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/tat/v20201028"
 
 	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/connectivity"
@@ -623,3 +627,140 @@ func (me *TatService) DescribeTatInvocationTaskByFilter(ctx context.Context, par
 
 	return
 }
+
+func (me *TatService) DescribeTatAgentStatus(ctx context.Context, instanceId string) (status string, errRet error) {
+	agents, err := me.DescribeTatAgentByFilter(ctx, map[string]interface{}{"InstanceIds": []*string{&instanceId}})
+	if err != nil {
+		errRet = err
+		return
+	}
+
+	for _, agent := range agents {
+		if agent.InstanceId != nil && *agent.InstanceId == instanceId && agent.AgentStatus != nil {
+			status = *agent.AgentStatus
+		}
+	}
+	return
+}
+
+// WaitTatAgentOnline waits until the TAT agent of the instance is online, the agent comes online a while after the instance is running.
+func (me *TatService) WaitTatAgentOnline(ctx context.Context, instanceId string, timeout time.Duration) error {
+	return resource.Retry(timeout, func() *resource.RetryError {
+		status, e := me.DescribeTatAgentStatus(ctx, instanceId)
+		if e != nil {
+			return tccommon.RetryError(e)
+		}
+		if status != TAT_AGENT_STATUS_ONLINE {
+			return resource.RetryableError(fmt.Errorf("TAT agent of instance %s is `%s`, please make sure the agent is installed in the image", instanceId, status))
+		}
+		return nil
+	})
+}
+
+func (me *TatService) RunTatCommand(ctx context.Context, request *tat.RunCommandRequest) (invocationId string, errRet error) {
+	logId := tccommon.GetLogId(ctx)
+
+	defer func() {
+		if errRet != nil {
+			log.Printf("[CRITAL]%s api[%s] fail, request body [%s], reason[%s]\n", logId, request.GetAction(), request.ToJsonString(), errRet.Error())
+		}
+	}()
+
+	errRet = resource.Retry(tccommon.WriteRetryTimeout, func() *resource.RetryError {
+		ratelimit.Check(request.GetAction())
+		response, e := me.client.UseTatClient().RunCommand(request)
+		if e != nil {
+			return tccommon.RetryError(e)
+		}
+		log.Printf("[DEBUG]%s api[%s] success, request body [%s], response body [%s]\n", logId, request.GetAction(), request.ToJsonString(), response.ToJsonString())
+
+		if response == nil || response.Response == nil || response.Response.InvocationId == nil {
+			return resource.NonRetryableError(fmt.Errorf("RunCommand returned empty invocation id"))
+		}
+		invocationId = *response.Response.InvocationId
+		return nil
+	})
+	return
+}
+
+func (me *TatService) DescribeTatInvocationTask(ctx context.Context, invocationId, instanceId string) (task *tat.InvocationTask, errRet error) {
+	tasks, err := me.DescribeTatInvocationTaskByFilter(ctx, map[string]interface{}{
+		"filters": []*tat.Filter{
+			{Name: helper.String("invocation-id"), Values: []*string{&invocationId}},
+			{Name: helper.String("instance-id"), Values: []*string{&instanceId}},
+		},
+		"HideOutput": helper.Bool(false),
+	})
+	if err != nil {
+		errRet = err
+		return
+	}
+
+	if len(tasks) < 1 {
+		return
+	}
+	task = tasks[0]
+	return
+}
+
+// RunTatCommandAndWait runs the command on the instance and waits for the task to finish,
+// a task which is not finished successfully is returned as an error with the tail of its output.
+func (me *TatService) RunTatCommandAndWait(ctx context.Context, instanceId string, request *tat.RunCommandRequest, timeout time.Duration) (task *tat.InvocationTask, errRet error) {
+	request.InstanceIds = []*string{&instanceId}
+	invocationId, err := me.RunTatCommand(ctx, request)
+	if err != nil {
+		errRet = err
+		return
+	}
+
+	errRet = resource.Retry(timeout, func() *resource.RetryError {
+		result, e := me.DescribeTatInvocationTask(ctx, invocationId, instanceId)
+		if e != nil {
+			return tccommon.RetryError(e)
+		}
+		if result == nil || result.TaskStatus == nil {
+			return resource.RetryableError(fmt.Errorf("task of invocation %s is not found", invocationId))
+		}
+		if tccommon.IsContains(TAT_TASK_PENDING_STATUS, *result.TaskStatus) {
+			return resource.RetryableError(fmt.Errorf("task of invocation %s is %s", invocationId, *result.TaskStatus))
+		}
+		task = result
+		return nil
+	})
+	if errRet != nil {
+		return
+	}
+
+	if *task.TaskStatus != TAT_TASK_STATUS_SUCCESS {
+		errRet = fmt.Errorf("task of invocation %s on instance %s is %s: %s", invocationId, instanceId, *task.TaskStatus, tatInvocationTaskOutputTail(task))
+	}
+	return
+}
+
+// tatInvocationTaskOutputTail returns the error info and the last lines of the output of the task.
+func tatInvocationTaskOutputTail(task *tat.InvocationTask) string {
+	const maxLength = 2048
+
+	messages := make([]string, 0, 3)
+	if task.ErrorInfo != nil && *task.ErrorInfo != "" {
+		messages = append(messages, *task.ErrorInfo)
+	}
+	if task.TaskResult != nil {
+		if task.TaskResult.ExitCode != nil {
+			messages = append(messages, fmt.Sprintf("exit code %d", *task.TaskResult.ExitCode))
+		}
+		if task.TaskResult.Output != nil {
+			output, err := tccommon.Base64ToString(*task.TaskResult.Output)
+			if err != nil {
+				output = *task.TaskResult.Output
+			}
+			if len(output) > maxLength {
+				output = "..." + output[len(output)-maxLength:]
+			}
+			if output != "" {
+				messages = append(messages, "output:\n"+output)
+			}
+		}
+	}
+	return strings.Join(messages, ", ")
+}
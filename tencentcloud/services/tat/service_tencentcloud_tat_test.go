@@ -0,0 +1,59 @@
+package tat
+
+import (
+	"strings"
+	"testing"
+
+	tat "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/tat/v20201028"
+
+	tccommon "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/common"
+	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/internal/helper"
+)
+
+func TestTatInvocationTaskOutputTail(t *testing.T) {
+	long := strings.Repeat("a", 100) + strings.Repeat("b", 2048)
+
+	cases := []struct {
+		name string
+		task *tat.InvocationTask
+		want string
+	}{
+		{name: "empty task", task: &tat.InvocationTask{}},
+		{
+			name: "error info only",
+			task: &tat.InvocationTask{ErrorInfo: helper.String("agent offline")},
+			want: "agent offline",
+		},
+		{
+			name: "exit code and output",
+			task: &tat.InvocationTask{
+				ErrorInfo:  helper.String(""),
+				TaskResult: &tat.TaskResult{ExitCode: helper.Int64(2), Output: helper.String(tccommon.StringToBase64("no such file\n"))},
+			},
+			want: "exit code 2, output:\nno such file\n",
+		},
+		{
+			name: "output which is not base64",
+			task: &tat.InvocationTask{TaskResult: &tat.TaskResult{Output: helper.String("plain!")}},
+			want: "output:\nplain!",
+		},
+		{
+			name: "long output keeps the tail",
+			task: &tat.InvocationTask{
+				ErrorInfo:  helper.String("timeout"),
+				TaskResult: &tat.TaskResult{ExitCode: helper.Int64(1), Output: helper.String(tccommon.StringToBase64(long))},
+			},
+			want: "timeout, exit code 1, output:\n..." + strings.Repeat("b", 2048),
+		},
+		{
+			name: "empty output",
+			task: &tat.InvocationTask{TaskResult: &tat.TaskResult{ExitCode: helper.Int64(0), Output: helper.String("")}},
+			want: "exit code 0",
+		},
+	}
+	for _, c := range cases {
+		if got := tatInvocationTaskOutputTail(c.task); got != c.want {
+			t.Errorf("%s: output tail %q, want %q", c.name, got, c.want)
+		}
+	}
+}
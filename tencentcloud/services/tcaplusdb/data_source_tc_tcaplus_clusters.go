@@ -155,7 +155,7 @@ func dataSourceTencentCloudTcaplusClustersRead(d *schema.ResourceData, meta inte
 	}
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		return tccommon.WriteToFile(output.(string), list)
+		return tccommon.WriteToFile(output.(string), list, tccommon.GetResultOutputOption(d))
 	}
 	return nil
 
@@ -114,7 +114,7 @@ func dataSourceTencentCloudTcaplusTableGroupsRead(d *schema.ResourceData, meta i
 	}
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		return tccommon.WriteToFile(output.(string), list)
+		return tccommon.WriteToFile(output.(string), list, tccommon.GetResultOutputOption(d))
 	}
 	return nil
 
@@ -222,7 +222,7 @@ func dataSourceTencentCloudTcmqSubscribeRead(d *schema.ResourceData, meta interf
 	_ = d.Set("subscription_list", result)
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), result); e != nil {
+		if e := tccommon.WriteToFile(output.(string), result, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
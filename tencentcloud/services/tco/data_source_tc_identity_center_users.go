@@ -259,7 +259,7 @@ func dataSourceTencentCloudIdentityCenterUsersRead(d *schema.ResourceData, meta
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), usersList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), usersList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
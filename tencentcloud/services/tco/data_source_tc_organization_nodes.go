@@ -202,7 +202,7 @@ func dataSourceTencentCloudOrganizationNodesRead(d *schema.ResourceData, meta in
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), itemList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), itemList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
@@ -138,7 +138,7 @@ func dataSourceTencentCloudOrganizationOrgAuthNodeRead(d *schema.ResourceData, m
 	d.SetId(helper.DataResourceIdsHash(ids))
 	output3, ok := d.GetOk("result_output_file")
 	if ok && output3.(string) != "" {
-		if e := tccommon.WriteToFile(output3.(string), tmpList); e != nil {
+		if e := tccommon.WriteToFile(output3.(string), tmpList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
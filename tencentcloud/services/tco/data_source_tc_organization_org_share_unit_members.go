@@ -124,7 +124,7 @@ func dataSourceTencentCloudOrganizationOrgShareUnitMembersRead(d *schema.Resourc
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), itemsList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), itemsList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
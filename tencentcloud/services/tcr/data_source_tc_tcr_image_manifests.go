@@ -133,7 +133,7 @@ func dataSourceTencentCloudTcrImageManifestsRead(d *schema.ResourceData, meta in
 	d.SetId(helper.DataResourceIdsHash([]string{registryId, namespaceName, repositoryName, imageVersion}))
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), tmpList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), tmpList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
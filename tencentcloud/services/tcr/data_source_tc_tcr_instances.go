@@ -149,7 +149,7 @@ func dataSourceTencentCloudTCRInstancesRead(d *schema.ResourceData, meta interfa
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), instanceList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), instanceList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
@@ -109,7 +109,7 @@ func dataSourceTencentCloudTCRNamespacesRead(d *schema.ResourceData, meta interf
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), namespaceList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), namespaceList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
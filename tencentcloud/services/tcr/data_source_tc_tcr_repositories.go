@@ -168,7 +168,7 @@ func dataSourceTencentCloudTCRRepositoriesRead(d *schema.ResourceData, meta inte
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), repositoryList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), repositoryList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
@@ -186,7 +186,7 @@ func dataSourceTencentCloudTcrTagRetentionExecutionTasksRead(d *schema.ResourceD
 	d.SetId(helper.DataResourceIdsHash([]string{registryId, retentionId, executionId}))
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), tmpList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), tmpList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
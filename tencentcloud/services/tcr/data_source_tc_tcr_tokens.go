@@ -117,7 +117,7 @@ func dataSourceTencentCloudTCRTokensRead(d *schema.ResourceData, meta interface{
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), tokenList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), tokenList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
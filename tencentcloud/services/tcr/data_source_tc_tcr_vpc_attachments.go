@@ -147,7 +147,7 @@ func dataSourceTencentCloudTCRVPCAttachmentsRead(d *schema.ResourceData, meta in
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), vpcAccessList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), vpcAccessList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
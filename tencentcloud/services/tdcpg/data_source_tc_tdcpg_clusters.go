@@ -386,7 +386,7 @@ func dataSourceTencentCloudTdcpgClustersRead(d *schema.ResourceData, meta interf
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), clusterList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), clusterList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
@@ -346,7 +346,7 @@ func dataSourceTencentCloudTeoRuleEngineSettingsRead(d *schema.ResourceData, met
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), actionsList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), actionsList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
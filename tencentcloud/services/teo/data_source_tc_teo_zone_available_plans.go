@@ -149,7 +149,7 @@ func dataSourceTencentCloudTeoZoneAvailablePlansRead(d *schema.ResourceData, met
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), planInfoList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), planInfoList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
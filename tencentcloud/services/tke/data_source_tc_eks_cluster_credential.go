@@ -246,7 +246,7 @@ func datasourceTencentCloudEksClusterCredentialRead(d *schema.ResourceData, meta
 	}
 
 	if output, ok := d.GetOk("result_output_file"); ok {
-		return tccommon.WriteToFile(output.(string), result)
+		return tccommon.WriteToFile(output.(string), result, tccommon.GetResultOutputOption(d))
 	}
 
 	return nil
@@ -224,7 +224,7 @@ LOOP:
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if err = tccommon.WriteToFile(output.(string), list); err != nil {
+		if err = tccommon.WriteToFile(output.(string), list, tccommon.GetResultOutputOption(d)); err != nil {
 			return err
 		}
 	}
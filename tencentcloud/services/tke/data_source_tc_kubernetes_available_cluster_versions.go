@@ -134,7 +134,7 @@ func dataSourceTencentCloudKubernetesAvailableClusterVersionsRead(d *schema.Reso
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), dataSourceTencentCloudKubernetesAvailableClusterVersionsReadOutputContent(ctx)); e != nil {
+		if e := tccommon.WriteToFile(output.(string), dataSourceTencentCloudKubernetesAvailableClusterVersionsReadOutputContent(ctx), tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
@@ -150,7 +150,7 @@ func dataSourceTencentCloudKubernetesClusterCommonNamesRead(d *schema.ResourceDa
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), commonNamesList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), commonNamesList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
@@ -0,0 +1,314 @@
+package tke
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"gopkg.in/yaml.v2"
+
+	tccommon "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/common"
+	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/internal/helper"
+)
+
+const (
+	TKE_EXEC_CREDENTIAL_API_VERSION = "client.authentication.k8s.io/v1beta1"
+	TKE_EXEC_CREDENTIAL_COMMAND     = "tencentcloud-k8s-token"
+)
+
+func DataSourceTencentCloudKubernetesClusterExecKubeconfig() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceTencentCloudKubernetesClusterExecKubeconfigRead,
+		Schema: map[string]*schema.Schema{
+			"cluster_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Cluster ID.",
+			},
+			"is_extranet": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to use the internet endpoint of the cluster, the endpoint must be enabled. Default is `false`, which uses the intranet endpoint.",
+			},
+			"region": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Region passed to the credential command. Default is the region of the provider.",
+			},
+			"role_arn": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "CAM role the credential command assumes before requesting the credential of the cluster.",
+			},
+			"command": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     TKE_EXEC_CREDENTIAL_COMMAND,
+				Description: "Command printing the exec credential. Default is `tencentcloud-k8s-token`, which can be installed by `go install github.com/tencentcloudstack/terraform-provider-tencentcloud/cmd/tencentcloud-k8s-token@latest`.",
+			},
+			"extra_args": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Extra arguments appended to the arguments of the credential command.",
+			},
+			"env": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Environment variables of the credential command, such as `TENCENTCLOUD_PROFILE`.",
+			},
+			"host": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Address of the cluster API server.",
+			},
+			"cluster_ca_certificate": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "PEM-encoded CA certificate of the cluster API server.",
+			},
+			"exec": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Exec plugin configuration, can be used in the `exec` block of the kubernetes and helm providers.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"api_version": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "API version of the exec credential.",
+						},
+						"command": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Command printing the exec credential.",
+						},
+						"args": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Arguments of the command.",
+						},
+						"env": {
+							Type:        schema.TypeMap,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Environment variables of the command.",
+						},
+					},
+				},
+			},
+			"kubeconfig": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Kubeconfig of the cluster, which gets the client certificate of the CAM identity through the exec plugin instead of keeping it.",
+			},
+			"result_output_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Used to save results.",
+			},
+		},
+	}
+}
+
+func dataSourceTencentCloudKubernetesClusterExecKubeconfigRead(d *schema.ResourceData, meta interface{}) error {
+	defer tccommon.LogElapsed("data_source.tencentcloud_kubernetes_cluster_exec_kubeconfig.read")()
+
+	var (
+		logId      = tccommon.GetLogId(tccommon.ContextNil)
+		ctx        = context.WithValue(context.TODO(), tccommon.LogIdKey, logId)
+		client     = meta.(tccommon.ProviderMeta).GetAPIV3Conn()
+		service    = TkeService{client: client}
+		clusterId  = d.Get("cluster_id").(string)
+		isExtranet = d.Get("is_extranet").(bool)
+		region     = client.Region
+		config     string
+	)
+
+	if v, ok := d.GetOk("region"); ok {
+		region = v.(string)
+	}
+
+	err := resource.Retry(tccommon.ReadRetryTimeout, func() *resource.RetryError {
+		result, e := service.DescribeClusterConfig(ctx, clusterId, isExtranet)
+		if e != nil {
+			return tccommon.RetryError(e)
+		}
+		config = result
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	host, caData, err := parseTkeKubeconfigCluster(config)
+	if err != nil {
+		return fmt.Errorf("parse kubeconfig of cluster %s failed: %s", clusterId, err.Error())
+	}
+	caCertificate, err := base64.StdEncoding.DecodeString(caData)
+	if err != nil {
+		return fmt.Errorf("decode CA certificate of cluster %s failed: %s", clusterId, err.Error())
+	}
+
+	args := []string{"--cluster-id", clusterId}
+	if region != "" {
+		args = append(args, "--region", region)
+	}
+	if v, ok := d.GetOk("role_arn"); ok {
+		args = append(args, "--role-arn", v.(string))
+	}
+	for _, v := range d.Get("extra_args").([]interface{}) {
+		args = append(args, v.(string))
+	}
+	envMap := d.Get("env").(map[string]interface{})
+	env := make(map[string]string, len(envMap))
+	for k, v := range envMap {
+		env[k] = v.(string)
+	}
+	command := d.Get("command").(string)
+
+	kubeconfig, err := buildTkeExecKubeconfig(clusterId, host, caData, command, args, env)
+	if err != nil {
+		return err
+	}
+
+	idParts := []string{clusterId, strconv.FormatBool(isExtranet), region, d.Get("role_arn").(string), command}
+	idParts = append(idParts, args...)
+	envNames := make([]string, 0, len(env))
+	for k := range env {
+		envNames = append(envNames, k)
+	}
+	sort.Strings(envNames)
+	for _, k := range envNames {
+		idParts = append(idParts, k+"="+env[k])
+	}
+	d.SetId(helper.DataResourceIdsHash(idParts))
+	_ = d.Set("host", host)
+	_ = d.Set("cluster_ca_certificate", string(caCertificate))
+	_ = d.Set("exec", []map[string]interface{}{{
+		"api_version": TKE_EXEC_CREDENTIAL_API_VERSION,
+		"command":     command,
+		"args":        args,
+		"env":         envMap,
+	}})
+	_ = d.Set("kubeconfig", kubeconfig)
+
+	output, ok := d.GetOk("result_output_file")
+	if ok && output.(string) != "" {
+		if err = tccommon.WriteToFile(output.(string), map[string]interface{}{
+			"cluster_id": clusterId,
+			"host":       host,
+			"kubeconfig": kubeconfig,
+		}, tccommon.GetResultOutputOption(d)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type tkeKubeconfig struct {
+	ApiVersion     string                 `yaml:"apiVersion"`
+	Kind           string                 `yaml:"kind"`
+	Clusters       []tkeKubeconfigCluster `yaml:"clusters"`
+	Users          []tkeKubeconfigUser    `yaml:"users"`
+	Contexts       []tkeKubeconfigContext `yaml:"contexts"`
+	CurrentContext string                 `yaml:"current-context"`
+}
+
+type tkeKubeconfigCluster struct {
+	Name    string `yaml:"name"`
+	Cluster struct {
+		Server                   string `yaml:"server"`
+		CertificateAuthorityData string `yaml:"certificate-authority-data"`
+	} `yaml:"cluster"`
+}
+
+type tkeKubeconfigUser struct {
+	Name string `yaml:"name"`
+	User struct {
+		Exec tkeKubeconfigExec `yaml:"exec"`
+	} `yaml:"user"`
+}
+
+type tkeKubeconfigExec struct {
+	ApiVersion         string                 `yaml:"apiVersion"`
+	Command            string                 `yaml:"command"`
+	Args               []string               `yaml:"args"`
+	Env                []tkeKubeconfigExecEnv `yaml:"env,omitempty"`
+	InteractiveMode    string                 `yaml:"interactiveMode"`
+	ProvideClusterInfo bool                   `yaml:"provideClusterInfo"`
+}
+
+type tkeKubeconfigExecEnv struct {
+	Name  string `yaml:"name"`
+	Value string `yaml:"value"`
+}
+
+type tkeKubeconfigContext struct {
+	Name    string `yaml:"name"`
+	Context struct {
+		Cluster string `yaml:"cluster"`
+		User    string `yaml:"user"`
+	} `yaml:"context"`
+}
+
+// parseTkeKubeconfigCluster returns the server and the base64 encoded CA of the kubeconfig returned by TKE.
+func parseTkeKubeconfigCluster(config string) (server, caData string, err error) {
+	kubeconfig := tkeKubeconfig{}
+	if err = yaml.Unmarshal([]byte(config), &kubeconfig); err != nil {
+		return
+	}
+	if len(kubeconfig.Clusters) == 0 || kubeconfig.Clusters[0].Cluster.Server == "" {
+		err = fmt.Errorf("no cluster server found, please check the endpoint of the cluster is enabled")
+		return
+	}
+	server = kubeconfig.Clusters[0].Cluster.Server
+	caData = kubeconfig.Clusters[0].Cluster.CertificateAuthorityData
+	return
+}
+
+func buildTkeExecKubeconfig(clusterId, server, caData, command string, args []string, env map[string]string) (string, error) {
+	var (
+		kubeconfig = tkeKubeconfig{ApiVersion: "v1", Kind: "Config", CurrentContext: clusterId}
+		cluster    = tkeKubeconfigCluster{Name: clusterId}
+		user       = tkeKubeconfigUser{Name: clusterId}
+		kubeCtx    = tkeKubeconfigContext{Name: clusterId}
+		envNames   = make([]string, 0, len(env))
+	)
+
+	cluster.Cluster.Server = server
+	cluster.Cluster.CertificateAuthorityData = caData
+	user.User.Exec = tkeKubeconfigExec{
+		ApiVersion:      TKE_EXEC_CREDENTIAL_API_VERSION,
+		Command:         command,
+		Args:            args,
+		InteractiveMode: "Never",
+	}
+	for k := range env {
+		envNames = append(envNames, k)
+	}
+	sort.Strings(envNames)
+	for _, k := range envNames {
+		user.User.Exec.Env = append(user.User.Exec.Env, tkeKubeconfigExecEnv{Name: k, Value: env[k]})
+	}
+	kubeCtx.Context.Cluster = clusterId
+	kubeCtx.Context.User = clusterId
+
+	kubeconfig.Clusters = []tkeKubeconfigCluster{cluster}
+	kubeconfig.Users = []tkeKubeconfigUser{user}
+	kubeconfig.Contexts = []tkeKubeconfigContext{kubeCtx}
+
+	data, err := yaml.Marshal(kubeconfig)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
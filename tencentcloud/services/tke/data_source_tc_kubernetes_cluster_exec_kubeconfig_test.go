@@ -0,0 +1,45 @@
+package tke_test
+
+import (
+	"testing"
+
+	tcacctest "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/acctest"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// go test -i; go test -test.run TestAccTencentCloudKubernetesClusterExecKubeconfigDataSource_basic -v
+func TestAccTencentCloudKubernetesClusterExecKubeconfigDataSource_basic(t *testing.T) {
+	t.Parallel()
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			tcacctest.AccPreCheck(t)
+		},
+		Providers: tcacctest.AccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesClusterExecKubeconfigDataSource,
+				Check: resource.ComposeTestCheckFunc(
+					tcacctest.AccCheckTencentCloudDataSourceID("data.tencentcloud_kubernetes_cluster_exec_kubeconfig.example"),
+					resource.TestCheckResourceAttrSet("data.tencentcloud_kubernetes_cluster_exec_kubeconfig.example", "host"),
+					resource.TestCheckResourceAttrSet("data.tencentcloud_kubernetes_cluster_exec_kubeconfig.example", "cluster_ca_certificate"),
+					resource.TestCheckResourceAttrSet("data.tencentcloud_kubernetes_cluster_exec_kubeconfig.example", "kubeconfig"),
+					resource.TestCheckResourceAttr("data.tencentcloud_kubernetes_cluster_exec_kubeconfig.example", "exec.0.command", "tencentcloud-k8s-token"),
+					resource.TestCheckResourceAttr("data.tencentcloud_kubernetes_cluster_exec_kubeconfig.example", "exec.0.args.0", "--cluster-id"),
+					resource.TestCheckResourceAttr("data.tencentcloud_kubernetes_cluster_exec_kubeconfig.example", "exec.0.env.TENCENTCLOUD_PROFILE", "default"),
+				),
+			},
+		},
+	})
+}
+
+const testAccKubernetesClusterExecKubeconfigDataSource = testAccTkeCluster + `
+
+data "tencentcloud_kubernetes_cluster_exec_kubeconfig" "example" {
+	cluster_id = tencentcloud_kubernetes_cluster.managed_cluster.id
+	env = {
+		TENCENTCLOUD_PROFILE = "default"
+	}
+}
+
+`
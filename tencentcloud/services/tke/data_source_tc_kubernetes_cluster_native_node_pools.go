@@ -1019,7 +1019,7 @@ func dataSourceTencentCloudKubernetesClusterNativeNodePoolsRead(d *schema.Resour
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), nodePoolsList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), nodePoolsList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
@@ -804,7 +804,7 @@ func dataSourceTencentCloudKubernetesClusterNodePoolsRead(d *schema.ResourceData
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), nodePoolSetList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), nodePoolSetList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
@@ -306,6 +306,8 @@ func DataSourceTencentCloudKubernetesClusters() *schema.Resource {
 				},
 			},
 
+			"filter": tccommon.DataSourceFilterSchema("DescribeClusters"),
+
 			"result_output_file": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -444,7 +446,7 @@ func dataSourceTencentCloudKubernetesClustersRead(d *schema.ResourceData, meta i
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), dataSourceTencentCloudKubernetesClustersReadOutputContent(ctx)); e != nil {
+		if e := tccommon.WriteToFile(output.(string), dataSourceTencentCloudKubernetesClustersReadOutputContent(ctx), tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
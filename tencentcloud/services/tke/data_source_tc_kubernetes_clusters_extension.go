@@ -33,12 +33,20 @@ func dataSourceTencentCloudKubernetesClustersReadPreRequest0(ctx context.Context
 	if clusterID != "" {
 		req.ClusterIds = []*string{&clusterID}
 	}
+
+	filterMap := make(map[string][]string)
 	if clusterName != "" {
-		filter := &tke.Filter{
-			Name:   helper.String("ClusterName"),
-			Values: []*string{&clusterName},
-		}
-		req.Filters = []*tke.Filter{filter}
+		filterMap["ClusterName"] = []string{clusterName}
+	}
+	filters, err := tccommon.GetDataSourceFilters(d, filterMap)
+	if err != nil {
+		return err
+	}
+	for _, v := range filters {
+		req.Filters = append(req.Filters, &tke.Filter{
+			Name:   helper.String(v.Name),
+			Values: helper.Strings(v.Values),
+		})
 	}
 
 	return nil
@@ -52,6 +60,10 @@ func dataSourceTencentCloudKubernetesClustersReadPreHandleResponse0(ctx context.
 	if d == nil {
 		return fmt.Errorf("resource data can not be nil")
 	}
+	tccommon.SortByKey(*resp, func(cls *tke.Cluster) string {
+		return *cls.ClusterId
+	})
+
 	tags := helper.GetTags(d, "tags")
 	if len(tags) == 0 {
 		return nil
@@ -70,3 +70,35 @@ data "tencentcloud_kubernetes_clusters" "tags" {
   }
 }
 `
+
+func TestAccTencentCloudKubernetesClusterFilterDataSource(t *testing.T) {
+	t.Parallel()
+
+	key := "data.tencentcloud_kubernetes_clusters.filter"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { tcacctest.AccPreCheck(t) },
+		Providers: tcacctest.AccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceTencentCloudTkeFilter,
+				Check: resource.ComposeTestCheckFunc(
+					// generic filter
+					tcacctest.AccCheckTencentCloudDataSourceID(key),
+					resource.TestCheckResourceAttrSet(key, "list.#"),
+					resource.TestCheckResourceAttr(key, "list.0.cluster_deploy_type", "MANAGED_CLUSTER"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceTencentCloudTkeFilter = `
+data "tencentcloud_kubernetes_clusters" "filter" {
+  #examples have been created to serve other resources
+  filter {
+    name   = "ClusterType"
+    values = ["MANAGED_CLUSTER"]
+  }
+}
+`
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"log"
 	"strings"
+	"time"
 
 	sdkErrors "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common/errors"
 
@@ -27,6 +28,10 @@ func ResourceTencentCloudKubernetesNativeNodePool() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Update: schema.DefaultTimeout(30 * time.Minute),
+		},
+		CustomizeDiff: tkeNodePoolUpgradeCustomizeDiff(nativeNodePoolUpgradeCapacity, nativeNodePoolLaunchArgs...),
 		Schema: map[string]*schema.Schema{
 			"cluster_id": {
 				Type:        schema.TypeString,
@@ -586,6 +591,10 @@ func ResourceTencentCloudKubernetesNativeNodePool() *schema.Resource {
 				Computed:    true,
 				Description: "Creation time.",
 			},
+
+			"upgrade_strategy": tkeNodePoolUpgradeStrategySchema("the launch settings of `native`, such as `instance_types`, `system_disk`, `data_disks`, `kubelet_args` and `lifecycle`,"),
+
+			"upgrade_progress": tkeNodePoolUpgradeProgressSchema(),
 		},
 	}
 }
@@ -1416,6 +1425,10 @@ func resourceTencentCloudKubernetesNativeNodePoolUpdate(d *schema.ResourceData,
 	clusterId := idSplit[0]
 	nodePoolId := idSplit[1]
 
+	if err := resourceTencentCloudKubernetesNativeNodePoolUpdateOnStart(ctx); err != nil {
+		return err
+	}
+
 	needChange := false
 	mutableArgs := []string{"name", "labels", "taints", "tags", "deletion_protection", "unschedulable", "native", "annotations"}
 	for _, v := range mutableArgs {
@@ -1744,6 +1757,10 @@ func resourceTencentCloudKubernetesNativeNodePoolUpdate(d *schema.ResourceData,
 		}
 	}
 
+	if err := resourceTencentCloudKubernetesNativeNodePoolUpdateOnExit(ctx); err != nil {
+		return err
+	}
+
 	return resourceTencentCloudKubernetesNativeNodePoolRead(d, meta)
 }
 
@@ -1 +1,118 @@
 package tke
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	tke2 "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/tke/v20220501"
+
+	tccommon "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/common"
+)
+
+// arguments of `native` changing the machines launched by the node pool
+var nativeNodePoolLaunchArgs = []string{
+	"native.0.instance_charge_type",
+	"native.0.system_disk",
+	"native.0.instance_types",
+	"native.0.security_group_ids",
+	"native.0.instance_charge_prepaid",
+	"native.0.management",
+	"native.0.host_name_pattern",
+	"native.0.kubelet_args",
+	"native.0.lifecycle",
+	"native.0.runtime_root_dir",
+	"native.0.internet_accessible",
+	"native.0.data_disks",
+	"native.0.key_ids",
+	"native.0.machine_type",
+}
+
+// nativeNodePoolUpgradeCapacity returns the planned replicas and max replicas of the native node pool.
+func nativeNodePoolUpgradeCapacity(d *schema.ResourceDiff) (desiredCapacity, maxSize int64, ok bool) {
+	if !d.NewValueKnown("native.0.replicas") || !d.NewValueKnown("native.0.scaling.0.max_replicas") {
+		return 0, 0, false
+	}
+	return int64(d.Get("native.0.replicas").(int)), int64(d.Get("native.0.scaling.0.max_replicas").(int)), true
+}
+
+func resourceTencentCloudKubernetesNativeNodePoolUpdateOnStart(ctx context.Context) error {
+	d := tccommon.ResourceDataFromContext(ctx)
+	meta := tccommon.ProviderMetaFromContext(ctx)
+	service := TkeService{client: meta.(tccommon.ProviderMeta).GetAPIV3Conn()}
+
+	items := strings.Split(d.Id(), tccommon.FILED_SP)
+	if len(items) != 2 {
+		return fmt.Errorf("resource_tc_kubernetes_native_node_pool id is broken")
+	}
+	clusterId := items[0]
+	nodePoolId := items[1]
+
+	// record the machines to replace before modifying, new machines use the new launch settings
+	return prepareTkeNodePoolRollingReplace(ctx, d, service.nativeNodePoolInstanceOperator(clusterId, nodePoolId), nativeNodePoolLaunchArgs...)
+}
+
+func resourceTencentCloudKubernetesNativeNodePoolUpdateOnExit(ctx context.Context) error {
+	d := tccommon.ResourceDataFromContext(ctx)
+	meta := tccommon.ProviderMetaFromContext(ctx)
+	service := TkeService{client: meta.(tccommon.ProviderMeta).GetAPIV3Conn()}
+
+	items := strings.Split(d.Id(), tccommon.FILED_SP)
+	if len(items) != 2 {
+		return fmt.Errorf("resource_tc_kubernetes_native_node_pool id is broken")
+	}
+	clusterId := items[0]
+	nodePoolId := items[1]
+
+	return tkeNodePoolRollingReplace(ctx, d, nodePoolId, nativeNodePoolScaler(&service, clusterId, nodePoolId),
+		service.nativeNodePoolInstanceOperator(clusterId, nodePoolId))
+}
+
+func nativeNodePoolScaler(service *TkeService, clusterId, nodePoolId string) TkeNodePoolScaler {
+	describe := func(ctx context.Context) (*tke2.NativeNodePoolInfo, error) {
+		nodePool, err := service.DescribeKubernetesNativeNodePoolById(ctx, clusterId, nodePoolId)
+		if err != nil {
+			return nil, err
+		}
+		if nodePool == nil || nodePool.Native == nil {
+			return nil, fmt.Errorf("native node pool %s not found", nodePoolId)
+		}
+		return nodePool.Native, nil
+	}
+	return TkeNodePoolScaler{
+		GetDesiredCapacity: func(ctx context.Context) (int64, error) {
+			native, err := describe(ctx)
+			if err != nil {
+				return 0, err
+			}
+			if native.Replicas == nil {
+				return 0, fmt.Errorf("replicas of native node pool %s not found", nodePoolId)
+			}
+			return *native.Replicas, nil
+		},
+		SetDesiredCapacity: func(ctx context.Context, desiredCapacity int64) error {
+			request := tke2.NewModifyNodePoolRequest()
+			request.ClusterId = &clusterId
+			request.NodePoolId = &nodePoolId
+			request.Native = &tke2.UpdateNativeNodePoolParam{Replicas: &desiredCapacity}
+			return resource.Retry(tccommon.WriteRetryTimeout, func() *resource.RetryError {
+				if _, e := service.client.UseTke2Client().ModifyNodePoolWithContext(ctx, request); e != nil {
+					return tccommon.RetryError(e)
+				}
+				return nil
+			})
+		},
+		GetMaxSize: func(ctx context.Context) (int64, error) {
+			native, err := describe(ctx)
+			if err != nil {
+				return 0, err
+			}
+			if native.Scaling == nil || native.Scaling.MaxReplicas == nil {
+				return 0, nil
+			}
+			return *native.Scaling.MaxReplicas, nil
+		},
+	}
+}
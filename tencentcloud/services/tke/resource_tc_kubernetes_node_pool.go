@@ -31,6 +31,7 @@ func ResourceTencentCloudKubernetesNodePool() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: nodePoolCustomResourceImporter,
 		},
+		CustomizeDiff: tkeNodePoolUpgradeCustomizeDiff(nodePoolUpgradeCapacity, nodePoolUpgradeTriggers...),
 		Schema: map[string]*schema.Schema{
 			"cluster_id": {
 				Type:        schema.TypeString,
@@ -677,6 +678,10 @@ func ResourceTencentCloudKubernetesNodePool() *schema.Resource {
 				Computed:    true,
 				Description: "The auto scaling group ID.",
 			},
+
+			"upgrade_strategy": tkeNodePoolUpgradeStrategySchema("`auto_scaling_config`, `node_os` or `node_os_type`"),
+
+			"upgrade_progress": tkeNodePoolUpgradeProgressSchema(),
 		},
 	}
 }
@@ -577,6 +577,11 @@ func resourceTencentCloudKubernetesNodePoolUpdateOnStart(ctx context.Context) er
 		return err
 	}
 
+	// record the nodes to replace before scaling, new nodes use the new launch configuration
+	if err := prepareTkeNodePoolRollingReplace(ctx, d, service.nodePoolInstanceOperator(clusterId, nodePoolId), nodePoolUpgradeTriggers...); err != nil {
+		return err
+	}
+
 	d.Partial(true)
 
 	nodePool, _, err := service.DescribeNodePool(ctx, clusterId, nodePoolId)
@@ -831,9 +836,58 @@ func resourceTencentCloudKubernetesNodePoolUpdateOnExit(ctx context.Context) err
 	}
 	d.Partial(false)
 
+	// replace nodes after partial is off, so the progress is saved in state if it is interrupted
+	if err := tkeNodePoolRollingReplace(ctx, d, nodePoolId, nodePoolScaler(&service, clusterId, nodePoolId), service.nodePoolInstanceOperator(clusterId, nodePoolId)); err != nil {
+		return err
+	}
+
 	return nil
 }
 
+// nodePoolUpgradeTriggers are the changes which replace the nodes of the node pool if `upgrade_strategy` is set.
+var nodePoolUpgradeTriggers = []string{"auto_scaling_config", "node_os", "node_os_type"}
+
+// nodePoolUpgradeCapacity returns the planned desired capacity and max size of the node pool.
+func nodePoolUpgradeCapacity(d *schema.ResourceDiff) (desiredCapacity, maxSize int64, ok bool) {
+	if !d.NewValueKnown("desired_capacity") || !d.NewValueKnown("max_size") {
+		return 0, 0, false
+	}
+	return int64(d.Get("desired_capacity").(int)), int64(d.Get("max_size").(int)), true
+}
+
+func nodePoolScaler(service *TkeService, clusterId, nodePoolId string) TkeNodePoolScaler {
+	return TkeNodePoolScaler{
+		GetDesiredCapacity: func(ctx context.Context) (int64, error) {
+			nodePool, _, err := service.DescribeNodePool(ctx, clusterId, nodePoolId)
+			if err != nil {
+				return 0, err
+			}
+			if nodePool == nil || nodePool.DesiredNodesNum == nil {
+				return 0, fmt.Errorf("node pool %s not found", nodePoolId)
+			}
+			return *nodePool.DesiredNodesNum, nil
+		},
+		GetMaxSize: func(ctx context.Context) (int64, error) {
+			nodePool, _, err := service.DescribeNodePool(ctx, clusterId, nodePoolId)
+			if err != nil {
+				return 0, err
+			}
+			if nodePool == nil || nodePool.MaxNodesNum == nil {
+				return 0, fmt.Errorf("node pool %s not found", nodePoolId)
+			}
+			return *nodePool.MaxNodesNum, nil
+		},
+		SetDesiredCapacity: func(ctx context.Context, desiredCapacity int64) error {
+			return resource.Retry(tccommon.WriteRetryTimeout, func() *resource.RetryError {
+				if e := service.ModifyClusterNodePoolDesiredCapacity(ctx, clusterId, nodePoolId, desiredCapacity); e != nil {
+					return tccommon.RetryError(e)
+				}
+				return nil
+			})
+		},
+	}
+}
+
 // merge `instance_type` to `backup_instance_types` as param `instance_types`
 func getNodePoolInstanceTypes(d *schema.ResourceData) []*string {
 	configParas := d.Get("auto_scaling_config").([]interface{})
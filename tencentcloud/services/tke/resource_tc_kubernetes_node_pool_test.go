@@ -231,6 +231,34 @@ func TestAccTencentCloudKubernetesNodePoolResource_GPUInstance(t *testing.T) {
 	})
 }
 
+func TestAccTencentCloudKubernetesNodePoolResource_UpgradeStrategy(t *testing.T) {
+	t.Parallel()
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { tcacctest.AccPreCheck(t) },
+		Providers:    tcacctest.AccProviders,
+		CheckDestroy: testAccCheckTkeNodePoolDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccTkeNodePoolClusterUpgradeStrategy, 50),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckTkeNodePoolExists,
+					resource.TestCheckResourceAttr(testTkeClusterNodePoolResourceKey, "upgrade_strategy.0.max_surge", "1"),
+					resource.TestCheckResourceAttr(testTkeClusterNodePoolResourceKey, "upgrade_strategy.0.drain_timeout", "300"),
+					resource.TestCheckResourceAttr(testTkeClusterNodePoolResourceKey, "upgrade_progress.#", "0"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccTkeNodePoolClusterUpgradeStrategy, 60),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckTkeNodePoolExists,
+					resource.TestCheckResourceAttr(testTkeClusterNodePoolResourceKey, "auto_scaling_config.0.system_disk_size", "60"),
+					resource.TestCheckResourceAttr(testTkeClusterNodePoolResourceKey, "upgrade_progress.#", "0"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckTkeNodePoolDestroy(s *terraform.State) error {
 	logId := tccommon.GetLogId(tccommon.ContextNil)
 	ctx := context.WithValue(context.TODO(), tccommon.LogIdKey, logId)
@@ -658,3 +686,33 @@ resource "tencentcloud_kubernetes_node_pool" "np_test" {
   }
 }
 `
+
+const testAccTkeNodePoolClusterUpgradeStrategy string = testAccTkeNodePoolClusterBasic + `
+resource "tencentcloud_kubernetes_node_pool" "np_test" {
+  name                 = "mynodepool_upgrade"
+  cluster_id           = local.cluster_id
+  max_size             = 3
+  min_size             = 0
+  vpc_id               = data.tencentcloud_vpc_subnets.vpc.instance_list.0.vpc_id
+  subnet_ids           = [data.tencentcloud_vpc_subnets.vpc.instance_list.0.subnet_id]
+  desired_capacity     = 1
+  enable_auto_scale    = false
+  wait_node_ready      = true
+  delete_keep_instance = false
+  node_os              = "tlinux2.2(tkernel3)x86_64"
+
+  auto_scaling_config {
+    instance_type              = var.ins_type
+    system_disk_type           = "CLOUD_PREMIUM"
+    system_disk_size           = "%d"
+    orderly_security_group_ids = [data.tencentcloud_security_groups.sg.security_groups[0].security_group_id]
+    instance_charge_type       = "POSTPAID_BY_HOUR"
+    password                   = "test123#"
+  }
+
+  upgrade_strategy {
+    max_surge       = 1
+    max_unavailable = 0
+  }
+}
+`
@@ -2836,24 +2836,26 @@ func (me *TkeService) DescribeKubernetesClustersByFilter(ctx context.Context, pa
 		}
 	}()
 
-	ratelimit.Check(request.GetAction())
-
 	if err := dataSourceTencentCloudKubernetesClustersReadPreRequest0(ctx, request); err != nil {
 		return nil, err
 	}
 
-	response, err := me.client.UseTkeV20180525Client().DescribeClusters(request)
-	if err != nil {
-		errRet = err
-		return
-	}
-	log.Printf("[DEBUG]%s api[%s] success, request body [%s], response body [%s]\n", logId, request.GetAction(), request.ToJsonString(), response.ToJsonString())
-
-	if len(response.Response.Clusters) < 1 {
-		return
-	}
+	ret, errRet = tccommon.DescribeAllPages(100, func(offset, limit int) ([]*tke.Cluster, int, error) {
+		request.Offset = helper.IntInt64(offset)
+		request.Limit = helper.IntInt64(limit)
+		ratelimit.Check(request.GetAction())
+		response, err := me.client.UseTkeV20180525Client().DescribeClusters(request)
+		if err != nil {
+			return nil, 0, err
+		}
+		log.Printf("[DEBUG]%s api[%s] success, request body [%s], response body [%s]\n", logId, request.GetAction(), request.ToJsonString(), response.ToJsonString())
 
-	ret = response.Response.Clusters
+		total := -1
+		if response.Response.TotalCount != nil {
+			total = int(*response.Response.TotalCount)
+		}
+		return response.Response.Clusters, total, nil
+	})
 	return
 }
 
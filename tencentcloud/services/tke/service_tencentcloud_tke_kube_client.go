@@ -0,0 +1,249 @@
+package tke
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"gopkg.in/yaml.v2"
+)
+
+// tkeKubeClient is a minimal client of the cluster API server, only used to cordon and drain nodes.
+type tkeKubeClient struct {
+	server string
+	token  string
+	client *http.Client
+}
+
+// newTkeKubeClient connects to the internet endpoint of the cluster, or the intranet one if the former is disabled.
+func (me *TkeService) newTkeKubeClient(ctx context.Context, clusterId string) (*tkeKubeClient, error) {
+	var lastErr error
+	for _, isExtranet := range []bool{true, false} {
+		config, err := me.DescribeClusterConfig(ctx, clusterId, isExtranet)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		client, err := newTkeKubeClientFromConfig(config)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		return client, nil
+	}
+	return nil, lastErr
+}
+
+// tkeKubeClientConfig is the part of the kubeconfig returned by DescribeClusterKubeconfig used by tkeKubeClient.
+type tkeKubeClientConfig struct {
+	Clusters []struct {
+		Cluster struct {
+			Server                   string `yaml:"server"`
+			CertificateAuthorityData string `yaml:"certificate-authority-data"`
+		} `yaml:"cluster"`
+	} `yaml:"clusters"`
+	Users []struct {
+		User struct {
+			ClientCertificateData string `yaml:"client-certificate-data"`
+			ClientKeyData         string `yaml:"client-key-data"`
+			Token                 string `yaml:"token"`
+		} `yaml:"user"`
+	} `yaml:"users"`
+}
+
+func newTkeKubeClientFromConfig(config string) (*tkeKubeClient, error) {
+	kubeconfig := tkeKubeClientConfig{}
+	if err := yaml.Unmarshal([]byte(config), &kubeconfig); err != nil {
+		return nil, err
+	}
+	if len(kubeconfig.Clusters) == 0 || kubeconfig.Clusters[0].Cluster.Server == "" || len(kubeconfig.Users) == 0 {
+		return nil, fmt.Errorf("no cluster server or user found in kubeconfig")
+	}
+
+	var (
+		cluster   = kubeconfig.Clusters[0].Cluster
+		user      = kubeconfig.Users[0].User
+		tlsConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	)
+	if cluster.CertificateAuthorityData != "" {
+		ca, err := base64.StdEncoding.DecodeString(cluster.CertificateAuthorityData)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		tlsConfig.RootCAs.AppendCertsFromPEM(ca)
+	}
+	if user.ClientCertificateData != "" && user.ClientKeyData != "" {
+		cert, err := base64.StdEncoding.DecodeString(user.ClientCertificateData)
+		if err != nil {
+			return nil, err
+		}
+		key, err := base64.StdEncoding.DecodeString(user.ClientKeyData)
+		if err != nil {
+			return nil, err
+		}
+		keyPair, err := tls.X509KeyPair(cert, key)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.Certificates = []tls.Certificate{keyPair}
+	}
+
+	return &tkeKubeClient{
+		server: strings.TrimSuffix(cluster.Server, "/"),
+		token:  user.Token,
+		client: &http.Client{Timeout: 30 * time.Second, Transport: &http.Transport{TLSClientConfig: tlsConfig}},
+	}, nil
+}
+
+// do sends the request and decodes the response into out, it returns the status code for callers to handle 404 and 429.
+func (me *tkeKubeClient) do(ctx context.Context, method, path, contentType string, body, out interface{}) (int, error) {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return 0, err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, me.server+path, reader)
+	if err != nil {
+		return 0, err
+	}
+	request.Header.Set("Accept", "application/json")
+	if contentType != "" {
+		request.Header.Set("Content-Type", contentType)
+	}
+	if me.token != "" {
+		request.Header.Set("Authorization", "Bearer "+me.token)
+	}
+
+	response, err := me.client.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+
+	data, err := io.ReadAll(response.Body)
+	if err != nil {
+		return response.StatusCode, err
+	}
+	if response.StatusCode >= http.StatusBadRequest {
+		return response.StatusCode, fmt.Errorf("kubernetes api %s %s failed, status %d: %s", method, path, response.StatusCode, string(data))
+	}
+	if out != nil {
+		return response.StatusCode, json.Unmarshal(data, out)
+	}
+	return response.StatusCode, nil
+}
+
+// FindNode returns the name of the node of the instance, or empty if the instance is not registered.
+func (me *tkeKubeClient) FindNode(ctx context.Context, instanceId, lanIp string) (string, error) {
+	nodes := struct {
+		Items []struct {
+			Metadata struct {
+				Name string `json:"name"`
+			} `json:"metadata"`
+		} `json:"items"`
+	}{}
+	query := url.Values{"labelSelector": []string{TKE_NODE_INSTANCE_ID_LABEL + "=" + instanceId}}
+	if _, err := me.do(ctx, http.MethodGet, "/api/v1/nodes?"+query.Encode(), "", nil, &nodes); err != nil {
+		return "", err
+	}
+	if len(nodes.Items) > 0 {
+		return nodes.Items[0].Metadata.Name, nil
+	}
+
+	// nodes are named by the private ip by default
+	if lanIp == "" {
+		return "", nil
+	}
+	status, err := me.do(ctx, http.MethodGet, "/api/v1/nodes/"+url.PathEscape(lanIp), "", nil, nil)
+	if status == http.StatusNotFound {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return lanIp, nil
+}
+
+// CordonNode marks the node unschedulable.
+func (me *tkeKubeClient) CordonNode(ctx context.Context, nodeName string) error {
+	body := map[string]interface{}{"spec": map[string]interface{}{"unschedulable": true}}
+	_, err := me.do(ctx, http.MethodPatch, "/api/v1/nodes/"+url.PathEscape(nodeName), "application/strategic-merge-patch+json", body, nil)
+	return err
+}
+
+// DrainNode evicts the pods of the node except DaemonSet and mirror pods, evictions blocked by PodDisruptionBudgets are
+// retried until timeout.
+func (me *tkeKubeClient) DrainNode(ctx context.Context, nodeName string, timeout time.Duration) error {
+	type pod struct {
+		Metadata struct {
+			Name            string            `json:"name"`
+			Namespace       string            `json:"namespace"`
+			Annotations     map[string]string `json:"annotations"`
+			OwnerReferences []struct {
+				Kind string `json:"kind"`
+			} `json:"ownerReferences"`
+		} `json:"metadata"`
+		Status struct {
+			Phase string `json:"phase"`
+		} `json:"status"`
+	}
+
+	query := url.Values{"fieldSelector": []string{"spec.nodeName=" + nodeName}}
+	return resource.Retry(timeout, func() *resource.RetryError {
+		pods := struct {
+			Items []pod `json:"items"`
+		}{}
+		if _, err := me.do(ctx, http.MethodGet, "/api/v1/pods?"+query.Encode(), "", nil, &pods); err != nil {
+			return resource.RetryableError(err)
+		}
+
+		remaining := 0
+	podLoop:
+		for _, item := range pods.Items {
+			if _, ok := item.Metadata.Annotations["kubernetes.io/config.mirror"]; ok {
+				continue
+			}
+			if item.Status.Phase == "Succeeded" || item.Status.Phase == "Failed" {
+				continue
+			}
+			for _, owner := range item.Metadata.OwnerReferences {
+				if owner.Kind == "DaemonSet" {
+					continue podLoop
+				}
+			}
+
+			remaining++
+			eviction := map[string]interface{}{
+				"apiVersion": "policy/v1",
+				"kind":       "Eviction",
+				"metadata":   map[string]interface{}{"name": item.Metadata.Name, "namespace": item.Metadata.Namespace},
+			}
+			path := fmt.Sprintf("/api/v1/namespaces/%s/pods/%s/eviction", url.PathEscape(item.Metadata.Namespace), url.PathEscape(item.Metadata.Name))
+			status, err := me.do(ctx, http.MethodPost, path, "application/json", eviction, nil)
+			// 429 means the eviction is blocked by a PodDisruptionBudget for now
+			if err != nil && status != http.StatusNotFound && status != http.StatusTooManyRequests {
+				return resource.NonRetryableError(err)
+			}
+		}
+
+		if remaining > 0 {
+			return resource.RetryableError(fmt.Errorf("node %s still has %d pods to evict", nodeName, remaining))
+		}
+		return nil
+	})
+}
@@ -0,0 +1,551 @@
+package tke
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	tchttp "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common/http"
+	tke2 "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/tke/v20220501"
+
+	tccommon "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/common"
+	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/internal/helper"
+	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/ratelimit"
+)
+
+const (
+	TKE_NODE_INSTANCE_ID_LABEL = "cloud.tencent.com/node-instance-id"
+	TKE_INSTANCE_STATE_RUNNING = "running"
+)
+
+// TkeNodePoolUpgradeStrategy is the `upgrade_strategy` of node pools.
+type TkeNodePoolUpgradeStrategy struct {
+	MaxSurge            int
+	MaxUnavailable      int
+	DrainTimeout        time.Duration
+	PauseBetweenBatches time.Duration
+	// Timeout of waiting for the node pool to scale in each batch.
+	ScaleTimeout time.Duration
+}
+
+// TkeNodePoolUpgradeProgress is the progress of a rolling replacement, it is saved in state to resume interrupted replacements.
+type TkeNodePoolUpgradeProgress struct {
+	PendingInstanceIds []string
+	// Desired capacity the node pool is scaled back to after each batch.
+	DesiredCapacity int64
+}
+
+// TkeNodePoolScaler reads and modifies the desired capacity of a node pool.
+type TkeNodePoolScaler struct {
+	GetDesiredCapacity func(ctx context.Context) (int64, error)
+	SetDesiredCapacity func(ctx context.Context, desiredCapacity int64) error
+	// GetMaxSize returns the max size of the node pool, 0 if the node pool has no max size.
+	GetMaxSize func(ctx context.Context) (int64, error)
+}
+
+// tkeNodeDrainer cordons and drains the nodes of instances, it is implemented by tkeKubeClient.
+type tkeNodeDrainer interface {
+	FindNode(ctx context.Context, instanceId, lanIp string) (string, error)
+	CordonNode(ctx context.Context, nodeName string) error
+	DrainNode(ctx context.Context, nodeName string, timeout time.Duration) error
+}
+
+// tkeNodePoolInstanceOperator lists and terminates the instances of a node pool during a rolling replacement.
+type tkeNodePoolInstanceOperator struct {
+	DescribeInstances func(ctx context.Context) ([]InstanceInfo, error)
+	DeleteInstances   func(ctx context.Context, instanceIds []string) error
+	ConnectCluster    func(ctx context.Context) (tkeNodeDrainer, error)
+}
+
+// DescribeNodePoolInstances returns the worker instances of the node pool.
+func (me *TkeService) DescribeNodePoolInstances(ctx context.Context, clusterId, nodePoolId string) (instances []InstanceInfo, errRet error) {
+	_, workers, err := me.DescribeClusterInstances(ctx, clusterId)
+	if err != nil {
+		errRet = err
+		return
+	}
+	for _, worker := range workers {
+		if worker.NodePoolId == nodePoolId {
+			instances = append(instances, worker)
+		}
+	}
+	return
+}
+
+func (me *TkeService) nodePoolInstanceOperator(clusterId, nodePoolId string) tkeNodePoolInstanceOperator {
+	return tkeNodePoolInstanceOperator{
+		DescribeInstances: func(ctx context.Context) ([]InstanceInfo, error) {
+			return me.DescribeNodePoolInstances(ctx, clusterId, nodePoolId)
+		},
+		DeleteInstances: func(ctx context.Context, instanceIds []string) error {
+			return resource.Retry(tccommon.WriteRetryTimeout, func() *resource.RetryError {
+				if e := me.DeleteClusterInstances(ctx, clusterId, instanceIds); e != nil {
+					return tccommon.RetryError(e, tccommon.InternalError)
+				}
+				return nil
+			})
+		},
+		ConnectCluster: func(ctx context.Context) (tkeNodeDrainer, error) {
+			kubeClient, err := me.newTkeKubeClient(ctx, clusterId)
+			if err != nil {
+				return nil, fmt.Errorf("connect to the API server of cluster %s to drain nodes failed: %s", clusterId, err.Error())
+			}
+			return kubeClient, nil
+		},
+	}
+}
+
+// DescribeNativeNodePoolInstances returns the instances of the native node pool and the machine names of them.
+func (me *TkeService) DescribeNativeNodePoolInstances(ctx context.Context, clusterId, nodePoolId string) (instances []InstanceInfo,
+	machineNames map[string]string, errRet error) {
+	logId := tccommon.GetLogId(ctx)
+
+	request := tke2.NewDescribeClusterInstancesRequest()
+	request.ClusterId = &clusterId
+	request.Filters = []*tke2.Filter{{Name: helper.String("NodePoolIds"), Values: []*string{&nodePoolId}}}
+
+	defer func() {
+		if errRet != nil {
+			log.Printf("[CRITAL]%s api[%s] fail, request body [%s], reason[%s]\n", logId, request.GetAction(), request.ToJsonString(), errRet.Error())
+		}
+	}()
+
+	var (
+		offset int64 = 0
+		limit  int64 = 100
+	)
+	machineNames = make(map[string]string)
+	for {
+		request.Offset = &offset
+		request.Limit = &limit
+		ratelimit.Check(request.GetAction())
+		response, err := me.client.UseTke2Client().DescribeClusterInstancesWithContext(ctx, request)
+		if err != nil {
+			errRet = err
+			return
+		}
+		log.Printf("[DEBUG]%s api[%s] success, request body [%s], response body [%s]\n", logId, request.GetAction(), request.ToJsonString(), response.ToJsonString())
+
+		if response == nil || response.Response == nil || len(response.Response.InstanceSet) < 1 {
+			break
+		}
+		for _, item := range response.Response.InstanceSet {
+			if item.NodePoolId == nil || *item.NodePoolId != nodePoolId || item.Native == nil || item.Native.MachineName == nil {
+				continue
+			}
+			instance := InstanceInfo{
+				InstanceId:    helper.PString(item.InstanceId),
+				InstanceRole:  helper.PString(item.InstanceRole),
+				InstanceState: helper.PString(item.InstanceState),
+				FailedReason:  helper.PString(item.FailedReason),
+				NodePoolId:    nodePoolId,
+				CreatedTime:   helper.PString(item.CreatedTime),
+				LanIp:         helper.PString(item.LanIP),
+			}
+			// machines which are not launched yet have no instance id
+			if instance.InstanceId == "" {
+				instance.InstanceId = *item.Native.MachineName
+			}
+			instances = append(instances, instance)
+			machineNames[instance.InstanceId] = *item.Native.MachineName
+		}
+		if len(response.Response.InstanceSet) < int(limit) {
+			break
+		}
+		offset += limit
+	}
+	return
+}
+
+// DeleteClusterMachines terminates the machines of native node pools and scales in the node pools, the API is not in the SDK yet.
+func (me *TkeService) DeleteClusterMachines(ctx context.Context, clusterId string, machineNames []string) (errRet error) {
+	logId := tccommon.GetLogId(ctx)
+
+	request := tchttp.NewCommonRequest("tke", "2018-05-25", "DeleteClusterMachines")
+	if err := request.SetActionParameters(map[string]interface{}{
+		"ClusterId":       clusterId,
+		"MachineNames":    machineNames,
+		"EnableScaleDown": true,
+	}); err != nil {
+		return err
+	}
+	reqBody, _ := request.MarshalJSON()
+
+	defer func() {
+		if errRet != nil {
+			log.Printf("[CRITAL]%s api[%s] fail, request body [%s], reason[%s]\n", logId, request.GetAction(), string(reqBody), errRet.Error())
+		}
+	}()
+
+	ratelimit.Check(request.GetAction())
+	response := tchttp.NewCommonResponse()
+	if err := me.client.UseOmitNilClient("tke").Send(request, response); err != nil {
+		errRet = err
+		return
+	}
+	log.Printf("[DEBUG]%s api[%s] success, request body [%s], response body [%s]\n", logId, request.GetAction(), string(reqBody), string(response.GetBody()))
+	return
+}
+
+func (me *TkeService) nativeNodePoolInstanceOperator(clusterId, nodePoolId string) tkeNodePoolInstanceOperator {
+	operator := me.nodePoolInstanceOperator(clusterId, nodePoolId)
+	operator.DescribeInstances = func(ctx context.Context) ([]InstanceInfo, error) {
+		instances, _, err := me.DescribeNativeNodePoolInstances(ctx, clusterId, nodePoolId)
+		return instances, err
+	}
+	operator.DeleteInstances = func(ctx context.Context, instanceIds []string) error {
+		_, machineNames, err := me.DescribeNativeNodePoolInstances(ctx, clusterId, nodePoolId)
+		if err != nil {
+			return err
+		}
+		names := make([]string, 0, len(instanceIds))
+		for _, instanceId := range instanceIds {
+			if name, ok := machineNames[instanceId]; ok {
+				names = append(names, name)
+			}
+		}
+		if len(names) == 0 {
+			return nil
+		}
+		return resource.Retry(tccommon.WriteRetryTimeout, func() *resource.RetryError {
+			if e := me.DeleteClusterMachines(ctx, clusterId, names); e != nil {
+				return tccommon.RetryError(e, tccommon.InternalError)
+			}
+			return nil
+		})
+	}
+	return operator
+}
+
+// checkTkeNodePoolSurge checks the node pool can scale out surge nodes over the desired capacity within its max size.
+func checkTkeNodePoolSurge(nodePoolId string, desiredCapacity, surge, maxSize int64) error {
+	if surge > 0 && maxSize > 0 && desiredCapacity+surge > maxSize {
+		return fmt.Errorf("node pool %s can not surge %d nodes over its desired capacity %d, which exceeds its max size %d, "+
+			"raise the max size or lower `max_surge` of `upgrade_strategy`", nodePoolId, surge, desiredCapacity, maxSize)
+	}
+	return nil
+}
+
+// rollingReplaceNodePoolInstances replaces the instances of progress in batches, each batch surges new nodes,
+// cordons and drains the old ones, terminates them and scales the node pool back to the desired capacity.
+// onProgress is called after each batch with the remaining instances.
+func rollingReplaceNodePoolInstances(ctx context.Context, nodePoolId string, progress TkeNodePoolUpgradeProgress, strategy TkeNodePoolUpgradeStrategy,
+	scaler TkeNodePoolScaler, operator tkeNodePoolInstanceOperator, onProgress func(progress TkeNodePoolUpgradeProgress)) error {
+	logId := tccommon.GetLogId(ctx)
+
+	batchSize := strategy.MaxSurge + strategy.MaxUnavailable
+	if batchSize < 1 {
+		return fmt.Errorf("at least one of `max_surge` and `max_unavailable` of `upgrade_strategy` must be greater than 0")
+	}
+
+	instances, err := operator.DescribeInstances(ctx)
+	if err != nil {
+		return err
+	}
+	instanceMap := make(map[string]InstanceInfo, len(instances))
+	for _, instance := range instances {
+		instanceMap[instance.InstanceId] = instance
+	}
+
+	// instances removed out of the replacement, such as scaled in by auto scaling, are skipped
+	pending := make([]string, 0, len(progress.PendingInstanceIds))
+	for _, instanceId := range progress.PendingInstanceIds {
+		if _, ok := instanceMap[instanceId]; ok {
+			pending = append(pending, instanceId)
+		}
+	}
+	progress.PendingInstanceIds = pending
+	onProgress(progress)
+	if len(pending) == 0 {
+		return nil
+	}
+
+	// fail before the first batch instead of in the middle of the replacement if the node pool can not surge
+	surge := int64(strategy.MaxSurge)
+	if surge > int64(len(pending)) {
+		surge = int64(len(pending))
+	}
+	if surge > 0 {
+		maxSize, err := scaler.GetMaxSize(ctx)
+		if err != nil {
+			return err
+		}
+		if err = checkTkeNodePoolSurge(nodePoolId, progress.DesiredCapacity, surge, maxSize); err != nil {
+			return err
+		}
+	}
+
+	drainer, err := operator.ConnectCluster(ctx)
+	if err != nil {
+		return err
+	}
+
+	for len(progress.PendingInstanceIds) > 0 {
+		batch := progress.PendingInstanceIds
+		if len(batch) > batchSize {
+			batch = batch[:batchSize]
+		}
+		surge := int64(strategy.MaxSurge)
+		if surge > int64(len(batch)) {
+			surge = int64(len(batch))
+		}
+		log.Printf("[DEBUG]%s rolling replace instances %v of node pool %s, surge %d\n", logId, batch, nodePoolId, surge)
+
+		if surge > 0 {
+			if err = scaler.SetDesiredCapacity(ctx, progress.DesiredCapacity+surge); err != nil {
+				return err
+			}
+			if err = waitNodePoolRunningInstances(ctx, operator, nodePoolId, progress.DesiredCapacity+surge, nil, strategy.ScaleTimeout); err != nil {
+				return err
+			}
+		}
+
+		for _, instanceId := range batch {
+			nodeName, err := drainer.FindNode(ctx, instanceId, instanceMap[instanceId].LanIp)
+			if err != nil {
+				return err
+			}
+			if nodeName == "" {
+				log.Printf("[WARN]%s instance %s is not registered as a node, skip draining it\n", logId, instanceId)
+				continue
+			}
+			if err = drainer.CordonNode(ctx, nodeName); err != nil {
+				return err
+			}
+			if err = drainer.DrainNode(ctx, nodeName, strategy.DrainTimeout); err != nil {
+				return err
+			}
+		}
+
+		if err = operator.DeleteInstances(ctx, batch); err != nil {
+			return err
+		}
+
+		if err = scaler.SetDesiredCapacity(ctx, progress.DesiredCapacity); err != nil {
+			return err
+		}
+		if err = waitNodePoolRunningInstances(ctx, operator, nodePoolId, progress.DesiredCapacity, batch, strategy.ScaleTimeout); err != nil {
+			return err
+		}
+
+		progress.PendingInstanceIds = progress.PendingInstanceIds[len(batch):]
+		onProgress(progress)
+
+		if len(progress.PendingInstanceIds) > 0 && strategy.PauseBetweenBatches > 0 {
+			time.Sleep(strategy.PauseBetweenBatches)
+		}
+	}
+
+	return nil
+}
+
+// waitNodePoolRunningInstances waits until the node pool has at least count running instances and none of the removed ones.
+func waitNodePoolRunningInstances(ctx context.Context, operator tkeNodePoolInstanceOperator, nodePoolId string, count int64, removed []string, timeout time.Duration) error {
+	return resource.Retry(timeout, func() *resource.RetryError {
+		instances, err := operator.DescribeInstances(ctx)
+		if err != nil {
+			return tccommon.RetryError(err, tccommon.InternalError)
+		}
+
+		var running int64
+		for _, instance := range instances {
+			for _, instanceId := range removed {
+				if instance.InstanceId == instanceId {
+					return resource.RetryableError(fmt.Errorf("instance %s of node pool %s is still being removed", instanceId, nodePoolId))
+				}
+			}
+			if instance.InstanceState == TKE_INSTANCE_STATE_RUNNING {
+				running++
+			}
+		}
+		if running < count {
+			return resource.RetryableError(fmt.Errorf("node pool %s has %d running instances, waiting for %d", nodePoolId, running, count))
+		}
+		return nil
+	})
+}
+
+func tkeNodePoolUpgradeStrategySchema(triggers string) *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Description: "Rolling replacement strategy of existing nodes. If set, changing " + triggers + " replaces the existing nodes in batches: " +
+			"each batch scales out `max_surge` new nodes, cordons and drains the old nodes through the cluster API server, terminates them and scales the node pool back. " +
+			"Only pay-as-you-go nodes can be terminated. The progress is saved in `upgrade_progress`, an interrupted replacement is resumed by the next apply.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"max_surge": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      1,
+					ValidateFunc: validation.IntAtLeast(0),
+					Description: "Number of new nodes created before removing old nodes in each batch. Default is `1`. " +
+						"The desired capacity plus `max_surge` must not exceed the max size of the node pool, otherwise the plan fails.",
+				},
+				"max_unavailable": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      0,
+					ValidateFunc: validation.IntAtLeast(0),
+					Description:  "Number of old nodes removed without surging new nodes first in each batch, the batch size is `max_surge` + `max_unavailable`. Default is `0`.",
+				},
+				"drain_timeout": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      300,
+					ValidateFunc: validation.IntAtLeast(1),
+					Description:  "Timeout in seconds of draining a node, evictions blocked by PodDisruptionBudgets are retried until the timeout. Default is `300`.",
+				},
+				"pause_between_batches": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      0,
+					ValidateFunc: validation.IntAtLeast(0),
+					Description:  "Pause in seconds between batches. Default is `0`.",
+				},
+			},
+		},
+	}
+}
+
+func tkeNodePoolUpgradeProgressSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "Progress of the rolling replacement of `upgrade_strategy`, empty if no replacement is in progress.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"pending_instance_ids": {
+					Type:        schema.TypeList,
+					Computed:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+					Description: "Old instances waiting to be replaced.",
+				},
+				"desired_capacity": {
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "Desired capacity the node pool is scaled back to after each batch.",
+				},
+			},
+		},
+	}
+}
+
+// tkeNodePoolUpgradeCustomizeDiff plans an update to resume the interrupted rolling replacement, and fails the plan
+// if a replacement is planned but the node pool can not surge `max_surge` nodes within its max size. capacity returns
+// the desired capacity and the max size of the node pool in the plan, ok is false if they are unknown.
+func tkeNodePoolUpgradeCustomizeDiff(capacity func(d *schema.ResourceDiff) (desiredCapacity, maxSize int64, ok bool),
+	triggers ...string) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		if d.Id() == "" {
+			return nil
+		}
+		v, ok := d.GetOk("upgrade_strategy")
+		if !ok || len(v.([]interface{})) == 0 || v.([]interface{})[0] == nil {
+			return nil
+		}
+		strategy := v.([]interface{})[0].(map[string]interface{})
+		resuming := false
+		if v, ok := d.GetOk("upgrade_progress"); ok && len(v.([]interface{})) > 0 {
+			resuming = true
+			if err := d.SetNewComputed("upgrade_progress"); err != nil {
+				return err
+			}
+		}
+		if !resuming && !d.HasChanges(triggers...) {
+			return nil
+		}
+		if desiredCapacity, maxSize, ok := capacity(d); ok {
+			return checkTkeNodePoolSurge(d.Id(), desiredCapacity, int64(strategy["max_surge"].(int)), maxSize)
+		}
+		return nil
+	}
+}
+
+func tkeNodePoolUpgradeStrategyFromData(d *schema.ResourceData) (strategy TkeNodePoolUpgradeStrategy, ok bool) {
+	v, ok := helper.InterfacesHeadMap(d, "upgrade_strategy")
+	if !ok {
+		return
+	}
+	strategy.MaxSurge = v["max_surge"].(int)
+	strategy.MaxUnavailable = v["max_unavailable"].(int)
+	strategy.DrainTimeout = time.Duration(v["drain_timeout"].(int)) * time.Second
+	strategy.PauseBetweenBatches = time.Duration(v["pause_between_batches"].(int)) * time.Second
+	strategy.ScaleTimeout = d.Timeout(schema.TimeoutUpdate)
+	return
+}
+
+func tkeNodePoolUpgradeProgressFromData(d *schema.ResourceData) (progress TkeNodePoolUpgradeProgress) {
+	v, ok := helper.InterfacesHeadMap(d, "upgrade_progress")
+	if !ok {
+		return
+	}
+	progress.PendingInstanceIds = helper.InterfacesStrings(v["pending_instance_ids"].([]interface{}))
+	progress.DesiredCapacity = int64(v["desired_capacity"].(int))
+	return
+}
+
+func setTkeNodePoolUpgradeProgress(d *schema.ResourceData, progress TkeNodePoolUpgradeProgress) {
+	if len(progress.PendingInstanceIds) == 0 {
+		_ = d.Set("upgrade_progress", nil)
+		return
+	}
+	_ = d.Set("upgrade_progress", []map[string]interface{}{{
+		"pending_instance_ids": progress.PendingInstanceIds,
+		"desired_capacity":     progress.DesiredCapacity,
+	}})
+}
+
+// prepareTkeNodePoolRollingReplace records the instances to replace before the node pool is modified,
+// it keeps the progress of an interrupted replacement and clears the progress if `upgrade_strategy` is removed.
+func prepareTkeNodePoolRollingReplace(ctx context.Context, d *schema.ResourceData, operator tkeNodePoolInstanceOperator, triggers ...string) error {
+	if _, ok := tkeNodePoolUpgradeStrategyFromData(d); !ok {
+		setTkeNodePoolUpgradeProgress(d, TkeNodePoolUpgradeProgress{})
+		return nil
+	}
+	if progress := tkeNodePoolUpgradeProgressFromData(d); len(progress.PendingInstanceIds) > 0 || !d.HasChanges(triggers...) {
+		return nil
+	}
+
+	instances, err := operator.DescribeInstances(ctx)
+	if err != nil {
+		return err
+	}
+
+	// the desired capacity is read after the node pool is modified, as it may be changed in the same apply
+	progress := TkeNodePoolUpgradeProgress{}
+	for _, instance := range instances {
+		progress.PendingInstanceIds = append(progress.PendingInstanceIds, instance.InstanceId)
+	}
+	setTkeNodePoolUpgradeProgress(d, progress)
+	return nil
+}
+
+// tkeNodePoolRollingReplace replaces the instances recorded by prepareTkeNodePoolRollingReplace, the progress is saved
+// in state after each batch.
+func tkeNodePoolRollingReplace(ctx context.Context, d *schema.ResourceData, nodePoolId string, scaler TkeNodePoolScaler, operator tkeNodePoolInstanceOperator) error {
+	strategy, ok := tkeNodePoolUpgradeStrategyFromData(d)
+	if !ok {
+		return nil
+	}
+	progress := tkeNodePoolUpgradeProgressFromData(d)
+	if len(progress.PendingInstanceIds) == 0 {
+		return nil
+	}
+	if progress.DesiredCapacity == 0 {
+		desiredCapacity, err := scaler.GetDesiredCapacity(ctx)
+		if err != nil {
+			return err
+		}
+		progress.DesiredCapacity = desiredCapacity
+	}
+
+	return rollingReplaceNodePoolInstances(ctx, nodePoolId, progress, strategy, scaler, operator, func(progress TkeNodePoolUpgradeProgress) {
+		setTkeNodePoolUpgradeProgress(d, progress)
+	})
+}
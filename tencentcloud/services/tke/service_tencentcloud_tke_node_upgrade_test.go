@@ -0,0 +1,269 @@
+package tke
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeNodePool is a node pool whose instances are launched and terminated immediately.
+type fakeNodePool struct {
+	instances []InstanceInfo
+	launched  int
+	events    []string
+	// failDelete fails the deletion of the instance
+	failDelete string
+	// unregistered instances are not found as nodes
+	unregistered map[string]bool
+	// maxSize of the node pool, 0 means no max size
+	maxSize int64
+}
+
+func newFakeNodePool(instanceIds ...string) *fakeNodePool {
+	pool := &fakeNodePool{unregistered: map[string]bool{}}
+	for _, instanceId := range instanceIds {
+		pool.instances = append(pool.instances, InstanceInfo{InstanceId: instanceId, InstanceState: TKE_INSTANCE_STATE_RUNNING, LanIp: "10.0.0." + instanceId})
+	}
+	return pool
+}
+
+func (pool *fakeNodePool) operator() tkeNodePoolInstanceOperator {
+	return tkeNodePoolInstanceOperator{
+		DescribeInstances: func(ctx context.Context) ([]InstanceInfo, error) {
+			return append([]InstanceInfo(nil), pool.instances...), nil
+		},
+		DeleteInstances: func(ctx context.Context, instanceIds []string) error {
+			pool.events = append(pool.events, "delete "+strings.Join(instanceIds, ","))
+			for _, instanceId := range instanceIds {
+				if instanceId == pool.failDelete {
+					return fmt.Errorf("delete %s failed", instanceId)
+				}
+			}
+			var remaining []InstanceInfo
+			for _, instance := range pool.instances {
+				deleted := false
+				for _, instanceId := range instanceIds {
+					deleted = deleted || instance.InstanceId == instanceId
+				}
+				if !deleted {
+					remaining = append(remaining, instance)
+				}
+			}
+			pool.instances = remaining
+			return nil
+		},
+		ConnectCluster: func(ctx context.Context) (tkeNodeDrainer, error) {
+			return pool, nil
+		},
+	}
+}
+
+func (pool *fakeNodePool) scaler() TkeNodePoolScaler {
+	return TkeNodePoolScaler{
+		GetDesiredCapacity: func(ctx context.Context) (int64, error) {
+			return int64(len(pool.instances)), nil
+		},
+		SetDesiredCapacity: func(ctx context.Context, desiredCapacity int64) error {
+			pool.events = append(pool.events, fmt.Sprintf("scale %d", desiredCapacity))
+			for int64(len(pool.instances)) < desiredCapacity {
+				pool.launched++
+				pool.instances = append(pool.instances, InstanceInfo{InstanceId: fmt.Sprintf("new%d", pool.launched), InstanceState: TKE_INSTANCE_STATE_RUNNING})
+			}
+			return nil
+		},
+		GetMaxSize: func(ctx context.Context) (int64, error) {
+			return pool.maxSize, nil
+		},
+	}
+}
+
+func (pool *fakeNodePool) FindNode(ctx context.Context, instanceId, lanIp string) (string, error) {
+	if pool.unregistered[instanceId] {
+		return "", nil
+	}
+	return lanIp, nil
+}
+
+func (pool *fakeNodePool) CordonNode(ctx context.Context, nodeName string) error {
+	pool.events = append(pool.events, "cordon "+nodeName)
+	return nil
+}
+
+func (pool *fakeNodePool) DrainNode(ctx context.Context, nodeName string, timeout time.Duration) error {
+	pool.events = append(pool.events, "drain "+nodeName)
+	return nil
+}
+
+func TestRollingReplaceNodePoolInstances(t *testing.T) {
+	pool := newFakeNodePool("1", "2", "3")
+	strategy := TkeNodePoolUpgradeStrategy{MaxSurge: 1, MaxUnavailable: 1, ScaleTimeout: time.Second}
+	progress := TkeNodePoolUpgradeProgress{PendingInstanceIds: []string{"1", "2", "3"}, DesiredCapacity: 3}
+
+	var saved [][]string
+	err := rollingReplaceNodePoolInstances(context.TODO(), "np-test", progress, strategy, pool.scaler(), pool.operator(), func(progress TkeNodePoolUpgradeProgress) {
+		saved = append(saved, progress.PendingInstanceIds)
+		if progress.DesiredCapacity != 3 {
+			t.Errorf("unexpected desired capacity %d", progress.DesiredCapacity)
+		}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// batches of max_surge + max_unavailable instances, the last batch surges only one node
+	expectedEvents := []string{
+		"scale 4", "cordon 10.0.0.1", "drain 10.0.0.1", "cordon 10.0.0.2", "drain 10.0.0.2", "delete 1,2", "scale 3",
+		"scale 4", "cordon 10.0.0.3", "drain 10.0.0.3", "delete 3", "scale 3",
+	}
+	if !reflect.DeepEqual(pool.events, expectedEvents) {
+		t.Errorf("unexpected events:\n%v\nexpected:\n%v", pool.events, expectedEvents)
+	}
+	expectedSaved := [][]string{{"1", "2", "3"}, {"3"}, {}}
+	if !reflect.DeepEqual(saved, expectedSaved) {
+		t.Errorf("unexpected progress %v, expected %v", saved, expectedSaved)
+	}
+	if len(pool.instances) != 3 {
+		t.Errorf("unexpected instances %v", pool.instances)
+	}
+	for _, instance := range pool.instances {
+		if !strings.HasPrefix(instance.InstanceId, "new") {
+			t.Errorf("instance %s is not replaced", instance.InstanceId)
+		}
+	}
+}
+
+func TestRollingReplaceNodePoolInstancesMaxUnavailable(t *testing.T) {
+	pool := newFakeNodePool("1", "2")
+	pool.unregistered["2"] = true
+	strategy := TkeNodePoolUpgradeStrategy{MaxUnavailable: 1, ScaleTimeout: time.Second}
+	progress := TkeNodePoolUpgradeProgress{PendingInstanceIds: []string{"1", "2"}, DesiredCapacity: 2}
+
+	err := rollingReplaceNodePoolInstances(context.TODO(), "np-test", progress, strategy, pool.scaler(), pool.operator(), func(TkeNodePoolUpgradeProgress) {})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// no surge, the node pool is scaled back after the old node is terminated, unregistered instances are not drained
+	expectedEvents := []string{
+		"cordon 10.0.0.1", "drain 10.0.0.1", "delete 1", "scale 2",
+		"delete 2", "scale 2",
+	}
+	if !reflect.DeepEqual(pool.events, expectedEvents) {
+		t.Errorf("unexpected events:\n%v\nexpected:\n%v", pool.events, expectedEvents)
+	}
+}
+
+func TestRollingReplaceNodePoolInstancesResume(t *testing.T) {
+	pool := newFakeNodePool("2", "3", "new1")
+	pool.launched = 1
+	strategy := TkeNodePoolUpgradeStrategy{MaxSurge: 1, ScaleTimeout: time.Second}
+	// instance 1 was replaced and instance gone was scaled in since the replacement was interrupted
+	progress := TkeNodePoolUpgradeProgress{PendingInstanceIds: []string{"2", "gone", "3"}, DesiredCapacity: 3}
+
+	var saved [][]string
+	err := rollingReplaceNodePoolInstances(context.TODO(), "np-test", progress, strategy, pool.scaler(), pool.operator(), func(progress TkeNodePoolUpgradeProgress) {
+		saved = append(saved, progress.PendingInstanceIds)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedSaved := [][]string{{"2", "3"}, {"3"}, {}}
+	if !reflect.DeepEqual(saved, expectedSaved) {
+		t.Errorf("unexpected progress %v, expected %v", saved, expectedSaved)
+	}
+	for _, event := range pool.events {
+		if strings.Contains(event, "gone") {
+			t.Errorf("unexpected event %s of the removed instance", event)
+		}
+	}
+}
+
+func TestRollingReplaceNodePoolInstancesInterrupted(t *testing.T) {
+	pool := newFakeNodePool("1", "2", "3")
+	pool.failDelete = "2"
+	strategy := TkeNodePoolUpgradeStrategy{MaxSurge: 1, ScaleTimeout: time.Second}
+	progress := TkeNodePoolUpgradeProgress{PendingInstanceIds: []string{"1", "2", "3"}, DesiredCapacity: 3}
+
+	var last TkeNodePoolUpgradeProgress
+	err := rollingReplaceNodePoolInstances(context.TODO(), "np-test", progress, strategy, pool.scaler(), pool.operator(), func(progress TkeNodePoolUpgradeProgress) {
+		last = progress
+	})
+	if err == nil {
+		t.Fatal("expected error of the failed deletion")
+	}
+
+	// the failed batch is kept, so the next apply resumes from it
+	if !reflect.DeepEqual(last.PendingInstanceIds, []string{"2", "3"}) || last.DesiredCapacity != 3 {
+		t.Errorf("unexpected progress %+v", last)
+	}
+}
+
+func TestRollingReplaceNodePoolInstancesNothingPending(t *testing.T) {
+	pool := newFakeNodePool("new1")
+	strategy := TkeNodePoolUpgradeStrategy{MaxSurge: 1, ScaleTimeout: time.Second}
+	progress := TkeNodePoolUpgradeProgress{PendingInstanceIds: []string{"1"}, DesiredCapacity: 1}
+
+	var saved [][]string
+	err := rollingReplaceNodePoolInstances(context.TODO(), "np-test", progress, strategy, pool.scaler(), pool.operator(), func(progress TkeNodePoolUpgradeProgress) {
+		saved = append(saved, progress.PendingInstanceIds)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(saved, [][]string{{}}) || len(pool.events) != 0 {
+		t.Errorf("unexpected progress %v and events %v", saved, pool.events)
+	}
+
+	strategy.MaxSurge = 0
+	if err = rollingReplaceNodePoolInstances(context.TODO(), "np-test", progress, strategy, pool.scaler(), pool.operator(), func(TkeNodePoolUpgradeProgress) {}); err == nil {
+		t.Error("expected error of empty batches")
+	}
+}
+
+func TestRollingReplaceNodePoolInstancesMaxSize(t *testing.T) {
+	strategy := TkeNodePoolUpgradeStrategy{MaxSurge: 2, ScaleTimeout: time.Second}
+	progress := TkeNodePoolUpgradeProgress{PendingInstanceIds: []string{"1", "2", "3"}, DesiredCapacity: 3}
+
+	// the node pool can not surge over its max size, nothing is changed
+	pool := newFakeNodePool("1", "2", "3")
+	pool.maxSize = 4
+	err := rollingReplaceNodePoolInstances(context.TODO(), "np-test", progress, strategy, pool.scaler(), pool.operator(), func(TkeNodePoolUpgradeProgress) {})
+	if err == nil || !strings.Contains(err.Error(), "exceeds its max size 4") {
+		t.Errorf("unexpected error %v", err)
+	}
+	if len(pool.events) != 0 {
+		t.Errorf("unexpected events %v", pool.events)
+	}
+
+	// the surge is limited to the pending instances
+	pool = newFakeNodePool("1", "2", "3")
+	pool.maxSize = 4
+	progress.PendingInstanceIds = []string{"3"}
+	if err = rollingReplaceNodePoolInstances(context.TODO(), "np-test", progress, strategy, pool.scaler(), pool.operator(), func(TkeNodePoolUpgradeProgress) {}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCheckTkeNodePoolSurge(t *testing.T) {
+	cases := []struct {
+		name                            string
+		desiredCapacity, surge, maxSize int64
+		err                             bool
+	}{
+		{name: "within max size", desiredCapacity: 3, surge: 1, maxSize: 4},
+		{name: "exceeds max size", desiredCapacity: 3, surge: 2, maxSize: 4, err: true},
+		{name: "at max size", desiredCapacity: 4, surge: 1, maxSize: 4, err: true},
+		{name: "no surge", desiredCapacity: 4, maxSize: 4},
+		{name: "no max size", desiredCapacity: 4, surge: 1},
+	}
+	for _, c := range cases {
+		err := checkTkeNodePoolSurge("np-test", c.desiredCapacity, c.surge, c.maxSize)
+		if (err != nil) != c.err {
+			t.Errorf("%s: unexpected error %v", c.name, err)
+		}
+	}
+}
@@ -332,7 +332,7 @@ func dataSourceTencentCloudRocketmqClusterRead(d *schema.ResourceData, meta inte
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), clusterListList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), clusterListList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
@@ -139,7 +139,7 @@ func dataSourceTencentCloudTdmqRocketmqNamespaceRead(d *schema.ResourceData, met
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), namespaceList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), namespaceList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
@@ -131,7 +131,7 @@ func dataSourceTencentCloudTdmqRocketmqRoleRead(d *schema.ResourceData, meta int
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), roleSetList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), roleSetList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
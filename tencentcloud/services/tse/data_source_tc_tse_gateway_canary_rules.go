@@ -293,7 +293,7 @@ func dataSourceTencentCloudTseGatewayCanaryRulesRead(d *schema.ResourceData, met
 	d.SetId(gatewayId)
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), cloudAPIGatewayCanaryRuleListMap); e != nil {
+		if e := tccommon.WriteToFile(output.(string), cloudAPIGatewayCanaryRuleListMap, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
@@ -158,7 +158,7 @@ func dataSourceTencentCloudTseGatewayNodesRead(d *schema.ResourceData, meta inte
 	d.SetId(helper.DataResourceIdsHash(ids))
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), nodeListList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), nodeListList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
@@ -469,7 +469,7 @@ func dataSourceTencentCloudTseGatewaysRead(d *schema.ResourceData, meta interfac
 	d.SetId(helper.DataResourceIdsHash(ids))
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), listCloudNativeAPIGatewayResultMap); e != nil {
+		if e := tccommon.WriteToFile(output.(string), listCloudNativeAPIGatewayResultMap, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
@@ -363,7 +363,7 @@ func dataSourceTencentCloudTsfApiDetailRead(d *schema.ResourceData, meta interfa
 	d.SetId(strings.Join([]string{microserviceId, path, method, pkgVersion, applicationId}, tccommon.FILED_SP))
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), apiDetailResponseMap); e != nil {
+		if e := tccommon.WriteToFile(output.(string), apiDetailResponseMap, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
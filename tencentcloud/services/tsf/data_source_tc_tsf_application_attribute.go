@@ -106,7 +106,7 @@ func dataSourceTencentCloudTsfApplicationAttributeRead(d *schema.ResourceData, m
 	d.SetId(ids)
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), applicationAttributeMap); e != nil {
+		if e := tccommon.WriteToFile(output.(string), applicationAttributeMap, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
@@ -263,7 +263,7 @@ func dataSourceTencentCloudTsfApplicationConfigRead(d *schema.ResourceData, meta
 	d.SetId(helper.DataResourceIdsHash(ids))
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), tsfPageConfigMap); e != nil {
+		if e := tccommon.WriteToFile(output.(string), tsfPageConfigMap, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
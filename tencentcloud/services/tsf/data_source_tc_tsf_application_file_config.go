@@ -296,7 +296,7 @@ func dataSourceTencentCloudTsfApplicationFileConfigRead(d *schema.ResourceData,
 	d.SetId(helper.DataResourceIdsHash(ids))
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), tsfPageFileConfigMap); e != nil {
+		if e := tccommon.WriteToFile(output.(string), tsfPageFileConfigMap, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
@@ -250,7 +250,7 @@ func dataSourceTencentCloudTsfApplicationPublicConfigRead(d *schema.ResourceData
 	d.SetId(helper.DataResourceIdsHash(ids))
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), tsfPageConfigMap); e != nil {
+		if e := tccommon.WriteToFile(output.(string), tsfPageConfigMap, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
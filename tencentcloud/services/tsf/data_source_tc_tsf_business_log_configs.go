@@ -392,7 +392,7 @@ func dataSourceTencentCloudTsfBusinessLogConfigsRead(d *schema.ResourceData, met
 	d.SetId(helper.DataResourceIdsHash(ids))
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), tsfPageBusinessLogConfigMap); e != nil {
+		if e := tccommon.WriteToFile(output.(string), tsfPageBusinessLogConfigMap, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
@@ -526,7 +526,7 @@ func dataSourceTencentCloudTsfClusterRead(d *schema.ResourceData, meta interface
 	d.SetId(helper.DataResourceIdsHash(ids))
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), tsfPageClusterMap); e != nil {
+		if e := tccommon.WriteToFile(output.(string), tsfPageClusterMap, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
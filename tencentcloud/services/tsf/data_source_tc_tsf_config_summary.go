@@ -284,7 +284,7 @@ func dataSourceTencentCloudTsfConfigSummaryRead(d *schema.ResourceData, meta int
 	d.SetId(helper.DataResourceIdsHash(ids))
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), tsfPageConfigMap); e != nil {
+		if e := tccommon.WriteToFile(output.(string), tsfPageConfigMap, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
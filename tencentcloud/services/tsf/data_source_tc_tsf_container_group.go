@@ -312,7 +312,7 @@ func dataSourceTencentCloudTsfContainerGroupRead(d *schema.ResourceData, meta in
 	d.SetId(helper.DataResourceIdsHash(ids))
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), containGroupResultMap); e != nil {
+		if e := tccommon.WriteToFile(output.(string), containGroupResultMap, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
@@ -96,7 +96,7 @@ func dataSourceTencentCloudTsfDeliveryConfigByGroupIdRead(d *schema.ResourceData
 	d.SetId(groupId)
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), simpleKafkaDeliveryConfigMap); e != nil {
+		if e := tccommon.WriteToFile(output.(string), simpleKafkaDeliveryConfigMap, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
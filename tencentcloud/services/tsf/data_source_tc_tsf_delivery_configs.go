@@ -380,7 +380,7 @@ func dataSourceTencentCloudTsfDeliveryConfigsRead(d *schema.ResourceData, meta i
 	d.SetId(helper.DataResourceIdsHash(ids))
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), deliveryConfigBindGroupsMap); e != nil {
+		if e := tccommon.WriteToFile(output.(string), deliveryConfigBindGroupsMap, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
@@ -489,7 +489,7 @@ func dataSourceTencentCloudTsfGroupInstancesRead(d *schema.ResourceData, meta in
 	d.SetId(helper.DataResourceIdsHash(ids))
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), tsfPageInstanceMap); e != nil {
+		if e := tccommon.WriteToFile(output.(string), tsfPageInstanceMap, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
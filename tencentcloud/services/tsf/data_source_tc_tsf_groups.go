@@ -358,7 +358,7 @@ func dataSourceTencentCloudTsfGroupsRead(d *schema.ResourceData, meta interface{
 	d.SetId(helper.DataResourceIdsHash(ids))
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), tsfPageVmGroupMap); e != nil {
+		if e := tccommon.WriteToFile(output.(string), tsfPageVmGroupMap, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
@@ -225,7 +225,7 @@ func dataSourceTencentCloudTsfMicroserviceRead(d *schema.ResourceData, meta inte
 	d.SetId(helper.DataResourceIdsHash(ids))
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), tsfPageMicroserviceMap); e != nil {
+		if e := tccommon.WriteToFile(output.(string), tsfPageMicroserviceMap, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
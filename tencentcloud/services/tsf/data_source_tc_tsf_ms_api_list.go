@@ -155,7 +155,7 @@ func dataSourceTencentCloudTsfMsApiListRead(d *schema.ResourceData, meta interfa
 	d.SetId(helper.DataResourceIdsHash(ids))
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), tsfApiListResponseMap); e != nil {
+		if e := tccommon.WriteToFile(output.(string), tsfApiListResponseMap, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
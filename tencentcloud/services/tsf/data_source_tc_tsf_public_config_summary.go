@@ -274,7 +274,7 @@ func dataSourceTencentCloudTsfPublicConfigSummaryRead(d *schema.ResourceData, me
 	d.SetId(helper.DataResourceIdsHash(ids))
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), tsfPageConfigMap); e != nil {
+		if e := tccommon.WriteToFile(output.(string), tsfPageConfigMap, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
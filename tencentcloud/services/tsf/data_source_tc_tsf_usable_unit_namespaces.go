@@ -163,7 +163,7 @@ func dataSourceTencentCloudTsfUsableUnitNamespacesRead(d *schema.ResourceData, m
 	d.SetId(helper.DataResourceIdsHash(ids))
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), tsfPageUnitNamespaceMap); e != nil {
+		if e := tccommon.WriteToFile(output.(string), tsfPageUnitNamespaceMap, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
@@ -174,7 +174,7 @@ func dataSourceTencentCloudVodImageSpriteTemplatesRead(d *schema.ResourceData, m
 	}
 
 	if output, ok := d.GetOk("result_output_file"); ok && output.(string) != "" {
-		if err := tccommon.WriteToFile(output.(string), templatesList); err != nil {
+		if err := tccommon.WriteToFile(output.(string), templatesList, tccommon.GetResultOutputOption(d)); err != nil {
 			log.Printf("[CRITAL]%s output file[%s] fail, reason[%s]", logId, output.(string), err.Error())
 			return err
 		}
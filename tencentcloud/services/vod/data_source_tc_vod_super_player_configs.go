@@ -207,7 +207,7 @@ func dataSourceTencentCloudVodSuperPlayerConfigsRead(d *schema.ResourceData, met
 	}
 
 	if output, ok := d.GetOk("result_output_file"); ok && output.(string) != "" {
-		if err := tccommon.WriteToFile(output.(string), configsList); err != nil {
+		if err := tccommon.WriteToFile(output.(string), configsList, tccommon.GetResultOutputOption(d)); err != nil {
 			log.Printf("[CRITAL]%s output file[%s] fail, reason[%s]", logId, output.(string), err.Error())
 			return err
 		}
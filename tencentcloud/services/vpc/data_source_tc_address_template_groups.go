@@ -120,7 +120,7 @@ func dataSourceTencentCloudAddressTemplateGroupsRead(d *schema.ResourceData, met
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), templateGroupList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), templateGroupList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
@@ -210,7 +210,7 @@ func dataSourceTencentCloudDnatsRead(d *schema.ResourceData, meta interface{}) e
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), dnatList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), dnatList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
@@ -445,7 +445,7 @@ func dataSourceTencentCloudElasticPublicIpv6sRead(d *schema.ResourceData, meta i
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), addressSetList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), addressSetList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
@@ -341,7 +341,7 @@ func dataSourceTencentCloudEnisRead(d *schema.ResourceData, m interface{}) error
 	d.SetId(helper.DataResourceIdsHash(eniIds))
 
 	if output, ok := d.GetOk("result_output_file"); ok && output.(string) != "" {
-		if err := tccommon.WriteToFile(output.(string), enis); err != nil {
+		if err := tccommon.WriteToFile(output.(string), enis, tccommon.GetResultOutputOption(d)); err != nil {
 			log.Printf("[CRITAL]%s output file[%s] fail, reason[%v]",
 				logId, output.(string), err)
 			return err
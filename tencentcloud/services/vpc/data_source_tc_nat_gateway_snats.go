@@ -120,7 +120,7 @@ func dataSourceTencentCloudNatGatewaySnatRead(d *schema.ResourceData, meta inter
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), snatList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), snatList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
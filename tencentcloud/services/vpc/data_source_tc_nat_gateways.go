@@ -183,7 +183,7 @@ func dataSourceTencentCloudNatGatewaysRead(d *schema.ResourceData, meta interfac
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), natList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), natList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
@@ -0,0 +1,186 @@
+package vpc
+
+import (
+	"context"
+
+	tccommon "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func securityGroupEffectiveRuleSchema(description string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: description,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"policy_index": {
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "Index of the rule, the rules are evaluated in ascending order.",
+				},
+				"action": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Rule policy. Valid values: `ACCEPT`, `DROP`.",
+				},
+				"protocol": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Type of IP protocol, empty if the rule refers to a service template.",
+				},
+				"port": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Range of the port, empty if the rule refers to a service template.",
+				},
+				"services": {
+					Type:        schema.TypeList,
+					Computed:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+					Description: "Protocols and ports expanded from the service template, e.g. `tcp:80,443`.",
+				},
+				"addresses": {
+					Type:        schema.TypeList,
+					Computed:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+					Description: "CIDR blocks, IPs or IP ranges of the peer, including the ones expanded from the address template.",
+				},
+				"source_security_id": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "ID of the security group of the peer.",
+				},
+				"description": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Description of the rule.",
+				},
+			},
+		},
+	}
+}
+
+func DataSourceTencentCloudSecurityGroupEffectiveRules() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceTencentCloudSecurityGroupEffectiveRulesRead,
+		Schema: map[string]*schema.Schema{
+			"security_group_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "ID of the security group.",
+			},
+			"result_output_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Used to save results.",
+			},
+			// computed
+			"ingress": securityGroupEffectiveRuleSchema("Ingress rules in evaluation order."),
+			"egress":  securityGroupEffectiveRuleSchema("Egress rules in evaluation order."),
+			"findings": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Problems found in the rules.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Type of the problem. Valid values: `SHADOWED` (the rule is covered by an earlier rule and never takes effect), `DUPLICATE` (the rule is the same as an earlier rule), `WORLD_OPEN` (the ingress rule accepts `0.0.0.0/0` or `::/0`).",
+						},
+						"direction": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Direction of the rule. Valid values: `ingress`, `egress`.",
+						},
+						"policy_index": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Index of the rule.",
+						},
+						"related_policy_index": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Index of the earlier rule shadowing or duplicating the rule, -1 for `WORLD_OPEN`.",
+						},
+						"message": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Description of the problem.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceTencentCloudSecurityGroupEffectiveRulesRead(d *schema.ResourceData, meta interface{}) error {
+	defer tccommon.LogElapsed("data_source.tencentcloud_security_group_effective_rules.read")()
+	defer tccommon.InconsistentCheck(d, meta)()
+
+	var (
+		logId   = tccommon.GetLogId(tccommon.ContextNil)
+		ctx     = context.WithValue(context.TODO(), tccommon.LogIdKey, logId)
+		service = VpcService{client: meta.(tccommon.ProviderMeta).GetAPIV3Conn()}
+		sgId    = d.Get("security_group_id").(string)
+	)
+
+	ingress, egress, err := service.DescribeSecurityGroupEffectiveRules(ctx, sgId)
+	if err != nil {
+		return err
+	}
+
+	findings := make([]map[string]interface{}, 0)
+	for _, finding := range append(LintSecurityGroupRules(ingress), LintSecurityGroupRules(egress)...) {
+		relatedIndex := int64(-1)
+		if finding.RelatedRule != nil {
+			relatedIndex = finding.RelatedRule.Index
+		}
+		findings = append(findings, map[string]interface{}{
+			"type":                 finding.Type,
+			"direction":            finding.Rule.Direction,
+			"policy_index":         finding.Rule.Index,
+			"related_policy_index": relatedIndex,
+			"message":              finding.Message,
+		})
+	}
+
+	ingressList, egressList := flattenSecurityGroupEffectiveRules(ingress), flattenSecurityGroupEffectiveRules(egress)
+	_ = d.Set("ingress", ingressList)
+	_ = d.Set("egress", egressList)
+	_ = d.Set("findings", findings)
+
+	d.SetId(sgId)
+
+	output, ok := d.GetOk("result_output_file")
+	if ok && output.(string) != "" {
+		if e := tccommon.WriteToFile(output.(string), map[string]interface{}{
+			"ingress":  ingressList,
+			"egress":   egressList,
+			"findings": findings,
+		}, tccommon.GetResultOutputOption(d)); e != nil {
+			return e
+		}
+	}
+	return nil
+}
+
+func flattenSecurityGroupEffectiveRules(rules []*VpcNetworkRule) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(rules))
+	for _, rule := range rules {
+		result = append(result, map[string]interface{}{
+			"policy_index":       rule.Index,
+			"action":             rule.Action,
+			"protocol":           rule.Protocol,
+			"port":               rule.Port,
+			"services":           rule.Services,
+			"addresses":          rule.PeerAddresses,
+			"source_security_id": rule.PeerSecurityGroupId,
+			"description":        rule.Description,
+		})
+	}
+	return result
+}
@@ -0,0 +1,74 @@
+package vpc_test
+
+import (
+	"testing"
+
+	tcacctest "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/acctest"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccTencentCloudSecurityGroupEffectiveRulesDataSource_basic(t *testing.T) {
+	t.Parallel()
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			tcacctest.AccPreCheck(t)
+		},
+		Providers: tcacctest.AccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSecurityGroupEffectiveRulesDataSource,
+				Check: resource.ComposeTestCheckFunc(
+					tcacctest.AccCheckTencentCloudDataSourceID("data.tencentcloud_security_group_effective_rules.example"),
+					resource.TestCheckResourceAttr("data.tencentcloud_security_group_effective_rules.example", "ingress.#", "3"),
+					resource.TestCheckResourceAttr("data.tencentcloud_security_group_effective_rules.example", "ingress.1.addresses.#", "2"),
+					resource.TestCheckResourceAttr("data.tencentcloud_security_group_effective_rules.example", "findings.#", "2"),
+					resource.TestCheckResourceAttr("data.tencentcloud_security_group_effective_rules.example", "findings.0.type", "WORLD_OPEN"),
+					resource.TestCheckResourceAttr("data.tencentcloud_security_group_effective_rules.example", "findings.1.type", "SHADOWED"),
+					resource.TestCheckResourceAttr("data.tencentcloud_security_group_effective_rules.example", "findings.1.related_policy_index", "0"),
+				),
+			},
+		},
+	})
+}
+
+const testAccSecurityGroupEffectiveRulesDataSource = `
+resource "tencentcloud_security_group" "example" {
+  name        = "tf-example-effective-rules"
+  description = "security group for effective rules"
+}
+
+resource "tencentcloud_address_template" "example" {
+  name      = "tf-example-effective-rules"
+  addresses = ["10.0.0.1", "10.0.1.0/24"]
+}
+
+resource "tencentcloud_security_group_rule_set" "example" {
+  security_group_id = tencentcloud_security_group.example.id
+
+  ingress {
+    action     = "ACCEPT"
+    cidr_block = "0.0.0.0/0"
+    protocol   = "TCP"
+    port       = "22"
+  }
+
+  ingress {
+    action              = "ACCEPT"
+    address_template_id = tencentcloud_address_template.example.id
+    protocol            = "TCP"
+    port                = "443"
+  }
+
+  ingress {
+    action     = "DROP"
+    cidr_block = "10.0.0.0/8"
+    protocol   = "TCP"
+    port       = "22"
+  }
+}
+
+data "tencentcloud_security_group_effective_rules" "example" {
+  security_group_id = tencentcloud_security_group_rule_set.example.id
+}
+`
@@ -248,7 +248,7 @@ func dataSourceTencentCloudSecurityGroupsRead(d *schema.ResourceData, m interfac
 	d.SetId(idBuilder.String())
 
 	if output, ok := d.GetOk("result_output_file"); ok && output.(string) != "" {
-		if err := tccommon.WriteToFile(output.(string), sgInstances); err != nil {
+		if err := tccommon.WriteToFile(output.(string), sgInstances, tccommon.GetResultOutputOption(d)); err != nil {
 			log.Printf("[CRITAL]%s output file[%s] fail, reason[%v]", logId, output.(string), err)
 			return err
 		}
@@ -51,6 +51,7 @@ func DataSourceTencentCloudVpcInstances() *schema.Resource {
 				Optional:    true,
 				Description: "Tags of the VPC to be queried.",
 			},
+			"filter": tccommon.DataSourceFilterSchema("DescribeVpcs"),
 			"result_output_file": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -157,8 +158,33 @@ func dataSourceTencentCloudVpcInstancesRead(d *schema.ResourceData, meta interfa
 		vpcInfos []VpcBasicInfo
 		err      error
 	)
+
+	filterMap := make(map[string][]string)
+	if vpcId != "" {
+		filterMap["vpc-id"] = []string{vpcId}
+	}
+	if name != "" {
+		filterMap["vpc-name"] = []string{name}
+	}
+	if tagKey != "" {
+		filterMap["tag-key"] = []string{tagKey}
+	}
+	if cidrBlock != "" {
+		filterMap["cidr-block"] = []string{cidrBlock}
+	}
+	if isDefault != nil {
+		filterMap["is-default"] = []string{fmt.Sprintf("%t", *isDefault)}
+	}
+	for k, v := range tags {
+		filterMap["tag:"+k] = []string{v}
+	}
+	filters, err := tccommon.GetDataSourceFilters(d, filterMap)
+	if err != nil {
+		return err
+	}
+
 	err = resource.Retry(tccommon.ReadRetryTimeout, func() *resource.RetryError {
-		vpcInfos, err = service.DescribeVpcs(ctx, vpcId, name, tags, isDefault, tagKey, cidrBlock)
+		vpcInfos, err = service.DescribeVpcsByFilters(ctx, vpcId, filters)
 		if err != nil {
 			return tccommon.RetryError(err, tccommon.InternalError)
 		}
@@ -169,6 +195,10 @@ func dataSourceTencentCloudVpcInstancesRead(d *schema.ResourceData, meta interfa
 		return err
 	}
 
+	tccommon.SortByKey(vpcInfos, func(info VpcBasicInfo) string {
+		return info.vpcId
+	})
+
 	var vpcInfoList = make([]map[string]interface{}, 0, len(vpcInfos))
 
 	for _, item := range vpcInfos {
@@ -219,6 +249,7 @@ func dataSourceTencentCloudVpcInstancesRead(d *schema.ResourceData, meta interfa
 		"tagKey":    tagKey,
 		"cidrBlock": cidrBlock,
 		"tags":      tags,
+		"filters":   filters,
 	})
 	if err != nil {
 		log.Printf("[CRITAL]%s create data source id error, reason:%s\n ", logId, err.Error())
@@ -231,7 +262,7 @@ func dataSourceTencentCloudVpcInstancesRead(d *schema.ResourceData, meta interfa
 	d.SetId(id)
 
 	if output, ok := d.GetOk("result_output_file"); ok && output.(string) != "" {
-		if err := tccommon.WriteToFile(output.(string), vpcInfoList); err != nil {
+		if err := tccommon.WriteToFile(output.(string), vpcInfoList, tccommon.GetResultOutputOption(d)); err != nil {
 			log.Printf("[CRITAL]%s output file[%s] fail, reason[%s]\n",
 				logId, output.(string), err.Error())
 			return err
@@ -61,6 +61,11 @@ func TestAccDataSourceTencentCloudVpcV3Instances_basic(t *testing.T) {
 					tcacctest.AccCheckTencentCloudDataSourceID("data.tencentcloud_vpc_instances.cidr_instances"),
 					resource.TestCheckResourceAttrSet("data.tencentcloud_vpc_instances.cidr_instances", "instance_list.#"),
 					resource.TestCheckResourceAttr("data.tencentcloud_vpc_instances.cidr_instances", "instance_list.0.cidr_block", "10.0.0.0/16"),
+
+					// generic filter
+					tcacctest.AccCheckTencentCloudDataSourceID("data.tencentcloud_vpc_instances.filter_instances"),
+					resource.TestCheckResourceAttr("data.tencentcloud_vpc_instances.filter_instances", "instance_list.#", "1"),
+					resource.TestCheckResourceAttr("data.tencentcloud_vpc_instances.filter_instances", "instance_list.0.name", "guagua_vpc_instance_test"),
 				),
 			},
 		},
@@ -92,4 +97,16 @@ data "tencentcloud_vpc_instances" "name_instances" {
 data "tencentcloud_vpc_instances" "tags_instances" {
   tags = tencentcloud_vpc.foo.tags
 }
+
+data "tencentcloud_vpc_instances" "filter_instances" {
+  filter {
+    name   = "vpc-id"
+    values = [tencentcloud_vpc.foo.id]
+  }
+
+  filter {
+    name   = "is-default"
+    values = ["false"]
+  }
+}
 `
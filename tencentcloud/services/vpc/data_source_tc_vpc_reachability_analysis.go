@@ -0,0 +1,262 @@
+package vpc
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+
+	tccommon "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/internal/helper"
+)
+
+func vpcReachabilityEndpointSchema(description string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Required:    true,
+		MaxItems:    1,
+		Description: description,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"eni_id": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "ID of the ENI, the primary private IP, the VPC, the subnet and the security groups of the ENI are used. Only one of `eni_id`, `clb_id` and `ip` can be set.",
+				},
+				"clb_id": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "ID of the CLB, the VIP and the security groups of the CLB are used. A public CLB is regarded as out of VPC. Only one of `eni_id`, `clb_id` and `ip` can be set.",
+				},
+				"ip": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					ValidateFunc: validation.IsIPAddress,
+					Description:  "IP address of the endpoint, it is regarded as out of VPC unless `subnet_id` is set. Only one of `eni_id`, `clb_id` and `ip` can be set.",
+				},
+				"vpc_id": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "ID of the VPC of the endpoint, only valid with `ip`. Defaults to the VPC of `subnet_id`.",
+				},
+				"subnet_id": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "ID of the subnet of the endpoint, whose network ACL and route table are evaluated, only valid with `ip`.",
+				},
+				"security_group_ids": {
+					Type:        schema.TypeList,
+					Optional:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+					Description: "IDs of the security groups bound to the endpoint in priority order, only valid with `ip`.",
+				},
+			},
+		},
+	}
+}
+
+func DataSourceTencentCloudVpcReachabilityAnalysis() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceTencentCloudVpcReachabilityAnalysisRead,
+		Schema: map[string]*schema.Schema{
+			"source":      vpcReachabilityEndpointSchema("Source of the flow."),
+			"destination": vpcReachabilityEndpointSchema("Destination of the flow."),
+			"protocol": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice(VPC_NETWORK_FLOW_PROTOCOL, false),
+				Description:  "Protocol of the flow. Valid values: `TCP`, `UDP`, `ICMP`.",
+			},
+			"port": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IsPortNumber,
+				Description:  "Destination port of the flow, required unless `protocol` is `ICMP`.",
+			},
+			"result_output_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Used to save results.",
+			},
+			// computed
+			"reachable": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the flow is allowed on every hop of the path, false if a hop is `NOT_EVALUATED`.",
+			},
+			"source_ip": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "IP address of the source.",
+			},
+			"destination_ip": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "IP address of the destination.",
+			},
+			"blocking_hop": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The hop denying the flow, empty if reachable.",
+			},
+			"blocking_resource_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the security group, network ACL, route table, CCN, peering connection or NAT gateway denying the flow, empty if reachable.",
+			},
+			"blocking_rule": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The rule or route denying the flow, empty if reachable.",
+			},
+			"hops": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Hops evaluated in order, the evaluation stops at the first hop denying the flow.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"hop": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Type of the hop. Valid values: `SOURCE_SECURITY_GROUP`, `SOURCE_NETWORK_ACL`, `ROUTE`, `DESTINATION_NETWORK_ACL`, `DESTINATION_SECURITY_GROUP`.",
+						},
+						"resource_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ID of the resource evaluated on the hop.",
+						},
+						"action": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Result of the hop. Valid values: `ALLOW`, `DENY`, `NOT_EVALUATED`.",
+						},
+						"rule": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The rule or route which decides the result.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceTencentCloudVpcReachabilityAnalysisRead(d *schema.ResourceData, meta interface{}) error {
+	defer tccommon.LogElapsed("data_source.tencentcloud_vpc_reachability_analysis.read")()
+	defer tccommon.InconsistentCheck(d, meta)()
+
+	var (
+		logId   = tccommon.GetLogId(tccommon.ContextNil)
+		ctx     = context.WithValue(context.TODO(), tccommon.LogIdKey, logId)
+		service = VpcService{client: meta.(tccommon.ProviderMeta).GetAPIV3Conn()}
+		flow    = VpcNetworkFlow{Protocol: d.Get("protocol").(string), Port: int64(d.Get("port").(int))}
+	)
+	if flow.Protocol != "ICMP" && flow.Port == 0 {
+		return fmt.Errorf("`port` is required for protocol %s", flow.Protocol)
+	}
+
+	source, err := describeVpcReachabilityEndpoint(ctx, &service, d, "source")
+	if err != nil {
+		return err
+	}
+	destination, err := describeVpcReachabilityEndpoint(ctx, &service, d, "destination")
+	if err != nil {
+		return err
+	}
+
+	input, err := service.DescribeVpcReachabilityInput(ctx, source, destination, flow)
+	if err != nil {
+		return err
+	}
+	result := EvaluateVpcReachability(input)
+
+	hops := make([]map[string]interface{}, 0, len(result.Hops))
+	for _, hop := range result.Hops {
+		hops = append(hops, map[string]interface{}{
+			"hop":         hop.Hop,
+			"resource_id": hop.ResourceId,
+			"action":      hop.Action,
+			"rule":        hop.Rule,
+		})
+	}
+	_ = d.Set("hops", hops)
+	_ = d.Set("reachable", result.Reachable)
+	_ = d.Set("source_ip", source.Ip)
+	_ = d.Set("destination_ip", destination.Ip)
+	if result.Blocking != nil {
+		_ = d.Set("blocking_hop", result.Blocking.Hop)
+		_ = d.Set("blocking_resource_id", result.Blocking.ResourceId)
+		_ = d.Set("blocking_rule", result.Blocking.Rule)
+	} else {
+		_ = d.Set("blocking_hop", "")
+		_ = d.Set("blocking_resource_id", "")
+		_ = d.Set("blocking_rule", "")
+	}
+
+	d.SetId(helper.DataResourceIdsHash([]string{source.Id, destination.Id, flow.Protocol, strconv.FormatInt(flow.Port, 10)}))
+
+	output, ok := d.GetOk("result_output_file")
+	if ok && output.(string) != "" {
+		if e := tccommon.WriteToFile(output.(string), hops, tccommon.GetResultOutputOption(d)); e != nil {
+			return e
+		}
+	}
+	return nil
+}
+
+func describeVpcReachabilityEndpoint(ctx context.Context, service *VpcService, d *schema.ResourceData, key string) (endpoint *VpcNetworkEndpoint, err error) {
+	config, ok := helper.InterfacesHeadMap(d, key)
+	if !ok {
+		return nil, fmt.Errorf("`%s` must be set", key)
+	}
+	eniId, clbId, ip := config["eni_id"].(string), config["clb_id"].(string), config["ip"].(string)
+
+	set := 0
+	for _, value := range []string{eniId, clbId, ip} {
+		if value != "" {
+			set++
+		}
+	}
+	if set != 1 {
+		return nil, fmt.Errorf("exactly one of `eni_id`, `clb_id` and `ip` must be set in `%s`", key)
+	}
+
+	switch {
+	case eniId != "":
+		endpoint, err = service.DescribeVpcNetworkEndpointByEni(ctx, eniId)
+	case clbId != "":
+		endpoint, err = service.DescribeVpcNetworkEndpointByClb(ctx, clbId)
+	default:
+		if net.ParseIP(ip) == nil {
+			return nil, fmt.Errorf("`%s.ip` %s is not a valid IP address", key, ip)
+		}
+		endpoint = &VpcNetworkEndpoint{
+			Id:       ip,
+			Ip:       ip,
+			VpcId:    config["vpc_id"].(string),
+			SubnetId: config["subnet_id"].(string),
+		}
+		for _, sgId := range config["security_group_ids"].([]interface{}) {
+			endpoint.SecurityGroupIds = append(endpoint.SecurityGroupIds, sgId.(string))
+		}
+		if endpoint.SubnetId != "" && endpoint.VpcId == "" {
+			subnet, e := service.describeVpcReachabilitySubnet(ctx, endpoint.SubnetId)
+			if e != nil {
+				return nil, e
+			}
+			endpoint.VpcId = helper.PString(subnet.VpcId)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if endpoint.Ip == "" {
+		return nil, fmt.Errorf("IP address of `%s` %s is not found", key, endpoint.Id)
+	}
+	return endpoint, nil
+}
@@ -0,0 +1,48 @@
+package vpc_test
+
+import (
+	"testing"
+
+	tcacctest "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/acctest"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccTencentCloudVpcReachabilityAnalysisDataSource_basic(t *testing.T) {
+	t.Parallel()
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			tcacctest.AccPreCheck(t)
+		},
+		Providers: tcacctest.AccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVpcReachabilityAnalysisDataSource,
+				Check: resource.ComposeTestCheckFunc(
+					tcacctest.AccCheckTencentCloudDataSourceID("data.tencentcloud_vpc_reachability_analysis.example"),
+					resource.TestCheckResourceAttr("data.tencentcloud_vpc_reachability_analysis.example", "source_ip", "10.0.20.10"),
+					resource.TestCheckResourceAttrSet("data.tencentcloud_vpc_reachability_analysis.example", "reachable"),
+					resource.TestCheckResourceAttrSet("data.tencentcloud_vpc_reachability_analysis.example", "hops.#"),
+				),
+			},
+		},
+	})
+}
+
+const testAccVpcReachabilityAnalysisDataSource = tcacctest.DefaultVpcVariable + `
+
+data "tencentcloud_vpc_reachability_analysis" "example" {
+  source {
+    ip                 = "10.0.20.10"
+    subnet_id          = var.subnet_id
+    security_group_ids = [var.sg_id]
+  }
+
+  destination {
+    ip = "1.1.1.1"
+  }
+
+  protocol = "TCP"
+  port     = 443
+}
+`
@@ -17,18 +17,28 @@ func DataSourceTencentCloudVpcRouteConflicts() *schema.Resource {
 		Read: dataSourceTencentCloudVpcRouteConflictsRead,
 		Schema: map[string]*schema.Schema{
 			"route_table_id": {
-				Required:    true,
-				Type:        schema.TypeString,
-				Description: "Routing table instance ID, for example:rtb-azd4dt1c.",
+				Optional:     true,
+				Type:         schema.TypeString,
+				ExactlyOneOf: []string{"route_table_id", "vpc_id"},
+				RequiredWith: []string{"destination_cidr_blocks"},
+				Description:  "Routing table instance ID, for example:rtb-azd4dt1c. Only one of `route_table_id` and `vpc_id` can be set.",
 			},
 
 			"destination_cidr_blocks": {
-				Required: true,
+				Optional: true,
 				Type:     schema.TypeSet,
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
-				Description: "List of conflicting destinations to check for.",
+				RequiredWith: []string{"route_table_id"},
+				Description:  "List of conflicting destinations to check for, required with `route_table_id`.",
+			},
+
+			"vpc_id": {
+				Optional:     true,
+				Type:         schema.TypeString,
+				ExactlyOneOf: []string{"route_table_id", "vpc_id"},
+				Description:  "ID of the VPC. If set, the routes of the route tables of the VPC, the CCNs which the VPC is attached to or routes to, and the VPN gateways of the VPC are checked, and the result is in `conflicts`. Only one of `route_table_id` and `vpc_id` can be set.",
 			},
 
 			"route_conflict_set": {
@@ -120,6 +130,61 @@ func DataSourceTencentCloudVpcRouteConflicts() *schema.Resource {
 				},
 			},
 
+			"conflicts": {
+				Computed:    true,
+				Type:        schema.TypeList,
+				Description: "Conflicts of the routes in the VPC, only set with `vpc_id`.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Type of the conflict. Valid values: `EQUAL` (the routes go to the same destination with the same priority), `OVERLAP` (the route is overridden by a more specific route partially), `INEFFECTIVE` (the route cannot be enabled, or its traffic is not routed by the CCN).",
+						},
+						"route_table_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ID of the route table, the CCN or the VPN gateway of the route.",
+						},
+						"route_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ID of the route.",
+						},
+						"destination_cidr_block": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Destination of the route.",
+						},
+						"gateway_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Type of the next hop of the route.",
+						},
+						"gateway_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ID of the next hop of the route.",
+						},
+						"conflict_route_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ID of the route conflicting with the route, empty if the traffic of the route is not routed by the CCN.",
+						},
+						"conflict_destination_cidr_block": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Destination of the route conflicting with the route, empty if the traffic of the route is not routed by the CCN.",
+						},
+						"message": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Description of the conflict.",
+						},
+					},
+				},
+			},
+
 			"result_output_file": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -137,6 +202,10 @@ func dataSourceTencentCloudVpcRouteConflictsRead(d *schema.ResourceData, meta in
 
 	ctx := context.WithValue(context.TODO(), tccommon.LogIdKey, logId)
 
+	if v, ok := d.GetOk("vpc_id"); ok {
+		return dataSourceTencentCloudVpcRouteConflictsReadByVpcId(ctx, d, meta, v.(string))
+	}
+
 	paramMap := make(map[string]interface{})
 	if v, ok := d.GetOk("route_table_id"); ok {
 		paramMap["RouteTableId"] = helper.String(v.(string))
@@ -247,7 +316,44 @@ func dataSourceTencentCloudVpcRouteConflictsRead(d *schema.ResourceData, meta in
 	d.SetId(helper.DataResourceIdsHash(ids))
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), tmpList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), tmpList, tccommon.GetResultOutputOption(d)); e != nil {
+			return e
+		}
+	}
+	return nil
+}
+
+func dataSourceTencentCloudVpcRouteConflictsReadByVpcId(ctx context.Context, d *schema.ResourceData, meta interface{}, vpcId string) error {
+	service := VpcService{client: meta.(tccommon.ProviderMeta).GetAPIV3Conn()}
+
+	conflicts, err := service.DescribeVpcRouteConflictsByVpcId(ctx, vpcId)
+	if err != nil {
+		return err
+	}
+
+	tmpList := make([]map[string]interface{}, 0, len(conflicts))
+	for _, conflict := range conflicts {
+		conflictMap := map[string]interface{}{
+			"type":                   conflict.Type,
+			"route_table_id":         conflict.Route.RouteTableId,
+			"route_id":               conflict.Route.RouteId,
+			"destination_cidr_block": conflict.Route.DestinationCidr,
+			"gateway_type":           conflict.Route.GatewayType,
+			"gateway_id":             conflict.Route.GatewayId,
+			"message":                conflict.Message,
+		}
+		if conflict.ConflictRoute != nil {
+			conflictMap["conflict_route_id"] = conflict.ConflictRoute.RouteId
+			conflictMap["conflict_destination_cidr_block"] = conflict.ConflictRoute.DestinationCidr
+		}
+		tmpList = append(tmpList, conflictMap)
+	}
+	_ = d.Set("conflicts", tmpList)
+
+	d.SetId(helper.DataResourceIdsHash([]string{vpcId}))
+	output, ok := d.GetOk("result_output_file")
+	if ok && output.(string) != "" {
+		if e := tccommon.WriteToFile(output.(string), tmpList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
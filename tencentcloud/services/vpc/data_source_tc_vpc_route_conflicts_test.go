@@ -20,6 +20,13 @@ func TestAccTencentCloudVpcRouteConflictsDataSource_basic(t *testing.T) {
 				Config: testAccVpcRouteConflictsDataSource,
 				Check:  resource.ComposeTestCheckFunc(tcacctest.AccCheckTencentCloudDataSourceID("data.tencentcloud_vpc_route_conflicts.route_conflicts")),
 			},
+			{
+				Config: testAccVpcRouteConflictsDataSourceByVpcId,
+				Check: resource.ComposeTestCheckFunc(
+					tcacctest.AccCheckTencentCloudDataSourceID("data.tencentcloud_vpc_route_conflicts.route_conflicts"),
+					resource.TestCheckResourceAttrSet("data.tencentcloud_vpc_route_conflicts.route_conflicts", "conflicts.#"),
+				),
+			},
 		},
 	})
 }
@@ -32,3 +39,11 @@ data "tencentcloud_vpc_route_conflicts" "route_conflicts" {
 }
 
 `
+
+const testAccVpcRouteConflictsDataSourceByVpcId = `
+
+data "tencentcloud_vpc_route_conflicts" "route_conflicts" {
+  vpc_id = "vpc-86v957zb"
+}
+
+`
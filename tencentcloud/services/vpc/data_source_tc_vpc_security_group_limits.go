@@ -143,7 +143,7 @@ func dataSourceTencentCloudVpcSecurityGroupLimitsRead(d *schema.ResourceData, me
 	d.SetId(helper.DataResourceIdsHash(ids))
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), securityGroupLimitSetMap); e != nil {
+		if e := tccommon.WriteToFile(output.(string), securityGroupLimitSetMap, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
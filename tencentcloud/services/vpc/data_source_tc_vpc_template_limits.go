@@ -107,7 +107,7 @@ func dataSourceTencentCloudVpcTemplateLimitsRead(d *schema.ResourceData, meta in
 	d.SetId(helper.DataResourceIdsHash(ids))
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), templateLimitMap); e != nil {
+		if e := tccommon.WriteToFile(output.(string), templateLimitMap, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
@@ -3,6 +3,7 @@ package vpc
 import (
 	"context"
 	"fmt"
+	"log"
 	"strconv"
 	"strings"
 
@@ -26,6 +27,7 @@ func ResourceTencentCloudVpcRouteEntry() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: vpcRouteEntryCustomizeDiff,
 
 		Schema: map[string]*schema.Schema{
 			"route_table_id": {
@@ -71,10 +73,62 @@ func ResourceTencentCloudVpcRouteEntry() *schema.Resource {
 				Computed:    true,
 				Description: "ID of route table entry.",
 			},
+			"fail_on_route_conflict": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to fail the plan when the entry conflicts with the routes of the route table, default is `false`.",
+			},
+			"route_conflicts": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Conflicts of the entry with the routes of the route table found at plan time.",
+			},
 		},
 	}
 }
 
+// vpcRouteEntryCustomizeDiff checks the new entry against the routes of the route table at plan time. The conflicts
+// are shown in `route_conflicts` and only fail the plan with `fail_on_route_conflict`, since a route to the same
+// destination may be deleted in the same apply, e.g. when the entry is renamed.
+func vpcRouteEntryCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() != "" && !d.HasChange("route_table_id") && !d.HasChange("destination_cidr_block") {
+		return nil
+	}
+	if !d.NewValueKnown("route_table_id") || !d.NewValueKnown("destination_cidr_block") {
+		return d.SetNewComputed("route_conflicts")
+	}
+
+	logId := tccommon.GetLogId(tccommon.ContextNil)
+	ctx = context.WithValue(ctx, tccommon.LogIdKey, logId)
+	service := VpcService{client: meta.(tccommon.ProviderMeta).GetAPIV3Conn()}
+
+	route := &VpcRoute{
+		RouteTableId:    d.Get("route_table_id").(string),
+		DestinationCidr: d.Get("destination_cidr_block").(string),
+		GatewayType:     d.Get("next_type").(string),
+		GatewayId:       d.Get("next_hub").(string),
+		Enabled:         true,
+	}
+	if d.Id() != "" {
+		// the entry is replaced, it is deleted before the new one is created
+		oldRouteItemId, _ := d.GetChange("route_item_id")
+		route.RouteId = oldRouteItemId.(string)
+	}
+
+	existing, err := service.DescribeVpcRouteTableRoutes(ctx, route.RouteTableId)
+	if err != nil {
+		return err
+	}
+	var conflicts []string
+	for _, conflict := range CheckVpcRouteConflicts(route, existing) {
+		log.Printf("[WARN]%s route conflict: %s\n", logId, conflict.Message)
+		conflicts = append(conflicts, conflict.Message)
+	}
+	return tccommon.SetRouteConflicts(d, conflicts)
+}
+
 func resourceTencentCloudVpcRouteEntryCreate(d *schema.ResourceData, meta interface{}) error {
 	defer tccommon.LogElapsed("resource.tencentcloud_route_table_entry.create")()
 
@@ -128,7 +182,12 @@ func resourceTencentCloudVpcRouteEntryCreate(d *schema.ResourceData, meta interf
 	// route cannot disable on create
 	entryId, err := service.CreateRoutes(ctx, routeTableId, destinationCidrBlock, nextType, nextHub, description, true)
 	if err != nil {
-		return err
+		existing, e := service.DescribeVpcRouteTableRoutes(ctx, routeTableId)
+		if e != nil {
+			return err
+		}
+		route := &VpcRoute{RouteTableId: routeTableId, DestinationCidr: destinationCidrBlock, GatewayType: nextType, GatewayId: nextHub, Enabled: true}
+		return VpcRouteConflictsError(err, route, existing)
 	}
 
 	d.SetId(fmt.Sprintf("%d.%s", entryId, routeTableId))
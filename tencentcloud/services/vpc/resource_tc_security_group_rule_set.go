@@ -90,6 +90,7 @@ func ResourceTencentCloudSecurityGroupRuleSet() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: securityGroupRuleSetCustomizeDiff,
 		Schema: map[string]*schema.Schema{
 			"security_group_id": {
 				Type:        schema.TypeString,
@@ -114,10 +115,67 @@ func ResourceTencentCloudSecurityGroupRuleSet() *schema.Resource {
 				Computed:    true,
 				Description: "Security policies version, auto increment for every update.",
 			},
+			"guardrail_violations": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Violations of the provider `security_group_guardrails` found at plan time, only set in `warn` mode.",
+			},
 		},
 	}
 }
 
+// securityGroupRuleSetCustomizeDiff checks the planned rules against the provider `security_group_guardrails`,
+// the violations fail the plan in `fail` mode and are only shown in `guardrail_violations` in `warn` mode.
+func securityGroupRuleSetCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	var guardrails *tccommon.SecurityGroupGuardrails
+	if m, ok := meta.(tccommon.SecurityGroupGuardrailsMeta); ok {
+		guardrails = m.GetSecurityGroupGuardrails()
+	}
+	if guardrails == nil {
+		if len(d.Get("guardrail_violations").([]interface{})) > 0 {
+			return d.SetNew("guardrail_violations", []string{})
+		}
+		return nil
+	}
+	if !d.NewValueKnown("security_group_id") || !d.NewValueKnown("ingress") || !d.NewValueKnown("egress") {
+		return d.SetNewComputed("guardrail_violations")
+	}
+
+	logId := tccommon.GetLogId(tccommon.ContextNil)
+	ctx = context.WithValue(ctx, tccommon.LogIdKey, logId)
+	service := VpcService{client: meta.(tccommon.ProviderMeta).GetAPIV3Conn()}
+	resolver := newVpcTemplateResolver(&service)
+	sgId := d.Get("security_group_id").(string)
+
+	var violations []string
+	for _, direction := range []string{VPC_NETWORK_DIRECTION_INGRESS, VPC_NETWORK_DIRECTION_EGRESS} {
+		policies, err := unmarshalSecurityPolicy(d.Get(direction).([]interface{}))
+		if err != nil {
+			return err
+		}
+		for i, policy := range policies {
+			policy.PolicyIndex = helper.IntInt64(i)
+		}
+		rules, err := resolver.securityGroupRules(ctx, sgId, direction, policies)
+		if err != nil {
+			return err
+		}
+		violations = append(violations, SecurityGroupGuardrailViolations(guardrails, LintSecurityGroupRules(rules))...)
+	}
+
+	if len(violations) > 0 && guardrails.Mode == tccommon.SECURITY_GROUP_GUARDRAILS_MODE_FAIL {
+		return fmt.Errorf("security group rules of %s violate the guardrails:\n  %s", sgId, strings.Join(violations, "\n  "))
+	}
+	for _, violation := range violations {
+		log.Printf("[WARN]%s security group guardrail violation: %s\n", logId, violation)
+	}
+	if old := helper.InterfacesStrings(d.Get("guardrail_violations").([]interface{})); strings.Join(old, "\n") != strings.Join(violations, "\n") {
+		return d.SetNew("guardrail_violations", violations)
+	}
+	return nil
+}
+
 func resourceTencentCloudSecurityGroupRuleSetCreate(d *schema.ResourceData, m interface{}) error {
 	defer tccommon.LogElapsed("resource.tencentcloud_security_group_rule_set.create")()
 
@@ -0,0 +1,184 @@
+package vpc
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"strings"
+
+	tccommon "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func ResourceTencentCloudVpcIpamAllocation() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceTencentCloudVpcIpamAllocationCreate,
+		Read:   resourceTencentCloudVpcIpamAllocationRead,
+		Delete: resourceTencentCloudVpcIpamAllocationDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		CustomizeDiff: vpcIpamAllocationCustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			"pool_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the ipam pool.",
+			},
+			"prefix_length": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"prefix_length", "cidr_block"},
+				ValidateFunc: tccommon.ValidateIntegerInRange(1, 32),
+				Description:  "Prefix length of the cidr to allocate, the lowest free cidr of the length in the pool is allocated. The pool is checked to have a free cidr of the length at plan time. Conflicts with `cidr_block`.",
+			},
+			"cidr_block": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: tccommon.ValidateCIDRNetworkAddress,
+				Description:  "Cidr to allocate, which must be in the pool and must not overlap the used and allocated cidrs. It is checked at plan time. Conflicts with `prefix_length`.",
+			},
+		},
+	}
+}
+
+// vpcIpamAllocationCustomizeDiff checks a specified cidr against the pool at plan time, and checks the pool has a free cidr
+// of a prefix length. The cidr of a prefix length is chosen at apply time since other allocations may change in the meantime.
+func vpcIpamAllocationCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() != "" || !d.NewValueKnown("pool_id") {
+		return nil
+	}
+
+	var (
+		cidrBlock    string
+		prefixLength int
+	)
+	if d.NewValueKnown("cidr_block") {
+		cidrBlock = d.Get("cidr_block").(string)
+	}
+	if d.NewValueKnown("prefix_length") {
+		prefixLength = d.Get("prefix_length").(int)
+	}
+	if cidrBlock == "" && prefixLength == 0 {
+		return nil
+	}
+
+	logId := tccommon.GetLogId(tccommon.ContextNil)
+	ctx = context.WithValue(ctx, tccommon.LogIdKey, logId)
+	service := VpcService{client: meta.(tccommon.ProviderMeta).GetAPIV3Conn()}
+
+	poolId := d.Get("pool_id").(string)
+	pool, err := service.DescribeVpcIpamPoolByName(ctx, poolId)
+	if err != nil {
+		return err
+	}
+	if pool == nil {
+		// the pool is created in the same apply, the cidr is checked at apply time
+		return nil
+	}
+
+	used, err := service.DescribeVpcIpamPoolUsage(ctx, pool)
+	if err != nil {
+		return err
+	}
+
+	if cidrBlock != "" {
+		return CheckVpcIpamCidr(pool, cidrBlock, used)
+	}
+	_, err = NextVpcIpamCidr(pool, prefixLength, used)
+	return err
+}
+
+func resourceTencentCloudVpcIpamAllocationCreate(d *schema.ResourceData, meta interface{}) error {
+	defer tccommon.LogElapsed("resource.tencentcloud_vpc_ipam_allocation.create")()
+
+	logId := tccommon.GetLogId(tccommon.ContextNil)
+	ctx := context.WithValue(context.TODO(), tccommon.LogIdKey, logId)
+	service := VpcService{client: meta.(tccommon.ProviderMeta).GetAPIV3Conn()}
+
+	poolId := d.Get("pool_id").(string)
+	pool, err := service.DescribeVpcIpamPoolByName(ctx, poolId)
+	if err != nil {
+		return err
+	}
+	if pool == nil {
+		return fmt.Errorf("ipam pool `%s` not found", poolId)
+	}
+
+	cidrBlock, err := service.AllocateVpcIpamCidr(ctx, pool, d.Get("prefix_length").(int), d.Get("cidr_block").(string))
+	if err != nil {
+		log.Printf("[CRITAL]%s allocate cidr from ipam pool failed, reason:%+v", logId, err)
+		return err
+	}
+
+	d.SetId(poolId + tccommon.FILED_SP + cidrBlock)
+	return resourceTencentCloudVpcIpamAllocationRead(d, meta)
+}
+
+func resourceTencentCloudVpcIpamAllocationRead(d *schema.ResourceData, meta interface{}) error {
+	defer tccommon.LogElapsed("resource.tencentcloud_vpc_ipam_allocation.read")()
+	defer tccommon.InconsistentCheck(d, meta)()
+
+	logId := tccommon.GetLogId(tccommon.ContextNil)
+	ctx := context.WithValue(context.TODO(), tccommon.LogIdKey, logId)
+	service := VpcService{client: meta.(tccommon.ProviderMeta).GetAPIV3Conn()}
+
+	idSplit := strings.Split(d.Id(), tccommon.FILED_SP)
+	if len(idSplit) != 2 {
+		return fmt.Errorf("id is broken,%s", d.Id())
+	}
+	poolId := idSplit[0]
+	cidrBlock := idSplit[1]
+
+	allocations, err := service.DescribeVpcIpamAllocations(ctx, poolId)
+	if err != nil {
+		return err
+	}
+
+	found := false
+	for _, allocation := range allocations {
+		if allocation == cidrBlock {
+			found = true
+			break
+		}
+	}
+	if !found {
+		d.SetId("")
+		log.Printf("[WARN]%s resource `tencentcloud_vpc_ipam_allocation` [%s] not found, please check if it has been deleted.", logId, d.Id())
+		return nil
+	}
+
+	_, ipNet, err := net.ParseCIDR(cidrBlock)
+	if err != nil {
+		return err
+	}
+	prefixLength, _ := ipNet.Mask.Size()
+
+	_ = d.Set("pool_id", poolId)
+	_ = d.Set("cidr_block", cidrBlock)
+	_ = d.Set("prefix_length", prefixLength)
+
+	return nil
+}
+
+func resourceTencentCloudVpcIpamAllocationDelete(d *schema.ResourceData, meta interface{}) error {
+	defer tccommon.LogElapsed("resource.tencentcloud_vpc_ipam_allocation.delete")()
+
+	logId := tccommon.GetLogId(tccommon.ContextNil)
+	ctx := context.WithValue(context.TODO(), tccommon.LogIdKey, logId)
+	service := VpcService{client: meta.(tccommon.ProviderMeta).GetAPIV3Conn()}
+
+	idSplit := strings.Split(d.Id(), tccommon.FILED_SP)
+	if len(idSplit) != 2 {
+		return fmt.Errorf("id is broken,%s", d.Id())
+	}
+
+	return service.ReleaseVpcIpamCidr(ctx, idSplit[0], idSplit[1])
+}
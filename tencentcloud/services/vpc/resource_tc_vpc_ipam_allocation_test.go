@@ -0,0 +1,72 @@
+package vpc_test
+
+import (
+	"regexp"
+	"testing"
+
+	tcacctest "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/acctest"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// go test -i; go test -test.run TestAccTencentCloudVpcIpamAllocationResource_basic -v
+func TestAccTencentCloudVpcIpamAllocationResource_basic(t *testing.T) {
+	t.Parallel()
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { tcacctest.AccPreCheck(t) },
+		Providers: tcacctest.AccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVpcIpamAllocation,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tencentcloud_vpc_ipam_allocation.specified", "cidr_block", "10.200.0.0/16"),
+					resource.TestCheckResourceAttr("tencentcloud_vpc_ipam_allocation.specified", "prefix_length", "16"),
+					resource.TestCheckResourceAttrSet("tencentcloud_vpc_ipam_allocation.next", "cidr_block"),
+					resource.TestCheckResourceAttr("tencentcloud_vpc_ipam_allocation.next", "prefix_length", "20"),
+					resource.TestCheckResourceAttrPair("tencentcloud_vpc.example", "cidr_block", "tencentcloud_vpc_ipam_allocation.next", "cidr_block"),
+				),
+			},
+			{
+				ResourceName:      "tencentcloud_vpc_ipam_allocation.specified",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config:      testAccVpcIpamAllocation + testAccVpcIpamAllocationOverlap,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("overlaps"),
+			},
+		},
+	})
+}
+
+const testAccVpcIpamAllocation = `
+resource "tencentcloud_vpc_ipam_pool" "example" {
+  name        = "tf-example-ipam-allocation"
+  cidr_blocks = ["10.0.0.0/8"]
+}
+
+resource "tencentcloud_vpc_ipam_allocation" "specified" {
+  pool_id    = tencentcloud_vpc_ipam_pool.example.id
+  cidr_block = "10.200.0.0/16"
+}
+
+resource "tencentcloud_vpc_ipam_allocation" "next" {
+  pool_id       = tencentcloud_vpc_ipam_pool.example.id
+  prefix_length = 20
+
+  depends_on = [tencentcloud_vpc_ipam_allocation.specified]
+}
+
+resource "tencentcloud_vpc" "example" {
+  name       = "tf-example-ipam"
+  cidr_block = tencentcloud_vpc_ipam_allocation.next.cidr_block
+}
+`
+
+const testAccVpcIpamAllocationOverlap = `
+resource "tencentcloud_vpc_ipam_allocation" "overlap" {
+  pool_id    = tencentcloud_vpc_ipam_pool.example.id
+  cidr_block = "10.200.128.0/17"
+}
+`
@@ -0,0 +1,312 @@
+package vpc
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"regexp"
+
+	tccommon "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/internal/helper"
+)
+
+func ResourceTencentCloudVpcIpamPool() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceTencentCloudVpcIpamPoolCreate,
+		Read:   resourceTencentCloudVpcIpamPoolRead,
+		Update: resourceTencentCloudVpcIpamPoolUpdate,
+		Delete: resourceTencentCloudVpcIpamPoolDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		CustomizeDiff: vpcIpamPoolCustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[a-zA-Z0-9_.-]{1,64}$`), "name must be 1 to 64 letters, digits, `_`, `.` or `-`"),
+				Description:  "Name of the pool, which is unique in the account. It is also the ID of the pool.",
+			},
+			"cidr_blocks": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: tccommon.ValidateCIDRNetworkAddress},
+				Description: "IPv4 supernets to allocate cidrs from, such as `10.0.0.0/8`. They must not overlap each other. A cidr block can not be removed while it has allocations.",
+			},
+			"reserved_cidr_blocks": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: tccommon.ValidateCIDRNetworkAddress},
+				Description: "Cidrs in the pool which are never allocated, such as the ranges used by IDC or other clouds.",
+			},
+			"regions": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Regions whose VPC and subnet cidrs are regarded as used. Defaults to the region of the provider.",
+			},
+			"ccn_ids": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "IDs of the CCN whose attached instance cidrs are regarded as used.",
+			},
+			"allocated_cidr_blocks": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Cidrs allocated from the pool by `tencentcloud_vpc_ipam_allocation`.",
+			},
+			"used_cidr_blocks": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Existing cidrs in the pool which are not allocated by the pool, including the reserved cidrs.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"cidr_block": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Cidr block.",
+						},
+						"resource_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Type of the resource using the cidr, valid values: `vpc`, `subnet`, `ccn_instance` and `reserved`.",
+						},
+						"resource_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ID of the resource using the cidr.",
+						},
+						"region": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Region of the resource using the cidr.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func vpcIpamPoolCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("cidr_blocks") {
+		return nil
+	}
+
+	blocks := make([]*net.IPNet, 0)
+	for _, item := range d.Get("cidr_blocks").(*schema.Set).List() {
+		_, ipNet, err := net.ParseCIDR(item.(string))
+		if err != nil {
+			return err
+		}
+		if ipNet.IP.To4() == nil {
+			return fmt.Errorf("cidr block %s of ipam pool is not an IPv4 cidr", item.(string))
+		}
+		for _, block := range blocks {
+			if helper.CidrOverlaps(block, ipNet) {
+				return fmt.Errorf("cidr block %s of ipam pool overlaps %s", ipNet.String(), block.String())
+			}
+		}
+		blocks = append(blocks, ipNet)
+	}
+	return nil
+}
+
+func resourceTencentCloudVpcIpamPoolCreate(d *schema.ResourceData, meta interface{}) error {
+	defer tccommon.LogElapsed("resource.tencentcloud_vpc_ipam_pool.create")()
+
+	logId := tccommon.GetLogId(tccommon.ContextNil)
+	ctx := context.WithValue(context.TODO(), tccommon.LogIdKey, logId)
+	service := VpcService{client: meta.(tccommon.ProviderMeta).GetAPIV3Conn()}
+
+	name := d.Get("name").(string)
+	pool, err := service.DescribeVpcIpamPoolByName(ctx, name)
+	if err != nil {
+		return err
+	}
+	if pool != nil {
+		return fmt.Errorf("ipam pool `%s` already exists, please import it", name)
+	}
+
+	regions := helper.InterfacesStrings(d.Get("regions").(*schema.Set).List())
+	if len(regions) == 0 {
+		regions = []string{meta.(tccommon.ProviderMeta).GetAPIV3Conn().Region}
+	}
+
+	// the pool exists once it has cidr blocks, so they are saved last
+	entries := []struct {
+		kind   string
+		values []string
+	}{
+		{VPC_IPAM_POOL_ENTRY_REGION, regions},
+		{VPC_IPAM_POOL_ENTRY_CCN, helper.InterfacesStrings(d.Get("ccn_ids").(*schema.Set).List())},
+		{VPC_IPAM_POOL_ENTRY_RESERVED, helper.InterfacesStrings(d.Get("reserved_cidr_blocks").(*schema.Set).List())},
+		{VPC_IPAM_POOL_ENTRY_CIDR, helper.InterfacesStrings(d.Get("cidr_blocks").(*schema.Set).List())},
+	}
+	for _, entry := range entries {
+		if err := service.ModifyVpcIpamPoolEntries(ctx, name, entry.kind, entry.values, nil); err != nil {
+			log.Printf("[CRITAL]%s create ipam pool failed, reason:%+v", logId, err)
+			return err
+		}
+	}
+
+	d.SetId(name)
+	return resourceTencentCloudVpcIpamPoolRead(d, meta)
+}
+
+func resourceTencentCloudVpcIpamPoolRead(d *schema.ResourceData, meta interface{}) error {
+	defer tccommon.LogElapsed("resource.tencentcloud_vpc_ipam_pool.read")()
+	defer tccommon.InconsistentCheck(d, meta)()
+
+	logId := tccommon.GetLogId(tccommon.ContextNil)
+	ctx := context.WithValue(context.TODO(), tccommon.LogIdKey, logId)
+	service := VpcService{client: meta.(tccommon.ProviderMeta).GetAPIV3Conn()}
+
+	pool, err := service.DescribeVpcIpamPoolByName(ctx, d.Id())
+	if err != nil {
+		return err
+	}
+	if pool == nil {
+		d.SetId("")
+		log.Printf("[WARN]%s resource `tencentcloud_vpc_ipam_pool` [%s] not found, please check if it has been deleted.", logId, d.Id())
+		return nil
+	}
+
+	_ = d.Set("name", pool.Name)
+	_ = d.Set("cidr_blocks", pool.CidrBlocks)
+	_ = d.Set("reserved_cidr_blocks", pool.ReservedCidrBlocks)
+	_ = d.Set("regions", pool.Regions)
+	_ = d.Set("ccn_ids", pool.CcnIds)
+
+	allocations, err := service.DescribeVpcIpamAllocations(ctx, pool.Name)
+	if err != nil {
+		return err
+	}
+	_ = d.Set("allocated_cidr_blocks", allocations)
+
+	used, err := service.DescribeVpcIpamUsedCidrs(ctx, pool)
+	if err != nil {
+		return err
+	}
+	usedList := make([]map[string]interface{}, 0, len(used))
+	for _, item := range used {
+		usedList = append(usedList, map[string]interface{}{
+			"cidr_block":    item.CidrBlock,
+			"resource_type": item.ResourceType,
+			"resource_id":   item.ResourceId,
+			"region":        item.Region,
+		})
+	}
+	_ = d.Set("used_cidr_blocks", usedList)
+
+	return nil
+}
+
+func resourceTencentCloudVpcIpamPoolUpdate(d *schema.ResourceData, meta interface{}) error {
+	defer tccommon.LogElapsed("resource.tencentcloud_vpc_ipam_pool.update")()
+
+	logId := tccommon.GetLogId(tccommon.ContextNil)
+	ctx := context.WithValue(context.TODO(), tccommon.LogIdKey, logId)
+	service := VpcService{client: meta.(tccommon.ProviderMeta).GetAPIV3Conn()}
+
+	name := d.Id()
+
+	if d.HasChange("cidr_blocks") {
+		o, n := d.GetChange("cidr_blocks")
+		add := helper.InterfacesStrings(n.(*schema.Set).Difference(o.(*schema.Set)).List())
+		remove := helper.InterfacesStrings(o.(*schema.Set).Difference(n.(*schema.Set)).List())
+
+		if len(remove) > 0 {
+			allocations, err := service.DescribeVpcIpamAllocations(ctx, name)
+			if err != nil {
+				return err
+			}
+			for _, block := range remove {
+				_, blockNet, err := net.ParseCIDR(block)
+				if err != nil {
+					return err
+				}
+				for _, allocation := range allocations {
+					if _, allocationNet, e := net.ParseCIDR(allocation); e == nil && helper.CidrContains(blockNet, allocationNet) {
+						return fmt.Errorf("cidr block %s of ipam pool `%s` can not be removed, it has allocation %s", block, name, allocation)
+					}
+				}
+			}
+		}
+
+		if err := service.ModifyVpcIpamPoolEntries(ctx, name, VPC_IPAM_POOL_ENTRY_CIDR, add, remove); err != nil {
+			return err
+		}
+	}
+
+	for key, kind := range map[string]string{
+		"reserved_cidr_blocks": VPC_IPAM_POOL_ENTRY_RESERVED,
+		"regions":              VPC_IPAM_POOL_ENTRY_REGION,
+		"ccn_ids":              VPC_IPAM_POOL_ENTRY_CCN,
+	} {
+		if !d.HasChange(key) {
+			continue
+		}
+		o, n := d.GetChange(key)
+		add := helper.InterfacesStrings(n.(*schema.Set).Difference(o.(*schema.Set)).List())
+		remove := helper.InterfacesStrings(o.(*schema.Set).Difference(n.(*schema.Set)).List())
+		if err := service.ModifyVpcIpamPoolEntries(ctx, name, kind, add, remove); err != nil {
+			return err
+		}
+	}
+
+	return resourceTencentCloudVpcIpamPoolRead(d, meta)
+}
+
+func resourceTencentCloudVpcIpamPoolDelete(d *schema.ResourceData, meta interface{}) error {
+	defer tccommon.LogElapsed("resource.tencentcloud_vpc_ipam_pool.delete")()
+
+	logId := tccommon.GetLogId(tccommon.ContextNil)
+	ctx := context.WithValue(context.TODO(), tccommon.LogIdKey, logId)
+	service := VpcService{client: meta.(tccommon.ProviderMeta).GetAPIV3Conn()}
+
+	name := d.Id()
+	allocations, err := service.DescribeVpcIpamAllocations(ctx, name)
+	if err != nil {
+		return err
+	}
+	if len(allocations) > 0 {
+		return fmt.Errorf("ipam pool `%s` can not be deleted, it still has allocations %v", name, allocations)
+	}
+
+	pool, err := service.DescribeVpcIpamPoolByName(ctx, name)
+	if err != nil {
+		return err
+	}
+	if pool == nil {
+		return nil
+	}
+
+	// the cidr blocks are removed first, so a partially deleted pool is regarded as deleted
+	entries := []struct {
+		kind   string
+		values []string
+	}{
+		{VPC_IPAM_POOL_ENTRY_CIDR, pool.CidrBlocks},
+		{VPC_IPAM_POOL_ENTRY_RESERVED, pool.ReservedCidrBlocks},
+		{VPC_IPAM_POOL_ENTRY_REGION, pool.Regions},
+		{VPC_IPAM_POOL_ENTRY_CCN, pool.CcnIds},
+	}
+	for _, entry := range entries {
+		if err := service.ModifyVpcIpamPoolEntries(ctx, name, entry.kind, nil, entry.values); err != nil {
+			log.Printf("[CRITAL]%s delete ipam pool failed, reason:%+v", logId, err)
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package vpc_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	tcacctest "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/acctest"
+	tccommon "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/common"
+	svcvpc "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/services/vpc"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// go test -i; go test -test.run TestAccTencentCloudVpcIpamPoolResource_basic -v
+func TestAccTencentCloudVpcIpamPoolResource_basic(t *testing.T) {
+	t.Parallel()
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { tcacctest.AccPreCheck(t) },
+		Providers:    tcacctest.AccProviders,
+		CheckDestroy: testAccCheckVpcIpamPoolDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVpcIpamPool,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tencentcloud_vpc_ipam_pool.example", "id", "tf-example-ipam"),
+					resource.TestCheckResourceAttr("tencentcloud_vpc_ipam_pool.example", "cidr_blocks.#", "1"),
+					resource.TestCheckResourceAttr("tencentcloud_vpc_ipam_pool.example", "reserved_cidr_blocks.#", "1"),
+					resource.TestCheckResourceAttr("tencentcloud_vpc_ipam_pool.example", "regions.#", "1"),
+				),
+			},
+			{
+				Config: testAccVpcIpamPoolUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tencentcloud_vpc_ipam_pool.example", "cidr_blocks.#", "2"),
+					resource.TestCheckResourceAttr("tencentcloud_vpc_ipam_pool.example", "reserved_cidr_blocks.#", "0"),
+				),
+			},
+			{
+				ResourceName:      "tencentcloud_vpc_ipam_pool.example",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckVpcIpamPoolDestroy(s *terraform.State) error {
+	logId := tccommon.GetLogId(tccommon.ContextNil)
+	ctx := context.WithValue(context.TODO(), tccommon.LogIdKey, logId)
+	service := svcvpc.NewVpcService(tcacctest.AccProvider.Meta().(tccommon.ProviderMeta).GetAPIV3Conn())
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "tencentcloud_vpc_ipam_pool" {
+			continue
+		}
+		pool, err := service.DescribeVpcIpamPoolByName(ctx, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if pool != nil {
+			return fmt.Errorf("vpc ipam pool %s still exists", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+const testAccVpcIpamPool = `
+resource "tencentcloud_vpc_ipam_pool" "example" {
+  name                 = "tf-example-ipam"
+  cidr_blocks          = ["10.0.0.0/8"]
+  reserved_cidr_blocks = ["10.255.0.0/16"]
+}
+`
+
+const testAccVpcIpamPoolUpdate = `
+resource "tencentcloud_vpc_ipam_pool" "example" {
+  name        = "tf-example-ipam"
+  cidr_blocks = ["10.0.0.0/8", "172.16.0.0/12"]
+}
+`
@@ -346,99 +346,93 @@ func (me *VpcService) DescribeVpcs(ctx context.Context,
 	isDefaultPtr *bool,
 	tagKey string,
 	cidrBlock string) (infos []VpcBasicInfo, errRet error) {
-	logId := tccommon.GetLogId(ctx)
-	request := vpc.NewDescribeVpcsRequest()
-	defer func() {
-		if errRet != nil {
-			log.Printf("[CRITAL]%s api[%s] fail, request body [%s], reason[%s]\n",
-				logId, request.GetAction(), request.ToJsonString(), errRet.Error())
-		}
-	}()
-
-	infos = make([]VpcBasicInfo, 0, 100)
-
-	var (
-		offset  = 0
-		limit   = 100
-		total   = -1
-		hasVpc  = map[string]bool{}
-		filters []*vpc.Filter
-	)
+	var filters []tccommon.DataSourceFilter
 
 	if vpcId != "" {
-		filters = me.fillFilter(filters, "vpc-id", vpcId)
+		filters = append(filters, tccommon.DataSourceFilter{Name: "vpc-id", Values: []string{vpcId}})
 	}
 
 	if name != "" {
-		filters = me.fillFilter(filters, "vpc-name", name)
+		filters = append(filters, tccommon.DataSourceFilter{Name: "vpc-name", Values: []string{name}})
 	}
 
 	if tagKey != "" {
-		filters = me.fillFilter(filters, "tag-key", tagKey)
+		filters = append(filters, tccommon.DataSourceFilter{Name: "tag-key", Values: []string{tagKey}})
 	}
 
 	if cidrBlock != "" {
-		filters = me.fillFilter(filters, "cidr-block", cidrBlock)
+		filters = append(filters, tccommon.DataSourceFilter{Name: "cidr-block", Values: []string{cidrBlock}})
 	}
 
 	if isDefaultPtr != nil {
-		filters = me.fillFilter(filters, "is-default", map[bool]string{true: "true", false: "false"}[*isDefaultPtr])
+		filters = append(filters, tccommon.DataSourceFilter{Name: "is-default", Values: []string{map[bool]string{true: "true", false: "false"}[*isDefaultPtr]}})
 	}
 
 	for k, v := range tags {
-		filters = me.fillFilter(filters, "tag:"+k, v)
+		filters = append(filters, tccommon.DataSourceFilter{Name: "tag:" + k, Values: []string{v}})
 	}
 
-	if len(filters) > 0 {
-		request.Filters = filters
-	}
-
-getMoreData:
+	return me.DescribeVpcsByFilters(ctx, vpcId, filters)
+}
 
-	if total >= 0 {
-		if offset >= total {
-			return
+// DescribeVpcsByFilters describes all the vpcs matching the filters, vpcId is only used to route the request.
+func (me *VpcService) DescribeVpcsByFilters(ctx context.Context, vpcId string, filters []tccommon.DataSourceFilter) (infos []VpcBasicInfo, errRet error) {
+	logId := tccommon.GetLogId(ctx)
+	request := vpc.NewDescribeVpcsRequest()
+	defer func() {
+		if errRet != nil {
+			log.Printf("[CRITAL]%s api[%s] fail, request body [%s], reason[%s]\n",
+				logId, request.GetAction(), request.ToJsonString(), errRet.Error())
 		}
+	}()
+
+	for _, v := range filters {
+		request.Filters = append(request.Filters, &vpc.Filter{
+			Name:   helper.String(v.Name),
+			Values: helper.Strings(v.Values),
+		})
 	}
-	var strLimit = fmt.Sprintf("%d", limit)
-	request.Limit = &strLimit
 
-	var strOffset = fmt.Sprintf("%d", offset)
-	request.Offset = &strOffset
-	var response *vpc.DescribeVpcsResponse
-	var iacExtInfo connectivity.IacExtInfo
-	iacExtInfo.InstanceId = vpcId
-	if err := resource.Retry(tccommon.ReadRetryTimeout, func() *resource.RetryError {
-		ratelimit.Check(request.GetAction())
-		var result *vpc.DescribeVpcsResponse
-		var err error
-		if vpcId != "" {
-			result, err = me.client.UseVpcClient(iacExtInfo).DescribeVpcs(request)
-		} else {
-			result, err = me.client.UseVpcClient().DescribeVpcs(request)
+	vpcs, err := tccommon.DescribeAllPages(100, func(offset, limit int) ([]*vpc.Vpc, int, error) {
+		request.Limit = helper.String(fmt.Sprintf("%d", limit))
+		request.Offset = helper.String(fmt.Sprintf("%d", offset))
+
+		var response *vpc.DescribeVpcsResponse
+		var iacExtInfo connectivity.IacExtInfo
+		iacExtInfo.InstanceId = vpcId
+		if err := resource.Retry(tccommon.ReadRetryTimeout, func() *resource.RetryError {
+			ratelimit.Check(request.GetAction())
+			var result *vpc.DescribeVpcsResponse
+			var err error
+			if vpcId != "" {
+				result, err = me.client.UseVpcClient(iacExtInfo).DescribeVpcs(request)
+			} else {
+				result, err = me.client.UseVpcClient().DescribeVpcs(request)
+			}
+
+			if err != nil {
+				return tccommon.RetryError(err, tccommon.InternalError)
+			}
+			response = result
+			return nil
+		}); err != nil {
+			log.Printf("[CRITAL]%s read vpc failed, reason: %v", logId, err)
+			return nil, 0, err
 		}
 
-		if err != nil {
-			return tccommon.RetryError(err, tccommon.InternalError)
+		total := -1
+		if response.Response.TotalCount != nil {
+			total = int(*response.Response.TotalCount)
 		}
-		response = result
-		return nil
-	}); err != nil {
-		log.Printf("[CRITAL]%s read vpc failed, reason: %v", logId, err)
+		return response.Response.VpcSet, total, nil
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	if total < 0 {
-		total = int(*response.Response.TotalCount)
-	}
-
-	if len(response.Response.VpcSet) > 0 {
-		offset += limit
-	} else {
-		// get empty VpcInfo, we're done
-		return
-	}
-	for _, item := range response.Response.VpcSet {
+	infos = make([]VpcBasicInfo, 0, len(vpcs))
+	hasVpc := map[string]bool{}
+	for _, item := range vpcs {
 		var basicInfo VpcBasicInfo
 		basicInfo.cidr = *item.CidrBlock
 		basicInfo.createTime = *item.CreatedTime
@@ -476,9 +470,9 @@ getMoreData:
 
 		infos = append(infos, basicInfo)
 	}
-	goto getMoreData
-
+	return
 }
+
 func (me *VpcService) DescribeSubnet(ctx context.Context,
 	subnetId string,
 	isRemoteVpcSNAT *bool,
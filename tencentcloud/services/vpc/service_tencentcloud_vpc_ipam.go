@@ -0,0 +1,560 @@
+package vpc
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+
+	tccommon "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	sdkErrors "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common/errors"
+	tag "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/tag/v20180813"
+	vpc "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/vpc/v20170312"
+
+	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/internal/helper"
+	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/ratelimit"
+)
+
+// VPC has no IPAM API, pools and allocations are saved as standalone tags of the account, which are global
+// and shared by all the states, and creating an existing tag fails so that a cidr can only be claimed once.
+const (
+	VPC_IPAM_POOL_TAG_KEY_PREFIX       = "tf-ipam-pool:"
+	VPC_IPAM_ALLOCATION_TAG_KEY_PREFIX = "tf-ipam-allocation:"
+
+	VPC_IPAM_POOL_ENTRY_CIDR     = "cidr"
+	VPC_IPAM_POOL_ENTRY_RESERVED = "reserved"
+	VPC_IPAM_POOL_ENTRY_REGION   = "region"
+	VPC_IPAM_POOL_ENTRY_CCN      = "ccn"
+
+	VPC_IPAM_USED_TYPE_VPC          = "vpc"
+	VPC_IPAM_USED_TYPE_SUBNET       = "subnet"
+	VPC_IPAM_USED_TYPE_CCN_INSTANCE = "ccn_instance"
+	VPC_IPAM_USED_TYPE_RESERVED     = "reserved"
+	VPC_IPAM_USED_TYPE_ALLOCATION   = "allocation"
+
+	tagErrorTagDuplicate = "ResourceInUse.TagDuplicate"
+	tagErrorTagNonExist  = "ResourceNotFound.TagNonExist"
+)
+
+// serializes the allocations of the provider, the tags only protect the allocations of different processes
+var vpcIpamAllocationLocker = &sync.Mutex{}
+
+type VpcIpamPool struct {
+	Name               string
+	CidrBlocks         []string
+	ReservedCidrBlocks []string
+	Regions            []string
+	CcnIds             []string
+}
+
+// VpcIpamUsedCidr is a cidr in the pool which can not be allocated.
+type VpcIpamUsedCidr struct {
+	CidrBlock    string
+	ResourceType string
+	ResourceId   string
+	Region       string
+}
+
+func vpcIpamPoolTagKey(poolName string) string {
+	return VPC_IPAM_POOL_TAG_KEY_PREFIX + poolName
+}
+
+func vpcIpamAllocationTagKey(poolName string) string {
+	return VPC_IPAM_ALLOCATION_TAG_KEY_PREFIX + poolName
+}
+
+func (me *VpcService) DescribeVpcIpamTagValues(ctx context.Context, tagKey string) (values []string, errRet error) {
+	logId := tccommon.GetLogId(ctx)
+	request := tag.NewDescribeTagValuesRequest()
+	request.TagKeys = []*string{&tagKey}
+
+	defer func() {
+		if errRet != nil {
+			log.Printf("[CRITAL]%s api[%s] fail, request body [%s], reason[%s]\n", logId, request.GetAction(), request.ToJsonString(), errRet.Error())
+		}
+	}()
+
+	var (
+		offset uint64 = 0
+		limit  uint64 = 100
+	)
+	for {
+		request.Offset = &offset
+		request.Limit = &limit
+
+		var response *tag.DescribeTagValuesResponse
+		err := resource.Retry(tccommon.ReadRetryTimeout, func() *resource.RetryError {
+			ratelimit.Check(request.GetAction())
+			result, e := me.client.UseTagClient().DescribeTagValues(request)
+			if e != nil {
+				return tccommon.RetryError(e)
+			}
+			response = result
+			return nil
+		})
+		if err != nil {
+			errRet = err
+			return
+		}
+		log.Printf("[DEBUG]%s api[%s] success, request body [%s], response body [%s]\n", logId, request.GetAction(), request.ToJsonString(), response.ToJsonString())
+
+		if response == nil || response.Response == nil || len(response.Response.Tags) < 1 {
+			break
+		}
+		for _, item := range response.Response.Tags {
+			if item.TagKey != nil && *item.TagKey == tagKey && item.TagValue != nil {
+				values = append(values, *item.TagValue)
+			}
+		}
+		if len(response.Response.Tags) < int(limit) {
+			break
+		}
+		offset += limit
+	}
+	return
+}
+
+// CreateVpcIpamTag creates the standalone tag, created is false if the tag already exists.
+func (me *VpcService) CreateVpcIpamTag(ctx context.Context, tagKey, tagValue string) (created bool, errRet error) {
+	logId := tccommon.GetLogId(ctx)
+	request := tag.NewCreateTagRequest()
+	request.TagKey = &tagKey
+	request.TagValue = &tagValue
+
+	errRet = resource.Retry(tccommon.WriteRetryTimeout, func() *resource.RetryError {
+		ratelimit.Check(request.GetAction())
+		response, e := me.client.UseTagClient().CreateTag(request)
+		if e != nil {
+			if sdkErr, ok := e.(*sdkErrors.TencentCloudSDKError); ok && sdkErr.Code == tagErrorTagDuplicate {
+				return nil
+			}
+			log.Printf("[CRITAL]%s api[%s] fail, request body [%s], reason[%s]\n", logId, request.GetAction(), request.ToJsonString(), e.Error())
+			return tccommon.RetryError(e)
+		}
+		log.Printf("[DEBUG]%s api[%s] success, request body [%s], response body [%s]\n", logId, request.GetAction(), request.ToJsonString(), response.ToJsonString())
+		created = true
+		return nil
+	})
+	return
+}
+
+func (me *VpcService) DeleteVpcIpamTag(ctx context.Context, tagKey, tagValue string) (errRet error) {
+	logId := tccommon.GetLogId(ctx)
+	request := tag.NewDeleteTagRequest()
+	request.TagKey = &tagKey
+	request.TagValue = &tagValue
+
+	return resource.Retry(tccommon.WriteRetryTimeout, func() *resource.RetryError {
+		ratelimit.Check(request.GetAction())
+		response, e := me.client.UseTagClient().DeleteTag(request)
+		if e != nil {
+			if sdkErr, ok := e.(*sdkErrors.TencentCloudSDKError); ok && sdkErr.Code == tagErrorTagNonExist {
+				return nil
+			}
+			log.Printf("[CRITAL]%s api[%s] fail, request body [%s], reason[%s]\n", logId, request.GetAction(), request.ToJsonString(), e.Error())
+			return tccommon.RetryError(e)
+		}
+		log.Printf("[DEBUG]%s api[%s] success, request body [%s], response body [%s]\n", logId, request.GetAction(), request.ToJsonString(), response.ToJsonString())
+		return nil
+	})
+}
+
+// DescribeVpcIpamPoolByName returns nil if the pool does not exist.
+func (me *VpcService) DescribeVpcIpamPoolByName(ctx context.Context, poolName string) (pool *VpcIpamPool, errRet error) {
+	values, err := me.DescribeVpcIpamTagValues(ctx, vpcIpamPoolTagKey(poolName))
+	if err != nil {
+		errRet = err
+		return
+	}
+
+	pool = &VpcIpamPool{Name: poolName}
+	for _, value := range values {
+		kind, entry, ok := strings.Cut(value, ":")
+		if !ok {
+			continue
+		}
+		switch kind {
+		case VPC_IPAM_POOL_ENTRY_CIDR:
+			pool.CidrBlocks = append(pool.CidrBlocks, entry)
+		case VPC_IPAM_POOL_ENTRY_RESERVED:
+			pool.ReservedCidrBlocks = append(pool.ReservedCidrBlocks, entry)
+		case VPC_IPAM_POOL_ENTRY_REGION:
+			pool.Regions = append(pool.Regions, entry)
+		case VPC_IPAM_POOL_ENTRY_CCN:
+			pool.CcnIds = append(pool.CcnIds, entry)
+		}
+	}
+	if len(pool.CidrBlocks) == 0 {
+		pool = nil
+		return
+	}
+
+	sort.Strings(pool.CidrBlocks)
+	sort.Strings(pool.ReservedCidrBlocks)
+	sort.Strings(pool.Regions)
+	sort.Strings(pool.CcnIds)
+	return
+}
+
+// ModifyVpcIpamPoolEntries adds and removes the entries of kind of the pool.
+func (me *VpcService) ModifyVpcIpamPoolEntries(ctx context.Context, poolName, kind string, add, remove []string) error {
+	tagKey := vpcIpamPoolTagKey(poolName)
+	for _, entry := range add {
+		if _, err := me.CreateVpcIpamTag(ctx, tagKey, kind+":"+entry); err != nil {
+			return err
+		}
+	}
+	for _, entry := range remove {
+		if err := me.DeleteVpcIpamTag(ctx, tagKey, kind+":"+entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (me *VpcService) DescribeVpcIpamAllocations(ctx context.Context, poolName string) (cidrBlocks []string, errRet error) {
+	cidrBlocks, errRet = me.DescribeVpcIpamTagValues(ctx, vpcIpamAllocationTagKey(poolName))
+	sort.Strings(cidrBlocks)
+	return
+}
+
+// DescribeVpcIpamUsedCidrs returns the cidrs of the vpcs, subnets and ccn instances overlapping the pool and the reserved cidrs.
+// Cidrs strictly containing a cidr block of the pool are skipped, so a pool can be used to allocate subnets of a vpc.
+func (me *VpcService) DescribeVpcIpamUsedCidrs(ctx context.Context, pool *VpcIpamPool) (used []VpcIpamUsedCidr, errRet error) {
+	discovered := make([]VpcIpamUsedCidr, 0)
+	for _, cidr := range pool.ReservedCidrBlocks {
+		discovered = append(discovered, VpcIpamUsedCidr{CidrBlock: cidr, ResourceType: VPC_IPAM_USED_TYPE_RESERVED})
+	}
+
+	regions := pool.Regions
+	if len(regions) == 0 {
+		regions = []string{me.client.Region}
+	}
+	for _, region := range regions {
+		result, err := me.describeVpcIpamRegionCidrs(ctx, region)
+		if err != nil {
+			errRet = err
+			return
+		}
+		discovered = append(discovered, result...)
+	}
+
+	for _, ccnId := range pool.CcnIds {
+		result, err := me.describeVpcIpamCcnCidrs(ctx, ccnId)
+		if err != nil {
+			errRet = err
+			return
+		}
+		discovered = append(discovered, result...)
+	}
+
+	for _, item := range discovered {
+		if vpcIpamCidrInPool(pool, item.CidrBlock) {
+			used = append(used, item)
+		}
+	}
+	return
+}
+
+func (me *VpcService) describeVpcIpamRegionCidrs(ctx context.Context, region string) (used []VpcIpamUsedCidr, errRet error) {
+	logId := tccommon.GetLogId(ctx)
+	client := me.client.UseVpcClientRegion(region)
+
+	vpcRequest := vpc.NewDescribeVpcsRequest()
+	vpcs, err := tccommon.DescribeAllPages(100, func(offset, limit int) ([]*vpc.Vpc, int, error) {
+		vpcRequest.Offset = helper.String(fmt.Sprintf("%d", offset))
+		vpcRequest.Limit = helper.String(fmt.Sprintf("%d", limit))
+
+		var response *vpc.DescribeVpcsResponse
+		if err := resource.Retry(tccommon.ReadRetryTimeout, func() *resource.RetryError {
+			ratelimit.Check(vpcRequest.GetAction())
+			result, e := client.DescribeVpcs(vpcRequest)
+			if e != nil {
+				return tccommon.RetryError(e, tccommon.InternalError)
+			}
+			response = result
+			return nil
+		}); err != nil {
+			log.Printf("[CRITAL]%s api[%s] fail, region [%s], reason[%s]\n", logId, vpcRequest.GetAction(), region, err.Error())
+			return nil, 0, err
+		}
+
+		total := -1
+		if response.Response.TotalCount != nil {
+			total = int(*response.Response.TotalCount)
+		}
+		return response.Response.VpcSet, total, nil
+	})
+	if err != nil {
+		errRet = err
+		return
+	}
+
+	for _, item := range vpcs {
+		vpcId := helper.PString(item.VpcId)
+		if item.CidrBlock != nil {
+			used = append(used, VpcIpamUsedCidr{CidrBlock: *item.CidrBlock, ResourceType: VPC_IPAM_USED_TYPE_VPC, ResourceId: vpcId, Region: region})
+		}
+		for _, assistant := range item.AssistantCidrSet {
+			if assistant.CidrBlock != nil {
+				used = append(used, VpcIpamUsedCidr{CidrBlock: *assistant.CidrBlock, ResourceType: VPC_IPAM_USED_TYPE_VPC, ResourceId: vpcId, Region: region})
+			}
+		}
+	}
+
+	subnetRequest := vpc.NewDescribeSubnetsRequest()
+	subnets, err := tccommon.DescribeAllPages(100, func(offset, limit int) ([]*vpc.Subnet, int, error) {
+		subnetRequest.Offset = helper.String(fmt.Sprintf("%d", offset))
+		subnetRequest.Limit = helper.String(fmt.Sprintf("%d", limit))
+
+		var response *vpc.DescribeSubnetsResponse
+		if err := resource.Retry(tccommon.ReadRetryTimeout, func() *resource.RetryError {
+			ratelimit.Check(subnetRequest.GetAction())
+			result, e := client.DescribeSubnets(subnetRequest)
+			if e != nil {
+				return tccommon.RetryError(e, tccommon.InternalError)
+			}
+			response = result
+			return nil
+		}); err != nil {
+			log.Printf("[CRITAL]%s api[%s] fail, region [%s], reason[%s]\n", logId, subnetRequest.GetAction(), region, err.Error())
+			return nil, 0, err
+		}
+
+		total := -1
+		if response.Response.TotalCount != nil {
+			total = int(*response.Response.TotalCount)
+		}
+		return response.Response.SubnetSet, total, nil
+	})
+	if err != nil {
+		errRet = err
+		return
+	}
+
+	for _, item := range subnets {
+		if item.CidrBlock != nil && *item.CidrBlock != "" {
+			used = append(used, VpcIpamUsedCidr{CidrBlock: *item.CidrBlock, ResourceType: VPC_IPAM_USED_TYPE_SUBNET, ResourceId: helper.PString(item.SubnetId), Region: region})
+		}
+	}
+	return
+}
+
+func (me *VpcService) describeVpcIpamCcnCidrs(ctx context.Context, ccnId string) (used []VpcIpamUsedCidr, errRet error) {
+	logId := tccommon.GetLogId(ctx)
+	request := vpc.NewDescribeCcnAttachedInstancesRequest()
+	request.CcnId = &ccnId
+
+	defer func() {
+		if errRet != nil {
+			log.Printf("[CRITAL]%s api[%s] fail, request body [%s], reason[%s]\n", logId, request.GetAction(), request.ToJsonString(), errRet.Error())
+		}
+	}()
+
+	var (
+		offset uint64 = 0
+		limit  uint64 = 100
+	)
+	for {
+		request.Offset = &offset
+		request.Limit = &limit
+
+		var response *vpc.DescribeCcnAttachedInstancesResponse
+		err := resource.Retry(tccommon.ReadRetryTimeout, func() *resource.RetryError {
+			ratelimit.Check(request.GetAction())
+			result, e := me.client.UseVpcClient().DescribeCcnAttachedInstances(request)
+			if e != nil {
+				return tccommon.RetryError(e, tccommon.InternalError)
+			}
+			response = result
+			return nil
+		})
+		if err != nil {
+			errRet = err
+			return
+		}
+
+		if response == nil || response.Response == nil || len(response.Response.InstanceSet) < 1 {
+			break
+		}
+		for _, instance := range response.Response.InstanceSet {
+			for _, cidr := range instance.CidrBlock {
+				if cidr != nil && *cidr != "" {
+					used = append(used, VpcIpamUsedCidr{
+						CidrBlock:    *cidr,
+						ResourceType: VPC_IPAM_USED_TYPE_CCN_INSTANCE,
+						ResourceId:   helper.PString(instance.InstanceId),
+						Region:       helper.PString(instance.InstanceRegion),
+					})
+				}
+			}
+		}
+		if len(response.Response.InstanceSet) < int(limit) {
+			break
+		}
+		offset += limit
+	}
+	return
+}
+
+// vpcIpamCidrInPool returns whether the cidr overlaps the pool without strictly containing a cidr block of the pool,
+// a cidr equal to a cidr block uses up the block.
+func vpcIpamCidrInPool(pool *VpcIpamPool, cidr string) bool {
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil || ipNet.IP.To4() == nil {
+		return false
+	}
+	for _, block := range pool.CidrBlocks {
+		_, blockNet, err := net.ParseCIDR(block)
+		if err != nil {
+			continue
+		}
+		if !helper.CidrOverlaps(blockNet, ipNet) {
+			continue
+		}
+		blockOnes, _ := blockNet.Mask.Size()
+		if ones, _ := ipNet.Mask.Size(); ones >= blockOnes {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckVpcIpamCidr checks the cidr is in the pool and does not overlap the used cidrs.
+func CheckVpcIpamCidr(pool *VpcIpamPool, cidr string, used []VpcIpamUsedCidr) error {
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return err
+	}
+	if ipNet.String() != cidr {
+		return fmt.Errorf("%s is not a network address, it should be %s", cidr, ipNet.String())
+	}
+
+	inPool := false
+	for _, block := range pool.CidrBlocks {
+		if _, blockNet, e := net.ParseCIDR(block); e == nil && helper.CidrContains(blockNet, ipNet) {
+			inPool = true
+			break
+		}
+	}
+	if !inPool {
+		return fmt.Errorf("%s is not in the cidr blocks %v of ipam pool `%s`", cidr, pool.CidrBlocks, pool.Name)
+	}
+
+	for _, item := range used {
+		_, usedNet, e := net.ParseCIDR(item.CidrBlock)
+		if e != nil || !helper.CidrOverlaps(usedNet, ipNet) {
+			continue
+		}
+		owner := item.ResourceType
+		if item.ResourceId != "" {
+			owner = fmt.Sprintf("%s %s", item.ResourceType, item.ResourceId)
+		}
+		if item.Region != "" {
+			owner = fmt.Sprintf("%s in %s", owner, item.Region)
+		}
+		return fmt.Errorf("%s overlaps %s of %s", cidr, item.CidrBlock, owner)
+	}
+	return nil
+}
+
+// NextVpcIpamCidr returns the lowest free cidr of prefixLength in the cidr blocks of the pool.
+func NextVpcIpamCidr(pool *VpcIpamPool, prefixLength int, used []VpcIpamUsedCidr) (string, error) {
+	usedNets := make([]*net.IPNet, 0, len(used))
+	for _, item := range used {
+		if _, usedNet, err := net.ParseCIDR(item.CidrBlock); err == nil {
+			usedNets = append(usedNets, usedNet)
+		}
+	}
+
+	checked := 0
+	for _, block := range pool.CidrBlocks {
+		_, blockNet, err := net.ParseCIDR(block)
+		if err != nil {
+			return "", err
+		}
+		if ones, _ := blockNet.Mask.Size(); ones > prefixLength {
+			continue
+		}
+		checked++
+		next, err := helper.CidrNextFree(blockNet, prefixLength, usedNets)
+		if err != nil {
+			return "", err
+		}
+		if next != nil {
+			return next.String(), nil
+		}
+	}
+	if checked == 0 {
+		return "", fmt.Errorf("prefix length %d is shorter than the cidr blocks %v of ipam pool `%s`", prefixLength, pool.CidrBlocks, pool.Name)
+	}
+	return "", fmt.Errorf("ipam pool `%s` has no free cidr of prefix length %d", pool.Name, prefixLength)
+}
+
+// DescribeVpcIpamPoolUsage returns the used cidrs of the pool including its allocations.
+func (me *VpcService) DescribeVpcIpamPoolUsage(ctx context.Context, pool *VpcIpamPool) (used []VpcIpamUsedCidr, errRet error) {
+	used, errRet = me.DescribeVpcIpamUsedCidrs(ctx, pool)
+	if errRet != nil {
+		return
+	}
+	allocations, err := me.DescribeVpcIpamAllocations(ctx, pool.Name)
+	if err != nil {
+		errRet = err
+		return
+	}
+	for _, allocation := range allocations {
+		used = append(used, VpcIpamUsedCidr{CidrBlock: allocation, ResourceType: VPC_IPAM_USED_TYPE_ALLOCATION})
+	}
+	return
+}
+
+// AllocateVpcIpamCidr claims cidrBlock, or the lowest free cidr of prefixLength if cidrBlock is empty, from the pool.
+func (me *VpcService) AllocateVpcIpamCidr(ctx context.Context, pool *VpcIpamPool, prefixLength int, cidrBlock string) (string, error) {
+	vpcIpamAllocationLocker.Lock()
+	defer vpcIpamAllocationLocker.Unlock()
+
+	used, err := me.DescribeVpcIpamPoolUsage(ctx, pool)
+	if err != nil {
+		return "", err
+	}
+
+	tagKey := vpcIpamAllocationTagKey(pool.Name)
+	if cidrBlock != "" {
+		if err := CheckVpcIpamCidr(pool, cidrBlock, used); err != nil {
+			return "", err
+		}
+		created, err := me.CreateVpcIpamTag(ctx, tagKey, cidrBlock)
+		if err != nil {
+			return "", err
+		}
+		if !created {
+			return "", fmt.Errorf("%s has been allocated from ipam pool `%s` by others", cidrBlock, pool.Name)
+		}
+		return cidrBlock, nil
+	}
+
+	// another process may claim the same cidr in the meantime, try the next one
+	for {
+		next, err := NextVpcIpamCidr(pool, prefixLength, used)
+		if err != nil {
+			return "", err
+		}
+		created, err := me.CreateVpcIpamTag(ctx, tagKey, next)
+		if err != nil {
+			return "", err
+		}
+		if created {
+			return next, nil
+		}
+		used = append(used, VpcIpamUsedCidr{CidrBlock: next, ResourceType: VPC_IPAM_USED_TYPE_ALLOCATION})
+	}
+}
+
+func (me *VpcService) ReleaseVpcIpamCidr(ctx context.Context, poolName, cidrBlock string) error {
+	return me.DeleteVpcIpamTag(ctx, vpcIpamAllocationTagKey(poolName), cidrBlock)
+}
@@ -0,0 +1,105 @@
+package vpc
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestVpcIpamCidrInPool(t *testing.T) {
+	pool := &VpcIpamPool{Name: "test", CidrBlocks: []string{"10.0.0.0/16", "172.16.0.0/20"}}
+	cases := []struct {
+		cidr string
+		want bool
+	}{
+		{cidr: "10.0.1.0/24", want: true},
+		{cidr: "172.16.8.0/21", want: true},
+		// a vpc equal to a cidr block uses up the block
+		{cidr: "10.0.0.0/16", want: true},
+		{cidr: "172.16.0.0/20", want: true},
+		// a vpc strictly containing a cidr block allocates its subnets from the pool
+		{cidr: "10.0.0.0/8", want: false},
+		{cidr: "172.16.0.0/12", want: false},
+		{cidr: "10.1.0.0/16", want: false},
+		{cidr: "192.168.0.0/16", want: false},
+		{cidr: "fd00::/64", want: false},
+		{cidr: "invalid", want: false},
+	}
+	for _, c := range cases {
+		if got := vpcIpamCidrInPool(pool, c.cidr); got != c.want {
+			t.Errorf("vpcIpamCidrInPool(%s) = %t, want %t", c.cidr, got, c.want)
+		}
+	}
+}
+
+func TestCheckVpcIpamCidr(t *testing.T) {
+	pool := &VpcIpamPool{Name: "test", CidrBlocks: []string{"10.0.0.0/16"}}
+	used := []VpcIpamUsedCidr{
+		{CidrBlock: "10.0.1.0/24", ResourceType: VPC_IPAM_USED_TYPE_SUBNET, ResourceId: "subnet-1", Region: "ap-guangzhou"},
+		{CidrBlock: "10.0.4.0/22", ResourceType: VPC_IPAM_USED_TYPE_RESERVED},
+	}
+	cases := []struct {
+		cidr    string
+		wantErr string
+	}{
+		{cidr: "10.0.0.0/24"},
+		{cidr: "10.0.2.0/23"},
+		{cidr: "10.0.8.0/21"},
+		{cidr: "10.0.0.0/23", wantErr: "overlaps 10.0.1.0/24 of subnet subnet-1 in ap-guangzhou"},
+		{cidr: "10.0.5.0/24", wantErr: "overlaps 10.0.4.0/22 of reserved"},
+		{cidr: "10.0.0.0/8", wantErr: "is not in the cidr blocks"},
+		{cidr: "10.1.0.0/24", wantErr: "is not in the cidr blocks"},
+		{cidr: "10.0.0.1/24", wantErr: "is not a network address, it should be 10.0.0.0/24"},
+		{cidr: "invalid", wantErr: "invalid CIDR address"},
+	}
+	for _, c := range cases {
+		err := CheckVpcIpamCidr(pool, c.cidr, used)
+		if c.wantErr == "" {
+			if err != nil {
+				t.Errorf("CheckVpcIpamCidr(%s): %s", c.cidr, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), c.wantErr) {
+			t.Errorf("CheckVpcIpamCidr(%s) = %v, want error containing %q", c.cidr, err, c.wantErr)
+		}
+	}
+}
+
+func TestNextVpcIpamCidr(t *testing.T) {
+	pool := &VpcIpamPool{Name: "test", CidrBlocks: []string{"10.0.0.0/24", "10.1.0.0/16"}}
+	cases := []struct {
+		name         string
+		prefixLength int
+		used         []string
+		want         string
+		wantErr      string
+	}{
+		{name: "first block", prefixLength: 26, want: "10.0.0.0/26"},
+		{name: "next in first block", prefixLength: 26, used: []string{"10.0.0.0/26", "10.0.0.128/26"}, want: "10.0.0.64/26"},
+		{name: "first block exhausted", prefixLength: 25, used: []string{"10.0.0.0/25", "10.0.0.128/25"}, want: "10.1.0.0/25"},
+		{name: "first block too small", prefixLength: 20, want: "10.1.0.0/20"},
+		{name: "whole block", prefixLength: 16, want: "10.1.0.0/16"},
+		{name: "block used by an equal vpc", prefixLength: 24, used: []string{"10.0.0.0/24", "10.1.0.0/16"}, wantErr: "has no free cidr of prefix length 24"},
+		{name: "prefix shorter than all blocks", prefixLength: 8, wantErr: "is shorter than the cidr blocks"},
+	}
+	for _, c := range cases {
+		used := make([]VpcIpamUsedCidr, 0, len(c.used))
+		for _, cidr := range c.used {
+			used = append(used, VpcIpamUsedCidr{CidrBlock: cidr, ResourceType: VPC_IPAM_USED_TYPE_ALLOCATION})
+		}
+		got, err := NextVpcIpamCidr(pool, c.prefixLength, used)
+		if c.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), c.wantErr) {
+				t.Errorf("%s: got %s, %v, want error containing %q", c.name, got, err, c.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %s", c.name, err)
+			continue
+		}
+		if got != c.want {
+			t.Errorf("%s: got %s, want %s", c.name, got, c.want)
+		}
+	}
+}
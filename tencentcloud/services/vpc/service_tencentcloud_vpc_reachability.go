@@ -0,0 +1,1017 @@
+package vpc
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	clb "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/clb/v20180317"
+	vpc "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/vpc/v20170312"
+
+	tccommon "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/common"
+	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/internal/helper"
+	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/ratelimit"
+	svcccn "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/services/ccn"
+)
+
+const (
+	VPC_REACHABILITY_HOP_SOURCE_SECURITY_GROUP      = "SOURCE_SECURITY_GROUP"
+	VPC_REACHABILITY_HOP_SOURCE_NETWORK_ACL         = "SOURCE_NETWORK_ACL"
+	VPC_REACHABILITY_HOP_ROUTE                      = "ROUTE"
+	VPC_REACHABILITY_HOP_DESTINATION_NETWORK_ACL    = "DESTINATION_NETWORK_ACL"
+	VPC_REACHABILITY_HOP_DESTINATION_SECURITY_GROUP = "DESTINATION_SECURITY_GROUP"
+
+	VPC_REACHABILITY_ALLOW = "ALLOW"
+	VPC_REACHABILITY_DENY  = "DENY"
+	// the hop is not evaluated, such as the routes to VPN gateways, so the flow is not regarded as reachable
+	VPC_REACHABILITY_NOT_EVALUATED = "NOT_EVALUATED"
+
+	VPC_NETWORK_RULE_ACCEPT = "ACCEPT"
+	VPC_NETWORK_RULE_DROP   = "DROP"
+
+	VPC_NETWORK_DIRECTION_INGRESS = "ingress"
+	VPC_NETWORK_DIRECTION_EGRESS  = "egress"
+
+	VPC_ROUTE_GATEWAY_TYPE_CCN = "CCN"
+
+	VPC_PEERING_CONNECTION_STATE_ACTIVE = "ACTIVE"
+	VPC_NAT_GATEWAY_STATE_AVAILABLE     = "AVAILABLE"
+)
+
+var VPC_NETWORK_FLOW_PROTOCOL = []string{"TCP", "UDP", "ICMP"}
+
+// VpcNetworkEndpoint is an end of a flow, the vpc and subnet are empty for the addresses out of VPC.
+type VpcNetworkEndpoint struct {
+	Id               string
+	Ip               string
+	VpcId            string
+	SubnetId         string
+	SecurityGroupIds []string
+}
+
+type VpcNetworkFlow struct {
+	Protocol string
+	Port     int64
+}
+
+// VpcNetworkRule is a rule of a security group or a network ACL, the address and service templates are resolved.
+// The peer is the other end of the flow, either the addresses or the security group is set. The local addresses
+// are only set for the quintuple ACL.
+type VpcNetworkRule struct {
+	ResourceId          string
+	Direction           string
+	Index               int64
+	Action              string
+	Protocol            string
+	Port                string
+	Services            []string
+	PeerAddresses       []string
+	PeerSecurityGroupId string
+	LocalAddresses      []string
+	Description         string
+}
+
+func (r *VpcNetworkRule) String() string {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "%s %s #%d: %s", r.ResourceId, r.Direction, r.Index, r.Action)
+	if len(r.Services) > 0 {
+		fmt.Fprintf(&buf, " %s", strings.Join(r.Services, ","))
+	} else {
+		fmt.Fprintf(&buf, " %s:%s", vpcNetworkRuleValue(r.Protocol), vpcNetworkRuleValue(r.Port))
+	}
+	peer := r.PeerSecurityGroupId
+	if peer == "" {
+		peer = strings.Join(r.PeerAddresses, ",")
+	}
+	if r.Direction == VPC_NETWORK_DIRECTION_INGRESS {
+		fmt.Fprintf(&buf, " from %s", peer)
+	} else {
+		fmt.Fprintf(&buf, " to %s", peer)
+	}
+	if r.Description != "" {
+		fmt.Fprintf(&buf, " (%s)", r.Description)
+	}
+	return buf.String()
+}
+
+func vpcNetworkRuleValue(value string) string {
+	if value == "" {
+		return "ALL"
+	}
+	return strings.ToUpper(value)
+}
+
+// Match reports whether the flow between local and peer matches the rule.
+func (r *VpcNetworkRule) Match(flow VpcNetworkFlow, local, peer *VpcNetworkEndpoint) bool {
+	if !r.MatchFlow(flow) {
+		return false
+	}
+
+	if r.PeerSecurityGroupId != "" {
+		if !helper.StringsContain(peer.SecurityGroupIds, r.PeerSecurityGroupId) {
+			return false
+		}
+	} else if !matchVpcNetworkAddresses(r.PeerAddresses, peer.Ip) {
+		return false
+	}
+
+	return len(r.LocalAddresses) == 0 || matchVpcNetworkAddresses(r.LocalAddresses, local.Ip)
+}
+
+// MatchFlow reports whether the protocol and the port of the flow match the rule regardless of the addresses.
+func (r *VpcNetworkRule) MatchFlow(flow VpcNetworkFlow) bool {
+	if len(r.Services) == 0 {
+		return matchVpcNetworkProtocolPort(r.Protocol, r.Port, flow)
+	}
+	for _, service := range r.Services {
+		protocol, port, _ := strings.Cut(service, ":")
+		if matchVpcNetworkProtocolPort(protocol, port, flow) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchVpcNetworkProtocolPort(protocol, port string, flow VpcNetworkFlow) bool {
+	protocol = strings.ToUpper(protocol)
+	if protocol == "" || protocol == "ALL" {
+		return true
+	}
+	if protocol != flow.Protocol {
+		return false
+	}
+	if flow.Protocol == "ICMP" || port == "" || strings.ToUpper(port) == "ALL" {
+		return true
+	}
+	for _, item := range strings.Split(port, ",") {
+		from, to, isRange := strings.Cut(strings.TrimSpace(item), "-")
+		if !isRange {
+			to = from
+		}
+		fromPort, e1 := strconv.ParseInt(from, 10, 64)
+		toPort, e2 := strconv.ParseInt(to, 10, 64)
+		if e1 == nil && e2 == nil && flow.Port >= fromPort && flow.Port <= toPort {
+			return true
+		}
+	}
+	return false
+}
+
+// matchVpcNetworkAddresses reports whether the ip is in any of the addresses, an address is a cidr, an ip or an ip range.
+func matchVpcNetworkAddresses(addresses []string, ip string) bool {
+	target := net.ParseIP(ip)
+	if target == nil {
+		return false
+	}
+	for _, address := range addresses {
+		address = strings.TrimSpace(address)
+		if _, cidr, err := net.ParseCIDR(address); err == nil {
+			if cidr.Contains(target) {
+				return true
+			}
+			continue
+		}
+		if from, to, isRange := strings.Cut(address, "-"); isRange {
+			fromIp, toIp := net.ParseIP(from), net.ParseIP(to)
+			if fromIp != nil && toIp != nil && bytes.Compare(target.To16(), fromIp.To16()) >= 0 && bytes.Compare(target.To16(), toIp.To16()) <= 0 {
+				return true
+			}
+			continue
+		}
+		if target.Equal(net.ParseIP(address)) {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchVpcNetworkRules returns the first rule matching the flow, or nil if no rule matches.
+func MatchVpcNetworkRules(rules []*VpcNetworkRule, flow VpcNetworkFlow, local, peer *VpcNetworkEndpoint) *VpcNetworkRule {
+	for _, rule := range rules {
+		if rule.Match(flow, local, peer) {
+			return rule
+		}
+	}
+	return nil
+}
+
+// VpcRoute is a route of a route table or a CCN. For a CCN route, the gateway is the instance which the route goes to.
+type VpcRoute struct {
+	RouteTableId    string
+	RouteId         string
+	RouteType       string
+	DestinationCidr string
+	GatewayType     string
+	GatewayId       string
+	Enabled         bool
+	// the routes to the same destination with different priorities are active and standby
+	Priority int64
+}
+
+func (r *VpcRoute) String() string {
+	routeTableId := r.RouteTableId
+	if r.RouteId != "" {
+		routeTableId += " route " + r.RouteId
+	}
+	return fmt.Sprintf("%s: %s -> %s %s", routeTableId, r.DestinationCidr, r.GatewayType, r.GatewayId)
+}
+
+// MatchVpcRoute returns the enabled route with the longest prefix containing the ip, or nil if no route matches.
+func MatchVpcRoute(routes []*VpcRoute, ip string) *VpcRoute {
+	target := net.ParseIP(ip)
+	var (
+		matched *VpcRoute
+		longest = -1
+	)
+	for _, route := range routes {
+		if !route.Enabled {
+			continue
+		}
+		_, cidr, err := net.ParseCIDR(route.DestinationCidr)
+		if err != nil || !cidr.Contains(target) {
+			continue
+		}
+		if ones, _ := cidr.Mask.Size(); ones > longest {
+			matched, longest = route, ones
+		}
+	}
+	return matched
+}
+
+type VpcReachabilityInput struct {
+	Source      *VpcNetworkEndpoint
+	Destination *VpcNetworkEndpoint
+	Flow        VpcNetworkFlow
+
+	// egress rules of the security groups of source in priority order
+	SourceSecurityGroupRules []*VpcNetworkRule
+	SourceNetworkAclId       string
+	SourceNetworkAclRules    []*VpcNetworkRule
+	SourceVpcCidrs           []string
+	SourceRouteTableId       string
+	SourceRoutes             []*VpcRoute
+	// routes of the CCNs which the source routes go to, by CCN id
+	CcnRoutes map[string][]*VpcRoute
+	// peering connections and NAT gateways which the source route to destination goes to, by id, absent if not found
+	PeeringConnections map[string]*vpc.PeerConnection
+	NatGateways        map[string]*vpc.NatGateway
+	// CIDRs of the VPCs on the other side of the peering connections, by VPC id, absent if unknown
+	PeerVpcCidrs                  map[string][]string
+	DestinationNetworkAclId       string
+	DestinationNetworkAclRules    []*VpcNetworkRule
+	DestinationSecurityGroupRules []*VpcNetworkRule
+}
+
+type VpcReachabilityHop struct {
+	Hop        string
+	ResourceId string
+	Action     string
+	Rule       string
+}
+
+type VpcReachabilityResult struct {
+	// whether every hop allows the flow, false if a hop is not evaluated
+	Reachable bool
+	Hops      []VpcReachabilityHop
+	// the hop denying the flow, nil if reachable
+	Blocking *VpcReachabilityHop
+}
+
+// EvaluateVpcReachability evaluates the flow from source to destination hop by hop, and stops at the first hop denying it.
+// The security groups are stateful, so only the egress of source and the ingress of destination are evaluated. The return
+// traffic through the stateless ACLs is not evaluated, as its port is unknown.
+func EvaluateVpcReachability(input *VpcReachabilityInput) *VpcReachabilityResult {
+	result := &VpcReachabilityResult{}
+	add := func(hop VpcReachabilityHop) bool {
+		result.Hops = append(result.Hops, hop)
+		if hop.Action == VPC_REACHABILITY_DENY {
+			result.Blocking = &result.Hops[len(result.Hops)-1]
+			return false
+		}
+		return true
+	}
+
+	if len(input.Source.SecurityGroupIds) > 0 &&
+		!add(evaluateVpcReachabilityRules(VPC_REACHABILITY_HOP_SOURCE_SECURITY_GROUP, strings.Join(input.Source.SecurityGroupIds, ","),
+			input.SourceSecurityGroupRules, input.Flow, input.Source, input.Destination)) {
+		return result
+	}
+	if input.SourceNetworkAclId != "" &&
+		!add(evaluateVpcReachabilityRules(VPC_REACHABILITY_HOP_SOURCE_NETWORK_ACL, input.SourceNetworkAclId,
+			input.SourceNetworkAclRules, input.Flow, input.Source, input.Destination)) {
+		return result
+	}
+	if input.Source.VpcId != "" && !add(evaluateVpcReachabilityRoute(input)) {
+		return result
+	}
+	if input.DestinationNetworkAclId != "" &&
+		!add(evaluateVpcReachabilityRules(VPC_REACHABILITY_HOP_DESTINATION_NETWORK_ACL, input.DestinationNetworkAclId,
+			input.DestinationNetworkAclRules, input.Flow, input.Destination, input.Source)) {
+		return result
+	}
+	if len(input.Destination.SecurityGroupIds) > 0 &&
+		!add(evaluateVpcReachabilityRules(VPC_REACHABILITY_HOP_DESTINATION_SECURITY_GROUP, strings.Join(input.Destination.SecurityGroupIds, ","),
+			input.DestinationSecurityGroupRules, input.Flow, input.Destination, input.Source)) {
+		return result
+	}
+
+	result.Reachable = true
+	for _, hop := range result.Hops {
+		if hop.Action == VPC_REACHABILITY_NOT_EVALUATED {
+			result.Reachable = false
+		}
+	}
+	return result
+}
+
+func evaluateVpcReachabilityRules(hop, resourceId string, rules []*VpcNetworkRule, flow VpcNetworkFlow, local, peer *VpcNetworkEndpoint) VpcReachabilityHop {
+	rule := MatchVpcNetworkRules(rules, flow, local, peer)
+	if rule == nil {
+		return VpcReachabilityHop{Hop: hop, ResourceId: resourceId, Action: VPC_REACHABILITY_DENY, Rule: "no rule matches, dropped by default"}
+	}
+	action := VPC_REACHABILITY_DENY
+	if rule.Action == VPC_NETWORK_RULE_ACCEPT {
+		action = VPC_REACHABILITY_ALLOW
+	}
+	return VpcReachabilityHop{Hop: hop, ResourceId: rule.ResourceId, Action: action, Rule: rule.String()}
+}
+
+func evaluateVpcReachabilityRoute(input *VpcReachabilityInput) VpcReachabilityHop {
+	hop := VpcReachabilityHop{Hop: VPC_REACHABILITY_HOP_ROUTE, ResourceId: input.SourceRouteTableId, Action: VPC_REACHABILITY_ALLOW}
+	source, destination := input.Source, input.Destination
+
+	if destination.VpcId == source.VpcId || (destination.VpcId == "" && matchVpcNetworkAddresses(input.SourceVpcCidrs, destination.Ip)) {
+		hop.ResourceId = source.VpcId
+		hop.Rule = "local route of " + source.VpcId
+		return hop
+	}
+
+	route := MatchVpcRoute(input.SourceRoutes, destination.Ip)
+	if route == nil {
+		if ip := net.ParseIP(destination.Ip); ip != nil && destination.VpcId == "" && !ip.IsPrivate() && !isVpcSharedAddress(ip) {
+			hop.Rule = "no route matches, goes to the public network, which requires a public IP of the source"
+			return hop
+		}
+		hop.Action = VPC_REACHABILITY_DENY
+		hop.Rule = "no route matches " + destination.Ip
+		return hop
+	}
+	hop.Rule = route.String()
+	switch route.GatewayType {
+	case VPC_ROUTE_GATEWAY_TYPE_CCN:
+	case GATE_WAY_TYPE_PEERCONNECTION:
+		return evaluateVpcReachabilityPeeringRoute(input, hop, route)
+	case GATE_WAY_TYPE_NAT:
+		return evaluateVpcReachabilityNatRoute(input, hop, route)
+	default:
+		hop.ResourceId = route.GatewayId
+		hop.Action = VPC_REACHABILITY_NOT_EVALUATED
+		hop.Rule = fmt.Sprintf("%s, the next hop of type %s is not evaluated", route, route.GatewayType)
+		return hop
+	}
+
+	var ccnRoutes []*VpcRoute
+	for _, ccnRoute := range input.CcnRoutes[route.GatewayId] {
+		if ccnRoute.GatewayId != source.VpcId {
+			ccnRoutes = append(ccnRoutes, ccnRoute)
+		}
+	}
+	ccnRoute := MatchVpcRoute(ccnRoutes, destination.Ip)
+	switch {
+	case ccnRoute == nil:
+		hop.ResourceId = route.GatewayId
+		hop.Action = VPC_REACHABILITY_DENY
+		hop.Rule = fmt.Sprintf("%s, but no enabled route of %s matches %s", route, route.GatewayId, destination.Ip)
+	case destination.VpcId != "" && ccnRoute.GatewayId != destination.VpcId:
+		hop.ResourceId = route.GatewayId
+		hop.Action = VPC_REACHABILITY_DENY
+		hop.Rule = fmt.Sprintf("%s, but %s goes to %s instead of %s", route, ccnRoute, ccnRoute.GatewayId, destination.VpcId)
+	default:
+		hop.Rule = fmt.Sprintf("%s, %s", route, ccnRoute)
+	}
+	return hop
+}
+
+// evaluateVpcReachabilityPeeringRoute checks the peering connection is active and reaches the destination on the other side.
+func evaluateVpcReachabilityPeeringRoute(input *VpcReachabilityInput, hop VpcReachabilityHop, route *VpcRoute) VpcReachabilityHop {
+	source, destination := input.Source, input.Destination
+
+	peering := input.PeeringConnections[route.GatewayId]
+	var peerVpcId string
+	if peering != nil {
+		peerVpcId = helper.PString(peering.PeerVpcId)
+		if peerVpcId == source.VpcId {
+			peerVpcId = helper.PString(peering.SourceVpcId)
+		}
+	}
+
+	switch cidrs, ok := input.PeerVpcCidrs[peerVpcId]; {
+	case peering == nil:
+		hop.Action = VPC_REACHABILITY_DENY
+		hop.Rule = fmt.Sprintf("%s, but peering connection %s is not found", route, route.GatewayId)
+	case helper.PString(peering.State) != VPC_PEERING_CONNECTION_STATE_ACTIVE:
+		hop.Action = VPC_REACHABILITY_DENY
+		hop.Rule = fmt.Sprintf("%s, but peering connection %s is %s", route, route.GatewayId, helper.PString(peering.State))
+	case destination.VpcId != "" && peerVpcId != destination.VpcId:
+		hop.Action = VPC_REACHABILITY_DENY
+		hop.Rule = fmt.Sprintf("%s, but peering connection %s goes to %s instead of %s", route, route.GatewayId, peerVpcId, destination.VpcId)
+	case destination.VpcId != "":
+		hop.Rule = fmt.Sprintf("%s, peering connection %s goes to %s", route, route.GatewayId, peerVpcId)
+	case !ok:
+		// the VPC of another region or account can not be described
+		hop.Action = VPC_REACHABILITY_NOT_EVALUATED
+		hop.Rule = fmt.Sprintf("%s, but the CIDRs of %s on the other side are unknown", route, peerVpcId)
+	case !matchVpcNetworkAddresses(cidrs, destination.Ip):
+		hop.Action = VPC_REACHABILITY_DENY
+		hop.Rule = fmt.Sprintf("%s, but %s is out of %s on the other side", route, destination.Ip, peerVpcId)
+	default:
+		hop.Rule = fmt.Sprintf("%s, peering connection %s goes to %s", route, route.GatewayId, peerVpcId)
+	}
+	if hop.Action != VPC_REACHABILITY_ALLOW {
+		hop.ResourceId = route.GatewayId
+	}
+	return hop
+}
+
+// evaluateVpcReachabilityNatRoute checks the NAT gateway exists in the source VPC and is available.
+func evaluateVpcReachabilityNatRoute(input *VpcReachabilityInput, hop VpcReachabilityHop, route *VpcRoute) VpcReachabilityHop {
+	natGateway := input.NatGateways[route.GatewayId]
+	switch {
+	case natGateway == nil:
+		hop.Action = VPC_REACHABILITY_DENY
+		hop.Rule = fmt.Sprintf("%s, but NAT gateway %s is not found", route, route.GatewayId)
+	case helper.PString(natGateway.VpcId) != input.Source.VpcId:
+		hop.Action = VPC_REACHABILITY_DENY
+		hop.Rule = fmt.Sprintf("%s, but NAT gateway %s is in %s", route, route.GatewayId, helper.PString(natGateway.VpcId))
+	case helper.PString(natGateway.State) != VPC_NAT_GATEWAY_STATE_AVAILABLE:
+		hop.Action = VPC_REACHABILITY_DENY
+		hop.Rule = fmt.Sprintf("%s, but NAT gateway %s is %s", route, route.GatewayId, helper.PString(natGateway.State))
+	default:
+		hop.Rule = fmt.Sprintf("%s, NAT gateway %s is %s", route, route.GatewayId, VPC_NAT_GATEWAY_STATE_AVAILABLE)
+	}
+	if hop.Action != VPC_REACHABILITY_ALLOW {
+		hop.ResourceId = route.GatewayId
+	}
+	return hop
+}
+
+var vpcSharedAddressCidr = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+func isVpcSharedAddress(ip net.IP) bool {
+	return vpcSharedAddressCidr.Contains(ip)
+}
+
+// vpcTemplateResolver resolves the address and service templates of the rules, the templates are cached.
+type vpcTemplateResolver struct {
+	service   *VpcService
+	addresses map[string][]string
+	services  map[string][]string
+}
+
+func newVpcTemplateResolver(service *VpcService) *vpcTemplateResolver {
+	return &vpcTemplateResolver{service: service, addresses: make(map[string][]string), services: make(map[string][]string)}
+}
+
+func (me *vpcTemplateResolver) addressesOf(ctx context.Context, spec *vpc.AddressTemplateSpecification) ([]string, error) {
+	if spec == nil {
+		return nil, nil
+	}
+	if groupId := helper.PString(spec.AddressGroupId); groupId != "" {
+		if cached, ok := me.addresses[groupId]; ok {
+			return cached, nil
+		}
+		var group *vpc.AddressTemplateGroup
+		err := resource.Retry(tccommon.ReadRetryTimeout, func() *resource.RetryError {
+			result, _, e := me.service.DescribeAddressTemplateGroupById(ctx, groupId)
+			if e != nil {
+				return tccommon.RetryError(e)
+			}
+			group = result
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		var addresses []string
+		if group != nil {
+			for _, templateId := range group.AddressTemplateIdSet {
+				items, err := me.addressesOf(ctx, &vpc.AddressTemplateSpecification{AddressId: templateId})
+				if err != nil {
+					return nil, err
+				}
+				addresses = append(addresses, items...)
+			}
+		}
+		me.addresses[groupId] = addresses
+		return addresses, nil
+	}
+
+	templateId := helper.PString(spec.AddressId)
+	if cached, ok := me.addresses[templateId]; ok || templateId == "" {
+		return cached, nil
+	}
+	var template *vpc.AddressTemplate
+	err := resource.Retry(tccommon.ReadRetryTimeout, func() *resource.RetryError {
+		result, _, e := me.service.DescribeAddressTemplateById(ctx, templateId)
+		if e != nil {
+			return tccommon.RetryError(e)
+		}
+		template = result
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	var addresses []string
+	if template != nil {
+		addresses = helper.PStrings(template.AddressSet)
+	}
+	me.addresses[templateId] = addresses
+	return addresses, nil
+}
+
+func (me *vpcTemplateResolver) servicesOf(ctx context.Context, spec *vpc.ServiceTemplateSpecification) ([]string, error) {
+	if spec == nil {
+		return nil, nil
+	}
+	if groupId := helper.PString(spec.ServiceGroupId); groupId != "" {
+		if cached, ok := me.services[groupId]; ok {
+			return cached, nil
+		}
+		var group *vpc.ServiceTemplateGroup
+		err := resource.Retry(tccommon.ReadRetryTimeout, func() *resource.RetryError {
+			result, _, e := me.service.DescribeServiceTemplateGroupById(ctx, groupId)
+			if e != nil {
+				return tccommon.RetryError(e)
+			}
+			group = result
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		var services []string
+		if group != nil {
+			for _, templateId := range group.ServiceTemplateIdSet {
+				items, err := me.servicesOf(ctx, &vpc.ServiceTemplateSpecification{ServiceId: templateId})
+				if err != nil {
+					return nil, err
+				}
+				services = append(services, items...)
+			}
+		}
+		me.services[groupId] = services
+		return services, nil
+	}
+
+	templateId := helper.PString(spec.ServiceId)
+	if cached, ok := me.services[templateId]; ok || templateId == "" {
+		return cached, nil
+	}
+	var template *vpc.ServiceTemplate
+	err := resource.Retry(tccommon.ReadRetryTimeout, func() *resource.RetryError {
+		result, _, e := me.service.DescribeServiceTemplateById(ctx, templateId)
+		if e != nil {
+			return tccommon.RetryError(e)
+		}
+		template = result
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	var services []string
+	if template != nil {
+		services = helper.PStrings(template.ServiceSet)
+	}
+	me.services[templateId] = services
+	return services, nil
+}
+
+// vpcSecurityGroupPolicyRule converts the policy of the security group to a rule, the templates are left to resolve.
+// A policy has either an IPv4 or an IPv6 cidr block.
+func vpcSecurityGroupPolicyRule(sgId, direction string, policy *vpc.SecurityGroupPolicy) *VpcNetworkRule {
+	rule := &VpcNetworkRule{
+		ResourceId:          sgId,
+		Direction:           direction,
+		Index:               helper.PInt64(policy.PolicyIndex),
+		Action:              strings.ToUpper(helper.PString(policy.Action)),
+		Protocol:            helper.PString(policy.Protocol),
+		Port:                helper.PString(policy.Port),
+		PeerSecurityGroupId: helper.PString(policy.SecurityGroupId),
+		Description:         helper.PString(policy.PolicyDescription),
+	}
+	for _, cidr := range []*string{policy.CidrBlock, policy.Ipv6CidrBlock} {
+		if helper.PString(cidr) != "" {
+			rule.PeerAddresses = append(rule.PeerAddresses, *cidr)
+		}
+	}
+	return rule
+}
+
+func (me *vpcTemplateResolver) securityGroupRules(ctx context.Context, sgId, direction string, policies []*vpc.SecurityGroupPolicy) ([]*VpcNetworkRule, error) {
+	rules := make([]*VpcNetworkRule, 0, len(policies))
+	for _, policy := range policies {
+		rule := vpcSecurityGroupPolicyRule(sgId, direction, policy)
+		addresses, err := me.addressesOf(ctx, policy.AddressTemplate)
+		if err != nil {
+			return nil, err
+		}
+		rule.PeerAddresses = append(rule.PeerAddresses, addresses...)
+		if rule.Services, err = me.servicesOf(ctx, policy.ServiceTemplate); err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// DescribeVpcSecurityGroupRules returns the ingress and egress rules of the security group in order, with the templates resolved.
+func (me *VpcService) DescribeVpcSecurityGroupRules(ctx context.Context, sgId string) (ingress, egress []*VpcNetworkRule, errRet error) {
+	return me.describeVpcSecurityGroupRules(ctx, newVpcTemplateResolver(me), sgId)
+}
+
+func (me *VpcService) describeVpcSecurityGroupRules(ctx context.Context, resolver *vpcTemplateResolver, sgId string) (ingress, egress []*VpcNetworkRule, errRet error) {
+	var policySet *vpc.SecurityGroupPolicySet
+	errRet = resource.Retry(tccommon.ReadRetryTimeout, func() *resource.RetryError {
+		result, e := me.DescribeSecurityGroupPolicies(ctx, sgId)
+		if e != nil {
+			return tccommon.RetryError(e)
+		}
+		policySet = result
+		return nil
+	})
+	if errRet != nil || policySet == nil {
+		return
+	}
+	if ingress, errRet = resolver.securityGroupRules(ctx, sgId, VPC_NETWORK_DIRECTION_INGRESS, policySet.Ingress); errRet != nil {
+		return
+	}
+	egress, errRet = resolver.securityGroupRules(ctx, sgId, VPC_NETWORK_DIRECTION_EGRESS, policySet.Egress)
+	return
+}
+
+// DescribeVpcNetworkAclRules returns the ingress and egress rules of the network ACL in order. For the quintuple ACL,
+// the source ports are ignored, as the port of the client is unknown.
+func (me *VpcService) DescribeVpcNetworkAclRules(ctx context.Context, aclId string) (ingress, egress []*VpcNetworkRule, errRet error) {
+	var acl *vpc.NetworkAcl
+	errRet = resource.Retry(tccommon.ReadRetryTimeout, func() *resource.RetryError {
+		result, _, e := me.DescribeNetWorkByACLID(ctx, aclId)
+		if e != nil {
+			return tccommon.RetryError(e)
+		}
+		acl = result
+		return nil
+	})
+	if errRet != nil || acl == nil {
+		return
+	}
+
+	if helper.PString(acl.NetworkAclType) != "QUINTUPLE" {
+		convert := func(direction string, entries []*vpc.NetworkAclEntry) (rules []*VpcNetworkRule) {
+			for i, entry := range entries {
+				rules = append(rules, &VpcNetworkRule{
+					ResourceId:    aclId,
+					Direction:     direction,
+					Index:         int64(i),
+					Action:        strings.ToUpper(helper.PString(entry.Action)),
+					Protocol:      helper.PString(entry.Protocol),
+					Port:          helper.PString(entry.Port),
+					PeerAddresses: []string{helper.PString(entry.CidrBlock)},
+					Description:   helper.PString(entry.Description),
+				})
+			}
+			return
+		}
+		return convert(VPC_NETWORK_DIRECTION_INGRESS, acl.IngressEntries), convert(VPC_NETWORK_DIRECTION_EGRESS, acl.EgressEntries), nil
+	}
+
+	var entries []*vpc.NetworkAclQuintupleEntry
+	errRet = resource.Retry(tccommon.ReadRetryTimeout, func() *resource.RetryError {
+		result, e := me.DescribeVpcNetworkAclQuintupleById(ctx, aclId)
+		if e != nil {
+			return tccommon.RetryError(e)
+		}
+		entries = result
+		return nil
+	})
+	if errRet != nil {
+		return
+	}
+	for _, entry := range entries {
+		rule := &VpcNetworkRule{
+			ResourceId:  aclId,
+			Action:      strings.ToUpper(helper.PString(entry.Action)),
+			Protocol:    helper.PString(entry.Protocol),
+			Port:        helper.PString(entry.DestinationPort),
+			Description: helper.PString(entry.Description),
+		}
+		// the flow goes to the destination port in both directions
+		if helper.PString(entry.NetworkAclDirection) == "EGRESS" {
+			rule.Direction = VPC_NETWORK_DIRECTION_EGRESS
+			rule.LocalAddresses = []string{helper.PString(entry.SourceCidr)}
+			rule.PeerAddresses = []string{helper.PString(entry.DestinationCidr)}
+			rule.Index = int64(len(egress))
+			egress = append(egress, rule)
+		} else {
+			rule.Direction = VPC_NETWORK_DIRECTION_INGRESS
+			rule.LocalAddresses = []string{helper.PString(entry.DestinationCidr)}
+			rule.PeerAddresses = []string{helper.PString(entry.SourceCidr)}
+			rule.Index = int64(len(ingress))
+			ingress = append(ingress, rule)
+		}
+	}
+	return
+}
+
+// DescribeVpcRouteTableRoutes returns the routes of the route table.
+func (me *VpcService) DescribeVpcRouteTableRoutes(ctx context.Context, routeTableId string) (routes []*VpcRoute, errRet error) {
+	var info VpcRouteTableBasicInfo
+	errRet = resource.Retry(tccommon.ReadRetryTimeout, func() *resource.RetryError {
+		result, _, e := me.DescribeRouteTable(ctx, routeTableId)
+		if e != nil {
+			return tccommon.RetryError(e)
+		}
+		info = result
+		return nil
+	})
+	if errRet != nil {
+		return
+	}
+	for _, entry := range info.entryInfos {
+		routes = append(routes, &VpcRoute{
+			RouteTableId:    routeTableId,
+			RouteId:         entry.routeItemId,
+			RouteType:       entry.entryType,
+			DestinationCidr: entry.destinationCidr,
+			GatewayType:     entry.nextType,
+			GatewayId:       entry.nextBub,
+			Enabled:         entry.enabled,
+		})
+	}
+	return
+}
+
+// DescribeVpcCcnRoutes returns the routes of the CCN, the gateway of a route is the instance which it goes to.
+func (me *VpcService) DescribeVpcCcnRoutes(ctx context.Context, ccnId string) (routes []*VpcRoute, errRet error) {
+	ccnService := svcccn.NewVpcService(me.client)
+	var ccnRoutes []*vpc.CcnRoute
+	errRet = resource.Retry(tccommon.ReadRetryTimeout, func() *resource.RetryError {
+		result, e := ccnService.DescribeVpcDescribeCcnRoutesByFilter(ctx, map[string]interface{}{"CcnId": helper.String(ccnId)})
+		if e != nil {
+			return tccommon.RetryError(e)
+		}
+		ccnRoutes = result
+		return nil
+	})
+	for _, item := range ccnRoutes {
+		routes = append(routes, &VpcRoute{
+			RouteTableId:    ccnId,
+			RouteId:         helper.PString(item.RouteId),
+			DestinationCidr: helper.PString(item.DestinationCidrBlock),
+			GatewayType:     helper.PString(item.InstanceType),
+			GatewayId:       helper.PString(item.InstanceId),
+			Enabled:         item.Enabled != nil && *item.Enabled,
+			Priority:        int64(helper.PUint64(item.RoutePriority)),
+		})
+	}
+	return
+}
+
+// DescribeVpcNetworkEndpointByEni returns the endpoint of the primary private ip of the ENI.
+func (me *VpcService) DescribeVpcNetworkEndpointByEni(ctx context.Context, eniId string) (endpoint *VpcNetworkEndpoint, errRet error) {
+	var enis []*vpc.NetworkInterface
+	errRet = resource.Retry(tccommon.ReadRetryTimeout, func() *resource.RetryError {
+		result, e := me.DescribeEniById(ctx, []string{eniId})
+		if e != nil {
+			return tccommon.RetryError(e)
+		}
+		enis = result
+		return nil
+	})
+	if errRet != nil {
+		return
+	}
+	if len(enis) == 0 {
+		errRet = fmt.Errorf("ENI %s is not found", eniId)
+		return
+	}
+	eni := enis[0]
+	endpoint = &VpcNetworkEndpoint{
+		Id:               eniId,
+		VpcId:            helper.PString(eni.VpcId),
+		SubnetId:         helper.PString(eni.SubnetId),
+		SecurityGroupIds: helper.PStrings(eni.GroupSet),
+	}
+	for _, address := range eni.PrivateIpAddressSet {
+		if address.Primary != nil && *address.Primary {
+			endpoint.Ip = helper.PString(address.PrivateIpAddress)
+		}
+	}
+	return
+}
+
+// DescribeVpcNetworkEndpointByClb returns the endpoint of the VIP of the CLB. The public CLB is regarded as out of VPC.
+func (me *VpcService) DescribeVpcNetworkEndpointByClb(ctx context.Context, clbId string) (endpoint *VpcNetworkEndpoint, errRet error) {
+	logId := tccommon.GetLogId(ctx)
+	request := clb.NewDescribeLoadBalancersRequest()
+	request.LoadBalancerIds = []*string{helper.String(clbId)}
+
+	var loadBalancers []*clb.LoadBalancer
+	errRet = resource.Retry(tccommon.ReadRetryTimeout, func() *resource.RetryError {
+		ratelimit.Check(request.GetAction())
+		response, e := me.client.UseClbClient().DescribeLoadBalancers(request)
+		if e != nil {
+			return tccommon.RetryError(e)
+		}
+		loadBalancers = response.Response.LoadBalancerSet
+		return nil
+	})
+	if errRet != nil {
+		return
+	}
+	if len(loadBalancers) == 0 || len(loadBalancers[0].LoadBalancerVips) == 0 {
+		errRet = fmt.Errorf("CLB %s is not found, %s", clbId, logId)
+		return
+	}
+	loadBalancer := loadBalancers[0]
+	endpoint = &VpcNetworkEndpoint{
+		Id:               clbId,
+		Ip:               *loadBalancer.LoadBalancerVips[0],
+		SecurityGroupIds: helper.PStrings(loadBalancer.SecureGroups),
+	}
+	if helper.PString(loadBalancer.LoadBalancerType) == "INTERNAL" {
+		endpoint.VpcId = helper.PString(loadBalancer.VpcId)
+		endpoint.SubnetId = helper.PString(loadBalancer.SubnetId)
+	}
+	return
+}
+
+// DescribeVpcReachabilityInput loads the security groups, the network ACLs and the routes on the path of the flow.
+func (me *VpcService) DescribeVpcReachabilityInput(ctx context.Context, source, destination *VpcNetworkEndpoint, flow VpcNetworkFlow) (input *VpcReachabilityInput, errRet error) {
+	input = &VpcReachabilityInput{
+		Source:             source,
+		Destination:        destination,
+		Flow:               flow,
+		CcnRoutes:          make(map[string][]*VpcRoute),
+		PeeringConnections: make(map[string]*vpc.PeerConnection),
+		NatGateways:        make(map[string]*vpc.NatGateway),
+		PeerVpcCidrs:       make(map[string][]string),
+	}
+	resolver := newVpcTemplateResolver(me)
+
+	for _, sgId := range source.SecurityGroupIds {
+		_, egress, err := me.describeVpcSecurityGroupRules(ctx, resolver, sgId)
+		if err != nil {
+			return nil, err
+		}
+		input.SourceSecurityGroupRules = append(input.SourceSecurityGroupRules, egress...)
+	}
+	for _, sgId := range destination.SecurityGroupIds {
+		ingress, _, err := me.describeVpcSecurityGroupRules(ctx, resolver, sgId)
+		if err != nil {
+			return nil, err
+		}
+		input.DestinationSecurityGroupRules = append(input.DestinationSecurityGroupRules, ingress...)
+	}
+
+	if source.SubnetId != "" {
+		subnet, err := me.describeVpcReachabilitySubnet(ctx, source.SubnetId)
+		if err != nil {
+			return nil, err
+		}
+		input.SourceNetworkAclId = helper.PString(subnet.NetworkAclId)
+		if input.SourceNetworkAclId != "" {
+			if _, input.SourceNetworkAclRules, err = me.DescribeVpcNetworkAclRules(ctx, input.SourceNetworkAclId); err != nil {
+				return nil, err
+			}
+		}
+		input.SourceRouteTableId = helper.PString(subnet.RouteTableId)
+		if input.SourceRoutes, err = me.DescribeVpcRouteTableRoutes(ctx, input.SourceRouteTableId); err != nil {
+			return nil, err
+		}
+		for _, route := range input.SourceRoutes {
+			if route.GatewayType != VPC_ROUTE_GATEWAY_TYPE_CCN || input.CcnRoutes[route.GatewayId] != nil {
+				continue
+			}
+			if input.CcnRoutes[route.GatewayId], err = me.DescribeVpcCcnRoutes(ctx, route.GatewayId); err != nil {
+				return nil, err
+			}
+		}
+		if route := MatchVpcRoute(input.SourceRoutes, destination.Ip); route != nil {
+			if err = me.describeVpcReachabilityRouteTarget(ctx, input, route); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if source.VpcId != "" {
+		if input.SourceVpcCidrs, _, errRet = me.describeVpcReachabilityVpcCidrs(ctx, source.VpcId); errRet != nil {
+			return nil, errRet
+		}
+	}
+
+	if destination.SubnetId != "" {
+		subnet, err := me.describeVpcReachabilitySubnet(ctx, destination.SubnetId)
+		if err != nil {
+			return nil, err
+		}
+		input.DestinationNetworkAclId = helper.PString(subnet.NetworkAclId)
+		if input.DestinationNetworkAclId != "" {
+			if input.DestinationNetworkAclRules, _, err = me.DescribeVpcNetworkAclRules(ctx, input.DestinationNetworkAclId); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return
+}
+
+// describeVpcReachabilityRouteTarget loads the peering connection or the NAT gateway which the route goes to.
+func (me *VpcService) describeVpcReachabilityRouteTarget(ctx context.Context, input *VpcReachabilityInput, route *VpcRoute) (errRet error) {
+	switch route.GatewayType {
+	case GATE_WAY_TYPE_PEERCONNECTION:
+		var peering *vpc.PeerConnection
+		errRet = resource.Retry(tccommon.ReadRetryTimeout, func() *resource.RetryError {
+			result, e := me.DescribeVpcPeerConnectManagerById(ctx, route.GatewayId)
+			if e != nil {
+				return tccommon.RetryError(e)
+			}
+			peering = result
+			return nil
+		})
+		if errRet != nil || peering == nil {
+			return
+		}
+		input.PeeringConnections[route.GatewayId] = peering
+
+		peerVpcId := helper.PString(peering.PeerVpcId)
+		if peerVpcId == input.Source.VpcId {
+			peerVpcId = helper.PString(peering.SourceVpcId)
+		}
+		if input.Destination.VpcId != "" {
+			return
+		}
+		cidrs, ok, err := me.describeVpcReachabilityVpcCidrs(ctx, peerVpcId)
+		if err != nil {
+			return err
+		}
+		if ok {
+			input.PeerVpcCidrs[peerVpcId] = cidrs
+		}
+	case GATE_WAY_TYPE_NAT:
+		var natGateway *vpc.NatGateway
+		errRet = resource.Retry(tccommon.ReadRetryTimeout, func() *resource.RetryError {
+			result, e := me.DescribeNatGatewayById(ctx, route.GatewayId)
+			if e != nil {
+				return tccommon.RetryError(e)
+			}
+			natGateway = result
+			return nil
+		})
+		if errRet == nil && natGateway != nil {
+			input.NatGateways[route.GatewayId] = natGateway
+		}
+	}
+	return
+}
+
+// describeVpcReachabilityVpcCidrs returns the CIDR and the assistant CIDRs of the VPC, ok is false if the VPC is not found.
+func (me *VpcService) describeVpcReachabilityVpcCidrs(ctx context.Context, vpcId string) (cidrs []string, ok bool, errRet error) {
+	var instance *vpc.Vpc
+	errRet = resource.Retry(tccommon.ReadRetryTimeout, func() *resource.RetryError {
+		result, e := me.DescribeVpcById(ctx, vpcId)
+		if e != nil {
+			return tccommon.RetryError(e)
+		}
+		instance = result
+		return nil
+	})
+	if errRet != nil || instance == nil {
+		return
+	}
+	ok = true
+	cidrs = append(cidrs, helper.PString(instance.CidrBlock))
+	for _, cidr := range instance.AssistantCidrSet {
+		cidrs = append(cidrs, helper.PString(cidr.CidrBlock))
+	}
+	return
+}
+
+func (me *VpcService) describeVpcReachabilitySubnet(ctx context.Context, subnetId string) (subnet *vpc.Subnet, errRet error) {
+	errRet = resource.Retry(tccommon.ReadRetryTimeout, func() *resource.RetryError {
+		result, e := me.DescribeSubnetById(ctx, subnetId)
+		if e != nil {
+			return tccommon.RetryError(e)
+		}
+		subnet = result
+		return nil
+	})
+	if errRet == nil && subnet == nil {
+		errRet = fmt.Errorf("subnet %s is not found", subnetId)
+	}
+	return
+}
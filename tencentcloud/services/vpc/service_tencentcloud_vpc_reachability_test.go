@@ -0,0 +1,472 @@
+package vpc
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	vpc "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/vpc/v20170312"
+
+	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/internal/helper"
+)
+
+func TestMatchVpcNetworkAddresses(t *testing.T) {
+	cases := []struct {
+		addresses []string
+		ip        string
+		want      bool
+	}{
+		{addresses: []string{"10.0.0.0/16"}, ip: "10.0.1.1", want: true},
+		{addresses: []string{"10.0.0.0/16"}, ip: "10.1.0.1", want: false},
+		{addresses: []string{"192.168.0.0/16", " 10.0.0.5 "}, ip: "10.0.0.5", want: true},
+		{addresses: []string{"10.0.0.10-10.0.0.20"}, ip: "10.0.0.15", want: true},
+		{addresses: []string{"10.0.0.10-10.0.0.20"}, ip: "10.0.0.21", want: false},
+		{addresses: []string{"0.0.0.0/0"}, ip: "8.8.8.8", want: true},
+		{addresses: []string{"0.0.0.0/0"}, ip: "2001:db8::1", want: false},
+		{addresses: []string{"::/0"}, ip: "2001:db8::1", want: true},
+		{addresses: []string{"2001:db8::/32"}, ip: "2001:db8:1::1", want: true},
+		{addresses: []string{"2001:db8::1-2001:db8::ff"}, ip: "2001:db8::10", want: true},
+		{addresses: []string{"invalid", "10.0.0.0/33"}, ip: "10.0.0.1", want: false},
+		{addresses: []string{"0.0.0.0/0"}, ip: "not an ip", want: false},
+		{addresses: nil, ip: "10.0.0.1", want: false},
+	}
+	for _, c := range cases {
+		if got := matchVpcNetworkAddresses(c.addresses, c.ip); got != c.want {
+			t.Errorf("matchVpcNetworkAddresses(%v, %s) = %t, want %t", c.addresses, c.ip, got, c.want)
+		}
+	}
+}
+
+func TestVpcNetworkRuleMatch(t *testing.T) {
+	local := &VpcNetworkEndpoint{Ip: "10.0.1.10", SecurityGroupIds: []string{"sg-local"}}
+	peer := &VpcNetworkEndpoint{Ip: "10.0.2.20", SecurityGroupIds: []string{"sg-peer", "sg-other"}}
+	cases := []struct {
+		name string
+		rule VpcNetworkRule
+		flow VpcNetworkFlow
+		want bool
+	}{
+		{name: "all protocols", rule: VpcNetworkRule{Protocol: "ALL", PeerAddresses: []string{"0.0.0.0/0"}}, flow: VpcNetworkFlow{"UDP", 53}, want: true},
+		{name: "empty protocol", rule: VpcNetworkRule{PeerAddresses: []string{"0.0.0.0/0"}}, flow: VpcNetworkFlow{"TCP", 22}, want: true},
+		{name: "port", rule: VpcNetworkRule{Protocol: "tcp", Port: "443", PeerAddresses: []string{"0.0.0.0/0"}}, flow: VpcNetworkFlow{"TCP", 443}, want: true},
+		{name: "other port", rule: VpcNetworkRule{Protocol: "TCP", Port: "443", PeerAddresses: []string{"0.0.0.0/0"}}, flow: VpcNetworkFlow{"TCP", 80}, want: false},
+		{name: "other protocol", rule: VpcNetworkRule{Protocol: "UDP", Port: "443", PeerAddresses: []string{"0.0.0.0/0"}}, flow: VpcNetworkFlow{"TCP", 443}, want: false},
+		{name: "port range and list", rule: VpcNetworkRule{Protocol: "TCP", Port: "22, 8000-8080", PeerAddresses: []string{"0.0.0.0/0"}}, flow: VpcNetworkFlow{"TCP", 8080}, want: true},
+		{name: "all ports", rule: VpcNetworkRule{Protocol: "TCP", Port: "ALL", PeerAddresses: []string{"0.0.0.0/0"}}, flow: VpcNetworkFlow{"TCP", 1}, want: true},
+		{name: "ICMP ignores port", rule: VpcNetworkRule{Protocol: "ICMP", Port: "8", PeerAddresses: []string{"0.0.0.0/0"}}, flow: VpcNetworkFlow{"ICMP", 0}, want: true},
+		{name: "service template", rule: VpcNetworkRule{Services: []string{"udp:53", "tcp:80,443"}, PeerAddresses: []string{"0.0.0.0/0"}}, flow: VpcNetworkFlow{"TCP", 443}, want: true},
+		{name: "service template not matched", rule: VpcNetworkRule{Protocol: "ALL", Services: []string{"udp:53"}, PeerAddresses: []string{"0.0.0.0/0"}}, flow: VpcNetworkFlow{"TCP", 53}, want: false},
+		{name: "peer address", rule: VpcNetworkRule{PeerAddresses: []string{"10.0.1.0/24"}}, flow: VpcNetworkFlow{"TCP", 80}, want: false},
+		{name: "peer security group", rule: VpcNetworkRule{PeerSecurityGroupId: "sg-other"}, flow: VpcNetworkFlow{"TCP", 80}, want: true},
+		{name: "other peer security group", rule: VpcNetworkRule{PeerSecurityGroupId: "sg-local"}, flow: VpcNetworkFlow{"TCP", 80}, want: false},
+		{name: "local address", rule: VpcNetworkRule{PeerAddresses: []string{"0.0.0.0/0"}, LocalAddresses: []string{"10.0.1.0/24"}}, flow: VpcNetworkFlow{"TCP", 80}, want: true},
+		{name: "other local address", rule: VpcNetworkRule{PeerAddresses: []string{"0.0.0.0/0"}, LocalAddresses: []string{"10.0.3.0/24"}}, flow: VpcNetworkFlow{"TCP", 80}, want: false},
+	}
+	for _, c := range cases {
+		if got := c.rule.Match(c.flow, local, peer); got != c.want {
+			t.Errorf("%s: Match = %t, want %t", c.name, got, c.want)
+		}
+	}
+}
+
+func TestVpcSecurityGroupPolicyRule(t *testing.T) {
+	cases := []struct {
+		name   string
+		policy *vpc.SecurityGroupPolicy
+		peer   string
+		want   []string
+		match  bool
+	}{
+		{
+			name:   "IPv4",
+			policy: &vpc.SecurityGroupPolicy{PolicyIndex: helper.Int64(0), Action: helper.String("accept"), Protocol: helper.String("TCP"), Port: helper.String("22"), CidrBlock: helper.String("10.0.0.0/8")},
+			peer:   "10.1.1.1",
+			want:   []string{"10.0.0.0/8"},
+			match:  true,
+		},
+		{
+			name:   "IPv6",
+			policy: &vpc.SecurityGroupPolicy{PolicyIndex: helper.Int64(1), Action: helper.String("DROP"), Protocol: helper.String("TCP"), Port: helper.String("22"), Ipv6CidrBlock: helper.String("::/0")},
+			peer:   "2001:db8::1",
+			want:   []string{"::/0"},
+			match:  true,
+		},
+		{
+			name:   "IPv6 does not match IPv4",
+			policy: &vpc.SecurityGroupPolicy{PolicyIndex: helper.Int64(2), Action: helper.String("DROP"), Protocol: helper.String("TCP"), Port: helper.String("22"), Ipv6CidrBlock: helper.String("::/0")},
+			peer:   "10.1.1.1",
+			want:   []string{"::/0"},
+			match:  false,
+		},
+		{
+			name:   "security group",
+			policy: &vpc.SecurityGroupPolicy{PolicyIndex: helper.Int64(3), Action: helper.String("ACCEPT"), Protocol: helper.String("ALL"), SecurityGroupId: helper.String("sg-peer")},
+			peer:   "10.1.1.1",
+			match:  true,
+		},
+	}
+	for _, c := range cases {
+		rule := vpcSecurityGroupPolicyRule("sg-test", VPC_NETWORK_DIRECTION_INGRESS, c.policy)
+		if rule.ResourceId != "sg-test" || rule.Index != *c.policy.PolicyIndex || rule.Action != strings.ToUpper(*c.policy.Action) {
+			t.Errorf("%s: unexpected rule %s", c.name, rule)
+		}
+		if !reflect.DeepEqual(rule.PeerAddresses, c.want) {
+			t.Errorf("%s: peer addresses %v, want %v", c.name, rule.PeerAddresses, c.want)
+		}
+		peer := &VpcNetworkEndpoint{Ip: c.peer, SecurityGroupIds: []string{"sg-peer"}}
+		if got := rule.Match(VpcNetworkFlow{"TCP", 22}, &VpcNetworkEndpoint{}, peer); got != c.match {
+			t.Errorf("%s: Match = %t, want %t", c.name, got, c.match)
+		}
+	}
+}
+
+func TestMatchVpcRoute(t *testing.T) {
+	routes := []*VpcRoute{
+		{RouteId: "r-default", DestinationCidr: "0.0.0.0/0", GatewayType: "NAT", Enabled: true},
+		{RouteId: "r-16", DestinationCidr: "172.16.0.0/16", GatewayType: "PEERCONNECTION", Enabled: true},
+		{RouteId: "r-24", DestinationCidr: "172.16.1.0/24", GatewayType: "CCN", Enabled: true},
+		{RouteId: "r-28-disabled", DestinationCidr: "172.16.1.0/28", GatewayType: "VPN", Enabled: false},
+		{RouteId: "r-invalid", DestinationCidr: "invalid", GatewayType: "VPN", Enabled: true},
+		{RouteId: "r-v6", DestinationCidr: "2001:db8::/32", GatewayType: "CCN", Enabled: true},
+	}
+	cases := []struct {
+		ip   string
+		want string
+	}{
+		{ip: "172.16.1.5", want: "r-24"},
+		{ip: "172.16.2.5", want: "r-16"},
+		{ip: "8.8.8.8", want: "r-default"},
+		{ip: "2001:db8::1", want: "r-v6"},
+		{ip: "2001:db9::1"},
+		{ip: "invalid"},
+	}
+	for _, c := range cases {
+		route := MatchVpcRoute(routes, c.ip)
+		got := ""
+		if route != nil {
+			got = route.RouteId
+		}
+		if got != c.want {
+			t.Errorf("MatchVpcRoute(%s) = %q, want %q", c.ip, got, c.want)
+		}
+	}
+	if route := MatchVpcRoute(routes[3:4], "172.16.1.1"); route != nil {
+		t.Errorf("disabled route %s is matched", route)
+	}
+}
+
+func TestEvaluateVpcReachability(t *testing.T) {
+	acceptAll := func(resourceId, direction string) []*VpcNetworkRule {
+		return []*VpcNetworkRule{{ResourceId: resourceId, Direction: direction, Action: VPC_NETWORK_RULE_ACCEPT, Protocol: "ALL", PeerAddresses: []string{"0.0.0.0/0"}}}
+	}
+	newInput := func() *VpcReachabilityInput {
+		return &VpcReachabilityInput{
+			Source:                   &VpcNetworkEndpoint{Id: "eni-src", Ip: "10.0.1.10", VpcId: "vpc-a", SecurityGroupIds: []string{"sg-src"}},
+			Destination:              &VpcNetworkEndpoint{Id: "eni-dst", Ip: "10.0.2.20", VpcId: "vpc-a", SecurityGroupIds: []string{"sg-dst"}},
+			Flow:                     VpcNetworkFlow{Protocol: "TCP", Port: 443},
+			SourceSecurityGroupRules: acceptAll("sg-src", VPC_NETWORK_DIRECTION_EGRESS),
+			SourceVpcCidrs:           []string{"10.0.0.0/16"},
+			SourceRouteTableId:       "rtb-a",
+			DestinationSecurityGroupRules: []*VpcNetworkRule{
+				{ResourceId: "sg-dst", Direction: VPC_NETWORK_DIRECTION_INGRESS, Index: 0, Action: VPC_NETWORK_RULE_DROP, Protocol: "TCP", Port: "22", PeerAddresses: []string{"0.0.0.0/0"}},
+				{ResourceId: "sg-dst", Direction: VPC_NETWORK_DIRECTION_INGRESS, Index: 1, Action: VPC_NETWORK_RULE_ACCEPT, Protocol: "TCP", Port: "443", PeerAddresses: []string{"10.0.0.0/16"}},
+			},
+		}
+	}
+	toVpcB := func(input *VpcReachabilityInput) {
+		input.Destination.Ip, input.Destination.VpcId = "172.16.1.5", "vpc-b"
+		input.DestinationSecurityGroupRules = acceptAll("sg-dst", VPC_NETWORK_DIRECTION_INGRESS)
+	}
+	toPublic := func(ip string) func(input *VpcReachabilityInput) {
+		return func(input *VpcReachabilityInput) {
+			input.Destination = &VpcNetworkEndpoint{Id: ip, Ip: ip}
+		}
+	}
+	activePeering := &vpc.PeerConnection{PeeringConnectionId: helper.String("pcx-1"), SourceVpcId: helper.String("vpc-b"),
+		PeerVpcId: helper.String("vpc-a"), State: helper.String(VPC_PEERING_CONNECTION_STATE_ACTIVE)}
+	gatewayRoute := func(gatewayType, gatewayId string, change func(input *VpcReachabilityInput)) func(input *VpcReachabilityInput) {
+		return func(input *VpcReachabilityInput) {
+			toVpcB(input)
+			input.SourceRoutes = []*VpcRoute{{RouteTableId: "rtb-a", RouteId: "r-1", DestinationCidr: "172.16.0.0/16", GatewayType: gatewayType, GatewayId: gatewayId, Enabled: true}}
+			change(input)
+		}
+	}
+	ccnRoutes := func(routes ...*VpcRoute) func(input *VpcReachabilityInput) {
+		return func(input *VpcReachabilityInput) {
+			toVpcB(input)
+			input.SourceRoutes = []*VpcRoute{{RouteTableId: "rtb-a", RouteId: "r-ccn", DestinationCidr: "172.16.0.0/16", GatewayType: VPC_ROUTE_GATEWAY_TYPE_CCN, GatewayId: "ccn-1", Enabled: true}}
+			input.CcnRoutes = map[string][]*VpcRoute{"ccn-1": routes}
+		}
+	}
+
+	cases := []struct {
+		name      string
+		change    func(input *VpcReachabilityInput)
+		reachable bool
+		hops      []string
+		// the rule of the last hop contains it
+		rule string
+	}{
+		{
+			name:      "same vpc",
+			reachable: true,
+			hops:      []string{"SOURCE_SECURITY_GROUP sg-src ALLOW", "ROUTE vpc-a ALLOW", "DESTINATION_SECURITY_GROUP sg-dst ALLOW"},
+			rule:      "sg-dst ingress #1: ACCEPT TCP:443 from 10.0.0.0/16",
+		},
+		{
+			name: "security group first match",
+			change: func(input *VpcReachabilityInput) {
+				input.DestinationSecurityGroupRules = append([]*VpcNetworkRule{
+					{ResourceId: "sg-dst", Direction: VPC_NETWORK_DIRECTION_INGRESS, Index: 0, Action: VPC_NETWORK_RULE_DROP, Protocol: "ALL", PeerAddresses: []string{"10.0.1.0/24"}},
+				}, acceptAll("sg-dst", VPC_NETWORK_DIRECTION_INGRESS)...)
+			},
+			hops: []string{"SOURCE_SECURITY_GROUP sg-src ALLOW", "ROUTE vpc-a ALLOW", "DESTINATION_SECURITY_GROUP sg-dst DENY"},
+			rule: "sg-dst ingress #0: DROP ALL:ALL from 10.0.1.0/24",
+		},
+		{
+			name: "security group default deny",
+			change: func(input *VpcReachabilityInput) {
+				input.DestinationSecurityGroupRules = input.DestinationSecurityGroupRules[:1]
+			},
+			hops: []string{"SOURCE_SECURITY_GROUP sg-src ALLOW", "ROUTE vpc-a ALLOW", "DESTINATION_SECURITY_GROUP sg-dst DENY"},
+			rule: "no rule matches, dropped by default",
+		},
+		{
+			name: "source security group without rules",
+			change: func(input *VpcReachabilityInput) {
+				input.SourceSecurityGroupRules = nil
+			},
+			hops: []string{"SOURCE_SECURITY_GROUP sg-src DENY"},
+			rule: "dropped by default",
+		},
+		{
+			name: "peer security group",
+			change: func(input *VpcReachabilityInput) {
+				input.DestinationSecurityGroupRules = []*VpcNetworkRule{{ResourceId: "sg-dst", Direction: VPC_NETWORK_DIRECTION_INGRESS, Action: VPC_NETWORK_RULE_ACCEPT, Protocol: "ALL", PeerSecurityGroupId: "sg-src"}}
+			},
+			reachable: true,
+			hops:      []string{"SOURCE_SECURITY_GROUP sg-src ALLOW", "ROUTE vpc-a ALLOW", "DESTINATION_SECURITY_GROUP sg-dst ALLOW"},
+			rule:      "from sg-src",
+		},
+		{
+			name: "source network ACL first match",
+			change: func(input *VpcReachabilityInput) {
+				input.SourceNetworkAclId = "acl-src"
+				input.SourceNetworkAclRules = append([]*VpcNetworkRule{
+					{ResourceId: "acl-src", Direction: VPC_NETWORK_DIRECTION_EGRESS, Action: VPC_NETWORK_RULE_DROP, Protocol: "TCP", Port: "443", PeerAddresses: []string{"10.0.2.0/24"}},
+				}, acceptAll("acl-src", VPC_NETWORK_DIRECTION_EGRESS)...)
+			},
+			hops: []string{"SOURCE_SECURITY_GROUP sg-src ALLOW", "SOURCE_NETWORK_ACL acl-src DENY"},
+			rule: "acl-src egress #0: DROP TCP:443 to 10.0.2.0/24",
+		},
+		{
+			name: "network ACLs allow",
+			change: func(input *VpcReachabilityInput) {
+				input.SourceNetworkAclId, input.SourceNetworkAclRules = "acl-src", acceptAll("acl-src", VPC_NETWORK_DIRECTION_EGRESS)
+				input.DestinationNetworkAclId, input.DestinationNetworkAclRules = "acl-dst", acceptAll("acl-dst", VPC_NETWORK_DIRECTION_INGRESS)
+			},
+			reachable: true,
+			hops: []string{"SOURCE_SECURITY_GROUP sg-src ALLOW", "SOURCE_NETWORK_ACL acl-src ALLOW", "ROUTE vpc-a ALLOW",
+				"DESTINATION_NETWORK_ACL acl-dst ALLOW", "DESTINATION_SECURITY_GROUP sg-dst ALLOW"},
+		},
+		{
+			name: "destination network ACL default deny",
+			change: func(input *VpcReachabilityInput) {
+				input.DestinationNetworkAclId = "acl-dst"
+			},
+			hops: []string{"SOURCE_SECURITY_GROUP sg-src ALLOW", "ROUTE vpc-a ALLOW", "DESTINATION_NETWORK_ACL acl-dst DENY"},
+			rule: "dropped by default",
+		},
+		{
+			name: "longest prefix route",
+			change: func(input *VpcReachabilityInput) {
+				toVpcB(input)
+				input.SourceRoutes = []*VpcRoute{
+					{RouteTableId: "rtb-a", RouteId: "r-nat", DestinationCidr: "172.16.0.0/12", GatewayType: "NAT", GatewayId: "nat-1", Enabled: true},
+					{RouteTableId: "rtb-a", RouteId: "r-pcx", DestinationCidr: "172.16.1.0/24", GatewayType: "PEERCONNECTION", GatewayId: "pcx-1", Enabled: true},
+					{RouteTableId: "rtb-a", RouteId: "r-disabled", DestinationCidr: "172.16.1.0/28", GatewayType: "VPN", GatewayId: "vpngw-1", Enabled: false},
+				}
+				input.PeeringConnections = map[string]*vpc.PeerConnection{"pcx-1": activePeering}
+			},
+			reachable: true,
+			hops:      []string{"SOURCE_SECURITY_GROUP sg-src ALLOW", "ROUTE rtb-a ALLOW", "DESTINATION_SECURITY_GROUP sg-dst ALLOW"},
+			rule:      "",
+		},
+		{
+			name:   "no route to another vpc",
+			change: toVpcB,
+			hops:   []string{"SOURCE_SECURITY_GROUP sg-src ALLOW", "ROUTE rtb-a DENY"},
+			rule:   "no route matches 172.16.1.5",
+		},
+		{
+			name: "CCN next hop",
+			change: ccnRoutes(
+				// the route back to the source vpc is skipped
+				&VpcRoute{RouteTableId: "ccn-1", DestinationCidr: "172.16.1.0/24", GatewayType: "VPC", GatewayId: "vpc-a", Enabled: true},
+				&VpcRoute{RouteTableId: "ccn-1", DestinationCidr: "172.16.0.0/16", GatewayType: "VPC", GatewayId: "vpc-b", Enabled: true},
+			),
+			reachable: true,
+			hops:      []string{"SOURCE_SECURITY_GROUP sg-src ALLOW", "ROUTE rtb-a ALLOW", "DESTINATION_SECURITY_GROUP sg-dst ALLOW"},
+		},
+		{
+			name: "CCN goes to another vpc",
+			change: ccnRoutes(
+				&VpcRoute{RouteTableId: "ccn-1", DestinationCidr: "172.16.1.0/24", GatewayType: "VPC", GatewayId: "vpc-c", Enabled: true},
+				&VpcRoute{RouteTableId: "ccn-1", DestinationCidr: "172.16.0.0/16", GatewayType: "VPC", GatewayId: "vpc-b", Enabled: true},
+			),
+			hops: []string{"SOURCE_SECURITY_GROUP sg-src ALLOW", "ROUTE ccn-1 DENY"},
+			rule: "goes to vpc-c instead of vpc-b",
+		},
+		{
+			name: "CCN route disabled",
+			change: ccnRoutes(
+				&VpcRoute{RouteTableId: "ccn-1", DestinationCidr: "172.16.1.0/24", GatewayType: "VPC", GatewayId: "vpc-b", Enabled: false},
+			),
+			hops: []string{"SOURCE_SECURITY_GROUP sg-src ALLOW", "ROUTE ccn-1 DENY"},
+			rule: "no enabled route of ccn-1 matches 172.16.1.5",
+		},
+		{
+			name:   "peering connection not found",
+			change: gatewayRoute("PEERCONNECTION", "pcx-1", func(input *VpcReachabilityInput) {}),
+			hops:   []string{"SOURCE_SECURITY_GROUP sg-src ALLOW", "ROUTE pcx-1 DENY"},
+			rule:   "peering connection pcx-1 is not found",
+		},
+		{
+			name: "peering connection not active",
+			change: gatewayRoute("PEERCONNECTION", "pcx-1", func(input *VpcReachabilityInput) {
+				input.PeeringConnections = map[string]*vpc.PeerConnection{"pcx-1": {SourceVpcId: helper.String("vpc-a"), PeerVpcId: helper.String("vpc-b"), State: helper.String("PENDING")}}
+			}),
+			hops: []string{"SOURCE_SECURITY_GROUP sg-src ALLOW", "ROUTE pcx-1 DENY"},
+			rule: "peering connection pcx-1 is PENDING",
+		},
+		{
+			name: "peering connection goes to another vpc",
+			change: gatewayRoute("PEERCONNECTION", "pcx-1", func(input *VpcReachabilityInput) {
+				input.PeeringConnections = map[string]*vpc.PeerConnection{"pcx-1": {SourceVpcId: helper.String("vpc-a"), PeerVpcId: helper.String("vpc-c"),
+					State: helper.String(VPC_PEERING_CONNECTION_STATE_ACTIVE)}}
+			}),
+			hops: []string{"SOURCE_SECURITY_GROUP sg-src ALLOW", "ROUTE pcx-1 DENY"},
+			rule: "peering connection pcx-1 goes to vpc-c instead of vpc-b",
+		},
+		{
+			name: "peering connection to an address in the peer vpc",
+			change: gatewayRoute("PEERCONNECTION", "pcx-1", func(input *VpcReachabilityInput) {
+				input.Destination = &VpcNetworkEndpoint{Id: "172.16.1.5", Ip: "172.16.1.5"}
+				input.PeeringConnections = map[string]*vpc.PeerConnection{"pcx-1": activePeering}
+				input.PeerVpcCidrs = map[string][]string{"vpc-b": {"172.16.0.0/20"}}
+			}),
+			reachable: true,
+			hops:      []string{"SOURCE_SECURITY_GROUP sg-src ALLOW", "ROUTE rtb-a ALLOW"},
+			rule:      "peering connection pcx-1 goes to vpc-b",
+		},
+		{
+			name: "peering connection to an address out of the peer vpc",
+			change: gatewayRoute("PEERCONNECTION", "pcx-1", func(input *VpcReachabilityInput) {
+				input.Destination = &VpcNetworkEndpoint{Id: "172.16.99.5", Ip: "172.16.99.5"}
+				input.PeeringConnections = map[string]*vpc.PeerConnection{"pcx-1": activePeering}
+				input.PeerVpcCidrs = map[string][]string{"vpc-b": {"172.16.0.0/20"}}
+			}),
+			hops: []string{"SOURCE_SECURITY_GROUP sg-src ALLOW", "ROUTE pcx-1 DENY"},
+			rule: "172.16.99.5 is out of vpc-b",
+		},
+		{
+			name: "peering connection to a vpc of another region",
+			change: gatewayRoute("PEERCONNECTION", "pcx-1", func(input *VpcReachabilityInput) {
+				input.Destination = &VpcNetworkEndpoint{Id: "172.16.1.5", Ip: "172.16.1.5"}
+				input.PeeringConnections = map[string]*vpc.PeerConnection{"pcx-1": activePeering}
+			}),
+			hops: []string{"SOURCE_SECURITY_GROUP sg-src ALLOW", "ROUTE pcx-1 NOT_EVALUATED"},
+			rule: "the CIDRs of vpc-b on the other side are unknown",
+		},
+		{
+			name: "NAT gateway available",
+			change: gatewayRoute("NAT", "nat-1", func(input *VpcReachabilityInput) {
+				input.NatGateways = map[string]*vpc.NatGateway{"nat-1": {VpcId: helper.String("vpc-a"), State: helper.String(VPC_NAT_GATEWAY_STATE_AVAILABLE)}}
+			}),
+			reachable: true,
+			hops:      []string{"SOURCE_SECURITY_GROUP sg-src ALLOW", "ROUTE rtb-a ALLOW", "DESTINATION_SECURITY_GROUP sg-dst ALLOW"},
+		},
+		{
+			name:   "NAT gateway not found",
+			change: gatewayRoute("NAT", "nat-1", func(input *VpcReachabilityInput) {}),
+			hops:   []string{"SOURCE_SECURITY_GROUP sg-src ALLOW", "ROUTE nat-1 DENY"},
+			rule:   "NAT gateway nat-1 is not found",
+		},
+		{
+			name: "NAT gateway not available",
+			change: gatewayRoute("NAT", "nat-1", func(input *VpcReachabilityInput) {
+				input.NatGateways = map[string]*vpc.NatGateway{"nat-1": {VpcId: helper.String("vpc-a"), State: helper.String("FAILED")}}
+			}),
+			hops: []string{"SOURCE_SECURITY_GROUP sg-src ALLOW", "ROUTE nat-1 DENY"},
+			rule: "NAT gateway nat-1 is FAILED",
+		},
+		{
+			name:   "next hop not evaluated",
+			change: gatewayRoute("VPN", "vpngw-1", func(input *VpcReachabilityInput) {}),
+			hops:   []string{"SOURCE_SECURITY_GROUP sg-src ALLOW", "ROUTE vpngw-1 NOT_EVALUATED", "DESTINATION_SECURITY_GROUP sg-dst ALLOW"},
+		},
+		{
+			name: "destination in the vpc cidr",
+			change: func(input *VpcReachabilityInput) {
+				input.Destination = &VpcNetworkEndpoint{Id: "10.0.9.9", Ip: "10.0.9.9"}
+			},
+			reachable: true,
+			hops:      []string{"SOURCE_SECURITY_GROUP sg-src ALLOW", "ROUTE vpc-a ALLOW"},
+			rule:      "local route of vpc-a",
+		},
+		{
+			name:      "public address",
+			change:    toPublic("8.8.8.8"),
+			reachable: true,
+			hops:      []string{"SOURCE_SECURITY_GROUP sg-src ALLOW", "ROUTE rtb-a ALLOW"},
+			rule:      "goes to the public network",
+		},
+		{
+			name:   "private address out of VPC",
+			change: toPublic("192.168.0.1"),
+			hops:   []string{"SOURCE_SECURITY_GROUP sg-src ALLOW", "ROUTE rtb-a DENY"},
+			rule:   "no route matches 192.168.0.1",
+		},
+		{
+			name:   "shared address out of VPC",
+			change: toPublic("100.64.0.1"),
+			hops:   []string{"SOURCE_SECURITY_GROUP sg-src ALLOW", "ROUTE rtb-a DENY"},
+			rule:   "no route matches 100.64.0.1",
+		},
+		{
+			name: "source out of VPC",
+			change: func(input *VpcReachabilityInput) {
+				input.Source = &VpcNetworkEndpoint{Id: "lb-public", Ip: "1.1.1.1"}
+				input.DestinationSecurityGroupRules = acceptAll("sg-dst", VPC_NETWORK_DIRECTION_INGRESS)
+			},
+			reachable: true,
+			hops:      []string{"DESTINATION_SECURITY_GROUP sg-dst ALLOW"},
+		},
+	}
+	for _, c := range cases {
+		input := newInput()
+		if c.change != nil {
+			c.change(input)
+		}
+		result := EvaluateVpcReachability(input)
+
+		hops := make([]string, 0, len(result.Hops))
+		for _, hop := range result.Hops {
+			hops = append(hops, strings.Join([]string{hop.Hop, hop.ResourceId, hop.Action}, " "))
+		}
+		if !reflect.DeepEqual(hops, c.hops) {
+			t.Errorf("%s: hops %v, want %v", c.name, hops, c.hops)
+			continue
+		}
+		if result.Reachable != c.reachable {
+			t.Errorf("%s: reachable %t, want %t", c.name, result.Reachable, c.reachable)
+		}
+		last := result.Hops[len(result.Hops)-1]
+		if (last.Action == VPC_REACHABILITY_DENY) != (result.Blocking != nil) || (result.Blocking != nil && *result.Blocking != last) {
+			t.Errorf("%s: unexpected blocking hop %+v", c.name, result.Blocking)
+		}
+		if !strings.Contains(last.Rule, c.rule) {
+			t.Errorf("%s: rule %q, want containing %q", c.name, last.Rule, c.rule)
+		}
+	}
+}
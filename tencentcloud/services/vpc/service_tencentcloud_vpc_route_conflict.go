@@ -0,0 +1,292 @@
+package vpc
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	vpc "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/vpc/v20170312"
+
+	tccommon "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/common"
+	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/internal/helper"
+	svcccn "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/services/ccn"
+)
+
+const (
+	VPC_ROUTE_CONFLICT_EQUAL       = "EQUAL"
+	VPC_ROUTE_CONFLICT_OVERLAP     = "OVERLAP"
+	VPC_ROUTE_CONFLICT_INEFFECTIVE = "INEFFECTIVE"
+
+	VPC_ROUTE_TYPE_LOCAL = "LOCAL"
+)
+
+// VpcRouteConflict is a conflict of a route with another route. For an overlap, the route is the less specific one
+// which is partially overridden by the conflict route. For an ineffective route, the conflict route may be nil.
+type VpcRouteConflict struct {
+	Type          string
+	Route         *VpcRoute
+	ConflictRoute *VpcRoute
+	Message       string
+}
+
+// DetectVpcRouteConflicts finds the routes to the same destination and the routes partially overridden by a more
+// specific one, among the routes of a route table, a CCN or a VPN gateway. The local routes and the default routes
+// are not regarded as conflicts.
+func DetectVpcRouteConflicts(routes []*VpcRoute) (conflicts []VpcRouteConflict) {
+	for i, route := range routes {
+		for _, other := range routes[i+1:] {
+			if conflict := compareVpcRoutes(other, route); conflict != nil {
+				conflicts = append(conflicts, *conflict)
+			} else if conflict := compareVpcRoutes(route, other); conflict != nil {
+				conflicts = append(conflicts, *conflict)
+			}
+		}
+	}
+	return
+}
+
+// CheckVpcRouteConflicts checks a new route against the existing routes. A route to the same destination, even if it
+// is disabled, fails the creation or gets the new route disabled, and an existing more specific route overrides the
+// new route partially.
+func CheckVpcRouteConflicts(route *VpcRoute, existing []*VpcRoute) (conflicts []VpcRouteConflict) {
+	for _, other := range existing {
+		if other.RouteId != "" && other.RouteId == route.RouteId {
+			continue
+		}
+		if conflict := compareVpcRoutes(route, other); conflict != nil {
+			conflicts = append(conflicts, *conflict)
+		} else if !other.Enabled && other.RouteType != VPC_ROUTE_TYPE_LOCAL && route.Priority == other.Priority && equalVpcRouteDestination(route, other) {
+			conflicts = append(conflicts, VpcRouteConflict{
+				Type:          VPC_ROUTE_CONFLICT_EQUAL,
+				Route:         route,
+				ConflictRoute: other,
+				Message:       fmt.Sprintf("%s routes the same destination as the disabled %s", route, other),
+			})
+		}
+	}
+	return
+}
+
+// VpcRouteConflictsError adds the routes to the same destination as the route to the error of its creation. The
+// conflicts only fail the plan on request, because a route to the same destination may be deleted in the same apply.
+func VpcRouteConflictsError(err error, route *VpcRoute, existing []*VpcRoute) error {
+	var messages []string
+	for _, conflict := range CheckVpcRouteConflicts(route, existing) {
+		if conflict.Type == VPC_ROUTE_CONFLICT_EQUAL {
+			messages = append(messages, conflict.Message)
+		}
+	}
+	if len(messages) == 0 {
+		return err
+	}
+	return fmt.Errorf("%s, route conflict: %s", err.Error(), strings.Join(messages, "; "))
+}
+
+func equalVpcRouteDestination(route, other *VpcRoute) bool {
+	_, cidr, err1 := net.ParseCIDR(route.DestinationCidr)
+	_, otherCidr, err2 := net.ParseCIDR(other.DestinationCidr)
+	return err1 == nil && err2 == nil && cidr.String() == otherCidr.String()
+}
+
+// compareVpcRoutes returns the conflict of route with other, nil if they do not conflict.
+func compareVpcRoutes(route, other *VpcRoute) *VpcRouteConflict {
+	if route.RouteType == VPC_ROUTE_TYPE_LOCAL || other.RouteType == VPC_ROUTE_TYPE_LOCAL {
+		return nil
+	}
+	_, cidr, err1 := net.ParseCIDR(route.DestinationCidr)
+	_, otherCidr, err2 := net.ParseCIDR(other.DestinationCidr)
+	if err1 != nil || err2 != nil || len(cidr.IP) != len(otherCidr.IP) {
+		return nil
+	}
+	ones, _ := cidr.Mask.Size()
+	otherOnes, _ := otherCidr.Mask.Size()
+
+	if cidr.String() == otherCidr.String() {
+		if route.Priority != other.Priority {
+			return nil
+		}
+		switch {
+		case route.Enabled && other.Enabled:
+			return &VpcRouteConflict{
+				Type:          VPC_ROUTE_CONFLICT_EQUAL,
+				Route:         route,
+				ConflictRoute: other,
+				Message:       fmt.Sprintf("%s routes the same destination as %s", route, other),
+			}
+		case !route.Enabled && other.Enabled:
+			return &VpcRouteConflict{
+				Type:          VPC_ROUTE_CONFLICT_INEFFECTIVE,
+				Route:         route,
+				ConflictRoute: other,
+				Message:       fmt.Sprintf("%s is disabled and cannot be enabled while %s routes the same destination", route, other),
+			}
+		}
+		return nil
+	}
+
+	if ones > 0 && ones < otherOnes && other.Enabled && cidr.Contains(otherCidr.IP) {
+		return &VpcRouteConflict{
+			Type:          VPC_ROUTE_CONFLICT_OVERLAP,
+			Route:         route,
+			ConflictRoute: other,
+			Message:       fmt.Sprintf("%s is overridden by the more specific %s", route, other),
+		}
+	}
+	return nil
+}
+
+// DetectVpcCcnRouteConflicts finds the routes to CCN whose destinations are not routed by any enabled route of the CCN
+// to an instance other than the VPC, the traffic of those routes is dropped by the CCN.
+func DetectVpcCcnRouteConflicts(vpcId string, routes []*VpcRoute, ccnRoutes map[string][]*VpcRoute) (conflicts []VpcRouteConflict) {
+	for _, route := range routes {
+		if !route.Enabled || route.GatewayType != VPC_ROUTE_GATEWAY_TYPE_CCN {
+			continue
+		}
+		candidates, ok := ccnRoutes[route.GatewayId]
+		if !ok {
+			continue
+		}
+		_, cidr, err := net.ParseCIDR(route.DestinationCidr)
+		if err != nil {
+			continue
+		}
+		routed := false
+		for _, ccnRoute := range candidates {
+			if !ccnRoute.Enabled || ccnRoute.GatewayId == vpcId {
+				continue
+			}
+			if _, ccnCidr, err := net.ParseCIDR(ccnRoute.DestinationCidr); err == nil && len(ccnCidr.IP) == len(cidr.IP) {
+				ones, _ := cidr.Mask.Size()
+				ccnOnes, _ := ccnCidr.Mask.Size()
+				if routed = ccnCidr.Contains(cidr.IP) || (ccnOnes > ones && cidr.Contains(ccnCidr.IP)); routed {
+					break
+				}
+			}
+		}
+		if !routed {
+			conflicts = append(conflicts, VpcRouteConflict{
+				Type:    VPC_ROUTE_CONFLICT_INEFFECTIVE,
+				Route:   route,
+				Message: fmt.Sprintf("%s, but no enabled route of %s goes to %s", route, route.GatewayId, route.DestinationCidr),
+			})
+		}
+	}
+	return
+}
+
+// DescribeVpcRouteConflictsByVpcId loads the route tables of the VPC, the CCNs which the VPC is attached to or routes to, and
+// the VPN gateways of the VPC, and finds the conflicts of their routes.
+func (me *VpcService) DescribeVpcRouteConflictsByVpcId(ctx context.Context, vpcId string) (conflicts []VpcRouteConflict, errRet error) {
+	var tables []VpcRouteTableBasicInfo
+	errRet = resource.Retry(tccommon.ReadRetryTimeout, func() *resource.RetryError {
+		result, e := me.DescribeRouteTables(ctx, "", "", vpcId, nil, nil, "")
+		if e != nil {
+			return tccommon.RetryError(e)
+		}
+		tables = result
+		return nil
+	})
+	if errRet != nil {
+		return
+	}
+
+	var (
+		ccnIds    []string
+		vpcRoutes []*VpcRoute
+	)
+	for _, table := range tables {
+		var routes []*VpcRoute
+		for _, entry := range table.entryInfos {
+			routes = append(routes, &VpcRoute{
+				RouteTableId:    table.routeTableId,
+				RouteId:         entry.routeItemId,
+				RouteType:       entry.entryType,
+				DestinationCidr: entry.destinationCidr,
+				GatewayType:     entry.nextType,
+				GatewayId:       entry.nextBub,
+				Enabled:         entry.enabled,
+			})
+			if entry.nextType == VPC_ROUTE_GATEWAY_TYPE_CCN && !helper.StringsContain(ccnIds, entry.nextBub) {
+				ccnIds = append(ccnIds, entry.nextBub)
+			}
+		}
+		conflicts = append(conflicts, DetectVpcRouteConflicts(routes)...)
+		vpcRoutes = append(vpcRoutes, routes...)
+	}
+
+	var (
+		ccnService  = svcccn.NewVpcService(me.client)
+		attachments []vpc.CcnAttachedInstance
+	)
+	errRet = resource.Retry(tccommon.ReadRetryTimeout, func() *resource.RetryError {
+		result, e := ccnService.DescribeCcnAttachmentsByInstance(ctx, svcccn.CNN_INSTANCE_TYPE_VPC, vpcId, me.client.Region)
+		if e != nil {
+			return tccommon.RetryError(e)
+		}
+		attachments = result
+		return nil
+	})
+	if errRet != nil {
+		return
+	}
+	for _, attachment := range attachments {
+		if ccnId := helper.PString(attachment.CcnId); ccnId != "" && !helper.StringsContain(ccnIds, ccnId) {
+			ccnIds = append(ccnIds, ccnId)
+		}
+	}
+
+	ccnRoutes := make(map[string][]*VpcRoute)
+	for _, ccnId := range ccnIds {
+		routes, err := me.DescribeVpcCcnRoutes(ctx, ccnId)
+		if err != nil {
+			return nil, err
+		}
+		ccnRoutes[ccnId] = routes
+		conflicts = append(conflicts, DetectVpcRouteConflicts(routes)...)
+	}
+	conflicts = append(conflicts, DetectVpcCcnRouteConflicts(vpcId, vpcRoutes, ccnRoutes)...)
+
+	var gateways []*vpc.VpnGateway
+	errRet = resource.Retry(tccommon.ReadRetryTimeout, func() *resource.RetryError {
+		result, e := me.DescribeVpnGwByFilter(ctx, map[string]string{"vpc-id": vpcId})
+		if e != nil {
+			return tccommon.RetryError(e)
+		}
+		gateways = result
+		return nil
+	})
+	if errRet != nil {
+		return
+	}
+	for _, gateway := range gateways {
+		routes, err := me.DescribeVpcVpnGatewayRoutes(ctx, helper.PString(gateway.VpnGatewayId))
+		if err != nil {
+			return nil, err
+		}
+		conflicts = append(conflicts, DetectVpcRouteConflicts(routes)...)
+	}
+	return
+}
+
+// DescribeVpcVpnGatewayRoutes returns the routes of the VPN gateway.
+func (me *VpcService) DescribeVpcVpnGatewayRoutes(ctx context.Context, vpnGatewayId string) (routes []*VpcRoute, errRet error) {
+	errRet, result := me.DescribeVpnGatewayRoutes(ctx, vpnGatewayId, nil)
+	if errRet != nil {
+		return
+	}
+	for _, item := range result {
+		routes = append(routes, &VpcRoute{
+			RouteTableId:    vpnGatewayId,
+			RouteId:         helper.PString(item.RouteId),
+			RouteType:       strings.ToUpper(helper.PString(item.Type)),
+			DestinationCidr: helper.PString(item.DestinationCidrBlock),
+			GatewayType:     helper.PString(item.InstanceType),
+			GatewayId:       helper.PString(item.InstanceId),
+			Enabled:         helper.PString(item.Status) == "ENABLE",
+			Priority:        helper.PInt64(item.Priority),
+		})
+	}
+	return
+}
@@ -0,0 +1,242 @@
+package vpc
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func newVpcConflictRoute(id, cidr string, enabled bool) *VpcRoute {
+	return &VpcRoute{RouteTableId: "rtb-test", RouteId: id, DestinationCidr: cidr, GatewayType: "NAT", GatewayId: "nat-" + id, Enabled: enabled}
+}
+
+func describeVpcRouteConflicts(conflicts []VpcRouteConflict) (got []string) {
+	for _, conflict := range conflicts {
+		item := conflict.Type + " " + conflict.Route.RouteId
+		if conflict.ConflictRoute != nil {
+			item += " " + conflict.ConflictRoute.RouteId
+		}
+		got = append(got, item)
+	}
+	return
+}
+
+func TestDetectVpcRouteConflicts(t *testing.T) {
+	route := newVpcConflictRoute
+	withPriority := func(r *VpcRoute, priority int64) *VpcRoute {
+		r.Priority = priority
+		return r
+	}
+	local := route("local", "10.0.0.0/16", true)
+	local.RouteType = VPC_ROUTE_TYPE_LOCAL
+
+	cases := []struct {
+		name   string
+		routes []*VpcRoute
+		want   []string
+	}{
+		{
+			name:   "same destination",
+			routes: []*VpcRoute{route("a", "10.1.0.0/16", true), route("b", "10.1.0.0/16", true)},
+			want:   []string{"EQUAL b a"},
+		},
+		{
+			name:   "same destination in another form",
+			routes: []*VpcRoute{route("a", "10.1.0.0/16", true), route("b", "10.1.2.3/16", true)},
+			want:   []string{"EQUAL b a"},
+		},
+		{
+			name:   "same destination with other priorities",
+			routes: []*VpcRoute{withPriority(route("a", "10.1.0.0/16", true), 0), withPriority(route("b", "10.1.0.0/16", true), 10)},
+		},
+		{
+			name:   "disabled after enabled",
+			routes: []*VpcRoute{route("a", "10.1.0.0/16", true), route("b", "10.1.0.0/16", false)},
+			want:   []string{"INEFFECTIVE b a"},
+		},
+		{
+			name:   "disabled before enabled",
+			routes: []*VpcRoute{route("a", "10.1.0.0/16", false), route("b", "10.1.0.0/16", true)},
+			want:   []string{"INEFFECTIVE a b"},
+		},
+		{
+			name:   "both disabled",
+			routes: []*VpcRoute{route("a", "10.1.0.0/16", false), route("b", "10.1.0.0/16", false)},
+		},
+		{
+			name:   "more specific after",
+			routes: []*VpcRoute{route("a", "10.1.0.0/16", true), route("b", "10.1.1.0/24", true)},
+			want:   []string{"OVERLAP a b"},
+		},
+		{
+			name:   "more specific before",
+			routes: []*VpcRoute{route("b", "10.1.1.0/24", true), route("a", "10.1.0.0/16", true)},
+			want:   []string{"OVERLAP a b"},
+		},
+		{
+			name:   "disabled more specific",
+			routes: []*VpcRoute{route("a", "10.1.0.0/16", true), route("b", "10.1.1.0/24", false)},
+		},
+		{
+			name:   "disjoint",
+			routes: []*VpcRoute{route("a", "10.1.0.0/16", true), route("b", "10.2.1.0/24", true)},
+		},
+		{
+			name:   "default route",
+			routes: []*VpcRoute{route("a", "0.0.0.0/0", true), route("b", "10.1.1.0/24", true)},
+		},
+		{
+			name:   "local route",
+			routes: []*VpcRoute{local, route("a", "10.0.0.0/16", true), route("b", "10.0.1.0/24", true)},
+			want:   []string{"OVERLAP a b"},
+		},
+		{
+			name:   "IPv6",
+			routes: []*VpcRoute{route("a", "2001:db8::/32", true), route("b", "2001:db8:1::/48", true), route("c", "0.0.0.0/8", true)},
+			want:   []string{"OVERLAP a b"},
+		},
+		{
+			name:   "invalid destination",
+			routes: []*VpcRoute{route("a", "10.1.0.0/16", true), route("b", "invalid", true)},
+		},
+		{
+			name: "several conflicts",
+			routes: []*VpcRoute{route("a", "10.1.0.0/16", true), route("b", "10.1.1.0/24", true),
+				route("c", "10.1.0.0/16", true)},
+			want: []string{"OVERLAP a b", "EQUAL c a", "OVERLAP c b"},
+		},
+	}
+	for _, c := range cases {
+		if got := describeVpcRouteConflicts(DetectVpcRouteConflicts(c.routes)); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%s: conflicts %v, want %v", c.name, got, c.want)
+		}
+	}
+}
+
+func TestCheckVpcRouteConflicts(t *testing.T) {
+	route := newVpcConflictRoute
+	local := route("local", "10.0.0.0/16", false)
+	local.RouteType = VPC_ROUTE_TYPE_LOCAL
+	standby := route("standby", "10.1.0.0/16", true)
+	standby.Priority = 10
+
+	cases := []struct {
+		name     string
+		route    *VpcRoute
+		existing []*VpcRoute
+		want     []string
+	}{
+		{
+			name:     "same destination",
+			route:    route("", "10.1.0.0/16", true),
+			existing: []*VpcRoute{route("a", "10.1.0.0/16", true), route("b", "10.2.0.0/16", true)},
+			want:     []string{"EQUAL  a"},
+		},
+		{
+			name:     "same destination as a disabled route",
+			route:    route("", "10.1.0.0/16", true),
+			existing: []*VpcRoute{route("a", "10.1.0.0/16", false)},
+			want:     []string{"EQUAL  a"},
+		},
+		{
+			name:     "same destination as a disabled local route",
+			route:    route("", "10.0.0.0/16", true),
+			existing: []*VpcRoute{local},
+		},
+		{
+			name:     "same destination with another priority",
+			route:    route("", "10.1.0.0/16", true),
+			existing: []*VpcRoute{standby},
+		},
+		{
+			name:     "replaced route",
+			route:    route("a", "10.1.0.0/16", true),
+			existing: []*VpcRoute{route("a", "10.1.0.0/16", true)},
+		},
+		{
+			name:     "more specific existing route",
+			route:    route("", "10.1.0.0/16", true),
+			existing: []*VpcRoute{route("a", "10.1.1.0/24", true), route("b", "10.1.2.0/24", false)},
+			want:     []string{"OVERLAP  a"},
+		},
+		{
+			name:     "less specific existing route",
+			route:    route("", "10.1.1.0/24", true),
+			existing: []*VpcRoute{route("a", "10.1.0.0/16", true), route("b", "0.0.0.0/0", true)},
+		},
+	}
+	for _, c := range cases {
+		if got := describeVpcRouteConflicts(CheckVpcRouteConflicts(c.route, c.existing)); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%s: conflicts %v, want %v", c.name, got, c.want)
+		}
+	}
+}
+
+func TestVpcRouteConflictsError(t *testing.T) {
+	err := errors.New("[TencentCloudSDKError] Code=InvalidParameterValue.Duplicate")
+	route := newVpcConflictRoute("", "10.1.0.0/16", true)
+
+	got := VpcRouteConflictsError(err, route, []*VpcRoute{newVpcConflictRoute("a", "10.1.0.0/16", true)})
+	want := "[TencentCloudSDKError] Code=InvalidParameterValue.Duplicate, route conflict: " +
+		"rtb-test: 10.1.0.0/16 -> NAT nat- routes the same destination as rtb-test route a: 10.1.0.0/16 -> NAT nat-a"
+	if got.Error() != want {
+		t.Errorf("VpcRouteConflictsError = %q, want %q", got, want)
+	}
+
+	// a more specific route does not fail the creation
+	if got := VpcRouteConflictsError(err, route, []*VpcRoute{newVpcConflictRoute("a", "10.1.1.0/24", true)}); got != err {
+		t.Errorf("VpcRouteConflictsError of an overlap = %q, want %q", got, err)
+	}
+}
+
+func TestDetectVpcCcnRouteConflicts(t *testing.T) {
+	ccnRoute := func(cidr, vpcId string, enabled bool) *VpcRoute {
+		return &VpcRoute{RouteTableId: "ccn-1", DestinationCidr: cidr, GatewayType: "VPC", GatewayId: vpcId, Enabled: enabled}
+	}
+	routes := []*VpcRoute{
+		{RouteTableId: "rtb-a", RouteId: "r-ccn", DestinationCidr: "172.16.1.0/24", GatewayType: VPC_ROUTE_GATEWAY_TYPE_CCN, GatewayId: "ccn-1", Enabled: true},
+		{RouteTableId: "rtb-a", RouteId: "r-disabled", DestinationCidr: "172.17.0.0/16", GatewayType: VPC_ROUTE_GATEWAY_TYPE_CCN, GatewayId: "ccn-1", Enabled: false},
+		{RouteTableId: "rtb-a", RouteId: "r-nat", DestinationCidr: "172.18.0.0/16", GatewayType: "NAT", GatewayId: "nat-1", Enabled: true},
+		{RouteTableId: "rtb-a", RouteId: "r-unknown", DestinationCidr: "172.19.0.0/16", GatewayType: VPC_ROUTE_GATEWAY_TYPE_CCN, GatewayId: "ccn-2", Enabled: true},
+	}
+
+	cases := []struct {
+		name      string
+		ccnRoutes []*VpcRoute
+		want      []string
+	}{
+		{
+			name:      "routed by a less specific route",
+			ccnRoutes: []*VpcRoute{ccnRoute("172.16.0.0/16", "vpc-b", true)},
+		},
+		{
+			name:      "routed by a more specific route",
+			ccnRoutes: []*VpcRoute{ccnRoute("172.16.1.128/25", "vpc-b", true)},
+		},
+		{
+			name:      "routed back to the vpc",
+			ccnRoutes: []*VpcRoute{ccnRoute("172.16.1.0/24", "vpc-a", true)},
+			want:      []string{"INEFFECTIVE r-ccn"},
+		},
+		{
+			name:      "disabled route",
+			ccnRoutes: []*VpcRoute{ccnRoute("172.16.1.0/24", "vpc-b", false)},
+			want:      []string{"INEFFECTIVE r-ccn"},
+		},
+		{
+			name:      "other destination",
+			ccnRoutes: []*VpcRoute{ccnRoute("172.20.0.0/16", "vpc-b", true), ccnRoute("2001:db8::/32", "vpc-b", true)},
+			want:      []string{"INEFFECTIVE r-ccn"},
+		},
+		{
+			name: "no routes",
+			want: []string{"INEFFECTIVE r-ccn"},
+		},
+	}
+	for _, c := range cases {
+		conflicts := DetectVpcCcnRouteConflicts("vpc-a", routes, map[string][]*VpcRoute{"ccn-1": c.ccnRoutes})
+		if got := describeVpcRouteConflicts(conflicts); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%s: conflicts %v, want %v", c.name, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,248 @@
+package vpc
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	tccommon "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/common"
+)
+
+const (
+	SECURITY_GROUP_FINDING_SHADOWED   = "SHADOWED"
+	SECURITY_GROUP_FINDING_DUPLICATE  = "DUPLICATE"
+	SECURITY_GROUP_FINDING_WORLD_OPEN = "WORLD_OPEN"
+)
+
+// SecurityGroupRuleFinding is a problem of a rule, the related rule is the earlier rule shadowing or duplicating it.
+type SecurityGroupRuleFinding struct {
+	Type        string
+	Rule        *VpcNetworkRule
+	RelatedRule *VpcNetworkRule
+	Message     string
+}
+
+// SortSecurityGroupRules sorts the rules by policy index, which is the order the platform evaluates them in.
+func SortSecurityGroupRules(rules []*VpcNetworkRule) {
+	sort.SliceStable(rules, func(i, j int) bool {
+		return rules[i].Index < rules[j].Index
+	})
+}
+
+// LintSecurityGroupRules flags the rules shadowed or duplicated by an earlier rule and the ingress rules accepting
+// 0.0.0.0/0 or ::/0. The rules must be in evaluation order and in the same direction.
+func LintSecurityGroupRules(rules []*VpcNetworkRule) (findings []SecurityGroupRuleFinding) {
+	for i, rule := range rules {
+		for _, earlier := range rules[:i] {
+			if !coverVpcNetworkRule(earlier, rule) {
+				continue
+			}
+			if earlier.Action == rule.Action && coverVpcNetworkRule(rule, earlier) {
+				findings = append(findings, SecurityGroupRuleFinding{
+					Type:        SECURITY_GROUP_FINDING_DUPLICATE,
+					Rule:        rule,
+					RelatedRule: earlier,
+					Message:     fmt.Sprintf("%s duplicates #%d", rule, earlier.Index),
+				})
+			} else {
+				findings = append(findings, SecurityGroupRuleFinding{
+					Type:        SECURITY_GROUP_FINDING_SHADOWED,
+					Rule:        rule,
+					RelatedRule: earlier,
+					Message:     fmt.Sprintf("%s is shadowed by #%d %s and never takes effect", rule, earlier.Index, earlier.Action),
+				})
+			}
+			break
+		}
+
+		if rule.Direction == VPC_NETWORK_DIRECTION_INGRESS && rule.Action == VPC_NETWORK_RULE_ACCEPT && isWorldOpenVpcNetworkRule(rule) {
+			findings = append(findings, SecurityGroupRuleFinding{
+				Type:    SECURITY_GROUP_FINDING_WORLD_OPEN,
+				Rule:    rule,
+				Message: fmt.Sprintf("%s is open to the world", rule),
+			})
+		}
+	}
+	return
+}
+
+// SecurityGroupGuardrailViolations returns the messages of the findings violating the guardrails.
+func SecurityGroupGuardrailViolations(guardrails *tccommon.SecurityGroupGuardrails, findings []SecurityGroupRuleFinding) (violations []string) {
+	for _, finding := range findings {
+		switch finding.Type {
+		case SECURITY_GROUP_FINDING_SHADOWED:
+			if !guardrails.CheckShadowedRules {
+				continue
+			}
+		case SECURITY_GROUP_FINDING_DUPLICATE:
+			if !guardrails.CheckDuplicateRules {
+				continue
+			}
+		case SECURITY_GROUP_FINDING_WORLD_OPEN:
+			var ports []string
+			for _, port := range guardrails.WorldOpenPorts {
+				for _, protocol := range []string{"TCP", "UDP"} {
+					if finding.Rule.MatchFlow(VpcNetworkFlow{Protocol: protocol, Port: port}) {
+						ports = append(ports, fmt.Sprintf("%s:%d", strings.ToLower(protocol), port))
+					}
+				}
+			}
+			if len(ports) == 0 {
+				continue
+			}
+			violations = append(violations, fmt.Sprintf("%s, which opens %s", finding.Message, strings.Join(ports, ",")))
+			continue
+		}
+		violations = append(violations, finding.Message)
+	}
+	return
+}
+
+func isWorldOpenVpcNetworkRule(rule *VpcNetworkRule) bool {
+	for _, address := range rule.PeerAddresses {
+		if _, cidr, err := net.ParseCIDR(strings.TrimSpace(address)); err == nil {
+			if ones, _ := cidr.Mask.Size(); ones == 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// coverVpcNetworkRule reports whether every flow matching rule also matches cover. It is conservative,
+// a rule with a security group as the peer is only covered by the rules with the same security group.
+func coverVpcNetworkRule(cover, rule *VpcNetworkRule) bool {
+	if rule.PeerSecurityGroupId != "" || cover.PeerSecurityGroupId != "" {
+		if rule.PeerSecurityGroupId != cover.PeerSecurityGroupId {
+			return false
+		}
+	} else {
+		if len(rule.PeerAddresses) == 0 {
+			return false
+		}
+		for _, address := range rule.PeerAddresses {
+			if !coverVpcNetworkAddress(cover.PeerAddresses, address) {
+				return false
+			}
+		}
+	}
+
+	coverServices := vpcNetworkRuleServices(cover)
+	for _, service := range vpcNetworkRuleServices(rule) {
+		covered := false
+		for _, coverService := range coverServices {
+			if covered = coverService.cover(service); covered {
+				break
+			}
+		}
+		if !covered {
+			return false
+		}
+	}
+	return true
+}
+
+type vpcNetworkService struct {
+	protocol string
+	from, to int64
+	valid    bool
+}
+
+func (s vpcNetworkService) cover(service vpcNetworkService) bool {
+	if !s.valid || !service.valid {
+		return false
+	}
+	if s.protocol == "" {
+		return true
+	}
+	return s.protocol == service.protocol && s.from <= service.from && s.to >= service.to
+}
+
+// vpcNetworkRuleServices returns the protocol and port ranges of the rule, an empty protocol means all protocols.
+func vpcNetworkRuleServices(rule *VpcNetworkRule) (services []vpcNetworkService) {
+	items := rule.Services
+	if len(items) == 0 {
+		items = []string{rule.Protocol + ":" + rule.Port}
+	}
+	for _, item := range items {
+		protocol, ports, _ := strings.Cut(item, ":")
+		protocol = strings.ToUpper(strings.TrimSpace(protocol))
+		if protocol == "ALL" {
+			protocol = ""
+		}
+		ports = strings.TrimSpace(ports)
+		if protocol == "" || strings.HasPrefix(protocol, "ICMP") || ports == "" || strings.ToUpper(ports) == "ALL" {
+			services = append(services, vpcNetworkService{protocol: protocol, from: 0, to: 65535, valid: true})
+			continue
+		}
+		for _, port := range strings.Split(ports, ",") {
+			from, to, isRange := strings.Cut(strings.TrimSpace(port), "-")
+			if !isRange {
+				to = from
+			}
+			fromPort, e1 := strconv.ParseInt(from, 10, 64)
+			toPort, e2 := strconv.ParseInt(to, 10, 64)
+			services = append(services, vpcNetworkService{protocol: protocol, from: fromPort, to: toPort, valid: e1 == nil && e2 == nil})
+		}
+	}
+	return
+}
+
+// coverVpcNetworkAddress reports whether the address is in any of the addresses, an address is a cidr, an ip or an ip range.
+func coverVpcNetworkAddress(addresses []string, address string) bool {
+	from, to, ok := vpcNetworkAddressRange(address)
+	if !ok {
+		return false
+	}
+	for _, item := range addresses {
+		coverFrom, coverTo, ok := vpcNetworkAddressRange(item)
+		if ok && len(coverFrom) == len(from) && bytes.Compare(coverFrom, from) <= 0 && bytes.Compare(coverTo, to) >= 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// vpcNetworkAddressRange returns the first and the last ip of the address, in 4 bytes for IPv4 and 16 bytes for IPv6.
+func vpcNetworkAddressRange(address string) (from, to net.IP, ok bool) {
+	normalize := func(ip net.IP) net.IP {
+		if ip4 := ip.To4(); ip4 != nil {
+			return ip4
+		}
+		return ip
+	}
+	address = strings.TrimSpace(address)
+	if _, cidr, err := net.ParseCIDR(address); err == nil {
+		from = normalize(cidr.IP)
+		to = make(net.IP, len(from))
+		for i := range from {
+			to[i] = from[i] | ^cidr.Mask[len(cidr.Mask)-len(from)+i]
+		}
+		return from, to, true
+	}
+	if first, last, isRange := strings.Cut(address, "-"); isRange {
+		firstIp, lastIp := net.ParseIP(strings.TrimSpace(first)), net.ParseIP(strings.TrimSpace(last))
+		if firstIp == nil || lastIp == nil {
+			return nil, nil, false
+		}
+		from, to = normalize(firstIp), normalize(lastIp)
+		return from, to, len(from) == len(to)
+	}
+	if ip := net.ParseIP(address); ip != nil {
+		return normalize(ip), normalize(ip), true
+	}
+	return nil, nil, false
+}
+
+// DescribeSecurityGroupEffectiveRules returns the ingress and egress rules of the security group in evaluation order,
+// with the templates resolved.
+func (me *VpcService) DescribeSecurityGroupEffectiveRules(ctx context.Context, sgId string) (ingress, egress []*VpcNetworkRule, errRet error) {
+	ingress, egress, errRet = me.DescribeVpcSecurityGroupRules(ctx, sgId)
+	SortSecurityGroupRules(ingress)
+	SortSecurityGroupRules(egress)
+	return
+}
@@ -0,0 +1,251 @@
+package vpc
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	tccommon "github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/common"
+)
+
+func newSecurityGroupLintRule(index int64, action, protocol, port string, peers ...string) *VpcNetworkRule {
+	return &VpcNetworkRule{ResourceId: "sg-test", Direction: VPC_NETWORK_DIRECTION_INGRESS, Index: index,
+		Action: action, Protocol: protocol, Port: port, PeerAddresses: peers}
+}
+
+func TestLintSecurityGroupRules(t *testing.T) {
+	const (
+		accept = VPC_NETWORK_RULE_ACCEPT
+		drop   = VPC_NETWORK_RULE_DROP
+	)
+	rule := newSecurityGroupLintRule
+	cases := []struct {
+		name  string
+		rules []*VpcNetworkRule
+		want  []string
+	}{
+		{
+			name:  "shadowed by a wider drop",
+			rules: []*VpcNetworkRule{rule(0, drop, "TCP", "22", "10.0.0.0/8"), rule(1, accept, "TCP", "22", "10.1.0.0/16")},
+			want:  []string{"SHADOWED #1 by #0"},
+		},
+		{
+			name:  "redundant after a wider accept",
+			rules: []*VpcNetworkRule{rule(0, accept, "ALL", "ALL", "10.0.0.0/8"), rule(1, accept, "TCP", "80", "10.1.0.0/16")},
+			want:  []string{"SHADOWED #1 by #0"},
+		},
+		{
+			name:  "duplicate",
+			rules: []*VpcNetworkRule{rule(0, accept, "TCP", "443", "10.0.0.0/8"), rule(1, accept, "tcp", "443", " 10.0.0.0/8 ")},
+			want:  []string{"DUPLICATE #1 by #0"},
+		},
+		{
+			name:  "same match with another action",
+			rules: []*VpcNetworkRule{rule(0, accept, "TCP", "443", "10.0.0.0/8"), rule(1, drop, "TCP", "443", "10.0.0.0/8")},
+			want:  []string{"SHADOWED #1 by #0"},
+		},
+		{
+			name: "reported once by the first covering rule",
+			rules: []*VpcNetworkRule{rule(0, drop, "ALL", "", "10.0.0.0/8"), rule(1, drop, "ALL", "", "10.0.0.0/8"),
+				rule(2, accept, "TCP", "22", "10.0.0.0/24")},
+			want: []string{"DUPLICATE #1 by #0", "SHADOWED #2 by #0"},
+		},
+		{
+			name:  "narrower earlier rule",
+			rules: []*VpcNetworkRule{rule(0, accept, "TCP", "80", "10.1.0.0/16"), rule(1, drop, "ALL", "ALL", "10.0.0.0/8")},
+		},
+		{
+			name:  "partially overlapping addresses",
+			rules: []*VpcNetworkRule{rule(0, drop, "TCP", "22", "10.0.0.0/24"), rule(1, accept, "TCP", "22", "10.0.0.0/24", "10.0.1.0/24")},
+		},
+		{
+			name:  "ip range",
+			rules: []*VpcNetworkRule{rule(0, drop, "TCP", "22", "10.0.0.0-10.0.0.127"), rule(1, accept, "TCP", "22", "10.0.0.64/26", "10.0.0.1")},
+			want:  []string{"SHADOWED #1 by #0"},
+		},
+		{
+			name:  "world open",
+			rules: []*VpcNetworkRule{rule(0, accept, "TCP", "22", "0.0.0.0/0"), rule(1, drop, "TCP", "3389", "0.0.0.0/0")},
+			want:  []string{"WORLD_OPEN #0"},
+		},
+		{
+			name:  "world open and shadowed",
+			rules: []*VpcNetworkRule{rule(0, drop, "ALL", "ALL", "0.0.0.0/0"), rule(1, accept, "TCP", "22", "0.0.0.0/0")},
+			want:  []string{"SHADOWED #1 by #0", "WORLD_OPEN #1"},
+		},
+		{
+			name: "world open egress",
+			rules: []*VpcNetworkRule{{ResourceId: "sg-test", Direction: VPC_NETWORK_DIRECTION_EGRESS, Action: accept, Protocol: "ALL",
+				PeerAddresses: []string{"0.0.0.0/0"}}},
+		},
+		{
+			name:  "IPv6 world open",
+			rules: []*VpcNetworkRule{rule(0, accept, "TCP", "22", "::/0")},
+			want:  []string{"WORLD_OPEN #0"},
+		},
+		{
+			name:  "IPv6 shadowed",
+			rules: []*VpcNetworkRule{rule(0, drop, "ALL", "ALL", "2001:db8::/32"), rule(1, accept, "TCP", "22", "2001:db8:1::/48")},
+			want:  []string{"SHADOWED #1 by #0"},
+		},
+		{
+			name:  "IPv6 not covered by IPv4",
+			rules: []*VpcNetworkRule{rule(0, drop, "ALL", "ALL", "0.0.0.0/0"), rule(1, accept, "TCP", "22", "2001:db8::/32")},
+		},
+		{
+			name:  "IPv4 not covered by IPv6",
+			rules: []*VpcNetworkRule{rule(0, drop, "ALL", "ALL", "::/0"), rule(1, accept, "TCP", "22", "10.0.0.0/8")},
+		},
+		{
+			name:  "port range covers ports",
+			rules: []*VpcNetworkRule{rule(0, drop, "TCP", "1-1024", "10.0.0.0/8"), rule(1, accept, "TCP", "22,80, 443", "10.0.0.0/8")},
+			want:  []string{"SHADOWED #1 by #0"},
+		},
+		{
+			name:  "port range covers a range",
+			rules: []*VpcNetworkRule{rule(0, drop, "TCP", "1000-2000", "10.0.0.0/8"), rule(1, accept, "TCP", "1000-2000", "10.0.0.0/8")},
+			want:  []string{"SHADOWED #1 by #0"},
+		},
+		{
+			name:  "port range overlapping",
+			rules: []*VpcNetworkRule{rule(0, drop, "TCP", "1-100", "10.0.0.0/8"), rule(1, accept, "TCP", "80-443", "10.0.0.0/8")},
+		},
+		{
+			name:  "port list partially covered",
+			rules: []*VpcNetworkRule{rule(0, drop, "TCP", "22,80", "10.0.0.0/8"), rule(1, accept, "TCP", "80,443", "10.0.0.0/8")},
+		},
+		{
+			name:  "all ports",
+			rules: []*VpcNetworkRule{rule(0, drop, "TCP", "ALL", "10.0.0.0/8"), rule(1, accept, "TCP", "8080", "10.0.0.0/8")},
+			want:  []string{"SHADOWED #1 by #0"},
+		},
+		{
+			name:  "other protocol",
+			rules: []*VpcNetworkRule{rule(0, drop, "UDP", "53", "10.0.0.0/8"), rule(1, accept, "TCP", "53", "10.0.0.0/8")},
+		},
+		{
+			name:  "ICMP ignores the port",
+			rules: []*VpcNetworkRule{rule(0, drop, "ICMP", "", "10.0.0.0/8"), rule(1, accept, "ICMP", "8", "10.0.0.0/8")},
+			want:  []string{"SHADOWED #1 by #0"},
+		},
+		{
+			name:  "invalid port is never covered",
+			rules: []*VpcNetworkRule{rule(0, drop, "TCP", "ssh", "10.0.0.0/8"), rule(1, accept, "TCP", "ssh", "10.0.0.0/8")},
+		},
+		{
+			name: "service template",
+			rules: []*VpcNetworkRule{
+				{ResourceId: "sg-test", Direction: VPC_NETWORK_DIRECTION_INGRESS, Index: 0, Action: drop,
+					Services: []string{"tcp:1-1000", "udp:53"}, PeerAddresses: []string{"10.0.0.0/8"}},
+				rule(1, accept, "TCP", "22", "10.0.0.0/8"),
+				rule(2, accept, "UDP", "123", "10.0.0.0/8"),
+			},
+			want: []string{"SHADOWED #1 by #0"},
+		},
+		{
+			name: "peer security group",
+			rules: []*VpcNetworkRule{
+				{ResourceId: "sg-test", Direction: VPC_NETWORK_DIRECTION_INGRESS, Index: 0, Action: drop, Protocol: "ALL", PeerAddresses: []string{"0.0.0.0/0"}},
+				{ResourceId: "sg-test", Direction: VPC_NETWORK_DIRECTION_INGRESS, Index: 1, Action: accept, Protocol: "ALL", PeerSecurityGroupId: "sg-peer"},
+				{ResourceId: "sg-test", Direction: VPC_NETWORK_DIRECTION_INGRESS, Index: 2, Action: accept, Protocol: "TCP", Port: "22", PeerSecurityGroupId: "sg-peer"},
+				{ResourceId: "sg-test", Direction: VPC_NETWORK_DIRECTION_INGRESS, Index: 3, Action: accept, Protocol: "ALL", PeerSecurityGroupId: "sg-other"},
+			},
+			want: []string{"SHADOWED #2 by #1"},
+		},
+		{
+			name:  "rule without peer",
+			rules: []*VpcNetworkRule{rule(0, drop, "ALL", "ALL", "0.0.0.0/0"), rule(1, accept, "ALL", "ALL")},
+		},
+	}
+	for _, c := range cases {
+		var got []string
+		for _, finding := range LintSecurityGroupRules(c.rules) {
+			if finding.RelatedRule != nil {
+				got = append(got, fmt.Sprintf("%s #%d by #%d", finding.Type, finding.Rule.Index, finding.RelatedRule.Index))
+			} else {
+				got = append(got, fmt.Sprintf("%s #%d", finding.Type, finding.Rule.Index))
+			}
+		}
+		if !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%s: findings %v, want %v", c.name, got, c.want)
+		}
+	}
+}
+
+func TestSecurityGroupGuardrailViolations(t *testing.T) {
+	rules := []*VpcNetworkRule{
+		newSecurityGroupLintRule(0, VPC_NETWORK_RULE_ACCEPT, "TCP", "80,443", "0.0.0.0/0"),
+		newSecurityGroupLintRule(1, VPC_NETWORK_RULE_ACCEPT, "ALL", "ALL", "::/0"),
+		newSecurityGroupLintRule(2, VPC_NETWORK_RULE_ACCEPT, "TCP", "80", "0.0.0.0/0"),
+	}
+	findings := LintSecurityGroupRules(rules)
+
+	cases := []struct {
+		name       string
+		guardrails *tccommon.SecurityGroupGuardrails
+		want       []string
+	}{
+		{
+			name:       "nothing checked",
+			guardrails: &tccommon.SecurityGroupGuardrails{},
+		},
+		{
+			name:       "shadowed and duplicate rules",
+			guardrails: &tccommon.SecurityGroupGuardrails{CheckShadowedRules: true, CheckDuplicateRules: true},
+			want:       []string{"sg-test ingress #2: ACCEPT TCP:80 from 0.0.0.0/0 is shadowed by #0 ACCEPT and never takes effect"},
+		},
+		{
+			name:       "world open ports",
+			guardrails: &tccommon.SecurityGroupGuardrails{WorldOpenPorts: []int64{22, 443}},
+			want: []string{
+				"sg-test ingress #0: ACCEPT TCP:80,443 from 0.0.0.0/0 is open to the world, which opens tcp:443",
+				"sg-test ingress #1: ACCEPT ALL:ALL from ::/0 is open to the world, which opens tcp:22,udp:22,tcp:443,udp:443",
+			},
+		},
+	}
+	for _, c := range cases {
+		if got := SecurityGroupGuardrailViolations(c.guardrails, findings); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%s: violations %q, want %q", c.name, got, c.want)
+		}
+	}
+}
+
+func TestVpcNetworkAddressRange(t *testing.T) {
+	cases := []struct {
+		address  string
+		from, to string
+		ok       bool
+	}{
+		{address: "10.0.0.0/24", from: "10.0.0.0", to: "10.0.0.255", ok: true},
+		{address: "10.0.0.77/30", from: "10.0.0.76", to: "10.0.0.79", ok: true},
+		{address: "0.0.0.0/0", from: "0.0.0.0", to: "255.255.255.255", ok: true},
+		{address: " 10.0.0.5 ", from: "10.0.0.5", to: "10.0.0.5", ok: true},
+		{address: "10.0.0.1 - 10.0.0.9", from: "10.0.0.1", to: "10.0.0.9", ok: true},
+		{address: "2001:db8::/126", from: "2001:db8::", to: "2001:db8::3", ok: true},
+		{address: "::/0", from: "::", to: "ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff", ok: true},
+		{address: "2001:db8::1", from: "2001:db8::1", to: "2001:db8::1", ok: true},
+		{address: "2001:db8::1-2001:db8::ff", from: "2001:db8::1", to: "2001:db8::ff", ok: true},
+		{address: "10.0.0.1-2001:db8::1"},
+		{address: "10.0.0.1-"},
+		{address: "10.0.0.0/33"},
+		{address: "invalid"},
+		{address: ""},
+	}
+	for _, c := range cases {
+		from, to, ok := vpcNetworkAddressRange(c.address)
+		if ok != c.ok {
+			t.Errorf("vpcNetworkAddressRange(%q) ok = %t, want %t", c.address, ok, c.ok)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if from.String() != c.from || to.String() != c.to {
+			t.Errorf("vpcNetworkAddressRange(%q) = %s, %s, want %s, %s", c.address, from, to, c.from, c.to)
+		}
+		// IPv4 is in 4 bytes so it never compares with IPv6
+		if want := map[bool]int{true: 4, false: 16}[from.To4() != nil]; len(from) != want || len(to) != want {
+			t.Errorf("vpcNetworkAddressRange(%q) in %d, %d bytes, want %d", c.address, len(from), len(to), want)
+		}
+	}
+}
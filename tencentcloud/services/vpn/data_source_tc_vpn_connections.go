@@ -365,7 +365,7 @@ func dataSourceTencentCloudVpnConnectionsRead(d *schema.ResourceData, meta inter
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), connectionList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), connectionList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
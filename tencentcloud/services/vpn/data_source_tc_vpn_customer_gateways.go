@@ -181,7 +181,7 @@ func dataSourceTencentCloudVpnCustomerGatewaysRead(d *schema.ResourceData, meta
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), gatewayList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), gatewayList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
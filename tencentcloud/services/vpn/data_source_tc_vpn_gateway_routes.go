@@ -105,7 +105,7 @@ func dataSourceTencentCloudVpnGatewayRoutesRead(d *schema.ResourceData, meta int
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), routeList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), routeList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
@@ -262,7 +262,7 @@ func dataSourceTencentCloudVpnGatewaysRead(d *schema.ResourceData, meta interfac
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := tccommon.WriteToFile(output.(string), gatewayList); e != nil {
+		if e := tccommon.WriteToFile(output.(string), gatewayList, tccommon.GetResultOutputOption(d)); e != nil {
 			return e
 		}
 	}
@@ -16,6 +16,20 @@ import (
 )
 
 func ResourceTencentCloudVpnGatewayRoute() *schema.Resource {
+	routeSchema := VpnGatewayRoutePara()
+	routeSchema["fail_on_route_conflict"] = &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: "Whether to fail the plan when the route conflicts with the routes of the VPN gateway, default is `false`.",
+	}
+	routeSchema["route_conflicts"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Description: "Conflicts of the route with the routes of the VPN gateway found at plan time.",
+	}
+
 	return &schema.Resource{
 		Create: resourceTencentCloudVpnGatewayRouteCreate,
 		Read:   resourceTencentCloudVpnGatewayRouteRead,
@@ -24,8 +38,9 @@ func ResourceTencentCloudVpnGatewayRoute() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: vpnGatewayRouteCustomizeDiff,
 
-		Schema: VpnGatewayRoutePara(),
+		Schema: routeSchema,
 	}
 }
 
@@ -89,6 +104,47 @@ func VpnGatewayRoutePara() map[string]*schema.Schema {
 	}
 }
 
+// vpnGatewayRouteCustomizeDiff checks the new route against the routes of the VPN gateway at plan time. The conflicts
+// are shown in `route_conflicts` and only fail the plan with `fail_on_route_conflict`, since a route to the same
+// destination with the same priority may be deleted in the same apply.
+func vpnGatewayRouteCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() != "" && !d.HasChange("vpn_gateway_id") && !d.HasChange("destination_cidr_block") && !d.HasChange("priority") {
+		return nil
+	}
+	if !d.NewValueKnown("vpn_gateway_id") || !d.NewValueKnown("destination_cidr_block") || !d.NewValueKnown("priority") {
+		return d.SetNewComputed("route_conflicts")
+	}
+
+	logId := tccommon.GetLogId(tccommon.ContextNil)
+	ctx = context.WithValue(ctx, tccommon.LogIdKey, logId)
+	service := svcvpc.NewVpcService(meta.(tccommon.ProviderMeta).GetAPIV3Conn())
+
+	route := &svcvpc.VpcRoute{
+		RouteTableId:    d.Get("vpn_gateway_id").(string),
+		DestinationCidr: d.Get("destination_cidr_block").(string),
+		GatewayType:     d.Get("instance_type").(string),
+		GatewayId:       d.Get("instance_id").(string),
+		Enabled:         d.Get("status").(string) != "DISABLE",
+		Priority:        int64(d.Get("priority").(int)),
+	}
+	if d.Id() != "" {
+		// the route is replaced, it is deleted before the new one is created
+		oldRouteId, _ := d.GetChange("route_id")
+		route.RouteId = oldRouteId.(string)
+	}
+
+	existing, err := service.DescribeVpcVpnGatewayRoutes(ctx, route.RouteTableId)
+	if err != nil {
+		return err
+	}
+	var conflicts []string
+	for _, conflict := range svcvpc.CheckVpcRouteConflicts(route, existing) {
+		log.Printf("[WARN]%s route conflict: %s\n", logId, conflict.Message)
+		conflicts = append(conflicts, conflict.Message)
+	}
+	return tccommon.SetRouteConflicts(d, conflicts)
+}
+
 func resourceTencentCloudVpnGatewayRouteCreate(d *schema.ResourceData, meta interface{}) error {
 	defer tccommon.LogElapsed("resource.tencentcloud_vpn_gateway_route.create")()
 
@@ -112,7 +168,18 @@ func resourceTencentCloudVpnGatewayRouteCreate(d *schema.ResourceData, meta inte
 	err, routeList := vpcService.CreateVpnGatewayRoute(ctx, vpnGatewayId, []*vpc.VpnGatewayRoute{route})
 	if err != nil {
 		log.Printf("[CRITAL]%s create VPN gateway route failed, reason:%s\n", logId, err.Error())
-		return err
+		existing, e := vpcService.DescribeVpcVpnGatewayRoutes(ctx, vpnGatewayId)
+		if e != nil {
+			return err
+		}
+		return svcvpc.VpcRouteConflictsError(err, &svcvpc.VpcRoute{
+			RouteTableId:    vpnGatewayId,
+			DestinationCidr: *route.DestinationCidrBlock,
+			GatewayType:     *route.InstanceType,
+			GatewayId:       *route.InstanceId,
+			Enabled:         *route.Status != "DISABLE",
+			Priority:        priority,
+		}, existing)
 	}
 
 	if len(routeList) == 0 {